@@ -1,9 +1,17 @@
 package main
 
-import "github.com/reearth/reearth/server/internal/app"
+import (
+	"os"
+
+	"github.com/reearth/reearth/server/internal/app"
+)
 
 var version = ""
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		app.Export(debug, os.Args[2:])
+		return
+	}
 	app.Start(debug, version)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 	"github.com/reearth/reearthx/usecasex"
@@ -105,3 +106,11 @@ func intToInt64(i *int) *int64 {
 	}
 	return lo.ToPtr(int64(*i))
 }
+
+// FromCRS parses a CRS such as "EPSG:6677". It returns nil if s is nil or empty.
+func FromCRS(s *string) (*crs.CRS, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	return crs.Parse(*s)
+}
@@ -2,6 +2,7 @@ package gqlmodel
 
 import (
 	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearthx/util"
 )
 
 func ToAsset(a *asset.Asset) *Asset {
@@ -10,16 +11,51 @@ func ToAsset(a *asset.Asset) *Asset {
 	}
 
 	return &Asset{
-		ID:          IDFrom(a.ID()),
-		CreatedAt:   a.CreatedAt(),
-		TeamID:      IDFrom(a.Workspace()),
-		Name:        a.Name(),
-		Size:        a.Size(),
-		URL:         a.URL(),
-		ContentType: a.ContentType(),
+		ID:           IDFrom(a.ID()),
+		CreatedAt:    a.CreatedAt(),
+		TeamID:       IDFrom(a.Workspace()),
+		Name:         a.Name(),
+		Size:         a.Size(),
+		URL:          a.URL(),
+		ContentType:  a.ContentType(),
+		ThumbnailURL: util.ToPtrIfNotEmpty(a.Thumbnail()),
+		Metadata:     ToAssetMetadata(a.Metadata()),
 	}
 }
 
+func ToAssetMetadata(m *asset.Metadata) *AssetMetadata {
+	if m == nil {
+		return nil
+	}
+
+	res := &AssetMetadata{
+		Width:    util.ToPtrIfNotEmpty(m.Width),
+		Height:   util.ToPtrIfNotEmpty(m.Height),
+		Epsg:     util.ToPtrIfNotEmpty(m.EPSG),
+		Duration: util.ToPtrIfNotEmpty(m.Duration),
+	}
+	if m.Bounds != nil {
+		res.Bounds = &Rect{
+			West:  m.Bounds.West,
+			South: m.Bounds.South,
+			East:  m.Bounds.East,
+			North: m.Bounds.North,
+		}
+	}
+	if m.Model != nil {
+		res.Model = &AssetModelStats{
+			NodeCount:      m.Model.Nodes,
+			MeshCount:      m.Model.Meshes,
+			PrimitiveCount: m.Model.Primitives,
+			VertexCount:    m.Model.Vertices,
+			MaterialCount:  m.Model.Materials,
+			TextureCount:   m.Model.Textures,
+			AnimationCount: m.Model.Animations,
+		}
+	}
+	return res
+}
+
 func AssetSortTypeFrom(ast *AssetSortType) *asset.SortType {
 	if ast == nil {
 		return nil
@@ -0,0 +1,27 @@
+package gqlmodel
+
+import (
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearthx/util"
+)
+
+func ToAuditLog(l *auditlog.Log) *AuditLog {
+	if l == nil {
+		return nil
+	}
+
+	return &AuditLog{
+		ID:        IDFrom(l.ID()),
+		CreatedAt: l.CreatedAt(),
+		ActorID:   IDFromRef(l.Actor()),
+		Action:    string(l.Action()),
+		TeamID:    IDFrom(l.Workspace()),
+		SceneID:   IDFromRef(l.Scene()),
+		Targets: util.Map(l.Targets(), func(t auditlog.Target) *AuditLogTarget {
+			return &AuditLogTarget{Type: t.Type, ID: ID(t.ID)}
+		}),
+		Changes: util.Map(l.Changes(), func(c auditlog.Change) *AuditLogChange {
+			return &AuditLogChange{Field: c.Field, Before: c.Before, After: c.After}
+		}),
+	}
+}
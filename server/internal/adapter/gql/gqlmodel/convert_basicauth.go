@@ -0,0 +1,25 @@
+package gqlmodel
+
+import (
+	"github.com/reearth/reearth/server/pkg/basicauth"
+	"github.com/samber/lo"
+)
+
+func ToBasicAuthCredential(c *basicauth.Credential) *BasicAuthCredential {
+	if c == nil {
+		return nil
+	}
+
+	return &BasicAuthCredential{
+		Name:      c.Name(),
+		Username:  c.Username(),
+		CreatedAt: c.CreatedAt(),
+		RevokedAt: c.RevokedAt(),
+	}
+}
+
+func ToBasicAuthCredentials(l basicauth.List) []*BasicAuthCredential {
+	return lo.Map(l, func(c *basicauth.Credential, _ int) *BasicAuthCredential {
+		return ToBasicAuthCredential(c)
+	})
+}
@@ -1,9 +1,12 @@
 package gqlmodel
 
 import (
+	"time"
+
 	"github.com/reearth/reearth/server/pkg/dataset"
 	"github.com/reearth/reearth/server/pkg/value"
 	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
 )
 
 func ToDatasetValue(v *dataset.Value) *interface{} {
@@ -61,5 +64,87 @@ func ToDatasetSchema(ds *dataset.Schema) *DatasetSchema {
 				RefID:    IDFromRef(f.Ref()),
 			}
 		}),
+		Refresh: ToDatasetSchemaRefresh(ds.Refresh()),
+	}
+}
+
+func ToDatasetSchemaRefresh(r *dataset.Refresh) *DatasetSchemaRefresh {
+	if r == nil {
+		return nil
+	}
+
+	return &DatasetSchemaRefresh{
+		Interval:     int(r.Interval() / time.Minute),
+		Publish:      r.Publish(),
+		NextAt:       *r.NextAt(),
+		LastSyncedAt: r.LastSyncedAt(),
+	}
+}
+
+func ToDatasetSyncLog(l *dataset.SyncLog) *DatasetSyncLog {
+	if l == nil {
+		return nil
+	}
+
+	return &DatasetSyncLog{
+		ID:              IDFrom(l.ID()),
+		DatasetSchemaID: IDFromRef(l.Schema()),
+		Source:          l.Source(),
+		Auto:            l.Auto(),
+		Added:           l.Added(),
+		Removed:         l.Removed(),
+		Changed:         l.Changed(),
+		Error:           lo.EmptyableToPtr(l.Error()),
+		SyncedAt:        l.SyncedAt(),
+	}
+}
+
+func ToPreviewDatasetPayload(p *dataset.CSVPreview) *PreviewDatasetPayload {
+	if p == nil {
+		return nil
+	}
+
+	fields := p.Schema.Fields()
+	return &PreviewDatasetPayload{
+		Fields: util.Map(fields, func(f *dataset.SchemaField) *DatasetPreviewField {
+			return &DatasetPreviewField{
+				Name:   f.Name(),
+				Source: f.Source(),
+				Type:   ToValueType(value.Type(f.Type())),
+			}
+		}),
+		Rows: util.Map(p.Datasets, func(ds *dataset.Dataset) JSON {
+			row := JSON{}
+			for _, sf := range fields {
+				if f := ds.Field(sf.ID()); f != nil {
+					row[sf.Name()] = valueInterfaceToGqlValue(f.Value().Value())
+				}
+			}
+			return row
+		}),
+		TotalCount: p.Total,
+		Errors: util.Map(p.Errors, func(e *dataset.CSVRowError) *DatasetPreviewRowError {
+			return &DatasetPreviewRowError{
+				Row:     e.Row,
+				Column:  lo.EmptyableToPtr(e.Column),
+				Message: e.Err.Error(),
+			}
+		}),
+	}
+}
+
+func FromCSVOptions(input ImportDatasetInput) dataset.CSVOptions {
+	o := dataset.CSVOptions{
+		LatColumn:      lo.FromPtr(input.LatColumn),
+		LngColumn:      lo.FromPtr(input.LngColumn),
+		HeightColumn:   lo.FromPtr(input.HeightColumn),
+		GeometryColumn: lo.FromPtr(input.GeometryColumn),
+	}
+	if len(input.Types) > 0 {
+		o.Types = make(map[string]dataset.ValueType, len(input.Types))
+		for _, t := range input.Types {
+			o.Types[t.Column] = dataset.ValueType(FromValueType(t.Type))
+		}
 	}
+	return o
 }
@@ -0,0 +1,23 @@
+package gqlmodel
+
+import (
+	"github.com/reearth/reearth/server/pkg/history"
+	"github.com/reearth/reearthx/util"
+)
+
+func ToHistoryEntry(e *history.Entry) *HistoryEntry {
+	if e == nil {
+		return nil
+	}
+
+	return &HistoryEntry{
+		ID:      IDFrom(e.ID()),
+		SceneID: IDFrom(e.Scene()),
+		Action:  string(e.Action()),
+		Targets: util.Map(e.Changes(), func(c history.Change) *HistoryTarget {
+			return &HistoryTarget{Type: string(c.Kind()), ID: ID(c.ID())}
+		}),
+		CreatedAt: e.CreatedAt(),
+		UndoneAt:  e.UndoneAt(),
+	}
+}
@@ -22,6 +22,7 @@ func ToNLSLayerSimple(l *nlslayer.NLSLayerSimple) *NLSLayerSimple {
 		Config:    JSON(*l.Config()),
 		IsSketch:  l.IsSketch(),
 		Sketch:    ToNLSLayerSketchInfo(l.Sketch()),
+		Version:   l.Version(),
 	}
 }
 
@@ -58,6 +59,7 @@ func ToNLSLayerGroup(l *nlslayer.NLSLayerGroup, parent *id.NLSLayerID) *NLSLayer
 		Config:      JSON(*l.Config()),
 		Infobox:     ToNLSInfobox(l.Infobox(), l.ID(), l.Scene()),
 		ChildrenIds: util.Map(l.Children().Layers(), IDFrom[id.NLSLayer]),
+		Version:     l.Version(),
 	}
 }
 
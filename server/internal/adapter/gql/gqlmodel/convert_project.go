@@ -46,7 +46,6 @@ func ToProject(p *project.Project) *Project {
 		IsArchived:        p.IsArchived(),
 		IsBasicAuthActive: p.IsBasicAuthActive(),
 		BasicAuthUsername: p.BasicAuthUsername(),
-		BasicAuthPassword: p.BasicAuthPassword(),
 		Alias:             p.Alias(),
 		Name:              p.Name(),
 		Description:       p.Description(),
@@ -63,5 +62,21 @@ func ToProject(p *project.Project) *Project {
 		CoreSupport:       p.CoreSupport(),
 		EnableGa:          p.EnableGA(),
 		TrackingID:        p.TrackingID(),
+		PublishSchedule:   ToPublishSchedule(p.PublishSchedule()),
+		IsTemplate:        p.IsTemplate(),
+
+		BasicAuthCredentials: ToBasicAuthCredentials(p.BasicAuthCredentials()),
+	}
+}
+
+func ToPublishSchedule(s *project.PublishSchedule) *PublishSchedule {
+	if s == nil {
+		return nil
+	}
+
+	return &PublishSchedule{
+		PublishmentStatus: ToPublishmentStatus(s.Status()),
+		PublishAt:         s.PublishAt(),
+		UnpublishAt:       s.UnpublishAt(),
 	}
 }
@@ -0,0 +1,44 @@
+package gqlmodel
+
+import (
+	"github.com/reearth/reearth/server/pkg/projectgrant"
+)
+
+func ToProjectGrant(g *projectgrant.Grant) *ProjectGrant {
+	if g == nil {
+		return nil
+	}
+
+	return &ProjectGrant{
+		ID:          IDFrom(g.ID()),
+		ProjectID:   IDFrom(g.Project()),
+		UserID:      IDFrom(g.User()),
+		Role:        ToProjectGrantRole(g.Role()),
+		GrantedByID: IDFromRef(g.GrantedBy()),
+		CreatedAt:   g.CreatedAt(),
+	}
+}
+
+func ToProjectGrantRole(r projectgrant.Role) ProjectGrantRole {
+	switch r {
+	case projectgrant.RoleViewer:
+		return ProjectGrantRoleViewer
+	case projectgrant.RoleCommenter:
+		return ProjectGrantRoleCommenter
+	case projectgrant.RoleEditor:
+		return ProjectGrantRoleEditor
+	}
+	return ProjectGrantRole("")
+}
+
+func FromProjectGrantRole(r ProjectGrantRole) projectgrant.Role {
+	switch r {
+	case ProjectGrantRoleViewer:
+		return projectgrant.RoleViewer
+	case ProjectGrantRoleCommenter:
+		return projectgrant.RoleCommenter
+	case ProjectGrantRoleEditor:
+		return projectgrant.RoleEditor
+	}
+	return projectgrant.Role("")
+}
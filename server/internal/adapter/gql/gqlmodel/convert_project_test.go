@@ -96,6 +96,8 @@ func TestToProject(t *testing.T) {
 				CoreSupport:       false,
 				EnableGa:          false,
 				TrackingID:        "",
+
+				BasicAuthCredentials: []*BasicAuthCredential{},
 			},
 		},
 	}
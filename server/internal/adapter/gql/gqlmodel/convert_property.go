@@ -224,6 +224,7 @@ func ToProperty(property *property.Property) *Property {
 		ID:       IDFrom(property.ID()),
 		SchemaID: IDFromPropertySchemaID(property.Schema()),
 		Items:    items,
+		Version:  property.Version(),
 	}
 }
 
@@ -0,0 +1,27 @@
+package gqlmodel
+
+import (
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/revision"
+	"github.com/reearth/reearthx/util"
+)
+
+func ToPublishedRevision(r *revision.Revision) *PublishedRevision {
+	if r == nil {
+		return nil
+	}
+
+	return &PublishedRevision{
+		ID:                IDFrom(r.ID()),
+		Number:            r.Number(),
+		Alias:             r.Alias(),
+		PublishmentStatus: ToPublishmentStatus(project.PublishmentStatus(r.Status())),
+		SchemaVersion:     r.SchemaVersion(),
+		PublishedAt:       r.PublishedAt(),
+		PublishedByID:     IDFromRef(r.PublishedBy()),
+	}
+}
+
+func ToPublishedRevisions(l revision.List) []*PublishedRevision {
+	return util.Map(l, ToPublishedRevision)
+}
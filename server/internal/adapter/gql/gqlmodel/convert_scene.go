@@ -1,6 +1,7 @@
 package gqlmodel
 
 import (
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/util"
 )
@@ -56,14 +57,16 @@ func ToScene(scene *scene.Scene) *Scene {
 		Clusters:          util.Map(scene.Clusters().Clusters(), ToCluster),
 		Widgets:           util.Map(scene.Widgets().Widgets(), ToSceneWidget),
 		WidgetAlignSystem: ToWidgetAlignSystem(scene.Widgets().Alignment()),
+		Version:           scene.Version(),
 	}
 }
 
 func ToStyle(v *scene.Style) *Style {
 	return &Style{
-		ID:    IDFrom(v.ID()),
-		Name:  v.Name(),
-		Value: JSON(*v.Value()),
+		ID:      IDFrom(v.ID()),
+		Name:    v.Name(),
+		Value:   JSON(*v.Value()),
+		Version: v.Version(),
 	}
 }
 
@@ -85,3 +88,19 @@ func ToStyles(styles scene.StyleList) []*Style {
 		return ToStyle(s)
 	})
 }
+
+func ToSceneUsage(u *interfaces.SceneUsage) *SceneUsage {
+	if u == nil {
+		return nil
+	}
+	return &SceneUsage{
+		SceneID:             IDFrom(u.SceneID),
+		Policy:              ToPolicy(u.Policy),
+		NlsLayerCount:       u.NLSLayerCount,
+		SketchFeatureCount:  u.SketchFeatureCount,
+		StoryCount:          u.StoryCount,
+		StoryPageCount:      u.StoryPageCount,
+		PublishedStoryCount: u.PublishedStoryCount,
+		PluginCount:         u.PluginCount,
+	}
+}
@@ -0,0 +1,19 @@
+package gqlmodel
+
+import (
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearthx/util"
+)
+
+func ToSceneEvent(e gateway.SceneEvent) *SceneEvent {
+	return &SceneEvent{
+		SceneID: IDFrom(e.Scene),
+		Action:  string(e.Action),
+		Targets: util.Map(e.Targets, func(t auditlog.Target) *AuditLogTarget {
+			return &AuditLogTarget{Type: t.Type, ID: ID(t.ID)}
+		}),
+		ActorID:   IDFromRef(e.Actor),
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -22,14 +22,29 @@ func ToStory(s *storytelling.Story) *Story {
 		PublishedAt:       s.PublishedAt(),
 		PanelPosition:     ToStoryPosition(s.PanelPosition()),
 		BgColor:           ToStoryBgColor(s.BgColor()),
+		Version:           s.Version(),
 
 		IsBasicAuthActive: s.IsBasicAuthActive(),
 		BasicAuthUsername: s.BasicAuthUsername(),
-		BasicAuthPassword: s.BasicAuthPassword(),
 		PublicTitle:       s.PublicTitle(),
 		PublicDescription: s.PublicDescription(),
 		PublicImage:       s.PublicImage(),
 		PublicNoIndex:     s.PublicNoIndex(),
+		PublishSchedule:   ToStoryPublishSchedule(s.PublishSchedule()),
+
+		BasicAuthCredentials: ToBasicAuthCredentials(s.BasicAuthCredentials()),
+	}
+}
+
+func ToStoryPublishSchedule(s *storytelling.PublishSchedule) *PublishSchedule {
+	if s == nil {
+		return nil
+	}
+
+	return &PublishSchedule{
+		PublishmentStatus: ToStoryPublishmentStatus(s.Status()),
+		PublishAt:         s.PublishAt(),
+		UnpublishAt:       s.UnpublishAt(),
 	}
 }
 
@@ -1,8 +1,10 @@
 package gqlmodel
 
 import (
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/policy"
 	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/util"
 )
 
 func ToWorkspace(t *workspace.Workspace) *Team {
@@ -72,5 +74,35 @@ func ToPolicy(p *policy.Policy) *Policy {
 		AssetStorageSize:      o.AssetStorageSize,
 		DatasetSchemaCount:    o.DatasetSchemaCount,
 		DatasetCount:          o.DatasetCount,
+		NlsLayerCount:         o.NLSLayerCount,
+		SketchFeatureCount:    o.SketchFeatureCount,
+		StoryCount:            o.StoryCount,
+		StoryPageCount:        o.StoryPageCount,
+		PublishedStoryCount:   o.PublishedStoryCount,
+		PluginCount:           o.PluginCount,
+		MaxUploadSize:         o.MaxUploadSize,
 	}
 }
+
+func ToWorkspaceUsage(u *interfaces.WorkspaceUsage) *WorkspaceUsage {
+	if u == nil {
+		return nil
+	}
+
+	return &WorkspaceUsage{
+		TeamID: IDFrom(u.WorkspaceID),
+		Policy: ToPolicy(u.Policy),
+		Items:  ToWorkspaceUsageItems(u.Items),
+	}
+}
+
+func ToWorkspaceUsageItems(items []policy.UsageItem) []*WorkspaceUsageItem {
+	return util.Map(items, func(i policy.UsageItem) *WorkspaceUsageItem {
+		return &WorkspaceUsageItem{
+			Name:       i.Name,
+			Used:       i.Used,
+			Limit:      i.Limit,
+			Percentage: i.Percentage(),
+		}
+	})
+}
@@ -36,6 +36,13 @@ func TestToPolicy(t *testing.T) {
 		AssetStorageSize:      lo.ToPtr(int64(5)),
 		DatasetCount:          lo.ToPtr(6),
 		DatasetSchemaCount:    lo.ToPtr(7),
+		NlsLayerCount:         lo.ToPtr(8),
+		SketchFeatureCount:    lo.ToPtr(9),
+		StoryCount:            lo.ToPtr(10),
+		StoryPageCount:        lo.ToPtr(11),
+		PublishedStoryCount:   lo.ToPtr(12),
+		PluginCount:           lo.ToPtr(13),
+		MaxUploadSize:         lo.ToPtr(int64(14)),
 	}, ToPolicy(policy.New(policy.Option{
 		ID:                    policy.ID("x"),
 		Name:                  "aaa",
@@ -46,6 +53,13 @@ func TestToPolicy(t *testing.T) {
 		AssetStorageSize:      lo.ToPtr(int64(5)),
 		DatasetCount:          lo.ToPtr(6),
 		DatasetSchemaCount:    lo.ToPtr(7),
+		NLSLayerCount:         lo.ToPtr(8),
+		SketchFeatureCount:    lo.ToPtr(9),
+		StoryCount:            lo.ToPtr(10),
+		StoryPageCount:        lo.ToPtr(11),
+		PublishedStoryCount:   lo.ToPtr(12),
+		PluginCount:           lo.ToPtr(13),
+		MaxUploadSize:         lo.ToPtr(int64(14)),
 	})))
 	assert.Nil(t, ToPolicy(nil))
 }
@@ -54,6 +54,7 @@ type NLSLayer interface {
 	GetInfobox() *NLSInfobox
 	GetIsSketch() bool
 	GetSketch() *SketchInfo
+	GetVersion() int
 }
 
 type Node interface {
@@ -185,6 +186,13 @@ type AddNLSLayerSimplePayload struct {
 	Layers *NLSLayerSimple `json:"layers"`
 }
 
+type AddProjectBasicAuthCredentialInput struct {
+	ProjectID ID     `json:"projectId"`
+	Name      string `json:"name"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+}
+
 type AddPropertyItemInput struct {
 	PropertyID     ID          `json:"propertyId"`
 	SchemaGroupID  ID          `json:"schemaGroupId"`
@@ -193,6 +201,13 @@ type AddPropertyItemInput struct {
 	NameFieldType  *ValueType  `json:"nameFieldType,omitempty"`
 }
 
+type AddStoryBasicAuthCredentialInput struct {
+	StoryID  ID     `json:"storyId"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
 type AddStyleInput struct {
 	SceneID ID     `json:"sceneId"`
 	Name    string `json:"name"`
@@ -215,14 +230,16 @@ type AddWidgetPayload struct {
 }
 
 type Asset struct {
-	ID          ID        `json:"id"`
-	CreatedAt   time.Time `json:"createdAt"`
-	TeamID      ID        `json:"teamId"`
-	Name        string    `json:"name"`
-	Size        int64     `json:"size"`
-	URL         string    `json:"url"`
-	ContentType string    `json:"contentType"`
-	Team        *Team     `json:"team,omitempty"`
+	ID           ID             `json:"id"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	TeamID       ID             `json:"teamId"`
+	Name         string         `json:"name"`
+	Size         int64          `json:"size"`
+	URL          string         `json:"url"`
+	ContentType  string         `json:"contentType"`
+	ThumbnailURL *string        `json:"thumbnailUrl,omitempty"`
+	Metadata     *AssetMetadata `json:"metadata,omitempty"`
+	Team         *Team          `json:"team,omitempty"`
 }
 
 func (Asset) IsNode()        {}
@@ -240,6 +257,25 @@ type AssetEdge struct {
 	Node   *Asset          `json:"node,omitempty"`
 }
 
+type AssetMetadata struct {
+	Width    *int             `json:"width,omitempty"`
+	Height   *int             `json:"height,omitempty"`
+	Bounds   *Rect            `json:"bounds,omitempty"`
+	Epsg     *int             `json:"epsg,omitempty"`
+	Duration *float64         `json:"duration,omitempty"`
+	Model    *AssetModelStats `json:"model,omitempty"`
+}
+
+type AssetModelStats struct {
+	NodeCount      int `json:"nodeCount"`
+	MeshCount      int `json:"meshCount"`
+	PrimitiveCount int `json:"primitiveCount"`
+	VertexCount    int `json:"vertexCount"`
+	MaterialCount  int `json:"materialCount"`
+	TextureCount   int `json:"textureCount"`
+	AnimationCount int `json:"animationCount"`
+}
+
 type AttachTagItemToGroupInput struct {
 	ItemID  ID `json:"itemID"`
 	GroupID ID `json:"groupID"`
@@ -258,6 +294,48 @@ type AttachTagToLayerPayload struct {
 	Layer Layer `json:"layer"`
 }
 
+type AuditLog struct {
+	ID        ID                `json:"id"`
+	CreatedAt time.Time         `json:"createdAt"`
+	ActorID   *ID               `json:"actorId,omitempty"`
+	Actor     *User             `json:"actor,omitempty"`
+	Action    string            `json:"action"`
+	TeamID    ID                `json:"teamId"`
+	SceneID   *ID               `json:"sceneId,omitempty"`
+	Targets   []*AuditLogTarget `json:"targets"`
+	Changes   []*AuditLogChange `json:"changes"`
+}
+
+type AuditLogChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+type AuditLogConnection struct {
+	Edges      []*AuditLogEdge `json:"edges"`
+	Nodes      []*AuditLog     `json:"nodes"`
+	PageInfo   *PageInfo       `json:"pageInfo"`
+	TotalCount int             `json:"totalCount"`
+}
+
+type AuditLogEdge struct {
+	Cursor usecasex.Cursor `json:"cursor"`
+	Node   *AuditLog       `json:"node,omitempty"`
+}
+
+type AuditLogTarget struct {
+	Type string `json:"type"`
+	ID   ID     `json:"id"`
+}
+
+type BasicAuthCredential struct {
+	Name      string     `json:"name"`
+	Username  string     `json:"username"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
 type Camera struct {
 	Lat      float64 `json:"lat"`
 	Lng      float64 `json:"lng"`
@@ -309,6 +387,7 @@ type CreateProjectInput struct {
 	Alias       *string    `json:"alias,omitempty"`
 	Archived    *bool      `json:"archived,omitempty"`
 	CoreSupport *bool      `json:"coreSupport,omitempty"`
+	TemplateID  *ID        `json:"templateId,omitempty"`
 }
 
 type CreateSceneInput struct {
@@ -394,6 +473,11 @@ type Dataset struct {
 func (Dataset) IsNode()        {}
 func (this Dataset) GetID() ID { return this.ID }
 
+type DatasetColumnTypeInput struct {
+	Column string    `json:"column"`
+	Type   ValueType `json:"type"`
+}
+
 type DatasetConnection struct {
 	Edges      []*DatasetEdge `json:"edges"`
 	Nodes      []*Dataset     `json:"nodes"`
@@ -417,6 +501,18 @@ type DatasetField struct {
 	ValueRef *Dataset            `json:"valueRef,omitempty"`
 }
 
+type DatasetPreviewField struct {
+	Name   string    `json:"name"`
+	Source string    `json:"source"`
+	Type   ValueType `json:"type"`
+}
+
+type DatasetPreviewRowError struct {
+	Row     int     `json:"row"`
+	Column  *string `json:"column,omitempty"`
+	Message string  `json:"message"`
+}
+
 type DatasetSchema struct {
 	ID                    ID                    `json:"id"`
 	Source                string                `json:"source"`
@@ -429,6 +525,8 @@ type DatasetSchema struct {
 	Datasets              *DatasetConnection    `json:"datasets"`
 	Scene                 *Scene                `json:"scene,omitempty"`
 	RepresentativeField   *DatasetSchemaField   `json:"representativeField,omitempty"`
+	Refresh               *DatasetSchemaRefresh `json:"refresh,omitempty"`
+	SyncLogs              []*DatasetSyncLog     `json:"syncLogs"`
 }
 
 func (DatasetSchema) IsNode()        {}
@@ -460,6 +558,25 @@ type DatasetSchemaField struct {
 func (DatasetSchemaField) IsNode()        {}
 func (this DatasetSchemaField) GetID() ID { return this.ID }
 
+type DatasetSchemaRefresh struct {
+	Interval     int        `json:"interval"`
+	Publish      bool       `json:"publish"`
+	NextAt       time.Time  `json:"nextAt"`
+	LastSyncedAt *time.Time `json:"lastSyncedAt,omitempty"`
+}
+
+type DatasetSyncLog struct {
+	ID              ID        `json:"id"`
+	DatasetSchemaID *ID       `json:"datasetSchemaId,omitempty"`
+	Source          string    `json:"source"`
+	Auto            bool      `json:"auto"`
+	Added           int       `json:"added"`
+	Removed         int       `json:"removed"`
+	Changed         int       `json:"changed"`
+	Error           *string   `json:"error,omitempty"`
+	SyncedAt        time.Time `json:"syncedAt"`
+}
+
 type DeleteGeoJSONFeatureInput struct {
 	FeatureID ID `json:"featureId"`
 	LayerID   ID `json:"layerId"`
@@ -539,6 +656,12 @@ type DuplicateNLSLayerPayload struct {
 	Layer NLSLayer `json:"layer"`
 }
 
+type DuplicateProjectInput struct {
+	ProjectID ID      `json:"projectId"`
+	TeamID    *ID     `json:"teamId,omitempty"`
+	Name      *string `json:"name,omitempty"`
+}
+
 type DuplicateStoryPageInput struct {
 	SceneID ID `json:"sceneId"`
 	StoryID ID `json:"storyId"`
@@ -572,6 +695,30 @@ type GeometryCollection struct {
 
 func (GeometryCollection) IsGeometry() {}
 
+type GrantProjectAccessInput struct {
+	ProjectID ID               `json:"projectId"`
+	UserID    ID               `json:"userId"`
+	Role      ProjectGrantRole `json:"role"`
+}
+
+type GrantProjectAccessPayload struct {
+	Grant *ProjectGrant `json:"grant"`
+}
+
+type HistoryEntry struct {
+	ID        ID               `json:"id"`
+	SceneID   ID               `json:"sceneId"`
+	Action    string           `json:"action"`
+	Targets   []*HistoryTarget `json:"targets"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UndoneAt  *time.Time       `json:"undoneAt,omitempty"`
+}
+
+type HistoryTarget struct {
+	Type string `json:"type"`
+	ID   ID     `json:"id"`
+}
+
 type ImportDatasetFromGoogleSheetInput struct {
 	AccessToken     string `json:"accessToken"`
 	FileID          string `json:"fileId"`
@@ -581,19 +728,36 @@ type ImportDatasetFromGoogleSheetInput struct {
 }
 
 type ImportDatasetInput struct {
-	File            graphql.Upload `json:"file"`
-	SceneID         ID             `json:"sceneId"`
-	DatasetSchemaID *ID            `json:"datasetSchemaId,omitempty"`
+	File            graphql.Upload            `json:"file"`
+	SceneID         ID                        `json:"sceneId"`
+	DatasetSchemaID *ID                       `json:"datasetSchemaId,omitempty"`
+	Crs             *string                   `json:"crs,omitempty"`
+	LatColumn       *string                   `json:"latColumn,omitempty"`
+	LngColumn       *string                   `json:"lngColumn,omitempty"`
+	HeightColumn    *string                   `json:"heightColumn,omitempty"`
+	GeometryColumn  *string                   `json:"geometryColumn,omitempty"`
+	Types           []*DatasetColumnTypeInput `json:"types,omitempty"`
+	SheetName       *string                   `json:"sheetName,omitempty"`
 }
 
 type ImportDatasetPayload struct {
 	DatasetSchema *DatasetSchema `json:"datasetSchema"`
 }
 
+type ImportGeoPackageInput struct {
+	File    graphql.Upload `json:"file"`
+	SceneID ID             `json:"sceneId"`
+}
+
+type ImportGeoPackagePayload struct {
+	DatasetSchemas []*DatasetSchema `json:"datasetSchemas"`
+}
+
 type ImportLayerInput struct {
 	LayerID ID                  `json:"layerId"`
 	File    graphql.Upload      `json:"file"`
 	Format  LayerEncodingFormat `json:"format"`
+	Crs     *string             `json:"crs,omitempty"`
 }
 
 type ImportLayerPayload struct {
@@ -601,6 +765,24 @@ type ImportLayerPayload struct {
 	ParentLayer *LayerGroup `json:"parentLayer"`
 }
 
+type ImportNLSLayerInput struct {
+	SceneID ID                  `json:"sceneId"`
+	LayerID *ID                 `json:"layerId,omitempty"`
+	Title   *string             `json:"title,omitempty"`
+	File    graphql.Upload      `json:"file"`
+	Format  LayerEncodingFormat `json:"format"`
+	Crs     *string             `json:"crs,omitempty"`
+}
+
+type ImportNLSLayerPayload struct {
+	Layer NLSLayer `json:"layer"`
+}
+
+type ImportProjectInput struct {
+	TeamID ID             `json:"teamId"`
+	File   graphql.Upload `json:"file"`
+}
+
 type Infobox struct {
 	SceneID         ID              `json:"sceneId"`
 	LayerID         ID              `json:"layerId"`
@@ -1000,6 +1182,7 @@ type NLSLayerGroup struct {
 	Scene       *Scene      `json:"scene,omitempty"`
 	IsSketch    bool        `json:"isSketch"`
 	Sketch      *SketchInfo `json:"sketch,omitempty"`
+	Version     int         `json:"version"`
 }
 
 func (NLSLayerGroup) IsNLSLayer()                  {}
@@ -1012,6 +1195,7 @@ func (this NLSLayerGroup) GetVisible() bool        { return this.Visible }
 func (this NLSLayerGroup) GetInfobox() *NLSInfobox { return this.Infobox }
 func (this NLSLayerGroup) GetIsSketch() bool       { return this.IsSketch }
 func (this NLSLayerGroup) GetSketch() *SketchInfo  { return this.Sketch }
+func (this NLSLayerGroup) GetVersion() int         { return this.Version }
 
 type NLSLayerSimple struct {
 	ID        ID          `json:"id"`
@@ -1024,6 +1208,7 @@ type NLSLayerSimple struct {
 	Scene     *Scene      `json:"scene,omitempty"`
 	IsSketch  bool        `json:"isSketch"`
 	Sketch    *SketchInfo `json:"sketch,omitempty"`
+	Version   int         `json:"version"`
 }
 
 func (NLSLayerSimple) IsNLSLayer()                  {}
@@ -1036,6 +1221,7 @@ func (this NLSLayerSimple) GetVisible() bool        { return this.Visible }
 func (this NLSLayerSimple) GetInfobox() *NLSInfobox { return this.Infobox }
 func (this NLSLayerSimple) GetIsSketch() bool       { return this.IsSketch }
 func (this NLSLayerSimple) GetSketch() *SketchInfo  { return this.Sketch }
+func (this NLSLayerSimple) GetVersion() int         { return this.Version }
 
 type PageInfo struct {
 	StartCursor     *usecasex.Cursor `json:"startCursor,omitempty"`
@@ -1115,6 +1301,13 @@ type Policy struct {
 	AssetStorageSize      *int64 `json:"assetStorageSize,omitempty"`
 	DatasetSchemaCount    *int   `json:"datasetSchemaCount,omitempty"`
 	DatasetCount          *int   `json:"datasetCount,omitempty"`
+	NlsLayerCount         *int   `json:"nlsLayerCount,omitempty"`
+	SketchFeatureCount    *int   `json:"sketchFeatureCount,omitempty"`
+	StoryCount            *int   `json:"storyCount,omitempty"`
+	StoryPageCount        *int   `json:"storyPageCount,omitempty"`
+	PublishedStoryCount   *int   `json:"publishedStoryCount,omitempty"`
+	PluginCount           *int   `json:"pluginCount,omitempty"`
+	MaxUploadSize         *int64 `json:"maxUploadSize,omitempty"`
 }
 
 type Polygon struct {
@@ -1124,31 +1317,53 @@ type Polygon struct {
 
 func (Polygon) IsGeometry() {}
 
+type PreviewDatasetPayload struct {
+	Fields     []*DatasetPreviewField    `json:"fields"`
+	Rows       []JSON                    `json:"rows"`
+	TotalCount int                       `json:"totalCount"`
+	Errors     []*DatasetPreviewRowError `json:"errors"`
+}
+
+type PreviewProjectInput struct {
+	ProjectID ID         `json:"projectId"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type PreviewProjectPayload struct {
+	Project    *Project  `json:"project"`
+	PreviewURL url.URL   `json:"previewUrl"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
 type Project struct {
-	ID                ID                `json:"id"`
-	IsArchived        bool              `json:"isArchived"`
-	IsBasicAuthActive bool              `json:"isBasicAuthActive"`
-	BasicAuthUsername string            `json:"basicAuthUsername"`
-	BasicAuthPassword string            `json:"basicAuthPassword"`
-	CreatedAt         time.Time         `json:"createdAt"`
-	UpdatedAt         time.Time         `json:"updatedAt"`
-	PublishedAt       *time.Time        `json:"publishedAt,omitempty"`
-	Name              string            `json:"name"`
-	Description       string            `json:"description"`
-	Alias             string            `json:"alias"`
-	PublicTitle       string            `json:"publicTitle"`
-	PublicDescription string            `json:"publicDescription"`
-	PublicImage       string            `json:"publicImage"`
-	PublicNoIndex     bool              `json:"publicNoIndex"`
-	ImageURL          *url.URL          `json:"imageUrl,omitempty"`
-	TeamID            ID                `json:"teamId"`
-	Visualizer        Visualizer        `json:"visualizer"`
-	PublishmentStatus PublishmentStatus `json:"publishmentStatus"`
-	Team              *Team             `json:"team,omitempty"`
-	Scene             *Scene            `json:"scene,omitempty"`
-	CoreSupport       bool              `json:"coreSupport"`
-	EnableGa          bool              `json:"enableGa"`
-	TrackingID        string            `json:"trackingId"`
+	ID                   ID                     `json:"id"`
+	IsArchived           bool                   `json:"isArchived"`
+	IsBasicAuthActive    bool                   `json:"isBasicAuthActive"`
+	BasicAuthUsername    string                 `json:"basicAuthUsername"`
+	BasicAuthPassword    string                 `json:"basicAuthPassword"`
+	BasicAuthCredentials []*BasicAuthCredential `json:"basicAuthCredentials"`
+	CreatedAt            time.Time              `json:"createdAt"`
+	UpdatedAt            time.Time              `json:"updatedAt"`
+	PublishedAt          *time.Time             `json:"publishedAt,omitempty"`
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description"`
+	Alias                string                 `json:"alias"`
+	PublicTitle          string                 `json:"publicTitle"`
+	PublicDescription    string                 `json:"publicDescription"`
+	PublicImage          string                 `json:"publicImage"`
+	PublicNoIndex        bool                   `json:"publicNoIndex"`
+	ImageURL             *url.URL               `json:"imageUrl,omitempty"`
+	TeamID               ID                     `json:"teamId"`
+	Visualizer           Visualizer             `json:"visualizer"`
+	PublishmentStatus    PublishmentStatus      `json:"publishmentStatus"`
+	Team                 *Team                  `json:"team,omitempty"`
+	Scene                *Scene                 `json:"scene,omitempty"`
+	CoreSupport          bool                   `json:"coreSupport"`
+	EnableGa             bool                   `json:"enableGa"`
+	TrackingID           string                 `json:"trackingId"`
+	Revisions            []*PublishedRevision   `json:"revisions"`
+	PublishSchedule      *PublishSchedule       `json:"publishSchedule,omitempty"`
+	IsTemplate           bool                   `json:"isTemplate"`
 }
 
 func (Project) IsNode()        {}
@@ -1171,6 +1386,16 @@ type ProjectEdge struct {
 	Node   *Project        `json:"node,omitempty"`
 }
 
+type ProjectGrant struct {
+	ID          ID               `json:"id"`
+	ProjectID   ID               `json:"projectId"`
+	UserID      ID               `json:"userId"`
+	User        *User            `json:"user,omitempty"`
+	Role        ProjectGrantRole `json:"role"`
+	GrantedByID *ID              `json:"grantedById,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+}
+
 type ProjectPayload struct {
 	Project *Project `json:"project"`
 }
@@ -1182,6 +1407,7 @@ type Property struct {
 	Schema   *PropertySchema `json:"schema,omitempty"`
 	Layer    Layer           `json:"layer,omitempty"`
 	Merged   *MergedProperty `json:"merged,omitempty"`
+	Version  int             `json:"version"`
 }
 
 func (Property) IsNode()        {}
@@ -1312,12 +1538,32 @@ type PublishProjectInput struct {
 	Status    PublishmentStatus `json:"status"`
 }
 
+type PublishSchedule struct {
+	PublishmentStatus PublishmentStatus `json:"publishmentStatus"`
+	PublishAt         *time.Time        `json:"publishAt,omitempty"`
+	UnpublishAt       *time.Time        `json:"unpublishAt,omitempty"`
+}
+
 type PublishStoryInput struct {
 	StoryID ID                `json:"storyId"`
 	Alias   *string           `json:"alias,omitempty"`
 	Status  PublishmentStatus `json:"status"`
 }
 
+type PublishedRevision struct {
+	ID                ID                `json:"id"`
+	Number            int               `json:"number"`
+	Alias             string            `json:"alias"`
+	PublishmentStatus PublishmentStatus `json:"publishmentStatus"`
+	SchemaVersion     int               `json:"schemaVersion"`
+	PublishedAt       time.Time         `json:"publishedAt"`
+	PublishedByID     *ID               `json:"publishedById,omitempty"`
+	PublishedBy       *User             `json:"publishedBy,omitempty"`
+}
+
+func (PublishedRevision) IsNode()        {}
+func (this PublishedRevision) GetID() ID { return this.ID }
+
 type Query struct {
 }
 
@@ -1328,6 +1574,14 @@ type Rect struct {
 	North float64 `json:"north"`
 }
 
+type RedoInput struct {
+	SceneID ID `json:"sceneId"`
+}
+
+type RedoPayload struct {
+	Entry *HistoryEntry `json:"entry"`
+}
+
 type RemoveAssetInput struct {
 	AssetID ID `json:"assetId"`
 }
@@ -1473,6 +1727,36 @@ type RemoveWidgetPayload struct {
 	WidgetID ID     `json:"widgetId"`
 }
 
+type RevokeProjectAccessInput struct {
+	ProjectID ID `json:"projectId"`
+	UserID    ID `json:"userId"`
+}
+
+type RevokeProjectAccessPayload struct {
+	ProjectID ID `json:"projectId"`
+	UserID    ID `json:"userId"`
+}
+
+type RevokeProjectBasicAuthCredentialInput struct {
+	ProjectID ID     `json:"projectId"`
+	Name      string `json:"name"`
+}
+
+type RevokeStoryBasicAuthCredentialInput struct {
+	StoryID ID     `json:"storyId"`
+	Name    string `json:"name"`
+}
+
+type RollbackProjectInput struct {
+	ProjectID ID  `json:"projectId"`
+	Revision  int `json:"revision"`
+}
+
+type RollbackStoryInput struct {
+	StoryID  ID  `json:"storyId"`
+	Revision int `json:"revision"`
+}
+
 type Scene struct {
 	ID                ID                       `json:"id"`
 	ProjectID         ID                       `json:"projectId"`
@@ -1495,11 +1779,26 @@ type Scene struct {
 	TagIds            []ID                     `json:"tagIds"`
 	Tags              []Tag                    `json:"tags"`
 	Clusters          []*Cluster               `json:"clusters"`
+	Version           int                      `json:"version"`
 }
 
 func (Scene) IsNode()        {}
 func (this Scene) GetID() ID { return this.ID }
 
+type SceneEvent struct {
+	SceneID   ID                `json:"sceneId"`
+	Action    string            `json:"action"`
+	Targets   []*AuditLogTarget `json:"targets"`
+	ActorID   *ID               `json:"actorId,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type SceneHistory struct {
+	SceneID ID            `json:"sceneId"`
+	Undo    *HistoryEntry `json:"undo,omitempty"`
+	Redo    *HistoryEntry `json:"redo,omitempty"`
+}
+
 type ScenePlugin struct {
 	PluginID   ID        `json:"pluginId"`
 	PropertyID *ID       `json:"propertyId,omitempty"`
@@ -1507,6 +1806,17 @@ type ScenePlugin struct {
 	Property   *Property `json:"property,omitempty"`
 }
 
+type SceneUsage struct {
+	SceneID             ID      `json:"sceneId"`
+	Policy              *Policy `json:"policy,omitempty"`
+	NlsLayerCount       int     `json:"nlsLayerCount"`
+	SketchFeatureCount  int     `json:"sketchFeatureCount"`
+	StoryCount          int     `json:"storyCount"`
+	StoryPageCount      int     `json:"storyPageCount"`
+	PublishedStoryCount int     `json:"publishedStoryCount"`
+	PluginCount         int     `json:"pluginCount"`
+}
+
 type SceneWidget struct {
 	ID          ID               `json:"id"`
 	PluginID    ID               `json:"pluginId"`
@@ -1519,6 +1829,30 @@ type SceneWidget struct {
 	Property    *Property        `json:"property,omitempty"`
 }
 
+type SchedulePublishProjectInput struct {
+	ProjectID   ID                 `json:"projectId"`
+	Status      *PublishmentStatus `json:"status,omitempty"`
+	PublishAt   *time.Time         `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time         `json:"unpublishAt,omitempty"`
+}
+
+type SchedulePublishStoryInput struct {
+	StoryID     ID                 `json:"storyId"`
+	Status      *PublishmentStatus `json:"status,omitempty"`
+	PublishAt   *time.Time         `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time         `json:"unpublishAt,omitempty"`
+}
+
+type SendWorkspaceUsageWarningInput struct {
+	TeamID    ID      `json:"teamId"`
+	Threshold float64 `json:"threshold"`
+}
+
+type SendWorkspaceUsageWarningPayload struct {
+	TeamID   ID                    `json:"teamId"`
+	Exceeded []*WorkspaceUsageItem `json:"exceeded"`
+}
+
 type SignupInput struct {
 	Lang   *language.Tag `json:"lang,omitempty"`
 	Theme  *Theme        `json:"theme,omitempty"`
@@ -1545,27 +1879,31 @@ type Spacing struct {
 }
 
 type Story struct {
-	ID                ID                `json:"id"`
-	Title             string            `json:"title"`
-	Alias             string            `json:"alias"`
-	PropertyID        ID                `json:"propertyId"`
-	Property          *Property         `json:"property,omitempty"`
-	Pages             []*StoryPage      `json:"pages"`
-	PublishmentStatus PublishmentStatus `json:"publishmentStatus"`
-	CreatedAt         time.Time         `json:"createdAt"`
-	UpdatedAt         time.Time         `json:"updatedAt"`
-	PublishedAt       *time.Time        `json:"publishedAt,omitempty"`
-	SceneID           ID                `json:"sceneId"`
-	Scene             *Scene            `json:"scene,omitempty"`
-	PanelPosition     Position          `json:"panelPosition"`
-	BgColor           *string           `json:"bgColor,omitempty"`
-	IsBasicAuthActive bool              `json:"isBasicAuthActive"`
-	BasicAuthUsername string            `json:"basicAuthUsername"`
-	BasicAuthPassword string            `json:"basicAuthPassword"`
-	PublicTitle       string            `json:"publicTitle"`
-	PublicDescription string            `json:"publicDescription"`
-	PublicImage       string            `json:"publicImage"`
-	PublicNoIndex     bool              `json:"publicNoIndex"`
+	ID                   ID                     `json:"id"`
+	Title                string                 `json:"title"`
+	Alias                string                 `json:"alias"`
+	PropertyID           ID                     `json:"propertyId"`
+	Property             *Property              `json:"property,omitempty"`
+	Pages                []*StoryPage           `json:"pages"`
+	PublishmentStatus    PublishmentStatus      `json:"publishmentStatus"`
+	CreatedAt            time.Time              `json:"createdAt"`
+	UpdatedAt            time.Time              `json:"updatedAt"`
+	PublishedAt          *time.Time             `json:"publishedAt,omitempty"`
+	SceneID              ID                     `json:"sceneId"`
+	Scene                *Scene                 `json:"scene,omitempty"`
+	PanelPosition        Position               `json:"panelPosition"`
+	BgColor              *string                `json:"bgColor,omitempty"`
+	IsBasicAuthActive    bool                   `json:"isBasicAuthActive"`
+	BasicAuthUsername    string                 `json:"basicAuthUsername"`
+	BasicAuthPassword    string                 `json:"basicAuthPassword"`
+	BasicAuthCredentials []*BasicAuthCredential `json:"basicAuthCredentials"`
+	PublicTitle          string                 `json:"publicTitle"`
+	PublicDescription    string                 `json:"publicDescription"`
+	PublicImage          string                 `json:"publicImage"`
+	PublicNoIndex        bool                   `json:"publicNoIndex"`
+	Revisions            []*PublishedRevision   `json:"revisions"`
+	PublishSchedule      *PublishSchedule       `json:"publishSchedule,omitempty"`
+	Version              int                    `json:"version"`
 }
 
 func (Story) IsNode()        {}
@@ -1619,6 +1957,10 @@ type Style struct {
 	Value   JSON   `json:"value"`
 	SceneID ID     `json:"sceneId"`
 	Scene   *Scene `json:"scene,omitempty"`
+	Version int    `json:"version"`
+}
+
+type Subscription struct {
 }
 
 type SyncDatasetInput struct {
@@ -1725,6 +2067,14 @@ type Typography struct {
 	Underline  *bool      `json:"underline,omitempty"`
 }
 
+type UndoInput struct {
+	SceneID ID `json:"sceneId"`
+}
+
+type UndoPayload struct {
+	Entry *HistoryEntry `json:"entry"`
+}
+
 type UninstallPluginInput struct {
 	SceneID  ID `json:"sceneId"`
 	PluginID ID `json:"pluginId"`
@@ -1763,6 +2113,12 @@ type UpdateDatasetSchemaPayload struct {
 	DatasetSchema *DatasetSchema `json:"datasetSchema,omitempty"`
 }
 
+type UpdateDatasetSchemaRefreshInput struct {
+	SchemaID ID    `json:"schemaId"`
+	Interval int   `json:"interval"`
+	Publish  *bool `json:"publish,omitempty"`
+}
+
 type UpdateGeoJSONFeatureInput struct {
 	FeatureID  ID   `json:"featureId"`
 	Geometry   JSON `json:"geometry,omitempty"`
@@ -1804,10 +2160,11 @@ type UpdateMemberOfTeamPayload struct {
 }
 
 type UpdateNLSLayerInput struct {
-	LayerID ID      `json:"layerId"`
-	Name    *string `json:"name,omitempty"`
-	Visible *bool   `json:"visible,omitempty"`
-	Config  JSON    `json:"config,omitempty"`
+	LayerID         ID      `json:"layerId"`
+	Name            *string `json:"name,omitempty"`
+	Visible         *bool   `json:"visible,omitempty"`
+	Config          JSON    `json:"config,omitempty"`
+	ExpectedVersion *int    `json:"expectedVersion,omitempty"`
 }
 
 type UpdateNLSLayerPayload struct {
@@ -1833,6 +2190,7 @@ type UpdateProjectInput struct {
 	EnableGa          *bool    `json:"enableGa,omitempty"`
 	TrackingID        *string  `json:"trackingId,omitempty"`
 	SceneID           *ID      `json:"sceneId,omitempty"`
+	IsTemplate        *bool    `json:"isTemplate,omitempty"`
 }
 
 type UpdatePropertyItemInput struct {
@@ -1850,12 +2208,13 @@ type UpdatePropertyItemOperationInput struct {
 }
 
 type UpdatePropertyValueInput struct {
-	PropertyID    ID          `json:"propertyId"`
-	SchemaGroupID *ID         `json:"schemaGroupId,omitempty"`
-	ItemID        *ID         `json:"itemId,omitempty"`
-	FieldID       ID          `json:"fieldId"`
-	Value         interface{} `json:"value,omitempty"`
-	Type          ValueType   `json:"type"`
+	PropertyID      ID          `json:"propertyId"`
+	SchemaGroupID   *ID         `json:"schemaGroupId,omitempty"`
+	ItemID          *ID         `json:"itemId,omitempty"`
+	FieldID         ID          `json:"fieldId"`
+	Value           interface{} `json:"value,omitempty"`
+	Type            ValueType   `json:"type"`
+	ExpectedVersion *int        `json:"expectedVersion,omitempty"`
 }
 
 type UpdateStoryInput struct {
@@ -1874,6 +2233,7 @@ type UpdateStoryInput struct {
 	PublicImage       *string   `json:"publicImage,omitempty"`
 	PublicNoIndex     *bool     `json:"publicNoIndex,omitempty"`
 	DeletePublicImage *bool     `json:"deletePublicImage,omitempty"`
+	ExpectedVersion   *int      `json:"expectedVersion,omitempty"`
 }
 
 type UpdateStoryPageInput struct {
@@ -1885,12 +2245,14 @@ type UpdateStoryPageInput struct {
 	Layers          []ID    `json:"layers,omitempty"`
 	SwipeableLayers []ID    `json:"swipeableLayers,omitempty"`
 	Index           *int    `json:"index,omitempty"`
+	ExpectedVersion *int    `json:"expectedVersion,omitempty"`
 }
 
 type UpdateStyleInput struct {
-	StyleID ID      `json:"styleId"`
-	Name    *string `json:"name,omitempty"`
-	Value   JSON    `json:"value,omitempty"`
+	StyleID         ID      `json:"styleId"`
+	Name            *string `json:"name,omitempty"`
+	Value           JSON    `json:"value,omitempty"`
+	ExpectedVersion *int    `json:"expectedVersion,omitempty"`
 }
 
 type UpdateStylePayload struct {
@@ -1917,13 +2279,14 @@ type UpdateTeamPayload struct {
 }
 
 type UpdateWidgetAlignSystemInput struct {
-	SceneID    ID                      `json:"sceneId"`
-	Location   *WidgetLocationInput    `json:"location"`
-	Align      *WidgetAreaAlign        `json:"align,omitempty"`
-	Padding    *WidgetAreaPaddingInput `json:"padding,omitempty"`
-	Gap        *int                    `json:"gap,omitempty"`
-	Centered   *bool                   `json:"centered,omitempty"`
-	Background *string                 `json:"background,omitempty"`
+	SceneID         ID                      `json:"sceneId"`
+	Location        *WidgetLocationInput    `json:"location"`
+	Align           *WidgetAreaAlign        `json:"align,omitempty"`
+	Padding         *WidgetAreaPaddingInput `json:"padding,omitempty"`
+	Gap             *int                    `json:"gap,omitempty"`
+	Centered        *bool                   `json:"centered,omitempty"`
+	Background      *string                 `json:"background,omitempty"`
+	ExpectedVersion *int                    `json:"expectedVersion,omitempty"`
 }
 
 type UpdateWidgetAlignSystemPayload struct {
@@ -1931,12 +2294,13 @@ type UpdateWidgetAlignSystemPayload struct {
 }
 
 type UpdateWidgetInput struct {
-	SceneID  ID                   `json:"sceneId"`
-	WidgetID ID                   `json:"widgetId"`
-	Enabled  *bool                `json:"enabled,omitempty"`
-	Location *WidgetLocationInput `json:"location,omitempty"`
-	Extended *bool                `json:"extended,omitempty"`
-	Index    *int                 `json:"index,omitempty"`
+	SceneID         ID                   `json:"sceneId"`
+	WidgetID        ID                   `json:"widgetId"`
+	Enabled         *bool                `json:"enabled,omitempty"`
+	Location        *WidgetLocationInput `json:"location,omitempty"`
+	Extended        *bool                `json:"extended,omitempty"`
+	Index           *int                 `json:"index,omitempty"`
+	ExpectedVersion *int                 `json:"expectedVersion,omitempty"`
 }
 
 type UpdateWidgetPayload struct {
@@ -2049,6 +2413,19 @@ type WidgetZone struct {
 	Right  *WidgetSection `json:"right,omitempty"`
 }
 
+type WorkspaceUsage struct {
+	TeamID ID                    `json:"teamId"`
+	Policy *Policy               `json:"policy,omitempty"`
+	Items  []*WorkspaceUsageItem `json:"items"`
+}
+
+type WorkspaceUsageItem struct {
+	Name       string   `json:"name"`
+	Used       int64    `json:"used"`
+	Limit      *int64   `json:"limit,omitempty"`
+	Percentage *float64 `json:"percentage,omitempty"`
+}
+
 type AssetSortType string
 
 const (
@@ -2341,6 +2718,49 @@ func (e Position) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ProjectGrantRole string
+
+const (
+	ProjectGrantRoleViewer    ProjectGrantRole = "VIEWER"
+	ProjectGrantRoleCommenter ProjectGrantRole = "COMMENTER"
+	ProjectGrantRoleEditor    ProjectGrantRole = "EDITOR"
+)
+
+var AllProjectGrantRole = []ProjectGrantRole{
+	ProjectGrantRoleViewer,
+	ProjectGrantRoleCommenter,
+	ProjectGrantRoleEditor,
+}
+
+func (e ProjectGrantRole) IsValid() bool {
+	switch e {
+	case ProjectGrantRoleViewer, ProjectGrantRoleCommenter, ProjectGrantRoleEditor:
+		return true
+	}
+	return false
+}
+
+func (e ProjectGrantRole) String() string {
+	return string(e)
+}
+
+func (e *ProjectGrantRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProjectGrantRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProjectGrantRole", str)
+	}
+	return nil
+}
+
+func (e ProjectGrantRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type PropertySchemaFieldUI string
 
 const (
@@ -13,18 +13,21 @@ const (
 )
 
 type Loaders struct {
-	usecases  interfaces.Container
-	Asset     *AssetLoader
-	Dataset   *DatasetLoader
-	Layer     *LayerLoader
-	Plugin    *PluginLoader
-	Policy    *PolicyLoader
-	Project   *ProjectLoader
-	Property  *PropertyLoader
-	Scene     *SceneLoader
-	Workspace *WorkspaceLoader
-	User      *UserLoader
-	Tag       *TagLoader
+	usecases     interfaces.Container
+	Asset        *AssetLoader
+	AuditLog     *AuditLogLoader
+	Dataset      *DatasetLoader
+	History      *HistoryLoader
+	Layer        *LayerLoader
+	Plugin       *PluginLoader
+	Policy       *PolicyLoader
+	Project      *ProjectLoader
+	ProjectGrant *ProjectGrantLoader
+	Property     *PropertyLoader
+	Scene        *SceneLoader
+	Workspace    *WorkspaceLoader
+	User         *UserLoader
+	Tag          *TagLoader
 }
 
 type DataLoaders struct {
@@ -52,18 +55,21 @@ func NewLoaders(usecases *interfaces.Container) *Loaders {
 		return nil
 	}
 	return &Loaders{
-		usecases:  *usecases,
-		Asset:     NewAssetLoader(usecases.Asset),
-		Dataset:   NewDatasetLoader(usecases.Dataset),
-		Layer:     NewLayerLoader(usecases.Layer),
-		Plugin:    NewPluginLoader(usecases.Plugin),
-		Policy:    NewPolicyLoader(usecases.Policy),
-		Project:   NewProjectLoader(usecases.Project),
-		Property:  NewPropertyLoader(usecases.Property),
-		Scene:     NewSceneLoader(usecases.Scene),
-		Workspace: NewWorkspaceLoader(usecases.Workspace),
-		User:      NewUserLoader(usecases.User),
-		Tag:       NewTagLoader(usecases.Tag),
+		usecases:     *usecases,
+		Asset:        NewAssetLoader(usecases.Asset),
+		AuditLog:     NewAuditLogLoader(usecases.AuditLog),
+		Dataset:      NewDatasetLoader(usecases.Dataset),
+		History:      NewHistoryLoader(usecases.History),
+		Layer:        NewLayerLoader(usecases.Layer),
+		Plugin:       NewPluginLoader(usecases.Plugin),
+		Policy:       NewPolicyLoader(usecases.Policy),
+		Project:      NewProjectLoader(usecases.Project),
+		ProjectGrant: NewProjectGrantLoader(usecases.ProjectGrant),
+		Property:     NewPropertyLoader(usecases.Property),
+		Scene:        NewSceneLoader(usecases.Scene),
+		Workspace:    NewWorkspaceLoader(usecases.Workspace),
+		User:         NewUserLoader(usecases.User),
+		Tag:          NewTagLoader(usecases.Tag),
 	}
 }
 
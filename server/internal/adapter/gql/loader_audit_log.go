@@ -0,0 +1,73 @@
+package gql
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/reearth/reearthx/util"
+)
+
+type AuditLogLoader struct {
+	usecase interfaces.AuditLog
+}
+
+func NewAuditLogLoader(usecase interfaces.AuditLog) *AuditLogLoader {
+	return &AuditLogLoader{usecase: usecase}
+}
+
+func (c *AuditLogLoader) FindByWorkspace(ctx context.Context, wsID gqlmodel.ID, sceneID, actorID *gqlmodel.ID, actions []string, targetID *gqlmodel.ID, since, until *time.Time, pagination *gqlmodel.Pagination) (*gqlmodel.AuditLogConnection, error) {
+	wid, err := gqlmodel.ToID[accountdomain.Workspace](wsID)
+	if err != nil {
+		return nil, err
+	}
+
+	f := interfaces.AuditLogFilter{
+		Actions: util.Map(actions, func(a string) auditlog.Action { return auditlog.Action(a) }),
+		Target:  (*string)(targetID),
+		Since:   since,
+		Until:   until,
+	}
+	if sceneID != nil {
+		sid, err := gqlmodel.ToID[id.Scene](*sceneID)
+		if err != nil {
+			return nil, err
+		}
+		f.Scene = &sid
+	}
+	if actorID != nil {
+		uid, err := gqlmodel.ToID[accountdomain.User](*actorID)
+		if err != nil {
+			return nil, err
+		}
+		f.Actor = &uid
+	}
+
+	logs, pi, err := c.usecase.FindByWorkspace(ctx, wid, f, gqlmodel.ToPagination(pagination), getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*gqlmodel.AuditLogEdge, 0, len(logs))
+	nodes := make([]*gqlmodel.AuditLog, 0, len(logs))
+	for _, l := range logs {
+		l2 := gqlmodel.ToAuditLog(l)
+		edges = append(edges, &gqlmodel.AuditLogEdge{
+			Node:   l2,
+			Cursor: usecasex.Cursor(l2.ID),
+		})
+		nodes = append(nodes, l2)
+	}
+
+	return &gqlmodel.AuditLogConnection{
+		Edges:      edges,
+		Nodes:      nodes,
+		PageInfo:   gqlmodel.ToPageInfo(pi),
+		TotalCount: int(pi.TotalCount),
+	}, nil
+}
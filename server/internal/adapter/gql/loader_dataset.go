@@ -9,6 +9,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/usecasex"
 	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
 )
 
 type DatasetLoader struct {
@@ -189,6 +190,20 @@ type DatasetDataLoader interface {
 	LoadAll([]gqlmodel.ID) ([]*gqlmodel.Dataset, []error)
 }
 
+func (c *DatasetLoader) FindSyncLogs(ctx context.Context, dsid gqlmodel.ID, limit *int) ([]*gqlmodel.DatasetSyncLog, error) {
+	schemaID, err := gqlmodel.ToID[id.DatasetSchema](dsid)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.usecase.FindSyncLogs(ctx, schemaID, lo.FromPtr(limit), getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Map(res, gqlmodel.ToDatasetSyncLog), nil
+}
+
 func (c *DatasetLoader) DataLoader(ctx context.Context) DatasetDataLoader {
 	return gqldataloader.NewDatasetLoader(gqldataloader.DatasetLoaderConfig{
 		Wait:     dataLoaderWait,
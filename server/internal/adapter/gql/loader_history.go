@@ -0,0 +1,35 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+type HistoryLoader struct {
+	usecase interfaces.History
+}
+
+func NewHistoryLoader(usecase interfaces.History) *HistoryLoader {
+	return &HistoryLoader{usecase: usecase}
+}
+
+func (c *HistoryLoader) Fetch(ctx context.Context, sceneID gqlmodel.ID) (*gqlmodel.SceneHistory, error) {
+	sid, err := gqlmodel.ToID[id.Scene](sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	undo, redo, err := c.usecase.Fetch(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.SceneHistory{
+		SceneID: sceneID,
+		Undo:    gqlmodel.ToHistoryEntry(undo),
+		Redo:    gqlmodel.ToHistoryEntry(redo),
+	}, nil
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqldataloader"
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/util"
 )
 
@@ -30,6 +32,34 @@ func (c *PolicyLoader) Fetch(ctx context.Context, ids []gqlmodel.ID) ([]*gqlmode
 	}), nil
 }
 
+func (c *PolicyLoader) FetchSceneUsage(ctx context.Context, sceneID gqlmodel.ID) (*gqlmodel.SceneUsage, error) {
+	sid, err := gqlmodel.ToID[id.Scene](sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.usecase.FetchSceneUsage(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlmodel.ToSceneUsage(res), nil
+}
+
+func (c *PolicyLoader) FetchWorkspaceUsage(ctx context.Context, teamID gqlmodel.ID) (*gqlmodel.WorkspaceUsage, error) {
+	wid, err := gqlmodel.ToID[accountdomain.Workspace](teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.usecase.FetchWorkspaceUsage(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlmodel.ToWorkspaceUsage(res), nil
+}
+
 // data loader
 
 type PolicyDataLoader interface {
@@ -39,6 +39,27 @@ func (c *ProjectLoader) Fetch(ctx context.Context, ids []gqlmodel.ID) ([]*gqlmod
 	return projects, nil
 }
 
+// FindGranted returns the projects granted to the operator apart from the workspaces.
+func (c *ProjectLoader) FindGranted(ctx context.Context) ([]*gqlmodel.Project, error) {
+	op := getOperator(ctx)
+	if op == nil || len(op.GrantedProjects) == 0 {
+		return []*gqlmodel.Project{}, nil
+	}
+
+	res, err := c.usecase.Fetch(ctx, op.GrantedProjects, op)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]*gqlmodel.Project, 0, len(res))
+	for _, project := range res {
+		if project != nil {
+			projects = append(projects, gqlmodel.ToProject(project))
+		}
+	}
+	return projects, nil
+}
+
 func (c *ProjectLoader) FindByWorkspace(ctx context.Context, wsID gqlmodel.ID, first *int, last *int, before *usecasex.Cursor, after *usecasex.Cursor) (*gqlmodel.ProjectConnection, error) {
 	tid, err := gqlmodel.ToID[accountdomain.Workspace](wsID)
 	if err != nil {
@@ -126,3 +147,17 @@ func (l *ordinaryProjectLoader) Load(key gqlmodel.ID) (*gqlmodel.Project, error)
 func (l *ordinaryProjectLoader) LoadAll(keys []gqlmodel.ID) ([]*gqlmodel.Project, []error) {
 	return l.fetch(keys)
 }
+
+func (c *ProjectLoader) FindRevisions(ctx context.Context, projectID gqlmodel.ID) ([]*gqlmodel.PublishedRevision, error) {
+	pid, err := gqlmodel.ToID[id.Project](projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.usecase.FindRevisions(ctx, pid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlmodel.ToPublishedRevisions(res), nil
+}
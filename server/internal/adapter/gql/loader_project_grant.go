@@ -0,0 +1,35 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/projectgrant"
+	"github.com/reearth/reearthx/util"
+)
+
+type ProjectGrantLoader struct {
+	usecase interfaces.ProjectGrant
+}
+
+func NewProjectGrantLoader(usecase interfaces.ProjectGrant) *ProjectGrantLoader {
+	return &ProjectGrantLoader{usecase: usecase}
+}
+
+func (c *ProjectGrantLoader) FindByProject(ctx context.Context, projectID gqlmodel.ID) ([]*gqlmodel.ProjectGrant, error) {
+	pid, err := gqlmodel.ToID[id.Project](projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.usecase.FetchByProject(ctx, pid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Map(res, func(g *projectgrant.Grant) *gqlmodel.ProjectGrant {
+		return gqlmodel.ToProjectGrant(g)
+	}), nil
+}
@@ -55,6 +55,32 @@ func (c *SceneLoader) FindByProject(ctx context.Context, projectID gqlmodel.ID)
 	return gqlmodel.ToScene(res), nil
 }
 
+func (c *SceneLoader) SubscribeEvents(ctx context.Context, sceneID gqlmodel.ID) (<-chan *gqlmodel.SceneEvent, error) {
+	sid, err := gqlmodel.ToID[id.Scene](sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := c.usecase.SubscribeEvents(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// the channel of events is closed when the context is done
+	res := make(chan *gqlmodel.SceneEvent)
+	go func() {
+		defer close(res)
+		for e := range events {
+			select {
+			case res <- gqlmodel.ToSceneEvent(e):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res, nil
+}
+
 // data loader
 
 type SceneDataLoader interface {
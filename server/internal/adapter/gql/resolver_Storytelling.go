@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/samber/lo"
 )
@@ -22,6 +23,20 @@ func (r *storyResolver) Property(ctx context.Context, obj *gqlmodel.Story) (*gql
 	return dataloaders(ctx).Property.Load(obj.PropertyID)
 }
 
+func (r *storyResolver) Revisions(ctx context.Context, obj *gqlmodel.Story) ([]*gqlmodel.PublishedRevision, error) {
+	sID, err := gqlmodel.ToID[id.Story](obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).StoryTelling.FindRevisions(ctx, sID, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlmodel.ToPublishedRevisions(res), nil
+}
+
 func (r *Resolver) StoryPage() StoryPageResolver {
 	return &storyPageResolver{r}
 }
@@ -0,0 +1,20 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+)
+
+func (r *Resolver) AuditLog() AuditLogResolver {
+	return &auditLogResolver{r}
+}
+
+type auditLogResolver struct{ *Resolver }
+
+func (r *auditLogResolver) Actor(ctx context.Context, obj *gqlmodel.AuditLog) (*gqlmodel.User, error) {
+	if obj.ActorID == nil {
+		return nil, nil
+	}
+	return dataloaders(ctx).User.Load(*obj.ActorID)
+}
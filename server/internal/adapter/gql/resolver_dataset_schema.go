@@ -42,6 +42,10 @@ func (r *datasetSchemaResolver) TotalCount(ctx context.Context, obj *gqlmodel.Da
 	return loaders(ctx).Dataset.CountBySchema(ctx, obj.ID)
 }
 
+func (r *datasetSchemaResolver) SyncLogs(ctx context.Context, obj *gqlmodel.DatasetSchema, limit *int) ([]*gqlmodel.DatasetSyncLog, error) {
+	return loaders(ctx).Dataset.FindSyncLogs(ctx, obj.ID, limit)
+}
+
 type datasetSchemaFieldResolver struct{ *Resolver }
 
 func (r *datasetSchemaFieldResolver) Schema(ctx context.Context, obj *gqlmodel.DatasetSchemaField) (*gqlmodel.DatasetSchema, error) {
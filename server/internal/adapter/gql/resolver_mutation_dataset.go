@@ -2,11 +2,13 @@ package gql
 
 import (
 	"context"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
 )
 
 func (r *mutationResolver) UpdateDatasetSchema(ctx context.Context, input gqlmodel.UpdateDatasetSchemaInput) (*gqlmodel.UpdateDatasetSchemaPayload, error) {
@@ -26,6 +28,24 @@ func (r *mutationResolver) UpdateDatasetSchema(ctx context.Context, input gqlmod
 	return &gqlmodel.UpdateDatasetSchemaPayload{DatasetSchema: gqlmodel.ToDatasetSchema(res)}, nil
 }
 
+func (r *mutationResolver) UpdateDatasetSchemaRefresh(ctx context.Context, input gqlmodel.UpdateDatasetSchemaRefreshInput) (*gqlmodel.UpdateDatasetSchemaPayload, error) {
+	dsid, err := gqlmodel.ToID[id.DatasetSchema](input.SchemaID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Dataset.UpdateDatasetSchemaRefresh(ctx, interfaces.UpdateDatasetSchemaRefreshParam{
+		SchemaID: dsid,
+		Interval: time.Duration(input.Interval) * time.Minute,
+		Publish:  lo.FromPtr(input.Publish),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateDatasetSchemaPayload{DatasetSchema: gqlmodel.ToDatasetSchema(res)}, nil
+}
+
 func (r *mutationResolver) SyncDataset(ctx context.Context, input gqlmodel.SyncDatasetInput) (*gqlmodel.SyncDatasetPayload, error) {
 	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
 	if err != nil {
@@ -86,10 +106,18 @@ func (r *mutationResolver) ImportDataset(ctx context.Context, input gqlmodel.Imp
 		return nil, err
 	}
 
+	c, err := gqlmodel.FromCRS(input.Crs)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := usecases(ctx).Dataset.ImportDataset(ctx, interfaces.ImportDatasetParam{
-		SceneId:  sid,
-		SchemaId: gqlmodel.ToIDRef[id.DatasetSchema](input.DatasetSchemaID),
-		File:     gqlmodel.FromFile(&input.File),
+		SceneId:   sid,
+		SchemaId:  gqlmodel.ToIDRef[id.DatasetSchema](input.DatasetSchemaID),
+		File:      gqlmodel.FromFile(&input.File),
+		CRS:       c,
+		Options:   gqlmodel.FromCSVOptions(input),
+		SheetName: lo.FromPtr(input.SheetName),
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -98,6 +126,49 @@ func (r *mutationResolver) ImportDataset(ctx context.Context, input gqlmodel.Imp
 	return &gqlmodel.ImportDatasetPayload{DatasetSchema: gqlmodel.ToDatasetSchema(res)}, nil
 }
 
+func (r *mutationResolver) PreviewDataset(ctx context.Context, input gqlmodel.ImportDatasetInput, limit *int) (*gqlmodel.PreviewDatasetPayload, error) {
+	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := gqlmodel.FromCRS(input.Crs)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Dataset.PreviewDataset(ctx, interfaces.ImportDatasetParam{
+		SceneId:   sid,
+		SchemaId:  gqlmodel.ToIDRef[id.DatasetSchema](input.DatasetSchemaID),
+		File:      gqlmodel.FromFile(&input.File),
+		CRS:       c,
+		Options:   gqlmodel.FromCSVOptions(input),
+		SheetName: lo.FromPtr(input.SheetName),
+	}, lo.FromPtrOr(limit, 10), getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlmodel.ToPreviewDatasetPayload(res), nil
+}
+
+func (r *mutationResolver) ImportGeoPackage(ctx context.Context, input gqlmodel.ImportGeoPackageInput) (*gqlmodel.ImportGeoPackagePayload, error) {
+	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Dataset.ImportGeoPackage(ctx, interfaces.ImportGeoPackageParam{
+		SceneId: sid,
+		File:    gqlmodel.FromFile(&input.File),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ImportGeoPackagePayload{DatasetSchemas: util.Map(res, gqlmodel.ToDatasetSchema)}, nil
+}
+
 func (r *mutationResolver) ImportDatasetFromGoogleSheet(ctx context.Context, input gqlmodel.ImportDatasetFromGoogleSheetInput) (*gqlmodel.ImportDatasetPayload, error) {
 	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
 	if err != nil {
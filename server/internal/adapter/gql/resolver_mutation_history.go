@@ -0,0 +1,40 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+func (r *mutationResolver) Undo(ctx context.Context, input gqlmodel.UndoInput) (*gqlmodel.UndoPayload, error) {
+	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := usecases(ctx).History.Undo(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UndoPayload{
+		Entry: gqlmodel.ToHistoryEntry(e),
+	}, nil
+}
+
+func (r *mutationResolver) Redo(ctx context.Context, input gqlmodel.RedoInput) (*gqlmodel.RedoPayload, error) {
+	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := usecases(ctx).History.Redo(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RedoPayload{
+		Entry: gqlmodel.ToHistoryEntry(e),
+	}, nil
+}
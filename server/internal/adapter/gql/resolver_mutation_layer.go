@@ -236,10 +236,16 @@ func (r *mutationResolver) ImportLayer(ctx context.Context, input gqlmodel.Impor
 		return nil, err
 	}
 
+	c, err := gqlmodel.FromCRS(input.Crs)
+	if err != nil {
+		return nil, err
+	}
+
 	l, l2, err := usecases(ctx).Layer.ImportLayer(ctx, interfaces.ImportLayerParam{
 		LayerID: lid,
 		File:    gqlmodel.FromFile(&input.File),
 		Format:  gqlmodel.FromLayerEncodingFormat(input.Format),
+		CRS:     c,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -58,10 +58,11 @@ func (r *mutationResolver) UpdateNLSLayer(ctx context.Context, input gqlmodel.Up
 	}
 
 	layer, err := usecases(ctx).NLSLayer.Update(ctx, interfaces.UpdateNLSLayerInput{
-		LayerID: lid,
-		Name:    input.Name,
-		Visible: input.Visible,
-		Config:  gqlmodel.ToNLSConfig(input.Config),
+		LayerID:         lid,
+		Name:            input.Name,
+		Visible:         input.Visible,
+		Config:          gqlmodel.ToNLSConfig(input.Config),
+		ExpectedVersion: input.ExpectedVersion,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -88,6 +89,34 @@ func (r *mutationResolver) DuplicateNLSLayer(ctx context.Context, input gqlmodel
 	}, nil
 }
 
+func (r *mutationResolver) ImportNLSLayer(ctx context.Context, input gqlmodel.ImportNLSLayerInput) (*gqlmodel.ImportNLSLayerPayload, error) {
+	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := gqlmodel.FromCRS(input.Crs)
+	if err != nil {
+		return nil, err
+	}
+
+	layer, err := usecases(ctx).NLSLayer.ImportLayer(ctx, interfaces.ImportNLSLayerParam{
+		SceneID: sid,
+		LayerID: gqlmodel.ToIDRef[id.NLSLayer](input.LayerID),
+		Title:   input.Title,
+		File:    gqlmodel.FromFile(&input.File),
+		Format:  gqlmodel.FromLayerEncodingFormat(input.Format),
+		CRS:     c,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ImportNLSLayerPayload{
+		Layer: gqlmodel.ToNLSLayer(layer, nil),
+	}, nil
+}
+
 func (r *mutationResolver) CreateNLSInfobox(ctx context.Context, input gqlmodel.CreateNLSInfoboxInput) (*gqlmodel.CreateNLSInfoboxPayload, error) {
 	lid, err := gqlmodel.ToID[id.NLSLayer](input.LayerID)
 	if err != nil {
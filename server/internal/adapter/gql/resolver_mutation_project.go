@@ -6,6 +6,7 @@ import (
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 	"github.com/reearth/reearthx/account/accountdomain"
 )
@@ -25,6 +26,7 @@ func (r *mutationResolver) CreateProject(ctx context.Context, input gqlmodel.Cre
 		Alias:       input.Alias,
 		Archived:    input.Archived,
 		CoreSupport: input.CoreSupport,
+		TemplateID:  gqlmodel.ToIDRef[id.Project](input.TemplateID),
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -68,6 +70,7 @@ func (r *mutationResolver) UpdateProject(ctx context.Context, input gqlmodel.Upd
 		EnableGa:          input.EnableGa,
 		TrackingID:        input.TrackingID,
 		SceneID:           gqlmodel.ToIDRef[id.Scene](input.SceneID),
+		IsTemplate:        input.IsTemplate,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -94,6 +97,139 @@ func (r *mutationResolver) PublishProject(ctx context.Context, input gqlmodel.Pu
 	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
 }
 
+func (r *mutationResolver) SchedulePublishProject(ctx context.Context, input gqlmodel.SchedulePublishProjectInput) (*gqlmodel.ProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var status project.PublishmentStatus
+	if input.Status != nil {
+		status = gqlmodel.FromPublishmentStatus(*input.Status)
+	}
+
+	res, err := usecases(ctx).Project.SchedulePublish(ctx, interfaces.SchedulePublishProjectParam{
+		ID:          pid,
+		Status:      status,
+		PublishAt:   input.PublishAt,
+		UnpublishAt: input.UnpublishAt,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
+}
+
+func (r *mutationResolver) RollbackProject(ctx context.Context, input gqlmodel.RollbackProjectInput) (*gqlmodel.ProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Project.Rollback(ctx, interfaces.RollbackProjectParam{
+		ID:       pid,
+		Revision: input.Revision,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
+}
+
+func (r *mutationResolver) AddProjectBasicAuthCredential(ctx context.Context, input gqlmodel.AddProjectBasicAuthCredentialInput) (*gqlmodel.ProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Project.AddBasicAuthCredential(ctx, interfaces.AddProjectBasicAuthCredentialParam{
+		ID:       pid,
+		Name:     input.Name,
+		Username: input.Username,
+		Password: input.Password,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
+}
+
+func (r *mutationResolver) RevokeProjectBasicAuthCredential(ctx context.Context, input gqlmodel.RevokeProjectBasicAuthCredentialInput) (*gqlmodel.ProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Project.RevokeBasicAuthCredential(ctx, interfaces.RevokeProjectBasicAuthCredentialParam{
+		ID:   pid,
+		Name: input.Name,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
+}
+
+func (r *mutationResolver) PreviewProject(ctx context.Context, input gqlmodel.PreviewProjectInput) (*gqlmodel.PreviewProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, u, err := usecases(ctx).Project.Preview(ctx, interfaces.PreviewProjectParam{
+		ID:        pid,
+		ExpiresAt: input.ExpiresAt,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.PreviewProjectPayload{
+		Project:    gqlmodel.ToProject(res),
+		PreviewURL: *u,
+		ExpiresAt:  res.Preview().ExpiresAt(),
+	}, nil
+}
+
+func (r *mutationResolver) ImportProject(ctx context.Context, input gqlmodel.ImportProjectInput) (*gqlmodel.ProjectPayload, error) {
+	tid, err := gqlmodel.ToID[accountdomain.Workspace](input.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Project.Import(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: tid,
+		File:        gqlmodel.FromFile(&input.File),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
+}
+
+func (r *mutationResolver) DuplicateProject(ctx context.Context, input gqlmodel.DuplicateProjectInput) (*gqlmodel.ProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Project.Duplicate(ctx, interfaces.DuplicateProjectParam{
+		ID:          pid,
+		WorkspaceID: gqlmodel.ToIDRef[accountdomain.Workspace](input.TeamID),
+		Name:        input.Name,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
+}
+
 func (r *mutationResolver) DeleteProject(ctx context.Context, input gqlmodel.DeleteProjectInput) (*gqlmodel.DeleteProjectPayload, error) {
 	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
 	if err != nil {
@@ -0,0 +1,46 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+func (r *mutationResolver) GrantProjectAccess(ctx context.Context, input gqlmodel.GrantProjectAccessInput) (*gqlmodel.GrantProjectAccessPayload, error) {
+	pid, uid, err := gqlmodel.ToID2[id.Project, accountdomain.User](input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := usecases(ctx).ProjectGrant.Grant(ctx, interfaces.GrantProjectAccessParam{
+		ProjectID: pid,
+		UserID:    uid,
+		Role:      gqlmodel.FromProjectGrantRole(input.Role),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.GrantProjectAccessPayload{
+		Grant: gqlmodel.ToProjectGrant(g),
+	}, nil
+}
+
+func (r *mutationResolver) RevokeProjectAccess(ctx context.Context, input gqlmodel.RevokeProjectAccessInput) (*gqlmodel.RevokeProjectAccessPayload, error) {
+	pid, uid, err := gqlmodel.ToID2[id.Project, accountdomain.User](input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecases(ctx).ProjectGrant.Revoke(ctx, pid, uid, getOperator(ctx)); err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RevokeProjectAccessPayload{
+		ProjectID: input.ProjectID,
+		UserID:    input.UserID,
+	}, nil
+}
@@ -34,7 +34,8 @@ func (r *mutationResolver) UpdatePropertyValue(ctx context.Context, input gqlmod
 			input.ItemID,
 			gqlmodel.ToStringIDRef[id.PropertyField](&input.FieldID),
 		),
-		Value: v,
+		Value:           v,
+		ExpectedVersion: input.ExpectedVersion,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
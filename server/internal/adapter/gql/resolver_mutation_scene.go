@@ -84,12 +84,13 @@ func (r *mutationResolver) UpdateWidget(ctx context.Context, input gqlmodel.Upda
 	}
 
 	scene, widget, err := usecases(ctx).Scene.UpdateWidget(ctx, interfaces.UpdateWidgetParam{
-		SceneID:  sid,
-		WidgetID: wid,
-		Enabled:  input.Enabled,
-		Extended: input.Extended,
-		Location: gqlmodel.FromSceneWidgetLocation(input.Location),
-		Index:    input.Index,
+		SceneID:         sid,
+		WidgetID:        wid,
+		Enabled:         input.Enabled,
+		Extended:        input.Extended,
+		Location:        gqlmodel.FromSceneWidgetLocation(input.Location),
+		Index:           input.Index,
+		ExpectedVersion: input.ExpectedVersion,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -129,13 +130,14 @@ func (r *mutationResolver) UpdateWidgetAlignSystem(ctx context.Context, input gq
 	}
 
 	scene, err := usecases(ctx).Scene.UpdateWidgetAlignSystem(ctx, interfaces.UpdateWidgetAlignSystemParam{
-		SceneID:    sid,
-		Location:   *gqlmodel.FromSceneWidgetLocation(input.Location),
-		Align:      gqlmodel.FromWidgetAlignType(input.Align),
-		Padding:    gqlmodel.FromSceneWidgetAreaPadding(input.Padding),
-		Gap:        input.Gap,
-		Centered:   input.Centered,
-		Background: input.Background,
+		SceneID:         sid,
+		Location:        *gqlmodel.FromSceneWidgetLocation(input.Location),
+		Align:           gqlmodel.FromWidgetAlignType(input.Align),
+		Padding:         gqlmodel.FromSceneWidgetAreaPadding(input.Padding),
+		Gap:             input.Gap,
+		Centered:        input.Centered,
+		Background:      input.Background,
+		ExpectedVersion: input.ExpectedVersion,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -7,6 +7,7 @@ import (
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/samber/lo"
 )
 
@@ -55,6 +56,7 @@ func (r *mutationResolver) UpdateStory(ctx context.Context, input gqlmodel.Updat
 		PublicImage:       input.PublicImage,
 		PublicNoIndex:     input.PublicNoIndex,
 		DeletePublicImage: input.DeletePublicImage,
+		ExpectedVersion:   input.ExpectedVersion,
 	}
 
 	res, err := usecases(ctx).StoryTelling.Update(ctx, inp, getOperator(ctx))
@@ -105,6 +107,83 @@ func (r *mutationResolver) PublishStory(ctx context.Context, input gqlmodel.Publ
 	return &gqlmodel.StoryPayload{Story: gqlmodel.ToStory(res)}, nil
 }
 
+func (r *mutationResolver) SchedulePublishStory(ctx context.Context, input gqlmodel.SchedulePublishStoryInput) (*gqlmodel.StoryPayload, error) {
+	sID, err := gqlmodel.ToID[id.Story](input.StoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var status storytelling.PublishmentStatus
+	if input.Status != nil {
+		status = gqlmodel.FromStoryPublishmentStatus(*input.Status)
+	}
+
+	res, err := usecases(ctx).StoryTelling.SchedulePublish(ctx, interfaces.SchedulePublishStoryInput{
+		ID:          sID,
+		Status:      status,
+		PublishAt:   input.PublishAt,
+		UnpublishAt: input.UnpublishAt,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.StoryPayload{Story: gqlmodel.ToStory(res)}, nil
+}
+
+func (r *mutationResolver) RollbackStory(ctx context.Context, input gqlmodel.RollbackStoryInput) (*gqlmodel.StoryPayload, error) {
+	sID, err := gqlmodel.ToID[id.Story](input.StoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).StoryTelling.Rollback(ctx, interfaces.RollbackStoryInput{
+		ID:       sID,
+		Revision: input.Revision,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.StoryPayload{Story: gqlmodel.ToStory(res)}, nil
+}
+
+func (r *mutationResolver) AddStoryBasicAuthCredential(ctx context.Context, input gqlmodel.AddStoryBasicAuthCredentialInput) (*gqlmodel.StoryPayload, error) {
+	sID, err := gqlmodel.ToID[id.Story](input.StoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).StoryTelling.AddBasicAuthCredential(ctx, interfaces.AddStoryBasicAuthCredentialInput{
+		ID:       sID,
+		Name:     input.Name,
+		Username: input.Username,
+		Password: input.Password,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.StoryPayload{Story: gqlmodel.ToStory(res)}, nil
+}
+
+func (r *mutationResolver) RevokeStoryBasicAuthCredential(ctx context.Context, input gqlmodel.RevokeStoryBasicAuthCredentialInput) (*gqlmodel.StoryPayload, error) {
+	sID, err := gqlmodel.ToID[id.Story](input.StoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).StoryTelling.RevokeBasicAuthCredential(ctx, interfaces.RevokeStoryBasicAuthCredentialInput{
+		ID:   sID,
+		Name: input.Name,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.StoryPayload{Story: gqlmodel.ToStory(res)}, nil
+}
+
 func (r *mutationResolver) MoveStory(ctx context.Context, input gqlmodel.MoveStoryInput) (*gqlmodel.MoveStoryPayload, error) {
 	scId, sId, err := gqlmodel.ToID2[id.Scene, id.Story](input.SceneID, input.StoryID)
 	if err != nil {
@@ -195,6 +274,7 @@ func (r *mutationResolver) UpdateStoryPage(ctx context.Context, input gqlmodel.U
 		Layers:          layersId,
 		SwipeableLayers: swipeableLayersIds,
 		Index:           input.Index,
+		ExpectedVersion: input.ExpectedVersion,
 	}
 
 	story, page, err := usecases(ctx).StoryTelling.UpdatePage(ctx, inp, getOperator(ctx))
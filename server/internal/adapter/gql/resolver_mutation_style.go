@@ -35,9 +35,10 @@ func (r *mutationResolver) UpdateStyle(ctx context.Context, input gqlmodel.Updat
 	}
 
 	s, err := usecases(ctx).Style.UpdateStyle(ctx, interfaces.UpdateStyleInput{
-		StyleID: sid,
-		Name:    input.Name,
-		Value:   gqlmodel.ToStyleValue(input.Value),
+		StyleID:         sid,
+		Name:            input.Name,
+		Value:           gqlmodel.ToStyleValue(input.Value),
+		ExpectedVersion: input.ExpectedVersion,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -85,3 +85,17 @@ func (r *mutationResolver) UpdateMemberOfTeam(ctx context.Context, input gqlmode
 
 	return &gqlmodel.UpdateMemberOfTeamPayload{Team: gqlmodel.ToWorkspace(res)}, nil
 }
+
+func (r *mutationResolver) SendWorkspaceUsageWarning(ctx context.Context, input gqlmodel.SendWorkspaceUsageWarningInput) (*gqlmodel.SendWorkspaceUsageWarningPayload, error) {
+	tid, err := gqlmodel.ToID[accountdomain.Workspace](input.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Policy.SendWorkspaceUsageWarning(ctx, tid, input.Threshold, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.SendWorkspaceUsageWarningPayload{TeamID: input.TeamID, Exceeded: gqlmodel.ToWorkspaceUsageItems(res)}, nil
+}
@@ -24,3 +24,20 @@ func (r *projectResolver) Scene(ctx context.Context, obj *gqlmodel.Project) (*gq
 	}
 	return s, nil
 }
+
+func (r *projectResolver) Revisions(ctx context.Context, obj *gqlmodel.Project) ([]*gqlmodel.PublishedRevision, error) {
+	return loaders(ctx).Project.FindRevisions(ctx, obj.ID)
+}
+
+func (r *Resolver) PublishedRevision() PublishedRevisionResolver {
+	return &publishedRevisionResolver{r}
+}
+
+type publishedRevisionResolver struct{ *Resolver }
+
+func (r *publishedRevisionResolver) PublishedBy(ctx context.Context, obj *gqlmodel.PublishedRevision) (*gqlmodel.User, error) {
+	if obj.PublishedByID == nil {
+		return nil, nil
+	}
+	return dataloaders(ctx).User.Load(*obj.PublishedByID)
+}
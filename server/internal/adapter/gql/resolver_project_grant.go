@@ -0,0 +1,17 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+)
+
+func (r *Resolver) ProjectGrant() ProjectGrantResolver {
+	return &projectGrantResolver{r}
+}
+
+type projectGrantResolver struct{ *Resolver }
+
+func (r *projectGrantResolver) User(ctx context.Context, obj *gqlmodel.ProjectGrant) (*gqlmodel.User, error) {
+	return dataloaders(ctx).User.Load(obj.UserID)
+}
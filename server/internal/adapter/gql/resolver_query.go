@@ -2,6 +2,7 @@ package gql
 
 import (
 	"context"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearthx/usecasex"
@@ -13,8 +14,24 @@ func (r *Resolver) Query() QueryResolver {
 
 type queryResolver struct{ *Resolver }
 
-func (r *queryResolver) Assets(ctx context.Context, teamID gqlmodel.ID, keyword *string, sortType *gqlmodel.AssetSortType, pagination *gqlmodel.Pagination) (*gqlmodel.AssetConnection, error) {
-	return loaders(ctx).Asset.FindByWorkspace(ctx, teamID, keyword, gqlmodel.AssetSortTypeFrom(sortType), pagination)
+func (r *queryResolver) AuditLogs(ctx context.Context, teamID gqlmodel.ID, sceneID *gqlmodel.ID, actorID *gqlmodel.ID, actions []string, targetID *gqlmodel.ID, since *time.Time, until *time.Time, pagination *gqlmodel.Pagination) (*gqlmodel.AuditLogConnection, error) {
+	return loaders(ctx).AuditLog.FindByWorkspace(ctx, teamID, sceneID, actorID, actions, targetID, since, until, pagination)
+}
+
+func (r *queryResolver) ProjectGrants(ctx context.Context, projectID gqlmodel.ID) ([]*gqlmodel.ProjectGrant, error) {
+	return loaders(ctx).ProjectGrant.FindByProject(ctx, projectID)
+}
+
+func (r *queryResolver) GrantedProjects(ctx context.Context) ([]*gqlmodel.Project, error) {
+	return loaders(ctx).Project.FindGranted(ctx)
+}
+
+func (r *queryResolver) SceneHistory(ctx context.Context, sceneID gqlmodel.ID) (*gqlmodel.SceneHistory, error) {
+	return loaders(ctx).History.Fetch(ctx, sceneID)
+}
+
+func (r *queryResolver) Assets(ctx context.Context, teamID gqlmodel.ID, keyword *string, contentTypes []string, sortType *gqlmodel.AssetSortType, pagination *gqlmodel.Pagination) (*gqlmodel.AssetConnection, error) {
+	return loaders(ctx).Asset.FindByWorkspace(ctx, teamID, keyword, contentTypes, gqlmodel.AssetSortTypeFrom(sortType), pagination)
 }
 
 func (r *queryResolver) Me(ctx context.Context) (*gqlmodel.Me, error) {
@@ -236,6 +253,14 @@ func (r *queryResolver) Scene(ctx context.Context, projectID gqlmodel.ID) (*gqlm
 	return loaders(ctx).Scene.FindByProject(ctx, projectID)
 }
 
+func (r *queryResolver) SceneUsage(ctx context.Context, sceneID gqlmodel.ID) (*gqlmodel.SceneUsage, error) {
+	return loaders(ctx).Policy.FetchSceneUsage(ctx, sceneID)
+}
+
+func (r *queryResolver) WorkspaceUsage(ctx context.Context, teamID gqlmodel.ID) (*gqlmodel.WorkspaceUsage, error) {
+	return loaders(ctx).Policy.FetchWorkspaceUsage(ctx, teamID)
+}
+
 func (r *queryResolver) Projects(ctx context.Context, teamID gqlmodel.ID, includeArchived *bool, first *int, last *int, after *usecasex.Cursor, before *usecasex.Cursor) (*gqlmodel.ProjectConnection, error) {
 	return loaders(ctx).Project.FindByWorkspace(ctx, teamID, first, last, before, after)
 }
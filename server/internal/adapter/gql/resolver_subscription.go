@@ -0,0 +1,17 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+)
+
+func (r *Resolver) Subscription() SubscriptionResolver {
+	return &subscriptionResolver{r}
+}
+
+type subscriptionResolver struct{ *Resolver }
+
+func (r *subscriptionResolver) SceneEvents(ctx context.Context, sceneID gqlmodel.ID) (<-chan *gqlmodel.SceneEvent, error) {
+	return loaders(ctx).Scene.SubscribeEvents(ctx, sceneID)
+}
@@ -25,7 +25,7 @@ func (r *teamResolver) Policy(ctx context.Context, obj *gqlmodel.Team) (*gqlmode
 }
 
 func (r *teamResolver) Assets(ctx context.Context, obj *gqlmodel.Team, first *int, last *int, after *usecasex.Cursor, before *usecasex.Cursor) (*gqlmodel.AssetConnection, error) {
-	return loaders(ctx).Asset.FindByWorkspace(ctx, obj.ID, nil, nil, &gqlmodel.Pagination{
+	return loaders(ctx).Asset.FindByWorkspace(ctx, obj.ID, nil, nil, nil, &gqlmodel.Pagination{
 		First:  first,
 		Last:   last,
 		After:  after,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/rerror"
 )
@@ -28,9 +29,26 @@ func ExportDataset() echo.HandlerFunc {
 			return rerror.ErrNotFound
 		}
 
+		var datasets []id.DatasetID
+		if q := c.QueryParam("datasets"); q != "" {
+			datasets, err = id.DatasetIDListFrom(strings.Split(q, ","))
+			if err != nil {
+				return echo.ErrBadRequest
+			}
+		}
+		var fields []string
+		if q := c.QueryParam("fields"); q != "" {
+			fields = strings.Split(q, ",")
+		}
+
 		res := c.Response()
 
-		if err := u.Dataset.Export(ctx, dsid, strings.TrimPrefix(ext, "."), res, func(name, contentType string) {
+		if err := u.Dataset.Export(ctx, interfaces.ExportDatasetParam{
+			SchemaID: dsid,
+			Format:   strings.TrimPrefix(ext, "."),
+			Fields:   fields,
+			Datasets: datasets,
+		}, res, func(name, contentType string) {
 			res.Header().Set(echo.HeaderContentType, contentType)
 			res.Header().Set("Content-Disposition", "attachment;filename="+name)
 			res.WriteHeader(http.StatusOK)
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// ExportPublishedProject responds a zip archive of the published scene of the project which can be viewed offline.
+func ExportPublishedProject() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		u := adapter.Usecases(ctx)
+
+		pid, err := id.ProjectIDFrom(c.Param("projectId"))
+		if err != nil {
+			return rerror.ErrNotFound
+		}
+
+		r, err := u.Project.ExportPublished(ctx, pid, adapter.Operator(ctx))
+		if err != nil {
+			return err
+		}
+
+		c.Response().Header().Set("Content-Disposition", "attachment;filename="+pid.String()+".zip")
+		return c.Stream(http.StatusOK, "application/zip", r)
+	}
+}
+
+// ExportProject responds a portable archive of the whole project, which can be imported with the importProject mutation.
+func ExportProject() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		u := adapter.Usecases(ctx)
+
+		pid, err := id.ProjectIDFrom(c.Param("projectId"))
+		if err != nil {
+			return rerror.ErrNotFound
+		}
+
+		r, err := u.Project.Export(ctx, pid, adapter.Operator(ctx))
+		if err != nil {
+			return err
+		}
+
+		c.Response().Header().Set("Content-Disposition", "attachment;filename="+pid.String()+".reearth.zip")
+		return c.Stream(http.StatusOK, "application/zip", r)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/scene/builder"
 )
 
 type PublishedController struct {
@@ -20,10 +22,26 @@ func (c *PublishedController) Metadata(ctx context.Context, name string) (interf
 	return c.usecase.Metadata(ctx, name)
 }
 
-func (c *PublishedController) Data(ctx context.Context, name string) (io.Reader, error) {
+func (c *PublishedController) Data(ctx context.Context, name string) (io.Reader, string, error) {
 	return c.usecase.Data(ctx, name)
 }
 
+func (c *PublishedController) Manifest(ctx context.Context, name string) (*builder.Manifest, error) {
+	return c.usecase.Manifest(ctx, name)
+}
+
+func (c *PublishedController) DataChunk(ctx context.Context, name, hash string) (io.Reader, string, error) {
+	return c.usecase.DataChunk(ctx, name, hash)
+}
+
+func (c *PublishedController) PreviewData(ctx context.Context, token string) (io.Reader, string, time.Time, error) {
+	return c.usecase.PreviewData(ctx, token)
+}
+
+func (c *PublishedController) PreviewIndex(ctx context.Context, token string, url *url.URL) (string, time.Time, error) {
+	return c.usecase.PreviewIndex(ctx, token, url)
+}
+
 func (c *PublishedController) Index(ctx context.Context, name string, url *url.URL) (string, error) {
 	return c.usecase.Index(ctx, name, url)
 }
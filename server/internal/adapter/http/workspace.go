@@ -0,0 +1,100 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+type WorkspaceUsageResponse struct {
+	WorkspaceID string                       `json:"workspaceId"`
+	PolicyID    *string                      `json:"policyId,omitempty"`
+	Items       []WorkspaceUsageItemResponse `json:"items"`
+}
+
+type WorkspaceUsageItemResponse struct {
+	Name       string   `json:"name"`
+	Used       int64    `json:"used"`
+	Limit      *int64   `json:"limit"`
+	Percentage *float64 `json:"percentage"`
+}
+
+type WorkspaceUsageWarningResponse struct {
+	WorkspaceID string                       `json:"workspaceId"`
+	Exceeded    []WorkspaceUsageItemResponse `json:"exceeded"`
+}
+
+// WorkspaceUsage responds the current usage of the workspace with the limits of its policy.
+func WorkspaceUsage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		u := adapter.Usecases(ctx)
+
+		wid, err := accountdomain.WorkspaceIDFrom(c.Param("workspaceId"))
+		if err != nil {
+			return rerror.ErrNotFound
+		}
+
+		res, err := u.Policy.FetchWorkspaceUsage(ctx, wid, adapter.Operator(ctx))
+		if err != nil {
+			return err
+		}
+
+		r := WorkspaceUsageResponse{
+			WorkspaceID: res.WorkspaceID.String(),
+			Items:       toWorkspaceUsageItemResponses(res.Items),
+		}
+		if res.Policy != nil {
+			r.PolicyID = util.ToPtrIfNotEmpty(res.Policy.ID().String())
+		}
+		return c.JSON(http.StatusOK, r)
+	}
+}
+
+// SendWorkspaceUsageWarning sends a warning email to the owners of the workspace
+// when any usage reaches the threshold given as a percentage of the limit.
+func SendWorkspaceUsageWarning() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		u := adapter.Usecases(ctx)
+
+		wid, err := accountdomain.WorkspaceIDFrom(c.Param("workspaceId"))
+		if err != nil {
+			return rerror.ErrNotFound
+		}
+
+		threshold, err := strconv.ParseFloat(c.QueryParam("threshold"), 64)
+		if err != nil {
+			return echo.ErrBadRequest
+		}
+
+		res, err := u.Policy.SendWorkspaceUsageWarning(ctx, wid, threshold, adapter.Operator(ctx))
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, WorkspaceUsageWarningResponse{
+			WorkspaceID: wid.String(),
+			Exceeded:    toWorkspaceUsageItemResponses(res),
+		})
+	}
+}
+
+func toWorkspaceUsageItemResponses(items []policy.UsageItem) []WorkspaceUsageItemResponse {
+	res := make([]WorkspaceUsageItemResponse, 0, len(items))
+	for _, i := range items {
+		res = append(res, WorkspaceUsageItemResponse{
+			Name:       i.Name,
+			Used:       i.Used,
+			Limit:      i.Limit,
+			Percentage: i.Percentage(),
+		})
+	}
+	return res
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/reearth/reearth/server/internal/adapter"
 	http2 "github.com/reearth/reearth/server/internal/adapter/http"
+	"github.com/reearth/reearth/server/internal/app/config"
 	"github.com/reearth/reearth/server/internal/usecase/interactor"
 	"github.com/reearth/reearthx/appx"
 	"github.com/reearth/reearthx/log"
@@ -35,12 +36,17 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 	// basic middleware
 	logger := log.NewEcho()
 	e.Logger = logger
+	// this runs before the access logger so that access tokens of WebSocket requests are not logged
+	e.Pre(websocketAuthMiddleware())
 	e.Use(
 		middleware.Recover(),
 		otelecho.Middleware("reearth"),
 		echo.WrapMiddleware(appx.RequestIDMiddleware()),
 		logger.AccessLogger(),
-		middleware.Gzip(),
+		middleware.GzipWithConfig(middleware.GzipConfig{
+			// responses of WebSocket connections must not be compressed
+			Skipper: isWebsocketRequest,
+		}),
 	)
 	if cfg.Config.HTTPSREDIRECT {
 		e.Use(middleware.HTTPSRedirectWithConfig(middleware.RedirectConfig{
@@ -84,21 +90,13 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 	}
 
 	// init usecases
-	var publishedIndexHTML string
-	if cfg.Config.Published.IndexURL == nil || cfg.Config.Published.IndexURL.String() == "" {
-		if html, err := fs.ReadFile(os.DirFS("."), "web/published.html"); err == nil {
-			favicon := ""
-			if cfg.Config.Web_FaviconURL != "" && !cfg.Config.Web_Disabled {
-				favicon = "/favicon.ico"
-			}
-			publishedIndexHTML = rewriteHTML(string(html), cfg.Config.Web_Title, favicon)
-		}
-	}
-
 	e.Use(UsecaseMiddleware(cfg.Repos, cfg.Gateways, cfg.AccountRepos, cfg.AccountGateways, interactor.ContainerConfig{
 		SignupSecret:       cfg.Config.SignupSecret,
-		PublishedIndexHTML: publishedIndexHTML,
+		PublishedIndexHTML: publishedIndexHTML(cfg.Config),
 		PublishedIndexURL:  cfg.Config.Published.IndexURL,
+		PublishedViewerFS:  publishedViewerFS(cfg.Config),
+		PreviewURL:         cfg.Config.PreviewURL(),
+		AssetBaseURL:       cfg.Config.AssetBaseURL,
 		AuthSrvUIDomain:    cfg.Config.Host_Web,
 	}))
 
@@ -110,11 +108,26 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 	api.GET("/ping", Ping(), privateCache)
 	api.GET("/published/:name", PublishedMetadata())
 	api.GET("/published_data/:name", PublishedData("", true))
+	api.GET("/published_data/:name/manifest", PublishedManifest())
+	api.GET("/published_data/:name/chunks/:hash", PublishedDataChunk())
 
 	apiPrivate := api.Group("", privateCache)
-	apiPrivate.POST("/graphql", GraphqlAPI(cfg.Config.GraphQL, gqldev))
+	gqlHandler := GraphqlAPI(cfg.Config.GraphQL, origins, gqldev)
+	apiPrivate.POST("/graphql", gqlHandler)
+	// subscriptions over WebSocket
+	apiPrivate.GET("/graphql", func(c echo.Context) error {
+		if !isWebsocketRequest(c) {
+			return echo.ErrMethodNotAllowed
+		}
+		return gqlHandler(c)
+	})
 	apiPrivate.GET("/layers/:param", ExportLayer(), AuthRequiredMiddleware())
+	apiPrivate.GET("/nlslayers/:param", ExportNLSLayer(), AuthRequiredMiddleware())
 	apiPrivate.GET("/datasets/:datasetSchemaId", http2.ExportDataset(), AuthRequiredMiddleware())
+	apiPrivate.GET("/projects/:projectId/export", http2.ExportPublishedProject(), AuthRequiredMiddleware())
+	apiPrivate.GET("/projects/:projectId/archive", http2.ExportProject(), AuthRequiredMiddleware())
+	apiPrivate.GET("/workspaces/:workspaceId/usage", http2.WorkspaceUsage(), AuthRequiredMiddleware())
+	apiPrivate.POST("/workspaces/:workspaceId/usage/warning", http2.SendWorkspaceUsageWarning(), AuthRequiredMiddleware())
 	apiPrivate.POST("/signup", Signup())
 
 	if !cfg.Config.AuthSrv.Disabled {
@@ -125,8 +138,14 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 
 	published := e.Group("/p", PublishedAuthMiddleware())
 	published.GET("/:name/data.json", PublishedData("", true))
+	published.GET("/:name/manifest.json", PublishedManifest())
+	published.GET("/:name/chunks/:hash", PublishedDataChunk())
 	published.GET("/:name/", PublishedIndex("", true))
 
+	preview := e.Group("/preview")
+	preview.GET("/:token/data.json", PreviewData())
+	preview.GET("/:token/", PreviewIndex())
+
 	serveFiles(e, cfg.Gateways.File)
 	(&WebHandler{
 		Disabled:    cfg.Config.Web_Disabled,
@@ -142,6 +161,30 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 	return e
 }
 
+// publishedIndexHTML returns index HTML of published pages read from the web directory when no index URL is configured.
+func publishedIndexHTML(conf *config.Config) string {
+	if conf.Published.IndexURL != nil && conf.Published.IndexURL.String() != "" {
+		return ""
+	}
+	html, err := fs.ReadFile(os.DirFS("."), "web/published.html")
+	if err != nil {
+		return ""
+	}
+	favicon := ""
+	if conf.Web_FaviconURL != "" && !conf.Web_Disabled {
+		favicon = "/favicon.ico"
+	}
+	return rewriteHTML(string(html), conf.Web_Title, favicon)
+}
+
+// publishedViewerFS returns files of the viewer served with web/published.html, which are bundled into exported scenes.
+func publishedViewerFS(conf *config.Config) fs.FS {
+	if conf.Published.IndexURL != nil && conf.Published.IndexURL.String() != "" {
+		return nil
+	}
+	return os.DirFS("web")
+}
+
 func errorHandler(next func(error, echo.Context)) func(error, echo.Context) {
 	return func(err error, c echo.Context) {
 		if c.Response().Committed {
@@ -6,6 +6,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth/server/internal/adapter"
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/projectgrant"
 	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/account/accountdomain/user"
 	"github.com/reearth/reearthx/account/accountdomain/workspace"
@@ -117,6 +118,10 @@ func generateOperator(ctx context.Context, cfg *ServerConfig, u *user.User) (*us
 	if err != nil {
 		return nil, err
 	}
+	grants, err := cfg.Repos.ProjectGrant.FindByUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
 
 	readableWorkspaces := workspaces.FilterByUserRole(uid, workspace.RoleReader).IDs()
 	writableWorkspaces := workspaces.FilterByUserRole(uid, workspace.RoleWriter).IDs()
@@ -133,10 +138,11 @@ func generateOperator(ctx context.Context, cfg *ServerConfig, u *user.User) (*us
 			OwningWorkspaces:       owningWorkspaces,
 			DefaultPolicy:          defaultPolicy,
 		},
-		ReadableScenes:    scenes.FilterByWorkspace(readableWorkspaces...).IDs(),
-		WritableScenes:    scenes.FilterByWorkspace(writableWorkspaces...).IDs(),
+		ReadableScenes:    append(scenes.FilterByWorkspace(readableWorkspaces...).IDs(), grants.Scenes(projectgrant.RoleViewer, projectgrant.RoleCommenter)...),
+		WritableScenes:    append(scenes.FilterByWorkspace(writableWorkspaces...).IDs(), grants.Scenes(projectgrant.RoleEditor)...),
 		MaintainingScenes: scenes.FilterByWorkspace(maintainingWorkspaces...).IDs(),
 		OwningScenes:      scenes.FilterByWorkspace(owningWorkspaces...).IDs(),
+		GrantedProjects:   grants.Projects(),
 		DefaultPolicy:     defaultPolicy,
 	}, nil
 }
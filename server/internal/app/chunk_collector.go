@@ -0,0 +1,19 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interactor"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+)
+
+const chunkCollectLockName = "chunk-collect"
+
+// runChunkCollector periodically removes chunks of built scenes which are referred by no built scenes, revisions and previews.
+func runChunkCollector(ctx context.Context, interval time.Duration, repos *repo.Container, gateways *gateway.Container) {
+	runLocked(ctx, repos.Lock, chunkCollectLockName, interval, func(ctx context.Context, now time.Time) error {
+		return interactor.NewProject(repos, gateways).RemoveUnusedChunks(ctx, now)
+	})
+}
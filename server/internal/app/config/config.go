@@ -41,6 +41,7 @@ type Config struct {
 	AssetBaseURL     string            `default:"http://localhost:8080/assets"`
 	Origins          []string          `pp:",omitempty"`
 	Policy           PolicyConfig      `pp:",omitempty"`
+	DataSource       DataSourceConfig  `pp:",omitempty"`
 	Web_Disabled     bool              `pp:",omitempty"`
 	Web_App_Disabled bool              `pp:",omitempty"`
 	Web              map[string]string `pp:",omitempty"`
@@ -140,6 +141,15 @@ func (c *Config) HostURL() *url.URL {
 	return u
 }
 
+// PreviewURL returns the base URL of preview builds of projects.
+func (c *Config) PreviewURL() *url.URL {
+	u := c.HostURL()
+	if u == nil {
+		return nil
+	}
+	return u.JoinPath("preview")
+}
+
 func (c *Config) HostWebURL() *url.URL {
 	u, err := url.Parse(c.Host_Web)
 	if err != nil {
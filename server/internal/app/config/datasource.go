@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type DataSourceConfig struct {
+	// AllowedHosts are hosts which datasets can be synced from, such as "example.com" or "*.example.com".
+	// Syncing datasets from URLs is disabled if it is empty.
+	AllowedHosts []string `pp:",omitempty"`
+	// RefreshInterval is the interval to check refreshes of dataset schemas. 0 disables the periodic refresh.
+	RefreshInterval time.Duration `default:"1m" pp:",omitempty"`
+}
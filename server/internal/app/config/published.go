@@ -1,8 +1,15 @@
 package config
 
-import "net/url"
+import (
+	"net/url"
+	"time"
+)
 
 type PublishedConfig struct {
 	IndexURL *url.URL `pp:",omitempty"`
 	Host     string   `pp:",omitempty"`
+	// ScheduleInterval is the interval to check publish schedules of projects and stories. 0 disables the scheduler.
+	ScheduleInterval time.Duration `default:"1m" pp:",omitempty"`
+	// ChunkGCInterval is the interval to remove chunks of built scenes which are no longer used. 0 disables the collector.
+	ChunkGCInterval time.Duration `default:"24h" pp:",omitempty"`
 }
@@ -0,0 +1,19 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interactor"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+)
+
+const datasetRefreshLockName = "dataset-refresh"
+
+// runDatasetRefresher periodically syncs dataset schemas from their URLs according to their refresh intervals.
+func runDatasetRefresher(ctx context.Context, interval time.Duration, repos *repo.Container, gateways *gateway.Container) {
+	runLocked(ctx, repos.Lock, datasetRefreshLockName, interval, func(ctx context.Context, now time.Time) error {
+		return interactor.NewDataset(repos, gateways).RefreshScheduled(ctx, now)
+	})
+}
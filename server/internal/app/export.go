@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/reearth/reearth/server/internal/app/config"
+	"github.com/reearth/reearth/server/internal/usecase/interactor"
+	"github.com/reearth/reearthx/log"
+)
+
+// Export runs the export command, which writes the published scene of the project with the alias
+// into a zip archive so that it can be carried to networks without internet access.
+//
+//	reearth export -alias <alias> [-o <file>]
+func Export(debug bool, args []string) {
+	fset := flag.NewFlagSet("export", flag.ExitOnError)
+	alias := fset.String("alias", "", "alias of the published project")
+	output := fset.String("o", "", "path of the zip archive to write (default: <alias>.zip)")
+	_ = fset.Parse(args)
+
+	if *alias == "" {
+		fset.Usage()
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = *alias + ".zip"
+	}
+
+	ctx := context.Background()
+
+	conf, cerr := config.ReadConfig(debug)
+	if cerr != nil {
+		log.Fatalf("failed to load config: %v", cerr)
+	}
+
+	repos, gateways, _, _ := initReposAndGateways(ctx, conf, debug)
+	prj := interactor.NewProjectWithConfig(repos, gateways, interactor.ProjectConfig{
+		AssetBaseURL:       conf.AssetBaseURL,
+		PublishedIndexHTML: publishedIndexHTML(conf),
+		PublishedIndexURL:  conf.Published.IndexURL,
+		PublishedViewerFS:  publishedViewerFS(conf),
+	})
+
+	r, err := prj.ExportPublishedByAlias(ctx, *alias)
+	if err != nil {
+		log.Fatalf("export: %v", err)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		log.Fatalf("export: %v", err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		_ = os.Remove(*output)
+		log.Fatalf("export: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("export: %v", err)
+	}
+	log.Infof("export: wrote %s", *output)
+}
@@ -2,6 +2,10 @@ package app
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -9,11 +13,13 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 	"github.com/ravilushqa/otelgqlgen"
 	"github.com/reearth/reearth/server/internal/adapter"
 	"github.com/reearth/reearth/server/internal/adapter/gql"
 	"github.com/reearth/reearth/server/internal/app/config"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
@@ -21,9 +27,11 @@ const (
 	enableDataLoaders = true
 	maxUploadSize     = 10 * 1024 * 1024 * 1024 // 10GB
 	maxMemorySize     = 100 * 1024 * 1024       // 100MB
+	// browsers cannot set the Authorization header on WebSocket connections, so the token is sent as a query param
+	websocketAccessTokenParam = "access_token"
 )
 
-func GraphqlAPI(conf config.GraphQLConfig, dev bool) echo.HandlerFunc {
+func GraphqlAPI(conf config.GraphQLConfig, origins []string, dev bool) echo.HandlerFunc {
 	schema := gql.NewExecutableSchema(gql.Config{
 		Resolvers: gql.NewResolver(),
 	})
@@ -32,6 +40,9 @@ func GraphqlAPI(conf config.GraphQLConfig, dev bool) echo.HandlerFunc {
 
 	srv.AddTransport(transport.Websocket{
 		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: checkWebsocketOrigin(origins),
+		},
 	})
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
@@ -65,10 +76,22 @@ func GraphqlAPI(conf config.GraphQLConfig, dev bool) echo.HandlerFunc {
 	srv.SetErrorPresenter(
 		// show more detailed error messgage in debug mode
 		func(ctx context.Context, e error) *gqlerror.Error {
+			var ge *gqlerror.Error
 			if dev {
-				return gqlerror.ErrorPathf(graphql.GetFieldContext(ctx).Path(), "%s", e.Error())
+				ge = gqlerror.ErrorPathf(graphql.GetFieldContext(ctx).Path(), "%s", e.Error())
+			} else {
+				ge = graphql.DefaultErrorPresenter(ctx, e)
+			}
+			// clients reload the object and retry their edits on version conflicts
+			var vce *repo.VersionConflictError
+			if errors.As(e, &vce) {
+				ge.Extensions = map[string]any{
+					"code":    "VERSION_CONFLICT",
+					"id":      vce.ID,
+					"version": vce.Version,
+				}
 			}
-			return graphql.DefaultErrorPresenter(ctx, e)
+			return ge
 		},
 	)
 
@@ -84,3 +107,39 @@ func GraphqlAPI(conf config.GraphQLConfig, dev bool) echo.HandlerFunc {
 		return nil
 	}
 }
+
+// checkWebsocketOrigin allows the same origins as CORS, or any origins if they are not configured.
+func checkWebsocketOrigin(origins []string) func(*http.Request) bool {
+	return func(r *http.Request) bool {
+		if len(origins) == 0 || slices.Contains(origins, "*") {
+			return true
+		}
+		o := r.Header.Get("Origin")
+		return o == "" || slices.Contains(origins, o)
+	}
+}
+
+// websocketAuthMiddleware moves the access token of a WebSocket request from the query to the Authorization header,
+// so that the request is authenticated in the same way as the others. It has to be registered with Echo.Pre,
+// as the token is removed from the request URI which is logged by the access logger.
+func websocketAuthMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if isWebsocketRequest(c) && req.Header.Get(echo.HeaderAuthorization) == "" {
+				q := req.URL.Query()
+				if token := q.Get(websocketAccessTokenParam); token != "" {
+					req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+					q.Del(websocketAccessTokenParam)
+					req.URL.RawQuery = q.Encode()
+					req.RequestURI = req.URL.RequestURI()
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+func isWebsocketRequest(c echo.Context) bool {
+	return strings.EqualFold(c.Request().Header.Get(echo.HeaderUpgrade), "websocket")
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebsocketAuthMiddleware(t *testing.T) {
+	e := echo.New()
+	var uri, auth string
+	e.Pre(websocketAuthMiddleware())
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		// runs where the access logger runs
+		return func(c echo.Context) error {
+			uri = c.Request().RequestURI
+			auth = c.Request().Header.Get(echo.HeaderAuthorization)
+			return next(c)
+		}
+	})
+	e.GET("/api/graphql", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/graphql?access_token=token&a=b", nil)
+	req.Header.Set(echo.HeaderUpgrade, "websocket")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "/api/graphql?a=b", uri)
+	assert.Equal(t, "Bearer token", auth)
+
+	// the token is not moved from requests other than WebSocket ones
+	req = httptest.NewRequest(http.MethodGet, "/api/graphql?access_token=token", nil)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "/api/graphql?access_token=token", uri)
+	assert.Empty(t, auth)
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearthx/log"
+)
+
+// runLocked runs fn at every interval until ctx is done, and is disabled if interval is not positive.
+// Only the instance that takes the lock with the name runs fn, so it is safe to run on every server instance.
+func runLocked(ctx context.Context, lock repo.Lock, name string, interval time.Duration, fn func(context.Context, time.Time) error) {
+	if interval <= 0 {
+		log.Infof("%s: disabled", name)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := runWithLock(ctx, lock, name, now, fn); err != nil {
+				log.Errorfc(ctx, "%s: %s", name, err)
+			}
+		}
+	}
+}
+
+func runWithLock(ctx context.Context, lock repo.Lock, name string, now time.Time, fn func(context.Context, time.Time) error) error {
+	if err := lock.Lock(ctx, name); err != nil {
+		if errors.Is(err, repo.ErrFailedToLock) || errors.Is(err, repo.ErrAlreadyLocked) {
+			// another instance is running the job
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		if err := lock.Unlock(ctx, name); err != nil {
+			log.Errorfc(ctx, "%s: failed to unlock: %s", name, err)
+		}
+	}()
+
+	return fn(ctx, now)
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLock struct {
+	err      error
+	unlocked []string
+}
+
+func (l *testLock) Lock(_ context.Context, _ string) error {
+	return l.err
+}
+
+func (l *testLock) Unlock(_ context.Context, name string) error {
+	l.unlocked = append(l.unlocked, name)
+	return nil
+}
+
+func TestRunWithLock(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	errJob := errors.New("job")
+
+	var ran []time.Time
+	job := func(_ context.Context, t time.Time) error {
+		ran = append(ran, t)
+		return errJob
+	}
+
+	// the job runs while the lock is held
+	l := &testLock{}
+	assert.Same(t, errJob, runWithLock(ctx, l, "job", now, job))
+	assert.Equal(t, []time.Time{now}, ran)
+	assert.Equal(t, []string{"job"}, l.unlocked)
+
+	// another instance is running the job
+	ran = nil
+	l = &testLock{err: repo.ErrAlreadyLocked}
+	assert.NoError(t, runWithLock(ctx, l, "job", now, job))
+	assert.Nil(t, ran)
+	assert.Nil(t, l.unlocked)
+
+	// other errors of the lock are returned
+	errLock := errors.New("lock")
+	l = &testLock{err: errLock}
+	assert.Same(t, errLock, runWithLock(ctx, l, "job", now, job))
+	assert.Nil(t, ran)
+}
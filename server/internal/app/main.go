@@ -45,6 +45,15 @@ func Start(debug bool, version string) {
 	// Init repositories
 	repos, gateways, acRepos, acGateways := initReposAndGateways(ctx, conf, debug)
 
+	// Start publish scheduler
+	go runPublishScheduler(ctx, conf.Published.ScheduleInterval, repos, gateways)
+
+	// Start chunk collector
+	go runChunkCollector(ctx, conf.Published.ChunkGCInterval, repos, gateways)
+
+	// Start dataset refresher
+	go runDatasetRefresher(ctx, conf.DataSource.RefreshInterval, repos, gateways)
+
 	// Start web server
 	NewServer(ctx, &ServerConfig{
 		Config:          conf,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/rerror"
 )
@@ -26,7 +27,12 @@ func ExportLayer() echo.HandlerFunc {
 			return rerror.ErrNotFound
 		}
 
-		reader, mime, err := u.Layer.Export(ctx, lid, params[1])
+		target, err := exportCRS(c)
+		if err != nil {
+			return err
+		}
+
+		reader, mime, err := u.Layer.Export(ctx, lid, params[1], target)
 		if err != nil {
 			return err
 		}
@@ -34,3 +40,42 @@ func ExportLayer() echo.HandlerFunc {
 		return c.Stream(http.StatusOK, mime, reader)
 	}
 }
+
+func ExportNLSLayer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		u := adapter.Usecases(ctx)
+
+		param := c.Param("param")
+		params := strings.Split(param, ".")
+		if len(params) != 2 {
+			return rerror.ErrNotFound
+		}
+
+		lid, err := id.NLSLayerIDFrom(params[0])
+		if err != nil {
+			return rerror.ErrNotFound
+		}
+
+		target, err := exportCRS(c)
+		if err != nil {
+			return err
+		}
+
+		reader, mime, err := u.NLSLayer.Export(ctx, lid, params[1], target, adapter.Operator(ctx))
+		if err != nil {
+			return err
+		}
+
+		return c.Stream(http.StatusOK, mime, reader)
+	}
+}
+
+// exportCRS returns the CRS specified by the "crs" query parameter such as EPSG:6677. It returns nil if it is not specified.
+func exportCRS(c echo.Context) (*crs.CRS, error) {
+	q := c.QueryParam("crs")
+	if q == "" {
+		return nil, nil
+	}
+	return crs.Parse(q)
+}
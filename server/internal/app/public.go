@@ -2,12 +2,13 @@ package app
 
 import (
 	"context"
-	"crypto/subtle"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -144,15 +145,84 @@ func PublishedData(pattern string, useParam bool) echo.HandlerFunc {
 			return err
 		}
 
-		r, err := contr.Data(c.Request().Context(), alias)
+		r, etag, err := contr.Data(c.Request().Context(), alias)
 		if err != nil {
 			return err
 		}
 
-		return c.Stream(http.StatusOK, "application/json", r)
+		return streamWithETag(c, "application/json", r, etag)
 	}
 }
 
+func PublishedManifest() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		name := c.Param("name")
+		if name == "" {
+			return rerror.ErrNotFound
+		}
+
+		contr, err := publishedController(c)
+		if err != nil {
+			return err
+		}
+
+		m, err := contr.Manifest(c.Request().Context(), name)
+		if err != nil {
+			return err
+		}
+
+		if notModified(c, m.ETag()) {
+			return c.NoContent(http.StatusNotModified)
+		}
+		return c.JSON(http.StatusOK, m)
+	}
+}
+
+func PublishedDataChunk() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		name, hash := c.Param("name"), c.Param("hash")
+		if name == "" || hash == "" {
+			return rerror.ErrNotFound
+		}
+
+		contr, err := publishedController(c)
+		if err != nil {
+			return err
+		}
+
+		r, etag, err := contr.DataChunk(c.Request().Context(), name, hash)
+		if err != nil {
+			return err
+		}
+
+		return streamWithETag(c, "application/json", r, etag)
+	}
+}
+
+// streamWithETag streams r with a strong ETag, or responds 304 Not Modified when the client already has it.
+func streamWithETag(c echo.Context, contentType string, r io.Reader, etag string) error {
+	if rc, ok := r.(io.Closer); ok {
+		defer func() {
+			_ = rc.Close()
+		}()
+	}
+	if etag != "" && notModified(c, etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Stream(http.StatusOK, contentType, r)
+}
+
+// notModified sets the ETag header and reports whether it matches If-None-Match of the request.
+func notModified(c echo.Context, etag string) bool {
+	c.Response().Header().Set("ETag", etag)
+	for _, t := range strings.Split(c.Request().Header.Get("If-None-Match"), ",") {
+		if t = strings.TrimSpace(t); t == etag || t == "*" {
+			return true
+		}
+	}
+	return false
+}
+
 func PublishedIndex(pattern string, useParam bool) echo.HandlerFunc {
 	return PublishedIndexMiddleware(pattern, useParam, true)(nil)
 }
@@ -192,6 +262,65 @@ func PublishedIndexMiddleware(pattern string, useParam, errorIfNotFound bool) ec
 	}
 }
 
+func PreviewData() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Param("token")
+		if token == "" {
+			return rerror.ErrNotFound
+		}
+
+		contr, err := publishedController(c)
+		if err != nil {
+			return err
+		}
+
+		r, etag, expiresAt, err := contr.PreviewData(c.Request().Context(), token)
+		if err != nil {
+			return err
+		}
+
+		setPreviewHeaders(c, expiresAt)
+		return streamWithETag(c, "application/json", r, etag)
+	}
+}
+
+func PreviewIndex() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Param("token")
+		if token == "" {
+			return rerror.ErrNotFound
+		}
+
+		contr, err := publishedController(c)
+		if err != nil {
+			return err
+		}
+
+		index, expiresAt, err := contr.PreviewIndex(c.Request().Context(), token, &url.URL{
+			Scheme: "http",
+			Host:   c.Request().Host,
+			Path:   c.Request().URL.Path,
+		})
+		if err != nil {
+			return err
+		}
+		if index == "" {
+			return rerror.ErrNotFound
+		}
+
+		setPreviewHeaders(c, expiresAt)
+		return c.HTML(http.StatusOK, index)
+	}
+}
+
+// setPreviewHeaders prevents previews from being cached or indexed after they expire.
+func setPreviewHeaders(c echo.Context, expiresAt time.Time) {
+	h := c.Response().Header()
+	h.Set(echo.HeaderCacheControl, "private, no-store")
+	h.Set("Expires", expiresAt.UTC().Format(http.TimeFormat))
+	h.Set("X-Robots-Tag", "noindex, nofollow")
+}
+
 func PublishedAuthMiddleware() echo.MiddlewareFunc {
 	key := struct{}{}
 	return middleware.BasicAuthWithConfig(middleware.BasicAuthConfig{
@@ -200,7 +329,7 @@ func PublishedAuthMiddleware() echo.MiddlewareFunc {
 			if !ok {
 				return true, echo.ErrNotFound
 			}
-			return !md.IsBasicAuthActive || subtle.ConstantTimeCompare([]byte(user), []byte(md.BasicAuthUsername)) == 1 && subtle.ConstantTimeCompare([]byte(password), []byte(md.BasicAuthPassword)) == 1, nil
+			return !md.IsBasicAuthActive || md.MatchBasicAuth(user, password), nil
 		},
 		Skipper: func(c echo.Context) bool {
 			name := c.Param("name")
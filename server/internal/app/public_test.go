@@ -9,11 +9,14 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth/server/internal/adapter"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -54,6 +57,19 @@ func TestPublishedAuthMiddleware(t *testing.T) {
 			BasicAuthUsername: "fooo",
 			BasicAuthPassword: "baar",
 		},
+		{
+			Name:              "auth with another named credentials",
+			PublishedName:     "active",
+			BasicAuthUsername: "hoge",
+			BasicAuthPassword: "piyo",
+		},
+		{
+			Name:              "auth with revoked credentials",
+			PublishedName:     "active",
+			BasicAuthUsername: "revoked",
+			BasicAuthPassword: "revoked",
+			Error:             echo.ErrUnauthorized,
+		},
 	}
 
 	for _, tc := range tests {
@@ -91,6 +107,7 @@ func TestPublishedData(t *testing.T) {
 	tests := []struct {
 		Name          string
 		PublishedName string
+		IfNoneMatch   string
 		Error         error
 	}{
 		{
@@ -106,6 +123,11 @@ func TestPublishedData(t *testing.T) {
 			Name:          "ok",
 			PublishedName: "prj",
 		},
+		{
+			Name:          "not modified",
+			PublishedName: "prj",
+			IfNoneMatch:   `"etag"`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -115,6 +137,7 @@ func TestPublishedData(t *testing.T) {
 
 			assert := assert.New(t)
 			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("If-None-Match", tc.IfNoneMatch)
 			res := httptest.NewRecorder()
 			e := echo.New()
 			c := e.NewContext(req, res)
@@ -123,10 +146,15 @@ func TestPublishedData(t *testing.T) {
 			m := mockPublishedUsecaseMiddleware(false)
 			err := m(PublishedData("", true))(c)
 
-			if tc.Error == nil {
+			if tc.IfNoneMatch != "" {
+				assert.NoError(err)
+				assert.Equal(http.StatusNotModified, res.Code)
+				assert.Empty(res.Body.String())
+			} else if tc.Error == nil {
 				assert.NoError(err)
 				assert.Equal(http.StatusOK, res.Code)
 				assert.Equal("application/json", res.Header().Get(echo.HeaderContentType))
+				assert.Equal(`"etag"`, res.Header().Get("ETag"))
 				assert.Equal("aaa", res.Body.String())
 			} else {
 				assert.ErrorIs(err, tc.Error)
@@ -190,6 +218,56 @@ func TestPublishedIndex(t *testing.T) {
 	}
 }
 
+func TestPreviewData(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Token string
+		Error error
+	}{
+		{
+			Name:  "empty",
+			Error: rerror.ErrNotFound,
+		},
+		{
+			Name:  "expired",
+			Token: "expired",
+			Error: rerror.ErrNotFound,
+		},
+		{
+			Name:  "ok",
+			Token: "token",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := assert.New(t)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			res := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, res)
+			c.SetParamNames("token")
+			c.SetParamValues(tc.Token)
+			m := mockPublishedUsecaseMiddleware(false)
+			err := m(PreviewData())(c)
+
+			if tc.Error == nil {
+				assert.NoError(err)
+				assert.Equal(http.StatusOK, res.Code)
+				assert.Equal("aaa", res.Body.String())
+				assert.Equal("private, no-store", res.Header().Get(echo.HeaderCacheControl))
+				assert.Equal("Mon, 01 Jan 2024 00:00:00 GMT", res.Header().Get("Expires"))
+				assert.Equal("noindex, nofollow", res.Header().Get("X-Robots-Tag"))
+			} else {
+				assert.ErrorIs(err, tc.Error)
+			}
+		})
+	}
+}
+
 func mockPublishedUsecaseMiddleware(emptyIndex bool) echo.MiddlewareFunc {
 	return ContextMiddleware(func(ctx context.Context) context.Context {
 		return adapter.AttachUsecases(ctx, &interfaces.Container{
@@ -203,28 +281,32 @@ type mockPublished struct {
 	EmptyIndex bool
 }
 
+var mockBasicAuthCredentials = lo.Must(basicauth.List{
+	lo.Must(basicauth.NewCredential(basicauth.DefaultName, "fooo", "baar")),
+	lo.Must(basicauth.NewCredential("client", "hoge", "piyo")),
+	lo.Must(basicauth.NewCredential("revoked", "revoked", "revoked")),
+}.Revoke("revoked"))
+
 func (p *mockPublished) Metadata(ctx context.Context, name string) (interfaces.ProjectPublishedMetadata, error) {
 	if name == "active" {
 		return interfaces.ProjectPublishedMetadata{
-			IsBasicAuthActive: true,
-			BasicAuthUsername: "fooo",
-			BasicAuthPassword: "baar",
+			IsBasicAuthActive:    true,
+			BasicAuthCredentials: mockBasicAuthCredentials,
 		}, nil
 	} else if name == "inactive" {
 		return interfaces.ProjectPublishedMetadata{
-			IsBasicAuthActive: false,
-			BasicAuthUsername: "fooo",
-			BasicAuthPassword: "baar",
+			IsBasicAuthActive:    false,
+			BasicAuthCredentials: mockBasicAuthCredentials,
 		}, nil
 	}
 	return interfaces.ProjectPublishedMetadata{}, rerror.ErrNotFound
 }
 
-func (p *mockPublished) Data(ctx context.Context, name string) (io.Reader, error) {
+func (p *mockPublished) Data(ctx context.Context, name string) (io.Reader, string, error) {
 	if name == "prj" {
-		return strings.NewReader("aaa"), nil
+		return strings.NewReader("aaa"), `"etag"`, nil
 	}
-	return nil, rerror.ErrNotFound
+	return nil, "", rerror.ErrNotFound
 }
 
 func (p *mockPublished) Index(ctx context.Context, name string, url *url.URL) (string, error) {
@@ -237,6 +319,13 @@ func (p *mockPublished) Index(ctx context.Context, name string, url *url.URL) (s
 	return "", rerror.ErrNotFound
 }
 
+func (p *mockPublished) PreviewData(ctx context.Context, token string) (io.Reader, string, time.Time, error) {
+	if token == "token" {
+		return strings.NewReader("aaa"), "", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return nil, "", time.Time{}, rerror.ErrNotFound
+}
+
 func TestGetAliasFromHost(t *testing.T) {
 	assert.Equal(t, "", getAliasFromHost("", ".example.com")) // invalid regexp
 	assert.Equal(t, "", getAliasFromHost("", "{}.example.com"))
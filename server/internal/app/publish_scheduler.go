@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interactor"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+)
+
+const publishScheduleLockName = "publish-schedule"
+
+// runPublishScheduler periodically publishes and unpublishes projects and stories according to their publish schedules.
+func runPublishScheduler(ctx context.Context, interval time.Duration, repos *repo.Container, gateways *gateway.Container) {
+	runLocked(ctx, repos.Lock, publishScheduleLockName, interval, func(ctx context.Context, now time.Time) error {
+		return publishScheduled(ctx, repos, gateways, now)
+	})
+}
+
+func publishScheduled(ctx context.Context, repos *repo.Container, gateways *gateway.Container, now time.Time) error {
+	prj := interactor.NewProject(repos, gateways)
+	if err := prj.PublishScheduled(ctx, now); err != nil {
+		return err
+	}
+	if err := prj.RemoveExpiredPreviews(ctx, now); err != nil {
+		return err
+	}
+	return interactor.NewStorytelling(repos, gateways).PublishScheduled(ctx, now)
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 
 	"github.com/reearth/reearth/server/internal/app/config"
+	"github.com/reearth/reearth/server/internal/infrastructure/archive"
 	"github.com/reearth/reearth/server/internal/infrastructure/auth0"
+	"github.com/reearth/reearth/server/internal/infrastructure/datasource"
 	"github.com/reearth/reearth/server/internal/infrastructure/fs"
 	"github.com/reearth/reearth/server/internal/infrastructure/gcs"
 	"github.com/reearth/reearth/server/internal/infrastructure/google"
 	"github.com/reearth/reearth/server/internal/infrastructure/marketplace"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	mongorepo "github.com/reearth/reearth/server/internal/infrastructure/mongo"
 	"github.com/reearth/reearth/server/internal/infrastructure/s3"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
@@ -81,10 +84,22 @@ func initReposAndGateways(ctx context.Context, conf *config.Config, debug bool)
 	// google
 	gateways.Google = google.NewGoogle()
 
+	// project archive
+	gateways.ProjectArchive = archive.NewProjectArchive()
+
+	// data source
+	if len(conf.DataSource.AllowedHosts) > 0 {
+		gateways.DataSource = datasource.New(conf.DataSource.AllowedHosts)
+	}
+
 	// mailer
 	mailer := mailer.New(ctx, &conf.Config)
 	gateways.Mailer = mailer
 	acGateways.Mailer = mailer
+
+	// pub/sub: events are only delivered to clients connected to this process
+	gateways.PubSub = memory.NewPubSub()
+
 	// Marketplace
 	if conf.Marketplace.Endpoint != "" {
 		gateways.PluginRegistry = marketplace.New(conf.Marketplace.Endpoint, conf.Marketplace.Secret, conf.Marketplace.OAuth.Config())
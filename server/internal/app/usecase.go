@@ -25,7 +25,7 @@ func UsecaseMiddleware(r *repo.Container, g *gateway.Container, ar *accountrepo.
 			repos = repos.Filtered(
 				ws,
 				sc,
-			)
+			).Granted(op.GrantedProjects, op.AllReadableScenes(), op.AllWritableScenes())
 		}
 
 		var ar2 *accountrepo.Container
@@ -0,0 +1,411 @@
+package archive
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/tag"
+	"github.com/reearth/reearth/server/pkg/value"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	projectArchiveType    = "reearth-project"
+	projectArchiveVersion = 1
+	manifestFile          = "manifest.json"
+)
+
+const (
+	projectCollection       = "project"
+	sceneCollection         = "scene"
+	layerCollection         = "layer"
+	nlsLayerCollection      = "nlsLayer"
+	propertyCollection      = "property"
+	datasetSchemaCollection = "datasetSchema"
+	datasetCollection       = "dataset"
+	tagCollection           = "tag"
+	styleCollection         = "style"
+	storytellingCollection  = "storytelling"
+)
+
+var collections = []string{
+	projectCollection, sceneCollection, layerCollection, nlsLayerCollection, propertyCollection,
+	datasetSchemaCollection, datasetCollection, tagCollection, styleCollection, storytellingCollection,
+}
+
+var (
+	// maxArchiveSize is the max size of an archive and the max total size of the files in it.
+	maxArchiveSize int64 = 512 << 20
+	// maxArchiveEntrySize is the max size of each file in an archive.
+	maxArchiveEntrySize int64 = 256 << 20
+)
+
+// idRegexp matches IDs of entities, which are lowercase ULIDs.
+var idRegexp = regexp.MustCompile(`^[0-9a-hjkmnp-tv-z]{26}$`)
+
+type manifest struct {
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+}
+
+// ProjectArchive writes and reads project archives. An archive is a zip file which has a manifest and
+// a file for each collection, in which documents are stored in the same format as in the database,
+// one Extended JSON document per line.
+type ProjectArchive struct{}
+
+func NewProjectArchive() *ProjectArchive {
+	return &ProjectArchive{}
+}
+
+func (a *ProjectArchive) Write(ctx context.Context, w io.Writer, d *gateway.ProjectArchiveData) error {
+	if d == nil || d.Project == nil || d.Scene == nil {
+		return gateway.ErrInvalidProjectArchive
+	}
+
+	zw := zip.NewWriter(w)
+
+	mf, err := zw.Create(manifestFile)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(mf).Encode(manifest{Type: projectArchiveType, Version: projectArchiveVersion}); err != nil {
+		return err
+	}
+
+	prj, _ := mongodoc.NewProject(d.Project)
+	sc, _ := mongodoc.NewScene(d.Scene)
+	layers, _ := mongodoc.NewLayers(d.Layers, nil)
+	nlsLayers, _ := mongodoc.NewNLSLayers(d.NLSLayers, nil)
+	properties, _ := mongodoc.NewProperties(d.Properties, nil)
+	datasetSchemas, _ := mongodoc.NewDatasetSchemas(d.DatasetSchemas, nil)
+	datasets, _ := mongodoc.NewDatasets(d.Datasets, nil)
+	tags, _ := mongodoc.NewTags(d.Tags, nil)
+	styles, _ := mongodoc.NewStyles(d.Styles, nil)
+	stories, _ := mongodoc.NewStorytellings(&d.Stories)
+
+	for _, c := range []struct {
+		name string
+		docs []any
+	}{
+		{projectCollection, []any{prj}},
+		{sceneCollection, []any{sc}},
+		{layerCollection, layers},
+		{nlsLayerCollection, nlsLayers},
+		{propertyCollection, properties},
+		{datasetSchemaCollection, datasetSchemas},
+		{datasetCollection, datasets},
+		{tagCollection, tags},
+		{styleCollection, styles},
+		{storytellingCollection, stories},
+	} {
+		if err := writeDocuments(zw, c.name, c.docs); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeDocuments(zw *zip.Writer, name string, docs []any) error {
+	w, err := zw.Create(name + ".jsonl")
+	if err != nil {
+		return err
+	}
+	for _, d := range docs {
+		b, err := bson.MarshalExtJSON(d, true, false)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(b, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ProjectArchive) Read(ctx context.Context, r io.Reader, ws accountdomain.WorkspaceID) (*gateway.ProjectArchiveData, error) {
+	files, err := readFiles(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var m manifest
+	if err := json.Unmarshal(files[manifestFile], &m); err != nil || m.Type != projectArchiveType {
+		return nil, gateway.ErrInvalidProjectArchive
+	}
+	if m.Version != projectArchiveVersion {
+		return nil, gateway.ErrUnsupportedProjectArchive
+	}
+
+	docs := map[string][]bson.D{}
+	mapper := newIDMapper(ws)
+	for _, name := range collections {
+		if err := eachLine(files[name+".jsonl"], func(line []byte) error {
+			var d bson.D
+			if err := bson.UnmarshalExtJSON(line, true, &d); err != nil {
+				return gateway.ErrInvalidProjectArchive
+			}
+			mapper.collect(d)
+			docs[name] = append(docs[name], d)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	prj, err := readDocuments(docs, mapper, projectCollection, (*mongodoc.ProjectDocument).Model)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := readDocuments(docs, mapper, sceneCollection, (*mongodoc.SceneDocument).Model)
+	if err != nil {
+		return nil, err
+	}
+	if len(prj) != 1 || len(sc) != 1 || sc[0].Project() != prj[0].ID() {
+		return nil, gateway.ErrInvalidProjectArchive
+	}
+
+	res := &gateway.ProjectArchiveData{
+		Project: prj[0],
+		Scene:   sc[0],
+	}
+
+	layers, err := readDocuments(docs, mapper, layerCollection, (*mongodoc.LayerDocument).Model)
+	if err != nil {
+		return nil, err
+	}
+	res.Layers = lo.Map(layers, func(l layer.Layer, _ int) *layer.Layer { return &l })
+
+	nlsLayers, err := readDocuments(docs, mapper, nlsLayerCollection, (*mongodoc.NLSLayerDocument).Model)
+	if err != nil {
+		return nil, err
+	}
+	res.NLSLayers = lo.Map(nlsLayers, func(l nlslayer.NLSLayer, _ int) *nlslayer.NLSLayer { return &l })
+
+	if res.Properties, err = readDocuments(docs, mapper, propertyCollection, (*mongodoc.PropertyDocument).Model); err != nil {
+		return nil, err
+	}
+	if res.DatasetSchemas, err = readDocuments(docs, mapper, datasetSchemaCollection, (*mongodoc.DatasetSchemaDocument).Model); err != nil {
+		return nil, err
+	}
+	if res.Datasets, err = readDocuments(docs, mapper, datasetCollection, (*mongodoc.DatasetDocument).Model); err != nil {
+		return nil, err
+	}
+
+	tags, err := readDocuments(docs, mapper, tagCollection, (*mongodoc.TagDocument).Model)
+	if err != nil {
+		return nil, err
+	}
+	res.Tags = lo.Map(tags, func(t tag.Tag, _ int) *tag.Tag { return &t })
+
+	if res.Styles, err = readDocuments(docs, mapper, styleCollection, (*mongodoc.StyleDocument).Model); err != nil {
+		return nil, err
+	}
+	if res.Stories, err = readDocuments(docs, mapper, storytellingCollection, (*mongodoc.StorytellingDocument).Model); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// readFiles extracts the files of an archive. Archives are supplied by users, so the size of the archive,
+// the size of each file and the total size of the files are limited so that they can not exhaust the memory.
+func readFiles(r io.Reader) (map[string][]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, maxArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > maxArchiveSize {
+		return nil, gateway.ErrProjectArchiveTooLarge
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, gateway.ErrInvalidProjectArchive
+	}
+
+	files := map[string][]byte{}
+	var total int64
+	for _, f := range zr.File {
+		// the sizes in headers are not trusted, but they are checked first to reject archives early
+		if f.UncompressedSize64 > uint64(maxArchiveEntrySize) {
+			return nil, gateway.ErrProjectArchiveTooLarge
+		}
+		fr, err := f.Open()
+		if err != nil {
+			return nil, gateway.ErrInvalidProjectArchive
+		}
+		c, err := io.ReadAll(io.LimitReader(fr, maxArchiveEntrySize+1))
+		_ = fr.Close()
+		if err != nil {
+			return nil, gateway.ErrInvalidProjectArchive
+		}
+		total += int64(len(c))
+		if int64(len(c)) > maxArchiveEntrySize || total > maxArchiveSize {
+			return nil, gateway.ErrProjectArchiveTooLarge
+		}
+		files[f.Name] = c
+	}
+	return files, nil
+}
+
+// idMapper replaces IDs of all entities in an archive with new ones and the workspace of the project with ws,
+// so that the project can be restored next to the original.
+// Only ID fields and fields which refer to entities are replaced; contents supplied by users are kept as they are.
+type idMapper struct {
+	ids map[string]string
+	ws  string
+}
+
+// refFields are the names of fields in documents whose values are IDs of entities or lists of them.
+var refFields = map[string]struct{}{
+	"id": {}, "project": {}, "scene": {}, "rootlayer": {}, "property": {}, "layers": {}, "children": {},
+	"swipelayers": {}, "tags": {}, "parent": {}, "linkeddataset": {}, "linkeddatasetschema": {},
+	"linkeddatasetid": {}, "linkeddatasetschemaid": {}, "linkeddatasetfieldid": {}, "schema": {}, "dataset": {},
+	"field": {}, "representativefield": {},
+}
+
+// pluginFields are the names of fields in documents whose values are IDs of plugins or property schemas,
+// which start with the scene ID if the plugins are private to the scene.
+var pluginFields = map[string]struct{}{
+	"plugin": {}, "schemaplugin": {}, "schema": {},
+}
+
+// userFields are the names of fields in documents which hold arbitrary values supplied by users.
+// Values of the "ref" type are IDs of entities though.
+var userFields = map[string]struct{}{
+	"config": {}, "value": {}, "properties": {}, "geometry": {}, "custompropertyschema": {},
+}
+
+func newIDMapper(ws accountdomain.WorkspaceID) *idMapper {
+	return &idMapper{ids: map[string]string{}, ws: ws.String()}
+}
+
+// collect collects values of "id" fields of documents and their embedded documents.
+func (m *idMapper) collect(v any) {
+	switch v := v.(type) {
+	case bson.D:
+		for _, e := range v {
+			if _, ok := userFields[e.Key]; ok {
+				continue
+			}
+			if s, ok := e.Value.(string); ok && e.Key == "id" && idRegexp.MatchString(s) {
+				if _, ok := m.ids[s]; !ok {
+					m.ids[s] = id.NewPropertyID().String()
+				}
+				continue
+			}
+			m.collect(e.Value)
+		}
+	case bson.A:
+		for _, w := range v {
+			m.collect(w)
+		}
+	}
+}
+
+// remap returns a copy of the document whose IDs and references are replaced.
+func (m *idMapper) remap(d bson.D) bson.D {
+	res := make(bson.D, 0, len(d))
+	for _, e := range d {
+		_, user := userFields[e.Key]
+		switch {
+		case e.Key == "team" || e.Key == "workspace":
+			if s, ok := e.Value.(string); ok && s != "" {
+				e.Value = m.ws
+			}
+		case user:
+			if e.Key == "value" && isRefField(d) {
+				if s, ok := e.Value.(string); ok && m.ids[s] != "" {
+					e.Value = m.ids[s]
+				}
+			}
+		default:
+			e.Value = m.remapValue(e.Key, e.Value)
+		}
+		res = append(res, e)
+	}
+	return res
+}
+
+func (m *idMapper) remapValue(key string, v any) any {
+	switch v := v.(type) {
+	case bson.D:
+		return m.remap(v)
+	case bson.A:
+		return lo.Map(v, func(w any, _ int) any { return m.remapValue(key, w) })
+	case string:
+		if _, ok := refFields[key]; ok {
+			if nid, ok := m.ids[v]; ok {
+				return nid
+			}
+		}
+		if _, ok := pluginFields[key]; ok {
+			if sid, rest, ok := strings.Cut(v, "~"); ok {
+				if nid, ok := m.ids[sid]; ok {
+					return nid + "~" + rest
+				}
+			}
+		}
+	}
+	return v
+}
+
+// isRefField reports whether the document is a field of a property or a dataset whose value is an ID of an entity.
+func isRefField(d bson.D) bool {
+	for _, e := range d {
+		if e.Key == "type" {
+			return e.Value == string(value.TypeRef)
+		}
+	}
+	return false
+}
+
+func readDocuments[D any, M any](docs map[string][]bson.D, mapper *idMapper, name string, model func(*D) (M, error)) ([]M, error) {
+	var res []M
+	for _, raw := range docs[name] {
+		b, err := bson.Marshal(mapper.remap(raw))
+		if err != nil {
+			return nil, gateway.ErrInvalidProjectArchive
+		}
+		var d D
+		if err := bson.Unmarshal(b, &d); err != nil {
+			return nil, gateway.ErrInvalidProjectArchive
+		}
+		m, err := model(&d)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		res = append(res, m)
+	}
+	return res, nil
+}
+
+func eachLine(b []byte, f func([]byte) error) error {
+	s := bufio.NewScanner(bytes.NewReader(b))
+	s.Buffer(nil, len(b)+1)
+	for s.Scan() {
+		if len(bytes.TrimSpace(s.Bytes())) == 0 {
+			continue
+		}
+		if err := f(s.Bytes()); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+var _ gateway.ProjectArchive = (*ProjectArchive)(nil)
@@ -0,0 +1,159 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/tag"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectArchive(t *testing.T) {
+	ctx := context.Background()
+	ws := accountdomain.NewWorkspaceID()
+	ws2 := accountdomain.NewWorkspaceID()
+
+	sid := scene.NewID()
+	// contents supplied by users are kept even if they contain IDs
+	prj := project.New().NewID().Workspace(ws).Name("project").Description("scene " + sid.String()).MustBuild()
+	pid := lo.Must(id.NewPluginID("test", "1.0.0", &sid))
+	tg := tag.NewItem().NewID().Scene(sid).Label("tag").MustBuild()
+	item := layer.NewItem().NewID().Scene(sid).Tags(layer.NewTagList([]layer.Tag{layer.NewTagItem(tg.ID())})).MustBuild()
+	sceneProp := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("ref").Value(property.OptionalValueFrom(property.ValueTypeRef.ValueFrom(item.ID().String()))).Build(),
+			property.NewField("text").Value(property.OptionalValueFrom(property.ValueTypeString.ValueFrom(item.ID().String()))).Build(),
+		}).MustBuild(),
+	}).MustBuild()
+	root := layer.NewGroup().NewID().Scene(sid).Layers(layer.NewIDList([]layer.ID{item.ID()})).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws).RootLayer(root.ID()).Property(sceneProp.ID()).
+		Plugins(scene.NewPlugins([]*scene.Plugin{scene.NewPlugin(pid, nil)})).MustBuild()
+	feature := lo.Must(nlslayer.NewFeatureWithNewId("Feature", nlslayer.NewPoint("Point", []float64{1, 2})))
+	feature.UpdateProperties(&map[string]any{"name": "feature"})
+	nl := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Config(&nlslayer.Config{}).IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*feature}))).MustBuild()
+	style := scene.NewStyle().NewID().Scene(sid).Name("style").Value(&scene.StyleValue{"color": "red", "id": item.ID().String()}).MustBuild()
+	page := storytelling.NewPage().NewID().Property(property.NewID()).Layers(storytelling.LayerIDList{nl.ID()}).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(sid).Property(property.NewID()).
+		Pages(storytelling.NewPageList([]*storytelling.Page{page})).MustBuild()
+
+	var b bytes.Buffer
+	a := NewProjectArchive()
+	assert.NoError(t, a.Write(ctx, &b, &gateway.ProjectArchiveData{
+		Project:    prj,
+		Scene:      s,
+		Layers:     layer.List{lo.ToPtr[layer.Layer](root), lo.ToPtr[layer.Layer](item)},
+		NLSLayers:  nlslayer.NLSLayerList{lo.ToPtr[nlslayer.NLSLayer](nl)},
+		Properties: property.List{sceneProp},
+		Tags:       []*tag.Tag{lo.ToPtr[tag.Tag](tg)},
+		Styles:     scene.StyleList{style},
+		Stories:    storytelling.StoryList{story},
+	}))
+
+	got, err := a.Read(ctx, bytes.NewReader(b.Bytes()), ws2)
+	assert.NoError(t, err)
+
+	// all IDs are replaced and references between entities are kept
+	assert.NotEqual(t, prj.ID(), got.Project.ID())
+	assert.Equal(t, ws2, got.Project.Workspace())
+	assert.Equal(t, "project", got.Project.Name())
+	assert.Equal(t, "scene "+sid.String(), got.Project.Description())
+	gs := got.Scene
+	assert.NotEqual(t, sid, gs.ID())
+	assert.Equal(t, got.Project.ID(), gs.Project())
+	assert.Equal(t, ws2, gs.Workspace())
+	assert.Equal(t, lo.ToPtr(gs.ID()), gs.Plugins().Plugins()[0].Plugin().Scene())
+
+	assert.Len(t, got.Properties, 1)
+	assert.Equal(t, gs.Property(), got.Properties[0].ID())
+	assert.Equal(t, gs.ID(), got.Properties[0].Scene())
+
+	assert.Len(t, got.Layers, 2)
+	groot := layer.ToLayerGroup(*got.Layers.Find(gs.RootLayer()))
+	assert.NotNil(t, groot)
+	assert.NotEqual(t, root.ID(), groot.ID())
+	gitem := layer.ToLayerItem(*got.Layers.Find(groot.Layers().LayerAt(0)))
+	assert.NotNil(t, gitem)
+	assert.Equal(t, gs.ID(), gitem.Scene())
+
+	assert.Len(t, got.Tags, 1)
+	gtag := *got.Tags[0]
+	assert.NotEqual(t, tg.ID(), gtag.ID())
+	assert.Equal(t, gtag.ID(), gitem.Tags().Tags()[0].ID())
+
+	assert.Len(t, got.NLSLayers, 1)
+	gnl := *got.NLSLayers[0]
+	assert.NotEqual(t, nl.ID(), gnl.ID())
+	gf := gnl.Sketch().FeatureCollection().Features()
+	assert.Len(t, gf, 1)
+	assert.NotEqual(t, feature.ID(), gf[0].ID())
+
+	gfields := got.Properties[0].Items()[0].(*property.Group).Fields(nil)
+	assert.Equal(t, gitem.ID().String(), gfields[0].Value().Value())
+	assert.Equal(t, item.ID().String(), gfields[1].Value().Value())
+
+	assert.Len(t, got.Styles, 1)
+	assert.Equal(t, gs.ID(), got.Styles[0].Scene())
+	assert.Equal(t, &scene.StyleValue{"color": "red", "id": item.ID().String()}, got.Styles[0].Value())
+
+	assert.Len(t, got.Stories, 1)
+	gpage := got.Stories[0].Pages().Pages()[0]
+	assert.NotEqual(t, page.Id(), gpage.Id())
+	assert.Equal(t, storytelling.LayerIDList{gnl.ID()}, gpage.Layers())
+
+	// invalid archives
+	_, err = a.Read(ctx, bytes.NewReader([]byte("invalid")), ws2)
+	assert.Same(t, gateway.ErrInvalidProjectArchive, err)
+
+	var b2 bytes.Buffer
+	zw := zip.NewWriter(&b2)
+	w, _ := zw.Create(manifestFile)
+	_, _ = w.Write([]byte(`{"type":"reearth-project","version":100}`))
+	_ = zw.Close()
+	_, err = a.Read(ctx, bytes.NewReader(b2.Bytes()), ws2)
+	assert.Same(t, gateway.ErrUnsupportedProjectArchive, err)
+}
+
+func TestProjectArchive_TooLarge(t *testing.T) {
+	defer func(s, e int64) {
+		maxArchiveSize, maxArchiveEntrySize = s, e
+	}(maxArchiveSize, maxArchiveEntrySize)
+
+	ctx := context.Background()
+	archive := func(sizes ...int) []byte {
+		var b bytes.Buffer
+		zw := zip.NewWriter(&b)
+		for i, s := range sizes {
+			w, _ := zw.Create(fmt.Sprintf("%d.jsonl", i))
+			_, _ = w.Write(bytes.Repeat([]byte{' '}, s))
+		}
+		_ = zw.Close()
+		return b.Bytes()
+	}
+
+	maxArchiveSize, maxArchiveEntrySize = 1000, 100
+	// entries are compressed well, so their sizes are checked after they are extracted
+	_, err := NewProjectArchive().Read(ctx, bytes.NewReader(archive(101)), accountdomain.NewWorkspaceID())
+	assert.Same(t, gateway.ErrProjectArchiveTooLarge, err)
+	_, err = NewProjectArchive().Read(ctx, bytes.NewReader(archive(100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100)), accountdomain.NewWorkspaceID())
+	assert.Same(t, gateway.ErrProjectArchiveTooLarge, err)
+	_, err = NewProjectArchive().Read(ctx, bytes.NewReader(archive(100)), accountdomain.NewWorkspaceID())
+	assert.Same(t, gateway.ErrInvalidProjectArchive, err)
+
+	maxArchiveSize = 10
+	_, err = NewProjectArchive().Read(ctx, bytes.NewReader(archive(1)), accountdomain.NewWorkspaceID())
+	assert.Same(t, gateway.ErrProjectArchiveTooLarge, err)
+}
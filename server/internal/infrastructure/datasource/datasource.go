@@ -0,0 +1,180 @@
+package datasource
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+const (
+	maxContentSize = 100 * 1024 * 1024
+	fetchTimeout   = 30 * time.Second
+)
+
+var ErrContentTooLarge = errors.New("content is too large")
+
+// DataSource fetches CSV, TSV, GeoJSON and JSON array files from URLs on the allowed hosts.
+// The source of the schema is the URL so that the schema can be migrated when the URL is synced again.
+type DataSource struct {
+	client       *http.Client
+	allowedHosts []string
+}
+
+func New(allowedHosts []string) gateway.DataSource {
+	return NewWithClient(allowedHosts, &http.Client{Timeout: fetchTimeout})
+}
+
+func NewWithClient(allowedHosts []string, client *http.Client) *DataSource {
+	d := &DataSource{
+		allowedHosts: make([]string, 0, len(allowedHosts)),
+	}
+	for _, h := range allowedHosts {
+		if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+			d.allowedHosts = append(d.allowedHosts, h)
+		}
+	}
+
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		// redirects to hosts which are not allowed are rejected
+		if !d.isAllowed(req.URL) {
+			return gateway.ErrDataSourceInvalidURL
+		}
+		return nil
+	}
+	d.client = &c
+	return d
+}
+
+func (d *DataSource) IsURLValid(_ context.Context, u string) bool {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	return d.isAllowed(parsed)
+}
+
+func (d *DataSource) isAllowed(u *url.URL) bool {
+	if u == nil || u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return false
+	}
+	for _, h := range d.allowedHosts {
+		if suffix, ok := strings.CutPrefix(h, "*"); ok && strings.HasPrefix(suffix, ".") {
+			if strings.HasSuffix(host, suffix) {
+				return true
+			}
+		} else if host == h {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DataSource) Fetch(ctx context.Context, u string, sid id.SceneID) ([]*dataset.Schema, []*dataset.Dataset, error) {
+	parsed, err := url.Parse(u)
+	if err != nil || !d.isAllowed(parsed) {
+		return nil, nil, gateway.ErrDataSourceInvalidURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := d.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("failed to fetch data: StatusCode=%d", res.StatusCode)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(res.Body, maxContentSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(content) > maxContentSize {
+		return nil, nil, ErrContentTooLarge
+	}
+
+	name := path.Base(parsed.Path)
+	if name == "/" || name == "." {
+		name = parsed.Host
+	}
+
+	var schema *dataset.Schema
+	var datasets []*dataset.Dataset
+	switch format(res.Header.Get("Content-Type"), parsed.Path) {
+	case "csv":
+		schema, datasets, err = parseCSV(content, name, u, ',', sid)
+	case "tsv":
+		schema, datasets, err = parseCSV(content, name, u, '\t', sid)
+	case "json":
+		p := dataset.NewJSONParser(bytes.NewReader(content), name)
+		p.SetSource(u)
+		schema, datasets, err = p.Parse(sid)
+	default:
+		return nil, nil, gateway.ErrDataSourceUnsupportedFormat
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return []*dataset.Schema{schema}, datasets, nil
+}
+
+func parseCSV(content []byte, name, source string, separator rune, sid id.SceneID) (*dataset.Schema, []*dataset.Dataset, error) {
+	p := dataset.NewCSVParser(bytes.NewReader(content), name, separator)
+	p.SetSource(source)
+	if err := p.Init(); err != nil {
+		return nil, nil, err
+	}
+	if err := p.GuessSchema(sid); err != nil {
+		return nil, nil, err
+	}
+	return p.ReadAll()
+}
+
+// format returns the format of the content from the content type, or from the extension of the path
+// when the content type is too generic such as text/plain or application/octet-stream.
+func format(contentType, p string) string {
+	if t, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch t {
+		case "text/csv":
+			return "csv"
+		case "text/tab-separated-values":
+			return "tsv"
+		case "application/json", "application/geo+json", "application/vnd.geo+json":
+			return "json"
+		}
+	}
+	switch strings.ToLower(path.Ext(p)) {
+	case ".csv":
+		return "csv"
+	case ".tsv":
+		return "tsv"
+	case ".json", ".geojson":
+		return "json"
+	}
+	return ""
+}
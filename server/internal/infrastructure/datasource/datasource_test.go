@@ -0,0 +1,108 @@
+package datasource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSource_IsURLValid(t *testing.T) {
+	d := New([]string{"example.com", "*.example.org", " Data.Example.NET "})
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://example.com/data.csv", want: true},
+		{url: "http://example.com:8080/data.csv", want: true},
+		{url: "https://sub.example.com/data.csv", want: false},
+		{url: "https://a.b.example.org/data.csv", want: true},
+		{url: "https://example.org/data.csv", want: false},
+		{url: "https://data.example.net/data.csv", want: true},
+		{url: "ftp://example.com/data.csv", want: false},
+		{url: "/data.csv", want: false},
+		{url: "https://example.com.evil.test/data.csv", want: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.url, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, d.IsURLValid(context.Background(), tc.url))
+		})
+	}
+}
+
+func TestDataSource_Fetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/data.csv", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("id,name,lat,lng\na,Tokyo,35.6,139.7\nb,Osaka,34.7,135.5"))
+	})
+	mux.HandleFunc("/data", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/tab-separated-values; charset=utf-8")
+		_, _ = w.Write([]byte("name\tcount\nx\t1"))
+	})
+	mux.HandleFunc("/data.geojson", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"type":"FeatureCollection","features":[{"type":"Feature","id":"p","geometry":{"type":"Point","coordinates":[139.7,35.6]},"properties":{"name":"Tokyo"}}]}`))
+	})
+	mux.HandleFunc("/data.txt", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("hello"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("name\nsecret"))
+	}))
+	t.Cleanup(other.Close)
+	otherURL, _ := url.Parse(other.URL)
+	mux.HandleFunc("/redirect.csv", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://localhost:"+otherURL.Port()+"/data.csv", http.StatusFound)
+	})
+
+	d := NewWithClient([]string{"127.0.0.1"}, server.Client())
+	sid := id.NewSceneID()
+	ctx := context.Background()
+
+	dss, ds, err := d.Fetch(ctx, server.URL+"/data.csv", sid)
+	assert.NoError(t, err)
+	assert.Len(t, dss, 1)
+	assert.Equal(t, server.URL+"/data.csv", dss[0].Source())
+	assert.Equal(t, "data.csv", dss[0].Name())
+	assert.Equal(t, sid, dss[0].Scene())
+	assert.NotNil(t, dss[0].FieldBySource("name"))
+	assert.NotNil(t, dss[0].FieldBySource("location"))
+	assert.Len(t, ds, 2)
+	assert.Equal(t, []string{"a", "b"}, []string{ds[0].Source(), ds[1].Source()})
+	assert.Equal(t, dataset.LatLng{Lat: 35.6, Lng: 139.7}, ds[0].FieldByType(dataset.ValueTypeLatLng).Value().Interface())
+
+	dss, ds, err = d.Fetch(ctx, server.URL+"/data", sid)
+	assert.NoError(t, err)
+	assert.Equal(t, dataset.ValueTypeNumber, dss[0].FieldBySource("count").Type())
+	assert.Len(t, ds, 1)
+
+	dss, ds, err = d.Fetch(ctx, server.URL+"/data.geojson", sid)
+	assert.NoError(t, err)
+	assert.NotNil(t, dss[0].FieldBySource("location"))
+	assert.Equal(t, "p", ds[0].Source())
+
+	_, _, err = d.Fetch(ctx, server.URL+"/data.txt", sid)
+	assert.Same(t, gateway.ErrDataSourceUnsupportedFormat, err)
+
+	_, _, err = d.Fetch(ctx, server.URL+"/notfound.csv", sid)
+	assert.EqualError(t, err, "failed to fetch data: StatusCode=404")
+
+	_, _, err = d.Fetch(ctx, server.URL+"/redirect.csv", sid)
+	assert.ErrorIs(t, err, gateway.ErrDataSourceInvalidURL)
+
+	_, _, err = d.Fetch(ctx, "http://localhost:"+otherURL.Port()+"/data.csv", sid)
+	assert.Same(t, gateway.ErrDataSourceInvalidURL, err)
+}
@@ -5,5 +5,8 @@ const (
 	pluginDir        = "plugins"
 	publishedDir     = "published"
 	storyDir         = "stories"
+	revisionDir      = "revisions"
+	previewDir       = "previews"
+	chunkDir         = "chunks"
 	manifestFilePath = "reearth.yml"
 )
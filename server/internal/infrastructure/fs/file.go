@@ -8,6 +8,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/kennygrant/sanitize"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
@@ -68,6 +70,32 @@ func (f *fileRepo) ReadPluginFile(ctx context.Context, pid id.PluginID, filename
 	return f.read(ctx, filepath.Join(pluginDir, pid.String(), sanitize.Path(filename)))
 }
 
+func (f *fileRepo) ListPluginFiles(ctx context.Context, pid id.PluginID) ([]string, error) {
+	dir := filepath.Join(pluginDir, pid.String())
+	var res []string
+	err := afero.Walk(f.fs, dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		res = append(res, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return res, nil
+}
+
 func (f *fileRepo) UploadPluginFile(ctx context.Context, pid id.PluginID, file *file.File) error {
 	_, err := f.upload(ctx, filepath.Join(pluginDir, pid.String(), sanitize.Path(file.Path)), file.Content)
 	return err
@@ -100,6 +128,56 @@ func (f *fileRepo) RemoveBuiltScene(ctx context.Context, name string) error {
 	return f.delete(ctx, filepath.Join(publishedDir, sanitize.Path(name+".json")))
 }
 
+func (f *fileRepo) WalkBuiltScenes(ctx context.Context, fn func(io.Reader) error) error {
+	for _, dir := range []string{publishedDir, revisionDir, previewDir} {
+		if err := f.walk(ctx, dir, func(name string, _ os.FileInfo) error {
+			r, err := f.read(ctx, filepath.Join(dir, name))
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = r.Close()
+			}()
+			return fn(r)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// built scene chunks
+
+func (f *fileRepo) ReadBuiltSceneChunk(ctx context.Context, hash string) (io.ReadCloser, error) {
+	return f.read(ctx, filepath.Join(chunkDir, sanitize.Path(hash+".json")))
+}
+
+func (f *fileRepo) UploadBuiltSceneChunk(ctx context.Context, reader io.Reader, hash string) error {
+	_, err := f.upload(ctx, filepath.Join(chunkDir, sanitize.Path(hash+".json")), reader)
+	return err
+}
+
+func (f *fileRepo) BuiltSceneChunkUpdatedAt(ctx context.Context, hash string) (time.Time, error) {
+	fi, err := f.fs.Stat(filepath.Join(chunkDir, sanitize.Path(hash+".json")))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return time.Time{}, rerror.ErrNotFound
+		}
+		return time.Time{}, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return fi.ModTime(), nil
+}
+
+func (f *fileRepo) WalkBuiltSceneChunks(ctx context.Context, fn func(string, time.Time) error) error {
+	return f.walk(ctx, chunkDir, func(name string, fi os.FileInfo) error {
+		return fn(strings.TrimSuffix(name, ".json"), fi.ModTime())
+	})
+}
+
+func (f *fileRepo) RemoveBuiltSceneChunk(ctx context.Context, hash string) error {
+	return f.delete(ctx, filepath.Join(chunkDir, sanitize.Path(hash+".json")))
+}
+
 // Stories
 
 func (f *fileRepo) ReadStoryFile(ctx context.Context, name string) (io.ReadCloser, error) {
@@ -123,6 +201,36 @@ func (f *fileRepo) RemoveStory(ctx context.Context, name string) error {
 	return f.delete(ctx, filepath.Join(storyDir, sanitize.Path(name+".json")))
 }
 
+// revisions
+
+func (f *fileRepo) ReadRevisionFile(ctx context.Context, name string) (io.ReadCloser, error) {
+	return f.read(ctx, filepath.Join(revisionDir, sanitize.Path(name+".json")))
+}
+
+func (f *fileRepo) UploadRevision(ctx context.Context, reader io.Reader, name string) error {
+	_, err := f.upload(ctx, filepath.Join(revisionDir, sanitize.Path(name+".json")), reader)
+	return err
+}
+
+func (f *fileRepo) RemoveRevision(ctx context.Context, name string) error {
+	return f.delete(ctx, filepath.Join(revisionDir, sanitize.Path(name+".json")))
+}
+
+// previews
+
+func (f *fileRepo) ReadPreviewFile(ctx context.Context, name string) (io.ReadCloser, error) {
+	return f.read(ctx, filepath.Join(previewDir, sanitize.Path(name+".json")))
+}
+
+func (f *fileRepo) UploadPreview(ctx context.Context, reader io.Reader, name string) error {
+	_, err := f.upload(ctx, filepath.Join(previewDir, sanitize.Path(name+".json")), reader)
+	return err
+}
+
+func (f *fileRepo) RemovePreview(ctx context.Context, name string) error {
+	return f.delete(ctx, filepath.Join(previewDir, sanitize.Path(name+".json")))
+}
+
 // helpers
 
 func (f *fileRepo) read(ctx context.Context, filename string) (io.ReadCloser, error) {
@@ -140,6 +248,26 @@ func (f *fileRepo) read(ctx context.Context, filename string) (io.ReadCloser, er
 	return file, nil
 }
 
+// walk calls the function with each file in the directory. It does nothing if the directory does not exist.
+func (f *fileRepo) walk(ctx context.Context, dir string, fn func(string, os.FileInfo) error) error {
+	files, err := afero.ReadDir(f.fs, dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	for _, fi := range files {
+		if fi.IsDir() {
+			continue
+		}
+		if err := fn(fi.Name(), fi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (f *fileRepo) upload(ctx context.Context, filename string, content io.Reader) (int64, error) {
 	if filename == "" {
 		return 0, gateway.ErrFailedToUploadFile
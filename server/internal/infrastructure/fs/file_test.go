@@ -136,6 +136,23 @@ func TestFile_ReadPluginFile(t *testing.T) {
 	assert.Nil(t, r)
 }
 
+func TestFile_ListPluginFiles(t *testing.T) {
+	fs := mockFs()
+	f, _ := NewFile(fs, "")
+	assert.NoError(t, f.UploadPluginFile(context.Background(), id.MustPluginID("aaa~1.0.0"), &file.File{
+		Path:    "lib/bar.js",
+		Content: io.NopCloser(strings.NewReader("bar")),
+	}))
+
+	files, err := f.ListPluginFiles(context.Background(), id.MustPluginID("aaa~1.0.0"))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"foo.js", "lib/bar.js"}, files)
+
+	files, err = f.ListPluginFiles(context.Background(), id.MustPluginID("aaa~1.0.1"))
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
 func TestFile_UploadPluginFile(t *testing.T) {
 	fs := mockFs()
 	f, _ := NewFile(fs, "")
@@ -240,6 +257,31 @@ func TestFile_RemoveBuiltScene(t *testing.T) {
 	assert.ErrorIs(t, err, os.ErrNotExist)
 }
 
+func TestFile_Revision(t *testing.T) {
+	fs := mockFs()
+	f, _ := NewFile(fs, "")
+
+	err := f.UploadRevision(context.Background(), strings.NewReader("{\"aaa\":1}"), "r")
+	assert.NoError(t, err)
+
+	r, err := f.ReadRevisionFile(context.Background(), "r")
+	assert.NoError(t, err)
+	c, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"aaa\":1}", string(c))
+	assert.NoError(t, r.Close())
+
+	r, err = f.ReadRevisionFile(context.Background(), "../published/s")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	assert.Nil(t, r)
+
+	err = f.RemoveRevision(context.Background(), "r")
+	assert.NoError(t, err)
+
+	_, err = fs.Stat(filepath.Join("revisions", "r.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestGetAssetFileURL(t *testing.T) {
 	e, err := url.Parse("http://hoge.com/assets/xxx.yyy")
 	assert.NoError(t, err)
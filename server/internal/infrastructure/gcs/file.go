@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/kennygrant/sanitize"
@@ -20,11 +21,14 @@ import (
 )
 
 const (
-	gcsAssetBasePath  string = "assets"
-	gcsPluginBasePath string = "plugins"
-	gcsMapBasePath    string = "maps"
-	gcsStoryBasePath  string = "stories"
-	fileSizeLimit     int64  = 1024 * 1024 * 100 // about 100MB
+	gcsAssetBasePath    string = "assets"
+	gcsPluginBasePath   string = "plugins"
+	gcsMapBasePath      string = "maps"
+	gcsStoryBasePath    string = "stories"
+	gcsRevisionBasePath string = "revisions"
+	gcsPreviewBasePath  string = "previews"
+	gcsChunkBasePath    string = "chunks"
+	fileSizeLimit       int64  = 1024 * 1024 * 100 // about 100MB
 )
 
 type fileRepo struct {
@@ -110,6 +114,18 @@ func (f *fileRepo) ReadPluginFile(ctx context.Context, pid id.PluginID, filename
 	return f.read(ctx, path.Join(gcsPluginBasePath, pid.String(), sn))
 }
 
+func (f *fileRepo) ListPluginFiles(ctx context.Context, pid id.PluginID) ([]string, error) {
+	dir := path.Join(gcsPluginBasePath, pid.String())
+	var res []string
+	if err := f.walk(ctx, dir, func(name string, _ time.Time) error {
+		res = append(res, strings.TrimPrefix(name, dir+"/"))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (f *fileRepo) UploadPluginFile(ctx context.Context, pid id.PluginID, file *file.File) error {
 	sn := sanitize.Path(file.Path)
 	if sn == "" {
@@ -162,6 +178,24 @@ func (f *fileRepo) RemoveBuiltScene(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(gcsMapBasePath, sn))
 }
 
+func (f *fileRepo) WalkBuiltScenes(ctx context.Context, fn func(io.Reader) error) error {
+	for _, p := range []string{gcsMapBasePath, gcsRevisionBasePath, gcsPreviewBasePath} {
+		if err := f.walk(ctx, p, func(name string, _ time.Time) error {
+			r, err := f.read(ctx, name)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = r.Close()
+			}()
+			return fn(r)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Stories
 
 func (f *fileRepo) ReadStoryFile(ctx context.Context, name string) (io.ReadCloser, error) {
@@ -199,6 +233,101 @@ func (f *fileRepo) RemoveStory(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(gcsStoryBasePath, sn))
 }
 
+// built scene chunks
+
+func (f *fileRepo) ReadBuiltSceneChunk(ctx context.Context, hash string) (io.ReadCloser, error) {
+	if hash == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, path.Join(gcsChunkBasePath, sanitize.Path(hash)+".json"))
+}
+
+func (f *fileRepo) UploadBuiltSceneChunk(ctx context.Context, content io.Reader, hash string) error {
+	sn := sanitize.Path(hash + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(gcsChunkBasePath, sn), content)
+	return err
+}
+
+func (f *fileRepo) BuiltSceneChunkUpdatedAt(ctx context.Context, hash string) (time.Time, error) {
+	if hash == "" {
+		return time.Time{}, rerror.ErrNotFound
+	}
+	return f.updatedAt(ctx, path.Join(gcsChunkBasePath, sanitize.Path(hash)+".json"))
+}
+
+func (f *fileRepo) WalkBuiltSceneChunks(ctx context.Context, fn func(string, time.Time) error) error {
+	return f.walk(ctx, gcsChunkBasePath, func(name string, updatedAt time.Time) error {
+		return fn(strings.TrimSuffix(path.Base(name), ".json"), updatedAt)
+	})
+}
+
+func (f *fileRepo) RemoveBuiltSceneChunk(ctx context.Context, hash string) error {
+	sn := sanitize.Path(hash + ".json")
+	if hash == "" || sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(gcsChunkBasePath, sn))
+}
+
+// revisions
+
+func (f *fileRepo) ReadRevisionFile(ctx context.Context, name string) (io.ReadCloser, error) {
+	if name == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, path.Join(gcsRevisionBasePath, sanitize.Path(name)+".json"))
+}
+
+func (f *fileRepo) UploadRevision(ctx context.Context, content io.Reader, name string) error {
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(gcsRevisionBasePath, sn), content)
+	return err
+}
+
+func (f *fileRepo) RemoveRevision(ctx context.Context, name string) error {
+	log.Infofc(ctx, "gcs: revision deleted: %s", name)
+
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(gcsRevisionBasePath, sn))
+}
+
+// previews
+
+func (f *fileRepo) ReadPreviewFile(ctx context.Context, name string) (io.ReadCloser, error) {
+	if name == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, path.Join(gcsPreviewBasePath, sanitize.Path(name)+".json"))
+}
+
+func (f *fileRepo) UploadPreview(ctx context.Context, content io.Reader, name string) error {
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(gcsPreviewBasePath, sn), content)
+	return err
+}
+
+func (f *fileRepo) RemovePreview(ctx context.Context, name string) error {
+	log.Infofc(ctx, "gcs: preview deleted: %s", name)
+
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(gcsPreviewBasePath, sn))
+}
+
 // helpers
 
 func (f *fileRepo) bucket(ctx context.Context) (*storage.BucketHandle, error) {
@@ -233,6 +362,52 @@ func (f *fileRepo) read(ctx context.Context, filename string) (io.ReadCloser, er
 	return reader, nil
 }
 
+func (f *fileRepo) updatedAt(ctx context.Context, filename string) (time.Time, error) {
+	bucket, err := f.bucket(ctx)
+	if err != nil {
+		log.Errorfc(ctx, "gcs: updatedAt bucket err: %+v\n", err)
+		return time.Time{}, rerror.ErrInternalByWithContext(ctx, err)
+	}
+
+	attrs, err := bucket.Object(filename).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return time.Time{}, rerror.ErrNotFound
+		}
+		log.Errorfc(ctx, "gcs: updatedAt err: %+v\n", err)
+		return time.Time{}, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return attrs.Updated, nil
+}
+
+// walk calls the function with the name and the updated time of each object under the directory.
+func (f *fileRepo) walk(ctx context.Context, dir string, fn func(string, time.Time) error) error {
+	bucket, err := f.bucket(ctx)
+	if err != nil {
+		log.Errorfc(ctx, "gcs: walk bucket err: %+v\n", err)
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+
+	it := bucket.Objects(ctx, &storage.Query{
+		Prefix: dir + "/",
+	})
+
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			log.Errorfc(ctx, "gcs: walk next err: %+v\n", err)
+			return rerror.ErrInternalByWithContext(ctx, err)
+		}
+		if err := fn(attrs.Name, attrs.Updated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (f *fileRepo) upload(ctx context.Context, filename string, content io.Reader) (int64, error) {
 	if filename == "" {
 		return 0, gateway.ErrInvalidFile
@@ -29,7 +29,10 @@ func fetchCSV(token string, fileId string, sheetName string) (io.ReadCloser, err
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
+	// sheets shared with anyone with the link can be fetched without a token
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/reearth/reearthx/util"
+)
+
+type AuditLog struct {
+	data    *util.SyncMap[id.AuditLogID, *auditlog.Log]
+	f       repo.WorkspaceFilter
+	granted id.SceneIDList
+}
+
+func NewAuditLog() *AuditLog {
+	return &AuditLog{
+		data: util.SyncMapFrom[id.AuditLogID, *auditlog.Log](nil),
+	}
+}
+
+func (r *AuditLog) Filtered(f repo.WorkspaceFilter) repo.AuditLog {
+	return &AuditLog{
+		data:    r.data,
+		f:       r.f.Merge(f),
+		granted: r.granted,
+	}
+}
+
+func (r *AuditLog) Granted(scenes id.SceneIDList) repo.AuditLog {
+	return &AuditLog{
+		data:    r.data,
+		f:       r.f,
+		granted: r.granted.AddUniq(scenes...),
+	}
+}
+
+func (r *AuditLog) FindByWorkspace(_ context.Context, wid accountdomain.WorkspaceID, f repo.AuditLogFilter, p *usecasex.Pagination) (auditlog.List, *usecasex.PageInfo, error) {
+	if !r.f.CanRead(wid) {
+		return nil, usecasex.EmptyPageInfo(), nil
+	}
+
+	res := auditlog.List(r.data.FindAll(func(_ id.AuditLogID, v *auditlog.Log) bool {
+		return v.Workspace() == wid &&
+			(f.Scene == nil || v.Scene() != nil && *v.Scene() == *f.Scene) &&
+			(f.Actor == nil || v.Actor() != nil && *v.Actor() == *f.Actor) &&
+			(len(f.Actions) == 0 || slices.Contains(f.Actions, v.Action())) &&
+			(f.Target == nil || v.HasTarget(*f.Target)) &&
+			(f.Since == nil || !v.CreatedAt().Before(*f.Since)) &&
+			(f.Until == nil || v.CreatedAt().Before(*f.Until))
+	}))
+	slices.SortStableFunc(res, func(a, b *auditlog.Log) int {
+		return b.ID().Compare(a.ID())
+	})
+	total := int64(len(res))
+
+	// only forward cursor pagination is supported
+	hasNext := false
+	if p != nil && p.Cursor != nil {
+		if p.Cursor.After != nil {
+			if i := slices.IndexFunc(res, func(l *auditlog.Log) bool { return l.ID().String() == string(*p.Cursor.After) }); i >= 0 {
+				res = res[i+1:]
+			}
+		}
+		if p.Cursor.First != nil && int64(len(res)) > *p.Cursor.First {
+			res = res[:*p.Cursor.First]
+			hasNext = true
+		}
+	}
+
+	var startCursor, endCursor *usecasex.Cursor
+	if len(res) > 0 {
+		startCursor = usecasex.Cursor(res[0].ID().String()).Ref()
+		endCursor = usecasex.Cursor(res[len(res)-1].ID().String()).Ref()
+	}
+	return res, usecasex.NewPageInfo(total, startCursor, endCursor, hasNext, false), nil
+}
+
+func (r *AuditLog) Save(_ context.Context, l *auditlog.Log) error {
+	if !r.f.CanWrite(l.Workspace()) && (l.Scene() == nil || !r.granted.Has(*l.Scene())) {
+		return repo.ErrOperationDenied
+	}
+
+	r.data.Store(l.ID(), l)
+	return nil
+}
@@ -10,9 +10,12 @@ import (
 func New() *repo.Container {
 	return &repo.Container{
 		Asset:          NewAsset(),
+		AuditLog:       NewAuditLog(),
 		Config:         NewConfig(),
 		DatasetSchema:  NewDatasetSchema(),
 		Dataset:        NewDataset(),
+		DatasetSyncLog: NewDatasetSyncLog(),
+		History:        NewHistory(),
 		Layer:          NewLayer(),
 		NLSLayer:       NewNLSLayer(),
 		Style:          NewStyle(),
@@ -20,6 +23,7 @@ func New() *repo.Container {
 		Project:        NewProject(),
 		PropertySchema: NewPropertySchema(),
 		Property:       NewProperty(),
+		Revision:       NewRevision(),
 		Scene:          NewScene(),
 		Tag:            NewTag(),
 		Workspace:      accountmemory.NewWorkspace(),
@@ -27,8 +31,10 @@ func New() *repo.Container {
 		SceneLock:      NewSceneLock(),
 		AuthRequest:    authserver.NewMemory(),
 		Policy:         NewPolicy(),
+		ProjectGrant:   NewProjectGrant(),
 		Storytelling:   NewStorytelling(),
+		UsageWarning:   NewUsageWarning(),
 		Lock:           NewLock(),
-		Transaction:    &usecasex.NopTransaction{},
+		Transaction:    repo.TransactionWithAfterCommit(&usecasex.NopTransaction{}),
 	}
 }
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/dataset"
@@ -89,6 +90,12 @@ func (r *DatasetSchema) FindBySceneAndSource(_ context.Context, s id.SceneID, sr
 	}), nil
 }
 
+func (r *DatasetSchema) FindByRefreshDue(_ context.Context, now time.Time) (dataset.SchemaList, error) {
+	return r.data.FindAll(func(_ id.DatasetSchemaID, v *dataset.Schema) bool {
+		return v.Refresh().Due(now) && r.f.CanRead(v.Scene())
+	}), nil
+}
+
 func (r *DatasetSchema) CountByScene(_ context.Context, sid id.SceneID) (int, error) {
 	return r.data.CountAll(func(k id.DatasetSchemaID, v *dataset.Schema) bool {
 		return v.Scene() == sid && r.f.CanRead(v.Scene())
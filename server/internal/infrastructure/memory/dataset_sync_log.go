@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/util"
+)
+
+type DatasetSyncLog struct {
+	data *util.SyncMap[id.DatasetSyncLogID, *dataset.SyncLog]
+	f    repo.SceneFilter
+}
+
+func NewDatasetSyncLog() *DatasetSyncLog {
+	return &DatasetSyncLog{
+		data: util.SyncMapFrom[id.DatasetSyncLogID, *dataset.SyncLog](nil),
+	}
+}
+
+func (r *DatasetSyncLog) Filtered(f repo.SceneFilter) repo.DatasetSyncLog {
+	return &DatasetSyncLog{
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *DatasetSyncLog) FindBySource(_ context.Context, sid id.SceneID, source string, limit int) (dataset.SyncLogList, error) {
+	if !r.f.CanRead(sid) {
+		return nil, nil
+	}
+
+	res := dataset.SyncLogList(r.data.FindAll(func(_ id.DatasetSyncLogID, v *dataset.SyncLog) bool {
+		return v.Scene() == sid && v.Source() == source
+	}))
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].SyncedAt().Equal(res[j].SyncedAt()) {
+			return res[i].SyncedAt().After(res[j].SyncedAt())
+		}
+		return res[i].ID().Compare(res[j].ID()) > 0
+	})
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (r *DatasetSyncLog) Save(_ context.Context, l *dataset.SyncLog) error {
+	if !r.f.CanWrite(l.Scene()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.data.Store(l.ID(), l)
+	return nil
+}
+
+func (r *DatasetSyncLog) RemoveByScene(_ context.Context, sid id.SceneID) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+	r.data.Range(func(k id.DatasetSyncLogID, v *dataset.SyncLog) bool {
+		if v.Scene() == sid {
+			r.data.Delete(k)
+		}
+		return true
+	})
+	return nil
+}
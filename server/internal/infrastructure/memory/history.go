@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/history"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+type History struct {
+	data *util.SyncMap[id.HistoryID, *history.Entry]
+	f    repo.SceneFilter
+}
+
+func NewHistory() *History {
+	return &History{
+		data: util.SyncMapFrom[id.HistoryID, *history.Entry](nil),
+	}
+}
+
+func (r *History) Filtered(f repo.SceneFilter) repo.History {
+	return &History{
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *History) FindLatest(_ context.Context, sid id.SceneID, uid accountdomain.UserID, undone bool) (*history.Entry, error) {
+	if !r.f.CanRead(sid) {
+		return nil, rerror.ErrNotFound
+	}
+
+	var res *history.Entry
+	r.data.Range(func(_ id.HistoryID, v *history.Entry) bool {
+		if v.Scene() != sid || v.User() != uid || v.IsUndone() != undone {
+			return true
+		}
+		if res == nil || undone && v.UndoneAt().After(*res.UndoneAt()) || !undone && v.ID().Compare(res.ID()) > 0 {
+			res = v
+		}
+		return true
+	})
+	if res == nil {
+		return nil, rerror.ErrNotFound
+	}
+	return res, nil
+}
+
+func (r *History) Save(_ context.Context, e *history.Entry) error {
+	if !r.f.CanWrite(e.Scene()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.data.Store(e.ID(), e)
+	return nil
+}
+
+func (r *History) RemoveUndone(_ context.Context, sid id.SceneID, uid accountdomain.UserID) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+
+	r.data.Range(func(k id.HistoryID, v *history.Entry) bool {
+		if v.Scene() == sid && v.User() == uid && v.IsUndone() {
+			r.data.Delete(k)
+		}
+		return true
+	})
+	return nil
+}
+
+func (r *History) RemoveOld(_ context.Context, sid id.SceneID, uid accountdomain.UserID, keep int) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+
+	var entries []*history.Entry
+	r.data.Range(func(_ id.HistoryID, v *history.Entry) bool {
+		if v.Scene() == sid && v.User() == uid {
+			entries = append(entries, v)
+		}
+		return true
+	})
+	if len(entries) <= keep {
+		return nil
+	}
+
+	slices.SortFunc(entries, func(a, b *history.Entry) int {
+		return b.ID().Compare(a.ID())
+	})
+	for _, e := range entries[keep:] {
+		r.data.Delete(e.ID())
+	}
+	return nil
+}
+
+func (r *History) RemoveByScene(_ context.Context, sid id.SceneID) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+
+	r.data.Range(func(k id.HistoryID, v *history.Entry) bool {
+		if v.Scene() == sid {
+			r.data.Delete(k)
+		}
+		return true
+	})
+	return nil
+}
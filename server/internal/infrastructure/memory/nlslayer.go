@@ -8,6 +8,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
 )
 
 type NLSLayer struct {
@@ -135,6 +136,11 @@ func (r *NLSLayer) Save(ctx context.Context, l nlslayer.NLSLayer) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
+	if err := checkVersion(r.data, l.ID(), l); err != nil {
+		return err
+	}
+
+	incrementVersion(l)
 	r.data[l.ID()] = l
 	return nil
 }
@@ -191,16 +197,38 @@ func (r *NLSLayer) FindByScene(ctx context.Context, sceneID id.SceneID) (nlslaye
 	return res, nil
 }
 
+func (r *NLSLayer) CountByScene(_ context.Context, sceneID id.SceneID) (n int, _ error) {
+	if !r.f.CanRead(sceneID) {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, l := range r.data {
+		if l.Scene() == sceneID {
+			n++
+		}
+	}
+	return
+}
+
 func (r *NLSLayer) SaveAll(ctx context.Context, ll nlslayer.NLSLayerList) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	for _, l := range ll {
-		layer := *l
-		if r.f.CanWrite(layer.Scene()) {
-			r.data[layer.ID()] = layer
+	layers := lo.FilterMap(ll, func(l *nlslayer.NLSLayer, _ int) (nlslayer.NLSLayer, bool) {
+		return *l, r.f.CanWrite((*l).Scene())
+	})
+	for _, l := range layers {
+		if err := checkVersion(r.data, l.ID(), l); err != nil {
+			return err
 		}
 	}
+	for _, l := range layers {
+		incrementVersion(l)
+		r.data[l.ID()] = l
+	}
 	return nil
 }
 
@@ -14,9 +14,10 @@ import (
 )
 
 type Project struct {
-	lock sync.Mutex
-	data map[id.ProjectID]*project.Project
-	f    repo.WorkspaceFilter
+	lock    sync.Mutex
+	data    map[id.ProjectID]*project.Project
+	f       repo.WorkspaceFilter
+	granted id.ProjectIDList
 }
 
 func NewProject() repo.Project {
@@ -28,8 +29,17 @@ func NewProject() repo.Project {
 func (r *Project) Filtered(f repo.WorkspaceFilter) repo.Project {
 	return &Project{
 		// note data is shared between the source repo and mutex cannot work well
-		data: r.data,
-		f:    r.f.Merge(f),
+		data:    r.data,
+		f:       r.f.Merge(f),
+		granted: r.granted,
+	}
+}
+
+func (r *Project) Granted(ids id.ProjectIDList) repo.Project {
+	return &Project{
+		data:    r.data,
+		f:       r.f,
+		granted: r.granted.AddUniq(ids...),
 	}
 }
 
@@ -89,7 +99,7 @@ func (r *Project) FindByIDs(ctx context.Context, ids id.ProjectIDList) ([]*proje
 
 	result := []*project.Project{}
 	for _, id := range ids {
-		if d, ok := r.data[id]; ok && r.f.CanRead(d.Workspace()) {
+		if d, ok := r.data[id]; ok && r.canRead(d) {
 			result = append(result, d)
 			continue
 		}
@@ -102,7 +112,7 @@ func (r *Project) FindByID(ctx context.Context, id id.ProjectID) (*project.Proje
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	if p, ok := r.data[id]; ok && r.f.CanRead(p.Workspace()) {
+	if p, ok := r.data[id]; ok && r.canRead(p) {
 		return p, nil
 	}
 	return nil, rerror.ErrNotFound
@@ -113,7 +123,7 @@ func (r *Project) FindByScene(_ context.Context, sId id.SceneID) (*project.Proje
 	defer r.lock.Unlock()
 
 	for _, d := range r.data {
-		if d.Scene() == sId && r.f.CanRead(d.Workspace()) {
+		if d.Scene() == sId && r.canRead(d) {
 			return d, nil
 		}
 	}
@@ -135,6 +145,45 @@ func (r *Project) FindByPublicName(ctx context.Context, name string) (*project.P
 	return nil, rerror.ErrNotFound
 }
 
+func (r *Project) FindByPublishScheduleDue(_ context.Context, now time.Time) (res []*project.Project, _ error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, p := range r.data {
+		if _, ok := p.PublishSchedule().Due(now); ok && r.f.CanRead(p.Workspace()) {
+			res = append(res, p)
+		}
+	}
+	return
+}
+
+func (r *Project) FindByPreviewToken(_ context.Context, token string) (*project.Project, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if token == "" {
+		return nil, rerror.ErrNotFound
+	}
+	for _, p := range r.data {
+		if p.Preview().Token() == token {
+			return p, nil
+		}
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *Project) FindByPreviewExpired(_ context.Context, now time.Time) (res []*project.Project, _ error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, p := range r.data {
+		if p.Preview() != nil && p.Preview().IsExpired(now) && r.f.CanRead(p.Workspace()) {
+			res = append(res, p)
+		}
+	}
+	return
+}
+
 func (r *Project) CountByWorkspace(_ context.Context, ws accountdomain.WorkspaceID) (n int, _ error) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -181,3 +230,7 @@ func (r *Project) Remove(ctx context.Context, id id.ProjectID) error {
 	}
 	return nil
 }
+
+func (r *Project) canRead(p *project.Project) bool {
+	return r.f.CanRead(p.Workspace()) || r.granted.Has(p.ID())
+}
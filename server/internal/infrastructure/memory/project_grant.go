@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/projectgrant"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+type ProjectGrant struct {
+	data *util.SyncMap[id.ProjectGrantID, *projectgrant.Grant]
+	f    repo.WorkspaceFilter
+}
+
+func NewProjectGrant() *ProjectGrant {
+	return &ProjectGrant{
+		data: util.SyncMapFrom[id.ProjectGrantID, *projectgrant.Grant](nil),
+	}
+}
+
+func (r *ProjectGrant) Filtered(f repo.WorkspaceFilter) repo.ProjectGrant {
+	return &ProjectGrant{
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *ProjectGrant) FindByProject(_ context.Context, pid id.ProjectID) (projectgrant.List, error) {
+	return r.find(func(g *projectgrant.Grant) bool {
+		return g.Project() == pid
+	}), nil
+}
+
+func (r *ProjectGrant) FindByProjectAndUser(_ context.Context, pid id.ProjectID, uid accountdomain.UserID) (*projectgrant.Grant, error) {
+	res := r.find(func(g *projectgrant.Grant) bool {
+		return g.Project() == pid && g.User() == uid
+	})
+	if len(res) == 0 {
+		return nil, rerror.ErrNotFound
+	}
+	return res[0], nil
+}
+
+func (r *ProjectGrant) FindByUser(_ context.Context, uid accountdomain.UserID) (projectgrant.List, error) {
+	return r.find(func(g *projectgrant.Grant) bool {
+		return g.User() == uid
+	}), nil
+}
+
+func (r *ProjectGrant) Save(_ context.Context, g *projectgrant.Grant) error {
+	if !r.f.CanWrite(g.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.data.Store(g.ID(), g)
+	return nil
+}
+
+func (r *ProjectGrant) Remove(_ context.Context, gid id.ProjectGrantID) error {
+	if g, ok := r.data.Load(gid); ok && r.f.CanWrite(g.Workspace()) {
+		r.data.Delete(gid)
+	}
+	return nil
+}
+
+func (r *ProjectGrant) RemoveByProject(_ context.Context, pid id.ProjectID) error {
+	r.data.Range(func(k id.ProjectGrantID, v *projectgrant.Grant) bool {
+		if v.Project() == pid && r.f.CanWrite(v.Workspace()) {
+			r.data.Delete(k)
+		}
+		return true
+	})
+	return nil
+}
+
+func (r *ProjectGrant) find(f func(*projectgrant.Grant) bool) projectgrant.List {
+	var res projectgrant.List
+	r.data.Range(func(_ id.ProjectGrantID, v *projectgrant.Grant) bool {
+		if r.f.CanRead(v.Workspace()) && f(v) {
+			res = append(res, v)
+		}
+		return true
+	})
+	// keep the order stable as the map is not ordered
+	slices.SortFunc(res, func(a, b *projectgrant.Grant) int {
+		return a.ID().Compare(b.ID())
+	})
+	return res
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/reearth/reearthx/rerror"
 
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/samber/lo"
 )
 
 type Property struct {
@@ -78,6 +79,24 @@ func (r *Property) FindByDataset(ctx context.Context, sid id.DatasetSchemaID, di
 	return result, nil
 }
 
+func (r *Property) FindByScene(_ context.Context, s id.SceneID) (property.List, error) {
+	if !r.f.CanRead(s) {
+		return nil, nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	result := property.List{}
+	for _, p := range r.data {
+		if p.Scene() == s {
+			result = append(result, p)
+		}
+	}
+	result.Sort()
+	return result, nil
+}
+
 func (r *Property) FindLinkedAll(ctx context.Context, s id.SceneID) (property.List, error) {
 	if !r.f.CanRead(s) {
 		return nil, nil
@@ -146,6 +165,11 @@ func (r *Property) Save(ctx context.Context, p *property.Property) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
+	if err := checkVersion(r.data, p.ID(), p); err != nil {
+		return err
+	}
+
+	incrementVersion(p)
 	r.data[p.ID()] = p
 	return nil
 }
@@ -154,11 +178,16 @@ func (r *Property) SaveAll(ctx context.Context, pl property.List) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
+	pl = lo.Filter(pl, func(p *property.Property, _ int) bool { return r.f.CanWrite(p.Scene()) })
 	for _, p := range pl {
-		if r.f.CanWrite(p.Scene()) {
-			r.data[p.ID()] = p
+		if err := checkVersion(r.data, p.ID(), p); err != nil {
+			return err
 		}
 	}
+	for _, p := range pl {
+		incrementVersion(p)
+		r.data[p.ID()] = p
+	}
 	return nil
 }
 
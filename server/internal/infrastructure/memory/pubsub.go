@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/log"
+)
+
+// pubSubBufferSize is the number of events buffered for each subscriber. Events are dropped for subscribers which do not keep up.
+const pubSubBufferSize = 64
+
+// PubSub delivers events to the subscribers in the same process.
+type PubSub struct {
+	lock sync.RWMutex
+	subs map[id.SceneID]map[chan gateway.SceneEvent]struct{}
+}
+
+func NewPubSub() *PubSub {
+	return &PubSub{
+		subs: map[id.SceneID]map[chan gateway.SceneEvent]struct{}{},
+	}
+}
+
+func (p *PubSub) PublishSceneEvent(ctx context.Context, e gateway.SceneEvent) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for ch := range p.subs[e.Scene] {
+		select {
+		case ch <- e:
+		default:
+			log.Warnfc(ctx, "pubsub: dropped %s event of scene %s for a slow subscriber", e.Action, e.Scene)
+		}
+	}
+	return nil
+}
+
+func (p *PubSub) SubscribeSceneEvents(ctx context.Context, sid id.SceneID) (<-chan gateway.SceneEvent, error) {
+	ch := make(chan gateway.SceneEvent, pubSubBufferSize)
+
+	p.lock.Lock()
+	if p.subs[sid] == nil {
+		p.subs[sid] = map[chan gateway.SceneEvent]struct{}{}
+	}
+	p.subs[sid][ch] = struct{}{}
+	p.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		delete(p.subs[sid], ch)
+		if len(p.subs[sid]) == 0 {
+			delete(p.subs, sid)
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
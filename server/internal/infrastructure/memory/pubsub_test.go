@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPubSub(t *testing.T) {
+	ctx := context.Background()
+	sid1, sid2 := id.NewSceneID(), id.NewSceneID()
+	p := NewPubSub()
+
+	ctx1, cancel1 := context.WithCancel(ctx)
+	ch1, err := p.SubscribeSceneEvents(ctx1, sid1)
+	assert.NoError(t, err)
+	ctx2, cancel2 := context.WithCancel(ctx)
+	defer cancel2()
+	ch2, err := p.SubscribeSceneEvents(ctx2, sid2)
+	assert.NoError(t, err)
+
+	e := gateway.SceneEvent{
+		Scene:     sid1,
+		Action:    auditlog.ActionLayerUpdate,
+		Targets:   []auditlog.Target{auditlog.TargetOf(id.NewLayerID())},
+		CreatedAt: time.Now(),
+	}
+	assert.NoError(t, p.PublishSceneEvent(ctx, e))
+
+	// only the subscribers of the scene receive the event
+	assert.Equal(t, e, <-ch1)
+	assert.Empty(t, ch2)
+
+	// the channel is closed when the context is done
+	cancel1()
+	_, ok := <-ch1
+	assert.False(t, ok)
+	assert.Eventually(t, func() bool {
+		p.lock.RLock()
+		defer p.lock.RUnlock()
+		return p.subs[sid1] == nil
+	}, time.Second, 10*time.Millisecond)
+	assert.NoError(t, p.PublishSceneEvent(ctx, e))
+}
+
+func TestPubSub_SlowSubscriber(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sid := id.NewSceneID()
+	p := NewPubSub()
+
+	ch, err := p.SubscribeSceneEvents(ctx, sid)
+	assert.NoError(t, err)
+
+	// publishing never blocks even if the subscriber does not receive events
+	for i := 0; i < pubSubBufferSize+1; i++ {
+		assert.NoError(t, p.PublishSceneEvent(ctx, gateway.SceneEvent{Scene: sid, Action: auditlog.ActionLayerUpdate}))
+	}
+	assert.Len(t, ch, pubSubBufferSize)
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/revision"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type Revision struct {
+	lock sync.Mutex
+	data map[id.RevisionID]*revision.Revision
+	f    repo.WorkspaceFilter
+}
+
+func NewRevision() repo.Revision {
+	return &Revision{
+		data: map[id.RevisionID]*revision.Revision{},
+	}
+}
+
+func NewRevisionWith(items ...*revision.Revision) repo.Revision {
+	r := NewRevision()
+	ctx := context.Background()
+	for _, i := range items {
+		_ = r.Save(ctx, i)
+	}
+	return r
+}
+
+func (r *Revision) Filtered(f repo.WorkspaceFilter) repo.Revision {
+	return &Revision{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *Revision) FindByID(ctx context.Context, id id.RevisionID) (*revision.Revision, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if d, ok := r.data[id]; ok && r.f.CanRead(d.Workspace()) {
+		return d, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *Revision) FindByProject(ctx context.Context, id id.ProjectID) (revision.List, error) {
+	return r.find(func(d *revision.Revision) bool {
+		p := d.Project()
+		return p != nil && *p == id
+	}), nil
+}
+
+func (r *Revision) FindByStory(ctx context.Context, id id.StoryID) (revision.List, error) {
+	return r.find(func(d *revision.Revision) bool {
+		s := d.Story()
+		return s != nil && *s == id
+	}), nil
+}
+
+func (r *Revision) Save(ctx context.Context, d *revision.Revision) error {
+	if !r.f.CanWrite(d.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[d.ID()] = d
+	return nil
+}
+
+func (r *Revision) RemoveByProject(ctx context.Context, id id.ProjectID) error {
+	return r.remove(func(d *revision.Revision) bool {
+		p := d.Project()
+		return p != nil && *p == id
+	})
+}
+
+func (r *Revision) RemoveByStory(ctx context.Context, id id.StoryID) error {
+	return r.remove(func(d *revision.Revision) bool {
+		s := d.Story()
+		return s != nil && *s == id
+	})
+}
+
+func (r *Revision) find(f func(*revision.Revision) bool) revision.List {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := revision.List{}
+	for _, d := range r.data {
+		if r.f.CanRead(d.Workspace()) && f(d) {
+			res = append(res, d)
+		}
+	}
+	return res.Sorted()
+}
+
+func (r *Revision) remove(f func(*revision.Revision) bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for rid, d := range r.data {
+		if r.f.CanWrite(d.Workspace()) && f(d) {
+			delete(r.data, rid)
+		}
+	}
+	return nil
+}
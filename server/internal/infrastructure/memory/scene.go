@@ -14,9 +14,11 @@ import (
 )
 
 type Scene struct {
-	lock sync.Mutex
-	data map[id.SceneID]*scene.Scene
-	f    repo.WorkspaceFilter
+	lock            sync.Mutex
+	data            map[id.SceneID]*scene.Scene
+	f               repo.WorkspaceFilter
+	granted         id.SceneIDList
+	grantedWritable id.SceneIDList
 }
 
 func NewScene() *Scene {
@@ -37,8 +39,19 @@ func NewSceneWith(items ...*scene.Scene) *Scene {
 func (r *Scene) Filtered(f repo.WorkspaceFilter) repo.Scene {
 	return &Scene{
 		// note data is shared between the source repo and mutex cannot work well
-		data: r.data,
-		f:    r.f.Merge(f),
+		data:            r.data,
+		f:               r.f.Merge(f),
+		granted:         r.granted,
+		grantedWritable: r.grantedWritable,
+	}
+}
+
+func (r *Scene) Granted(readable, writable id.SceneIDList) repo.Scene {
+	return &Scene{
+		data:            r.data,
+		f:               r.f,
+		granted:         r.granted.AddUniq(readable...).AddUniq(writable...),
+		grantedWritable: r.grantedWritable.AddUniq(writable...),
 	}
 }
 
@@ -46,7 +59,7 @@ func (r *Scene) FindByID(ctx context.Context, id id.SceneID) (*scene.Scene, erro
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	if s, ok := r.data[id]; ok && r.f.CanRead(s.Workspace()) {
+	if s, ok := r.data[id]; ok && r.canRead(s) {
 		return s, nil
 	}
 	return nil, rerror.ErrNotFound
@@ -58,7 +71,7 @@ func (r *Scene) FindByIDs(ctx context.Context, ids id.SceneIDList) (scene.List,
 
 	result := scene.List{}
 	for _, id := range ids {
-		if d, ok := r.data[id]; ok && r.f.CanRead(d.Workspace()) {
+		if d, ok := r.data[id]; ok && r.canRead(d) {
 			result = append(result, d)
 			continue
 		}
@@ -73,7 +86,7 @@ func (r *Scene) FindByProject(ctx context.Context, id id.ProjectID) (*scene.Scen
 	defer r.lock.Unlock()
 
 	for _, d := range r.data {
-		if d.Project() == id && r.f.CanRead(d.Workspace()) {
+		if d.Project() == id && r.canRead(d) {
 			return d, nil
 		}
 	}
@@ -94,13 +107,18 @@ func (r *Scene) FindByWorkspace(ctx context.Context, workspaces ...accountdomain
 }
 
 func (r *Scene) Save(ctx context.Context, s *scene.Scene) error {
-	if !r.f.CanWrite(s.Workspace()) {
+	if !r.canWrite(s) {
 		return repo.ErrOperationDenied
 	}
 
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
+	if err := checkVersion(r.data, s.ID(), s); err != nil {
+		return err
+	}
+
+	incrementVersion(s)
 	s.SetUpdatedAt(time.Now())
 	r.data[s.ID()] = s
 	return nil
@@ -116,3 +134,12 @@ func (r *Scene) Remove(ctx context.Context, id id.SceneID) error {
 
 	return nil
 }
+
+func (r *Scene) canRead(s *scene.Scene) bool {
+	return r.f.CanRead(s.Workspace()) || r.granted.Has(s.ID())
+}
+
+// canWrite reports whether the scene can be saved. The scenes granted to edit can be saved, but can not be removed.
+func (r *Scene) canWrite(s *scene.Scene) bool {
+	return r.f.CanWrite(s.Workspace()) || r.grantedWritable.Has(s.ID())
+}
@@ -90,7 +90,20 @@ func (r *Storytelling) FindByPublicName(ctx context.Context, name string) (*stor
 	return nil, rerror.ErrNotFound
 }
 
-func (r *Storytelling) Save(_ context.Context, p storytelling.Story) error {
+func (r *Storytelling) FindByPublishScheduleDue(_ context.Context, now time.Time) (*storytelling.StoryList, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	result := storytelling.StoryList{}
+	for _, s := range r.data {
+		if _, ok := s.PublishSchedule().Due(now); ok && r.f.CanRead(s.Scene()) {
+			result = append(result, s)
+		}
+	}
+	return &result, nil
+}
+
+func (r *Storytelling) Save(_ context.Context, p *storytelling.Story) error {
 	if !r.f.CanWrite(p.Scene()) {
 		return repo.ErrOperationDenied
 	}
@@ -98,8 +111,13 @@ func (r *Storytelling) Save(_ context.Context, p storytelling.Story) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
+	if err := checkVersion(r.data, p.Id(), p); err != nil {
+		return err
+	}
+
+	incrementVersion(p)
 	p.SetUpdatedAt(time.Now())
-	r.data[p.Id()] = &p
+	r.data[p.Id()] = p
 	return nil
 }
 
@@ -114,6 +132,12 @@ func (r *Storytelling) SaveAll(_ context.Context, sl storytelling.StoryList) err
 	defer r.lock.Unlock()
 
 	for _, s := range sl {
+		if err := checkVersion(r.data, s.Id(), s); err != nil {
+			return err
+		}
+	}
+	for _, s := range sl {
+		incrementVersion(s)
 		s.SetUpdatedAt(time.Now())
 		r.data[s.Id()] = s
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
 )
 
 type Style struct {
@@ -26,7 +27,7 @@ func NewStyleWith(items ...scene.Style) repo.Style {
 	r := NewStyle()
 	ctx := context.Background()
 	for _, i := range items {
-		_ = r.Save(ctx, i)
+		_ = r.Save(ctx, &i)
 	}
 	return r
 }
@@ -65,7 +66,7 @@ func (r *Style) FindByIDs(ctx context.Context, ids id.StyleIDList) (*scene.Style
 	return &result, nil
 }
 
-func (r *Style) Save(ctx context.Context, l scene.Style) error {
+func (r *Style) Save(ctx context.Context, l *scene.Style) error {
 	if !r.f.CanWrite(l.Scene()) {
 		return repo.ErrOperationDenied
 	}
@@ -73,7 +74,20 @@ func (r *Style) Save(ctx context.Context, l scene.Style) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	r.data[l.ID()] = l
+	if err := r.checkVersion(l); err != nil {
+		return err
+	}
+
+	incrementVersion(l)
+	r.data[l.ID()] = *l
+	return nil
+}
+
+// checkVersion works as checkVersion does for styles, which are stored as values.
+func (r *Style) checkVersion(l *scene.Style) error {
+	if stored, ok := r.data[l.ID()]; ok && stored.Version() != l.Version() {
+		return repo.NewVersionConflictError(l.ID(), l.Version())
+	}
 	return nil
 }
 
@@ -99,12 +113,16 @@ func (r *Style) SaveAll(ctx context.Context, ll scene.StyleList) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
+	ll = lo.Filter(ll, func(l *scene.Style, _ int) bool { return r.f.CanWrite(l.Scene()) })
 	for _, l := range ll {
-		layer := *l
-		if r.f.CanWrite(layer.Scene()) {
-			r.data[layer.ID()] = layer
+		if err := r.checkVersion(l); err != nil {
+			return err
 		}
 	}
+	for _, l := range ll {
+		incrementVersion(l)
+		r.data[l.ID()] = *l
+	}
 	return nil
 }
 
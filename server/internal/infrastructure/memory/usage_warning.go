@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type usageWarning struct {
+	lock sync.Mutex
+	data map[accountdomain.WorkspaceID]map[string]float64
+}
+
+func NewUsageWarning() repo.UsageWarning {
+	return &usageWarning{
+		data: map[accountdomain.WorkspaceID]map[string]float64{},
+	}
+}
+
+func (r *usageWarning) FindByWorkspace(_ context.Context, wid accountdomain.WorkspaceID) (map[string]float64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := map[string]float64{}
+	for k, v := range r.data[wid] {
+		res[k] = v
+	}
+	return res, nil
+}
+
+func (r *usageWarning) Save(_ context.Context, wid accountdomain.WorkspaceID, item string, threshold float64) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.data[wid] == nil {
+		r.data[wid] = map[string]float64{}
+	}
+	r.data[wid][item] = threshold
+	return nil
+}
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+)
+
+type versioned interface {
+	Version() int
+	SetVersion(int)
+}
+
+// checkVersion returns an error if the object has been saved since the given one was loaded.
+func checkVersion[K interface {
+	comparable
+	fmt.Stringer
+}, V versioned](data map[K]V, k K, o versioned) error {
+	if stored, ok := data[k]; ok && stored.Version() != o.Version() {
+		return repo.NewVersionConflictError(k, o.Version())
+	}
+	return nil
+}
+
+func incrementVersion(o versioned) {
+	o.SetVersion(o.Version() + 1)
+}
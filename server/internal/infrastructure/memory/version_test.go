@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProperty_Save_Version(t *testing.T) {
+	ctx := context.Background()
+	r := NewProperty()
+	p := property.New().NewID().Scene(id.NewSceneID()).Schema(id.MustPropertySchemaID("xxx~1.1.1/aa")).MustBuild()
+	assert.NoError(t, r.Save(ctx, p))
+	assert.Equal(t, 1, p.Version())
+
+	stale := p.Clone()
+	assert.NoError(t, r.Save(ctx, p))
+	assert.Equal(t, 2, p.Version())
+
+	err := r.Save(ctx, stale)
+	assert.True(t, errors.Is(err, repo.ErrVersionConflict))
+	assert.Equal(t, repo.NewVersionConflictError(p.ID(), 1), err)
+	assert.Equal(t, 1, stale.Version())
+
+	// nothing is saved if any of the properties is stale
+	p2 := property.New().NewID().Scene(p.Scene()).Schema(p.Schema()).MustBuild()
+	err = r.SaveAll(ctx, property.List{p2, stale})
+	assert.True(t, errors.Is(err, repo.ErrVersionConflict))
+	assert.Equal(t, 0, p2.Version())
+	_, err = r.FindByID(ctx, p2.ID())
+	assert.Error(t, err)
+}
+
+func TestStyle_Save_Version(t *testing.T) {
+	ctx := context.Background()
+	r := NewStyle()
+	s := scene.NewStyle().NewID().Scene(id.NewSceneID()).Name("style").Value(&scene.StyleValue{}).MustBuild()
+	assert.NoError(t, r.Save(ctx, s))
+
+	s1, _ := r.FindByID(ctx, s.ID())
+	s2, _ := r.FindByID(ctx, s.ID())
+	assert.NoError(t, r.Save(ctx, s1))
+	assert.True(t, errors.Is(r.Save(ctx, s2), repo.ErrVersionConflict))
+
+	got, _ := r.FindByID(ctx, s.ID())
+	assert.Equal(t, 2, got.Version())
+}
@@ -193,6 +193,10 @@ func (r *Asset) FindByWorkspace(ctx context.Context, id accountdomain.WorkspaceI
 		absoluteFilter["name"] = bson.M{"$regex": keywordRegex}
 	}
 
+	if p := contentTypePattern(f.ContentTypes); p != "" {
+		absoluteFilter["contenttype"] = bson.M{"$regex": primitive.Regex{Pattern: p, Options: "i"}}
+	}
+
 	totalCount, err := r.client.Client().CountDocuments(ctx, absoluteFilter)
 	if err != nil {
 		return nil, nil, err
@@ -337,3 +341,23 @@ func filterAssets(ids []id.AssetID, rows []*asset.Asset) []*asset.Asset {
 func (r *Asset) writeFilter(filter any) any {
 	return applyWorkspaceFilter(filter, r.f.Writable)
 }
+
+// contentTypePattern returns a regex that matches the content types in the same way as asset.MatchContentType.
+func contentTypePattern(types []string) string {
+	var patterns []string
+	for _, t := range types {
+		t = asset.MediaType(t)
+		if t == "" {
+			continue
+		}
+		if strings.Contains(t, "/") {
+			patterns = append(patterns, regexp.QuoteMeta(t)+`\s*(;|$)`)
+		} else {
+			patterns = append(patterns, regexp.QuoteMeta(t)+"/")
+		}
+	}
+	if len(patterns) == 0 {
+		return ""
+	}
+	return `^\s*(` + strings.Join(patterns, "|") + ")"
+}
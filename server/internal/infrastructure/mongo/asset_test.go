@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"regexp"
 	"testing"
 	"time"
 
@@ -91,3 +92,16 @@ func TestAsset_TotalSizeByWorkspace(t *testing.T) {
 	assert.Equal(t, repo.ErrOperationDenied, err)
 	assert.Zero(t, got)
 }
+
+func TestContentTypePattern(t *testing.T) {
+	assert.Equal(t, "", contentTypePattern(nil))
+	assert.Equal(t, "", contentTypePattern([]string{" "}))
+
+	p := regexp.MustCompile("(?i)" + contentTypePattern([]string{"image", "Text/Plain", "model/gltf+json"}))
+	assert.True(t, p.MatchString("image/png"))
+	assert.True(t, p.MatchString("text/plain; charset=utf-8"))
+	assert.True(t, p.MatchString("model/gltf+json"))
+	assert.False(t, p.MatchString("text/plainx"))
+	assert.False(t, p.MatchString("model/gltfxjson"))
+	assert.False(t, p.MatchString("video/image"))
+}
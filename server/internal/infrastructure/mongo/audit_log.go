@@ -0,0 +1,99 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+)
+
+var (
+	auditLogIndexes       = []string{"workspace", "workspace,scene", "workspace,actor", "workspace,targets.id", "createdat"}
+	auditLogUniqueIndexes = []string{"id"}
+)
+
+type AuditLog struct {
+	client  *mongox.ClientCollection
+	f       repo.WorkspaceFilter
+	granted id.SceneIDList
+}
+
+func NewAuditLog(client *mongox.Client) *AuditLog {
+	return &AuditLog{
+		client: client.WithCollection("auditLog"),
+	}
+}
+
+func (r *AuditLog) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, auditLogIndexes, auditLogUniqueIndexes)
+}
+
+func (r *AuditLog) Filtered(f repo.WorkspaceFilter) repo.AuditLog {
+	return &AuditLog{
+		client:  r.client,
+		f:       r.f.Merge(f),
+		granted: r.granted,
+	}
+}
+
+func (r *AuditLog) Granted(scenes id.SceneIDList) repo.AuditLog {
+	return &AuditLog{
+		client:  r.client,
+		f:       r.f,
+		granted: r.granted.AddUniq(scenes...),
+	}
+}
+
+func (r *AuditLog) FindByWorkspace(ctx context.Context, wid accountdomain.WorkspaceID, f repo.AuditLogFilter, p *usecasex.Pagination) (auditlog.List, *usecasex.PageInfo, error) {
+	if !r.f.CanRead(wid) {
+		return nil, usecasex.EmptyPageInfo(), nil
+	}
+
+	filter := bson.M{"workspace": wid.String()}
+	if f.Scene != nil {
+		filter["scene"] = f.Scene.String()
+	}
+	if f.Actor != nil {
+		filter["actor"] = f.Actor.String()
+	}
+	if len(f.Actions) > 0 {
+		filter["action"] = bson.M{"$in": lo.Map(f.Actions, func(a auditlog.Action, _ int) string { return string(a) })}
+	}
+	if f.Target != nil {
+		filter["targets.id"] = *f.Target
+	}
+	if f.Since != nil || f.Until != nil {
+		createdAt := bson.M{}
+		if f.Since != nil {
+			createdAt["$gte"] = *f.Since
+		}
+		if f.Until != nil {
+			createdAt["$lt"] = *f.Until
+		}
+		filter["createdat"] = createdAt
+	}
+
+	c := mongodoc.NewAuditLogConsumer(r.f.Readable)
+	pageInfo, err := r.client.Paginate(ctx, filter, &usecasex.Sort{Key: "id", Reverted: true}, p, c)
+	if err != nil {
+		return nil, nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, pageInfo, nil
+}
+
+func (r *AuditLog) Save(ctx context.Context, l *auditlog.Log) error {
+	if !r.f.CanWrite(l.Workspace()) && (l.Scene() == nil || !r.granted.Has(*l.Scene())) {
+		return repo.ErrOperationDenied
+	}
+	doc, id := mongodoc.NewAuditLog(l)
+	return r.client.SaveOne(ctx, id, doc)
+}
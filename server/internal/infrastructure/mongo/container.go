@@ -38,10 +38,13 @@ func New(ctx context.Context, db *mongo.Database, accountContainerWithReearthAcc
 
 	c := &repo.Container{
 		Asset:          NewAsset(reearthDbClient),
+		AuditLog:       NewAuditLog(reearthDbClient),
 		AuthRequest:    authserver.NewMongo(reearthDbClient.WithCollection("authRequest")),
 		Config:         NewConfig(db.Collection("config"), lock),
 		DatasetSchema:  NewDatasetSchema(reearthDbClient),
 		Dataset:        NewDataset(reearthDbClient),
+		DatasetSyncLog: NewDatasetSyncLog(reearthDbClient),
+		History:        NewHistory(reearthDbClient),
 		Layer:          NewLayer(reearthDbClient),
 		NLSLayer:       NewNLSLayer(reearthDbClient),
 		Style:          NewStyle(reearthDbClient),
@@ -49,15 +52,18 @@ func New(ctx context.Context, db *mongo.Database, accountContainerWithReearthAcc
 		Project:        NewProject(reearthDbClient),
 		PropertySchema: NewPropertySchema(reearthDbClient),
 		Property:       NewProperty(reearthDbClient),
+		Revision:       NewRevision(reearthDbClient),
 		Scene:          NewScene(reearthDbClient),
 		Tag:            NewTag(reearthDbClient),
 		SceneLock:      NewSceneLock(reearthDbClient),
 		Permittable:    NewPermittableWrapper(reearthDbClient), // TODO: Delete this once the permission check migration is complete.
 		Policy:         NewPolicy(reearthDbClient),
+		ProjectGrant:   NewProjectGrant(reearthDbClient),
 		Role:           NewRoleWrapper(reearthDbClient), // TODO: Delete this once the permission check migration is complete.
 		Storytelling:   NewStorytelling(reearthDbClient),
+		UsageWarning:   NewUsageWarning(reearthDbClient),
 		Lock:           lock,
-		Transaction:    reearthDbClient.Transaction(),
+		Transaction:    repo.TransactionWithAfterCommit(reearthDbClient.Transaction()),
 		Workspace:      accountContainerWithReearthAccountDbClient.Workspace,
 		User:           accountContainerWithReearthAccountDbClient.User,
 	}
@@ -121,19 +127,25 @@ func Init(r *repo.Container) error {
 	ctx := context.Background()
 	return util.Try(
 		func() error { return r.Asset.(*Asset).Init(ctx) },
+		func() error { return r.AuditLog.(*AuditLog).Init(ctx) },
 		func() error { return r.AuthRequest.(*authserver.Mongo).Init(ctx) },
 		func() error { return r.Dataset.(*Dataset).Init(ctx) },
 		func() error { return r.DatasetSchema.(*DatasetSchema).Init(ctx) },
+		func() error { return r.DatasetSyncLog.(*DatasetSyncLog).Init(ctx) },
+		func() error { return r.History.(*History).Init(ctx) },
 		func() error { return r.Layer.(*Layer).Init(ctx) },
 		func() error { return r.Permittable.(*PermittableWrapper).Init(ctx) }, // TODO: Delete this once the permission check migration is complete.
 		func() error { return r.Plugin.(*Plugin).Init(ctx) },
 		func() error { return r.Policy.(*Policy).Init(ctx) },
 		func() error { return r.Project.(*Project).Init(ctx) },
+		func() error { return r.ProjectGrant.(*ProjectGrant).Init(ctx) },
 		func() error { return r.Property.(*Property).Init(ctx) },
 		func() error { return r.PropertySchema.(*PropertySchema).Init(ctx) },
+		func() error { return r.Revision.(*Revision).Init(ctx) },
 		func() error { return r.Role.(*RoleWrapper).Init(ctx) }, // TODO: Delete this once the permission check migration is complete.
 		func() error { return r.Scene.(*Scene).Init(ctx) },
 		func() error { return r.Tag.(*Tag).Init(ctx) },
+		func() error { return r.UsageWarning.(*UsageWarning).Init(ctx) },
 		// User and Workspace are already initialized in accountRepoContainer
 	)
 }
@@ -20,7 +20,7 @@ import (
 )
 
 var (
-	datasetSchemaIndexes       = []string{"scene"}
+	datasetSchemaIndexes       = []string{"scene", "refresh.nextat"}
 	datasetSchemaUniqueIndexes = []string{"id"}
 )
 
@@ -226,6 +226,12 @@ func (r *DatasetSchema) FindBySceneAndSource(ctx context.Context, sceneID id.Sce
 	})
 }
 
+func (r *DatasetSchema) FindByRefreshDue(ctx context.Context, now time.Time) (dataset.SchemaList, error) {
+	return r.find(ctx, bson.M{
+		"refresh.nextat": bson.M{"$lte": now},
+	})
+}
+
 func (r *DatasetSchema) CountByScene(ctx context.Context, id id.SceneID) (int, error) {
 	if r.f.Readable != nil && !slices.Contains(r.f.Readable, id) {
 		return 0, nil
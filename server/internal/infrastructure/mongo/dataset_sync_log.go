@@ -0,0 +1,74 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/mongox"
+)
+
+var (
+	datasetSyncLogIndexes       = []string{"scene", "scene,source"}
+	datasetSyncLogUniqueIndexes = []string{"id"}
+)
+
+type DatasetSyncLog struct {
+	client *mongox.ClientCollection
+	f      repo.SceneFilter
+}
+
+func NewDatasetSyncLog(client *mongox.Client) *DatasetSyncLog {
+	return &DatasetSyncLog{
+		client: client.WithCollection("datasetSyncLog"),
+	}
+}
+
+func (r *DatasetSyncLog) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, datasetSyncLogIndexes, datasetSyncLogUniqueIndexes)
+}
+
+func (r *DatasetSyncLog) Filtered(f repo.SceneFilter) repo.DatasetSyncLog {
+	return &DatasetSyncLog{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *DatasetSyncLog) FindBySource(ctx context.Context, sid id.SceneID, source string, limit int) (dataset.SyncLogList, error) {
+	if !r.f.CanRead(sid) {
+		return nil, nil
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "syncedat", Value: -1}, {Key: "id", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	c := mongodoc.NewDatasetSyncLogConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, bson.M{
+		"scene":  sid.String(),
+		"source": source,
+	}, c, opts); err != nil {
+		return nil, err
+	}
+	return c.Result, nil
+}
+
+func (r *DatasetSyncLog) Save(ctx context.Context, l *dataset.SyncLog) error {
+	if !r.f.CanWrite(l.Scene()) {
+		return repo.ErrOperationDenied
+	}
+	doc, id := mongodoc.NewDatasetSyncLog(l)
+	return r.client.SaveOne(ctx, id, doc)
+}
+
+func (r *DatasetSyncLog) RemoveByScene(ctx context.Context, sid id.SceneID) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+	return r.client.RemoveAll(ctx, bson.M{"scene": sid.String()})
+}
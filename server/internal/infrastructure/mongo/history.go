@@ -0,0 +1,119 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/history"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var (
+	historyIndexes       = []string{"scene", "scene,user"}
+	historyUniqueIndexes = []string{"id"}
+)
+
+type History struct {
+	client *mongox.ClientCollection
+	f      repo.SceneFilter
+}
+
+func NewHistory(client *mongox.Client) *History {
+	return &History{
+		client: client.WithCollection("history"),
+	}
+}
+
+func (r *History) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, historyIndexes, historyUniqueIndexes)
+}
+
+func (r *History) Filtered(f repo.SceneFilter) repo.History {
+	return &History{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *History) FindLatest(ctx context.Context, sid id.SceneID, uid accountdomain.UserID, undone bool) (*history.Entry, error) {
+	if !r.f.CanRead(sid) {
+		return nil, rerror.ErrNotFound
+	}
+
+	filter := bson.M{
+		"scene": sid.String(),
+		"user":  uid.String(),
+	}
+	sort := bson.D{{Key: "id", Value: -1}}
+	if undone {
+		filter["undoneat"] = bson.M{"$ne": nil}
+		sort = bson.D{{Key: "undoneat", Value: -1}}
+	} else {
+		filter["undoneat"] = nil
+	}
+
+	c := mongodoc.NewHistoryConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, filter, c, options.Find().SetSort(sort).SetLimit(1)); err != nil {
+		return nil, err
+	}
+	if len(c.Result) == 0 {
+		return nil, rerror.ErrNotFound
+	}
+	return c.Result[0], nil
+}
+
+func (r *History) Save(ctx context.Context, e *history.Entry) error {
+	if !r.f.CanWrite(e.Scene()) {
+		return repo.ErrOperationDenied
+	}
+	doc, id := mongodoc.NewHistory(e)
+	return r.client.SaveOne(ctx, id, doc)
+}
+
+func (r *History) RemoveUndone(ctx context.Context, sid id.SceneID, uid accountdomain.UserID) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+	return r.client.RemoveAll(ctx, bson.M{
+		"scene":    sid.String(),
+		"user":     uid.String(),
+		"undoneat": bson.M{"$ne": nil},
+	})
+}
+
+func (r *History) RemoveOld(ctx context.Context, sid id.SceneID, uid accountdomain.UserID, keep int) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+
+	filter := bson.M{
+		"scene": sid.String(),
+		"user":  uid.String(),
+	}
+
+	// find the latest entry to remove; IDs of entries are ordered by their creation
+	c := mongodoc.NewHistoryConsumer(nil)
+	if err := r.client.Find(ctx, filter, c, options.Find().SetSort(bson.D{{Key: "id", Value: -1}}).SetSkip(int64(keep)).SetLimit(1)); err != nil {
+		return err
+	}
+	if len(c.Result) == 0 {
+		return nil
+	}
+
+	filter["id"] = bson.M{"$lte": c.Result[0].ID().String()}
+	return r.client.RemoveAll(ctx, filter)
+}
+
+func (r *History) RemoveByScene(ctx context.Context, sid id.SceneID) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+	return r.client.RemoveAll(ctx, bson.M{"scene": sid.String()})
+}
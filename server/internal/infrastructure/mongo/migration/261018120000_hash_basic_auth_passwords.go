@@ -0,0 +1,82 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/basicauth"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/util"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// HashBasicAuthPasswords converts plaintext basic auth usernames and passwords of projects and stories
+// into the default basic auth credential whose password is stored as a salted hash.
+func HashBasicAuthPasswords(ctx context.Context, c DBClient) error {
+	for _, name := range []string{"project", "storytelling"} {
+		if err := hashBasicAuthPasswords(ctx, c, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hashBasicAuthPasswords(ctx context.Context, c DBClient, collection string) error {
+	col := c.WithCollection(collection)
+	now := util.Now()
+
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"basicauthusername": bson.M{"$exists": true}},
+			bson.M{"basicauthpassword": bson.M{"$exists": true}},
+		},
+	}
+
+	return col.Find(ctx, filter, &mongox.BatchConsumer{
+		Size: 1000,
+		Callback: func(rows []bson.Raw) error {
+			ids := make([]string, 0, len(rows))
+			newRows := make([]any, 0, len(rows))
+
+			log.Infofc(ctx, "migration: HashBasicAuthPasswords: hit %s: %d\n", collection, len(rows))
+
+			for _, row := range rows {
+				doc := bson.M{}
+				if err := bson.Unmarshal(row, &doc); err != nil {
+					return err
+				}
+
+				username, _ := doc["basicauthusername"].(string)
+				password, _ := doc["basicauthpassword"].(string)
+				delete(doc, "basicauthusername")
+				delete(doc, "basicauthpassword")
+
+				if existing, ok := doc["basicauthcredentials"].(bson.A); (!ok || len(existing) == 0) && username != "" && password != "" {
+					hash := password
+					if !basicauth.IsHash(password) {
+						h, err := basicauth.HashPassword(password)
+						if err != nil {
+							return err
+						}
+						hash = h
+					}
+
+					doc["basicauthcredentials"] = bson.A{
+						bson.M{
+							"name":         basicauth.DefaultName,
+							"username":     username,
+							"passwordhash": hash,
+							"createdat":    now,
+						},
+					}
+				}
+
+				id, _ := doc["id"].(string)
+				ids = append(ids, id)
+				newRows = append(newRows, doc)
+			}
+
+			return col.SaveAll(ctx, ids, newRows)
+		},
+	})
+}
@@ -0,0 +1,70 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/basicauth"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestHashBasicAuthPasswords(t *testing.T) {
+	ctx := context.Background()
+	db := mongotest.Connect(t)(t)
+	c := mongox.NewClientWithDatabase(db)
+
+	prj := bson.M{
+		"_id":               primitive.NewObjectID(),
+		"id":                "prj",
+		"isbasicauthactive": true,
+		"basicauthusername": "user",
+		"basicauthpassword": "pass",
+	}
+	prjNoAuth := bson.M{
+		"_id":               primitive.NewObjectID(),
+		"id":                "prj-no-auth",
+		"basicauthusername": "",
+		"basicauthpassword": "",
+	}
+	story := bson.M{
+		"_id":               primitive.NewObjectID(),
+		"id":                "story",
+		"isbasicauthactive": true,
+		"basicauthusername": "user2",
+		"basicauthpassword": "pass2",
+	}
+
+	_, err := db.Collection("project").InsertMany(ctx, []any{prj, prjNoAuth})
+	require.NoError(t, err)
+	_, err = db.Collection("storytelling").InsertOne(ctx, story)
+	require.NoError(t, err)
+
+	require.NoError(t, HashBasicAuthPasswords(ctx, c))
+
+	var res1 bson.M
+	require.NoError(t, db.Collection("project").FindOne(ctx, bson.M{"id": "prj"}).Decode(&res1))
+	assert.NotContains(t, res1, "basicauthusername")
+	assert.NotContains(t, res1, "basicauthpassword")
+	cred1 := res1["basicauthcredentials"].(primitive.A)[0].(bson.M)
+	assert.Equal(t, basicauth.DefaultName, cred1["name"])
+	assert.Equal(t, "user", cred1["username"])
+	assert.NotEqual(t, "pass", cred1["passwordhash"])
+	assert.True(t, basicauth.IsHash(cred1["passwordhash"].(string)))
+
+	var res2 bson.M
+	require.NoError(t, db.Collection("project").FindOne(ctx, bson.M{"id": "prj-no-auth"}).Decode(&res2))
+	assert.NotContains(t, res2, "basicauthpassword")
+	assert.NotContains(t, res2, "basicauthcredentials")
+
+	var res3 bson.M
+	require.NoError(t, db.Collection("storytelling").FindOne(ctx, bson.M{"id": "story"}).Decode(&res3))
+	assert.NotContains(t, res3, "basicauthpassword")
+	cred3 := res3["basicauthcredentials"].(primitive.A)[0].(bson.M)
+	assert.Equal(t, "user2", cred3["username"])
+	assert.True(t, basicauth.IsHash(cred3["passwordhash"].(string)))
+}
@@ -20,4 +20,5 @@ var migrations = migration.Migrations[DBClient]{
 	250602123621: AddDefaultDataAttributionWidget,
 	260525000715: UpdateTileAndTerrainProviders,
 	260528082406: FixArcgisTerrain,
+	261018120000: HashBasicAuthPasswords,
 }
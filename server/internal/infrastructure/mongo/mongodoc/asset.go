@@ -18,6 +18,34 @@ type AssetDocument struct {
 	Size        int64
 	URL         string
 	ContentType string
+	Metadata    *AssetMetadataDocument `bson:",omitempty"`
+	Thumbnail   string                 `bson:",omitempty"`
+}
+
+type AssetMetadataDocument struct {
+	Width      int                      `bson:",omitempty"`
+	Height     int                      `bson:",omitempty"`
+	Bounds     *AssetBoundsDocument     `bson:",omitempty"`
+	EPSG       int                      `bson:",omitempty"`
+	Duration   float64                  `bson:",omitempty"`
+	ModelStats *AssetModelStatsDocument `bson:"model,omitempty"`
+}
+
+type AssetBoundsDocument struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
+}
+
+type AssetModelStatsDocument struct {
+	Nodes      int
+	Meshes     int
+	Primitives int
+	Vertices   int
+	Materials  int
+	Textures   int
+	Animations int
 }
 
 type AssetConsumer = Consumer[*AssetDocument, *asset.Asset]
@@ -38,6 +66,8 @@ func NewAsset(asset *asset.Asset) (*AssetDocument, string) {
 		Size:        asset.Size(),
 		URL:         asset.URL(),
 		ContentType: asset.ContentType(),
+		Metadata:    NewAssetMetadata(asset.Metadata()),
+		Thumbnail:   asset.Thumbnail(),
 	}, aid
 }
 
@@ -63,5 +93,61 @@ func (d *AssetDocument) Model() (*asset.Asset, error) {
 		Size(d.Size).
 		URL(d.URL).
 		ContentType(d.ContentType).
+		Metadata(d.Metadata.Model()).
+		Thumbnail(d.Thumbnail).
 		Build()
 }
+
+func NewAssetMetadata(m *asset.Metadata) *AssetMetadataDocument {
+	if m == nil {
+		return nil
+	}
+	d := &AssetMetadataDocument{
+		Width:    m.Width,
+		Height:   m.Height,
+		EPSG:     m.EPSG,
+		Duration: m.Duration,
+	}
+	if m.Bounds != nil {
+		d.Bounds = &AssetBoundsDocument{West: m.Bounds.West, South: m.Bounds.South, East: m.Bounds.East, North: m.Bounds.North}
+	}
+	if m.Model != nil {
+		d.ModelStats = &AssetModelStatsDocument{
+			Nodes:      m.Model.Nodes,
+			Meshes:     m.Model.Meshes,
+			Primitives: m.Model.Primitives,
+			Vertices:   m.Model.Vertices,
+			Materials:  m.Model.Materials,
+			Textures:   m.Model.Textures,
+			Animations: m.Model.Animations,
+		}
+	}
+	return d
+}
+
+func (d *AssetMetadataDocument) Model() *asset.Metadata {
+	if d == nil {
+		return nil
+	}
+	m := &asset.Metadata{
+		Width:    d.Width,
+		Height:   d.Height,
+		EPSG:     d.EPSG,
+		Duration: d.Duration,
+	}
+	if d.Bounds != nil {
+		m.Bounds = &asset.Bounds{West: d.Bounds.West, South: d.Bounds.South, East: d.Bounds.East, North: d.Bounds.North}
+	}
+	if d.ModelStats != nil {
+		m.Model = &asset.ModelStats{
+			Nodes:      d.ModelStats.Nodes,
+			Meshes:     d.ModelStats.Meshes,
+			Primitives: d.ModelStats.Primitives,
+			Vertices:   d.ModelStats.Vertices,
+			Materials:  d.ModelStats.Materials,
+			Textures:   d.ModelStats.Textures,
+			Animations: d.ModelStats.Animations,
+		}
+	}
+	return m
+}
@@ -0,0 +1,126 @@
+package mongodoc
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"golang.org/x/exp/slices"
+)
+
+type AuditLogDocument struct {
+	ID        string
+	Actor     *string
+	Action    string
+	Workspace string
+	Scene     *string
+	Targets   []AuditLogTargetDocument
+	Changes   []AuditLogChangeDocument
+	CreatedAt time.Time
+}
+
+type AuditLogTargetDocument struct {
+	Type string
+	ID   string
+}
+
+// AuditLogChangeDocument keeps values as JSON since they can be any type.
+type AuditLogChangeDocument struct {
+	Field  string
+	Before string `bson:",omitempty"`
+	After  string `bson:",omitempty"`
+}
+
+type AuditLogConsumer = Consumer[*AuditLogDocument, *auditlog.Log]
+
+func NewAuditLogConsumer(workspaces []accountdomain.WorkspaceID) *AuditLogConsumer {
+	return NewConsumer[*AuditLogDocument, *auditlog.Log](func(a *auditlog.Log) bool {
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+	})
+}
+
+func NewAuditLog(l *auditlog.Log) (*AuditLogDocument, string) {
+	lid := l.ID().String()
+	targets := make([]AuditLogTargetDocument, 0, len(l.Targets()))
+	for _, t := range l.Targets() {
+		targets = append(targets, AuditLogTargetDocument{Type: t.Type, ID: t.ID})
+	}
+	changes := make([]AuditLogChangeDocument, 0, len(l.Changes()))
+	for _, c := range l.Changes() {
+		changes = append(changes, AuditLogChangeDocument{
+			Field:  c.Field,
+			Before: auditLogValue(c.Before),
+			After:  auditLogValue(c.After),
+		})
+	}
+
+	return &AuditLogDocument{
+		ID:        lid,
+		Actor:     l.Actor().StringRef(),
+		Action:    string(l.Action()),
+		Workspace: l.Workspace().String(),
+		Scene:     l.Scene().StringRef(),
+		Targets:   targets,
+		Changes:   changes,
+		CreatedAt: l.CreatedAt(),
+	}, lid
+}
+
+func (d *AuditLogDocument) Model() (*auditlog.Log, error) {
+	lid, err := id.AuditLogIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := accountdomain.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]auditlog.Target, 0, len(d.Targets))
+	for _, t := range d.Targets {
+		targets = append(targets, auditlog.Target{Type: t.Type, ID: t.ID})
+	}
+	changes := make([]*auditlog.Change, 0, len(d.Changes))
+	for _, c := range d.Changes {
+		changes = append(changes, &auditlog.Change{
+			Field:  c.Field,
+			Before: auditLogValueFrom(c.Before),
+			After:  auditLogValueFrom(c.After),
+		})
+	}
+
+	return auditlog.New().
+		ID(lid).
+		Actor(accountdomain.UserIDFromRef(d.Actor)).
+		Action(auditlog.Action(d.Action)).
+		Workspace(wid).
+		Scene(id.SceneIDFromRef(d.Scene)).
+		Targets(targets...).
+		Changes(changes...).
+		CreatedAt(d.CreatedAt).
+		Build()
+}
+
+func auditLogValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func auditLogValueFrom(s string) any {
+	if s == "" {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,62 @@
+package mongodoc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/basicauth"
+	"github.com/samber/lo"
+)
+
+type BasicAuthCredentialDocument struct {
+	Name         string
+	Username     string
+	PasswordHash string
+	CreatedAt    time.Time
+	RevokedAt    *time.Time
+}
+
+func NewBasicAuthCredentials(l basicauth.List) []BasicAuthCredentialDocument {
+	if len(l) == 0 {
+		return nil
+	}
+	return lo.Map(l, func(c *basicauth.Credential, _ int) BasicAuthCredentialDocument {
+		return BasicAuthCredentialDocument{
+			Name:         c.Name(),
+			Username:     c.Username(),
+			PasswordHash: c.PasswordHash(),
+			CreatedAt:    c.CreatedAt(),
+			RevokedAt:    c.RevokedAt(),
+		}
+	})
+}
+
+func (d BasicAuthCredentialDocument) Model() (*basicauth.Credential, error) {
+	return basicauth.CredentialFrom(d.Name, d.Username, d.PasswordHash, d.CreatedAt, d.RevokedAt)
+}
+
+// ErrBasicAuthNotMigrated is returned when a document still has the username and password which were stored
+// before credentials were introduced. The HashBasicAuthPasswords migration converts them into credentials.
+var ErrBasicAuthNotMigrated = errors.New("basic auth password has not been migrated")
+
+// basicAuthCredentialsModel converts credentials documents into a list.
+// Documents which have not been migrated are rejected rather than read without their protection.
+func basicAuthCredentialsModel(docs []BasicAuthCredentialDocument, legacyUsername, legacyPassword string) (basicauth.List, error) {
+	if legacyUsername != "" || legacyPassword != "" {
+		return nil, ErrBasicAuthNotMigrated
+	}
+
+	res := make(basicauth.List, 0, len(docs))
+	for _, d := range docs {
+		c, err := d.Model()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return res, nil
+}
@@ -0,0 +1,30 @@
+package mongodoc
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/basicauth"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicAuthCredentialsModel(t *testing.T) {
+	c := lo.Must(basicauth.NewCredential(basicauth.DefaultName, "user", "pass"))
+	docs := NewBasicAuthCredentials(basicauth.List{c})
+
+	res, err := basicAuthCredentialsModel(docs, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, basicauth.List{c}, res)
+
+	res, err = basicAuthCredentialsModel(nil, "", "")
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	// documents which have not been migrated
+	res, err = basicAuthCredentialsModel(nil, "user", "pass")
+	assert.Same(t, ErrBasicAuthNotMigrated, err)
+	assert.Nil(t, res)
+	res, err = basicAuthCredentialsModel(docs, "user", "pass")
+	assert.Same(t, ErrBasicAuthNotMigrated, err)
+	assert.Nil(t, res)
+}
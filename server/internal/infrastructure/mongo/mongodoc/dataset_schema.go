@@ -1,6 +1,8 @@
 package mongodoc
 
 import (
+	"time"
+
 	"github.com/reearth/reearth/server/pkg/dataset"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/scene"
@@ -14,6 +16,14 @@ type DatasetSchemaDocument struct {
 	Fields              []*DatasetSchemaFieldDocument
 	RepresentativeField *string
 	Scene               string
+	Refresh             *DatasetSchemaRefreshDocument `bson:",omitempty"`
+}
+
+type DatasetSchemaRefreshDocument struct {
+	Interval     int64
+	Publish      bool
+	NextAt       time.Time
+	LastSyncedAt *time.Time
 }
 
 type DatasetSchemaFieldDocument struct {
@@ -64,7 +74,8 @@ func (d *DatasetSchemaDocument) Model() (*dataset.Schema, error) {
 		Name(d.Name).
 		Source(d.Source).
 		Scene(scene).
-		Fields(fields)
+		Fields(fields).
+		Refresh(d.Refresh.Model())
 	if d.RepresentativeField != nil {
 		dsfid, err := id.DatasetFieldIDFrom(*d.RepresentativeField)
 		if err != nil {
@@ -83,6 +94,7 @@ func NewDatasetSchema(dataset *dataset.Schema) (*DatasetSchemaDocument, string)
 		Source:              dataset.Source(),
 		Scene:               dataset.Scene().String(),
 		RepresentativeField: dataset.RepresentativeFieldID().StringRef(),
+		Refresh:             NewDatasetSchemaRefresh(dataset.Refresh()),
 	}
 
 	fields := dataset.Fields()
@@ -99,6 +111,25 @@ func NewDatasetSchema(dataset *dataset.Schema) (*DatasetSchemaDocument, string)
 	return &doc, did
 }
 
+func NewDatasetSchemaRefresh(r *dataset.Refresh) *DatasetSchemaRefreshDocument {
+	if r == nil {
+		return nil
+	}
+	return &DatasetSchemaRefreshDocument{
+		Interval:     int64(r.Interval()),
+		Publish:      r.Publish(),
+		NextAt:       *r.NextAt(),
+		LastSyncedAt: r.LastSyncedAt(),
+	}
+}
+
+func (d *DatasetSchemaRefreshDocument) Model() *dataset.Refresh {
+	if d == nil {
+		return nil
+	}
+	return dataset.RefreshFrom(time.Duration(d.Interval), d.Publish, d.NextAt, d.LastSyncedAt)
+}
+
 func NewDatasetSchemas(datasetSchemas []*dataset.Schema, f scene.IDList) ([]interface{}, []string) {
 	res := make([]interface{}, 0, len(datasetSchemas))
 	ids := make([]string, 0, len(datasetSchemas))
@@ -0,0 +1,68 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"golang.org/x/exp/slices"
+)
+
+type DatasetSyncLogDocument struct {
+	ID       string
+	Scene    string
+	Schema   *string
+	Source   string
+	Auto     bool
+	Added    int
+	Removed  int
+	Changed  int
+	Error    string
+	SyncedAt time.Time
+}
+
+type DatasetSyncLogConsumer = Consumer[*DatasetSyncLogDocument, *dataset.SyncLog]
+
+func NewDatasetSyncLogConsumer(scenes []id.SceneID) *DatasetSyncLogConsumer {
+	return NewConsumer[*DatasetSyncLogDocument, *dataset.SyncLog](func(a *dataset.SyncLog) bool {
+		return scenes == nil || slices.Contains(scenes, a.Scene())
+	})
+}
+
+func NewDatasetSyncLog(l *dataset.SyncLog) (*DatasetSyncLogDocument, string) {
+	lid := l.ID().String()
+	return &DatasetSyncLogDocument{
+		ID:       lid,
+		Scene:    l.Scene().String(),
+		Schema:   l.Schema().StringRef(),
+		Source:   l.Source(),
+		Auto:     l.Auto(),
+		Added:    l.Added(),
+		Removed:  l.Removed(),
+		Changed:  l.Changed(),
+		Error:    l.Error(),
+		SyncedAt: l.SyncedAt(),
+	}, lid
+}
+
+func (d *DatasetSyncLogDocument) Model() (*dataset.SyncLog, error) {
+	lid, err := id.DatasetSyncLogIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataset.NewSyncLog().
+		ID(lid).
+		Scene(sid).
+		Schema(id.DatasetSchemaIDFromRef(d.Schema)).
+		Source(d.Source).
+		Auto(d.Auto).
+		Stats(d.Added, d.Removed, d.Changed).
+		Error(d.Error).
+		SyncedAt(d.SyncedAt).
+		Build()
+}
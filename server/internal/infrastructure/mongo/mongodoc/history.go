@@ -0,0 +1,219 @@
+package mongodoc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/history"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"golang.org/x/exp/slices"
+)
+
+type HistoryDocument struct {
+	ID        string
+	Scene     string
+	User      string
+	Action    string
+	Changes   []HistoryChangeDocument
+	UndoneAt  *time.Time
+	CreatedAt time.Time
+}
+
+type HistoryChangeDocument struct {
+	Kind   string
+	ID     string
+	Before *HistorySnapshotDocument
+	After  *HistorySnapshotDocument
+}
+
+// HistorySnapshotDocument holds a snapshot of an object in the same form as its own collection.
+type HistorySnapshotDocument struct {
+	Property *PropertyDocument     `bson:",omitempty"`
+	Layer    *LayerDocument        `bson:",omitempty"`
+	NLSLayer *NLSLayerDocument     `bson:",omitempty"`
+	Story    *StorytellingDocument `bson:",omitempty"`
+	Style    *StyleDocument        `bson:",omitempty"`
+}
+
+type HistoryConsumer = Consumer[*HistoryDocument, *history.Entry]
+
+func NewHistoryConsumer(scenes []id.SceneID) *HistoryConsumer {
+	return NewConsumer[*HistoryDocument, *history.Entry](func(a *history.Entry) bool {
+		return scenes == nil || slices.Contains(scenes, a.Scene())
+	})
+}
+
+func NewHistory(e *history.Entry) (*HistoryDocument, string) {
+	hid := e.ID().String()
+	changes := make([]HistoryChangeDocument, 0, len(e.Changes()))
+	for _, c := range e.Changes() {
+		changes = append(changes, HistoryChangeDocument{
+			Kind:   string(c.Kind()),
+			ID:     c.ID(),
+			Before: newHistorySnapshot(c.Before()),
+			After:  newHistorySnapshot(c.After()),
+		})
+	}
+	return &HistoryDocument{
+		ID:        hid,
+		Scene:     e.Scene().String(),
+		User:      e.User().String(),
+		Action:    string(e.Action()),
+		Changes:   changes,
+		UndoneAt:  e.UndoneAt(),
+		CreatedAt: e.CreatedAt(),
+	}, hid
+}
+
+func newHistorySnapshot(o any) *HistorySnapshotDocument {
+	switch o := o.(type) {
+	case *property.Property:
+		d, _ := NewProperty(o)
+		return &HistorySnapshotDocument{Property: d}
+	case layer.Layer:
+		d, _ := NewLayer(o)
+		return &HistorySnapshotDocument{Layer: d}
+	case nlslayer.NLSLayer:
+		d, _ := NewNLSLayer(o)
+		return &HistorySnapshotDocument{NLSLayer: d}
+	case *storytelling.Story:
+		d, _ := NewStorytelling(o)
+		return &HistorySnapshotDocument{Story: d}
+	case *scene.Style:
+		d, _ := NewStyle(*o)
+		return &HistorySnapshotDocument{Style: d}
+	}
+	return nil
+}
+
+func (d *HistoryDocument) Model() (*history.Entry, error) {
+	hid, err := id.HistoryIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := accountdomain.UserIDFrom(d.User)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*history.Change, 0, len(d.Changes))
+	for _, c := range d.Changes {
+		hc, err := c.model()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, hc)
+	}
+
+	return history.New().
+		ID(hid).
+		Scene(sid).
+		User(uid).
+		Action(auditlog.Action(d.Action)).
+		Changes(changes...).
+		UndoneAt(d.UndoneAt).
+		CreatedAt(d.CreatedAt).
+		Build()
+}
+
+func (d HistoryChangeDocument) model() (*history.Change, error) {
+	switch history.Kind(d.Kind) {
+	case history.KindProperty:
+		before, err := d.Before.property()
+		if err != nil {
+			return nil, err
+		}
+		after, err := d.After.property()
+		if err != nil {
+			return nil, err
+		}
+		return history.PropertyChange(before, after), nil
+	case history.KindLayer:
+		before, err := d.Before.layer()
+		if err != nil {
+			return nil, err
+		}
+		after, err := d.After.layer()
+		if err != nil {
+			return nil, err
+		}
+		return history.LayerChange(before, after), nil
+	case history.KindNLSLayer:
+		before, err := d.Before.nlsLayer()
+		if err != nil {
+			return nil, err
+		}
+		after, err := d.After.nlsLayer()
+		if err != nil {
+			return nil, err
+		}
+		return history.NLSLayerChange(before, after), nil
+	case history.KindStory:
+		before, err := d.Before.story()
+		if err != nil {
+			return nil, err
+		}
+		after, err := d.After.story()
+		if err != nil {
+			return nil, err
+		}
+		return history.StoryChange(before, after), nil
+	case history.KindStyle:
+		before, err := d.Before.style()
+		if err != nil {
+			return nil, err
+		}
+		after, err := d.After.style()
+		if err != nil {
+			return nil, err
+		}
+		return history.StyleChange(before, after), nil
+	}
+	return nil, fmt.Errorf("unknown history kind: %s", d.Kind)
+}
+
+func (d *HistorySnapshotDocument) property() (*property.Property, error) {
+	if d == nil || d.Property == nil {
+		return nil, nil
+	}
+	return d.Property.Model()
+}
+
+func (d *HistorySnapshotDocument) layer() (layer.Layer, error) {
+	if d == nil || d.Layer == nil {
+		return nil, nil
+	}
+	return d.Layer.Model()
+}
+
+func (d *HistorySnapshotDocument) nlsLayer() (nlslayer.NLSLayer, error) {
+	if d == nil || d.NLSLayer == nil {
+		return nil, nil
+	}
+	return d.NLSLayer.Model()
+}
+
+func (d *HistorySnapshotDocument) story() (*storytelling.Story, error) {
+	if d == nil || d.Story == nil {
+		return nil, nil
+	}
+	return d.Story.Model()
+}
+
+func (d *HistorySnapshotDocument) style() (*scene.Style, error) {
+	if d == nil || d.Style == nil {
+		return nil, nil
+	}
+	return d.Style.Model()
+}
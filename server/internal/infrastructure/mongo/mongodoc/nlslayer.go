@@ -21,6 +21,7 @@ type NLSLayerDocument struct {
 	Group     *NLSLayerGroupDocument
 	IsSketch  bool
 	Sketch    *NLSLayerSketchInfoDocument
+	Version   int
 }
 
 type NLSLayerSimpleDocument struct {
@@ -127,6 +128,7 @@ func NewNLSLayer(l nlslayer.NLSLayer) (*NLSLayerDocument, string) {
 		Simple:    simple,
 		IsSketch:  l.IsSketch(),
 		Sketch:    NewNLSLayerSketchInfo(l.Sketch()),
+		Version:   l.Version(),
 	}, id
 }
 
@@ -195,6 +197,7 @@ func (d *NLSLayerDocument) ModelSimple() (*nlslayer.NLSLayerSimple, error) {
 		Config(NewNLSLayerConfig(d.Simple.Config)).
 		IsSketch(d.IsSketch).
 		Sketch(sketchInfo).
+		Version(d.Version).
 		Build()
 }
 
@@ -238,6 +241,7 @@ func (d *NLSLayerDocument) ModelGroup() (*nlslayer.NLSLayerGroup, error) {
 		Config(NewNLSLayerConfig(d.Simple.Config)).
 		IsSketch(d.IsSketch).
 		Sketch(sketchInfo).
+		Version(d.Version).
 		Build()
 }
 
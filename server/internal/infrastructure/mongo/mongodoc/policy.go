@@ -15,6 +15,13 @@ type PolicyDocument struct {
 	DatasetCount          *int
 	DatasetSchemaCount    *int
 	AssetStorageSize      *int64
+	NLSLayerCount         *int
+	SketchFeatureCount    *int
+	StoryCount            *int
+	StoryPageCount        *int
+	PublishedStoryCount   *int
+	PluginCount           *int
+	MaxUploadSize         *int64
 }
 
 func (d PolicyDocument) Model() *policy.Policy {
@@ -28,6 +35,13 @@ func (d PolicyDocument) Model() *policy.Policy {
 		DatasetCount:          d.DatasetCount,
 		DatasetSchemaCount:    d.DatasetSchemaCount,
 		AssetStorageSize:      d.AssetStorageSize,
+		NLSLayerCount:         d.NLSLayerCount,
+		SketchFeatureCount:    d.SketchFeatureCount,
+		StoryCount:            d.StoryCount,
+		StoryPageCount:        d.StoryPageCount,
+		PublishedStoryCount:   d.PublishedStoryCount,
+		PluginCount:           d.PluginCount,
+		MaxUploadSize:         d.MaxUploadSize,
 	})
 }
 
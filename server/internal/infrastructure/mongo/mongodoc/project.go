@@ -15,8 +15,9 @@ type ProjectDocument struct {
 	ID                string
 	Archived          bool
 	IsBasicAuthActive bool
-	BasicAuthUsername string
-	BasicAuthPassword string
+	// BasicAuthUsername and BasicAuthPassword are kept only to detect documents that have not been migrated yet.
+	BasicAuthUsername string `bson:",omitempty"`
+	BasicAuthPassword string `bson:",omitempty"`
 	UpdatedAt         time.Time
 	PublishedAt       time.Time
 	Name              string
@@ -34,14 +35,20 @@ type ProjectDocument struct {
 	CoreSupport       bool
 	EnableGA          bool
 	TrackingID        string
+	PublishSchedule   *PublishScheduleDocument `bson:",omitempty"`
+	Preview           *ProjectPreviewDocument  `bson:",omitempty"`
+	IsTemplate        bool
 	// Scene             string
+
+	BasicAuthCredentials []BasicAuthCredentialDocument
 }
 
 type ProjectConsumer = Consumer[*ProjectDocument, *project.Project]
 
-func NewProjectConsumer(workspaces []accountdomain.WorkspaceID) *ProjectConsumer {
+// NewProjectConsumer consumes the projects of the workspaces and the granted projects. Nil workspaces mean all workspaces.
+func NewProjectConsumer(workspaces []accountdomain.WorkspaceID, granted ...id.ProjectID) *ProjectConsumer {
 	return NewConsumer[*ProjectDocument, *project.Project](func(a *project.Project) bool {
-		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace()) || slices.Contains(granted, a.ID())
 	})
 }
 
@@ -59,8 +66,6 @@ func NewProject(project *project.Project) (*ProjectDocument, string) {
 		ID:                pid,
 		Archived:          project.IsArchived(),
 		IsBasicAuthActive: project.IsBasicAuthActive(),
-		BasicAuthUsername: project.BasicAuthUsername(),
-		BasicAuthPassword: project.BasicAuthPassword(),
 		UpdatedAt:         project.UpdatedAt(),
 		PublishedAt:       project.PublishedAt(),
 		Name:              project.Name(),
@@ -77,7 +82,12 @@ func NewProject(project *project.Project) (*ProjectDocument, string) {
 		CoreSupport:       project.CoreSupport(),
 		EnableGA:          project.EnableGA(),
 		TrackingID:        project.TrackingID(),
+		PublishSchedule:   NewProjectPublishSchedule(project.PublishSchedule()),
+		Preview:           NewProjectPreview(project.Preview()),
+		IsTemplate:        project.IsTemplate(),
 		// Scene:             project.Scene().String(),
+
+		BasicAuthCredentials: NewBasicAuthCredentials(project.BasicAuthCredentials()),
 	}, pid
 }
 
@@ -100,6 +110,21 @@ func (d *ProjectDocument) Model() (*project.Project, error) {
 	// 	return nil, err
 	// }
 
+	credentials, err := basicAuthCredentialsModel(d.BasicAuthCredentials, d.BasicAuthUsername, d.BasicAuthPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := d.PublishSchedule.ProjectModel()
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := d.Preview.Model()
+	if err != nil {
+		return nil, err
+	}
+
 	var imageURL *url.URL
 	if d.ImageURL != "" {
 		if imageURL, err = url.Parse(d.ImageURL); err != nil {
@@ -111,8 +136,7 @@ func (d *ProjectDocument) Model() (*project.Project, error) {
 		ID(pid).
 		IsArchived(d.Archived).
 		IsBasicAuthActive(d.IsBasicAuthActive).
-		BasicAuthUsername(d.BasicAuthUsername).
-		BasicAuthPassword(d.BasicAuthPassword).
+		BasicAuthCredentials(credentials).
 		UpdatedAt(d.UpdatedAt).
 		PublishedAt(d.PublishedAt).
 		Name(d.Name).
@@ -129,6 +153,9 @@ func (d *ProjectDocument) Model() (*project.Project, error) {
 		CoreSupport(d.CoreSupport).
 		EnableGA(d.EnableGA).
 		TrackingID(d.TrackingID).
+		PublishSchedule(schedule).
+		Preview(preview).
+		IsTemplate(d.IsTemplate).
 		// Scene(scene).
 		Build()
 }
@@ -0,0 +1,81 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/projectgrant"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"golang.org/x/exp/slices"
+)
+
+type ProjectGrantDocument struct {
+	ID        string
+	Project   string
+	Scene     string
+	Workspace string
+	User      string
+	Role      string
+	GrantedBy *string
+	CreatedAt time.Time
+}
+
+type ProjectGrantConsumer = Consumer[*ProjectGrantDocument, *projectgrant.Grant]
+
+func NewProjectGrantConsumer(workspaces []accountdomain.WorkspaceID) *ProjectGrantConsumer {
+	return NewConsumer[*ProjectGrantDocument, *projectgrant.Grant](func(g *projectgrant.Grant) bool {
+		return workspaces == nil || slices.Contains(workspaces, g.Workspace())
+	})
+}
+
+func NewProjectGrant(g *projectgrant.Grant) (*ProjectGrantDocument, string) {
+	gid := g.ID().String()
+	return &ProjectGrantDocument{
+		ID:        gid,
+		Project:   g.Project().String(),
+		Scene:     g.Scene().String(),
+		Workspace: g.Workspace().String(),
+		User:      g.User().String(),
+		Role:      string(g.Role()),
+		GrantedBy: g.GrantedBy().StringRef(),
+		CreatedAt: g.CreatedAt(),
+	}, gid
+}
+
+func (d *ProjectGrantDocument) Model() (*projectgrant.Grant, error) {
+	gid, err := id.ProjectGrantIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	pid, err := id.ProjectIDFrom(d.Project)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := accountdomain.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := accountdomain.UserIDFrom(d.User)
+	if err != nil {
+		return nil, err
+	}
+	role, err := projectgrant.RoleFrom(d.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return projectgrant.New().
+		ID(gid).
+		Project(pid).
+		Scene(sid).
+		Workspace(wid).
+		User(uid).
+		Role(role).
+		GrantedBy(accountdomain.UserIDFromRef(d.GrantedBy)).
+		CreatedAt(d.CreatedAt).
+		Build()
+}
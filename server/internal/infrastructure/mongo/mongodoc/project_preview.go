@@ -0,0 +1,31 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/project"
+)
+
+type ProjectPreviewDocument struct {
+	Token     string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func NewProjectPreview(p *project.Preview) *ProjectPreviewDocument {
+	if p == nil {
+		return nil
+	}
+	return &ProjectPreviewDocument{
+		Token:     p.Token(),
+		CreatedAt: p.CreatedAt(),
+		ExpiresAt: p.ExpiresAt(),
+	}
+}
+
+func (d *ProjectPreviewDocument) Model() (*project.Preview, error) {
+	if d == nil {
+		return nil, nil
+	}
+	return project.PreviewFrom(d.Token, d.CreatedAt, d.ExpiresAt)
+}
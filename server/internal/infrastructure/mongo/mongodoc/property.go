@@ -22,6 +22,7 @@ type PropertyDocument struct {
 	SchemaPlugin string
 	SchemaName   string
 	Items        []*PropertyItemDocument
+	Version      int
 }
 
 type PropertyFieldDocument struct {
@@ -159,6 +160,7 @@ func NewProperty(property *property.Property) (*PropertyDocument, string) {
 		SchemaName:   property.Schema().ID(),
 		Items:        make([]*PropertyItemDocument, 0, len(items)),
 		Scene:        property.Scene().String(),
+		Version:      property.Version(),
 	}
 	for _, f := range items {
 		doc.Items = append(doc.Items, newPropertyItem(f))
@@ -298,6 +300,7 @@ func (doc *PropertyDocument) Model() (*property.Property, error) {
 		Scene(sid).
 		Schema(id.NewPropertySchemaID(pl, doc.SchemaName)).
 		Items(items).
+		Version(doc.Version).
 		Build()
 }
 
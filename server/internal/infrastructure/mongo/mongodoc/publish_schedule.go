@@ -0,0 +1,50 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publishschedule"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+)
+
+type PublishScheduleDocument struct {
+	Status      string
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+	NextAt      *time.Time
+}
+
+func NewProjectPublishSchedule(s *project.PublishSchedule) *PublishScheduleDocument {
+	return newPublishSchedule(s)
+}
+
+func NewStoryPublishSchedule(s *storytelling.PublishSchedule) *PublishScheduleDocument {
+	return newPublishSchedule(s)
+}
+
+func newPublishSchedule[S ~string](s *publishschedule.Schedule[S]) *PublishScheduleDocument {
+	if s == nil {
+		return nil
+	}
+	return &PublishScheduleDocument{
+		Status:      string(s.Status()),
+		PublishAt:   s.PublishAt(),
+		UnpublishAt: s.UnpublishAt(),
+		NextAt:      s.NextAt(),
+	}
+}
+
+func (d *PublishScheduleDocument) ProjectModel() (*project.PublishSchedule, error) {
+	if d == nil {
+		return nil, nil
+	}
+	return project.NewPublishSchedule(project.PublishmentStatus(d.Status), d.PublishAt, d.UnpublishAt)
+}
+
+func (d *PublishScheduleDocument) StoryModel() (*storytelling.PublishSchedule, error) {
+	if d == nil {
+		return nil, nil
+	}
+	return storytelling.NewPublishSchedule(storytelling.PublishmentStatus(d.Status), d.PublishAt, d.UnpublishAt)
+}
@@ -0,0 +1,91 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/revision"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"golang.org/x/exp/slices"
+)
+
+type RevisionDocument struct {
+	ID            string
+	Project       *string
+	Story         *string
+	Scene         string
+	Workspace     string
+	Number        int
+	Alias         string
+	Status        string
+	SchemaVersion int
+	PublishedBy   *string
+	PublishedAt   time.Time
+}
+
+type RevisionConsumer = Consumer[*RevisionDocument, *revision.Revision]
+
+func NewRevisionConsumer(workspaces []accountdomain.WorkspaceID) *RevisionConsumer {
+	return NewConsumer[*RevisionDocument, *revision.Revision](func(a *revision.Revision) bool {
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+	})
+}
+
+func NewRevision(r *revision.Revision) (*RevisionDocument, string) {
+	rid := r.ID().String()
+	return &RevisionDocument{
+		ID:            rid,
+		Project:       r.Project().StringRef(),
+		Story:         r.Story().StringRef(),
+		Scene:         r.Scene().String(),
+		Workspace:     r.Workspace().String(),
+		Number:        r.Number(),
+		Alias:         r.Alias(),
+		Status:        r.Status(),
+		SchemaVersion: r.SchemaVersion(),
+		PublishedBy:   r.PublishedBy().StringRef(),
+		PublishedAt:   r.PublishedAt(),
+	}, rid
+}
+
+func (d *RevisionDocument) Model() (*revision.Revision, error) {
+	rid, err := id.RevisionIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := accountdomain.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	b := revision.New().
+		ID(rid).
+		Scene(sid).
+		Workspace(wid).
+		Number(d.Number).
+		Alias(d.Alias).
+		Status(d.Status).
+		SchemaVersion(d.SchemaVersion).
+		PublishedBy(accountdomain.UserIDFromRef(d.PublishedBy)).
+		PublishedAt(d.PublishedAt)
+
+	if d.Project != nil {
+		pid, err := id.ProjectIDFrom(*d.Project)
+		if err != nil {
+			return nil, err
+		}
+		b = b.Project(pid)
+	} else if d.Story != nil {
+		stid, err := id.StoryIDFrom(*d.Story)
+		if err != nil {
+			return nil, err
+		}
+		b = b.Story(stid)
+	}
+
+	return b.Build()
+}
@@ -25,6 +25,7 @@ type SceneDocument struct {
 	UpdateAt    time.Time
 	Property    string
 	Clusters    []SceneClusterDocument
+	Version     int
 }
 
 type SceneWidgetDocument struct {
@@ -49,9 +50,10 @@ type SceneClusterDocument struct {
 
 type SceneConsumer = Consumer[*SceneDocument, *scene.Scene]
 
-func NewSceneConsumer(workspaces []accountdomain.WorkspaceID) *SceneConsumer {
+// NewSceneConsumer consumes the scenes of the workspaces and the granted scenes. Nil workspaces mean all workspaces.
+func NewSceneConsumer(workspaces []accountdomain.WorkspaceID, granted ...id.SceneID) *SceneConsumer {
 	return NewConsumer[*SceneDocument, *scene.Scene](func(s *scene.Scene) bool {
-		return workspaces == nil || slices.Contains(workspaces, s.Workspace())
+		return workspaces == nil || slices.Contains(workspaces, s.Workspace()) || slices.Contains(granted, s.ID())
 	})
 }
 
@@ -127,6 +129,7 @@ func NewScene(scene *scene.Scene) (*SceneDocument, string) {
 		UpdateAt:    scene.UpdatedAt(),
 		Property:    scene.Property().String(),
 		Clusters:    clsuterDoc,
+		Version:     scene.Version(),
 	}, id
 }
 
@@ -227,6 +230,7 @@ func (d *SceneDocument) Model() (*scene.Scene, error) {
 		Plugins(scene.NewPlugins(ps)).
 		UpdatedAt(d.UpdateAt).
 		Property(prid).
+		Version(d.Version).
 		Build()
 }
 
@@ -23,14 +23,19 @@ type StorytellingDocument struct {
 	Index         int
 	PanelPosition string
 	BgColor       string
+	Version       int
 
 	IsBasicAuthActive bool
-	BasicAuthUsername string
-	BasicAuthPassword string
+	// BasicAuthUsername and BasicAuthPassword are kept only to detect documents that have not been migrated yet.
+	BasicAuthUsername string `bson:",omitempty"`
+	BasicAuthPassword string `bson:",omitempty"`
 	PublicTitle       string
 	PublicDescription string
 	PublicImage       string
 	PublicNoIndex     bool
+	PublishSchedule   *PublishScheduleDocument `bson:",omitempty"`
+
+	BasicAuthCredentials []BasicAuthCredentialDocument
 }
 
 type PageDocument struct {
@@ -74,14 +79,16 @@ func NewStorytelling(s *storytelling.Story) (*StorytellingDocument, string) {
 		Index:         1,
 		PanelPosition: string(s.PanelPosition()),
 		BgColor:       s.BgColor(),
+		Version:       s.Version(),
 
 		IsBasicAuthActive: s.IsBasicAuthActive(),
-		BasicAuthUsername: s.BasicAuthUsername(),
-		BasicAuthPassword: s.BasicAuthPassword(),
 		PublicTitle:       s.PublicTitle(),
 		PublicDescription: s.PublicDescription(),
 		PublicImage:       s.PublicImage(),
 		PublicNoIndex:     s.PublicNoIndex(),
+		PublishSchedule:   NewStoryPublishSchedule(s.PublishSchedule()),
+
+		BasicAuthCredentials: NewBasicAuthCredentials(s.BasicAuthCredentials()),
 	}, sId
 }
 
@@ -173,6 +180,16 @@ func (d *StorytellingDocument) Model() (*storytelling.Story, error) {
 		return nil, err
 	}
 
+	credentials, err := basicAuthCredentialsModel(d.BasicAuthCredentials, d.BasicAuthUsername, d.BasicAuthPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := d.PublishSchedule.StoryModel()
+	if err != nil {
+		return nil, err
+	}
+
 	s, err := storytelling.NewStory().
 		ID(sid).
 		Property(property).
@@ -185,11 +202,13 @@ func (d *StorytellingDocument) Model() (*storytelling.Story, error) {
 		PublishedAt(d.PublishedAt).
 		UpdatedAt(d.UpdatedAt).
 		Pages(storytelling.NewPageList(pages)).
-		PublicBasicAuth(d.IsBasicAuthActive, d.BasicAuthUsername, d.BasicAuthPassword).
+		PublicBasicAuth(d.IsBasicAuthActive, credentials).
 		PublicTitle(d.PublicTitle).
 		PublicDescription(d.PublicDescription).
 		PublicImage(d.PublicImage).
 		PublicNoIndex(d.PublicNoIndex).
+		PublishSchedule(schedule).
+		Version(d.Version).
 		Build()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,42 @@
+package mongodoc
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type UsageWarningDocument struct {
+	Workspace string
+	Item      string
+	Threshold float64
+}
+
+// UsageWarningConsumer consumes thresholds by names of usage items.
+type UsageWarningConsumer struct {
+	Rows map[string]float64
+}
+
+func (c *UsageWarningConsumer) Consume(raw bson.Raw) error {
+	if raw == nil {
+		return nil
+	}
+
+	var doc UsageWarningDocument
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+	if c.Rows == nil {
+		c.Rows = map[string]float64{}
+	}
+	c.Rows[doc.Item] = doc.Threshold
+	return nil
+}
+
+func NewUsageWarning(wid accountdomain.WorkspaceID, item string, threshold float64) *UsageWarningDocument {
+	return &UsageWarningDocument{
+		Workspace: wid.String(),
+		Item:      item,
+		Threshold: threshold,
+	}
+}
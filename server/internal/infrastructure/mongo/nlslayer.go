@@ -136,20 +136,36 @@ func (r *NLSLayer) FindByScene(ctx context.Context, id id.SceneID) (nlslayer.NLS
 	})
 }
 
+func (r *NLSLayer) CountByScene(ctx context.Context, sid id.SceneID) (int, error) {
+	if !r.f.CanRead(sid) {
+		return 0, repo.ErrOperationDenied
+	}
+
+	c, err := r.client.Count(ctx, bson.M{
+		"scene": sid.String(),
+	})
+	return int(c), err
+}
+
 func (r *NLSLayer) Save(ctx context.Context, layer nlslayer.NLSLayer) error {
 	if !r.f.CanWrite(layer.Scene()) {
 		return repo.ErrOperationDenied
 	}
-	doc, id := mongodoc.NewNLSLayer(layer)
-	return r.client.SaveOne(ctx, id, doc)
+	return saveVersioned(ctx, r.client, layer, newNLSLayerDocument)
 }
 
 func (r *NLSLayer) SaveAll(ctx context.Context, layers nlslayer.NLSLayerList) error {
 	if len(layers) == 0 {
 		return nil
 	}
-	docs, ids := mongodoc.NewNLSLayers(layers, r.f.Writable)
-	return r.client.SaveAll(ctx, ids, docs)
+	ll := lo.FilterMap(layers, func(l *nlslayer.NLSLayer, _ int) (nlslayer.NLSLayer, bool) {
+		return lo.FromPtr(l), l != nil && *l != nil && r.f.CanWrite((*l).Scene())
+	})
+	return saveAllVersioned(ctx, r.client, ll, newNLSLayerDocument)
+}
+
+func newNLSLayerDocument(l nlslayer.NLSLayer) (any, string) {
+	return mongodoc.NewNLSLayer(l)
 }
 
 func (r *NLSLayer) Remove(ctx context.Context, id id.NLSLayerID) error {
@@ -20,14 +20,15 @@ import (
 )
 
 var (
-	projectIndexes       = []string{"alias", "alias,publishmentstatus", "team", "workspace"}
+	projectIndexes       = []string{"alias", "alias,publishmentstatus", "team", "workspace", "publishschedule.nextat", "preview.token", "preview.expiresat"}
 	projectUniqueIndexes = []string{"id"}
 )
 
 type Project struct {
-	client *mongox.ClientCollection
-	f      repo.WorkspaceFilter
-	s      repo.SceneFilter
+	client  *mongox.ClientCollection
+	f       repo.WorkspaceFilter
+	s       repo.SceneFilter
+	granted id.ProjectIDList
 }
 
 func NewProject(client *mongox.Client) *Project {
@@ -42,8 +43,19 @@ func (r *Project) Init(ctx context.Context) error {
 
 func (r *Project) Filtered(f repo.WorkspaceFilter) repo.Project {
 	return &Project{
-		client: r.client,
-		f:      r.f.Merge(f),
+		client:  r.client,
+		f:       r.f.Merge(f),
+		s:       r.s,
+		granted: r.granted,
+	}
+}
+
+func (r *Project) Granted(ids id.ProjectIDList) repo.Project {
+	return &Project{
+		client:  r.client,
+		f:       r.f,
+		s:       r.s,
+		granted: r.granted.AddUniq(ids...),
 	}
 }
 
@@ -240,6 +252,27 @@ func (r *Project) FindByPublicName(ctx context.Context, name string) (*project.P
 	return r.findOne(ctx, f, false)
 }
 
+func (r *Project) FindByPublishScheduleDue(ctx context.Context, now time.Time) ([]*project.Project, error) {
+	return r.find(ctx, bson.M{
+		"publishschedule.nextat": bson.M{"$lte": now},
+	})
+}
+
+func (r *Project) FindByPreviewToken(ctx context.Context, token string) (*project.Project, error) {
+	if token == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return r.findOne(ctx, bson.M{
+		"preview.token": token,
+	}, false)
+}
+
+func (r *Project) FindByPreviewExpired(ctx context.Context, now time.Time) ([]*project.Project, error) {
+	return r.find(ctx, bson.M{
+		"preview.expiresat": bson.M{"$lte": now},
+	})
+}
+
 func (r *Project) CountByWorkspace(ctx context.Context, ws accountdomain.WorkspaceID) (int, error) {
 	if !r.f.CanRead(ws) {
 		return 0, repo.ErrOperationDenied
@@ -278,7 +311,7 @@ func (r *Project) Remove(ctx context.Context, id id.ProjectID) error {
 }
 
 func (r *Project) find(ctx context.Context, filter interface{}) ([]*project.Project, error) {
-	c := mongodoc.NewProjectConsumer(r.f.Readable)
+	c := mongodoc.NewProjectConsumer(r.f.Readable, r.granted...)
 	if err := r.client.Find(ctx, filter, c); err != nil {
 		return nil, err
 	}
@@ -290,7 +323,7 @@ func (r *Project) findOne(ctx context.Context, filter any, filterByWorkspaces bo
 	if filterByWorkspaces {
 		f = r.f.Readable
 	}
-	c := mongodoc.NewProjectConsumer(f)
+	c := mongodoc.NewProjectConsumer(f, r.granted...)
 	if err := r.client.FindOne(ctx, filter, c); err != nil {
 		return nil, err
 	}
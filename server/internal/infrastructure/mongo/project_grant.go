@@ -0,0 +1,93 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/projectgrant"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var (
+	projectGrantIndexes       = []string{"user", "project", "workspace"}
+	projectGrantUniqueIndexes = []string{"id", "project,user"}
+)
+
+type ProjectGrant struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewProjectGrant(client *mongox.Client) *ProjectGrant {
+	return &ProjectGrant{
+		client: client.WithCollection("projectGrant"),
+	}
+}
+
+func (r *ProjectGrant) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, projectGrantIndexes, projectGrantUniqueIndexes)
+}
+
+func (r *ProjectGrant) Filtered(f repo.WorkspaceFilter) repo.ProjectGrant {
+	return &ProjectGrant{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *ProjectGrant) FindByProject(ctx context.Context, pid id.ProjectID) (projectgrant.List, error) {
+	return r.find(ctx, bson.M{"project": pid.String()})
+}
+
+func (r *ProjectGrant) FindByProjectAndUser(ctx context.Context, pid id.ProjectID, uid accountdomain.UserID) (*projectgrant.Grant, error) {
+	res, err := r.find(ctx, bson.M{
+		"project": pid.String(),
+		"user":    uid.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, rerror.ErrNotFound
+	}
+	return res[0], nil
+}
+
+func (r *ProjectGrant) FindByUser(ctx context.Context, uid accountdomain.UserID) (projectgrant.List, error) {
+	return r.find(ctx, bson.M{"user": uid.String()})
+}
+
+func (r *ProjectGrant) Save(ctx context.Context, g *projectgrant.Grant) error {
+	if !r.f.CanWrite(g.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, id := mongodoc.NewProjectGrant(g)
+	return r.client.SaveOne(ctx, id, doc)
+}
+
+func (r *ProjectGrant) Remove(ctx context.Context, gid id.ProjectGrantID) error {
+	return r.client.RemoveOne(ctx, r.writeFilter(bson.M{"id": gid.String()}))
+}
+
+func (r *ProjectGrant) RemoveByProject(ctx context.Context, pid id.ProjectID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"project": pid.String()}))
+}
+
+func (r *ProjectGrant) find(ctx context.Context, filter any) (projectgrant.List, error) {
+	c := mongodoc.NewProjectGrantConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, filter, c, options.Find().SetSort(bson.D{{Key: "id", Value: 1}})); err != nil {
+		return nil, err
+	}
+	return c.Result, nil
+}
+
+func (r *ProjectGrant) writeFilter(filter any) any {
+	return applyWorkspaceFilter(filter, r.f.Writable)
+}
@@ -86,6 +86,12 @@ func (r *Property) FindByIDs(ctx context.Context, ids id.PropertyIDList) (proper
 	return filterProperties(ids, c.Result), nil
 }
 
+func (r *Property) FindByScene(ctx context.Context, id id.SceneID) (property.List, error) {
+	return r.find(ctx, bson.M{
+		"scene": id.String(),
+	})
+}
+
 func (r *Property) FindLinkedAll(ctx context.Context, id id.SceneID) (property.List, error) {
 	return r.find(ctx, bson.M{
 		"scene": id.String(),
@@ -149,16 +155,21 @@ func (r *Property) Save(ctx context.Context, property *property.Property) error
 	if !r.f.CanWrite(property.Scene()) {
 		return repo.ErrOperationDenied
 	}
-	doc, id := mongodoc.NewProperty(property)
-	return r.client.SaveOne(ctx, id, doc)
+	return saveVersioned(ctx, r.client, property, newPropertyDocument)
 }
 
 func (r *Property) SaveAll(ctx context.Context, properties property.List) error {
 	if len(properties) == 0 {
 		return nil
 	}
-	docs, ids := mongodoc.NewProperties(properties, r.f.Writable)
-	return r.client.SaveAll(ctx, ids, docs)
+	properties = lo.Filter(properties, func(p *property.Property, _ int) bool {
+		return p != nil && r.f.CanWrite(p.Scene())
+	})
+	return saveAllVersioned(ctx, r.client, properties, newPropertyDocument)
+}
+
+func newPropertyDocument(p *property.Property) (any, string) {
+	return mongodoc.NewProperty(p)
 }
 
 func (r *Property) UpdateSchemaPlugin(ctx context.Context, old, new id.PluginID, s id.SceneID) error {
@@ -0,0 +1,100 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/revision"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/mongox"
+)
+
+var (
+	revisionIndexes       = []string{"project", "story", "workspace"}
+	revisionUniqueIndexes = []string{"id"}
+)
+
+type Revision struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewRevision(client *mongox.Client) *Revision {
+	return &Revision{
+		client: client.WithCollection("revision"),
+	}
+}
+
+func (r *Revision) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, revisionIndexes, revisionUniqueIndexes)
+}
+
+func (r *Revision) Filtered(f repo.WorkspaceFilter) repo.Revision {
+	return &Revision{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *Revision) FindByID(ctx context.Context, id id.RevisionID) (*revision.Revision, error) {
+	return r.findOne(ctx, bson.M{
+		"id": id.String(),
+	}, true)
+}
+
+func (r *Revision) FindByProject(ctx context.Context, id id.ProjectID) (revision.List, error) {
+	return r.find(ctx, bson.M{
+		"project": id.String(),
+	})
+}
+
+func (r *Revision) FindByStory(ctx context.Context, id id.StoryID) (revision.List, error) {
+	return r.find(ctx, bson.M{
+		"story": id.String(),
+	})
+}
+
+func (r *Revision) Save(ctx context.Context, rev *revision.Revision) error {
+	if !r.f.CanWrite(rev.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, id := mongodoc.NewRevision(rev)
+	return r.client.SaveOne(ctx, id, doc)
+}
+
+func (r *Revision) RemoveByProject(ctx context.Context, id id.ProjectID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"project": id.String()}))
+}
+
+func (r *Revision) RemoveByStory(ctx context.Context, id id.StoryID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"story": id.String()}))
+}
+
+func (r *Revision) find(ctx context.Context, filter interface{}) (revision.List, error) {
+	c := mongodoc.NewRevisionConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, filter, c, options.Find().SetSort(bson.D{{Key: "number", Value: -1}})); err != nil {
+		return nil, err
+	}
+	return c.Result, nil
+}
+
+func (r *Revision) findOne(ctx context.Context, filter any, filterByWorkspaces bool) (*revision.Revision, error) {
+	var f []accountdomain.WorkspaceID
+	if filterByWorkspaces {
+		f = r.f.Readable
+	}
+	c := mongodoc.NewRevisionConsumer(f)
+	if err := r.client.FindOne(ctx, filter, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *Revision) writeFilter(filter interface{}) interface{} {
+	return applyWorkspaceFilter(filter, r.f.Writable)
+}
@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"github.com/reearth/reearth/server/pkg/storytelling"
 	"go.mongodb.org/mongo-driver/bson"
@@ -14,7 +15,7 @@ import (
 )
 
 var (
-	storytellingIndexes       = []string{"alias", "alias,status", "scene"}
+	storytellingIndexes       = []string{"alias", "alias,status", "scene", "publishschedule.nextat"}
 	storytellingUniqueIndexes = []string{"id"}
 )
 
@@ -85,12 +86,17 @@ func (r *Storytelling) FindByPublicName(ctx context.Context, name string) (*stor
 	return r.findOne(ctx, f, false)
 }
 
-func (r *Storytelling) Save(ctx context.Context, story storytelling.Story) error {
+func (r *Storytelling) FindByPublishScheduleDue(ctx context.Context, now time.Time) (*storytelling.StoryList, error) {
+	return r.find(ctx, bson.M{
+		"publishschedule.nextat": bson.M{"$lte": now},
+	})
+}
+
+func (r *Storytelling) Save(ctx context.Context, story *storytelling.Story) error {
 	if !r.f.CanWrite(story.Scene()) {
 		return repo.ErrOperationDenied
 	}
-	doc, sId := mongodoc.NewStorytelling(&story)
-	return r.client.SaveOne(ctx, sId, doc)
+	return saveVersioned(ctx, r.client, story, newStorytellingDocument)
 }
 
 func (r *Storytelling) SaveAll(ctx context.Context, stories storytelling.StoryList) error {
@@ -100,8 +106,11 @@ func (r *Storytelling) SaveAll(ctx context.Context, stories storytelling.StoryLi
 		}
 	}
 
-	doc, sId := mongodoc.NewStorytellings(&stories)
-	return r.client.SaveAll(ctx, sId, doc)
+	return saveAllVersioned(ctx, r.client, stories, newStorytellingDocument)
+}
+
+func newStorytellingDocument(s *storytelling.Story) (any, string) {
+	return mongodoc.NewStorytelling(s)
 }
 
 func (r *Storytelling) Remove(ctx context.Context, id id.StoryID) error {
@@ -71,12 +71,11 @@ func (r *Style) FindByScene(ctx context.Context, id id.SceneID) (*scene.StyleLis
 	})
 }
 
-func (r *Style) Save(ctx context.Context, style scene.Style) error {
+func (r *Style) Save(ctx context.Context, style *scene.Style) error {
 	if !r.f.CanWrite(style.Scene()) {
 		return repo.ErrOperationDenied
 	}
-	doc, sId := mongodoc.NewStyle(style)
-	return r.client.SaveOne(ctx, sId, doc)
+	return saveVersioned(ctx, r.client, style, newStyleDocument)
 }
 
 func (r *Style) SaveAll(ctx context.Context, styles scene.StyleList) error {
@@ -86,8 +85,11 @@ func (r *Style) SaveAll(ctx context.Context, styles scene.StyleList) error {
 		}
 	}
 
-	doc, sId := mongodoc.NewStyles(styles, r.f.Writable)
-	return r.client.SaveAll(ctx, sId, doc)
+	return saveAllVersioned(ctx, r.client, styles, newStyleDocument)
+}
+
+func newStyleDocument(s *scene.Style) (any, string) {
+	return mongodoc.NewStyle(*s)
 }
 
 func (r *Style) Remove(ctx context.Context, id id.StyleID) error {
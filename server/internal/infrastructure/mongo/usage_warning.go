@@ -0,0 +1,43 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var usageWarningUniqueIndexes = []string{"workspace,item"}
+
+type UsageWarning struct {
+	client *mongox.ClientCollection
+}
+
+func NewUsageWarning(client *mongox.Client) *UsageWarning {
+	return &UsageWarning{client: client.WithCollection("usageWarning")}
+}
+
+func (r *UsageWarning) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, nil, usageWarningUniqueIndexes)
+}
+
+func (r *UsageWarning) FindByWorkspace(ctx context.Context, wid accountdomain.WorkspaceID) (map[string]float64, error) {
+	c := mongodoc.UsageWarningConsumer{Rows: map[string]float64{}}
+	if err := r.client.Find(ctx, bson.M{"workspace": wid.String()}, &c); err != nil {
+		return nil, err
+	}
+	return c.Rows, nil
+}
+
+func (r *UsageWarning) Save(ctx context.Context, wid accountdomain.WorkspaceID, item string, threshold float64) error {
+	filter := bson.M{"workspace": wid.String(), "item": item}
+	doc := mongodoc.NewUsageWarning(wid, item, threshold)
+	if _, err := r.client.Client().UpdateOne(ctx, filter, bson.M{"$set": doc}, options.Update().SetUpsert(true)); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageWarning(t *testing.T) {
+	ctx := context.Background()
+	init := mongotest.Connect(t)
+	client := init(t)
+	r := NewUsageWarning(mongox.NewClientWithDatabase(client))
+	assert.NoError(t, r.Init(ctx))
+
+	wid := accountdomain.NewWorkspaceID()
+	wid2 := accountdomain.NewWorkspaceID()
+
+	got, err := r.FindByWorkspace(ctx, wid)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	assert.NoError(t, r.Save(ctx, wid, "projectCount", 80))
+	assert.NoError(t, r.Save(ctx, wid, "layerCount", 100))
+	assert.NoError(t, r.Save(ctx, wid, "projectCount", 100))
+	assert.NoError(t, r.Save(ctx, wid2, "projectCount", 80))
+
+	got, err = r.FindByWorkspace(ctx, wid)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"projectCount": 100, "layerCount": 100}, got)
+}
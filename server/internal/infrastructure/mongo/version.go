@@ -0,0 +1,88 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type versioned interface {
+	Version() int
+	SetVersion(int)
+}
+
+// saveVersioned replaces the document of the object only if its version is still the one the object has,
+// or inserts it if it does not exist yet. A document with another version makes the upsert violate
+// the unique index of ids, which is reported as a conflict.
+func saveVersioned[T versioned](ctx context.Context, c *mongox.Collection, o T, doc func(T) (any, string)) error {
+	v := o.Version()
+	o.SetVersion(v + 1)
+	d, id := doc(o)
+
+	if _, err := c.Client().ReplaceOne(ctx, versionFilter(id, v), d, options.Replace().SetUpsert(true)); err != nil {
+		o.SetVersion(v)
+		if mongo.IsDuplicateKeyError(err) {
+			return &repo.VersionConflictError{ID: id, Version: v}
+		}
+		return wrapVersionError(ctx, err)
+	}
+	return nil
+}
+
+// saveAllVersioned saves the objects in order as saveVersioned does, and stops at the first conflict.
+func saveAllVersioned[T versioned](ctx context.Context, c *mongox.Collection, objs []T, doc func(T) (any, string)) error {
+	if len(objs) == 0 {
+		return nil
+	}
+
+	versions := make([]int, 0, len(objs))
+	ids := make([]string, 0, len(objs))
+	models := make([]mongo.WriteModel, 0, len(objs))
+	for _, o := range objs {
+		v := o.Version()
+		o.SetVersion(v + 1)
+		d, id := doc(o)
+		versions = append(versions, v)
+		ids = append(ids, id)
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(versionFilter(id, v)).SetReplacement(d).SetUpsert(true))
+	}
+
+	if _, err := c.Client().BulkWrite(ctx, models); err != nil {
+		// the objects written before the failure keep their new versions
+		failed := 0
+		var bwe mongo.BulkWriteException
+		if errors.As(err, &bwe) && len(bwe.WriteErrors) > 0 {
+			failed = bwe.WriteErrors[0].Index
+		}
+		for i := failed; i < len(objs); i++ {
+			objs[i].SetVersion(versions[i])
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return &repo.VersionConflictError{ID: ids[failed], Version: versions[failed]}
+		}
+		return wrapVersionError(ctx, err)
+	}
+	return nil
+}
+
+// versionFilter matches the document with the version. Documents saved before versions were introduced have no version.
+func versionFilter(id string, version int) bson.M {
+	if version == 0 {
+		return bson.M{"id": id, "version": bson.M{"$in": bson.A{nil, 0}}}
+	}
+	return bson.M{"id": id, "version": version}
+}
+
+func wrapVersionError(ctx context.Context, err error) error {
+	if mongox.IsTransactionError(err) {
+		return usecasex.ErrTransaction
+	}
+	return rerror.ErrInternalByWithContext(ctx, err)
+}
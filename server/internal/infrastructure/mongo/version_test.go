@@ -0,0 +1,59 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestProperty_Save_Version(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	r := NewProperty(mongox.NewClientWithDatabase(c))
+	assert.NoError(t, r.Init(ctx))
+
+	sid := id.NewSceneID()
+	p := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("xxx~1.1.1/aa")).MustBuild()
+	assert.NoError(t, r.Save(ctx, p))
+	assert.Equal(t, 1, p.Version())
+
+	p1, err := r.FindByID(ctx, p.ID())
+	assert.NoError(t, err)
+	p2, err := r.FindByID(ctx, p.ID())
+	assert.NoError(t, err)
+	assert.NoError(t, r.Save(ctx, p1))
+	assert.Equal(t, 2, p1.Version())
+
+	// p2 was loaded before p1 was saved
+	err = r.Save(ctx, p2)
+	assert.True(t, errors.Is(err, repo.ErrVersionConflict))
+	assert.Equal(t, &repo.VersionConflictError{ID: p.ID().String(), Version: 1}, err)
+	assert.Equal(t, 1, p2.Version())
+
+	err = r.SaveAll(ctx, property.List{p1, p2})
+	assert.True(t, errors.Is(err, repo.ErrVersionConflict))
+	assert.Equal(t, 3, p1.Version())
+	assert.Equal(t, 1, p2.Version())
+
+	// documents saved before versions were introduced have no version
+	lid := id.NewPropertyID()
+	_, _ = c.Collection("property").InsertOne(ctx, bson.M{
+		"id":           lid.String(),
+		"scene":        sid.String(),
+		"schemaplugin": "xxx~1.1.1",
+		"schemaname":   "aa",
+	})
+	lp, err := r.FindByID(ctx, lid)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, lp.Version())
+	assert.NoError(t, r.Save(ctx, lp))
+	assert.Equal(t, 1, lp.Version())
+}
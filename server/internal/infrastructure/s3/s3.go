@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -24,11 +25,14 @@ import (
 )
 
 const (
-	assetBasePath  string = "assets"
-	pluginBasePath string = "plugins"
-	mapBasePath    string = "maps"
-	storyBasePath  string = "stories"
-	fileSizeLimit  int64  = 1024 * 1024 * 100 // about 100MB
+	assetBasePath    string = "assets"
+	pluginBasePath   string = "plugins"
+	mapBasePath      string = "maps"
+	storyBasePath    string = "stories"
+	revisionBasePath string = "revisions"
+	previewBasePath  string = "previews"
+	chunkBasePath    string = "chunks"
+	fileSizeLimit    int64  = 1024 * 1024 * 100 // about 100MB
 )
 
 type fileRepo struct {
@@ -119,6 +123,18 @@ func (f *fileRepo) ReadPluginFile(ctx context.Context, pid id.PluginID, filename
 	return f.read(ctx, path.Join(pluginBasePath, pid.String(), sn))
 }
 
+func (f *fileRepo) ListPluginFiles(ctx context.Context, pid id.PluginID) ([]string, error) {
+	dir := path.Join(pluginBasePath, pid.String())
+	var res []string
+	if err := f.walk(ctx, dir, func(name string, _ time.Time) error {
+		res = append(res, strings.TrimPrefix(name, dir+"/"))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (f *fileRepo) UploadPluginFile(ctx context.Context, pid id.PluginID, file *file.File) error {
 	sn := sanitize.Path(file.Path)
 	if sn == "" {
@@ -171,6 +187,24 @@ func (f *fileRepo) RemoveBuiltScene(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(mapBasePath, sn))
 }
 
+func (f *fileRepo) WalkBuiltScenes(ctx context.Context, fn func(io.Reader) error) error {
+	for _, p := range []string{mapBasePath, revisionBasePath, previewBasePath} {
+		if err := f.walk(ctx, p, func(name string, _ time.Time) error {
+			r, err := f.read(ctx, name)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = r.Close()
+			}()
+			return fn(r)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // stories
 
 func (f *fileRepo) ReadStoryFile(ctx context.Context, name string) (io.ReadCloser, error) {
@@ -208,6 +242,101 @@ func (f *fileRepo) RemoveStory(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(storyBasePath, sn))
 }
 
+// built scene chunks
+
+func (f *fileRepo) ReadBuiltSceneChunk(ctx context.Context, hash string) (io.ReadCloser, error) {
+	if hash == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, path.Join(chunkBasePath, sanitize.Path(hash)+".json"))
+}
+
+func (f *fileRepo) UploadBuiltSceneChunk(ctx context.Context, content io.Reader, hash string) error {
+	sn := sanitize.Path(hash + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(chunkBasePath, sn), content)
+	return err
+}
+
+func (f *fileRepo) BuiltSceneChunkUpdatedAt(ctx context.Context, hash string) (time.Time, error) {
+	if hash == "" {
+		return time.Time{}, rerror.ErrNotFound
+	}
+	return f.updatedAt(ctx, path.Join(chunkBasePath, sanitize.Path(hash)+".json"))
+}
+
+func (f *fileRepo) WalkBuiltSceneChunks(ctx context.Context, fn func(string, time.Time) error) error {
+	return f.walk(ctx, chunkBasePath, func(name string, updatedAt time.Time) error {
+		return fn(strings.TrimSuffix(path.Base(name), ".json"), updatedAt)
+	})
+}
+
+func (f *fileRepo) RemoveBuiltSceneChunk(ctx context.Context, hash string) error {
+	sn := sanitize.Path(hash + ".json")
+	if hash == "" || sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(chunkBasePath, sn))
+}
+
+// revisions
+
+func (f *fileRepo) ReadRevisionFile(ctx context.Context, name string) (io.ReadCloser, error) {
+	if name == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, path.Join(revisionBasePath, sanitize.Path(name)+".json"))
+}
+
+func (f *fileRepo) UploadRevision(ctx context.Context, content io.Reader, name string) error {
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(revisionBasePath, sn), content)
+	return err
+}
+
+func (f *fileRepo) RemoveRevision(ctx context.Context, name string) error {
+	log.Infofc(ctx, "s3: revision deleted: %s", name)
+
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(revisionBasePath, sn))
+}
+
+// previews
+
+func (f *fileRepo) ReadPreviewFile(ctx context.Context, name string) (io.ReadCloser, error) {
+	if name == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, path.Join(previewBasePath, sanitize.Path(name)+".json"))
+}
+
+func (f *fileRepo) UploadPreview(ctx context.Context, content io.Reader, name string) error {
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(previewBasePath, sn), content)
+	return err
+}
+
+func (f *fileRepo) RemovePreview(ctx context.Context, name string) error {
+	log.Infofc(ctx, "s3: preview deleted: %s", name)
+
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(previewBasePath, sn))
+}
+
 // helpers
 
 func (f *fileRepo) read(ctx context.Context, filename string) (io.ReadCloser, error) {
@@ -226,6 +355,41 @@ func (f *fileRepo) read(ctx context.Context, filename string) (io.ReadCloser, er
 	return obj.Body, nil
 }
 
+func (f *fileRepo) updatedAt(ctx context.Context, filename string) (time.Time, error) {
+	obj, err := f.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(f.bucketName),
+		Key:    aws.String(filename),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return time.Time{}, rerror.ErrNotFound
+		}
+		return time.Time{}, rerror.ErrInternalByWithContext(ctx, fmt.Errorf("s3: updatedAt err: %+v", err))
+	}
+	return lo.FromPtr(obj.LastModified), nil
+}
+
+// walk calls the function with the key and the last modified time of each object under the directory.
+func (f *fileRepo) walk(ctx context.Context, dir string, fn func(string, time.Time) error) error {
+	p := s3.NewListObjectsV2Paginator(f.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(f.bucketName),
+		Prefix: aws.String(dir + "/"),
+	})
+	for p.HasMorePages() {
+		l, err := p.NextPage(ctx)
+		if err != nil {
+			return rerror.ErrInternalByWithContext(ctx, fmt.Errorf("s3: walk err: %+v", err))
+		}
+		for _, obj := range l.Contents {
+			if err := fn(lo.FromPtr(obj.Key), lo.FromPtr(obj.LastModified)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (f *fileRepo) upload(ctx context.Context, filename string, content io.Reader) (int64, error) {
 	if filename == "" {
 		return 0, gateway.ErrInvalidFile
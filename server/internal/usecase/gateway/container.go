@@ -9,4 +9,6 @@ type Container struct {
 	PluginRegistry PluginRegistry
 	File           File
 	Google         Google
+	ProjectArchive ProjectArchive
+	PubSub         PubSub
 }
@@ -9,7 +9,8 @@ import (
 )
 
 var (
-	ErrDataSourceInvalidURL error = errors.New("invalid url")
+	ErrDataSourceInvalidURL        error = errors.New("invalid url")
+	ErrDataSourceUnsupportedFormat error = errors.New("unsupported data format")
 )
 
 type DataSource interface {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
@@ -23,6 +24,8 @@ type File interface {
 	RemoveAsset(context.Context, *url.URL) error
 
 	ReadPluginFile(context.Context, id.PluginID, string) (io.ReadCloser, error)
+	// ListPluginFiles returns paths of all files of the plugin relative to the plugin directory.
+	ListPluginFiles(context.Context, id.PluginID) ([]string, error)
 	UploadPluginFile(context.Context, id.PluginID, *file.File) error
 	RemovePlugin(context.Context, id.PluginID) error
 
@@ -31,8 +34,26 @@ type File interface {
 	MoveBuiltScene(context.Context, string, string) error
 	RemoveBuiltScene(context.Context, string) error
 
+	// WalkBuiltScenes calls the function with each built scene, revision and preview, which may be manifests of chunks.
+	WalkBuiltScenes(context.Context, func(io.Reader) error) error
+
+	UploadBuiltSceneChunk(context.Context, io.Reader, string) error
+	ReadBuiltSceneChunk(context.Context, string) (io.ReadCloser, error)
+	// BuiltSceneChunkUpdatedAt returns when the chunk was uploaded last, or rerror.ErrNotFound if it does not exist.
+	BuiltSceneChunkUpdatedAt(context.Context, string) (time.Time, error)
+	WalkBuiltSceneChunks(context.Context, func(hash string, updatedAt time.Time) error) error
+	RemoveBuiltSceneChunk(context.Context, string) error
+
 	UploadStory(context.Context, io.Reader, string) error
 	ReadStoryFile(context.Context, string) (io.ReadCloser, error)
 	MoveStory(context.Context, string, string) error
 	RemoveStory(context.Context, string) error
+
+	UploadRevision(context.Context, io.Reader, string) error
+	ReadRevisionFile(context.Context, string) (io.ReadCloser, error)
+	RemoveRevision(context.Context, string) error
+
+	UploadPreview(context.Context, io.Reader, string) error
+	ReadPreviewFile(context.Context, string) (io.ReadCloser, error)
+	RemovePreview(context.Context, string) error
 }
@@ -5,5 +5,6 @@ import (
 )
 
 type Google interface {
+	// FetchCSV fetches the sheet as CSV. The token can be empty if the file is shared with anyone with the link.
 	FetchCSV(token string, fileId string, sheetName string) (io.ReadCloser, error)
 }
@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/tag"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+var (
+	ErrInvalidProjectArchive     error = errors.New("invalid project archive")
+	ErrUnsupportedProjectArchive error = errors.New("unsupported project archive version")
+	ErrProjectArchiveTooLarge    error = errors.New("project archive is too large")
+)
+
+// ProjectArchiveData is a whole project with its scene and everything that belongs to the scene.
+// Plugins are not included; the scene only refers to installed plugins.
+type ProjectArchiveData struct {
+	Project        *project.Project
+	Scene          *scene.Scene
+	Layers         layer.List
+	NLSLayers      nlslayer.NLSLayerList
+	Properties     property.List
+	DatasetSchemas dataset.SchemaList
+	Datasets       dataset.List
+	Tags           []*tag.Tag
+	Styles         scene.StyleList
+	Stories        storytelling.StoryList
+}
+
+type ProjectArchive interface {
+	// Write writes the project as a versioned archive.
+	Write(context.Context, io.Writer, *ProjectArchiveData) error
+	// Read reads a project from an archive. All IDs in the archive are replaced with new ones
+	// so that the project can be restored next to the original, and the project is moved to the workspace.
+	Read(context.Context, io.Reader, accountdomain.WorkspaceID) (*ProjectArchiveData, error)
+}
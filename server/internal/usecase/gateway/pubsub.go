@@ -0,0 +1,27 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+// SceneEvent notifies clients viewing a scene that objects in it have been changed.
+type SceneEvent struct {
+	Scene id.SceneID
+	// Action is the same as the one recorded in the audit log, such as "layer.update".
+	Action  auditlog.Action
+	Targets []auditlog.Target
+	// Actor is nil if the change was made by the system.
+	Actor     *accountdomain.UserID
+	CreatedAt time.Time
+}
+
+type PubSub interface {
+	PublishSceneEvent(context.Context, SceneEvent) error
+	// SubscribeSceneEvents returns a channel of the events of the scene, which is closed when the context is done.
+	SubscribeSceneEvents(context.Context, id.SceneID) (<-chan SceneEvent, error)
+}
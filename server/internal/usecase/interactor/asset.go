@@ -1,29 +1,40 @@
 package interactor
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/url"
 	"path"
+	"strings"
 
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/policy"
 	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/usecasex"
 )
 
+// assetMetadataMaxSize is the size of the leading part of an uploaded file kept in memory to extract its metadata.
+// Thumbnails are generated only for files which fit in it.
+const assetMetadataMaxSize = 32 * 1024 * 1024
+
 type Asset struct {
+	usageWarner
 	repos    *repo.Container
 	gateways *gateway.Container
 }
 
 func NewAsset(r *repo.Container, g *gateway.Container) interfaces.Asset {
 	return &Asset{
-		repos:    r,
-		gateways: g,
+		usageWarner: newUsageWarner(r, g),
+		repos:       r,
+		gateways:    g,
 	}
 }
 
@@ -31,15 +42,16 @@ func (i *Asset) Fetch(ctx context.Context, assets []id.AssetID, operator *usecas
 	return i.repos.Asset.FindByIDs(ctx, assets)
 }
 
-func (i *Asset) FindByWorkspace(ctx context.Context, tid accountdomain.WorkspaceID, keyword *string, sort *asset.SortType, p *usecasex.Pagination, operator *usecase.Operator) ([]*asset.Asset, *usecasex.PageInfo, error) {
+func (i *Asset) FindByWorkspace(ctx context.Context, tid accountdomain.WorkspaceID, keyword *string, contentTypes []string, sort *asset.SortType, p *usecasex.Pagination, operator *usecase.Operator) ([]*asset.Asset, *usecasex.PageInfo, error) {
 	return Run2(
 		ctx, operator, i.repos,
 		Usecase().WithReadableWorkspaces(tid),
 		func(ctx context.Context) ([]*asset.Asset, *usecasex.PageInfo, error) {
 			return i.repos.Asset.FindByWorkspace(ctx, tid, repo.AssetFilter{
-				Sort:       sort,
-				Keyword:    keyword,
-				Pagination: p,
+				Sort:         sort,
+				Keyword:      keyword,
+				ContentTypes: contentTypes,
+				Pagination:   p,
 			})
 		},
 	)
@@ -59,33 +71,65 @@ func (i *Asset) Create(ctx context.Context, inp interfaces.CreateAssetParam, ope
 		return nil, interfaces.ErrOperationDenied
 	}
 
-	url, size, err := i.gateways.File.UploadAsset(ctx, inp.File)
+	head := &headBuffer{max: assetMetadataMaxSize}
+	f := *inp.File
+	f.Content = struct {
+		io.Reader
+		io.Closer
+	}{io.TeeReader(inp.File.Content, head), inp.File.Content}
+
+	url, size, err := i.gateways.File.UploadAsset(ctx, &f)
 	if err != nil {
 		return nil, err
 	}
 
 	// enforce policy
+	var pol *policy.Policy
 	if policyID := operator.Policy(ws.Policy()); policyID != nil {
-		p, err := i.repos.Policy.FindByID(ctx, *policyID)
-		if err != nil {
+		if pol, err = i.repos.Policy.FindByID(ctx, *policyID); err != nil {
 			return nil, err
 		}
 		s, err := i.repos.Asset.TotalSizeByWorkspace(ctx, ws.ID())
 		if err != nil {
 			return nil, err
 		}
-		if err := p.EnforceAssetStorageSize(s + size); err != nil {
+		if err := pol.EnforceUploadSize(size); err != nil {
+			_ = i.gateways.File.RemoveAsset(ctx, url)
+			return nil, err
+		}
+		if err := pol.EnforceAssetStorageSize(s + size); err != nil {
+			_ = i.gateways.File.RemoveAsset(ctx, url)
+			return nil, err
+		}
+	}
+
+	name := path.Base(inp.File.Path)
+	contentType := asset.DetectContentType(name, head.Bytes())
+	if contentType == "application/octet-stream" && inp.File.ContentType != "" {
+		contentType = inp.File.ContentType
+	}
+	// metadata is optional, so files which cannot be parsed are stored without it
+	metadata, _ := asset.ExtractMetadata(contentType, head.Bytes())
+
+	var thumbnail string
+	if !head.truncated {
+		if thumbnailURL, err := i.uploadThumbnail(ctx, name, contentType, head.Bytes()); err != nil {
 			_ = i.gateways.File.RemoveAsset(ctx, url)
 			return nil, err
+		} else if thumbnailURL != nil {
+			thumbnail = thumbnailURL.String()
 		}
 	}
 
 	a, err := asset.New().
 		NewID().
 		Workspace(inp.WorkspaceID).
-		Name(path.Base(inp.File.Path)).
+		Name(name).
 		Size(size).
 		URL(url.String()).
+		ContentType(contentType).
+		Metadata(metadata).
+		Thumbnail(thumbnail).
 		Build()
 	if err != nil {
 		return nil, err
@@ -95,6 +139,7 @@ func (i *Asset) Create(ctx context.Context, inp interfaces.CreateAssetParam, ope
 		return nil, err
 	}
 
+	i.warnUsage(ctx, ws.ID(), pol)
 	return a, nil
 }
 
@@ -117,8 +162,52 @@ func (i *Asset) Remove(ctx context.Context, aid id.AssetID, operator *usecase.Op
 					return aid, err
 				}
 			}
+			if asset.Thumbnail() != "" {
+				if url, _ := url.Parse(asset.Thumbnail()); url != nil {
+					if err := i.gateways.File.RemoveAsset(ctx, url); err != nil {
+						return aid, err
+					}
+				}
+			}
 
 			return aid, i.repos.Asset.Remove(ctx, aid)
 		},
 	)
 }
+
+// uploadThumbnail uploads a thumbnail of the file. It returns nil if the file does not support thumbnails.
+func (i *Asset) uploadThumbnail(ctx context.Context, name, contentType string, data []byte) (*url.URL, error) {
+	thumbnail, err := asset.Thumbnail(contentType, data, asset.ThumbnailSize)
+	if err != nil {
+		return nil, nil
+	}
+
+	u, _, err := i.gateways.File.UploadAsset(ctx, &file.File{
+		Content:     io.NopCloser(bytes.NewReader(thumbnail)),
+		Path:        strings.TrimSuffix(name, path.Ext(name)) + ".thumbnail.png",
+		Size:        int64(len(thumbnail)),
+		ContentType: "image/png",
+	})
+	return u, err
+}
+
+// headBuffer keeps the leading bytes written to it up to max.
+type headBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if n := b.max - b.buf.Len(); n < len(p) {
+		b.truncated = true
+		b.buf.Write(p[:max(n, 0)])
+	} else {
+		b.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+func (b *headBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
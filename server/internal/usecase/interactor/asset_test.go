@@ -3,7 +3,11 @@ package interactor
 import (
 	"bytes"
 	"context"
+	"image"
+	"image/png"
 	"io"
+	"net/url"
+	"path"
 	"testing"
 
 	"github.com/reearth/reearth/server/internal/infrastructure/fs"
@@ -65,7 +69,7 @@ func TestAsset_Create(t *testing.T) {
 		CreatedAt(aid.Timestamp()).
 		Name("hoge.txt").
 		Size(buflen).
-		ContentType("").
+		ContentType("text/plain; charset=utf-8").
 		MustBuild()
 
 	assert.NoError(t, err)
@@ -73,3 +77,66 @@ func TestAsset_Create(t *testing.T) {
 	a, _ := uc.repos.Asset.FindByID(ctx, aid)
 	assert.Equal(t, want, a)
 }
+
+func TestAsset_Create_Image(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	mfs := afero.NewMemMapFs()
+	f, _ := fs.NewFile(mfs, "https://example.com/assets")
+	uc := &Asset{
+		repos: &repo.Container{
+			Asset:     memory.NewAsset(),
+			Workspace: accountmemory.NewWorkspaceWith(ws),
+		},
+		gateways: &gateway.Container{
+			File: f,
+		},
+	}
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 512, 256))))
+	res, err := uc.Create(ctx, interfaces.CreateAssetParam{
+		WorkspaceID: ws.ID(),
+		File: &file.File{
+			Content:     io.NopCloser(buf),
+			Path:        "image",
+			ContentType: "application/octet-stream",
+		},
+	}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", res.ContentType())
+	assert.Equal(t, &asset.Metadata{Width: 512, Height: 256}, res.Metadata())
+	assert.NotEmpty(t, res.Thumbnail())
+
+	u, _ := url.Parse(res.Thumbnail())
+	r, err := f.ReadAsset(ctx, path.Base(u.Path))
+	assert.NoError(t, err)
+	thumbnail, err := png.DecodeConfig(r)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{asset.ThumbnailSize, asset.ThumbnailSize / 2}, []int{thumbnail.Width, thumbnail.Height})
+
+	// filter by content type
+	rop := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+	}
+	got, _, err := uc.FindByWorkspace(ctx, ws.ID(), nil, []string{"video", "image"}, nil, nil, rop)
+	assert.NoError(t, err)
+	assert.Equal(t, []*asset.Asset{res}, got)
+	got, _, err = uc.FindByWorkspace(ctx, ws.ID(), nil, []string{"image/jpeg"}, nil, nil, rop)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	// the thumbnail is removed with the asset
+	_, err = uc.Remove(ctx, res.ID(), op)
+	assert.NoError(t, err)
+	_, err = f.ReadAsset(ctx, path.Base(u.Path))
+	assert.Error(t, err)
+}
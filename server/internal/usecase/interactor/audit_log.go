@@ -0,0 +1,131 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+)
+
+const defaultAuditLogPageSize = 50
+
+type AuditLog struct {
+	common
+	auditLogRepo repo.AuditLog
+}
+
+func NewAuditLog(r *repo.Container) interfaces.AuditLog {
+	return &AuditLog{
+		auditLogRepo: r.AuditLog,
+	}
+}
+
+func (i *AuditLog) FindByWorkspace(ctx context.Context, wid accountdomain.WorkspaceID, f interfaces.AuditLogFilter, p *usecasex.Pagination, op *usecase.Operator) (auditlog.List, *usecasex.PageInfo, error) {
+	if err := i.OnlyOperator(op); err != nil {
+		return nil, nil, err
+	}
+	if !op.IsMaintainingWorkspace(wid) {
+		return nil, nil, interfaces.ErrOperationDenied
+	}
+
+	if p == nil {
+		p = usecasex.CursorPagination{First: lo.ToPtr(int64(defaultAuditLogPageSize))}.Wrap()
+	}
+	return i.auditLogRepo.FindByWorkspace(ctx, wid, repo.AuditLogFilter{
+		Scene:   f.Scene,
+		Actor:   f.Actor,
+		Actions: f.Actions,
+		Target:  f.Target,
+		Since:   f.Since,
+		Until:   f.Until,
+	}, p)
+}
+
+// commonAudit records mutations made by interactors to the audit log, and notifies clients viewing the scene of them.
+// The zero value records nothing, so interactors built without repos keep working.
+type commonAudit struct {
+	auditLogRepo   repo.AuditLog
+	auditSceneRepo repo.Scene
+	auditPubSub    gateway.PubSub
+}
+
+func newCommonAudit(r *repo.Container, g *gateway.Container) commonAudit {
+	a := commonAudit{
+		auditLogRepo:   r.AuditLog,
+		auditSceneRepo: r.Scene,
+	}
+	if g != nil {
+		a.auditPubSub = g.PubSub
+	}
+	return a
+}
+
+// auditScene records a mutation on the scene and its workspace.
+func (a commonAudit) auditScene(ctx context.Context, op *usecase.Operator, action auditlog.Action, sid id.SceneID, targets []auditlog.Target, changes ...*auditlog.Change) error {
+	if a.auditLogRepo == nil || a.auditSceneRepo == nil {
+		a.publishSceneEvent(ctx, op.UserID(), action, sid, targets...)
+		return nil
+	}
+	s, err := a.auditSceneRepo.FindByID(ctx, sid)
+	if err != nil {
+		return fmt.Errorf("audit: failed to find scene %s for %s: %w", sid, action, err)
+	}
+	return a.audit(ctx, op, action, s.Workspace(), &sid, targets, changes...)
+}
+
+// audit records a mutation in the transaction of the mutation.
+// The error has to be returned so that the mutation is rolled back instead of being made without its record.
+func (a commonAudit) audit(ctx context.Context, op *usecase.Operator, action auditlog.Action, wid accountdomain.WorkspaceID, sid *id.SceneID, targets []auditlog.Target, changes ...*auditlog.Change) error {
+	if sid != nil {
+		a.publishSceneEvent(ctx, op.UserID(), action, *sid, targets...)
+	}
+	if a.auditLogRepo == nil {
+		return nil
+	}
+	l, err := auditlog.New().
+		NewID().
+		Actor(op.UserID()).
+		Action(action).
+		Workspace(wid).
+		Scene(sid).
+		Targets(targets...).
+		Changes(changes...).
+		Build()
+	if err == nil {
+		err = a.auditLogRepo.Save(ctx, l)
+	}
+	if err != nil {
+		return fmt.Errorf("audit: failed to record %s: %w", action, err)
+	}
+	return nil
+}
+
+// publishSceneEvent notifies clients viewing the scene of the mutation once the transaction is committed,
+// so that clients do not refetch the objects before the changes are visible. Failures are only logged.
+func (a commonAudit) publishSceneEvent(ctx context.Context, actor *accountdomain.UserID, action auditlog.Action, sid id.SceneID, targets ...auditlog.Target) {
+	if a.auditPubSub == nil {
+		return
+	}
+	e := gateway.SceneEvent{
+		Scene:     sid,
+		Action:    action,
+		Targets:   targets,
+		Actor:     actor,
+		CreatedAt: time.Now(),
+	}
+	repo.AfterCommit(ctx, func() {
+		if err := a.auditPubSub.PublishSceneEvent(ctx, e); err != nil {
+			log.Errorfc(ctx, "pubsub: failed to publish %s: %v", action, err)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLog_Project(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	uid := accountdomain.NewUserID()
+	prj := project.New().NewID().Workspace(ws.ID()).Name("aaa").Alias("aliasalias").MustBuild()
+	sid := scene.NewID()
+	rootLayer := layer.NewGroup().NewID().Scene(sid).MustBuild()
+	prop := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(rootLayer.ID()).Property(prop.ID()).MustBuild()
+
+	r := memory.New()
+	_ = r.Workspace.Save(ctx, ws)
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.Layer.Save(ctx, rootLayer)
+	_ = r.Property.Save(ctx, prop)
+
+	f, _ := fs.NewFile(afero.NewMemMapFs(), "")
+	uc := NewProject(r, &gateway.Container{File: f})
+	auc := NewAuditLog(r)
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:                   &uid,
+			WritableWorkspaces:     workspace.IDList{ws.ID()},
+			ReadableWorkspaces:     workspace.IDList{ws.ID()},
+			MaintainableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+
+	_, err := uc.Update(ctx, interfaces.UpdateProjectParam{ID: prj.ID(), Name: lo.ToPtr("bbb"), Description: lo.ToPtr("")}, op)
+	assert.NoError(t, err)
+	_, err = uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj.ID(), Status: project.PublishmentStatusPublic}, op)
+	assert.NoError(t, err)
+	_, err = uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj.ID(), Status: project.PublishmentStatusPrivate}, op)
+	assert.NoError(t, err)
+
+	// all logs from the newest
+	logs, pi, err := auc.FindByWorkspace(ctx, ws.ID(), interfaces.AuditLogFilter{}, nil, op)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pi.TotalCount)
+	assert.Equal(t, []auditlog.Action{
+		auditlog.ActionProjectUnpublish,
+		auditlog.ActionProjectPublish,
+		auditlog.ActionProjectUpdate,
+	}, lo.Map(logs, func(l *auditlog.Log, _ int) auditlog.Action { return l.Action() }))
+	assert.Equal(t, []auditlog.Change{{Field: "name", Before: "aaa", After: "bbb"}}, logs[2].Changes())
+
+	// who unpublished the project
+	logs, _, err = auc.FindByWorkspace(ctx, ws.ID(), interfaces.AuditLogFilter{
+		Actions: []auditlog.Action{auditlog.ActionProjectUnpublish},
+		Target:  lo.ToPtr(prj.ID().String()),
+	}, nil, op)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, &uid, logs[0].Actor())
+	assert.Equal(t, &sid, logs[0].Scene())
+	assert.Equal(t, []auditlog.Target{auditlog.TargetOf(prj.ID())}, logs[0].Targets())
+	assert.Equal(t, []auditlog.Change{{Field: "publishmentStatus", Before: "public", After: "private"}}, logs[0].Changes())
+
+	// other actors
+	logs, _, err = auc.FindByWorkspace(ctx, ws.ID(), interfaces.AuditLogFilter{Actor: accountdomain.NewUserID().Ref()}, nil, op)
+	assert.NoError(t, err)
+	assert.Empty(t, logs)
+
+	// other mutations of the project
+	_, err = uc.AddBasicAuthCredential(ctx, interfaces.AddProjectBasicAuthCredentialParam{ID: prj.ID(), Name: "guest", Username: "user", Password: "password"}, op)
+	assert.NoError(t, err)
+	_, err = uc.RevokeBasicAuthCredential(ctx, interfaces.RevokeProjectBasicAuthCredentialParam{ID: prj.ID(), Name: "guest"}, op)
+	assert.NoError(t, err)
+	publishAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	_, err = uc.SchedulePublish(ctx, interfaces.SchedulePublishProjectParam{ID: prj.ID(), Status: project.PublishmentStatusPublic, PublishAt: &publishAt}, op)
+	assert.NoError(t, err)
+	_, err = uc.Rollback(ctx, interfaces.RollbackProjectParam{ID: prj.ID(), Revision: 1}, op)
+	assert.NoError(t, err)
+	dup, err := uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: prj.ID()}, op)
+	assert.NoError(t, err)
+
+	logs, _, err = auc.FindByWorkspace(ctx, ws.ID(), interfaces.AuditLogFilter{}, nil, op)
+	assert.NoError(t, err)
+	assert.Equal(t, []auditlog.Action{
+		auditlog.ActionProjectDuplicate,
+		auditlog.ActionProjectRollback,
+		auditlog.ActionProjectSchedulePublish,
+		auditlog.ActionProjectRevokeBasicAuthCredential,
+		auditlog.ActionProjectAddBasicAuthCredential,
+	}, lo.Map(logs[:5], func(l *auditlog.Log, _ int) auditlog.Action { return l.Action() }))
+	assert.Equal(t, []auditlog.Target{auditlog.TargetOf(dup.ID()), auditlog.TargetOf(prj.ID())}, logs[0].Targets())
+	assert.Equal(t, &sid, logs[1].Scene())
+	assert.Equal(t, []auditlog.Change{{Field: "publishmentStatus", Before: "private", After: "public"}}, logs[1].Changes())
+	assert.Equal(t, []auditlog.Change{{Field: "publishAt", Before: (*time.Time)(nil), After: &publishAt}}, logs[2].Changes())
+	assert.Equal(t, []auditlog.Change{{Field: "basicAuthCredential", Before: "guest", After: nil}}, logs[3].Changes())
+	assert.Equal(t, []auditlog.Change{{Field: "basicAuthCredential", Before: nil, After: "guest"}}, logs[4].Changes())
+
+	// only maintainers can see logs
+	_, _, err = auc.FindByWorkspace(ctx, ws.ID(), interfaces.AuditLogFilter{}, nil, &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:               &uid,
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+}
+
+func TestAuditLog_Grantee(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	prj := project.New().NewID().Workspace(ws.ID()).MustBuild()
+	sid := scene.NewID()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).Property(id.NewPropertyID()).MustBuild()
+	psf := property.NewSchemaField().ID("field").Type(property.ValueTypeString).MustBuild()
+	psg := property.NewSchemaGroup().ID("foobar").Fields([]*property.SchemaField{psf}).MustBuild()
+	ps := property.NewSchema().ID(property.MustSchemaID("xxx~1.1.1/aa")).
+		Groups(property.NewSchemaGroupList([]*property.SchemaGroup{psg})).
+		MustBuild()
+	p := property.New().NewID().Scene(sid).Schema(ps.ID()).MustBuild()
+
+	r := memory.New()
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.PropertySchema.Save(ctx, ps)
+	_ = r.Property.Save(ctx, p)
+
+	// the editor is not a member of the workspace of the scene
+	uid := accountdomain.NewUserID()
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:             &uid,
+			OwningWorkspaces: workspace.IDList{workspace.NewID()},
+		},
+		WritableScenes:  scene.IDList{sid},
+		GrantedProjects: id.ProjectIDList{prj.ID()},
+	}
+	r2 := r.Filtered(repo.WorkspaceFilterFromOperator(op), repo.SceneFilterFromOperator(op)).Granted(op.GrantedProjects, op.AllReadableScenes(), op.AllWritableScenes())
+	uc := NewProperty(r2, &gateway.Container{})
+
+	_, _, _, _, err := uc.UpdateValue(ctx, interfaces.UpdatePropertyValueParam{
+		PropertyID: p.ID(),
+		Pointer:    property.PointFieldBySchemaGroup(psg.ID(), psf.ID()),
+		Value:      property.ValueTypeString.ValueFrom("aaa"),
+	}, op)
+	assert.NoError(t, err)
+
+	logs, _, err := r.AuditLog.FindByWorkspace(ctx, ws.ID(), repo.AuditLogFilter{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, auditlog.ActionPropertyUpdateValue, logs[0].Action())
+	assert.Equal(t, &uid, logs[0].Actor())
+	assert.Equal(t, &sid, logs[0].Scene())
+
+	// the editor can not write logs of other scenes
+	l := auditlog.New().NewID().Workspace(ws.ID()).Scene(scene.NewID().Ref()).Action(auditlog.ActionPropertyUpdateValue).MustBuild()
+	assert.ErrorIs(t, r2.AuditLog.Save(ctx, l), repo.ErrOperationDenied)
+}
+
+func TestAuditLog_Dataset(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	uid := accountdomain.NewUserID()
+	s := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+
+	r := memory.New()
+	_ = r.Workspace.Save(ctx, ws)
+	_ = r.Scene.Save(ctx, s)
+
+	uc := NewDataset(r, &gateway.Container{})
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:               &uid,
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+		},
+		WritableScenes: scene.IDList{s.ID()},
+	}
+
+	ds, err := uc.AddDatasetSchema(ctx, interfaces.AddDatasetSchemaParam{SceneId: s.ID(), Name: "aaa", RepresentativeField: id.NewDatasetFieldID().Ref()}, op)
+	assert.NoError(t, err)
+	_, err = uc.UpdateDatasetSchema(ctx, interfaces.UpdateDatasetSchemaParam{SchemaId: ds.ID(), Name: "bbb"}, op)
+	assert.NoError(t, err)
+	_, err = uc.RemoveDatasetSchema(ctx, interfaces.RemoveDatasetSchemaParam{SchemaID: ds.ID()}, op)
+	assert.NoError(t, err)
+
+	logs, _, err := r.AuditLog.FindByWorkspace(ctx, ws.ID(), repo.AuditLogFilter{Target: lo.ToPtr(ds.ID().String())}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []auditlog.Action{
+		auditlog.ActionDatasetRemoveSchema,
+		auditlog.ActionDatasetUpdateSchema,
+		auditlog.ActionDatasetAddSchema,
+	}, lo.Map(logs, func(l *auditlog.Log, _ int) auditlog.Action { return l.Action() }))
+	assert.Equal(t, &uid, logs[0].Actor())
+	assert.Equal(t, lo.ToPtr(s.ID()), logs[0].Scene())
+	assert.Equal(t, []auditlog.Change{{Field: "name", Before: "aaa", After: "bbb"}}, logs[1].Changes())
+}
@@ -3,19 +3,25 @@ package interactor
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io/fs"
 	"net/url"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountusecase"
 	"github.com/reearth/reearthx/account/accountusecase/accountgateway"
 	"github.com/reearth/reearthx/account/accountusecase/accountinteractor"
 	"github.com/reearth/reearthx/account/accountusecase/accountrepo"
+	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 )
 
@@ -24,6 +30,9 @@ type ContainerConfig struct {
 	AuthSrvUIDomain    string
 	PublishedIndexHTML string
 	PublishedIndexURL  *url.URL
+	PublishedViewerFS  fs.FS
+	PreviewURL         *url.URL
+	AssetBaseURL       string
 }
 
 func NewContainer(r *repo.Container, g *gateway.Container,
@@ -36,15 +45,26 @@ func NewContainer(r *repo.Container, g *gateway.Container,
 		published = NewPublished(r.Project, r.Storytelling, g.File, config.PublishedIndexHTML)
 	}
 
+	prj := NewProjectWithConfig(r, g, ProjectConfig{
+		PreviewURL:         config.PreviewURL,
+		AssetBaseURL:       config.AssetBaseURL,
+		PublishedIndexHTML: config.PublishedIndexHTML,
+		PublishedIndexURL:  config.PublishedIndexURL,
+		PublishedViewerFS:  config.PublishedViewerFS,
+	})
+
 	return interfaces.Container{
 		Asset:        NewAsset(r, g),
+		AuditLog:     NewAuditLog(r),
 		Dataset:      NewDataset(r, g),
-		Layer:        NewLayer(r),
-		NLSLayer:     NewNLSLayer(r),
-		Style:        NewStyle(r),
+		History:      NewHistory(r, g),
+		Layer:        NewLayer(r, g),
+		NLSLayer:     NewNLSLayer(r, g),
+		Style:        NewStyle(r, g),
 		Plugin:       NewPlugin(r, g),
-		Policy:       NewPolicy(r),
-		Project:      NewProject(r, g),
+		Policy:       NewPolicy(r, g),
+		Project:      prj,
+		ProjectGrant: NewProjectGrant(r, g),
 		Property:     NewProperty(r, g),
 		Published:    published,
 		Scene:        NewScene(r, g),
@@ -107,6 +127,8 @@ func (i common) CanWriteScene(t id.SceneID, op *usecase.Operator) error {
 
 type commonSceneLock struct {
 	sceneLockRepo repo.SceneLock
+	// lockPubSub is optional, and notifies clients viewing the scene of lock changes if set.
+	lockPubSub gateway.PubSub
 }
 
 func (i commonSceneLock) CheckSceneLock(ctx context.Context, s id.SceneID) error {
@@ -136,20 +158,39 @@ func (i commonSceneLock) UpdateSceneLock(ctx context.Context, s id.SceneID, befo
 	if err != nil {
 		return err
 	}
+	i.publishSceneLock(ctx, s)
 	return nil
 }
 
 func (i commonSceneLock) ReleaseSceneLock(ctx context.Context, s id.SceneID) {
-	_ = i.sceneLockRepo.SaveLock(ctx, s, scene.LockModeFree)
+	if err := i.sceneLockRepo.SaveLock(ctx, s, scene.LockModeFree); err == nil {
+		i.publishSceneLock(ctx, s)
+	}
+}
+
+func (i commonSceneLock) publishSceneLock(ctx context.Context, s id.SceneID) {
+	if i.lockPubSub == nil {
+		return
+	}
+	if err := i.lockPubSub.PublishSceneEvent(ctx, gateway.SceneEvent{
+		Scene:     s,
+		Action:    auditlog.ActionSceneUpdateLock,
+		Targets:   []auditlog.Target{auditlog.TargetOf(s)},
+		CreatedAt: time.Now(),
+	}); err != nil {
+		log.Errorfc(ctx, "pubsub: failed to publish scene lock of %s: %v", s, err)
+	}
 }
 
 type SceneDeleter struct {
-	Scene         repo.Scene
-	SceneLock     repo.SceneLock
-	Layer         repo.Layer
-	Property      repo.Property
-	Dataset       repo.Dataset
-	DatasetSchema repo.DatasetSchema
+	Scene          repo.Scene
+	SceneLock      repo.SceneLock
+	Layer          repo.Layer
+	Property       repo.Property
+	Dataset        repo.Dataset
+	DatasetSchema  repo.DatasetSchema
+	DatasetSyncLog repo.DatasetSyncLog
+	History        repo.History
 }
 
 func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) error {
@@ -188,6 +229,20 @@ func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) er
 		return err
 	}
 
+	// Delete dataset sync logs
+	if d.DatasetSyncLog != nil {
+		if err := d.DatasetSyncLog.RemoveByScene(ctx, s.ID()); err != nil {
+			return err
+		}
+	}
+
+	// Delete undo history
+	if d.History != nil {
+		if err := d.History.RemoveByScene(ctx, s.ID()); err != nil {
+			return err
+		}
+	}
+
 	// Release scene lock
 	if err := d.SceneLock.SaveLock(ctx, s.ID(), scene.LockModeFree); err != nil {
 		return err
@@ -203,8 +258,10 @@ func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) er
 
 type ProjectDeleter struct {
 	SceneDeleter
-	File    gateway.File
-	Project repo.Project
+	File         gateway.File
+	Project      repo.Project
+	ProjectGrant repo.ProjectGrant
+	Revision     repo.Revision
 }
 
 func (d ProjectDeleter) Delete(ctx context.Context, prj *project.Project, force bool, operator *usecase.Operator) error {
@@ -230,6 +287,36 @@ func (d ProjectDeleter) Delete(ctx context.Context, prj *project.Project, force
 		}
 	}
 
+	// Delete preview
+	if p := prj.Preview(); p != nil {
+		if err := d.File.RemovePreview(ctx, p.Token()); err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+	}
+
+	// Delete revisions
+	if d.Revision != nil {
+		revisions, err := d.Revision.FindByProject(ctx, prj.ID())
+		if err != nil {
+			return err
+		}
+		for _, rev := range revisions {
+			if err := d.File.RemoveRevision(ctx, rev.FileName()); err != nil {
+				return err
+			}
+		}
+		if err := d.Revision.RemoveByProject(ctx, prj.ID()); err != nil {
+			return err
+		}
+	}
+
+	// Delete grants
+	if d.ProjectGrant != nil {
+		if err := d.ProjectGrant.RemoveByProject(ctx, prj.ID()); err != nil {
+			return err
+		}
+	}
+
 	// Delete project
 	if err := d.Project.Remove(ctx, prj.ID()); err != nil {
 		return err
@@ -237,3 +324,27 @@ func (d ProjectDeleter) Delete(ctx context.Context, prj *project.Project, force
 
 	return nil
 }
+
+func isSceneLocked(err error) bool {
+	return errors.Is(err, interfaces.ErrSceneIsLocked) || errors.Is(err, scene.ErrSceneIsLocked)
+}
+
+// scheduledPublishOperator returns an operator that runs publish schedules on behalf of their workspace.
+func scheduledPublishOperator(ws accountdomain.WorkspaceID, scenes ...id.SceneID) *usecase.Operator {
+	return &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws},
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws},
+		},
+		ReadableScenes: scenes,
+		WritableScenes: scenes,
+	}
+}
+
+// checkVersion fails if the client expects a version of the object other than the current one.
+func checkVersion(id fmt.Stringer, version int, expected *int) error {
+	if expected != nil && *expected != version {
+		return repo.NewVersionConflictError(id, *expected)
+	}
+	return nil
+}
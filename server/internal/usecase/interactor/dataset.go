@@ -1,15 +1,20 @@
 package interactor
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/auditlog"
 	"github.com/reearth/reearth/server/pkg/layer/layerops"
 	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/account/accountusecase/accountrepo"
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
@@ -24,6 +29,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/sceneops"
+	"github.com/samber/lo"
 )
 
 var extensionForLinkedLayers = id.PluginExtensionID("marker")
@@ -31,35 +37,43 @@ var extensionForLinkedLayers = id.PluginExtensionID("marker")
 type Dataset struct {
 	common
 	commonSceneLock
-	sceneRepo         repo.Scene
-	datasetRepo       repo.Dataset
-	datasetSchemaRepo repo.DatasetSchema
-	propertyRepo      repo.Property
-	layerRepo         repo.Layer
-	pluginRepo        repo.Plugin
-	policyRepo        repo.Policy
-	workspaceRepo     accountrepo.Workspace
-	datasource        gateway.DataSource
-	file              gateway.File
-	google            gateway.Google
-	transaction       usecasex.Transaction
+	commonAudit
+	usageWarner
+	sceneRepo          repo.Scene
+	datasetRepo        repo.Dataset
+	datasetSchemaRepo  repo.DatasetSchema
+	propertyRepo       repo.Property
+	layerRepo          repo.Layer
+	pluginRepo         repo.Plugin
+	policyRepo         repo.Policy
+	workspaceRepo      accountrepo.Workspace
+	datasource         gateway.DataSource
+	file               gateway.File
+	google             gateway.Google
+	transaction        usecasex.Transaction
+	datasetSyncLogRepo repo.DatasetSyncLog
+	project            *Project
 }
 
 func NewDataset(r *repo.Container, gr *gateway.Container) interfaces.Dataset {
 	return &Dataset{
-		commonSceneLock:   commonSceneLock{sceneLockRepo: r.SceneLock},
-		sceneRepo:         r.Scene,
-		workspaceRepo:     r.Workspace,
-		datasetRepo:       r.Dataset,
-		datasetSchemaRepo: r.DatasetSchema,
-		propertyRepo:      r.Property,
-		layerRepo:         r.Layer,
-		pluginRepo:        r.Plugin,
-		transaction:       r.Transaction,
-		policyRepo:        r.Policy,
-		datasource:        gr.DataSource,
-		file:              gr.File,
-		google:            gr.Google,
+		commonSceneLock:    commonSceneLock{sceneLockRepo: r.SceneLock, lockPubSub: gr.PubSub},
+		sceneRepo:          r.Scene,
+		workspaceRepo:      r.Workspace,
+		datasetRepo:        r.Dataset,
+		datasetSchemaRepo:  r.DatasetSchema,
+		propertyRepo:       r.Property,
+		layerRepo:          r.Layer,
+		pluginRepo:         r.Plugin,
+		transaction:        r.Transaction,
+		policyRepo:         r.Policy,
+		datasource:         gr.DataSource,
+		file:               gr.File,
+		google:             gr.Google,
+		datasetSyncLogRepo: r.DatasetSyncLog,
+		project:            newProject(r, gr),
+		commonAudit:        newCommonAudit(r, gr),
+		usageWarner:        newUsageWarner(r, gr),
 	}
 }
 
@@ -67,28 +81,46 @@ func (i *Dataset) Fetch(ctx context.Context, ids []id.DatasetID) (dataset.List,
 	return i.datasetRepo.FindByIDs(ctx, ids)
 }
 
-func (i *Dataset) Export(ctx context.Context, id id.DatasetSchemaID, format string, w io.Writer, before func(string, string)) error {
-	f, ok := dataset.ExportFormat(format)
+func (i *Dataset) Export(ctx context.Context, inp interfaces.ExportDatasetParam, w io.Writer, before func(string, string)) error {
+	f, ok := dataset.ExportFormat(inp.Format)
 	if !ok {
 		return rerror.ErrNotFound
 	}
 
-	s, err := i.datasetSchemaRepo.FindByID(ctx, id)
+	s, err := i.datasetSchemaRepo.FindByID(ctx, inp.SchemaID)
 	if err != nil {
 		return err
 	}
 
+	var fields []dataset.FieldID
+	if len(inp.Fields) > 0 {
+		for _, name := range inp.Fields {
+			sf := s.FieldRef(dataset.FieldIDFromRef(&name))
+			if sf == nil {
+				sf, _ = lo.Find(s.Fields(), func(f *dataset.SchemaField) bool { return f.Name() == name })
+			}
+			if sf == nil {
+				return interfaces.ErrDatasetFieldNotFound
+			}
+			fields = append(fields, sf.ID())
+		}
+		s = s.SelectFields(fields)
+	}
+
 	name := s.Name()
 	if !strings.HasSuffix(name, "."+f.Ext) {
 		name += "." + f.Ext
 	}
 	before(name, f.ContentType)
 
-	if err := dataset.Export(w, format, s, true, func(f func(*dataset.Dataset) error) error {
-		return i.datasetRepo.FindBySchemaAllBy(ctx, id, func(d *dataset.Dataset) error {
-			if d == nil {
+	if err := dataset.Export(w, inp.Format, s, true, func(f func(*dataset.Dataset) error) error {
+		return i.datasetRepo.FindBySchemaAllBy(ctx, inp.SchemaID, func(d *dataset.Dataset) error {
+			if d == nil || len(inp.Datasets) > 0 && !lo.Contains(inp.Datasets, d.ID()) {
 				return nil
 			}
+			if fields != nil {
+				d = d.SelectFields(fields)
+			}
 			return f(d)
 		})
 	}); err != nil {
@@ -98,7 +130,7 @@ func (i *Dataset) Export(ctx context.Context, id id.DatasetSchemaID, format stri
 	return nil
 }
 
-func (i *Dataset) UpdateDatasetSchema(ctx context.Context, inp interfaces.UpdateDatasetSchemaParam, _ *usecase.Operator) (_ *dataset.Schema, err error) {
+func (i *Dataset) UpdateDatasetSchema(ctx context.Context, inp interfaces.UpdateDatasetSchemaParam, operator *usecase.Operator) (_ *dataset.Schema, err error) {
 	schema, err := i.datasetSchemaRepo.FindByID(ctx, inp.SchemaId)
 	if err != nil {
 		return nil, err
@@ -117,12 +149,19 @@ func (i *Dataset) UpdateDatasetSchema(ctx context.Context, inp interfaces.Update
 		}
 	}()
 
+	prevName := schema.Name()
 	schema.Rename(inp.Name)
 	err = i.datasetSchemaRepo.Save(ctx, schema)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionDatasetUpdateSchema, schema.Scene(), []auditlog.Target{auditlog.TargetOf(schema.ID())},
+		auditlog.NewChange("name", prevName, schema.Name()),
+	); err != nil {
+		return nil, err
+	}
+
 	// Commit db transaction
 	tx.Commit()
 	return schema, nil
@@ -132,16 +171,102 @@ func (i *Dataset) ImportDataset(ctx context.Context, inp interfaces.ImportDatase
 	if err := i.CanWriteScene(inp.SceneId, operator); err != nil {
 		return nil, err
 	}
+
+	csvParser, err := newImportParser(inp)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.importDataset(ctx, csvParser, inp.SceneId, inp.SchemaId, operator)
+}
+
+func (i *Dataset) PreviewDataset(ctx context.Context, inp interfaces.ImportDatasetParam, limit int, operator *usecase.Operator) (*dataset.CSVPreview, error) {
+	if err := i.CanWriteScene(inp.SceneId, operator); err != nil {
+		return nil, err
+	}
+
+	csvParser, err := newImportParser(inp)
+	if err != nil {
+		return nil, err
+	}
+	if err := csvParser.Init(); err != nil {
+		return nil, err
+	}
+
+	if inp.SchemaId != nil {
+		dss, err := i.datasetSchemaRepo.FindByID(ctx, *inp.SchemaId)
+		if err != nil {
+			return nil, err
+		}
+		if dss.Scene() != inp.SceneId {
+			return nil, interfaces.ErrOperationDenied
+		}
+		if err := csvParser.CheckCompatible(dss); err != nil {
+			return nil, err
+		}
+	} else if err := csvParser.GuessSchema(inp.SceneId); err != nil {
+		return nil, err
+	}
+
+	return csvParser.Preview(limit)
+}
+
+func (i *Dataset) ImportGeoPackage(ctx context.Context, inp interfaces.ImportGeoPackageParam, operator *usecase.Operator) (dataset.SchemaList, error) {
+	if err := i.CanWriteScene(inp.SceneId, operator); err != nil {
+		return nil, err
+	}
 	if inp.File == nil {
 		return nil, interfaces.ErrFileNotIncluded
 	}
 
-	separator := ','
-	if strings.HasSuffix(inp.File.Path, ".tsv") {
-		separator = '\t'
+	content, err := io.ReadAll(inp.File.Content)
+	if err != nil {
+		return nil, err
+	}
+	parsers, err := dataset.NewGeoPackageParsers(bytes.NewReader(content), int64(len(content)), inp.File.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsers) == 0 {
+		return nil, interfaces.ErrNoFeatureTables
 	}
 
-	return i.importDataset(ctx, inp.File.Content, inp.File.Path, separator, inp.SceneId, inp.SchemaId, operator)
+	res := make(dataset.SchemaList, 0, len(parsers))
+	for _, p := range parsers {
+		s, err := i.importDataset(ctx, p, inp.SceneId, nil, operator)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+// newImportParser returns a parser of the file by its extension. CSV is assumed if the extension is unknown.
+func newImportParser(inp interfaces.ImportDatasetParam) (*dataset.DatasetCSVParser, error) {
+	if inp.File == nil {
+		return nil, interfaces.ErrFileNotIncluded
+	}
+
+	var p *dataset.DatasetCSVParser
+	switch strings.ToLower(path.Ext(inp.File.Path)) {
+	case ".xlsx":
+		content, err := io.ReadAll(inp.File.Content)
+		if err != nil {
+			return nil, err
+		}
+		p, err = dataset.NewXLSXParser(bytes.NewReader(content), int64(len(content)), inp.File.Path, inp.SheetName)
+		if err != nil {
+			return nil, err
+		}
+	case ".tsv":
+		p = dataset.NewCSVParser(inp.File.Content, inp.File.Path, '\t')
+	default:
+		p = dataset.NewCSVParser(inp.File.Content, inp.File.Path, ',')
+	}
+	p.SetCRS(inp.CRS)
+	p.SetOptions(inp.Options)
+	return p, nil
 }
 
 func (i *Dataset) ImportDatasetFromGoogleSheet(ctx context.Context, inp interfaces.ImportDatasetFromGoogleSheetParam, operator *usecase.Operator) (_ *dataset.Schema, err error) {
@@ -161,10 +286,13 @@ func (i *Dataset) ImportDatasetFromGoogleSheet(ctx context.Context, inp interfac
 		}
 	}()
 
-	return i.importDataset(ctx, csvFile, inp.SheetName, ',', inp.SceneId, inp.SchemaId, operator)
+	csvParser := dataset.NewCSVParser(csvFile, inp.SheetName, ',')
+	csvParser.SetSource(dataset.GoogleSheetSource(inp.FileID, inp.SheetName))
+	return i.importDataset(ctx, csvParser, inp.SceneId, inp.SchemaId, operator)
 }
 
-func (i *Dataset) importDataset(ctx context.Context, content io.Reader, name string, separator rune, sceneId id.SceneID, schemaId *id.DatasetSchemaID, o *usecase.Operator) (_ *dataset.Schema, err error) {
+// importDataset imports the data of the parser as a new schema, or replaces datasets of the schema if schemaId is given.
+func (i *Dataset) importDataset(ctx context.Context, csvParser *dataset.DatasetCSVParser, sceneId id.SceneID, schemaId *id.DatasetSchemaID, o *usecase.Operator) (_ *dataset.Schema, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return
@@ -204,7 +332,6 @@ func (i *Dataset) importDataset(ctx context.Context, content io.Reader, name str
 		return nil, err
 	}
 
-	csvParser := dataset.NewCSVParser(content, name, separator)
 	err = csvParser.Init()
 	if err != nil {
 		return nil, err
@@ -325,6 +452,11 @@ func (i *Dataset) importDataset(ctx context.Context, content io.Reader, name str
 		}
 	}
 
+	if err := i.audit(ctx, o, auditlog.ActionDatasetImport, ws.ID(), &sceneId, []auditlog.Target{auditlog.TargetOf(schema.ID())}); err != nil {
+		return nil, err
+	}
+	i.warnUsage(ctx, ws.ID(), policy)
+
 	// Commit db transaction
 	tx.Commit()
 	return schema, nil
@@ -416,6 +548,81 @@ func (i *Dataset) Sync(ctx context.Context, sceneID id.SceneID, url string, oper
 		return nil, nil, interfaces.ErrDataSourceInvalidURL
 	}
 
+	return i.sync(ctx, sceneID, url, operator, time.Now())
+}
+
+// sync fetches the source again, replaces the old schemas and datasets of the same source with the new ones,
+// and records the result to the sync log. Scheduled refreshes have no operator.
+func (i *Dataset) sync(ctx context.Context, sceneID id.SceneID, source string, op *usecase.Operator, now time.Time) (dss dataset.SchemaList, ds dataset.List, err error) {
+	auto := op == nil
+	var stats syncStats
+	dss, ds, stats, err = i.syncSource(ctx, sceneID, source, op, now)
+	if auto && isSceneLocked(err) {
+		// the refresh will be tried again, so it is not worth logging
+		return
+	}
+
+	b := dataset.NewSyncLog().
+		NewID().
+		Scene(sceneID).
+		Source(source).
+		Auto(auto).
+		Stats(stats.added, stats.removed, stats.changed).
+		SyncedAt(now)
+	if err != nil {
+		b = b.Error(err.Error())
+	} else if len(dss) == 1 {
+		b = b.Schema(dss[0].ID().Ref())
+	}
+	if err2 := i.datasetSyncLogRepo.Save(ctx, b.MustBuild()); err2 != nil {
+		log.Errorfc(ctx, "dataset: failed to save sync log: %s", err2)
+	}
+	return
+}
+
+// scenePolicy returns the workspace of the scene and its policy, or nil if no policy is applied.
+// Without an operator, only the policy set to the workspace is applied.
+func (i *Dataset) scenePolicy(ctx context.Context, sceneID id.SceneID, op *usecase.Operator) (accountdomain.WorkspaceID, *policy.Policy, error) {
+	s, err := i.sceneRepo.FindByID(ctx, sceneID)
+	if err != nil {
+		return accountdomain.WorkspaceID{}, nil, err
+	}
+	ws, err := i.workspaceRepo.FindByID(ctx, s.Workspace())
+	if err != nil {
+		return accountdomain.WorkspaceID{}, nil, err
+	}
+	policyID := ws.Policy()
+	if op != nil {
+		policyID = op.Policy(policyID)
+	}
+	if policyID == nil {
+		return ws.ID(), nil, nil
+	}
+	p, err := i.policyRepo.FindByID(ctx, *policyID)
+	if err != nil {
+		return accountdomain.WorkspaceID{}, nil, err
+	}
+	return ws.ID(), p, nil
+}
+
+type syncStats struct {
+	added, removed, changed int
+}
+
+// syncSource fetches the source before beginning the transaction and locking the scene,
+// as fetching may take long and other edits of the scene should not wait for it.
+func (i *Dataset) syncSource(ctx context.Context, sceneID id.SceneID, source string, op *usecase.Operator, now time.Time) (dss dataset.SchemaList, ds dataset.List, stats syncStats, err error) {
+	// skip fetching when the scene is locked, as it can not be synced anyway
+	if err := i.CheckSceneLock(ctx, sceneID); err != nil {
+		return nil, nil, stats, err
+	}
+
+	// Fetch
+	dss, ds, err = i.fetchSource(ctx, sceneID, source)
+	if err != nil {
+		return nil, nil, stats, err
+	}
+
 	// Begin Db transaction
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -430,23 +637,50 @@ func (i *Dataset) Sync(ctx context.Context, sceneID id.SceneID, url string, oper
 	}()
 
 	if err := i.UpdateSceneLock(ctx, sceneID, scene.LockModeFree, scene.LockModeDatasetSyncing); err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
 	}
 
 	defer i.ReleaseSceneLock(ctx, sceneID)
 
-	// Fetch
-	dss, ds, err = i.datasource.Fetch(ctx, url, sceneID)
+	// Carry over the refresh settings of the old schemas, which are replaced with the new ones
+	replaced := map[id.DatasetSchemaID]struct{}{}
+	for _, s := range dss {
+		old, err := i.datasetSchemaRepo.FindBySceneAndSource(ctx, sceneID, s.Source())
+		if err != nil {
+			return nil, nil, stats, err
+		}
+		for _, o := range old {
+			replaced[o.ID()] = struct{}{}
+			if o.Refresh() != nil {
+				s.SetRefresh(o.Refresh().Advance(now))
+			}
+		}
+	}
+
+	// enforce policy
+	wid, pol, err := i.scenePolicy(ctx, sceneID, op)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
+	}
+	dsc, err := i.datasetSchemaRepo.CountByScene(ctx, sceneID)
+	if err != nil {
+		return nil, nil, stats, err
+	}
+	if err := pol.EnforceDatasetSchemaCount(dsc - len(replaced) + len(dss)); err != nil {
+		return nil, nil, stats, err
+	}
+	for _, s := range dss {
+		if err := pol.EnforceDatasetCount(len(ds.FilterByDatasetSchema(s.ID()))); err != nil {
+			return nil, nil, stats, err
+		}
 	}
 
 	// Save
 	if err := i.datasetSchemaRepo.SaveAll(ctx, dss); err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
 	}
 	if err := i.datasetRepo.SaveAll(ctx, ds); err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
 	}
 
 	// Migrate
@@ -458,27 +692,182 @@ func (i *Dataset) Sync(ctx context.Context, sceneID id.SceneID, url string, oper
 		Plugin:            repo.PluginLoaderFrom(i.pluginRepo),
 	}.Migrate(ctx, sceneID, dss, ds)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
 	}
 
 	if err := i.propertyRepo.SaveAll(ctx, result.Properties.List()); err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
 	}
 	if err := i.layerRepo.SaveAll(ctx, result.Layers.List()); err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
 	}
 	if err := i.layerRepo.RemoveAll(ctx, result.RemovedLayers.List()); err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
 	}
 	if err := i.datasetRepo.RemoveAll(ctx, result.RemovedDatasets); err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
 	}
 	if err := i.datasetSchemaRepo.RemoveAll(ctx, result.RemovedDatasetSchemas); err != nil {
-		return nil, nil, err
+		return nil, nil, stats, err
+	}
+
+	for _, s := range dss {
+		if diff, ok := result.Diffs[s.ID()]; ok {
+			stats.added += len(diff.Added)
+			stats.removed += len(diff.Removed)
+			stats.changed += len(diff.Changed)
+		} else {
+			stats.added += len(ds.FilterByDatasetSchema(s.ID()))
+		}
 	}
 
+	targets := lo.Map(dss, func(s *dataset.Schema, _ int) auditlog.Target { return auditlog.TargetOf(s.ID()) })
+	if err := i.audit(ctx, op, auditlog.ActionDatasetSync, wid, &sceneID, targets); err != nil {
+		return nil, nil, stats, err
+	}
+	i.warnUsage(ctx, wid, pol)
 	tx.Commit()
-	return dss, ds, nil
+	return dss, ds, stats, nil
+}
+
+// fetchSource fetches schemas and datasets from a URL.
+// Google Sheets can not be fetched again, as they are fetched with the access token of the user which is not stored.
+func (i *Dataset) fetchSource(ctx context.Context, sceneID id.SceneID, source string) (dataset.SchemaList, dataset.List, error) {
+	if _, _, ok := dataset.GoogleSheetFromSource(source); ok {
+		return nil, nil, interfaces.ErrGoogleSheetNotRefreshable
+	}
+
+	if i.datasource == nil {
+		return nil, nil, interfaces.ErrNoDataSourceAvailable
+	}
+	if !i.datasource.IsURLValid(ctx, source) {
+		return nil, nil, interfaces.ErrDataSourceInvalidURL
+	}
+	return i.datasource.Fetch(ctx, source, sceneID)
+}
+
+func (i *Dataset) UpdateDatasetSchemaRefresh(ctx context.Context, inp interfaces.UpdateDatasetSchemaRefreshParam, operator *usecase.Operator) (_ *dataset.Schema, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	schema, err := i.datasetSchemaRepo.FindByID(ctx, inp.SchemaID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteScene(schema.Scene(), operator); err != nil {
+		return nil, err
+	}
+	if !schema.IsRemote() {
+		return nil, interfaces.ErrDatasetSchemaNotRemote
+	}
+
+	var r *dataset.Refresh
+	if inp.Interval > 0 {
+		if _, _, ok := dataset.GoogleSheetFromSource(schema.Source()); ok {
+			return nil, interfaces.ErrGoogleSheetNotRefreshable
+		}
+		if r, err = dataset.NewRefresh(inp.Interval, inp.Publish, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	before := schema.Refresh()
+	schema.SetRefresh(r)
+
+	if err := i.datasetSchemaRepo.Save(ctx, schema); err != nil {
+		return nil, err
+	}
+
+	if err := i.auditScene(ctx, operator, auditlog.ActionDatasetUpdateSchemaRefresh, schema.Scene(), []auditlog.Target{auditlog.TargetOf(schema.ID())},
+		auditlog.NewChange("refreshInterval", before.Interval().String(), r.Interval().String()),
+		auditlog.NewChange("refreshPublish", before.Publish(), r.Publish()),
+	); err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return schema, nil
+}
+
+func (i *Dataset) FindSyncLogs(ctx context.Context, sid id.DatasetSchemaID, limit int, operator *usecase.Operator) (dataset.SyncLogList, error) {
+	schema, err := i.datasetSchemaRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadScene(schema.Scene(), operator); err != nil {
+		return nil, err
+	}
+
+	return i.datasetSyncLogRepo.FindBySource(ctx, schema.Scene(), schema.Source(), limit)
+}
+
+func (i *Dataset) RefreshScheduled(ctx context.Context, now time.Time) error {
+	schemas, err := i.datasetSchemaRepo.FindByRefreshDue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range schemas {
+		if !s.Refresh().Due(now) {
+			continue
+		}
+
+		dss, _, err := i.sync(ctx, s.Scene(), s.Source(), nil, now)
+		if err != nil {
+			if isSceneLocked(err) {
+				// try again at the next tick
+				continue
+			}
+			log.Errorfc(ctx, "dataset refresh: failed to sync dataset schema (%s): %s", s.ID(), err)
+
+			// the old schema is kept, so postpone its refresh not to fetch a broken source at every tick
+			s.SetRefresh(s.Refresh().Advance(now))
+			if err := i.datasetSchemaRepo.Save(ctx, s); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !s.Refresh().Publish() {
+			continue
+		}
+		if err := i.republish(ctx, s.Scene(), dss); err != nil {
+			log.Errorfc(ctx, "dataset refresh: failed to republish scene (%s): %s", s.Scene(), err)
+		}
+	}
+
+	return nil
+}
+
+// republish publishes the project of the scene again if it is published and its layers link the schemas.
+func (i *Dataset) republish(ctx context.Context, sceneID id.SceneID, dss dataset.SchemaList) error {
+	linked := false
+	for _, s := range dss {
+		groups, err := i.layerRepo.FindGroupBySceneAndLinkedDatasetSchema(ctx, sceneID, s.ID())
+		if err != nil {
+			return err
+		}
+		if len(groups) > 0 {
+			linked = true
+			break
+		}
+	}
+	if !linked {
+		return nil
+	}
+
+	s, err := i.sceneRepo.FindByID(ctx, sceneID)
+	if err != nil {
+		return err
+	}
+	return i.project.republish(ctx, s.Project())
 }
 
 func (i *Dataset) AddDatasetSchema(ctx context.Context, inp interfaces.AddDatasetSchemaParam, operator *usecase.Operator) (ds *dataset.Schema, err error) {
@@ -514,6 +903,9 @@ func (i *Dataset) AddDatasetSchema(ctx context.Context, inp interfaces.AddDatase
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionDatasetAddSchema, inp.SceneId, []auditlog.Target{auditlog.TargetOf(ds.ID())}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return ds, nil
 }
@@ -615,6 +1007,9 @@ func (i *Dataset) RemoveDatasetSchema(ctx context.Context, inp interfaces.Remove
 		return inp.SchemaID, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionDatasetRemoveSchema, s.Scene(), []auditlog.Target{auditlog.TargetOf(inp.SchemaID)}); err != nil {
+		return inp.SchemaID, err
+	}
 	tx.Commit()
 	return inp.SchemaID, nil
 }
@@ -0,0 +1,360 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/datasource"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataset_Sync(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	s, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, s)
+
+	// the scene is not locked while the source is fetched
+	content := "id,name\na,Tokyo\nb,Osaka"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		assert.Equal(t, scene.LockModeFree, lo.Must(db.SceneLock.GetLock(ctx, s.ID())))
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+	u := server.URL + "/data.csv"
+
+	_, _, err := NewDataset(db, &gateway.Container{}).Sync(ctx, s.ID(), u, op)
+	assert.Same(t, interfaces.ErrNoDataSourceAvailable, err)
+
+	uc := NewDataset(db, &gateway.Container{DataSource: datasource.NewWithClient([]string{"127.0.0.1"}, server.Client())})
+	_, _, err = uc.Sync(ctx, s.ID(), "https://example.com/data.csv", op)
+	assert.Same(t, interfaces.ErrDataSourceInvalidURL, err)
+
+	dss, ds, err := uc.Sync(ctx, s.ID(), u, op)
+	assert.NoError(t, err)
+	assert.Len(t, dss, 1)
+	assert.Len(t, ds, 2)
+	oldSchema := dss[0]
+
+	// the schema and datasets are replaced with new ones, and removed rows are deleted
+	content = "id,name,pop\na,Tokyo,14\nc,Nagoya,2"
+	dss, ds, err = uc.Sync(ctx, s.ID(), u, op)
+	assert.NoError(t, err)
+	assert.Len(t, dss, 1)
+	assert.NotEqual(t, oldSchema.ID(), dss[0].ID())
+	assert.NotNil(t, dss[0].FieldBySource("pop"))
+	assert.Equal(t, []string{"a", "c"}, []string{ds[0].Source(), ds[1].Source()})
+
+	schemas, _, err := db.DatasetSchema.FindByScene(ctx, s.ID(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, dataset.SchemaList{dss[0]}, schemas)
+	datasets, _, err := db.Dataset.FindBySchema(ctx, dss[0].ID(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, datasets, 2)
+	datasets, _, err = db.Dataset.FindBySchema(ctx, oldSchema.ID(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, datasets)
+
+	logs, err := uc.FindSyncLogs(ctx, dss[0].ID(), 0, op)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Equal(t, dss[0].ID().Ref(), logs[0].Schema())
+	assert.False(t, logs[0].Auto())
+	assert.Equal(t, []int{1, 1, 1}, []int{logs[0].Added(), logs[0].Removed(), logs[0].Changed()})
+	assert.Equal(t, []int{2, 0, 0}, []int{logs[1].Added(), logs[1].Removed(), logs[1].Changed()})
+
+	_, _, err = uc.Sync(ctx, s.ID(), u, &usecase.Operator{})
+	assert.Error(t, err)
+}
+
+func TestDataset_SyncPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	content := "id,name\na,Tokyo\nb,Osaka"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+
+	db := memory.New()
+	db.Policy = memory.NewPolicyWith(policy.New(policy.Option{
+		ID:                 policy.ID("policy"),
+		DatasetSchemaCount: lo.ToPtr(2),
+		DatasetCount:       lo.ToPtr(2),
+	}))
+	ws := workspace.New().NewID().Policy(policy.ID("policy").Ref()).MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	s, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, s)
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{s.ID()},
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+	u := server.URL + "/data.csv"
+	uc := NewDataset(db, &gateway.Container{DataSource: datasource.NewWithClient([]string{"127.0.0.1"}, server.Client())})
+
+	_, _, err := uc.Sync(ctx, s.ID(), u, op)
+	assert.NoError(t, err)
+
+	// schemas of the same source are replaced, so they are not counted twice
+	_ = db.DatasetSchema.Save(ctx, dataset.NewSchema().NewID().Scene(s.ID()).Source("file:///data.csv").MustBuild())
+	_, _, err = uc.Sync(ctx, s.ID(), u, op)
+	assert.NoError(t, err)
+	_, _, err = uc.Sync(ctx, s.ID(), server.URL+"/data2.csv", op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	// the source grows beyond the limit
+	content = "id,name\na,Tokyo\nb,Osaka\nc,Nagoya"
+	_, _, err = uc.Sync(ctx, s.ID(), u, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	// scheduled refreshes are also limited by the policy of the workspace
+	current, _ := db.DatasetSchema.FindBySceneAndSource(ctx, s.ID(), u)
+	schema, err := uc.UpdateDatasetSchemaRefresh(ctx, interfaces.UpdateDatasetSchemaRefreshParam{SchemaID: current[0].ID(), Interval: time.Hour}, op)
+	assert.NoError(t, err)
+	assert.NoError(t, uc.RefreshScheduled(ctx, *schema.Refresh().NextAt()))
+	refreshed, _ := db.DatasetSchema.FindBySceneAndSource(ctx, s.ID(), u)
+	assert.Len(t, refreshed, 1)
+	assert.Equal(t, schema.ID(), refreshed[0].ID())
+	datasets, _, _ := db.Dataset.FindBySchema(ctx, schema.ID(), nil)
+	assert.Len(t, datasets, 2)
+
+	logs, err := uc.FindSyncLogs(ctx, schema.ID(), 0, op)
+	assert.NoError(t, err)
+	assert.True(t, logs[0].Auto())
+	assert.Equal(t, policy.ErrPolicyViolation.Error(), logs[0].Error())
+}
+
+func TestDataset_RefreshScheduled(t *testing.T) {
+	ctx := context.Background()
+
+	content := "id,name\na,Tokyo\nb,Osaka"
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+
+	db := memory.New()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	s, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, s)
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{s.ID()},
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+	u := server.URL + "/data.csv"
+	uc := NewDataset(db, &gateway.Container{DataSource: datasource.NewWithClient([]string{"127.0.0.1"}, server.Client())})
+
+	// schemas imported from files cannot be refreshed
+	fs := dataset.NewSchema().NewID().Scene(s.ID()).Source("file:///data.csv").MustBuild()
+	_ = db.DatasetSchema.Save(ctx, fs)
+	_, err := uc.UpdateDatasetSchemaRefresh(ctx, interfaces.UpdateDatasetSchemaRefreshParam{SchemaID: fs.ID(), Interval: time.Hour}, op)
+	assert.Same(t, interfaces.ErrDatasetSchemaNotRemote, err)
+
+	// schemas synced from Google Sheets cannot be refreshed without the access token
+	gs := dataset.NewSchema().NewID().Scene(s.ID()).Source(dataset.GoogleSheetSource("file", "Sheet1")).MustBuild()
+	_ = db.DatasetSchema.Save(ctx, gs)
+	_, err = uc.UpdateDatasetSchemaRefresh(ctx, interfaces.UpdateDatasetSchemaRefreshParam{SchemaID: gs.ID(), Interval: time.Hour}, op)
+	assert.Same(t, interfaces.ErrGoogleSheetNotRefreshable, err)
+
+	dss, _, err := uc.Sync(ctx, s.ID(), u, op)
+	assert.NoError(t, err)
+
+	_, err = uc.UpdateDatasetSchemaRefresh(ctx, interfaces.UpdateDatasetSchemaRefreshParam{SchemaID: dss[0].ID(), Interval: time.Minute}, op)
+	assert.Same(t, dataset.ErrInvalidRefreshInterval, err)
+
+	schema, err := uc.UpdateDatasetSchemaRefresh(ctx, interfaces.UpdateDatasetSchemaRefreshParam{SchemaID: dss[0].ID(), Interval: time.Hour}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, schema.Refresh().Interval())
+	next := *schema.Refresh().NextAt()
+
+	// not due yet
+	assert.NoError(t, uc.RefreshScheduled(ctx, next.Add(-time.Second)))
+	schemas, _, _ := db.DatasetSchema.FindByScene(ctx, s.ID(), nil)
+	assert.Contains(t, schemas, schema)
+
+	// the schema is replaced and the refresh is carried over to the new schema
+	content = "id,name\na,Tokyo!\nb,Osaka"
+	assert.NoError(t, uc.RefreshScheduled(ctx, next))
+	refreshed, err := db.DatasetSchema.FindBySceneAndSource(ctx, s.ID(), u)
+	assert.NoError(t, err)
+	assert.Len(t, refreshed, 1)
+	assert.NotEqual(t, schema.ID(), refreshed[0].ID())
+	assert.Equal(t, next.Add(time.Hour), *refreshed[0].Refresh().NextAt())
+	assert.Equal(t, next, *refreshed[0].Refresh().LastSyncedAt())
+
+	logs, err := uc.FindSyncLogs(ctx, refreshed[0].ID(), 1, op)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.True(t, logs[0].Auto())
+	assert.Equal(t, []int{0, 0, 1}, []int{logs[0].Added(), logs[0].Removed(), logs[0].Changed()})
+
+	// a failed refresh keeps the old schema and postpones the next refresh
+	status = http.StatusInternalServerError
+	later := next.Add(time.Hour)
+	assert.NoError(t, uc.RefreshScheduled(ctx, later))
+	failed, _ := db.DatasetSchema.FindByID(ctx, refreshed[0].ID())
+	assert.Equal(t, later.Add(time.Hour), *failed.Refresh().NextAt())
+
+	logs, err = uc.FindSyncLogs(ctx, refreshed[0].ID(), 0, op)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 3)
+	assert.False(t, logs[0].Succeeded())
+	assert.Nil(t, logs[0].Schema())
+	assert.Equal(t, "failed to fetch data: StatusCode=500", logs[0].Error())
+
+	// disable
+	schema, err = uc.UpdateDatasetSchemaRefresh(ctx, interfaces.UpdateDatasetSchemaRefreshParam{SchemaID: refreshed[0].ID()}, op)
+	assert.NoError(t, err)
+	assert.Nil(t, schema.Refresh())
+}
+
+func TestDataset_PreviewDataset(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	s, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, s)
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+	uc := NewDataset(db, &gateway.Container{})
+
+	res, err := uc.PreviewDataset(ctx, interfaces.ImportDatasetParam{
+		File: &file.File{
+			Content: io.NopCloser(strings.NewReader("name,n,e\na,35,139\nb,north,135\nc,34,135")),
+			Path:    "hoge.csv",
+		},
+		SceneId: s.ID(),
+		Options: dataset.CSVOptions{LatColumn: "n", LngColumn: "e"},
+	}, 1, op)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, res.Total)
+	assert.Len(t, res.Datasets, 1)
+	assert.Equal(t, dataset.ValueTypeLatLng, res.Schema.FieldBySource("location").Type())
+	assert.Len(t, res.Errors, 1)
+	assert.Equal(t, 2, res.Errors[0].Row)
+
+	// nothing is saved
+	schemas, _, err := db.DatasetSchema.FindByScene(ctx, s.ID(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, schemas)
+
+	_, err = uc.PreviewDataset(ctx, interfaces.ImportDatasetParam{SceneId: s.ID()}, 1, &usecase.Operator{})
+	assert.Error(t, err)
+}
+
+func TestDataset_ImportGeoPackage(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	s, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, s)
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+	uc := NewDataset(db, &gateway.Container{})
+
+	content, err := os.ReadFile("../../../pkg/gpkg/testdata/test.gpkg")
+	assert.NoError(t, err)
+
+	_, err = uc.ImportGeoPackage(ctx, interfaces.ImportGeoPackageParam{SceneId: s.ID()}, op)
+	assert.Same(t, interfaces.ErrFileNotIncluded, err)
+
+	// roads has a multi line string of two line strings, so nothing is imported
+	_, err = uc.ImportGeoPackage(ctx, interfaces.ImportGeoPackageParam{
+		File:    &file.File{Content: io.NopCloser(bytes.NewReader(content)), Path: "test.gpkg"},
+		SceneId: s.ID(),
+	}, op)
+	assert.ErrorIs(t, err, dataset.ErrUnsupportedGeometry)
+	schemas, _, err := db.DatasetSchema.FindByScene(ctx, s.ID(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, schemas)
+
+	_, err = uc.ImportGeoPackage(ctx, interfaces.ImportGeoPackageParam{
+		File:    &file.File{Content: io.NopCloser(bytes.NewReader(content)), Path: "test.gpkg"},
+		SceneId: s.ID(),
+	}, &usecase.Operator{})
+	assert.Error(t, err)
+}
+
+func TestDataset_Export(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	sid := id.NewSceneID()
+	fa := dataset.NewSchemaField().NewID().Name("a").Type(dataset.ValueTypeString).MustBuild()
+	fb := dataset.NewSchemaField().NewID().Name("b").Type(dataset.ValueTypeNumber).MustBuild()
+	ds := dataset.NewSchema().NewID().Scene(sid).Name("hoge").Fields([]*dataset.SchemaField{fa, fb}).MustBuild()
+	d1 := dataset.New().NewID().Scene(sid).Schema(ds.ID()).Fields([]*dataset.Field{
+		dataset.NewField(fa.ID(), dataset.ValueTypeString.ValueFrom("x"), "a"),
+		dataset.NewField(fb.ID(), dataset.ValueTypeNumber.ValueFrom(1), "b"),
+	}).MustBuild()
+	d2 := dataset.New().NewID().Scene(sid).Schema(ds.ID()).Fields([]*dataset.Field{
+		dataset.NewField(fa.ID(), dataset.ValueTypeString.ValueFrom("y"), "a"),
+	}).MustBuild()
+	_ = db.DatasetSchema.Save(ctx, ds)
+	_ = db.Dataset.SaveAll(ctx, dataset.List{d1, d2})
+	uc := NewDataset(db, &gateway.Container{})
+
+	var name, contentType string
+	before := func(n, c string) {
+		name, contentType = n, c
+	}
+
+	buf := &bytes.Buffer{}
+	err := uc.Export(ctx, interfaces.ExportDatasetParam{
+		SchemaID: ds.ID(),
+		Format:   "csv",
+		Fields:   []string{"b", fa.ID().String()},
+		Datasets: []id.DatasetID{d1.ID()},
+	}, buf, before)
+	assert.NoError(t, err)
+	assert.Equal(t, "hoge.csv", name)
+	assert.Equal(t, "text/csv", contentType)
+	assert.Equal(t, ",b,a\n"+d1.ID().String()+",1,x\n", buf.String())
+
+	buf.Reset()
+	err = uc.Export(ctx, interfaces.ExportDatasetParam{SchemaID: ds.ID(), Format: "geojson"}, buf, before)
+	assert.NoError(t, err)
+	assert.Equal(t, "hoge.geojson", name)
+	assert.Contains(t, buf.String(), d2.ID().String())
+
+	err = uc.Export(ctx, interfaces.ExportDatasetParam{SchemaID: ds.ID(), Format: "csv", Fields: []string{"c"}}, buf, before)
+	assert.Same(t, interfaces.ErrDatasetFieldNotFound, err)
+
+	err = uc.Export(ctx, interfaces.ExportDatasetParam{SchemaID: ds.ID(), Format: "txt"}, buf, before)
+	assert.Same(t, rerror.ErrNotFound, err)
+}
@@ -0,0 +1,363 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/history"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+)
+
+// historyConflictPageSize is the number of audit logs of a target checked at once for conflicts.
+const historyConflictPageSize = 100
+
+// historyLimit is the number of entries kept for each user in each scene. Older entries can not be undone.
+var historyLimit = 100
+
+type History struct {
+	common
+	commonSceneLock
+	commonAudit
+	commonPolicy
+	historyRepo      repo.History
+	sceneRepo        repo.Scene
+	propertyRepo     repo.Property
+	layerRepo        repo.Layer
+	nlsLayerRepo     repo.NLSLayer
+	storytellingRepo repo.Storytelling
+	styleRepo        repo.Style
+	transaction      usecasex.Transaction
+}
+
+func NewHistory(r *repo.Container, g *gateway.Container) interfaces.History {
+	return &History{
+		commonSceneLock:  commonSceneLock{sceneLockRepo: r.SceneLock},
+		historyRepo:      r.History,
+		sceneRepo:        r.Scene,
+		propertyRepo:     r.Property,
+		layerRepo:        r.Layer,
+		nlsLayerRepo:     r.NLSLayer,
+		storytellingRepo: r.Storytelling,
+		styleRepo:        r.Style,
+		transaction:      r.Transaction,
+		commonAudit:      newCommonAudit(r, g),
+		commonPolicy: commonPolicy{
+			sceneRepo:        r.Scene,
+			workspaceRepo:    r.Workspace,
+			policyRepo:       r.Policy,
+			storytellingRepo: r.Storytelling,
+		},
+	}
+}
+
+func (i *History) Fetch(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (*history.Entry, *history.Entry, error) {
+	if err := i.CanReadScene(sid, operator); err != nil {
+		return nil, nil, err
+	}
+	uid := operator.UserID()
+	if uid == nil {
+		return nil, nil, interfaces.ErrOperationDenied
+	}
+
+	undo, err := i.findLatest(ctx, sid, *uid, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	redo, err := i.findLatest(ctx, sid, *uid, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return undo, redo, nil
+}
+
+func (i *History) Undo(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (*history.Entry, error) {
+	return i.apply(ctx, sid, operator, true)
+}
+
+func (i *History) Redo(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (*history.Entry, error) {
+	return i.apply(ctx, sid, operator, false)
+}
+
+func (i *History) apply(ctx context.Context, sid id.SceneID, operator *usecase.Operator, undo bool) (_ *history.Entry, err error) {
+	if err := i.CanWriteScene(sid, operator); err != nil {
+		return nil, err
+	}
+	uid := operator.UserID()
+	if uid == nil {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	if err := i.CheckSceneLock(ctx, sid); err != nil {
+		return nil, err
+	}
+
+	// the latest edit not undone yet is undone, and the latest undone edit is redone
+	e, err := i.findLatest(ctx, sid, *uid, !undo)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		if undo {
+			return nil, interfaces.ErrNothingToUndo
+		}
+		return nil, interfaces.ErrNothingToRedo
+	}
+
+	since := e.CreatedAt()
+	if !undo {
+		since = *e.UndoneAt()
+	}
+	if err := i.checkConflict(ctx, e, *uid, since); err != nil {
+		return nil, err
+	}
+
+	pol, _, err := i.scenePolicy(ctx, sid, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := e.Changes()
+	if undo {
+		for j := len(changes) - 1; j >= 0; j-- {
+			if err := i.restore(ctx, pol, changes[j].Before(), changes[j].After()); err != nil {
+				return nil, err
+			}
+		}
+		e.Undo(time.Now())
+	} else {
+		for _, c := range changes {
+			if err := i.restore(ctx, pol, c.After(), c.Before()); err != nil {
+				return nil, err
+			}
+		}
+		e.Redo()
+	}
+
+	if err := i.historyRepo.Save(ctx, e); err != nil {
+		return nil, err
+	}
+
+	action := auditlog.ActionSceneRedo
+	if undo {
+		action = auditlog.ActionSceneUndo
+	}
+	if err := i.auditScene(ctx, operator, action, sid, historyTargets(e), auditlog.NewChange("action", nil, string(e.Action()))); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return e, nil
+}
+
+func (i *History) findLatest(ctx context.Context, sid id.SceneID, uid accountdomain.UserID, undone bool) (*history.Entry, error) {
+	e, err := i.historyRepo.FindLatest(ctx, sid, uid, undone)
+	if errors.Is(err, rerror.ErrNotFound) {
+		return nil, nil
+	}
+	return e, err
+}
+
+// checkConflict looks up the audit log for edits of the targets made by other users since the given time.
+// Without the audit log, the edits of other users would be overwritten unnoticed, so nothing can be undone.
+func (i *History) checkConflict(ctx context.Context, e *history.Entry, uid accountdomain.UserID, since time.Time) error {
+	if i.auditLogRepo == nil {
+		return interfaces.ErrHistoryUncheckable
+	}
+
+	s, err := i.sceneRepo.FindByID(ctx, e.Scene())
+	if err != nil {
+		return err
+	}
+
+	sid := e.Scene()
+	for _, t := range e.Targets() {
+		t := t
+		p := usecasex.CursorPagination{First: lo.ToPtr(int64(historyConflictPageSize))}.Wrap()
+		for {
+			logs, info, err := i.auditLogRepo.FindByWorkspace(ctx, s.Workspace(), repo.AuditLogFilter{
+				Scene:  &sid,
+				Target: &t,
+				Since:  &since,
+			}, p)
+			if err != nil {
+				return err
+			}
+			for _, l := range logs {
+				if a := l.Actor(); a == nil || *a != uid {
+					return interfaces.ErrHistoryConflict
+				}
+			}
+			if info == nil || !info.HasNextPage || info.EndCursor == nil {
+				break
+			}
+			p = usecasex.CursorPagination{First: lo.ToPtr(int64(historyConflictPageSize)), After: info.EndCursor}.Wrap()
+		}
+	}
+	return nil
+}
+
+// restore saves the snapshot, or removes the object of the other snapshot if it is nil.
+// Only the pages of a story are restored so that its publishing state is kept as it is.
+// Snapshots are saved with the versions of the stored objects, as they replace them.
+// Limits of the policy are checked only for what the snapshot adds, so that edits can still be undone
+// in a scene which already exceeds a lowered limit.
+func (i *History) restore(ctx context.Context, pol *policy.Policy, snapshot, other any) error {
+	switch s := snapshot.(type) {
+	case *property.Property:
+		p := s.Clone()
+		if cur, err := i.propertyRepo.FindByID(ctx, p.ID()); err == nil {
+			p.SetVersion(cur.Version())
+		} else if !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+		return i.propertyRepo.Save(ctx, p)
+	case layer.Layer:
+		if _, err := i.layerRepo.FindByID(ctx, s.ID()); errors.Is(err, rerror.ErrNotFound) {
+			n, err := i.layerRepo.CountByScene(ctx, s.Scene())
+			if err != nil {
+				return err
+			}
+			if err := pol.EnforceLayerCount(n + 1); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+		return i.layerRepo.Save(ctx, layer.Clone(s))
+	case nlslayer.NLSLayer:
+		l := nlslayer.Clone(s)
+		features := 0
+		if cur, err := i.nlsLayerRepo.FindByID(ctx, l.ID()); err == nil {
+			l.SetVersion(cur.Version())
+			features = countSketchFeatures(cur)
+		} else if errors.Is(err, rerror.ErrNotFound) {
+			n, err := i.nlsLayerRepo.CountByScene(ctx, l.Scene())
+			if err != nil {
+				return err
+			}
+			if err := pol.EnforceNLSLayerCount(n + 1); err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+		if n := countSketchFeatures(l); n > features {
+			if err := pol.EnforceSketchFeatureCount(n); err != nil {
+				return err
+			}
+		}
+		return i.nlsLayerRepo.Save(ctx, l)
+	case *storytelling.Story:
+		story, err := i.storytellingRepo.FindByID(ctx, s.Id())
+		if err != nil {
+			return err
+		}
+		if added := len(s.Pages().Pages()) - len(story.Pages().Pages()); added > 0 {
+			_, pages, err := i.countStories(ctx, story.Scene())
+			if err != nil {
+				return err
+			}
+			if err := pol.EnforceStoryPageCount(pages + added); err != nil {
+				return err
+			}
+		}
+		story.SetPages(s.Pages().Clone())
+		return i.storytellingRepo.Save(ctx, story)
+	case *scene.Style:
+		st := s.Clone()
+		if cur, err := i.styleRepo.FindByID(ctx, st.ID()); err == nil {
+			st.SetVersion(cur.Version())
+		} else if !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+		return i.styleRepo.Save(ctx, st)
+	}
+
+	switch o := other.(type) {
+	case *property.Property:
+		return i.propertyRepo.Remove(ctx, o.ID())
+	case layer.Layer:
+		return i.layerRepo.Remove(ctx, o.ID())
+	case nlslayer.NLSLayer:
+		return i.nlsLayerRepo.Remove(ctx, o.ID())
+	case *scene.Style:
+		return i.styleRepo.Remove(ctx, o.ID())
+	}
+	return nil
+}
+
+func historyTargets(e *history.Entry) []auditlog.Target {
+	return lo.Map(e.Changes(), func(c history.Change, _ int) auditlog.Target {
+		return auditlog.Target{Type: string(c.Kind()), ID: c.ID()}
+	})
+}
+
+// commonHistory records edits made by interactors so that users can undo them.
+// The zero value records nothing, so interactors built without repos keep working.
+type commonHistory struct {
+	historyRepo repo.History
+}
+
+func newCommonHistory(r *repo.Container) commonHistory {
+	return commonHistory{
+		historyRepo: r.History,
+	}
+}
+
+// recordHistory records an edit of the operator and discards the edits the operator has undone on the scene,
+// and the oldest ones beyond historyLimit.
+// It runs in the transaction of the edit and its error has to be returned, so that the edit is rolled back
+// rather than committed without a history entry that undo relies on.
+func (h commonHistory) recordHistory(ctx context.Context, op *usecase.Operator, sid id.SceneID, action auditlog.Action, changes ...*history.Change) error {
+	if h.historyRepo == nil || op == nil || op.UserID() == nil {
+		return nil
+	}
+	e, err := history.New().
+		NewID().
+		Scene(sid).
+		User(*op.UserID()).
+		Action(action).
+		Changes(changes...).
+		Build()
+	if errors.Is(err, history.ErrEmptyChanges) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := h.historyRepo.RemoveUndone(ctx, sid, *op.UserID()); err != nil {
+		return err
+	}
+	if err := h.historyRepo.Save(ctx, e); err != nil {
+		return err
+	}
+	return h.historyRepo.RemoveOld(ctx, sid, *op.UserID(), historyLimit)
+}
@@ -0,0 +1,265 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory_UndoRedo(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+
+	ws := workspace.New().NewID().MustBuild()
+	_ = r.Workspace.Save(ctx, ws)
+	s := scene.New().NewID().Workspace(ws.ID()).RootLayer(id.NewLayerID()).MustBuild()
+	psg := property.NewSchemaGroup().ID("foobar").IsList(true).MustBuild()
+	ps := property.NewSchema().ID(property.MustSchemaID("xxx~1.1.1/aa")).
+		Groups(property.NewSchemaGroupList([]*property.SchemaGroup{psg})).
+		MustBuild()
+	p := property.New().NewID().Scene(s.ID()).Schema(ps.ID()).MustBuild()
+	_ = r.Scene.Save(ctx, s)
+	_ = r.PropertySchema.Save(ctx, ps)
+	_ = r.Property.Save(ctx, p)
+
+	puc := NewProperty(r, &gateway.Container{})
+	huc := NewHistory(r, &gateway.Container{})
+	op := historyTestOperator(s.ID())
+
+	items := func() int {
+		p, err := r.Property.FindByID(ctx, p.ID())
+		assert.NoError(t, err)
+		return len(property.ToGroupList(p.ItemBySchema(psg.ID())).Groups())
+	}
+
+	// nothing to undo and redo yet
+	undo, redo, err := huc.Fetch(ctx, s.ID(), op)
+	assert.NoError(t, err)
+	assert.Nil(t, undo)
+	assert.Nil(t, redo)
+	_, err = huc.Undo(ctx, s.ID(), op)
+	assert.Equal(t, interfaces.ErrNothingToUndo, err)
+
+	_, err = puc.UpdateItems(ctx, interfaces.UpdatePropertyItemsParam{
+		PropertyID: p.ID(),
+		Pointer:    property.PointItemBySchema(psg.ID()),
+		Operations: []interfaces.UpdatePropertyItemsOperationParam{
+			{Operation: interfaces.ListOperationAdd},
+			{Operation: interfaces.ListOperationAdd},
+		},
+	}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, items())
+
+	undo, _, err = huc.Fetch(ctx, s.ID(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, auditlog.ActionPropertyUpdateItems, undo.Action())
+	assert.Equal(t, []string{p.ID().String()}, undo.Targets())
+
+	e, err := huc.Undo(ctx, s.ID(), op)
+	assert.NoError(t, err)
+	assert.True(t, e.IsUndone())
+	assert.Equal(t, 0, items())
+
+	e, err = huc.Redo(ctx, s.ID(), op)
+	assert.NoError(t, err)
+	assert.False(t, e.IsUndone())
+	assert.Equal(t, 2, items())
+
+	_, err = huc.Redo(ctx, s.ID(), op)
+	assert.Equal(t, interfaces.ErrNothingToRedo, err)
+
+	// a new edit discards the undone edits
+	_, err = huc.Undo(ctx, s.ID(), op)
+	assert.NoError(t, err)
+	_, _, _, err = puc.AddItem(ctx, interfaces.AddPropertyItemParam{
+		PropertyID: p.ID(),
+		Pointer:    property.PointItemBySchema(psg.ID()),
+		Index:      lo.ToPtr(-1),
+	}, op)
+	assert.NoError(t, err)
+	_, redo, err = huc.Fetch(ctx, s.ID(), op)
+	assert.NoError(t, err)
+	assert.Nil(t, redo)
+	_, err = huc.Redo(ctx, s.ID(), op)
+	assert.Equal(t, interfaces.ErrNothingToRedo, err)
+}
+
+func TestHistory_Undo_Conflict(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+
+	ws := workspace.New().NewID().MustBuild()
+	_ = r.Workspace.Save(ctx, ws)
+	s := scene.New().NewID().Workspace(ws.ID()).RootLayer(id.NewLayerID()).MustBuild()
+	psg := property.NewSchemaGroup().ID("foobar").IsList(true).MustBuild()
+	ps := property.NewSchema().ID(property.MustSchemaID("xxx~1.1.1/aa")).
+		Groups(property.NewSchemaGroupList([]*property.SchemaGroup{psg})).
+		MustBuild()
+	p := property.New().NewID().Scene(s.ID()).Schema(ps.ID()).MustBuild()
+	_ = r.Scene.Save(ctx, s)
+	_ = r.PropertySchema.Save(ctx, ps)
+	_ = r.Property.Save(ctx, p)
+
+	puc := NewProperty(r, &gateway.Container{})
+	huc := NewHistory(r, &gateway.Container{})
+	op1 := historyTestOperator(s.ID())
+	op2 := historyTestOperator(s.ID())
+
+	add := interfaces.AddPropertyItemParam{
+		PropertyID: p.ID(),
+		Pointer:    property.PointItemBySchema(psg.ID()),
+		Index:      lo.ToPtr(-1),
+	}
+	_, _, _, err := puc.AddItem(ctx, add, op1)
+	assert.NoError(t, err)
+	_, _, _, err = puc.AddItem(ctx, add, op2)
+	assert.NoError(t, err)
+
+	// the property has been changed by another user since the edit
+	_, err = huc.Undo(ctx, s.ID(), op1)
+	assert.Equal(t, interfaces.ErrHistoryConflict, err)
+
+	p2, _ := r.Property.FindByID(ctx, p.ID())
+	assert.Equal(t, 2, len(property.ToGroupList(p2.ItemBySchema(psg.ID())).Groups()))
+
+	// histories are per user
+	_, _, err = huc.Fetch(ctx, s.ID(), &usecase.Operator{ReadableScenes: id.SceneIDList{s.ID()}})
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+}
+
+func TestHistory_Limit(t *testing.T) {
+	defer func(l int) { historyLimit = l }(historyLimit)
+	historyLimit = 2
+
+	ctx := context.Background()
+	r := memory.New()
+
+	ws := workspace.New().NewID().MustBuild()
+	_ = r.Workspace.Save(ctx, ws)
+	s := scene.New().NewID().Workspace(ws.ID()).RootLayer(id.NewLayerID()).MustBuild()
+	psg := property.NewSchemaGroup().ID("foobar").IsList(true).MustBuild()
+	ps := property.NewSchema().ID(property.MustSchemaID("xxx~1.1.1/aa")).
+		Groups(property.NewSchemaGroupList([]*property.SchemaGroup{psg})).
+		MustBuild()
+	p := property.New().NewID().Scene(s.ID()).Schema(ps.ID()).MustBuild()
+	_ = r.Scene.Save(ctx, s)
+	_ = r.PropertySchema.Save(ctx, ps)
+	_ = r.Property.Save(ctx, p)
+
+	puc := NewProperty(r, &gateway.Container{})
+	huc := NewHistory(r, &gateway.Container{})
+	op := historyTestOperator(s.ID())
+
+	for range 3 {
+		_, _, _, err := puc.AddItem(ctx, interfaces.AddPropertyItemParam{
+			PropertyID: p.ID(),
+			Pointer:    property.PointItemBySchema(psg.ID()),
+			Index:      lo.ToPtr(-1),
+		}, op)
+		assert.NoError(t, err)
+	}
+
+	// only the latest two edits can be undone
+	_, err := huc.Undo(ctx, s.ID(), op)
+	assert.NoError(t, err)
+	_, err = huc.Undo(ctx, s.ID(), op)
+	assert.NoError(t, err)
+	_, err = huc.Undo(ctx, s.ID(), op)
+	assert.Equal(t, interfaces.ErrNothingToUndo, err)
+
+	p2, err := r.Property.FindByID(ctx, p.ID())
+	assert.NoError(t, err)
+	assert.Len(t, property.ToGroupList(p2.ItemBySchema(psg.ID())).Groups(), 1)
+}
+
+func TestHistory_Undo_Policy(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+
+	po := policy.New(policy.Option{
+		ID:            policy.ID("policy"),
+		NLSLayerCount: lo.ToPtr(1),
+	})
+	r.Policy = memory.NewPolicyWith(po)
+	ws := workspace.New().NewID().Policy(po.ID().Ref()).MustBuild()
+	_ = r.Workspace.Save(ctx, ws)
+	s := scene.New().NewID().Workspace(ws.ID()).RootLayer(id.NewLayerID()).MustBuild()
+	_ = r.Scene.Save(ctx, s)
+
+	luc := NewNLSLayer(r, &gateway.Container{})
+	huc := NewHistory(r, &gateway.Container{})
+	op1 := historyTestOperator(s.ID())
+	op2 := historyTestOperator(s.ID())
+
+	add := interfaces.AddNLSLayerSimpleInput{
+		SceneID:   s.ID(),
+		Title:     "layer",
+		LayerType: nlslayer.Simple,
+	}
+	l, err := luc.AddLayerSimple(ctx, add, op1)
+	assert.NoError(t, err)
+	_, _, err = luc.Remove(ctx, l.ID(), op1)
+	assert.NoError(t, err)
+	_, err = luc.AddLayerSimple(ctx, add, op2)
+	assert.NoError(t, err)
+
+	// restoring the removed layer exceeds the limit
+	_, err = huc.Undo(ctx, s.ID(), op1)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	n, err := r.NLSLayer.CountByScene(ctx, s.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestHistory_Undo_WithoutAuditLog(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	r.AuditLog = nil
+
+	ws := workspace.New().NewID().MustBuild()
+	_ = r.Workspace.Save(ctx, ws)
+	s := scene.New().NewID().Workspace(ws.ID()).RootLayer(id.NewLayerID()).MustBuild()
+	_ = r.Scene.Save(ctx, s)
+
+	luc := NewNLSLayer(r, &gateway.Container{})
+	huc := NewHistory(r, &gateway.Container{})
+	op := historyTestOperator(s.ID())
+
+	_, err := luc.AddLayerSimple(ctx, interfaces.AddNLSLayerSimpleInput{
+		SceneID:   s.ID(),
+		Title:     "layer",
+		LayerType: nlslayer.Simple,
+	}, op)
+	assert.NoError(t, err)
+
+	// edits of other users can not be checked
+	_, err = huc.Undo(ctx, s.ID(), op)
+	assert.Equal(t, interfaces.ErrHistoryUncheckable, err)
+	n, err := r.NLSLayer.CountByScene(ctx, s.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func historyTestOperator(sid id.SceneID) *usecase.Operator {
+	return &usecase.Operator{
+		AcOperator:     &accountusecase.Operator{User: lo.ToPtr(accountdomain.NewUserID())},
+		ReadableScenes: id.SceneIDList{sid},
+		WritableScenes: id.SceneIDList{sid},
+	}
+}
@@ -9,10 +9,14 @@ import (
 	"strings"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
 	"github.com/reearth/reearth/server/pkg/builtin"
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/history"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/layer/decoding"
@@ -20,12 +24,14 @@ import (
 	"github.com/reearth/reearth/server/pkg/layer/layerops"
 	"github.com/reearth/reearth/server/pkg/layer/merging"
 	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/policy"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/shp"
 	"github.com/reearth/reearth/server/pkg/tag"
 	"github.com/reearth/reearthx/account/accountusecase/accountrepo"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 )
 
 // TODO: レイヤー作成のドメインロジックがここに多く漏れ出しているのでドメイン層に移す
@@ -33,6 +39,9 @@ import (
 type Layer struct {
 	common
 	commonSceneLock
+	commonAudit
+	commonHistory
+	usageWarner
 	layerRepo          repo.Layer
 	tagRepo            repo.Tag
 	pluginRepo         repo.Plugin
@@ -47,7 +56,7 @@ type Layer struct {
 	transaction        usecasex.Transaction
 }
 
-func NewLayer(r *repo.Container) interfaces.Layer {
+func NewLayer(r *repo.Container, g *gateway.Container) interfaces.Layer {
 	return &Layer{
 		commonSceneLock:    commonSceneLock{sceneLockRepo: r.SceneLock},
 		layerRepo:          r.Layer,
@@ -62,6 +71,9 @@ func NewLayer(r *repo.Container) interfaces.Layer {
 		transaction:        r.Transaction,
 		policyRepo:         r.Policy,
 		workspaceRepo:      r.Workspace,
+		commonAudit:        newCommonAudit(r, g),
+		commonHistory:      newCommonHistory(r),
+		usageWarner:        newUsageWarner(r, g),
 	}
 }
 
@@ -130,7 +142,7 @@ func (i *Layer) FetchByTag(ctx context.Context, tag id.TagID, operator *usecase.
 	return i.layerRepo.FindByTag(ctx, tag)
 }
 
-func (l *Layer) Export(ctx context.Context, lid id.LayerID, ext string) (io.Reader, string, error) {
+func (l *Layer) Export(ctx context.Context, lid id.LayerID, ext string, c *crs.CRS) (io.Reader, string, error) {
 	_, err := l.layerRepo.FindByID(ctx, lid)
 	if err != nil {
 		return nil, "", err
@@ -150,6 +162,10 @@ func (l *Layer) Export(ctx context.Context, lid id.LayerID, ext string) (io.Read
 			DatasetGraphLoader: repo.DatasetGraphLoaderFrom(l.datasetRepo),
 		},
 		Encoder: e,
+		CRS:     c,
+	}
+	if err := ex.ValidateCRS(); err != nil {
+		return nil, "", err
 	}
 
 	go func() {
@@ -193,16 +209,16 @@ func (i *Layer) AddItem(ctx context.Context, inp interfaces.AddLayerItemInput, o
 	}
 
 	// enforce policy
+	var pol *policy.Policy
 	if policyID := operator.Policy(ws.Policy()); policyID != nil {
-		p, err := i.policyRepo.FindByID(ctx, *policyID)
-		if err != nil {
+		if pol, err = i.policyRepo.FindByID(ctx, *policyID); err != nil {
 			return nil, nil, err
 		}
 		s, err := i.layerRepo.CountByScene(ctx, s.ID())
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := p.EnforceLayerCount(s + 1); err != nil {
+		if err := pol.EnforceLayerCount(s + 1); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -268,6 +284,10 @@ func (i *Layer) AddItem(ctx context.Context, inp interfaces.AddLayerItemInput, o
 		return nil, nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionLayerAdd, s.Workspace(), lo.ToPtr(s.ID()), layerTargets(layerItem.ID(), parentLayer.ID())); err != nil {
+		return nil, nil, err
+	}
+	i.warnUsage(ctx, ws.ID(), pol)
 	tx.Commit()
 	return layerItem, parentLayer, nil
 }
@@ -300,9 +320,9 @@ func (i *Layer) AddGroup(ctx context.Context, inp interfaces.AddLayerGroupInput,
 	}
 
 	// enforce policy
+	var pol *policy.Policy
 	if policyID := operator.Policy(ws.Policy()); policyID != nil {
-		p, err := i.policyRepo.FindByID(ctx, *policyID)
-		if err != nil {
+		if pol, err = i.policyRepo.FindByID(ctx, *policyID); err != nil {
 			return nil, nil, err
 		}
 		s, err := i.layerRepo.CountByScene(ctx, s.ID())
@@ -317,7 +337,7 @@ func (i *Layer) AddGroup(ctx context.Context, inp interfaces.AddLayerGroupInput,
 			}
 		}
 
-		if err := p.EnforceLayerCount(s + dsc + 1); err != nil {
+		if err := pol.EnforceLayerCount(s + dsc + 1); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -487,10 +507,21 @@ func (i *Layer) AddGroup(ctx context.Context, inp interfaces.AddLayerGroupInput,
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerAdd, layerGroup.Scene(), layerTargets(layerGroup.ID(), parentLayer.ID())); err != nil {
+		return nil, nil, err
+	}
+	i.warnUsage(ctx, ws.ID(), pol)
 	tx.Commit()
 	return layerGroup, parentLayer, nil
 }
 
+// layerTargets returns the audit targets of the layers without duplicates.
+func layerTargets(ids ...id.LayerID) []auditlog.Target {
+	return lo.Map(lo.Uniq(ids), func(l id.LayerID, _ int) auditlog.Target {
+		return auditlog.TargetOf(l)
+	})
+}
+
 func (i *Layer) fetchAllChildren(ctx context.Context, l layer.Layer) ([]id.LayerID, []id.PropertyID, error) {
 	lidl := layer.ToLayerGroup(l).Layers().Layers()
 	layers, err := i.layerRepo.FindByIDs(ctx, lidl)
@@ -581,6 +612,13 @@ func (i *Layer) Remove(ctx context.Context, lid id.LayerID, operator *usecase.Op
 		return lid, nil, err
 	}
 
+	targets := layerTargets(lid)
+	if parentLayer != nil {
+		targets = layerTargets(lid, parentLayer.ID())
+	}
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerRemove, l.Scene(), targets); err != nil {
+		return lid, nil, err
+	}
 	tx.Commit()
 	return lid, parentLayer, nil
 }
@@ -598,34 +636,45 @@ func (i *Layer) Update(ctx context.Context, inp interfaces.UpdateLayerInput, ope
 		}
 	}()
 
-	layer, err := i.layerRepo.FindByID(ctx, inp.LayerID)
+	l, err := i.layerRepo.FindByID(ctx, inp.LayerID)
 	if err != nil {
 		return nil, err
 	}
-	if err := i.CanWriteScene(layer.Scene(), operator); err != nil {
+	if err := i.CanWriteScene(l.Scene(), operator); err != nil {
 		return nil, err
 	}
 
 	// check scene lock
-	if err := i.CheckSceneLock(ctx, layer.Scene()); err != nil {
+	if err := i.CheckSceneLock(ctx, l.Scene()); err != nil {
 		return nil, err
 	}
 
+	prev := layer.Clone(l)
+	prevName, prevVisible := l.Name(), l.IsVisible()
 	if inp.Name != nil {
-		layer.Rename(*inp.Name)
+		l.Rename(*inp.Name)
 	}
 
 	if inp.Visible != nil {
-		layer.SetVisible(*inp.Visible)
+		l.SetVisible(*inp.Visible)
 	}
 
-	err = i.layerRepo.Save(ctx, layer)
+	err = i.layerRepo.Save(ctx, l)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerUpdate, l.Scene(), []auditlog.Target{auditlog.TargetOf(l.ID())},
+		auditlog.NewChange("name", prevName, l.Name()),
+		auditlog.NewChange("visible", prevVisible, l.IsVisible()),
+	); err != nil {
+		return nil, err
+	}
+	if err := i.recordHistory(ctx, operator, l.Scene(), auditlog.ActionLayerUpdate, history.LayerChange(prev, l)); err != nil {
+		return nil, err
+	}
 	tx.Commit()
-	return layer, nil
+	return l, nil
 }
 
 func (i *Layer) Move(ctx context.Context, inp interfaces.MoveLayerInput, operator *usecase.Operator) (_ id.LayerID, _ *layer.Group, _ *layer.Group, _ int, err error) {
@@ -672,6 +721,8 @@ func (i *Layer) Move(ctx context.Context, inp interfaces.MoveLayerInput, operato
 		}
 	}
 
+	prevParent, prevToParent := parentLayer.Clone(), toParentLayer.Clone()
+	prevIndex := parentLayer.Layers().FindLayerIndex(inp.LayerID)
 	toParentLayer.MoveLayerFrom(inp.LayerID, inp.Index, parentLayer)
 
 	layers := layer.List{parentLayer.LayerRef()}
@@ -683,6 +734,19 @@ func (i *Layer) Move(ctx context.Context, inp interfaces.MoveLayerInput, operato
 		return inp.LayerID, nil, nil, -1, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerMove, parentLayer.Scene(), layerTargets(inp.LayerID, parentLayer.ID(), toParentLayer.ID()),
+		auditlog.NewChange("parent", parentLayer.ID().String(), toParentLayer.ID().String()),
+		auditlog.NewChange("index", prevIndex, toParentLayer.Layers().FindLayerIndex(inp.LayerID)),
+	); err != nil {
+		return inp.LayerID, nil, nil, -1, err
+	}
+	changes := []*history.Change{history.LayerChange(prevParent, parentLayer)}
+	if parentLayer.ID() != toParentLayer.ID() {
+		changes = append(changes, history.LayerChange(prevToParent, toParentLayer))
+	}
+	if err := i.recordHistory(ctx, operator, parentLayer.Scene(), auditlog.ActionLayerMove, changes...); err != nil {
+		return inp.LayerID, nil, nil, -1, err
+	}
 	tx.Commit()
 	return inp.LayerID,
 		parentLayer,
@@ -739,6 +803,9 @@ func (i *Layer) CreateInfobox(ctx context.Context, lid id.LayerID, operator *use
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerCreateInfobox, l.Scene(), []auditlog.Target{auditlog.TargetOf(l.ID()), auditlog.TargetOf(property.ID())}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return l, nil
 }
@@ -787,6 +854,9 @@ func (i *Layer) RemoveInfobox(ctx context.Context, layerID id.LayerID, operator
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerRemoveInfobox, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID()), auditlog.TargetOf(infobox.Property())}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return layer, nil
 }
@@ -861,6 +931,9 @@ func (i *Layer) AddInfoboxField(ctx context.Context, inp interfaces.AddInfoboxFi
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerAddInfoboxField, l.Scene(), []auditlog.Target{auditlog.TargetOf(l.ID()), auditlog.TargetOf(field.ID())}); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return field, l, err
 }
@@ -903,6 +976,9 @@ func (i *Layer) MoveInfoboxField(ctx context.Context, inp interfaces.MoveInfobox
 		return inp.InfoboxFieldID, nil, -1, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerMoveInfoboxField, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID()), auditlog.TargetOf(inp.InfoboxFieldID)}); err != nil {
+		return inp.InfoboxFieldID, nil, -1, err
+	}
 	tx.Commit()
 	return inp.InfoboxFieldID, layer, inp.Index, err
 }
@@ -945,6 +1021,9 @@ func (i *Layer) RemoveInfoboxField(ctx context.Context, inp interfaces.RemoveInf
 		return inp.InfoboxFieldID, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerRemoveInfoboxField, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID()), auditlog.TargetOf(inp.InfoboxFieldID)}); err != nil {
+		return inp.InfoboxFieldID, nil, err
+	}
 	tx.Commit()
 	return inp.InfoboxFieldID, layer, err
 }
@@ -1035,7 +1114,7 @@ func (i *Layer) ImportLayer(ctx context.Context, inp interfaces.ImportLayerParam
 	if decoder == nil {
 		return nil, nil, errors.New("unsupported format")
 	}
-	result, err := decoder.Decode()
+	result, err := decoding.ReprojectDecoder{Decoder: decoder, CRS: inp.CRS}.Decode()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1064,6 +1143,9 @@ func (i *Layer) ImportLayer(ctx context.Context, inp interfaces.ImportLayerParam
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionLayerImport, parent.Scene(), layerTargets(append(rootLayers.IDs().Layers(), parent.ID())...)); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return rootLayers, parent, nil
 }
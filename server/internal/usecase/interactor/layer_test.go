@@ -6,6 +6,7 @@ import (
 
 	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/scene"
@@ -19,7 +20,7 @@ func TestCreateInfobox(t *testing.T) {
 	db := memory.New()
 	scene, _ := scene.New().NewID().Workspace(accountdomain.NewWorkspaceID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
 	_ = db.Scene.Save(ctx, scene)
-	il := NewLayer(db)
+	il := NewLayer(db, &gateway.Container{})
 
 	l, _ := layer.NewItem().NewID().Scene(scene.ID()).Build()
 	_ = db.Layer.Save(ctx, l)
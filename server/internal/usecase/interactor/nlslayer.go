@@ -5,9 +5,12 @@ import (
 	"errors"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
 	"github.com/reearth/reearth/server/pkg/builtin"
+	"github.com/reearth/reearth/server/pkg/history"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/reearth/reearth/server/pkg/nlslayer/nlslayerops"
@@ -15,11 +18,15 @@ import (
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 )
 
 type NLSLayer struct {
 	common
 	commonSceneLock
+	commonPolicy
+	commonAudit
+	commonHistory
 	nlslayerRepo  repo.NLSLayer
 	sceneLockRepo repo.SceneLock
 	propertyRepo  repo.Property
@@ -27,7 +34,7 @@ type NLSLayer struct {
 	transaction   usecasex.Transaction
 }
 
-func NewNLSLayer(r *repo.Container) interfaces.NLSLayer {
+func NewNLSLayer(r *repo.Container, g *gateway.Container) interfaces.NLSLayer {
 	return &NLSLayer{
 		commonSceneLock: commonSceneLock{sceneLockRepo: r.SceneLock},
 		nlslayerRepo:    r.NLSLayer,
@@ -35,6 +42,14 @@ func NewNLSLayer(r *repo.Container) interfaces.NLSLayer {
 		propertyRepo:    r.Property,
 		pluginRepo:      r.Plugin,
 		transaction:     r.Transaction,
+		commonPolicy: commonPolicy{
+			sceneRepo:        r.Scene,
+			workspaceRepo:    r.Workspace,
+			policyRepo:       r.Policy,
+			storytellingRepo: r.Storytelling,
+		},
+		commonAudit:   newCommonAudit(r, g),
+		commonHistory: newCommonHistory(r),
 	}
 }
 
@@ -71,6 +86,10 @@ func (i *NLSLayer) AddLayerSimple(ctx context.Context, inp interfaces.AddNLSLaye
 		return nil, interfaces.ErrOperationDenied
 	}
 
+	if err := i.enforceNLSLayerCount(ctx, inp.SceneID, operator); err != nil {
+		return nil, err
+	}
+
 	layerSimple, err := nlslayerops.LayerSimple{
 		SceneID:   inp.SceneID,
 		Config:    inp.Config,
@@ -102,10 +121,28 @@ func (i *NLSLayer) AddLayerSimple(ctx context.Context, inp interfaces.AddNLSLaye
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerAdd, inp.SceneID, []auditlog.Target{auditlog.TargetOf(layerSimple.ID())}); err != nil {
+		return nil, err
+	}
+	if err := i.recordHistory(ctx, operator, inp.SceneID, auditlog.ActionNLSLayerAdd, history.NLSLayerChange(nil, layerSimple)); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return layerSimple, nil
 }
 
+func (i *NLSLayer) enforceNLSLayerCount(ctx context.Context, sid id.SceneID, operator *usecase.Operator) error {
+	p, _, err := i.scenePolicy(ctx, sid, operator)
+	if err != nil || p == nil {
+		return err
+	}
+	n, err := i.nlslayerRepo.CountByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+	return p.EnforceNLSLayerCount(n + 1)
+}
+
 func (i *NLSLayer) fetchAllChildren(ctx context.Context, l nlslayer.NLSLayer) ([]id.NLSLayerID, error) {
 	lidl := nlslayer.ToNLSLayerGroup(l).Children().Layers()
 	layers, err := i.nlslayerRepo.FindByIDs(ctx, lidl)
@@ -176,11 +213,26 @@ func (i *NLSLayer) Remove(ctx context.Context, lid id.NLSLayerID, operator *usec
 		return lid, nil, err
 	}
 	layers = append(layers, l.ID())
+	removed, err := i.nlslayerRepo.FindByIDs(ctx, layers)
+	if err != nil {
+		return lid, nil, err
+	}
 	err = i.nlslayerRepo.RemoveAll(ctx, layers)
 	if err != nil {
 		return lid, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerRemove, l.Scene(), []auditlog.Target{auditlog.TargetOf(lid)}); err != nil {
+		return lid, nil, err
+	}
+	if err := i.recordHistory(ctx, operator, l.Scene(), auditlog.ActionNLSLayerRemove, lo.Map(removed, func(l *nlslayer.NLSLayer, _ int) *history.Change {
+		if l == nil {
+			return nil
+		}
+		return history.NLSLayerChange(*l, nil)
+	})...); err != nil {
+		return lid, nil, err
+	}
 	tx.Commit()
 	return lid, parentLayer, nil
 }
@@ -205,7 +257,12 @@ func (i *NLSLayer) Update(ctx context.Context, inp interfaces.UpdateNLSLayerInpu
 	if err := i.CanWriteScene(layer.Scene(), operator); err != nil {
 		return nil, err
 	}
+	if err := checkVersion(layer.ID(), layer.Version(), inp.ExpectedVersion); err != nil {
+		return nil, err
+	}
 
+	prev := nlslayer.Clone(layer)
+	prevTitle, prevVisible := layer.Title(), layer.IsVisible()
 	if inp.Name != nil {
 		layer.Rename(*inp.Name)
 	}
@@ -223,6 +280,15 @@ func (i *NLSLayer) Update(ctx context.Context, inp interfaces.UpdateNLSLayerInpu
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerUpdate, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID())},
+		auditlog.NewChange("title", prevTitle, layer.Title()),
+		auditlog.NewChange("visible", prevVisible, layer.IsVisible()),
+	); err != nil {
+		return nil, err
+	}
+	if err := i.recordHistory(ctx, operator, layer.Scene(), auditlog.ActionNLSLayerUpdate, history.NLSLayerChange(prev, layer)); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return layer, nil
 }
@@ -275,6 +341,9 @@ func (i *NLSLayer) CreateNLSInfobox(ctx context.Context, lid id.NLSLayerID, oper
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerCreateInfobox, l.Scene(), []auditlog.Target{auditlog.TargetOf(l.ID()), auditlog.TargetOf(property.ID())}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return l, nil
 }
@@ -323,6 +392,9 @@ func (i *NLSLayer) RemoveNLSInfobox(ctx context.Context, layerID id.NLSLayerID,
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerRemoveInfobox, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID()), auditlog.TargetOf(infobox.Property())}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return layer, nil
 }
@@ -382,6 +454,7 @@ func (i *NLSLayer) AddNLSInfoboxBlock(ctx context.Context, inp interfaces.AddNLS
 	if infobox == nil {
 		return nil, nil, interfaces.ErrInfoboxNotFound
 	}
+	prev := nlslayer.Clone(l)
 
 	_, extension, err := i.getPlugin(ctx, l.Scene(), &inp.PluginID, &inp.ExtensionID)
 	if err != nil {
@@ -421,6 +494,15 @@ func (i *NLSLayer) AddNLSInfoboxBlock(ctx context.Context, inp interfaces.AddNLS
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerAddBlock, l.Scene(), []auditlog.Target{auditlog.TargetOf(l.ID()), auditlog.TargetOf(block.ID())}); err != nil {
+		return nil, nil, err
+	}
+	if err := i.recordHistory(ctx, operator, l.Scene(), auditlog.ActionNLSLayerAddBlock,
+		history.NLSLayerChange(prev, l),
+		history.PropertyChange(nil, property),
+	); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return block, l, err
 }
@@ -456,6 +538,7 @@ func (i *NLSLayer) MoveNLSInfoboxBlock(ctx context.Context, inp interfaces.MoveN
 		return inp.InfoboxBlockID, nil, -1, interfaces.ErrInfoboxNotFound
 	}
 
+	prev := nlslayer.Clone(layer)
 	infobox.Move(inp.InfoboxBlockID, inp.Index)
 
 	err = i.nlslayerRepo.Save(ctx, layer)
@@ -463,6 +546,12 @@ func (i *NLSLayer) MoveNLSInfoboxBlock(ctx context.Context, inp interfaces.MoveN
 		return inp.InfoboxBlockID, nil, -1, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerMoveBlock, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID()), auditlog.TargetOf(inp.InfoboxBlockID)}); err != nil {
+		return inp.InfoboxBlockID, nil, -1, err
+	}
+	if err := i.recordHistory(ctx, operator, layer.Scene(), auditlog.ActionNLSLayerMoveBlock, history.NLSLayerChange(prev, layer)); err != nil {
+		return inp.InfoboxBlockID, nil, -1, err
+	}
 	tx.Commit()
 	return inp.InfoboxBlockID, layer, inp.Index, err
 }
@@ -498,6 +587,7 @@ func (i *NLSLayer) RemoveNLSInfoboxBlock(ctx context.Context, inp interfaces.Rem
 		return inp.InfoboxBlockID, nil, interfaces.ErrInfoboxNotFound
 	}
 
+	prev := nlslayer.Clone(layer)
 	infobox.Remove(inp.InfoboxBlockID)
 
 	err = i.nlslayerRepo.Save(ctx, layer)
@@ -505,6 +595,12 @@ func (i *NLSLayer) RemoveNLSInfoboxBlock(ctx context.Context, inp interfaces.Rem
 		return inp.InfoboxBlockID, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerRemoveBlock, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID()), auditlog.TargetOf(inp.InfoboxBlockID)}); err != nil {
+		return inp.InfoboxBlockID, nil, err
+	}
+	if err := i.recordHistory(ctx, operator, layer.Scene(), auditlog.ActionNLSLayerRemoveBlock, history.NLSLayerChange(prev, layer)); err != nil {
+		return inp.InfoboxBlockID, nil, err
+	}
 	tx.Commit()
 	return inp.InfoboxBlockID, layer, err
 }
@@ -530,6 +626,10 @@ func (i *NLSLayer) Duplicate(ctx context.Context, lid id.NLSLayerID, operator *u
 		return nil, err
 	}
 
+	if err := i.enforceNLSLayerCount(ctx, layer.Scene(), operator); err != nil {
+		return nil, err
+	}
+
 	duplicatedLayer := layer.Duplicate()
 
 	err = i.nlslayerRepo.Save(ctx, duplicatedLayer)
@@ -537,6 +637,9 @@ func (i *NLSLayer) Duplicate(ctx context.Context, lid id.NLSLayerID, operator *u
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerAdd, layer.Scene(), []auditlog.Target{auditlog.TargetOf(duplicatedLayer.ID()), auditlog.TargetOf(lid)}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return duplicatedLayer, nil
 }
@@ -580,6 +683,9 @@ func (i *NLSLayer) AddCustomProperties(ctx context.Context, inp interfaces.AddCu
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerUpdateSchema, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID())}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return layer, nil
 }
@@ -602,6 +708,15 @@ func (i *NLSLayer) AddGeoJSONFeature(ctx context.Context, inp interfaces.AddNLSL
 		return nlslayer.Feature{}, err
 	}
 
+	// enforce policy
+	p, _, err := i.scenePolicy(ctx, layer.Scene(), operator)
+	if err != nil {
+		return nlslayer.Feature{}, err
+	}
+	if err := p.EnforceSketchFeatureCount(countSketchFeatures(layer) + 1); err != nil {
+		return nlslayer.Feature{}, err
+	}
+
 	geometry, err := nlslayer.NewGeometryFromMap(inp.Geometry)
 	if err != nil {
 		return nlslayer.Feature{}, err
@@ -619,6 +734,7 @@ func (i *NLSLayer) AddGeoJSONFeature(ctx context.Context, inp interfaces.AddNLSL
 		feature.UpdateProperties(inp.Properties)
 	}
 
+	prev := nlslayer.Clone(layer)
 	if layer.Sketch() == nil {
 		featureCollection := nlslayer.NewFeatureCollection(
 			"FeatureCollection",
@@ -641,6 +757,12 @@ func (i *NLSLayer) AddGeoJSONFeature(ctx context.Context, inp interfaces.AddNLSL
 		return nlslayer.Feature{}, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerAddFeature, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID()), auditlog.TargetOf(feature.ID())}); err != nil {
+		return nlslayer.Feature{}, err
+	}
+	if err := i.recordHistory(ctx, operator, layer.Scene(), auditlog.ActionNLSLayerAddFeature, history.NLSLayerChange(prev, layer)); err != nil {
+		return nlslayer.Feature{}, err
+	}
 	tx.Commit()
 	return *feature, nil
 }
@@ -667,6 +789,7 @@ func (i *NLSLayer) UpdateGeoJSONFeature(ctx context.Context, inp interfaces.Upda
 		return nlslayer.Feature{}, interfaces.ErrFeatureNotFound
 	}
 
+	prev := nlslayer.Clone(layer)
 	var updatedFeature nlslayer.Feature
 	var errUp error
 
@@ -693,6 +816,12 @@ func (i *NLSLayer) UpdateGeoJSONFeature(ctx context.Context, inp interfaces.Upda
 		return nlslayer.Feature{}, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerUpdateFeature, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID()), auditlog.TargetOf(inp.FeatureID)}); err != nil {
+		return nlslayer.Feature{}, err
+	}
+	if err := i.recordHistory(ctx, operator, layer.Scene(), auditlog.ActionNLSLayerUpdateFeature, history.NLSLayerChange(prev, layer)); err != nil {
+		return nlslayer.Feature{}, err
+	}
 	tx.Commit()
 	return updatedFeature, nil
 }
@@ -719,6 +848,7 @@ func (i *NLSLayer) DeleteGeoJSONFeature(ctx context.Context, inp interfaces.Dele
 		return id.FeatureID{}, interfaces.ErrFeatureNotFound
 	}
 
+	prev := nlslayer.Clone(layer)
 	err = layer.Sketch().FeatureCollection().RemoveFeature(inp.FeatureID)
 	if err != nil {
 		return id.FeatureID{}, err
@@ -729,6 +859,12 @@ func (i *NLSLayer) DeleteGeoJSONFeature(ctx context.Context, inp interfaces.Dele
 		return id.FeatureID{}, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerRemoveFeature, layer.Scene(), []auditlog.Target{auditlog.TargetOf(layer.ID()), auditlog.TargetOf(inp.FeatureID)}); err != nil {
+		return id.FeatureID{}, err
+	}
+	if err := i.recordHistory(ctx, operator, layer.Scene(), auditlog.ActionNLSLayerRemoveFeature, history.NLSLayerChange(prev, layer)); err != nil {
+		return id.FeatureID{}, err
+	}
 	tx.Commit()
 	return inp.FeatureID, nil
 }
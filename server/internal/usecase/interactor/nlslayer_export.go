@@ -0,0 +1,46 @@
+package interactor
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer/encoding"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// Export encodes sketch features of the layer and its descendants into the format specified by the extension.
+// Coordinates are transformed into the CRS unless it is nil.
+func (i *NLSLayer) Export(ctx context.Context, lid id.NLSLayerID, ext string, c *crs.CRS, operator *usecase.Operator) (io.Reader, string, error) {
+	l, err := i.nlslayerRepo.FindByID(ctx, lid)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := i.CanReadScene(l.Scene(), operator); err != nil {
+		return nil, "", err
+	}
+
+	reader, writer := io.Pipe()
+	e := encoding.EncoderFromExt(strings.ToLower(ext), writer)
+	if e == nil {
+		return nil, "", rerror.ErrNotFound
+	}
+	ex := &encoding.Exporter{
+		Loader:  repo.NLSLayerLoaderFrom(i.nlslayerRepo),
+		Encoder: e,
+		CRS:     c,
+	}
+	if err := ex.ValidateCRS(); err != nil {
+		return nil, "", err
+	}
+
+	go func() {
+		_ = writer.CloseWithError(ex.ExportLayer(ctx, l))
+	}()
+
+	return reader, e.MimeType(), nil
+}
@@ -0,0 +1,62 @@
+package interactor
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/nlslayer/encoding"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNLSLayer_Export(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	scene, _ := scene.New().NewID().Workspace(accountdomain.NewWorkspaceID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, scene)
+	il := NewNLSLayer(db, &gateway.Container{})
+
+	f := lo.Must(nlslayer.NewFeatureWithNewId("Feature", nlslayer.NewPoint("Point", []float64{1, 2})))
+	f.UpdateProperties(&map[string]any{"name": "a"})
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(scene.ID()).Title("layer").IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*f}))).MustBuild()
+	g := nlslayer.NewNLSLayerGroup().NewID().Scene(scene.ID()).Title("group").Layers(nlslayer.NewIDList([]nlslayer.ID{l.ID()})).MustBuild()
+	_ = db.NLSLayer.Save(ctx, l)
+	_ = db.NLSLayer.Save(ctx, g)
+
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{scene.ID()},
+	}
+
+	r, mime, err := il.Export(ctx, g.ID(), "KML", nil, op)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/xml", mime)
+	b := lo.Must(io.ReadAll(r))
+	assert.Contains(t, string(b), "<name>group</name>")
+	assert.Contains(t, string(b), "<name>layer</name>")
+	assert.Contains(t, string(b), "<coordinates>1,2</coordinates>")
+
+	_, _, err = il.Export(ctx, g.ID(), "txt", nil, op)
+	assert.Same(t, rerror.ErrNotFound, err)
+	_, _, err = il.Export(ctx, g.ID(), "kml", nil, &usecase.Operator{})
+	assert.Error(t, err)
+
+	c := lo.Must(crs.EPSG(3857))
+	_, _, err = il.Export(ctx, g.ID(), "kml", c, op)
+	assert.Same(t, encoding.ErrCRSNotSupported, err)
+	r, _, err = il.Export(ctx, g.ID(), "geojson", c, op)
+	assert.NoError(t, err)
+	b = lo.Must(io.ReadAll(r))
+	assert.Contains(t, string(b), `"name":"urn:ogc:def:crs:EPSG::3857"`)
+}
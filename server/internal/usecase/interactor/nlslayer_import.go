@@ -0,0 +1,162 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/layer/decoding"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	nlsdecoding "github.com/reearth/reearth/server/pkg/nlslayer/decoding"
+	"github.com/reearth/reearth/server/pkg/nlslayer/nlslayerops"
+	"github.com/reearth/reearth/server/pkg/shp"
+	"github.com/samber/lo"
+)
+
+// ImportLayer decodes a file into features of a sketch layer and infers the custom property schema from their properties.
+// Features are appended to the layer when the layer ID is specified, otherwise a new sketch layer is created.
+func (i *NLSLayer) ImportLayer(ctx context.Context, inp interfaces.ImportNLSLayerParam, operator *usecase.Operator) (_ nlslayer.NLSLayer, err error) {
+	if inp.File == nil {
+		return nil, interfaces.ErrFileNotIncluded
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	if err := i.CanWriteScene(inp.SceneID, operator); err != nil {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	// enforce policy
+	p, _, err := i.scenePolicy(ctx, inp.SceneID, operator)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.EnforceUploadSize(inp.File.Size); err != nil {
+		return nil, err
+	}
+
+	var l nlslayer.NLSLayer
+	if inp.LayerID != nil {
+		l, err = i.nlslayerRepo.FindByID(ctx, *inp.LayerID)
+		if err != nil {
+			return nil, err
+		}
+		if l.Scene() != inp.SceneID {
+			return nil, interfaces.ErrOperationDenied
+		}
+	}
+
+	decoder, err := nlsLayerDecoder(inp.File, inp.Format)
+	if err != nil {
+		return nil, err
+	}
+	features, err := nlsdecoding.ReprojectDecoder{Decoder: decoder, CRS: inp.CRS}.Decode()
+	if err != nil {
+		return nil, err
+	}
+	if len(features) == 0 {
+		return nil, interfaces.ErrNoFeaturesImported
+	}
+
+	if err := p.EnforceSketchFeatureCount(countSketchFeatures(l) + len(features)); err != nil {
+		return nil, err
+	}
+
+	if l == nil {
+		if err := i.enforceNLSLayerCount(ctx, inp.SceneID, operator); err != nil {
+			return nil, err
+		}
+
+		title := lo.FromPtr(inp.Title)
+		if title == "" {
+			title = strings.TrimSuffix(path.Base(inp.File.Path), path.Ext(inp.File.Path))
+		}
+		l, err = nlslayerops.LayerSimple{
+			SceneID:   inp.SceneID,
+			LayerType: nlslayer.Simple,
+			Title:     title,
+			Config: &nlslayer.Config{
+				"data": map[string]any{
+					"type": "geojson",
+				},
+				"properties": map[string]any{
+					"name": title,
+				},
+			},
+			IsSketch: lo.ToPtr(true),
+		}.Initialize()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if l.Sketch() == nil || l.Sketch().FeatureCollection() == nil {
+		schema := nlsdecoding.Schema(features)
+		l.SetIsSketch(true)
+		l.SetSketch(nlslayer.NewSketchInfo(&schema, nlslayer.NewFeatureCollection("FeatureCollection", features)))
+	} else {
+		schema := nlsdecoding.MergeSchema(lo.FromPtr(l.Sketch().CustomPropertySchema()), features)
+		for _, f := range features {
+			l.Sketch().FeatureCollection().AddFeature(f)
+		}
+		l.Sketch().SetCustomPropertySchema(&schema)
+	}
+
+	if err := i.nlslayerRepo.Save(ctx, l); err != nil {
+		return nil, err
+	}
+
+	if err := i.auditScene(ctx, operator, auditlog.ActionNLSLayerImport, l.Scene(), []auditlog.Target{auditlog.TargetOf(l.ID())}); err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return l, nil
+}
+
+func nlsLayerDecoder(f *file.File, format decoding.LayerEncodingFormat) (nlsdecoding.Decoder, error) {
+	switch format {
+	case decoding.LayerEncodingFormatKML:
+		return nlsdecoding.NewKMLDecoder(xml.NewDecoder(f.Content)), nil
+	case decoding.LayerEncodingFormatGEOJSON:
+		return nlsdecoding.NewGeoJSONDecoder(f.Content), nil
+	case decoding.LayerEncodingFormatCZML:
+		return nlsdecoding.NewCZMLDecoder(json.NewDecoder(f.Content)), nil
+	case decoding.LayerEncodingFormatSHAPE:
+		// limit file size to 2m
+		if f.Size > 2097152 {
+			return nil, errors.New("file is too big")
+		}
+		var reader nlsdecoding.ShapeReader
+		var err error
+		switch {
+		case strings.HasSuffix(f.Path, ".shp"):
+			reader, err = shp.ReadFrom(f.Content)
+		case strings.HasSuffix(f.Path, ".zip"):
+			reader, err = shp.ReadZipFrom(f.Content)
+		default:
+			return nil, errors.New("unsupported format")
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nlsdecoding.NewShapeDecoder(reader), nil
+	}
+	return nil, errors.New("unsupported format")
+}
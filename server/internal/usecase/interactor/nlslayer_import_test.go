@@ -0,0 +1,83 @@
+package interactor
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer/decoding"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNLSLayer_ImportLayer(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	scene, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, scene)
+	il := NewNLSLayer(db, &gateway.Container{})
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{scene.ID()},
+	}
+
+	newFile := func(name, content string) *file.File {
+		return &file.File{Path: name, Content: io.NopCloser(strings.NewReader(content)), Size: int64(len(content))}
+	}
+
+	l, err := il.ImportLayer(ctx, interfaces.ImportNLSLayerParam{
+		SceneID: scene.ID(),
+		File: newFile("points.geojson", `{"type":"FeatureCollection","features":[
+			{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{"name":"a","count":1}},
+			{"type":"Feature","geometry":{"type":"Point","coordinates":[3,4]},"properties":{"name":"b","count":2.5}}
+		]}`),
+		Format: decoding.LayerEncodingFormatGEOJSON,
+	}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, "points", l.Title())
+	assert.True(t, l.IsSketch())
+	assert.Len(t, l.Sketch().FeatureCollection().Features(), 2)
+	assert.Equal(t, map[string]any{"count": "Float_1", "name": "Text_2"}, *l.Sketch().CustomPropertySchema())
+
+	// append features to the layer
+	lid := l.ID()
+	l, err = il.ImportLayer(ctx, interfaces.ImportNLSLayerParam{
+		SceneID: scene.ID(),
+		LayerID: &lid,
+		File:    newFile("points.kml", `<kml><Placemark><name>c</name><ExtendedData><Data name="kind"><value>x</value></Data></ExtendedData><Point><coordinates>5,6</coordinates></Point></Placemark></kml>`),
+		Format:  decoding.LayerEncodingFormatKML,
+	}, op)
+	assert.NoError(t, err)
+	assert.Len(t, l.Sketch().FeatureCollection().Features(), 3)
+	assert.Equal(t, map[string]any{"count": "Float_1", "name": "Text_2", "kind": "Text_3"}, *l.Sketch().CustomPropertySchema())
+
+	res, err := db.NLSLayer.FindByID(ctx, lid)
+	assert.NoError(t, err)
+	assert.Len(t, res.Sketch().FeatureCollection().Features(), 3)
+
+	// errors
+	_, err = il.ImportLayer(ctx, interfaces.ImportNLSLayerParam{SceneID: scene.ID(), Format: decoding.LayerEncodingFormatGEOJSON}, op)
+	assert.Same(t, interfaces.ErrFileNotIncluded, err)
+	_, err = il.ImportLayer(ctx, interfaces.ImportNLSLayerParam{
+		SceneID: scene.ID(),
+		File:    newFile("empty.czml", `[{"id":"document"}]`),
+		Format:  decoding.LayerEncodingFormatCZML,
+	}, op)
+	assert.Same(t, interfaces.ErrNoFeaturesImported, err)
+	_, err = il.ImportLayer(ctx, interfaces.ImportNLSLayerParam{
+		SceneID: scene.ID(),
+		File:    newFile("points.geojson", `{}`),
+		Format:  decoding.LayerEncodingFormatGEOJSON,
+	}, &usecase.Operator{})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearthx/account/accountusecase/accountrepo"
 	"github.com/reearth/reearthx/usecasex"
 )
 
@@ -21,6 +22,8 @@ type Plugin struct {
 	layerRepo          repo.Layer
 	file               gateway.File
 	pluginRegistry     gateway.PluginRegistry
+	workspaceRepo      accountrepo.Workspace
+	policyRepo         repo.Policy
 	transaction        usecasex.Transaction
 }
 
@@ -31,6 +34,8 @@ func NewPlugin(r *repo.Container, gr *gateway.Container) interfaces.Plugin {
 		pluginRepo:         r.Plugin,
 		propertySchemaRepo: r.PropertySchema,
 		propertyRepo:       r.Property,
+		workspaceRepo:      r.Workspace,
+		policyRepo:         r.Policy,
 		transaction:        r.Transaction,
 		file:               gr.File,
 		pluginRegistry:     gr.PluginRegistry,
@@ -46,6 +51,14 @@ func (i *Plugin) pluginCommon() *pluginCommon {
 	}
 }
 
+func (i *Plugin) commonPolicy() commonPolicy {
+	return commonPolicy{
+		sceneRepo:     i.sceneRepo,
+		workspaceRepo: i.workspaceRepo,
+		policyRepo:    i.policyRepo,
+	}
+}
+
 func (i *Plugin) Fetch(ctx context.Context, ids []id.PluginID, operator *usecase.Operator) ([]*plugin.Plugin, error) {
 	return i.pluginRepo.FindByIDs(ctx, ids)
 }
@@ -1,6 +1,7 @@
 package interactor
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net/http"
@@ -25,6 +26,11 @@ func (i *Plugin) Upload(ctx context.Context, r io.Reader, sid id.SceneID, operat
 		return nil, nil, err
 	}
 
+	r, err = i.enforceUploadSize(ctx, r, sid, operator)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	p, err := pluginpack.PackageFromZip(r, &sid, pluginPackageSizeLimit)
 	if err != nil {
 		return nil, nil, &rerror.Error{
@@ -63,7 +69,12 @@ func (i *Plugin) UploadFromRemote(ctx context.Context, u *url.URL, sid id.SceneI
 		return nil, nil, interfaces.ErrInvalidPluginPackage
 	}
 
-	p, err := pluginpack.PackageFromZip(res.Body, &sid, pluginPackageSizeLimit)
+	r, err := i.enforceUploadSize(ctx, res.Body, sid, operator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := pluginpack.PackageFromZip(r, &sid, pluginPackageSizeLimit)
 	if err != nil {
 		_ = res.Body.Close()
 		return nil, nil, &rerror.Error{
@@ -76,6 +87,23 @@ func (i *Plugin) UploadFromRemote(ctx context.Context, u *url.URL, sid id.SceneI
 	return i.upload(ctx, p, sid, operator)
 }
 
+// enforceUploadSize checks the size of the package against the policy applied to the scene.
+// The returned reader has to be read instead of r, which has been read to measure the size.
+func (i *Plugin) enforceUploadSize(ctx context.Context, r io.Reader, sid id.SceneID, operator *usecase.Operator) (io.Reader, error) {
+	pol, _, err := i.commonPolicy().scenePolicy(ctx, sid, operator)
+	if err != nil || pol == nil {
+		return r, err
+	}
+	b, err := io.ReadAll(io.LimitReader(r, pluginPackageSizeLimit))
+	if err != nil {
+		return nil, err
+	}
+	if err := pol.EnforceUploadSize(int64(len(b))); err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(b), nil
+}
+
 func (i *Plugin) upload(ctx context.Context, p *pluginpack.Package, sid id.SceneID, operator *usecase.Operator) (_ *plugin.Plugin, _ *scene.Scene, err error) {
 	if err := i.CanWriteScene(sid, operator); err != nil {
 		return nil, nil, err
@@ -107,6 +135,23 @@ func (i *Plugin) upload(ctx context.Context, p *pluginpack.Package, sid id.Scene
 		return nil, nil, interfaces.ErrPluginAlreadyInstalled
 	}
 
+	if oldpid == nil {
+		// enforce policy
+		pol, _, err := i.commonPolicy().scenePolicy(ctx, sid, operator)
+		if err != nil {
+			return nil, nil, err
+		}
+		if pol != nil {
+			n, err := i.commonPolicy().countPlugins(ctx, s.Workspace())
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := pol.EnforcePluginCount(n + 1); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	if oldpid != nil {
 		oldPlugin, err := i.pluginRepo.FindByID(ctx, *oldpid)
 		if err != nil {
@@ -17,6 +17,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
 	"github.com/reearth/reearthx/account/accountusecase"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/spf13/afero"
@@ -92,6 +93,7 @@ func TestPlugin_Upload_New(t *testing.T) {
 	pid := mockPluginID.WithScene(sid.Ref())
 
 	repos := memory.New()
+	_ = repos.Workspace.Save(ctx, workspace.New().ID(ws).MustBuild())
 	mfs := mockFS(nil)
 	files, err := fs.NewFile(mfs, "")
 	assert.NoError(t, err)
@@ -103,6 +105,8 @@ func TestPlugin_Upload_New(t *testing.T) {
 		pluginRepo:         repos.Plugin,
 		propertySchemaRepo: repos.PropertySchema,
 		propertyRepo:       repos.Property,
+		workspaceRepo:      repos.Workspace,
+		policyRepo:         repos.Policy,
 		layerRepo:          repos.Layer,
 		file:               files,
 		transaction:        repos.Transaction,
@@ -153,6 +157,7 @@ func TestPlugin_Upload_SameVersion(t *testing.T) {
 	wid1 := id.NewWidgetID()
 
 	repos := memory.New()
+	_ = repos.Workspace.Save(ctx, workspace.New().ID(ws).MustBuild())
 	mfs := mockFS(map[string]string{
 		"plugins/" + pid.String() + "/hogehoge": "foobar",
 	})
@@ -187,6 +192,8 @@ func TestPlugin_Upload_SameVersion(t *testing.T) {
 		pluginRepo:         repos.Plugin,
 		propertySchemaRepo: repos.PropertySchema,
 		propertyRepo:       repos.Property,
+		workspaceRepo:      repos.Workspace,
+		policyRepo:         repos.Policy,
 		layerRepo:          repos.Layer,
 		file:               files,
 		transaction:        repos.Transaction,
@@ -270,6 +277,7 @@ func TestPlugin_Upload_DiffVersion(t *testing.T) {
 	wid := id.NewWidgetID()
 
 	repos := memory.New()
+	_ = repos.Workspace.Save(ctx, workspace.New().ID(ws).MustBuild())
 	mfs := mockFS(map[string]string{
 		"plugins/" + oldpid.String() + "/hogehoge": "foobar",
 	})
@@ -315,6 +323,8 @@ func TestPlugin_Upload_DiffVersion(t *testing.T) {
 		pluginRepo:         repos.Plugin,
 		propertySchemaRepo: repos.PropertySchema,
 		propertyRepo:       repos.Property,
+		workspaceRepo:      repos.Workspace,
+		policyRepo:         repos.Policy,
 		layerRepo:          repos.Layer,
 		file:               files,
 		transaction:        repos.Transaction,
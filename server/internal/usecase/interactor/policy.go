@@ -3,21 +3,42 @@ package interactor
 import (
 	"context"
 	"errors"
+	"fmt"
+	"html"
+	"math"
+	"strings"
 
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/account/accountdomain/user"
 	"github.com/reearth/reearthx/account/accountdomain/workspace"
 	"github.com/reearth/reearthx/account/accountusecase"
 	"github.com/reearth/reearthx/account/accountusecase/accountinteractor"
+	"github.com/reearth/reearthx/account/accountusecase/accountrepo"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/samber/lo"
 )
 
 type Policy struct {
-	repos *repo.Container
+	common
+	usageWarner
+	repos    *repo.Container
+	gateways *gateway.Container
 }
 
-func NewPolicy(repos *repo.Container) *Policy {
-	return &Policy{repos: repos}
+func NewPolicy(repos *repo.Container, gateways *gateway.Container) *Policy {
+	return &Policy{usageWarner: newUsageWarner(repos, gateways), repos: repos, gateways: gateways}
 }
 
 func (i *Policy) FetchPolicy(ctx context.Context, ids []policy.ID) ([]*policy.Policy, error) {
@@ -25,6 +46,451 @@ func (i *Policy) FetchPolicy(ctx context.Context, ids []policy.ID) ([]*policy.Po
 	return res, err
 }
 
+func (i *Policy) FetchSceneUsage(ctx context.Context, sid id.SceneID, op *usecase.Operator) (*interfaces.SceneUsage, error) {
+	if err := i.CanReadScene(sid, op); err != nil {
+		return nil, err
+	}
+
+	cp := commonPolicy{
+		sceneRepo:        i.repos.Scene,
+		workspaceRepo:    i.repos.Workspace,
+		policyRepo:       i.repos.Policy,
+		storytellingRepo: i.repos.Storytelling,
+	}
+	p, s, err := cp.scenePolicy(ctx, sid, op)
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := i.repos.NLSLayer.FindByScene(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	stories, pages, err := cp.countStories(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	published, err := cp.countPublishedStories(ctx, s.Workspace())
+	if err != nil {
+		return nil, err
+	}
+	plugins, err := cp.countPlugins(ctx, s.Workspace())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &interfaces.SceneUsage{
+		SceneID:             sid,
+		Policy:              p,
+		NLSLayerCount:       len(layers),
+		StoryCount:          stories,
+		StoryPageCount:      pages,
+		PublishedStoryCount: published,
+		PluginCount:         plugins,
+	}
+	for _, l := range layers {
+		if l == nil {
+			continue
+		}
+		if c := countSketchFeatures(*l); c > res.SketchFeatureCount {
+			res.SketchFeatureCount = c
+		}
+	}
+	return res, nil
+}
+
+func (i *Policy) FetchWorkspaceUsage(ctx context.Context, wid accountdomain.WorkspaceID, op *usecase.Operator) (*interfaces.WorkspaceUsage, error) {
+	if err := i.CanReadWorkspace(wid, op); err != nil {
+		return nil, err
+	}
+
+	ws, err := i.repos.Workspace.FindByID(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+
+	var p *policy.Policy
+	if policyID := op.Policy(ws.Policy()); policyID != nil {
+		p, err = i.repos.Policy.FindByID(ctx, *policyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	u, err := i.workspaceUsage(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.WorkspaceUsage{
+		WorkspaceID: wid,
+		Policy:      p,
+		Items:       p.UsageItems(u),
+	}, nil
+}
+
+func (i *Policy) SendWorkspaceUsageWarning(ctx context.Context, wid accountdomain.WorkspaceID, threshold float64, op *usecase.Operator) ([]policy.UsageItem, error) {
+	if threshold <= 0 || math.IsNaN(threshold) {
+		return nil, interfaces.ErrInvalidUsageThreshold
+	}
+	if err := i.CanWriteWorkspace(wid, op); err != nil {
+		return nil, err
+	}
+
+	ws, err := i.repos.Workspace.FindByID(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+	var p *policy.Policy
+	if policyID := op.Policy(ws.Policy()); policyID != nil {
+		if p, err = i.repos.Policy.FindByID(ctx, *policyID); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := i.checkUsage(ctx, ws, p, []float64{threshold})
+	if err != nil {
+		return nil, err
+	}
+	if err := i.sendUsageWarning(ctx, w); err != nil {
+		return nil, err
+	}
+	if err := i.recordUsageWarning(ctx, w); err != nil {
+		return nil, err
+	}
+	return w.items, nil
+}
+
+// usageWarningThresholds are the percentages of the limits which the owners of workspaces are warned of
+// when edits limited by the policy make the usage of their workspaces reach them.
+var usageWarningThresholds = []float64{80, 100}
+
+// usageWarner warns the owners of workspaces of usage reaching the limits of the policy.
+// Each usage item is warned of once for each threshold, until the usage falls below the threshold again.
+type usageWarner struct {
+	repos    *repo.Container
+	gateways *gateway.Container
+}
+
+func newUsageWarner(repos *repo.Container, gateways *gateway.Container) usageWarner {
+	return usageWarner{repos: repos, gateways: gateways}
+}
+
+// usageWarning holds the usage items which have reached thresholds they have not been warned of.
+type usageWarning struct {
+	workspace *workspace.Workspace
+	items     []policy.UsageItem
+	// threshold is the lowest of the thresholds which the items have reached
+	threshold float64
+	// levels are the thresholds to record by names of usage items whose levels have changed
+	levels map[string]float64
+}
+
+// warnUsage warns of the usage of the workspace reaching usageWarningThresholds after an edit limited by the policy p.
+// It is called in the transaction of the edit, and the mail is sent after the transaction is committed.
+// Failures are only logged, as the edit is still valid without the warning.
+func (w usageWarner) warnUsage(ctx context.Context, wid accountdomain.WorkspaceID, p *policy.Policy) {
+	if p == nil || w.gateways == nil || w.gateways.Mailer == nil || w.repos.UsageWarning == nil {
+		return
+	}
+
+	ws, err := w.repos.Workspace.FindByID(ctx, wid)
+	if err != nil {
+		log.Errorfc(ctx, "policy: failed to check usage of workspace %s: %v", wid, err)
+		return
+	}
+	uw, err := w.checkUsage(ctx, ws, p, usageWarningThresholds)
+	if err == nil {
+		err = w.recordUsageWarning(ctx, uw)
+	}
+	if err != nil {
+		log.Errorfc(ctx, "policy: failed to check usage of workspace %s: %v", wid, err)
+		return
+	}
+
+	repo.AfterCommit(ctx, func() {
+		if err := w.sendUsageWarning(ctx, uw); err != nil {
+			log.Errorfc(ctx, "policy: failed to send usage warning of workspace %s: %v", wid, err)
+		}
+	})
+}
+
+// checkUsage finds the usage items which have reached the highest of the thresholds they have not been warned of.
+func (w usageWarner) checkUsage(ctx context.Context, ws *workspace.Workspace, p *policy.Policy, thresholds []float64) (*usageWarning, error) {
+	u, err := w.workspaceUsage(ctx, ws.ID())
+	if err != nil {
+		return nil, err
+	}
+	var warned map[string]float64
+	if w.repos.UsageWarning != nil {
+		if warned, err = w.repos.UsageWarning.FindByWorkspace(ctx, ws.ID()); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &usageWarning{workspace: ws, levels: map[string]float64{}}
+	for _, item := range p.UsageItems(u) {
+		level := 0.0
+		for _, t := range thresholds {
+			if item.Exceeds(t) {
+				level = max(level, t)
+			}
+		}
+
+		// the usage falling below a threshold is also recorded so that reaching it again is warned of
+		if last := warned[item.Name]; level != last {
+			res.levels[item.Name] = level
+		}
+		if level > warned[item.Name] {
+			res.items = append(res.items, item)
+			if res.threshold == 0 || level < res.threshold {
+				res.threshold = level
+			}
+		}
+	}
+	return res, nil
+}
+
+func (w usageWarner) recordUsageWarning(ctx context.Context, uw *usageWarning) error {
+	if w.repos.UsageWarning == nil {
+		return nil
+	}
+	for name, level := range uw.levels {
+		if err := w.repos.UsageWarning.Save(ctx, uw.workspace.ID(), name, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendUsageWarning sends a warning mail of the usage items to the owners of the workspace.
+func (w usageWarner) sendUsageWarning(ctx context.Context, uw *usageWarning) error {
+	if len(uw.items) == 0 {
+		return nil
+	}
+
+	owners, err := w.repos.User.FindByIDs(ctx, uw.workspace.Members().UsersByRole(workspace.RoleOwner))
+	if err != nil {
+		return err
+	}
+	to := make([]mailer.Contact, 0, len(owners))
+	for _, o := range owners {
+		if o != nil && o.Email() != "" {
+			to = append(to, mailer.Contact{Email: o.Email(), Name: o.Name()})
+		}
+	}
+	if len(to) == 0 {
+		return nil
+	}
+
+	subject, text, htmlContent := workspaceUsageWarningMail(uw.workspace.Name(), uw.threshold, uw.items)
+	return w.gateways.Mailer.SendMail(ctx, to, subject, text, htmlContent)
+}
+
+// workspaceUsage counts what the policy of the workspace limits.
+func (w usageWarner) workspaceUsage(ctx context.Context, wid accountdomain.WorkspaceID) (u policy.Usage, err error) {
+	if u.ProjectCount, err = w.repos.Project.CountByWorkspace(ctx, wid); err != nil {
+		return
+	}
+	if u.PublishedProjectCount, err = w.repos.Project.CountPublicByWorkspace(ctx, wid); err != nil {
+		return
+	}
+	if u.AssetStorageSize, err = w.repos.Asset.TotalSizeByWorkspace(ctx, wid); err != nil {
+		return
+	}
+
+	scenes, err := w.repos.Scene.FindByWorkspace(ctx, wid)
+	if err != nil {
+		return
+	}
+	for _, s := range scenes {
+		layers, err := w.repos.Layer.CountByScene(ctx, s.ID())
+		if err != nil {
+			return u, err
+		}
+		u.LayerCount = max(u.LayerCount, layers)
+
+		schemas, err := w.repos.DatasetSchema.FindBySceneAll(ctx, s.ID())
+		if err != nil {
+			return u, err
+		}
+		u.DatasetSchemaCount = max(u.DatasetSchemaCount, len(schemas))
+
+		for _, ds := range schemas {
+			datasets, err := w.repos.Dataset.CountBySchema(ctx, ds.ID())
+			if err != nil {
+				return u, err
+			}
+			u.DatasetCount = max(u.DatasetCount, datasets)
+		}
+	}
+	return
+}
+
+func workspaceUsageWarningMail(name string, threshold float64, items []policy.UsageItem) (subject, text, htmlContent string) {
+	subject = fmt.Sprintf("[Re:Earth] Workspace \"%s\" has reached %g%% of its limits", name, threshold)
+
+	var t, h strings.Builder
+	fmt.Fprintf(&t, "The following usage of the workspace \"%s\" has reached %g%% of its limits.\n\n", name, threshold)
+	fmt.Fprintf(&h, "<p>The following usage of the workspace &quot;%s&quot; has reached %g%% of its limits.</p><ul>", html.EscapeString(name), threshold)
+	for _, item := range items {
+		line := fmt.Sprintf("%s: %d / %d (%.0f%%)", item.Name, item.Used, *item.Limit, *item.Percentage())
+		fmt.Fprintf(&t, "- %s\n", line)
+		fmt.Fprintf(&h, "<li>%s</li>", html.EscapeString(line))
+	}
+	h.WriteString("</ul>")
+	return subject, t.String(), h.String()
+}
+
+// commonPolicy finds the policy applied to a scene and counts what the policy limits.
+type commonPolicy struct {
+	sceneRepo        repo.Scene
+	workspaceRepo    accountrepo.Workspace
+	policyRepo       repo.Policy
+	storytellingRepo repo.Storytelling
+}
+
+// scenePolicy returns the policy applied to the workspace of the scene with the scene.
+// The policy is nil if no policy is applied, and enforcing a nil policy always succeeds.
+func (i commonPolicy) scenePolicy(ctx context.Context, sid id.SceneID, op *usecase.Operator) (*policy.Policy, *scene.Scene, error) {
+	s, err := i.sceneRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, nil, err
+	}
+	ws, err := i.workspaceRepo.FindByID(ctx, s.Workspace())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policyID := op.Policy(ws.Policy())
+	if policyID == nil || *policyID == "" {
+		return nil, s, nil
+	}
+	p, err := i.policyRepo.FindByID(ctx, *policyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, s, nil
+}
+
+// countStories returns the number of stories and the total number of their pages in the scene.
+func (i commonPolicy) countStories(ctx context.Context, sid id.SceneID) (stories, pages int, err error) {
+	sl, err := i.storytellingRepo.FindByScene(ctx, sid)
+	if err != nil || sl == nil {
+		return 0, 0, err
+	}
+	for _, s := range *sl {
+		if s == nil {
+			continue
+		}
+		stories++
+		pages += len(s.Pages().Pages())
+	}
+	return
+}
+
+// countPublishedStories returns the number of public or limited stories in the workspace.
+func (i commonPolicy) countPublishedStories(ctx context.Context, wid accountdomain.WorkspaceID) (n int, _ error) {
+	scenes, err := i.sceneRepo.FindByWorkspace(ctx, wid)
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range scenes {
+		sl, err := i.storytellingRepo.FindByScene(ctx, s.ID())
+		if err != nil {
+			return 0, err
+		}
+		if sl == nil {
+			continue
+		}
+		for _, st := range *sl {
+			if st != nil && isPublishedStory(st.PublishmentStatus()) {
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+// countPlugins returns the number of private plugins installed to the scenes in the workspace.
+func (i commonPolicy) countPlugins(ctx context.Context, wid accountdomain.WorkspaceID) (n int, _ error) {
+	scenes, err := i.sceneRepo.FindByWorkspace(ctx, wid)
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range scenes {
+		for _, p := range s.Plugins().Plugins() {
+			if p.Plugin().Scene() != nil {
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+func isPublishedStory(s storytelling.PublishmentStatus) bool {
+	return s == storytelling.PublishmentStatusPublic || s == storytelling.PublishmentStatusLimited
+}
+
+// sceneContents holds the entities which are saved to a new scene at once by duplicating or importing a project.
+type sceneContents struct {
+	layers         layer.List
+	nlsLayers      nlslayer.NLSLayerList
+	datasetSchemas dataset.SchemaList
+	datasets       dataset.List
+	stories        storytelling.StoryList
+}
+
+// enforceSceneContents checks the contents of a new scene against the limits of the policy.
+func enforceSceneContents(p *policy.Policy, c sceneContents) error {
+	if err := p.EnforceLayerCount(len(c.layers)); err != nil {
+		return err
+	}
+	if err := p.EnforceDatasetSchemaCount(len(c.datasetSchemas)); err != nil {
+		return err
+	}
+	datasets := map[id.DatasetSchemaID]int{}
+	for _, d := range c.datasets {
+		if d != nil {
+			datasets[d.Schema()]++
+		}
+	}
+	if err := p.EnforceDatasetCount(lo.Max(lo.Values(datasets))); err != nil {
+		return err
+	}
+
+	if err := p.EnforceNLSLayerCount(len(c.nlsLayers)); err != nil {
+		return err
+	}
+	features := 0
+	for _, l := range c.nlsLayers {
+		if l != nil {
+			features = max(features, countSketchFeatures(*l))
+		}
+	}
+	if err := p.EnforceSketchFeatureCount(features); err != nil {
+		return err
+	}
+
+	pages := 0
+	for _, s := range c.stories {
+		if s != nil {
+			pages += len(s.Pages().Pages())
+		}
+	}
+	if err := p.EnforceStoryCount(len(lo.Compact(c.stories))); err != nil {
+		return err
+	}
+	return p.EnforceStoryPageCount(pages)
+}
+
+func countSketchFeatures(l nlslayer.NLSLayer) int {
+	if l == nil || l.Sketch() == nil {
+		return 0
+	}
+	return len(l.Sketch().FeatureCollection().Features())
+}
+
 func workspaceMemberCountEnforcer(r *repo.Container) accountinteractor.WorkspaceMemberCountEnforcer {
 	return func(ctx context.Context, ws *workspace.Workspace, _ user.List, op *accountusecase.Operator) error {
 		policyID := op.Policy(ws.Policy())
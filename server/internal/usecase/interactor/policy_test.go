@@ -0,0 +1,322 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/revision"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain/user"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_FetchSceneUsage(t *testing.T) {
+	ctx := context.Background()
+
+	po := policy.New(policy.Option{
+		ID:                 policy.ID("policy"),
+		NLSLayerCount:      lo.ToPtr(2),
+		SketchFeatureCount: lo.ToPtr(1),
+		StoryCount:         lo.ToPtr(1),
+		StoryPageCount:     lo.ToPtr(1),
+	})
+	db := memory.New()
+	db.Policy = memory.NewPolicyWith(po)
+	ws := workspace.New().NewID().Policy(po.ID().Ref()).MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	s := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	_ = db.Scene.Save(ctx, s)
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{s.ID()},
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	il := NewNLSLayer(db, &gateway.Container{})
+	is := NewStorytelling(db, &gateway.Container{})
+
+	// NLS layers
+	addLayer := func() (*nlslayer.NLSLayerSimple, error) {
+		return il.AddLayerSimple(ctx, interfaces.AddNLSLayerSimpleInput{
+			SceneID:   s.ID(),
+			Title:     "layer",
+			LayerType: nlslayer.Simple,
+		}, op)
+	}
+	l, err := addLayer()
+	assert.NoError(t, err)
+	_, err = addLayer()
+	assert.NoError(t, err)
+	_, err = addLayer()
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	// sketch features
+	addFeature := func() error {
+		_, err := il.AddGeoJSONFeature(ctx, interfaces.AddNLSLayerGeoJSONFeatureParams{
+			LayerID:  l.ID(),
+			Type:     "Feature",
+			Geometry: map[string]any{"type": "Point", "coordinates": []any{1.0, 2.0}},
+		}, op)
+		return err
+	}
+	assert.NoError(t, addFeature())
+	assert.Same(t, policy.ErrPolicyViolation, addFeature())
+
+	// stories and pages
+	st, err := is.Create(ctx, interfaces.CreateStoryInput{SceneID: s.ID(), Title: "story"}, op)
+	assert.NoError(t, err)
+	_, err = is.Create(ctx, interfaces.CreateStoryInput{SceneID: s.ID(), Title: "story"}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	_, _, err = is.CreatePage(ctx, interfaces.CreatePageParam{SceneID: s.ID(), StoryID: st.Id()}, op)
+	assert.NoError(t, err)
+	_, _, err = is.CreatePage(ctx, interfaces.CreatePageParam{SceneID: s.ID(), StoryID: st.Id()}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	// usage
+	got, err := NewPolicy(db, &gateway.Container{}).FetchSceneUsage(ctx, s.ID(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, &interfaces.SceneUsage{
+		SceneID:            s.ID(),
+		Policy:             po,
+		NLSLayerCount:      2,
+		SketchFeatureCount: 1,
+		StoryCount:         1,
+		StoryPageCount:     1,
+	}, got)
+
+	// without policy
+	ws2 := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws2)
+	s2 := scene.New().NewID().Workspace(ws2.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	_ = db.Scene.Save(ctx, s2)
+	got, err = NewPolicy(db, &gateway.Container{}).FetchSceneUsage(ctx, s2.ID(), &usecase.Operator{ReadableScenes: []id.SceneID{s2.ID()}})
+	assert.NoError(t, err)
+	assert.Equal(t, &interfaces.SceneUsage{SceneID: s2.ID()}, got)
+
+	// not readable
+	_, err = NewPolicy(db, &gateway.Container{}).FetchSceneUsage(ctx, s.ID(), &usecase.Operator{})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+}
+
+func TestPolicy_WorkspaceUsage(t *testing.T) {
+	ctx := context.Background()
+
+	po := policy.New(policy.Option{
+		ID:               policy.ID("policy"),
+		ProjectCount:     lo.ToPtr(2),
+		LayerCount:       lo.ToPtr(10),
+		AssetStorageSize: lo.ToPtr(int64(100)),
+	})
+	db := memory.New()
+	db.Policy = memory.NewPolicyWith(po)
+	owner := user.New().NewID().Name("owner").Email("owner@example.com").MustBuild()
+	reader := user.New().NewID().Name("reader").Email("reader@example.com").MustBuild()
+	_ = db.User.Save(ctx, owner)
+	_ = db.User.Save(ctx, reader)
+	ws := workspace.New().NewID().Name("ws").Policy(po.ID().Ref()).Members(map[user.ID]workspace.Member{
+		owner.ID():  {Role: workspace.RoleOwner},
+		reader.ID(): {Role: workspace.RoleReader},
+	}).MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	_ = db.Project.Save(ctx, project.New().NewID().Workspace(ws.ID()).MustBuild())
+	_ = db.Project.Save(ctx, project.New().NewID().Workspace(ws.ID()).MustBuild())
+	_ = db.Asset.Save(ctx, asset.New().NewID().Workspace(ws.ID()).URL("https://example.com/a.png").Size(60).MustBuild())
+
+	m := mailer.NewMock()
+	uc := NewPolicy(db, &gateway.Container{Mailer: m})
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+
+	got, err := uc.FetchWorkspaceUsage(ctx, ws.ID(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, ws.ID(), got.WorkspaceID)
+	assert.Equal(t, po, got.Policy)
+	assert.Equal(t, []policy.UsageItem{
+		{Name: policy.UsageProjectCount, Used: 2, Limit: lo.ToPtr(int64(2))},
+		{Name: policy.UsagePublishedProjectCount},
+		{Name: policy.UsageLayerCount, Limit: lo.ToPtr(int64(10))},
+		{Name: policy.UsageAssetStorageSize, Used: 60, Limit: lo.ToPtr(int64(100))},
+		{Name: policy.UsageDatasetSchemaCount},
+		{Name: policy.UsageDatasetCount},
+	}, got.Items)
+
+	// nothing reaches the threshold
+	exceeded, err := uc.SendWorkspaceUsageWarning(ctx, ws.ID(), 101, op)
+	assert.NoError(t, err)
+	assert.Empty(t, exceeded)
+	assert.Empty(t, m.Mails())
+
+	exceeded, err = uc.SendWorkspaceUsageWarning(ctx, ws.ID(), 50, op)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{policy.UsageProjectCount, policy.UsageAssetStorageSize}, lo.Map(exceeded, func(i policy.UsageItem, _ int) string { return i.Name }))
+	mails := m.Mails()
+	assert.Len(t, mails, 1)
+	assert.Equal(t, []mailer.Contact{{Email: "owner@example.com", Name: "owner"}}, mails[0].To)
+	assert.Contains(t, mails[0].PlainContent, "- projectCount: 2 / 2 (100%)")
+	assert.Contains(t, mails[0].PlainContent, "- assetStorageSize: 60 / 100 (60%)")
+
+	// items are warned of only once for each threshold
+	exceeded, err = uc.SendWorkspaceUsageWarning(ctx, ws.ID(), 50, op)
+	assert.NoError(t, err)
+	assert.Empty(t, exceeded)
+	assert.Len(t, m.Mails(), 1)
+
+	exceeded, err = uc.SendWorkspaceUsageWarning(ctx, ws.ID(), 100, op)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{policy.UsageProjectCount}, lo.Map(exceeded, func(i policy.UsageItem, _ int) string { return i.Name }))
+	assert.Len(t, m.Mails(), 2)
+
+	_, err = uc.SendWorkspaceUsageWarning(ctx, ws.ID(), 0, op)
+	assert.Same(t, interfaces.ErrInvalidUsageThreshold, err)
+
+	_, err = uc.FetchWorkspaceUsage(ctx, ws.ID(), &usecase.Operator{AcOperator: &accountusecase.Operator{}})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+}
+
+func TestPolicy_UsageWarningOnEdit(t *testing.T) {
+	ctx := context.Background()
+
+	po := policy.New(policy.Option{
+		ID:           policy.ID("policy"),
+		ProjectCount: lo.ToPtr(5),
+	})
+	db := memory.New()
+	db.Policy = memory.NewPolicyWith(po)
+	owner := user.New().NewID().Name("owner").Email("owner@example.com").MustBuild()
+	_ = db.User.Save(ctx, owner)
+	ws := workspace.New().NewID().Name("ws").Policy(po.ID().Ref()).Members(map[user.ID]workspace.Member{
+		owner.ID(): {Role: workspace.RoleOwner},
+	}).MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+
+	m := mailer.NewMock()
+	uc := NewProject(db, &gateway.Container{Mailer: m})
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+	create := func() {
+		_, err := uc.Create(ctx, interfaces.CreateProjectParam{WorkspaceID: ws.ID()}, op)
+		assert.NoError(t, err)
+	}
+
+	for j := 0; j < 3; j++ {
+		create()
+	}
+	assert.Empty(t, m.Mails())
+
+	// 80%
+	create()
+	mails := m.Mails()
+	assert.Len(t, mails, 1)
+	assert.Equal(t, []mailer.Contact{{Email: "owner@example.com", Name: "owner"}}, mails[0].To)
+	assert.Contains(t, mails[0].Subject, "80%")
+	assert.Contains(t, mails[0].PlainContent, "- projectCount: 4 / 5 (80%)")
+
+	// 100%
+	create()
+	mails = m.Mails()
+	assert.Len(t, mails, 2)
+	assert.Contains(t, mails[1].Subject, "100%")
+
+	// the limit is enforced and nothing is warned of again
+	_, err := uc.Create(ctx, interfaces.CreateProjectParam{WorkspaceID: ws.ID()}, op)
+	assert.ErrorIs(t, err, policy.ErrPolicyViolation)
+	assert.Len(t, m.Mails(), 2)
+}
+
+func TestEnforceSceneContents(t *testing.T) {
+	sid := scene.NewID()
+	ds1 := dataset.NewSchema().NewID().Scene(sid).MustBuild()
+	ds2 := dataset.NewSchema().NewID().Scene(sid).MustBuild()
+	c := sceneContents{
+		layers:         layer.List{layer.NewGroup().NewID().Scene(sid).MustBuild().LayerRef()},
+		datasetSchemas: dataset.SchemaList{ds1, ds2},
+		datasets: dataset.List{
+			dataset.New().NewID().Scene(sid).Schema(ds1.ID()).MustBuild(),
+			dataset.New().NewID().Scene(sid).Schema(ds1.ID()).MustBuild(),
+			dataset.New().NewID().Scene(sid).Schema(ds2.ID()).MustBuild(),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		option *policy.Option
+		want   error
+	}{
+		{name: "no policy"},
+		{name: "within the limits", option: &policy.Option{LayerCount: lo.ToPtr(1), DatasetSchemaCount: lo.ToPtr(2), DatasetCount: lo.ToPtr(2)}},
+		{name: "layers", option: &policy.Option{LayerCount: lo.ToPtr(0)}, want: policy.ErrPolicyViolation},
+		{name: "dataset schemas", option: &policy.Option{DatasetSchemaCount: lo.ToPtr(1)}, want: policy.ErrPolicyViolation},
+		{name: "datasets of a schema", option: &policy.Option{DatasetCount: lo.ToPtr(1)}, want: policy.ErrPolicyViolation},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var p *policy.Policy
+			if tt.option != nil {
+				p = policy.New(*tt.option)
+			}
+			err := enforceSceneContents(p, c)
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Same(t, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestStorytelling_RollbackPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	po := policy.New(policy.Option{
+		ID:                  policy.ID("policy"),
+		PublishedStoryCount: lo.ToPtr(1),
+	})
+	db := memory.New()
+	db.Policy = memory.NewPolicyWith(po)
+	ws := workspace.New().NewID().Policy(po.ID().Ref()).MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	s := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	_ = db.Scene.Save(ctx, s)
+	published := storytelling.NewStory().NewID().Scene(s.ID()).Alias("published").Status(storytelling.PublishmentStatusPublic).MustBuild()
+	private := storytelling.NewStory().NewID().Scene(s.ID()).Alias("private").Status(storytelling.PublishmentStatusPrivate).MustBuild()
+	_ = db.Storytelling.Save(ctx, published)
+	_ = db.Storytelling.Save(ctx, private)
+	_ = db.Revision.Save(ctx, revision.New().NewID().Story(private.Id()).Scene(s.ID()).Workspace(ws.ID()).
+		Number(1).Alias("private").Status(string(storytelling.PublishmentStatusPublic)).MustBuild())
+
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{s.ID()},
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	// rolling back to a public revision publishes the story
+	_, err := NewStorytelling(db, &gateway.Container{}).Rollback(ctx, interfaces.RollbackStoryInput{ID: private.Id(), Revision: 1}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	got, _ := db.Storytelling.FindByID(ctx, private.Id())
+	assert.Equal(t, storytelling.PublishmentStatusPrivate, got.PublishmentStatus())
+}
@@ -1,63 +1,133 @@
 package interactor
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"io"
+	"io/fs"
+	"net/url"
 	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/policy"
 	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/revision"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/builder"
 	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/account/accountusecase/accountrepo"
+	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
 )
 
 type Project struct {
+	usageWarner
 	common
 	commonSceneLock
-	assetRepo         repo.Asset
-	projectRepo       repo.Project
-	userRepo          accountrepo.User
-	workspaceRepo     accountrepo.Workspace
-	sceneRepo         repo.Scene
-	propertyRepo      repo.Property
-	layerRepo         repo.Layer
-	datasetRepo       repo.Dataset
-	datasetSchemaRepo repo.DatasetSchema
-	tagRepo           repo.Tag
-	transaction       usecasex.Transaction
-	policyRepo        repo.Policy
-	file              gateway.File
-	nlsLayerRepo      repo.NLSLayer
-	layerStyles       repo.Style
+	commonAudit
+	assetRepo          repo.Asset
+	projectRepo        repo.Project
+	userRepo           accountrepo.User
+	workspaceRepo      accountrepo.Workspace
+	sceneRepo          repo.Scene
+	propertyRepo       repo.Property
+	layerRepo          repo.Layer
+	datasetRepo        repo.Dataset
+	datasetSchemaRepo  repo.DatasetSchema
+	datasetSyncLogRepo repo.DatasetSyncLog
+	historyRepo        repo.History
+	projectGrantRepo   repo.ProjectGrant
+	tagRepo            repo.Tag
+	transaction        usecasex.Transaction
+	policyRepo         repo.Policy
+	file               gateway.File
+	nlsLayerRepo       repo.NLSLayer
+	layerStyles        repo.Style
+	revisionRepo       repo.Revision
+	pluginRepo         repo.Plugin
+	storytellingRepo   repo.Storytelling
+	archive            gateway.ProjectArchive
+	previewURL         *url.URL
+	assetBaseURL       string
+	indexHTML          *util.Cache[string]
+	indexHTMLStr       string
+	indexURL           *url.URL
+	viewerFS           fs.FS
 }
 
+// ProjectConfig holds URLs and index HTML which the project usecase uses to issue previews and to export published scenes.
+type ProjectConfig struct {
+	PreviewURL         *url.URL
+	AssetBaseURL       string
+	PublishedIndexHTML string
+	PublishedIndexURL  *url.URL
+	// PublishedViewerFS holds files of the viewer which PublishedIndexHTML refers to. It is not used with PublishedIndexURL.
+	PublishedViewerFS fs.FS
+}
+
+const (
+	defaultPreviewTTL = 24 * time.Hour
+	maxPreviewTTL     = 7 * 24 * time.Hour
+)
+
 func NewProject(r *repo.Container, gr *gateway.Container) interfaces.Project {
+	return newProject(r, gr)
+}
+
+// NewProjectWithConfig returns a project usecase that issues preview URLs under PreviewURL
+// and exports published scenes with index HTML of the config.
+func NewProjectWithConfig(r *repo.Container, gr *gateway.Container, c ProjectConfig) interfaces.Project {
+	i := newProject(r, gr)
+	i.previewURL = c.PreviewURL
+	i.assetBaseURL = c.AssetBaseURL
+	if c.PublishedIndexURL != nil && c.PublishedIndexURL.String() != "" {
+		i.indexHTML = newIndexHTMLCache(c.PublishedIndexURL)
+		i.indexURL = c.PublishedIndexURL
+	} else {
+		i.indexHTMLStr = c.PublishedIndexHTML
+		i.viewerFS = c.PublishedViewerFS
+	}
+	return i
+}
+
+func newProject(r *repo.Container, gr *gateway.Container) *Project {
 	return &Project{
-		commonSceneLock:   commonSceneLock{sceneLockRepo: r.SceneLock},
-		assetRepo:         r.Asset,
-		projectRepo:       r.Project,
-		userRepo:          r.User,
-		workspaceRepo:     r.Workspace,
-		sceneRepo:         r.Scene,
-		propertyRepo:      r.Property,
-		layerRepo:         r.Layer,
-		datasetRepo:       r.Dataset,
-		datasetSchemaRepo: r.DatasetSchema,
-		tagRepo:           r.Tag,
-		transaction:       r.Transaction,
-		policyRepo:        r.Policy,
-		file:              gr.File,
-		nlsLayerRepo:      r.NLSLayer,
-		layerStyles:       r.Style,
+		usageWarner:        newUsageWarner(r, gr),
+		commonSceneLock:    commonSceneLock{sceneLockRepo: r.SceneLock, lockPubSub: gr.PubSub},
+		assetRepo:          r.Asset,
+		projectRepo:        r.Project,
+		userRepo:           r.User,
+		workspaceRepo:      r.Workspace,
+		sceneRepo:          r.Scene,
+		propertyRepo:       r.Property,
+		layerRepo:          r.Layer,
+		datasetRepo:        r.Dataset,
+		datasetSchemaRepo:  r.DatasetSchema,
+		datasetSyncLogRepo: r.DatasetSyncLog,
+		historyRepo:        r.History,
+		projectGrantRepo:   r.ProjectGrant,
+		tagRepo:            r.Tag,
+		transaction:        r.Transaction,
+		policyRepo:         r.Policy,
+		file:               gr.File,
+		nlsLayerRepo:       r.NLSLayer,
+		layerStyles:        r.Style,
+		revisionRepo:       r.Revision,
+		pluginRepo:         r.Plugin,
+		storytellingRepo:   r.Storytelling,
+		archive:            gr.ProjectArchive,
+		commonAudit:        newCommonAudit(r, gr),
 	}
 }
 
@@ -111,32 +181,33 @@ func (i *Project) Create(ctx context.Context, p interfaces.CreateProjectParam, o
 		}
 	}()
 
-	ws, err := i.workspaceRepo.FindByID(ctx, p.WorkspaceID)
+	pol, err := i.workspacePolicy(ctx, p.WorkspaceID, operator)
 	if err != nil {
 		return nil, err
 	}
+	if err := i.enforceProjectCount(ctx, pol, p.WorkspaceID); err != nil {
+		return nil, err
+	}
 
-	// enforce policy
-	if policyID := operator.Policy(ws.Policy()); policyID != nil {
-		p, err := i.policyRepo.FindByID(ctx, *policyID)
-		if err != nil {
-			return nil, err
-		}
+	pb := project.New().
+		NewID().
+		Workspace(p.WorkspaceID).
+		Visualizer(p.Visualizer)
 
-		projectCount, err := i.projectRepo.CountByWorkspace(ctx, ws.ID())
-		if err != nil {
+	var tmpl *project.Project
+	if p.TemplateID != nil {
+		if tmpl, err = i.projectRepo.FindByID(ctx, *p.TemplateID); err != nil {
 			return nil, err
 		}
-
-		if err := p.EnforceProjectCount(projectCount + 1); err != nil {
+		if err := i.CanReadWorkspace(tmpl.Workspace(), operator); err != nil {
 			return nil, err
 		}
+		if !tmpl.IsTemplate() {
+			return nil, interfaces.ErrProjectNotTemplate
+		}
+		pb = pb.Name(tmpl.Name()).Description(tmpl.Description()).ImageURL(tmpl.ImageURL())
 	}
 
-	pb := project.New().
-		NewID().
-		Workspace(p.WorkspaceID).
-		Visualizer(p.Visualizer)
 	if p.Name != nil {
 		pb = pb.Name(*p.Name)
 	}
@@ -161,15 +232,57 @@ func (i *Project) Create(ctx context.Context, p interfaces.CreateProjectParam, o
 		return nil, err
 	}
 
+	if tmpl != nil {
+		if err := i.duplicateScene(ctx, tmpl, proj, pol); err != nil {
+			return nil, err
+		}
+	}
+
 	err = i.projectRepo.Save(ctx, proj)
 	if err != nil {
 		return nil, err
 	}
 
+	targets := []auditlog.Target{auditlog.TargetOf(proj.ID())}
+	if tmpl != nil {
+		targets = append(targets, auditlog.TargetOf(tmpl.ID()))
+	}
+	if err := i.audit(ctx, operator, auditlog.ActionProjectCreate, proj.Workspace(), nil, targets); err != nil {
+		return nil, err
+	}
+	i.warnUsage(ctx, proj.Workspace(), pol)
 	tx.Commit()
 	return proj, nil
 }
 
+// workspacePolicy returns the policy applied to the workspace, or nil if no policy is applied.
+func (i *Project) workspacePolicy(ctx context.Context, wid accountdomain.WorkspaceID, operator *usecase.Operator) (*policy.Policy, error) {
+	ws, err := i.workspaceRepo.FindByID(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+
+	policyID := operator.Policy(ws.Policy())
+	if policyID == nil {
+		return nil, nil
+	}
+	return i.policyRepo.FindByID(ctx, *policyID)
+}
+
+// enforceProjectCount checks that one more project can be created in the workspace under its policy.
+func (i *Project) enforceProjectCount(ctx context.Context, p *policy.Policy, wid accountdomain.WorkspaceID) error {
+	if p == nil {
+		return nil
+	}
+
+	projectCount, err := i.projectRepo.CountByWorkspace(ctx, wid)
+	if err != nil {
+		return err
+	}
+
+	return p.EnforceProjectCount(projectCount + 1)
+}
+
 func (i *Project) Update(ctx context.Context, p interfaces.UpdateProjectParam, operator *usecase.Operator) (_ *project.Project, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -192,6 +305,7 @@ func (i *Project) Update(ctx context.Context, p interfaces.UpdateProjectParam, o
 	}
 
 	oldAlias := prj.Alias()
+	before := *prj
 
 	if p.Name != nil {
 		prj.UpdateName(*p.Name)
@@ -221,12 +335,8 @@ func (i *Project) Update(ctx context.Context, p interfaces.UpdateProjectParam, o
 		prj.SetIsBasicAuthActive(*p.IsBasicAuthActive)
 	}
 
-	if p.BasicAuthUsername != nil {
-		prj.SetBasicAuthUsername(*p.BasicAuthUsername)
-	}
-
-	if p.BasicAuthPassword != nil {
-		prj.SetBasicAuthPassword(*p.BasicAuthPassword)
+	if err := prj.SetBasicAuth(p.BasicAuthUsername, p.BasicAuthPassword); err != nil {
+		return nil, err
 	}
 
 	if p.PublicTitle != nil {
@@ -259,6 +369,10 @@ func (i *Project) Update(ctx context.Context, p interfaces.UpdateProjectParam, o
 		prj.UpdateSceneID(*p.SceneID)
 	}
 
+	if p.IsTemplate != nil {
+		prj.SetTemplate(*p.IsTemplate)
+	}
+
 	if p.PublicDescription != nil {
 		prj.UpdatePublicDescription(*p.PublicDescription)
 	}
@@ -276,6 +390,106 @@ func (i *Project) Update(ctx context.Context, p interfaces.UpdateProjectParam, o
 		return nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionProjectUpdate, prj.Workspace(), nil, []auditlog.Target{auditlog.TargetOf(prj.ID())}, projectChanges(&before, prj)...); err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return prj, nil
+}
+
+// projectChanges returns the changes of the fields of the project which can be updated.
+func projectChanges(before, after *project.Project) []*auditlog.Change {
+	return []*auditlog.Change{
+		auditlog.NewChange("name", before.Name(), after.Name()),
+		auditlog.NewChange("description", before.Description(), after.Description()),
+		auditlog.NewChange("alias", before.Alias(), after.Alias()),
+		auditlog.NewChange("archived", before.IsArchived(), after.IsArchived()),
+		auditlog.NewChange("isBasicAuthActive", before.IsBasicAuthActive(), after.IsBasicAuthActive()),
+		auditlog.NewChange("publicTitle", before.PublicTitle(), after.PublicTitle()),
+		auditlog.NewChange("publicDescription", before.PublicDescription(), after.PublicDescription()),
+		auditlog.NewChange("publicImage", before.PublicImage(), after.PublicImage()),
+		auditlog.NewChange("publicNoIndex", before.PublicNoIndex(), after.PublicNoIndex()),
+		auditlog.NewChange("isTemplate", before.IsTemplate(), after.IsTemplate()),
+	}
+}
+
+func (i *Project) AddBasicAuthCredential(ctx context.Context, params interfaces.AddProjectBasicAuthCredentialParam, operator *usecase.Operator) (_ *project.Project, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	prj, err := i.projectRepo.FindByID(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	c, err := basicauth.NewCredential(params.Name, params.Username, params.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := prj.AddBasicAuthCredential(c); err != nil {
+		return nil, err
+	}
+
+	if err := i.projectRepo.Save(ctx, prj); err != nil {
+		return nil, err
+	}
+
+	if err := i.audit(ctx, operator, auditlog.ActionProjectAddBasicAuthCredential, prj.Workspace(), nil, []auditlog.Target{auditlog.TargetOf(prj.ID())},
+		auditlog.NewChange("basicAuthCredential", nil, c.Name()),
+	); err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return prj, nil
+}
+
+func (i *Project) RevokeBasicAuthCredential(ctx context.Context, params interfaces.RevokeProjectBasicAuthCredentialParam, operator *usecase.Operator) (_ *project.Project, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	prj, err := i.projectRepo.FindByID(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	if err := prj.RevokeBasicAuthCredential(params.Name); err != nil {
+		return nil, err
+	}
+
+	if err := i.projectRepo.Save(ctx, prj); err != nil {
+		return nil, err
+	}
+
+	if err := i.audit(ctx, operator, auditlog.ActionProjectRevokeBasicAuthCredential, prj.Workspace(), nil, []auditlog.Target{auditlog.TargetOf(prj.ID())},
+		auditlog.NewChange("basicAuthCredential", params.Name, nil),
+	); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return prj, nil
 }
@@ -310,42 +524,15 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 	if err != nil {
 		return nil, err
 	}
-	coreSupport := prj.CoreSupport()
-	enableGa := prj.EnableGA()
-	trackingId := prj.TrackingID()
 	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
 		return nil, err
 	}
 
-	ws, err := i.workspaceRepo.FindByID(ctx, prj.Workspace())
+	pol, err := i.enforcePublishedProjectCount(ctx, prj, params.Status, operator)
 	if err != nil {
 		return nil, err
 	}
 
-	// enforce policy
-	if params.Status != project.PublishmentStatusPrivate {
-		if policyID := operator.Policy(ws.Policy()); policyID != nil {
-			p, err := i.policyRepo.FindByID(ctx, *policyID)
-			if err != nil {
-				return nil, err
-			}
-
-			projectCount, err := i.projectRepo.CountPublicByWorkspace(ctx, ws.ID())
-			if err != nil {
-				return nil, err
-			}
-
-			// newrly published
-			if prj.PublishmentStatus() == project.PublishmentStatusPrivate {
-				projectCount += 1
-			}
-
-			if err := p.EnforcePublishedProjectCount(projectCount); err != nil {
-				return nil, err
-			}
-		}
-	}
-
 	s, err := i.sceneRepo.FindByProject(ctx, params.ID)
 	if err != nil {
 		return nil, err
@@ -369,30 +556,24 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 
 	newAlias := prevAlias
 	if params.Alias != nil {
-		if prj2, err := i.projectRepo.FindByPublicName(ctx, *params.Alias); err != nil && !errors.Is(rerror.ErrNotFound, err) {
+		newAlias = *params.Alias
+	}
+
+	// the alias kept by the project may have been taken by another project while this one was private
+	if newAlias != "" && newAlias != prevPublishedAlias {
+		if err := i.checkAliasOwner(ctx, prj, newAlias); err != nil {
 			return nil, err
-		} else if prj2 != nil && prj.ID() != prj2.ID() {
-			return nil, interfaces.ErrProjectAliasAlreadyUsed
 		}
+	}
 
+	if params.Alias != nil {
 		if err := prj.UpdateAlias(*params.Alias); err != nil {
 			return nil, err
 		}
-		newAlias = *params.Alias
 	}
 
 	newPublishedAlias := newAlias
 
-	nlsLayers, err := i.nlsLayerRepo.FindByScene(ctx, sceneID)
-	if err != nil {
-		return nil, err
-	}
-
-	layerStyles, err := i.layerStyles.FindByScene(ctx, sceneID)
-	if err != nil {
-		return nil, err
-	}
-
 	// Lock
 	if err := i.UpdateSceneLock(ctx, sceneID, scene.LockModeFree, scene.LockModePublishing); err != nil {
 		return nil, err
@@ -400,6 +581,8 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 
 	defer i.ReleaseSceneLock(ctx, sceneID)
 
+	publishedAt := time.Now()
+
 	if params.Status == project.PublishmentStatusPrivate {
 		// unpublish
 		if err = i.file.RemoveBuiltScene(ctx, prevPublishedAlias); err != nil {
@@ -407,29 +590,43 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 		}
 	} else {
 		// publish
-		r, w := io.Pipe()
+		revisions, err := i.revisionRepo.FindByProject(ctx, prj.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		rev, err := revision.New().
+			NewID().
+			Project(prj.ID()).
+			Scene(sceneID).
+			Workspace(prj.Workspace()).
+			Number(revisions.NextNumber()).
+			Alias(newPublishedAlias).
+			Status(string(params.Status)).
+			SchemaVersion(builder.SchemaVersion).
+			PublishedBy(operator.UserID()).
+			PublishedAt(publishedAt).
+			Build()
+		if err != nil {
+			return nil, err
+		}
 
 		// Build
-		scenes := []id.SceneID{sceneID}
-		go func() {
-			var err error
-
-			defer func() {
-				_ = w.CloseWithError(err)
-			}()
-
-			err = builder.New(
-				repo.LayerLoaderFrom(i.layerRepo),
-				repo.PropertyLoaderFrom(i.propertyRepo),
-				repo.DatasetGraphLoaderFrom(i.datasetRepo),
-				repo.TagLoaderFrom(i.tagRepo),
-				repo.TagSceneLoaderFrom(i.tagRepo, scenes),
-				repo.NLSLayerLoaderFrom(i.nlsLayerRepo),
-			).ForScene(s).WithNLSLayers(&nlsLayers).WithLayerStyle(layerStyles).Build(ctx, w, time.Now(), coreSupport, enableGa, trackingId)
-		}()
-
-		// Save
-		if err := i.file.UploadBuiltScene(ctx, r, newPublishedAlias); err != nil {
+		r, err := i.buildScene(ctx, prj, s, publishedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		// Save the build as a new revision and point the alias to it
+		if err := i.file.UploadRevision(ctx, r, rev.FileName()); err != nil {
+			return nil, err
+		}
+
+		if err := i.uploadRevision(ctx, rev, newPublishedAlias); err != nil {
+			return nil, err
+		}
+
+		if err := i.revisionRepo.Save(ctx, rev); err != nil {
 			return nil, err
 		}
 
@@ -442,25 +639,535 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 		}
 	}
 
-	prj.UpdatePublishmentStatus(params.Status)
-	prj.SetPublishedAt(time.Now())
+	prevStatus := prj.PublishmentStatus()
+	updatePublishment(prj, params.Status, publishedAt)
 
-	switch params.Status {
-	case project.PublishmentStatusLimited:
-		prj.UpdatePublicNoIndex(true)
-	case project.PublishmentStatusPublic:
-		prj.UpdatePublicNoIndex(false)
-	case project.PublishmentStatusPrivate:
+	if err := i.projectRepo.Save(ctx, prj); err != nil {
+		return nil, err
+	}
+
+	action := auditlog.ActionProjectPublish
+	if params.Status == project.PublishmentStatusPrivate {
+		action = auditlog.ActionProjectUnpublish
+	}
+	if err := i.audit(ctx, operator, action, prj.Workspace(), &sceneID, []auditlog.Target{auditlog.TargetOf(prj.ID())},
+		auditlog.NewChange("publishmentStatus", string(prevStatus), string(prj.PublishmentStatus())),
+		auditlog.NewChange("alias", prevAlias, prj.Alias()),
+	); err != nil {
+		return nil, err
+	}
+	i.warnUsage(ctx, prj.Workspace(), pol)
+	tx.Commit()
+	return prj, nil
+}
+
+func (i *Project) SchedulePublish(ctx context.Context, params interfaces.SchedulePublishProjectParam, operator *usecase.Operator) (_ *project.Project, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
 	}
 
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	prj, err := i.projectRepo.FindByID(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	var schedule *project.PublishSchedule
+	if params.PublishAt != nil || params.UnpublishAt != nil {
+		if params.PublishAt != nil && prj.Alias() == "" {
+			return nil, interfaces.ErrProjectAliasIsNotSet
+		}
+
+		schedule, err = project.NewPublishSchedule(params.Status, params.PublishAt, params.UnpublishAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	before := prj.PublishSchedule()
+	prj.SetPublishSchedule(schedule)
+
 	if err := i.projectRepo.Save(ctx, prj); err != nil {
 		return nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionProjectSchedulePublish, prj.Workspace(), nil, []auditlog.Target{auditlog.TargetOf(prj.ID())},
+		auditlog.NewChange("publishAt", before.PublishAt(), schedule.PublishAt()),
+		auditlog.NewChange("unpublishAt", before.UnpublishAt(), schedule.UnpublishAt()),
+	); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return prj, nil
 }
 
+// PublishScheduled publishes or unpublishes projects whose publish schedule is due at now.
+// A fired schedule is advanced even if publishing fails so that it will not be retried forever,
+// except when the scene is locked, in which case it is retried on the next run.
+func (i *Project) PublishScheduled(ctx context.Context, now time.Time) error {
+	projects, err := i.projectRepo.FindByPublishScheduleDue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, prj := range projects {
+		status, ok := prj.PublishSchedule().Due(now)
+		if !ok {
+			continue
+		}
+
+		if _, err := i.Publish(ctx, interfaces.PublishProjectParam{
+			ID:     prj.ID(),
+			Status: status,
+		}, scheduledPublishOperator(prj.Workspace())); err != nil {
+			if isSceneLocked(err) {
+				continue
+			}
+			log.Errorfc(ctx, "publish schedule: failed to publish project (%s): %s", prj.ID(), err)
+		}
+
+		if err := i.advancePublishSchedule(ctx, prj.ID(), now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// republish publishes the project again with its current status to reflect changes of the scene such as refreshed datasets.
+// Private projects are left as they are.
+func (i *Project) republish(ctx context.Context, pid id.ProjectID) error {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return err
+	}
+	if prj.PublishmentStatus() == project.PublishmentStatusPrivate {
+		return nil
+	}
+
+	_, err = i.Publish(ctx, interfaces.PublishProjectParam{
+		ID:     pid,
+		Status: prj.PublishmentStatus(),
+	}, scheduledPublishOperator(prj.Workspace()))
+	return err
+}
+
+func (i *Project) advancePublishSchedule(ctx context.Context, pid id.ProjectID, now time.Time) error {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return err
+	}
+
+	prj.SetPublishSchedule(prj.PublishSchedule().Advance(now))
+	return i.projectRepo.Save(ctx, prj)
+}
+
+func (i *Project) FindRevisions(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (revision.List, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	return i.revisionRepo.FindByProject(ctx, pid)
+}
+
+func (i *Project) Rollback(ctx context.Context, params interfaces.RollbackProjectParam, operator *usecase.Operator) (_ *project.Project, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	prj, err := i.projectRepo.FindByID(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	alias := prj.Alias()
+	if alias == "" {
+		return nil, interfaces.ErrProjectAliasIsNotSet
+	}
+	// the revision is uploaded to the alias, which may have been taken by another project while this one was private
+	if err := i.checkAliasOwner(ctx, prj, alias); err != nil {
+		return nil, err
+	}
+
+	revisions, err := i.revisionRepo.FindByProject(ctx, prj.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	rev := revisions.FindByNumber(params.Revision)
+	if rev == nil {
+		return nil, rerror.ErrNotFound
+	}
+
+	status := project.PublishmentStatus(rev.Status())
+	pol, err := i.enforcePublishedProjectCount(ctx, prj, status, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	sceneID := rev.Scene()
+	if err := i.UpdateSceneLock(ctx, sceneID, scene.LockModeFree, scene.LockModePublishing); err != nil {
+		return nil, err
+	}
+
+	defer i.ReleaseSceneLock(ctx, sceneID)
+
+	if err := i.uploadRevision(ctx, rev, alias); err != nil {
+		return nil, err
+	}
+
+	prevStatus := prj.PublishmentStatus()
+	updatePublishment(prj, status, rev.PublishedAt())
+
+	if err := i.projectRepo.Save(ctx, prj); err != nil {
+		return nil, err
+	}
+
+	if err := i.audit(ctx, operator, auditlog.ActionProjectRollback, prj.Workspace(), &sceneID, []auditlog.Target{auditlog.TargetOf(prj.ID()), auditlog.TargetOf(rev.ID())},
+		auditlog.NewChange("publishmentStatus", string(prevStatus), string(prj.PublishmentStatus())),
+	); err != nil {
+		return nil, err
+	}
+	i.warnUsage(ctx, prj.Workspace(), pol)
+	tx.Commit()
+	return prj, nil
+}
+
+// checkAliasOwner returns ErrProjectAliasAlreadyUsed if another project is published with the alias.
+func (i *Project) checkAliasOwner(ctx context.Context, prj *project.Project, alias string) error {
+	prj2, err := i.projectRepo.FindByPublicName(ctx, alias)
+	if err != nil && !errors.Is(rerror.ErrNotFound, err) {
+		return err
+	}
+	if prj2 != nil && prj.ID() != prj2.ID() {
+		return interfaces.ErrProjectAliasAlreadyUsed
+	}
+	return nil
+}
+
+// enforcePublishedProjectCount checks that the project can be published with the status under the policy of its workspace.
+// It returns the policy, or nil if the project is not published or no policy is applied.
+func (i *Project) enforcePublishedProjectCount(ctx context.Context, prj *project.Project, status project.PublishmentStatus, operator *usecase.Operator) (*policy.Policy, error) {
+	if status == project.PublishmentStatusPrivate {
+		return nil, nil
+	}
+
+	ws, err := i.workspaceRepo.FindByID(ctx, prj.Workspace())
+	if err != nil {
+		return nil, err
+	}
+
+	policyID := operator.Policy(ws.Policy())
+	if policyID == nil {
+		return nil, nil
+	}
+
+	p, err := i.policyRepo.FindByID(ctx, *policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectCount, err := i.projectRepo.CountPublicByWorkspace(ctx, ws.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	// newly published
+	if prj.PublishmentStatus() == project.PublishmentStatusPrivate {
+		projectCount += 1
+	}
+
+	return p, p.EnforcePublishedProjectCount(projectCount)
+}
+
+func (i *Project) Preview(ctx context.Context, params interfaces.PreviewProjectParam, operator *usecase.Operator) (_ *project.Project, _ *url.URL, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	prj, err := i.projectRepo.FindByID(ctx, params.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, nil, err
+	}
+
+	now := util.Now()
+	ttl := defaultPreviewTTL
+	if params.ExpiresAt != nil {
+		ttl = params.ExpiresAt.Sub(now)
+	}
+	if ttl <= 0 || ttl > maxPreviewTTL {
+		return nil, nil, interfaces.ErrInvalidPreviewExpiry
+	}
+
+	s, err := i.sceneRepo.FindByProject(ctx, params.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := i.CheckSceneLock(ctx, s.ID()); err != nil {
+		return nil, nil, err
+	}
+
+	preview, err := project.NewPreview(now, ttl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := i.buildScene(ctx, prj, s, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := i.file.UploadPreview(ctx, r, preview.Token()); err != nil {
+		return nil, nil, err
+	}
+
+	// Only the latest preview of a project is kept
+	if old := prj.Preview(); old != nil {
+		if err := i.file.RemovePreview(ctx, old.Token()); err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			log.Errorfc(ctx, "preview: failed to remove old preview of project (%s): %s", prj.ID(), err)
+		}
+	}
+
+	prj.SetPreview(preview)
+
+	if err := i.projectRepo.Save(ctx, prj); err != nil {
+		return nil, nil, err
+	}
+
+	if err := i.audit(ctx, operator, auditlog.ActionProjectPreview, prj.Workspace(), lo.ToPtr(s.ID()), []auditlog.Target{auditlog.TargetOf(prj.ID())}); err != nil {
+		return nil, nil, err
+	}
+	tx.Commit()
+	return prj, i.previewURLOf(preview), nil
+}
+
+// RemoveExpiredPreviews removes previews of projects which have been expired at now.
+func (i *Project) RemoveExpiredPreviews(ctx context.Context, now time.Time) error {
+	projects, err := i.projectRepo.FindByPreviewExpired(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, prj := range projects {
+		if err := i.file.RemovePreview(ctx, prj.Preview().Token()); err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			log.Errorfc(ctx, "preview: failed to remove expired preview of project (%s): %s", prj.ID(), err)
+			continue
+		}
+
+		prj.SetPreview(nil)
+		if err := i.projectRepo.Save(ctx, prj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveUnusedChunks removes chunks of built scenes which are referred by none of the built scenes, revisions and previews.
+// Chunks uploaded within chunkRemovalGrace before now are kept, as builds in progress may refer to them.
+func (i *Project) RemoveUnusedChunks(ctx context.Context, now time.Time) error {
+	used := map[string]struct{}{}
+	if err := i.file.WalkBuiltScenes(ctx, func(r io.Reader) error {
+		m, ok, err := readManifest(bufio.NewReader(r))
+		if err != nil {
+			return err
+		}
+		if ok {
+			for _, h := range m.Hashes() {
+				used[h] = struct{}{}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var unused []string
+	if err := i.file.WalkBuiltSceneChunks(ctx, func(hash string, updatedAt time.Time) error {
+		if _, ok := used[hash]; !ok && now.Sub(updatedAt) >= chunkRemovalGrace {
+			unused = append(unused, hash)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, h := range unused {
+		if err := i.file.RemoveBuiltSceneChunk(ctx, h); err != nil {
+			return err
+		}
+	}
+	if len(unused) > 0 {
+		log.Infofc(ctx, "chunk: removed %d unused chunks", len(unused))
+	}
+	return nil
+}
+
+func (i *Project) previewURLOf(p *project.Preview) *url.URL {
+	base := i.previewURL
+	if base == nil {
+		base = &url.URL{Path: "/preview/"}
+	}
+	return base.JoinPath(p.Token(), "/")
+}
+
+const (
+	// chunkReuseLimit is how long a stored chunk is reused by builds without uploading it again.
+	// Uploading refreshes the updated time of the chunk, so that it is not removed while the manifest is being stored.
+	chunkReuseLimit = 24 * time.Hour
+	// chunkRemovalGrace is how long a chunk is kept after it was uploaded even if no manifest refers to it.
+	// It must be longer than chunkReuseLimit, as builds may still be storing manifests that refer to reused chunks.
+	chunkRemovalGrace = 2 * chunkReuseLimit
+)
+
+// buildScene builds the scene of the project as content-addressed chunks and returns a reader of the manifest.
+// Chunks that have been recently stored by previous builds are reused without uploading them again,
+// and NLS layers which have not been changed since the latest revision are not built again.
+func (i *Project) buildScene(ctx context.Context, prj *project.Project, s *scene.Scene, builtAt time.Time) (io.Reader, error) {
+	nlsLayers, err := i.nlsLayerRepo.FindByScene(ctx, s.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	layerStyles, err := i.layerStyles.FindByScene(ctx, s.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	scenes := []id.SceneID{s.ID()}
+	m, err := builder.New(
+		repo.LayerLoaderFrom(i.layerRepo),
+		repo.PropertyLoaderFrom(i.propertyRepo),
+		repo.DatasetGraphLoaderFrom(i.datasetRepo),
+		repo.TagLoaderFrom(i.tagRepo),
+		repo.TagSceneLoaderFrom(i.tagRepo, scenes),
+		repo.NLSLayerLoaderFrom(i.nlsLayerRepo),
+	).ForScene(s).WithNLSLayers(&nlsLayers).WithLayerStyle(layerStyles).
+		WithPreviousBuild(i.latestManifest(ctx, prj.ID())).
+		BuildChunks(ctx, builtAt, prj.CoreSupport(), prj.EnableGA(), prj.TrackingID(), builtSceneChunks{file: i.file, builtAt: builtAt})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := m.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(b), nil
+}
+
+// latestManifest returns the manifest of the latest revision of the project, or nil if it can not be read,
+// as it is only used to find chunks that do not have to be built again.
+func (i *Project) latestManifest(ctx context.Context, pid id.ProjectID) *builder.Manifest {
+	revisions, err := i.revisionRepo.FindByProject(ctx, pid)
+	if err != nil {
+		return nil
+	}
+	rev := revisions.Latest()
+	if rev == nil {
+		return nil
+	}
+
+	r, err := i.file.ReadRevisionFile(ctx, rev.FileName())
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil
+	}
+	m, _ := builder.ParseManifest(data)
+	return m
+}
+
+// builtSceneChunks stores chunks of built scenes in the file storage.
+type builtSceneChunks struct {
+	file    gateway.File
+	builtAt time.Time
+}
+
+func (c builtSceneChunks) Reusable(ctx context.Context, hash string) (bool, error) {
+	updatedAt, err := c.file.BuiltSceneChunkUpdatedAt(ctx, hash)
+	if errors.Is(err, rerror.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return c.builtAt.Sub(updatedAt) < chunkReuseLimit, nil
+}
+
+func (c builtSceneChunks) Put(ctx context.Context, chunk builder.Chunk) error {
+	return c.file.UploadBuiltSceneChunk(ctx, bytes.NewReader(chunk.Data), chunk.Hash)
+}
+
+// uploadRevision points the alias to the built data of the revision without rebuilding the scene.
+func (i *Project) uploadRevision(ctx context.Context, rev *revision.Revision, alias string) error {
+	r, err := i.file.ReadRevisionFile(ctx, rev.FileName())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	return i.file.UploadBuiltScene(ctx, r, alias)
+}
+
+func updatePublishment(prj *project.Project, status project.PublishmentStatus, publishedAt time.Time) {
+	prj.UpdatePublishmentStatus(status)
+	prj.SetPublishedAt(publishedAt)
+
+	switch status {
+	case project.PublishmentStatusLimited:
+		prj.UpdatePublicNoIndex(true)
+	case project.PublishmentStatusPublic:
+		prj.UpdatePublicNoIndex(false)
+	case project.PublishmentStatusPrivate:
+	}
+}
+
 func (i *Project) Delete(ctx context.Context, projectID id.ProjectID, operator *usecase.Operator) (err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -484,20 +1191,27 @@ func (i *Project) Delete(ctx context.Context, projectID id.ProjectID, operator *
 
 	deleter := ProjectDeleter{
 		SceneDeleter: SceneDeleter{
-			Scene:         i.sceneRepo,
-			SceneLock:     i.sceneLockRepo,
-			Layer:         i.layerRepo,
-			Property:      i.propertyRepo,
-			Dataset:       i.datasetRepo,
-			DatasetSchema: i.datasetSchemaRepo,
+			Scene:          i.sceneRepo,
+			SceneLock:      i.sceneLockRepo,
+			Layer:          i.layerRepo,
+			Property:       i.propertyRepo,
+			Dataset:        i.datasetRepo,
+			DatasetSchema:  i.datasetSchemaRepo,
+			DatasetSyncLog: i.datasetSyncLogRepo,
+			History:        i.historyRepo,
 		},
-		File:    i.file,
-		Project: i.projectRepo,
+		File:         i.file,
+		Project:      i.projectRepo,
+		ProjectGrant: i.projectGrantRepo,
+		Revision:     i.revisionRepo,
 	}
 	if err := deleter.Delete(ctx, prj, true, operator); err != nil {
 		return err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionProjectDelete, prj.Workspace(), nil, []auditlog.Target{auditlog.TargetOf(prj.ID())}); err != nil {
+		return err
+	}
 	tx.Commit()
 	return nil
 }
@@ -0,0 +1,189 @@
+package interactor
+
+import (
+	"context"
+	"io"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/samber/lo"
+)
+
+// Export writes the whole project into a portable archive, which can be imported into another workspace or server.
+func (i *Project) Export(ctx context.Context, pid id.ProjectID, op *usecase.Operator) (io.Reader, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadWorkspace(prj.Workspace(), op); err != nil {
+		return nil, err
+	}
+
+	d, err := i.archiveData(ctx, prj)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(i.archive.Write(ctx, pw, d))
+	}()
+	return pr, nil
+}
+
+func (i *Project) archiveData(ctx context.Context, prj *project.Project) (*gateway.ProjectArchiveData, error) {
+	s, err := i.sceneRepo.FindByProject(ctx, prj.ID())
+	if err != nil {
+		return nil, err
+	}
+	sid := s.ID()
+
+	d := &gateway.ProjectArchiveData{
+		Project: prj,
+		Scene:   s,
+	}
+
+	if d.Layers, err = i.layerRepo.FindByScene(ctx, sid); err != nil {
+		return nil, err
+	}
+	if d.NLSLayers, err = i.nlsLayerRepo.FindByScene(ctx, sid); err != nil {
+		return nil, err
+	}
+	if d.Properties, err = i.propertyRepo.FindByScene(ctx, sid); err != nil {
+		return nil, err
+	}
+	if d.DatasetSchemas, err = i.datasetSchemaRepo.FindBySceneAll(ctx, sid); err != nil {
+		return nil, err
+	}
+	for _, ds := range d.DatasetSchemas {
+		datasets, err := i.datasetRepo.FindBySchemaAll(ctx, ds.ID())
+		if err != nil {
+			return nil, err
+		}
+		d.Datasets = append(d.Datasets, datasets...)
+	}
+	if d.Tags, err = i.tagRepo.FindByScene(ctx, sid); err != nil {
+		return nil, err
+	}
+
+	styles, err := i.layerStyles.FindByScene(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if styles != nil {
+		d.Styles = *styles
+	}
+
+	stories, err := i.storytellingRepo.FindByScene(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if stories != nil {
+		d.Stories = lo.Compact(*stories)
+	}
+
+	return d, nil
+}
+
+// Import restores a project from an archive written by Export into the workspace. All entities get new IDs,
+// and the project and its stories are imported as private ones so that they do not conflict with the originals.
+func (i *Project) Import(ctx context.Context, p interfaces.ImportProjectParam, op *usecase.Operator) (_ *project.Project, err error) {
+	if err := i.CanWriteWorkspace(p.WorkspaceID, op); err != nil {
+		return nil, err
+	}
+	if p.File == nil {
+		return nil, interfaces.ErrFileNotIncluded
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	pol, err := i.workspacePolicy(ctx, p.WorkspaceID, op)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.enforceProjectCount(ctx, pol, p.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	d, err := i.archive.Read(ctx, p.File.Content, p.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := enforceSceneContents(pol, sceneContents{
+		layers:         d.Layers,
+		nlsLayers:      d.NLSLayers,
+		datasetSchemas: d.DatasetSchemas,
+		datasets:       d.Datasets,
+		stories:        d.Stories,
+	}); err != nil {
+		return nil, err
+	}
+
+	// archives are supplied by users, so the aliases in them may be used by other projects
+	prj := d.Project
+	prj.ClearAlias()
+	prj.UpdatePublishmentStatus(project.PublishmentStatusPrivate)
+	prj.SetPublishSchedule(nil)
+	prj.SetPreview(nil)
+	for _, s := range d.Stories {
+		s.ClearAlias()
+		s.UpdatePublishmentStatus(storytelling.PublishmentStatusPrivate)
+		s.SetPublishSchedule(nil)
+	}
+
+	f := repo.SceneFilter{Writable: scene.IDList{d.Scene.ID()}}
+	if err := i.projectRepo.Save(ctx, prj); err != nil {
+		return nil, err
+	}
+	if err := i.sceneRepo.Save(ctx, d.Scene); err != nil {
+		return nil, err
+	}
+	if err := i.layerRepo.Filtered(f).SaveAll(ctx, d.Layers); err != nil {
+		return nil, err
+	}
+	if err := i.nlsLayerRepo.Filtered(f).SaveAll(ctx, d.NLSLayers); err != nil {
+		return nil, err
+	}
+	if err := i.propertyRepo.Filtered(f).SaveAll(ctx, d.Properties); err != nil {
+		return nil, err
+	}
+	if err := i.datasetSchemaRepo.Filtered(f).SaveAll(ctx, d.DatasetSchemas); err != nil {
+		return nil, err
+	}
+	if err := i.datasetRepo.Filtered(f).SaveAll(ctx, d.Datasets); err != nil {
+		return nil, err
+	}
+	if err := i.tagRepo.Filtered(f).SaveAll(ctx, d.Tags); err != nil {
+		return nil, err
+	}
+	if err := i.layerStyles.Filtered(f).SaveAll(ctx, d.Styles); err != nil {
+		return nil, err
+	}
+	if err := i.storytellingRepo.Filtered(f).SaveAll(ctx, d.Stories); err != nil {
+		return nil, err
+	}
+
+	if err := i.audit(ctx, op, auditlog.ActionProjectImport, p.WorkspaceID, nil, []auditlog.Target{auditlog.TargetOf(prj.ID())}); err != nil {
+		return nil, err
+	}
+	i.warnUsage(ctx, p.WorkspaceID, pol)
+	tx.Commit()
+	return prj, nil
+}
@@ -0,0 +1,125 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/archive"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProject_ExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	ws2 := workspace.New().NewID().MustBuild()
+	prj := project.New().NewID().Workspace(ws.ID()).Name("project").Alias("aliasalias").PublishmentStatus(project.PublishmentStatusPublic).MustBuild()
+	sid := scene.NewID()
+	rootLayer := layer.NewGroup().NewID().Scene(sid).MustBuild()
+	prop := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(rootLayer.ID()).Property(prop.ID()).MustBuild()
+	nl := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Title("layer").Config(&nlslayer.Config{"data": "x"}).MustBuild()
+
+	r := memory.New()
+	_ = r.Workspace.Save(ctx, ws)
+	_ = r.Workspace.Save(ctx, ws2)
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.Layer.Save(ctx, rootLayer)
+	_ = r.Property.Save(ctx, prop)
+	_ = r.NLSLayer.Save(ctx, nl)
+
+	uc := NewProject(r, &gateway.Container{ProjectArchive: archive.NewProjectArchive()})
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: workspace.IDList{ws.ID(), ws2.ID()},
+			ReadableWorkspaces: workspace.IDList{ws.ID(), ws2.ID()},
+		},
+	}
+
+	res, err := uc.Export(ctx, prj.ID(), op)
+	assert.NoError(t, err)
+	b := lo.Must(io.ReadAll(res))
+
+	got, err := uc.Import(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws2.ID(),
+		File:        &file.File{Path: "project.zip", Content: io.NopCloser(bytes.NewReader(b))},
+	}, op)
+	assert.NoError(t, err)
+	assert.NotEqual(t, prj.ID(), got.ID())
+	assert.Equal(t, ws2.ID(), got.Workspace())
+	assert.Equal(t, "project", got.Name())
+	assert.Equal(t, project.PublishmentStatusPrivate, got.PublishmentStatus())
+	assert.Empty(t, got.Alias())
+
+	gs, err := r.Scene.FindByProject(ctx, got.ID())
+	assert.NoError(t, err)
+	assert.NotEqual(t, sid, gs.ID())
+	assert.Equal(t, ws2.ID(), gs.Workspace())
+	gl, err := r.Layer.FindByID(ctx, gs.RootLayer())
+	assert.NoError(t, err)
+	assert.Equal(t, gs.ID(), gl.Scene())
+	gp, err := r.Property.FindByID(ctx, gs.Property())
+	assert.NoError(t, err)
+	assert.Equal(t, gs.ID(), gp.Scene())
+
+	// the original project is kept as it is
+	orig, _ := r.Project.FindByID(ctx, prj.ID())
+	assert.Equal(t, project.PublishmentStatusPublic, orig.PublishmentStatus())
+
+	// invalid archive
+	_, err = uc.Import(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws2.ID(),
+		File:        &file.File{Path: "project.zip", Content: io.NopCloser(bytes.NewReader([]byte("invalid")))},
+	}, op)
+	assert.Same(t, gateway.ErrInvalidProjectArchive, err)
+
+	// contents of the scene are checked against the policy of the workspace
+	ws3 := workspace.New().NewID().Policy(policy.ID("policy").Ref()).MustBuild()
+	_ = r.Workspace.Save(ctx, ws3)
+	r.Policy = memory.NewPolicyWith(policy.New(policy.Option{ID: policy.ID("policy"), NLSLayerCount: lo.ToPtr(0)}))
+	uc = NewProject(r, &gateway.Container{ProjectArchive: archive.NewProjectArchive()})
+	_, err = uc.Import(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws3.ID(),
+		File:        &file.File{Path: "project.zip", Content: io.NopCloser(bytes.NewReader(b))},
+	}, &usecase.Operator{AcOperator: &accountusecase.Operator{WritableWorkspaces: workspace.IDList{ws3.ID()}}})
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	r.Policy = memory.NewPolicyWith(policy.New(policy.Option{ID: policy.ID("policy"), LayerCount: lo.ToPtr(0)}))
+	uc = NewProject(r, &gateway.Container{ProjectArchive: archive.NewProjectArchive()})
+	_, err = uc.Import(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws3.ID(),
+		File:        &file.File{Path: "project.zip", Content: io.NopCloser(bytes.NewReader(b))},
+	}, &usecase.Operator{AcOperator: &accountusecase.Operator{WritableWorkspaces: workspace.IDList{ws3.ID()}}})
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	// operation denied
+	readOnly := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: workspace.IDList{ws.ID(), ws2.ID()},
+		},
+	}
+	_, err = uc.Import(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws2.ID(),
+		File:        &file.File{Path: "project.zip", Content: io.NopCloser(bytes.NewReader(b))},
+	}, readOnly)
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+	_, err = uc.Export(ctx, prj.ID(), &usecase.Operator{AcOperator: &accountusecase.Operator{}})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+}
@@ -0,0 +1,833 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/tag"
+	"github.com/samber/lo"
+)
+
+// Duplicate creates a private copy of the project with a deep copy of its scene. The copy is created
+// in the same workspace unless another one is specified.
+func (i *Project) Duplicate(ctx context.Context, p interfaces.DuplicateProjectParam, op *usecase.Operator) (_ *project.Project, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	src, err := i.projectRepo.FindByID(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadWorkspace(src.Workspace(), op); err != nil {
+		return nil, err
+	}
+
+	wid := src.Workspace()
+	if p.WorkspaceID != nil {
+		wid = *p.WorkspaceID
+	}
+	if err := i.CanWriteWorkspace(wid, op); err != nil {
+		return nil, err
+	}
+	pol, err := i.workspacePolicy(ctx, wid, op)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.enforceProjectCount(ctx, pol, wid); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s (copy)", src.Name())
+	if p.Name != nil {
+		name = *p.Name
+	}
+
+	prj, err := project.New().
+		NewID().
+		Workspace(wid).
+		Visualizer(src.Visualizer()).
+		Name(name).
+		Description(src.Description()).
+		ImageURL(src.ImageURL()).
+		PublicTitle(src.PublicTitle()).
+		PublicDescription(src.PublicDescription()).
+		PublicImage(src.PublicImage()).
+		PublicNoIndex(src.PublicNoIndex()).
+		CoreSupport(src.CoreSupport()).
+		EnableGA(src.EnableGA()).
+		TrackingID(src.TrackingID()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.duplicateScene(ctx, src, prj, pol); err != nil {
+		return nil, err
+	}
+	if err := i.projectRepo.Save(ctx, prj); err != nil {
+		return nil, err
+	}
+
+	if err := i.audit(ctx, op, auditlog.ActionProjectDuplicate, wid, nil, []auditlog.Target{auditlog.TargetOf(prj.ID()), auditlog.TargetOf(src.ID())}); err != nil {
+		return nil, err
+	}
+	i.warnUsage(ctx, wid, pol)
+	tx.Commit()
+	return prj, nil
+}
+
+// duplicateScene saves a deep copy of the scene of src as the scene of prj. Everything that belongs to the scene
+// gets a new ID: properties, widgets, clusters, layers and their infobox fields, NLS layers and their infobox blocks,
+// tags, styles, dataset schemas and datasets, and stories and their blocks.
+// Fields of dataset schemas keep their IDs, and refresh settings of dataset schemas are not copied.
+// Plugins are not copied; the new scene refers to the same plugins as the original.
+// Private plugins can not be read from another workspace, so duplicating a scene with them to another workspace is rejected.
+// The copied layers, NLS layers, datasets and stories are checked against pol, the policy of the workspace of prj.
+func (i *Project) duplicateScene(ctx context.Context, src, prj *project.Project, pol *policy.Policy) error {
+	s, err := i.sceneRepo.FindByProject(ctx, src.ID())
+	if err != nil {
+		return err
+	}
+	sid := s.ID()
+
+	if prj.Workspace() != src.Workspace() && lo.SomeBy(s.Plugins().Plugins(), func(p *scene.Plugin) bool { return p.Plugin().Scene() != nil }) {
+		return interfaces.ErrProjectNotDuplicatable
+	}
+
+	properties, err := i.propertyRepo.FindByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+	layers, err := i.layerRepo.FindByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+	nlsLayers, err := i.nlsLayerRepo.FindByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+	tags, err := i.tagRepo.FindByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+	styles, err := i.layerStyles.FindByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+	stories, err := i.storytellingRepo.FindByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+	schemas, err := i.datasetSchemaRepo.FindBySceneAll(ctx, sid)
+	if err != nil {
+		return err
+	}
+	var datasets dataset.List
+	for _, ds := range schemas {
+		l, err := i.datasetRepo.FindBySchemaAll(ctx, ds.ID())
+		if err != nil {
+			return err
+		}
+		datasets = append(datasets, l...)
+	}
+	if err := enforceSceneContents(pol, sceneContents{
+		layers:         layers,
+		nlsLayers:      nlsLayers,
+		datasetSchemas: schemas,
+		datasets:       datasets,
+		stories:        lo.FromPtr(stories),
+	}); err != nil {
+		return err
+	}
+
+	d := newSceneDuplicator(properties)
+	d.mapTags(tags)
+	d.mapLayers(layers)
+	d.mapNLSLayers(nlsLayers)
+
+	schemas2, datasets2, err := d.datasets(schemas, datasets)
+	if err != nil {
+		return err
+	}
+	s2, err := d.scene(s, prj)
+	if err != nil {
+		return err
+	}
+	tags2, err := d.tags(tags)
+	if err != nil {
+		return err
+	}
+	layers2, err := d.layers(layers)
+	if err != nil {
+		return err
+	}
+	nlsLayers2, err := d.nlsLayers(nlsLayers)
+	if err != nil {
+		return err
+	}
+	var styles2 scene.StyleList
+	if styles != nil {
+		if styles2, err = d.styles(*styles); err != nil {
+			return err
+		}
+	}
+	var stories2 storytelling.StoryList
+	if stories != nil {
+		if stories2, err = d.stories(lo.Compact(*stories)); err != nil {
+			return err
+		}
+	}
+
+	f := repo.SceneFilter{Writable: scene.IDList{d.sceneID}}
+	if err := i.sceneRepo.Save(ctx, s2); err != nil {
+		return err
+	}
+	if err := i.propertyRepo.Filtered(f).SaveAll(ctx, d.properties); err != nil {
+		return err
+	}
+	if err := i.datasetSchemaRepo.Filtered(f).SaveAll(ctx, schemas2); err != nil {
+		return err
+	}
+	if err := i.datasetRepo.Filtered(f).SaveAll(ctx, datasets2); err != nil {
+		return err
+	}
+	if err := i.tagRepo.Filtered(f).SaveAll(ctx, tags2); err != nil {
+		return err
+	}
+	if err := i.layerRepo.Filtered(f).SaveAll(ctx, layers2); err != nil {
+		return err
+	}
+	if err := i.nlsLayerRepo.Filtered(f).SaveAll(ctx, nlsLayers2); err != nil {
+		return err
+	}
+	if err := i.layerStyles.Filtered(f).SaveAll(ctx, styles2); err != nil {
+		return err
+	}
+	return i.storytellingRepo.Filtered(f).SaveAll(ctx, stories2)
+}
+
+// sceneDuplicator copies entities of a scene into a new scene, replacing IDs of the copied entities
+// and references to them.
+type sceneDuplicator struct {
+	sceneID          id.SceneID
+	srcProperties    property.Map
+	properties       property.List
+	propertyIDs      map[id.PropertyID]id.PropertyID
+	layerIDs         map[id.LayerID]id.LayerID
+	nlsLayerIDs      map[id.NLSLayerID]id.NLSLayerID
+	nlsLayers2       map[id.NLSLayerID]nlslayer.NLSLayer
+	tagIDs           map[id.TagID]id.TagID
+	datasetSchemaIDs map[id.DatasetSchemaID]id.DatasetSchemaID
+	datasetIDs       map[id.DatasetID]id.DatasetID
+	datasetMigration property.DatasetMigrationParam
+}
+
+func newSceneDuplicator(properties property.List) *sceneDuplicator {
+	return &sceneDuplicator{
+		sceneID:          id.NewSceneID(),
+		srcProperties:    properties.Map(),
+		propertyIDs:      map[id.PropertyID]id.PropertyID{},
+		layerIDs:         map[id.LayerID]id.LayerID{},
+		nlsLayerIDs:      map[id.NLSLayerID]id.NLSLayerID{},
+		nlsLayers2:       map[id.NLSLayerID]nlslayer.NLSLayer{},
+		tagIDs:           map[id.TagID]id.TagID{},
+		datasetSchemaIDs: map[id.DatasetSchemaID]id.DatasetSchemaID{},
+		datasetIDs:       map[id.DatasetID]id.DatasetID{},
+	}
+}
+
+// property returns the ID of the copy of the property, copying the property at the first call.
+// Links of the copy to datasets are replaced with links to the copies of the datasets.
+func (d *sceneDuplicator) property(pid id.PropertyID) (id.PropertyID, error) {
+	if pid2, ok := d.propertyIDs[pid]; ok {
+		return pid2, nil
+	}
+	pid2 := id.NewPropertyID()
+	d.propertyIDs[pid] = pid2
+
+	if p := d.srcProperties[pid]; p != nil {
+		c := p.Clone()
+		p2, err := property.New().ID(pid2).Scene(d.sceneID).Schema(c.Schema()).Items(c.Items()).Build()
+		if err != nil {
+			return pid2, err
+		}
+		if len(p2.Datasets()) > 0 {
+			p2.MigrateDataset(d.datasetMigration)
+		}
+		d.properties = append(d.properties, p2)
+	}
+	return pid2, nil
+}
+
+func (d *sceneDuplicator) propertyRef(pid *id.PropertyID) (*id.PropertyID, error) {
+	if pid == nil {
+		return nil, nil
+	}
+	pid2, err := d.property(*pid)
+	return pid2.Ref(), err
+}
+
+// datasets copies the dataset schemas and the datasets. It has to be called before properties are copied,
+// as links of the properties to the datasets are replaced with links to the copies.
+func (d *sceneDuplicator) datasets(schemas dataset.SchemaList, datasets dataset.List) (dataset.SchemaList, dataset.List, error) {
+	for _, s := range schemas {
+		d.datasetSchemaIDs[s.ID()] = id.NewDatasetSchemaID()
+	}
+	for _, ds := range datasets {
+		d.datasetIDs[ds.ID()] = id.NewDatasetID()
+	}
+
+	fieldIDs := map[id.DatasetFieldID]id.DatasetFieldID{}
+	schemas2 := make(dataset.SchemaList, 0, len(schemas))
+	for _, s := range schemas {
+		fields := make([]*dataset.SchemaField, 0, len(s.Fields()))
+		for _, f := range s.Fields() {
+			fieldIDs[f.ID()] = f.ID()
+			f2, err := dataset.NewSchemaField().
+				ID(f.ID()).
+				Name(f.Name()).
+				Type(f.Type()).
+				Source(f.Source()).
+				Ref(d.datasetSchemaRef(f.Ref())).
+				Build()
+			if err != nil {
+				return nil, nil, err
+			}
+			fields = append(fields, f2)
+		}
+		b := dataset.NewSchema().
+			ID(d.datasetSchemaIDs[s.ID()]).
+			Scene(d.sceneID).
+			Name(s.Name()).
+			Source(s.Source()).
+			Fields(fields)
+		if rf := s.RepresentativeFieldID(); rf != nil {
+			b = b.RepresentativeField(*rf)
+		}
+		s2, err := b.Build()
+		if err != nil {
+			return nil, nil, err
+		}
+		schemas2 = append(schemas2, s2)
+	}
+
+	datasets2 := make(dataset.List, 0, len(datasets))
+	for _, ds := range datasets {
+		fields := make([]*dataset.Field, 0, len(ds.Fields()))
+		for _, f := range ds.Fields() {
+			v := f.Value()
+			if ref := v.ValueRef(); ref != nil {
+				if did, err := id.DatasetIDFrom(*ref); err == nil {
+					if did2 := d.datasetRef(&did); did2 != nil {
+						v = dataset.ValueTypeRef.ValueFrom(did2.String())
+					}
+				}
+			}
+			if f2 := dataset.NewField(f.Field(), v, f.Source()); f2 != nil {
+				fields = append(fields, f2)
+			}
+		}
+		ds2, err := dataset.New().
+			ID(d.datasetIDs[ds.ID()]).
+			Scene(d.sceneID).
+			Source(ds.Source()).
+			Schema(d.datasetSchemaIDs[ds.Schema()]).
+			Fields(fields).
+			Build()
+		if err != nil {
+			return nil, nil, err
+		}
+		datasets2 = append(datasets2, ds2)
+	}
+
+	d.datasetMigration = property.DatasetMigrationParam{
+		OldDatasetSchemaMap: d.datasetSchemaIDs,
+		OldDatasetMap:       d.datasetIDs,
+		DatasetFieldIDMap:   fieldIDs,
+		NewDatasetSchemaMap: schemas2.Map(),
+		NewDatasetMap:       datasets2.Map(),
+	}
+	return schemas2, datasets2, nil
+}
+
+// datasetSchemaRef returns the ID of the copy of the dataset schema, or nil if it is not copied.
+func (d *sceneDuplicator) datasetSchemaRef(s *id.DatasetSchemaID) *id.DatasetSchemaID {
+	if s == nil {
+		return nil
+	}
+	if s2, ok := d.datasetSchemaIDs[*s]; ok {
+		return s2.Ref()
+	}
+	return nil
+}
+
+// datasetRef returns the ID of the copy of the dataset, or nil if it is not copied.
+func (d *sceneDuplicator) datasetRef(ds *id.DatasetID) *id.DatasetID {
+	if ds == nil {
+		return nil
+	}
+	if ds2, ok := d.datasetIDs[*ds]; ok {
+		return ds2.Ref()
+	}
+	return nil
+}
+
+func (d *sceneDuplicator) scene(s *scene.Scene, prj *project.Project) (*scene.Scene, error) {
+	widgets := make([]*scene.Widget, 0, len(s.Widgets().Widgets()))
+	widgetIDs := make(map[id.WidgetID]id.WidgetID, len(s.Widgets().Widgets()))
+	for _, w := range s.Widgets().Widgets() {
+		c := w.Clone()
+		pid, err := d.property(c.Property())
+		if err != nil {
+			return nil, err
+		}
+		w2, err := scene.NewWidget(id.NewWidgetID(), c.Plugin(), c.Extension(), pid, c.Enabled(), c.Extended())
+		if err != nil {
+			return nil, err
+		}
+		widgetIDs[c.ID()] = w2.ID()
+		widgets = append(widgets, w2)
+	}
+	alignment := s.Widgets().Alignment().Clone()
+	alignment.ReplaceWidgetIDs(widgetIDs)
+
+	plugins := make([]*scene.Plugin, 0, len(s.Plugins().Plugins()))
+	for _, p := range s.Plugins().Plugins() {
+		c := p.Clone()
+		pid, err := d.propertyRef(c.Property())
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, scene.NewPlugin(c.Plugin(), pid))
+	}
+
+	clusters := make([]*scene.Cluster, 0, len(s.Clusters().Clusters()))
+	for _, c := range s.Clusters().Clusters() {
+		pid, err := d.property(c.Property())
+		if err != nil {
+			return nil, err
+		}
+		c2, err := scene.NewCluster(id.NewClusterID(), c.Name(), pid)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, c2)
+	}
+
+	pid, err := d.property(s.Property())
+	if err != nil {
+		return nil, err
+	}
+
+	return scene.New().
+		ID(d.sceneID).
+		Project(prj.ID()).
+		Workspace(prj.Workspace()).
+		RootLayer(d.layerIDs[s.RootLayer()]).
+		Property(pid).
+		Widgets(scene.NewWidgets(widgets, alignment)).
+		Plugins(scene.NewPlugins(plugins)).
+		Clusters(scene.NewClusterListFrom(clusters)).
+		Build()
+}
+
+func (d *sceneDuplicator) mapTags(tags []*tag.Tag) {
+	for _, t := range tags {
+		if t != nil {
+			d.tagIDs[(*t).ID()] = id.NewTagID()
+		}
+	}
+}
+
+func (d *sceneDuplicator) tags(tags []*tag.Tag) ([]*tag.Tag, error) {
+	res := make([]*tag.Tag, 0, len(tags))
+	for _, t := range tags {
+		if t == nil {
+			continue
+		}
+
+		var t2 tag.Tag
+		if ti := tag.ToTagItem(*t); ti != nil {
+			var parent *id.TagID
+			if ti.Parent() != nil {
+				parent = lo.ToPtr(d.tagIDs[*ti.Parent()])
+			}
+			i2, err := tag.NewItem().
+				ID(d.tagIDs[ti.ID()]).
+				Scene(d.sceneID).
+				Label(ti.Label()).
+				Parent(parent).
+				LinkedDatasetFieldID(ti.LinkedDatasetFieldID()).
+				LinkedDatasetID(d.datasetRef(ti.LinkedDatasetID())).
+				LinkedDatasetSchemaID(d.datasetSchemaRef(ti.LinkedDatasetSchemaID())).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			t2 = i2
+		} else if tg := tag.ToTagGroup(*t); tg != nil {
+			g2, err := tag.NewGroup().
+				ID(d.tagIDs[tg.ID()]).
+				Scene(d.sceneID).
+				Label(tg.Label()).
+				Tags(lo.Map(tg.Tags(), func(t id.TagID, _ int) id.TagID { return d.tagIDs[t] })).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			t2 = g2
+		} else {
+			continue
+		}
+		res = append(res, &t2)
+	}
+	return res, nil
+}
+
+func (d *sceneDuplicator) layerTags(tl *layer.TagList) *layer.TagList {
+	if tl == nil {
+		return nil
+	}
+	tags := make([]layer.Tag, 0, len(tl.Tags()))
+	for _, t := range tl.Tags() {
+		if tg := layer.TagGroupFrom(t); tg != nil {
+			children := lo.Map(tg.Children(), func(c *layer.TagItem, _ int) *layer.TagItem {
+				return layer.NewTagItem(d.tagIDs[c.ID()])
+			})
+			tags = append(tags, layer.NewTagGroup(d.tagIDs[tg.ID()], children))
+		} else if ti := layer.TagItemFrom(t); ti != nil {
+			tags = append(tags, layer.NewTagItem(d.tagIDs[ti.ID()]))
+		}
+	}
+	return layer.NewTagList(tags)
+}
+
+func (d *sceneDuplicator) mapLayers(layers layer.List) {
+	for _, l := range layers {
+		if l != nil {
+			d.layerIDs[(*l).ID()] = id.NewLayerID()
+		}
+	}
+}
+
+func (d *sceneDuplicator) layers(layers layer.List) (layer.List, error) {
+	res := make(layer.List, 0, len(layers))
+	for _, l := range layers {
+		if l == nil {
+			continue
+		}
+
+		pid, err := d.propertyRef((*l).Property())
+		if err != nil {
+			return nil, err
+		}
+		ib, err := d.layerInfobox((*l).Infobox())
+		if err != nil {
+			return nil, err
+		}
+
+		var l2 layer.Layer
+		if li := layer.ToLayerItem(*l); li != nil {
+			i2, err := layer.NewItem().
+				ID(d.layerIDs[li.ID()]).
+				Scene(d.sceneID).
+				Name(li.Name()).
+				IsVisible(li.IsVisible()).
+				Plugin(li.Plugin()).
+				Extension(li.Extension()).
+				Property(pid).
+				Infobox(ib).
+				LinkedDataset(d.datasetRef(li.LinkedDataset())).
+				Tags(d.layerTags(li.Tags())).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			l2 = i2
+		} else if lg := layer.ToLayerGroup(*l); lg != nil {
+			children := lo.Map(lg.Layers().Layers(), func(c id.LayerID, _ int) id.LayerID { return d.layerIDs[c] })
+			g2, err := layer.NewGroup().
+				ID(d.layerIDs[lg.ID()]).
+				Scene(d.sceneID).
+				Root(lg.IsRoot()).
+				Name(lg.Name()).
+				IsVisible(lg.IsVisible()).
+				Plugin(lg.Plugin()).
+				Extension(lg.Extension()).
+				Property(pid).
+				Infobox(ib).
+				Layers(layer.NewIDList(children)).
+				LinkedDatasetSchema(d.datasetSchemaRef(lg.LinkedDatasetSchema())).
+				Tags(d.layerTags(lg.Tags())).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			l2 = g2
+		} else {
+			continue
+		}
+		res = append(res, &l2)
+	}
+	return res, nil
+}
+
+func (d *sceneDuplicator) layerInfobox(ib *layer.Infobox) (*layer.Infobox, error) {
+	if ib == nil {
+		return nil, nil
+	}
+	fields := make([]*layer.InfoboxField, 0, ib.Count())
+	for _, f := range ib.Clone().Fields() {
+		pid, err := d.property(f.Property())
+		if err != nil {
+			return nil, err
+		}
+		f2, err := layer.NewInfoboxField().
+			NewID().
+			Plugin(f.Plugin()).
+			Extension(f.Extension()).
+			Property(pid).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f2)
+	}
+	pid, err := d.property(ib.Property())
+	if err != nil {
+		return nil, err
+	}
+	return layer.NewInfobox(fields, pid), nil
+}
+
+// mapNLSLayers duplicates simple NLS layers with their Duplicate method, which assigns new IDs and clones
+// their configs and sketches, so that the copies only need to be moved to the new scene later.
+func (d *sceneDuplicator) mapNLSLayers(layers nlslayer.NLSLayerList) {
+	for _, l := range layers {
+		if l == nil {
+			continue
+		}
+		if nlslayer.ToNLSLayerGroup(*l) != nil {
+			d.nlsLayerIDs[(*l).ID()] = id.NewNLSLayerID()
+			continue
+		}
+		l2 := (*l).Duplicate()
+		d.nlsLayerIDs[(*l).ID()] = l2.ID()
+		d.nlsLayers2[(*l).ID()] = l2
+	}
+}
+
+func (d *sceneDuplicator) nlsLayerIDList(ids id.NLSLayerIDList) id.NLSLayerIDList {
+	if ids == nil {
+		return nil
+	}
+	return lo.FilterMap(ids, func(l id.NLSLayerID, _ int) (id.NLSLayerID, bool) {
+		l2, ok := d.nlsLayerIDs[l]
+		return l2, ok
+	})
+}
+
+func (d *sceneDuplicator) nlsLayers(layers nlslayer.NLSLayerList) (nlslayer.NLSLayerList, error) {
+	res := make(nlslayer.NLSLayerList, 0, len(layers))
+	for _, l := range layers {
+		if l == nil {
+			continue
+		}
+
+		ib, err := d.nlsInfobox((*l).Infobox())
+		if err != nil {
+			return nil, err
+		}
+
+		var l2 nlslayer.NLSLayer
+		if lg := nlslayer.ToNLSLayerGroup(*l); lg != nil {
+			var config *nlslayer.Config
+			if lg.Config() != nil {
+				config = lo.ToPtr(lg.Config().Clone())
+			}
+			var children []id.NLSLayerID
+			if lg.Children() != nil {
+				children = d.nlsLayerIDList(lg.Children().Layers())
+			}
+			g2, err := nlslayer.NewNLSLayerGroup().
+				ID(d.nlsLayerIDs[lg.ID()]).
+				Scene(d.sceneID).
+				LayerType(lg.LayerType()).
+				Title(lg.Title()).
+				Root(lg.IsRoot()).
+				IsVisible(lg.IsVisible()).
+				Config(config).
+				Infobox(ib).
+				Layers(nlslayer.NewIDList(children)).
+				IsSketch(lg.IsSketch()).
+				Sketch(lg.Sketch().Clone()).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			l2 = g2
+		} else if dup := d.nlsLayers2[(*l).ID()]; dup != nil {
+			s2, err := nlslayer.NewNLSLayerSimple().
+				ID(dup.ID()).
+				Scene(d.sceneID).
+				LayerType(dup.LayerType()).
+				Title(dup.Title()).
+				IsVisible(dup.IsVisible()).
+				Config(dup.Config()).
+				Infobox(ib).
+				IsSketch(dup.IsSketch()).
+				Sketch(dup.Sketch()).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			l2 = s2
+		} else {
+			continue
+		}
+		res = append(res, &l2)
+	}
+	return res, nil
+}
+
+func (d *sceneDuplicator) nlsInfobox(ib *nlslayer.Infobox) (*nlslayer.Infobox, error) {
+	if ib == nil {
+		return nil, nil
+	}
+	blocks := make([]*nlslayer.InfoboxBlock, 0, ib.Count())
+	for _, b := range ib.Blocks() {
+		pid, err := d.property(b.Property())
+		if err != nil {
+			return nil, err
+		}
+		b2, err := nlslayer.NewInfoboxBlock().
+			NewID().
+			Plugin(b.Plugin()).
+			Extension(b.Extension()).
+			Property(pid).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, b2)
+	}
+	pid, err := d.property(ib.Property())
+	if err != nil {
+		return nil, err
+	}
+	return nlslayer.NewInfobox(blocks, pid), nil
+}
+
+// styles duplicates the styles with their Duplicate method and moves the copies to the new scene.
+func (d *sceneDuplicator) styles(styles scene.StyleList) (scene.StyleList, error) {
+	res := make(scene.StyleList, 0, len(styles))
+	for _, s := range styles {
+		if s == nil {
+			continue
+		}
+		dup := s.Duplicate()
+		var value *scene.StyleValue
+		if dup.Value() != nil {
+			value = lo.ToPtr(maps.Clone(*dup.Value()))
+		}
+		s2, err := scene.NewStyle().ID(dup.ID()).Scene(d.sceneID).Name(dup.Name()).Value(value).Build()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s2)
+	}
+	return res, nil
+}
+
+// stories copies the stories as private ones. Pages are duplicated with their Duplicate method
+// and keep their original titles.
+func (d *sceneDuplicator) stories(stories storytelling.StoryList) (storytelling.StoryList, error) {
+	res := make(storytelling.StoryList, 0, len(stories))
+	for _, s := range stories {
+		var pages []*storytelling.Page
+		for _, p := range s.Pages().Pages() {
+			dup := p.Duplicate()
+			blocks := make(storytelling.BlockList, 0, len(dup.Blocks()))
+			for _, b := range dup.Blocks() {
+				pid, err := d.property(b.Property())
+				if err != nil {
+					return nil, err
+				}
+				b2, err := storytelling.NewBlock().
+					NewID().
+					Plugin(b.Plugin()).
+					Extension(b.Extension()).
+					Property(pid).
+					Build()
+				if err != nil {
+					return nil, err
+				}
+				blocks = append(blocks, b2)
+			}
+			pid, err := d.property(dup.Property())
+			if err != nil {
+				return nil, err
+			}
+			p2, err := storytelling.NewPage().
+				ID(dup.Id()).
+				Property(pid).
+				Title(p.Title()).
+				Swipeable(dup.Swipeable()).
+				Layers(d.nlsLayerIDList(dup.Layers())).
+				SwipeableLayers(d.nlsLayerIDList(dup.SwipeableLayers())).
+				Blocks(blocks).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			pages = append(pages, p2)
+		}
+
+		pid, err := d.property(s.Property())
+		if err != nil {
+			return nil, err
+		}
+		s2, err := storytelling.NewStory().
+			NewID().
+			Scene(d.sceneID).
+			Property(pid).
+			Title(s.Title()).
+			Pages(storytelling.NewPageList(pages)).
+			Status(storytelling.PublishmentStatusPrivate).
+			PanelPosition(s.PanelPosition()).
+			BgColor(s.BgColor()).
+			PublicTitle(s.PublicTitle()).
+			PublicDescription(s.PublicDescription()).
+			PublicImage(s.PublicImage()).
+			PublicNoIndex(s.PublicNoIndex()).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s2)
+	}
+	return res, nil
+}
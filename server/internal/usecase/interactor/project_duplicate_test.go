@@ -0,0 +1,294 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/tag"
+	"github.com/reearth/reearth/server/pkg/visualizer"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProject_Duplicate(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	ws2 := workspace.New().NewID().MustBuild()
+	prj := project.New().NewID().Workspace(ws.ID()).Name("project").Alias("aliasalias").PublishmentStatus(project.PublishmentStatusPublic).MustBuild()
+	sid := scene.NewID()
+	pid := id.MustPluginID("reearth")
+
+	newProperty := func() *property.Property {
+		return property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	}
+	sceneProp, widgetProp, itemProp, nlsInfoboxProp, pageProp, blockProp, storyProp := newProperty(), newProperty(), newProperty(), newProperty(), newProperty(), newProperty(), newProperty()
+	clusterProp, infoboxProp, fieldProp, nlsBlockProp := newProperty(), newProperty(), newProperty(), newProperty()
+
+	tg := tag.NewItem().NewID().Scene(sid).Label("tag").MustBuild()
+	field := layer.NewInfoboxField().NewID().Plugin(pid).Extension("field").Property(fieldProp.ID()).MustBuild()
+	item := layer.NewItem().NewID().Scene(sid).Property(itemProp.IDRef()).
+		Infobox(layer.NewInfobox([]*layer.InfoboxField{field}, infoboxProp.ID())).
+		Tags(layer.NewTagList([]layer.Tag{layer.NewTagItem(tg.ID())})).MustBuild()
+	root := layer.NewGroup().NewID().Scene(sid).Root(true).Layers(layer.NewIDList([]layer.ID{item.ID()})).MustBuild()
+
+	widget := lo.Must(scene.NewWidget(scene.NewWidgetID(), pid, "widget", widgetProp.ID(), true, false))
+	was := scene.NewWidgetAlignSystem()
+	was.Area(scene.WidgetLocation{Zone: scene.WidgetZoneInner, Section: scene.WidgetSectionLeft, Area: scene.WidgetAreaTop}).Add(widget.ID(), -1)
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(root.ID()).Property(sceneProp.ID()).
+		Widgets(scene.NewWidgets([]*scene.Widget{widget}, was)).
+		Clusters(scene.NewClusterListFrom([]*scene.Cluster{lo.Must(scene.NewCluster(scene.NewClusterID(), "cluster", clusterProp.ID()))})).
+		Plugins(scene.NewPlugins([]*scene.Plugin{scene.NewPlugin(pid, nil)})).MustBuild()
+
+	nl := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Title("layer").Config(&nlslayer.Config{"data": "x"}).
+		Infobox(nlslayer.NewInfobox([]*nlslayer.InfoboxBlock{
+			nlslayer.NewInfoboxBlock().NewID().Plugin(pid).Extension("block").Property(nlsBlockProp.ID()).MustBuild(),
+		}, nlsInfoboxProp.ID())).MustBuild()
+	style := scene.NewStyle().NewID().Scene(sid).Name("style").Value(&scene.StyleValue{"color": "red"}).MustBuild()
+	block := storytelling.NewBlock().NewID().Plugin(pid).Extension("block").Property(blockProp.ID()).MustBuild()
+	page := storytelling.NewPage().NewID().Title("page").Property(pageProp.ID()).
+		Layers(storytelling.LayerIDList{nl.ID()}).Blocks(storytelling.BlockList{block}).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(sid).Property(storyProp.ID()).Title("story").Alias("storyalias").
+		Status(storytelling.PublishmentStatusPublic).Pages(storytelling.NewPageList([]*storytelling.Page{page})).MustBuild()
+
+	r := memory.New()
+	_ = r.Workspace.Save(ctx, ws)
+	_ = r.Workspace.Save(ctx, ws2)
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.Layer.SaveAll(ctx, layer.List{lo.ToPtr[layer.Layer](root), lo.ToPtr[layer.Layer](item)})
+	_ = r.Tag.Save(ctx, tg)
+	_ = r.Property.SaveAll(ctx, property.List{sceneProp, widgetProp, itemProp, nlsInfoboxProp, pageProp, blockProp, storyProp, clusterProp, infoboxProp, fieldProp, nlsBlockProp})
+	_ = r.NLSLayer.Save(ctx, nl)
+	_ = r.Style.Save(ctx, style)
+	_ = r.Storytelling.Save(ctx, story)
+
+	uc := NewProject(r, &gateway.Container{})
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: workspace.IDList{ws.ID(), ws2.ID()},
+			ReadableWorkspaces: workspace.IDList{ws.ID(), ws2.ID()},
+		},
+	}
+
+	got, err := uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: prj.ID()}, op)
+	assert.NoError(t, err)
+	assert.NotEqual(t, prj.ID(), got.ID())
+	assert.Equal(t, ws.ID(), got.Workspace())
+	assert.Equal(t, "project (copy)", got.Name())
+	assert.Equal(t, "", got.Alias())
+	assert.Equal(t, project.PublishmentStatusPrivate, got.PublishmentStatus())
+
+	gs, err := r.Scene.FindByProject(ctx, got.ID())
+	assert.NoError(t, err)
+	assert.NotEqual(t, sid, gs.ID())
+	gsid := gs.ID()
+
+	// properties are copied into the new scene
+	gprops, err := r.Property.FindByScene(ctx, gsid)
+	assert.NoError(t, err)
+	assert.Len(t, gprops, 11)
+	gpm := gprops.Map()
+	assert.NotNil(t, gpm[gs.Property()])
+	assert.NotEqual(t, sceneProp.ID(), gs.Property())
+
+	// widgets get new IDs and properties and keep their layout
+	gw := gs.Widgets().Widgets()
+	assert.Len(t, gw, 1)
+	assert.NotEqual(t, widget.ID(), gw[0].ID())
+	assert.NotEqual(t, widgetProp.ID(), gw[0].Property())
+	assert.NotNil(t, gpm[gw[0].Property()])
+	i, loc := gs.Widgets().Alignment().Find(gw[0].ID())
+	assert.Equal(t, 0, i)
+	assert.Equal(t, scene.WidgetLocation{Zone: scene.WidgetZoneInner, Section: scene.WidgetSectionLeft, Area: scene.WidgetAreaTop}, loc)
+	i, _ = s.Widgets().Alignment().Find(widget.ID())
+	assert.Equal(t, 0, i)
+	assert.True(t, gs.Plugins().Has(pid))
+
+	// clusters
+	gc := gs.Clusters().Clusters()
+	assert.Len(t, gc, 1)
+	assert.NotEqual(t, s.Clusters().Clusters()[0].ID(), gc[0].ID())
+	assert.Equal(t, "cluster", gc[0].Name())
+	assert.NotNil(t, gpm[gc[0].Property()])
+
+	// layers and tags
+	gl, err := r.Layer.FindByID(ctx, gs.RootLayer())
+	assert.NoError(t, err)
+	groot := layer.ToLayerGroup(gl)
+	assert.True(t, groot.IsRoot())
+	gitem, err := r.Layer.FindItemByID(ctx, groot.Layers().LayerAt(0))
+	assert.NoError(t, err)
+	assert.NotEqual(t, item.ID(), gitem.ID())
+	assert.Equal(t, gsid, gitem.Scene())
+	assert.NotNil(t, gpm[*gitem.Property()])
+	gfield := gitem.Infobox().FieldAt(0)
+	assert.NotEqual(t, field.ID(), gfield.ID())
+	assert.NotNil(t, gpm[gfield.Property()])
+	assert.NotNil(t, gpm[gitem.Infobox().Property()])
+	gtags, err := r.Tag.FindByScene(ctx, gsid)
+	assert.NoError(t, err)
+	assert.Len(t, gtags, 1)
+	assert.Equal(t, (*gtags[0]).ID(), gitem.Tags().Tags()[0].ID())
+	assert.NotEqual(t, tg.ID(), (*gtags[0]).ID())
+
+	// NLS layers and styles
+	gnls, err := r.NLSLayer.FindByScene(ctx, gsid)
+	assert.NoError(t, err)
+	assert.Len(t, gnls, 1)
+	gnl := *gnls[0]
+	assert.NotEqual(t, nl.ID(), gnl.ID())
+	assert.Equal(t, "layer", gnl.Title())
+	assert.Equal(t, nl.Config(), gnl.Config())
+	assert.NotNil(t, gpm[gnl.Infobox().Property()])
+	gblock := gnl.Infobox().Blocks()[0]
+	assert.NotEqual(t, nl.Infobox().Blocks()[0].ID(), gblock.ID())
+	assert.NotNil(t, gpm[gblock.Property()])
+	gstyles, err := r.Style.FindByScene(ctx, gsid)
+	assert.NoError(t, err)
+	assert.Len(t, *gstyles, 1)
+	assert.NotEqual(t, style.ID(), (*gstyles)[0].ID())
+	assert.Equal(t, "style", (*gstyles)[0].Name())
+
+	// stories are private and their pages refer to the copied layers
+	gstories, err := r.Storytelling.FindByScene(ctx, gsid)
+	assert.NoError(t, err)
+	gstory := lo.Compact(*gstories)[0]
+	assert.NotEqual(t, story.Id(), gstory.Id())
+	assert.Equal(t, storytelling.PublishmentStatusPrivate, gstory.Status())
+	assert.Equal(t, "", gstory.Alias())
+	assert.NotNil(t, gpm[gstory.Property()])
+	gpage := gstory.Pages().Pages()[0]
+	assert.NotEqual(t, page.Id(), gpage.Id())
+	assert.Equal(t, "page", gpage.Title())
+	assert.Equal(t, storytelling.LayerIDList{gnl.ID()}, gpage.Layers())
+	assert.NotNil(t, gpm[gpage.Property()])
+	assert.NotEqual(t, block.ID(), gpage.Blocks()[0].ID())
+	assert.NotNil(t, gpm[gpage.Blocks()[0].Property()])
+
+	// the original scene is kept as it is
+	props, _ := r.Property.FindByScene(ctx, sid)
+	assert.Len(t, props, 11)
+	orig, _ := r.Project.FindByID(ctx, prj.ID())
+	assert.Equal(t, project.PublishmentStatusPublic, orig.PublishmentStatus())
+
+	// into another workspace with a name
+	got, err = uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: prj.ID(), WorkspaceID: lo.ToPtr(ws2.ID()), Name: lo.ToPtr("copy")}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, ws2.ID(), got.Workspace())
+	assert.Equal(t, "copy", got.Name())
+
+	// operation denied
+	readOnly := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+	_, err = uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: prj.ID()}, readOnly)
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+
+	// create a project from the template
+	_, err = uc.Create(ctx, interfaces.CreateProjectParam{
+		WorkspaceID: ws2.ID(),
+		Visualizer:  visualizer.VisualizerCesium,
+		TemplateID:  lo.ToPtr(prj.ID()),
+	}, op)
+	assert.Same(t, interfaces.ErrProjectNotTemplate, err)
+
+	_, err = uc.Update(ctx, interfaces.UpdateProjectParam{ID: prj.ID(), IsTemplate: lo.ToPtr(true)}, op)
+	assert.NoError(t, err)
+	got, err = uc.Create(ctx, interfaces.CreateProjectParam{
+		WorkspaceID: ws2.ID(),
+		Visualizer:  visualizer.VisualizerCesium,
+		Name:        lo.ToPtr("from template"),
+		TemplateID:  lo.ToPtr(prj.ID()),
+	}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, "from template", got.Name())
+	assert.False(t, got.IsTemplate())
+	gs, err = r.Scene.FindByProject(ctx, got.ID())
+	assert.NoError(t, err)
+	assert.Len(t, gs.Widgets().Widgets(), 1)
+
+	// contents of the scene are checked against the policy of the workspace
+	ws3 := workspace.New().NewID().Policy(policy.ID("policy").Ref()).MustBuild()
+	_ = r.Workspace.Save(ctx, ws3)
+	r.Policy = memory.NewPolicyWith(policy.New(policy.Option{ID: policy.ID("policy"), NLSLayerCount: lo.ToPtr(0)}))
+	uc = NewProject(r, &gateway.Container{})
+	op.AcOperator.WritableWorkspaces = append(op.AcOperator.WritableWorkspaces, ws3.ID())
+	_, err = uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: prj.ID(), WorkspaceID: lo.ToPtr(ws3.ID())}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	_, err = uc.Create(ctx, interfaces.CreateProjectParam{
+		WorkspaceID: ws3.ID(),
+		Visualizer:  visualizer.VisualizerCesium,
+		TemplateID:  lo.ToPtr(prj.ID()),
+	}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	r.Policy = memory.NewPolicyWith(policy.New(policy.Option{ID: policy.ID("policy"), LayerCount: lo.ToPtr(0)}))
+	uc = NewProject(r, &gateway.Container{})
+	_, err = uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: prj.ID(), WorkspaceID: lo.ToPtr(ws3.ID())}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	// datasets are copied, and layers and properties are linked to the copies
+	dsf := dataset.NewSchemaField().NewID().Name("name").Type(dataset.ValueTypeString).MustBuild()
+	dss := dataset.NewSchema().NewID().Scene(sid).Name("schema").Fields([]*dataset.SchemaField{dsf}).MustBuild()
+	ds := dataset.New().NewID().Scene(sid).Schema(dss.ID()).
+		Fields([]*dataset.Field{dataset.NewField(dsf.ID(), dataset.ValueTypeString.ValueFrom("a"), "")}).MustBuild()
+	_ = r.DatasetSchema.Save(ctx, dss)
+	_ = r.Dataset.Save(ctx, ds)
+	linkedProp := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/marker")).
+		Items([]property.Item{
+			property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+				property.NewField("location").
+					Value(property.NewOptionalValue(property.ValueTypeString, nil)).
+					Links(property.NewLinks([]*property.Link{property.NewLink(ds.ID(), dss.ID(), dsf.ID())})).
+					MustBuild(),
+			}).MustBuild(),
+		}).MustBuild()
+	linked := layer.NewItem().NewID().Scene(sid).Property(linkedProp.IDRef()).LinkedDataset(ds.ID().Ref()).MustBuild()
+	root.Layers().AddLayer(linked.ID(), -1)
+	_ = r.Property.Save(ctx, linkedProp)
+	_ = r.Layer.SaveAll(ctx, layer.List{lo.ToPtr[layer.Layer](root), lo.ToPtr[layer.Layer](linked)})
+
+	got, err = uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: prj.ID(), WorkspaceID: lo.ToPtr(ws2.ID())}, op)
+	assert.NoError(t, err)
+	gs, _ = r.Scene.FindByProject(ctx, got.ID())
+	gdss, _ := r.DatasetSchema.FindBySceneAll(ctx, gs.ID())
+	assert.Len(t, gdss, 1)
+	assert.NotEqual(t, dss.ID(), gdss[0].ID())
+	assert.Equal(t, "schema", gdss[0].Name())
+	gds, _ := r.Dataset.FindBySchemaAll(ctx, gdss[0].ID())
+	assert.Len(t, gds, 1)
+	assert.NotEqual(t, ds.ID(), gds[0].ID())
+	assert.Equal(t, gs.ID(), gds[0].Scene())
+	assert.Equal(t, dataset.ValueTypeString.ValueFrom("a"), gds[0].Field(dsf.ID()).Value())
+	gl, _ = r.Layer.FindByID(ctx, gs.RootLayer())
+	glinked, _ := r.Layer.FindItemByID(ctx, layer.ToLayerGroup(gl).Layers().LayerAt(1))
+	assert.Equal(t, gds[0].ID().Ref(), glinked.LinkedDataset())
+	gprop, _ := r.Property.FindByID(ctx, *glinked.Property())
+	assert.Equal(t, []id.DatasetID{gds[0].ID()}, gprop.Datasets())
+	// the original datasets are kept
+	orgds, _ := r.Dataset.FindBySchemaAll(ctx, dss.ID())
+	assert.Len(t, orgds, 1)
+
+	// datasets are checked against the policy
+	r.Policy = memory.NewPolicyWith(policy.New(policy.Option{ID: policy.ID("policy"), DatasetSchemaCount: lo.ToPtr(0)}))
+	uc = NewProject(r, &gateway.Container{})
+	_, err = uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: prj.ID(), WorkspaceID: lo.ToPtr(ws3.ID())}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+}
@@ -0,0 +1,326 @@
+package interactor
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearthx/rerror"
+)
+
+const (
+	exportDataFile   = "data.json"
+	exportIndexFile  = "index.html"
+	exportAssetDir   = "assets"
+	exportPluginsDir = "plugins"
+	exportViewerDir  = "viewer"
+)
+
+var (
+	viewerRefRegexp  = regexp.MustCompile(`\b(src|href)="([^"]*)"`)
+	viewerHTTPClient = &http.Client{Timeout: 30 * time.Second}
+)
+
+// ExportPublished packages the published scene of the project into a zip archive which can be viewed without network access.
+// The archive contains the built scene, index HTML, and the assets and plugin files referenced by the scene.
+func (i *Project) ExportPublished(ctx context.Context, pid id.ProjectID, op *usecase.Operator) (io.Reader, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadWorkspace(prj.Workspace(), op); err != nil {
+		return nil, err
+	}
+	return i.exportPublished(ctx, prj)
+}
+
+// ExportPublishedByAlias exports the published scene of the project with the alias without permission checks.
+// It is intended for the export command run by operators.
+func (i *Project) ExportPublishedByAlias(ctx context.Context, alias string) (io.Reader, error) {
+	prj, err := i.projectRepo.FindByPublicName(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	if prj == nil {
+		return nil, rerror.ErrNotFound
+	}
+	return i.exportPublished(ctx, prj)
+}
+
+func (i *Project) exportPublished(ctx context.Context, prj *project.Project) (io.Reader, error) {
+	if prj.PublishmentStatus() == project.PublishmentStatusPrivate || prj.Alias() == "" {
+		return nil, interfaces.ErrProjectNotPublished
+	}
+
+	data, err := i.readBuiltScene(ctx, prj.Alias())
+	if err != nil {
+		return nil, err
+	}
+
+	indexHTML, viewerFiles, err := i.exportIndexHTML(ctx, prj)
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []string
+	rewrite := i.rewriteAssetURLs(&assets)
+	data = rewrite(data)
+	indexHTML = rewrite(indexHTML)
+
+	pluginFiles, err := i.exportPluginFiles(ctx, prj)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		zw := zip.NewWriter(pw)
+		err := i.writeExport(ctx, zw, data, indexHTML, viewerFiles, assets, pluginFiles)
+		if err == nil {
+			err = zw.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// readBuiltScene reads the built scene as a single document, assembling it when it was built as chunks.
+func (i *Project) readBuiltScene(ctx context.Context, name string) ([]byte, error) {
+	r, err := i.file.ReadBuiltSceneFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	br := bufio.NewReader(r)
+	m, ok, err := readManifest(br)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return io.ReadAll(br)
+	}
+
+	var b bytes.Buffer
+	if err := m.Assemble(ctx, &b, i.file.ReadBuiltSceneChunk); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// exportIndexHTML renders the index HTML whose references to files of the viewer are rewritten to paths in the archive,
+// and returns the files of the viewer to bundle.
+func (i *Project) exportIndexHTML(ctx context.Context, prj *project.Project) ([]byte, []string, error) {
+	htmlStr := i.indexHTMLStr
+	if i.indexHTML != nil {
+		htmlCachedStr, err := i.indexHTML.Get(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		htmlStr = htmlCachedStr
+	}
+	htmlStr, files := i.rewriteViewerURLs(htmlStr)
+	return []byte(renderIndex(htmlStr, "./", interfaces.PublishedMetadataFrom(prj))), files, nil
+}
+
+// rewriteViewerURLs rewrites src and href attributes of the index HTML which refer to files of the viewer,
+// such as scripts and stylesheets, to paths under the viewer directory of the archive.
+// Paths of the files relative to the root of the viewer are returned without duplicates.
+// URLs of other origins are kept as they are.
+func (i *Project) rewriteViewerURLs(htmlStr string) (string, []string) {
+	if i.indexURL == nil && i.viewerFS == nil {
+		return htmlStr, nil
+	}
+	base := i.indexURL
+	if base == nil {
+		base = &url.URL{Path: "/"}
+	}
+
+	var files []string
+	seen := map[string]struct{}{}
+	res := viewerRefRegexp.ReplaceAllStringFunc(htmlStr, func(m string) string {
+		sm := viewerRefRegexp.FindStringSubmatch(m)
+		u, err := base.Parse(html.UnescapeString(sm[2]))
+		if err != nil || u.Scheme != base.Scheme || u.Host != base.Host || strings.HasSuffix(u.Path, "/") {
+			return m
+		}
+		name := strings.TrimPrefix(path.Clean("/"+u.Path), "/")
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			files = append(files, name)
+		}
+		return sm[1] + `="` + html.EscapeString(exportViewerDir+"/"+name) + `"`
+	})
+	return res, files
+}
+
+// readViewerFile reads a file of the viewer from PublishedViewerFS, or from the origin of PublishedIndexURL.
+func (i *Project) readViewerFile(ctx context.Context, name string) (io.ReadCloser, error) {
+	if i.viewerFS != nil {
+		f, err := i.viewerFS.Open(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, rerror.ErrNotFound
+		}
+		return f, err
+	}
+
+	u := i.indexURL.ResolveReference(&url.URL{Path: "/" + name})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := viewerHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch viewer file %s: %w", name, err)
+	}
+	if res.StatusCode == http.StatusNotFound {
+		_ = res.Body.Close()
+		return nil, rerror.ErrNotFound
+	}
+	if res.StatusCode >= 300 {
+		_ = res.Body.Close()
+		return nil, fmt.Errorf("failed to fetch viewer file %s: status %d", name, res.StatusCode)
+	}
+	return res.Body, nil
+}
+
+// rewriteAssetURLs returns a function that rewrites URLs of assets to paths relative to the archive root.
+// Names of the rewritten assets are appended to assets without duplicates.
+func (i *Project) rewriteAssetURLs(assets *[]string) func([]byte) []byte {
+	base := strings.TrimSuffix(i.assetBaseURL, "/")
+	if base == "" {
+		return func(b []byte) []byte { return b }
+	}
+
+	re := regexp.MustCompile(regexp.QuoteMeta(base) + `/([0-9A-Za-z_\-.]+)`)
+	seen := map[string]struct{}{}
+	return func(b []byte) []byte {
+		return re.ReplaceAllFunc(b, func(m []byte) []byte {
+			name := string(re.FindSubmatch(m)[1])
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				*assets = append(*assets, name)
+			}
+			return []byte(exportAssetDir + "/" + name)
+		})
+	}
+}
+
+type exportPluginFile struct {
+	plugin id.PluginID
+	name   string
+}
+
+// exportPluginFiles returns all files of the plugins installed to the scene except the official plugin.
+func (i *Project) exportPluginFiles(ctx context.Context, prj *project.Project) ([]exportPluginFile, error) {
+	s, err := i.sceneRepo.FindByProject(ctx, prj.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []id.PluginID
+	for _, p := range s.Plugins().Plugins() {
+		if pid := p.Plugin(); !pid.Equal(id.OfficialPluginID) {
+			pids = append(pids, pid)
+		}
+	}
+	if len(pids) == 0 {
+		return nil, nil
+	}
+
+	var res []exportPluginFile
+	for _, pid := range pids {
+		names, err := i.file.ListPluginFiles(ctx, pid)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			res = append(res, exportPluginFile{plugin: pid, name: name})
+		}
+	}
+	return res, nil
+}
+
+func (i *Project) writeExport(ctx context.Context, zw *zip.Writer, data, indexHTML []byte, viewerFiles, assets []string, pluginFiles []exportPluginFile) error {
+	if err := writeToZip(zw, exportIndexFile, indexHTML); err != nil {
+		return err
+	}
+	if err := writeToZip(zw, exportDataFile, data); err != nil {
+		return err
+	}
+
+	for _, f := range viewerFiles {
+		if err := copyToZip(zw, path.Join(exportViewerDir, f), func() (io.ReadCloser, error) {
+			return i.readViewerFile(ctx, f)
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range assets {
+		if err := copyToZip(zw, path.Join(exportAssetDir, a), func() (io.ReadCloser, error) {
+			return i.file.ReadAsset(ctx, a)
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range pluginFiles {
+		if err := copyToZip(zw, path.Join(exportPluginsDir, f.plugin.String(), f.name), func() (io.ReadCloser, error) {
+			return i.file.ReadPluginFile(ctx, f.plugin, f.name)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeToZip(zw *zip.Writer, name string, b []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// copyToZip copies a file to the archive. Files which are not found are skipped
+// because assets and plugin files may have been removed after the scene was published,
+// and the index HTML may refer to files which the viewer does not serve.
+func copyToZip(zw *zip.Writer, name string, open func() (io.ReadCloser, error)) error {
+	r, err := open()
+	if errors.Is(err, rerror.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
@@ -0,0 +1,118 @@
+package interactor
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProject_ExportPublished(t *testing.T) {
+	ctx := context.Background()
+
+	mfs := afero.NewMemMapFs()
+	f, _ := fs.NewFile(mfs, "https://example.com/assets")
+	assetURL, _, err := f.UploadAsset(ctx, &file.File{Path: "image.png", Content: io.NopCloser(strings.NewReader("png"))})
+	assert.NoError(t, err)
+	assetName := path.Base(assetURL.Path)
+
+	pid := id.MustPluginID("plugin~1.0.0")
+	pl := plugin.New().ID(pid).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("marker").Type(plugin.ExtensionTypePrimitive).MustBuild(),
+	}).MustBuild()
+	assert.NoError(t, f.UploadPluginFile(ctx, pid, &file.File{Path: "marker.js", Content: io.NopCloser(strings.NewReader("js"))}))
+	assert.NoError(t, f.UploadPluginFile(ctx, pid, &file.File{Path: "lib/util.js", Content: io.NopCloser(strings.NewReader("util"))}))
+
+	ws := workspace.New().NewID().MustBuild()
+	prj := project.New().NewID().Workspace(ws.ID()).Alias("aliasalias").PublicImage(assetURL.String()).MustBuild()
+	sid := scene.NewID()
+	rootLayer := layer.NewGroup().NewID().Scene(sid).MustBuild()
+	prop := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(rootLayer.ID()).Property(prop.ID()).Plugins(scene.NewPlugins([]*scene.Plugin{
+		scene.NewPlugin(id.OfficialPluginID, nil),
+		scene.NewPlugin(pid, nil),
+	})).MustBuild()
+
+	r := memory.New()
+	_ = r.Workspace.Save(ctx, ws)
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.Layer.Save(ctx, rootLayer)
+	_ = r.Property.Save(ctx, prop)
+	_ = r.Plugin.Save(ctx, pl)
+
+	uc := NewProjectWithConfig(r, &gateway.Container{File: f}, ProjectConfig{
+		AssetBaseURL:       "https://example.com/assets",
+		PublishedIndexHTML: `<html><head><script src="/assets/index.js"></script><link href="https://cdn.example.com/a.css"></head></html>`,
+		PublishedViewerFS: fstest.MapFS{
+			"assets/index.js": &fstest.MapFile{Data: []byte("viewer")},
+		},
+	})
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+
+	// not published yet
+	_, err = uc.ExportPublished(ctx, prj.ID(), op)
+	assert.Same(t, interfaces.ErrProjectNotPublished, err)
+
+	_, err = uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj.ID(), Status: project.PublishmentStatusPublic}, op)
+	assert.NoError(t, err)
+
+	res, err := uc.ExportPublished(ctx, prj.ID(), op)
+	assert.NoError(t, err)
+	b := lo.Must(io.ReadAll(res))
+	zr := lo.Must(zip.NewReader(bytes.NewReader(b), int64(len(b))))
+
+	files := map[string]string{}
+	for _, zf := range zr.File {
+		rc := lo.Must(zf.Open())
+		files[zf.Name] = string(lo.Must(io.ReadAll(rc)))
+		_ = rc.Close()
+	}
+
+	assert.Len(t, files, 6)
+	assert.Contains(t, files["data.json"], `"schemaVersion"`)
+	assert.Contains(t, files["index.html"], `content="assets/`+assetName+`"`)
+	assert.NotContains(t, files["index.html"], "https://example.com/assets")
+	assert.Equal(t, "png", files["assets/"+assetName])
+	assert.Equal(t, "js", files["plugins/plugin~1.0.0/marker.js"])
+	assert.Equal(t, "util", files["plugins/plugin~1.0.0/lib/util.js"])
+	assert.Equal(t, "viewer", files["viewer/assets/index.js"])
+	assert.Contains(t, files["index.html"], `<script src="viewer/assets/index.js">`)
+	assert.Contains(t, files["index.html"], `href="https://cdn.example.com/a.css"`)
+
+	// by alias
+	res, err = uc.ExportPublishedByAlias(ctx, "aliasalias")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, lo.Must(io.ReadAll(res)))
+
+	// operation denied
+	_, err = uc.ExportPublished(ctx, prj.ID(), &usecase.Operator{AcOperator: &accountusecase.Operator{}})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+}
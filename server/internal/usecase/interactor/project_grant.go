@@ -0,0 +1,162 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/projectgrant"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountusecase/accountrepo"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type ProjectGrant struct {
+	common
+	commonAudit
+	projectGrantRepo repo.ProjectGrant
+	projectRepo      repo.Project
+	sceneRepo        repo.Scene
+	userRepo         accountrepo.User
+	transaction      usecasex.Transaction
+}
+
+func NewProjectGrant(r *repo.Container, g *gateway.Container) interfaces.ProjectGrant {
+	return &ProjectGrant{
+		projectGrantRepo: r.ProjectGrant,
+		projectRepo:      r.Project,
+		sceneRepo:        r.Scene,
+		userRepo:         r.User,
+		transaction:      r.Transaction,
+		commonAudit:      newCommonAudit(r, g),
+	}
+}
+
+func (i *ProjectGrant) FetchByProject(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (projectgrant.List, error) {
+	if _, err := i.maintainingProject(ctx, pid, operator); err != nil {
+		return nil, err
+	}
+	return i.projectGrantRepo.FindByProject(ctx, pid)
+}
+
+func (i *ProjectGrant) Grant(ctx context.Context, param interfaces.GrantProjectAccessParam, operator *usecase.Operator) (_ *projectgrant.Grant, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	prj, err := i.maintainingProject(ctx, param.ProjectID, operator)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := i.userRepo.FindByID(ctx, param.UserID); err != nil {
+		return nil, err
+	}
+
+	var prevRole any
+	g, err := i.projectGrantRepo.FindByProjectAndUser(ctx, prj.ID(), param.UserID)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+	if g != nil {
+		prevRole = string(g.Role())
+		if err := g.SetRole(param.Role); err != nil {
+			return nil, err
+		}
+	} else {
+		s, err := i.sceneRepo.FindByProject(ctx, prj.ID())
+		if err != nil {
+			return nil, err
+		}
+		g, err = projectgrant.New().
+			NewID().
+			Project(prj.ID()).
+			Scene(s.ID()).
+			Workspace(prj.Workspace()).
+			User(param.UserID).
+			Role(param.Role).
+			GrantedBy(operator.UserID()).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.projectGrantRepo.Save(ctx, g); err != nil {
+		return nil, err
+	}
+
+	if err := i.audit(ctx, operator, auditlog.ActionProjectGrant, prj.Workspace(), nil,
+		[]auditlog.Target{auditlog.TargetOf(prj.ID()), auditlog.TargetOf(param.UserID)},
+		auditlog.NewChange("role", prevRole, string(g.Role())),
+	); err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return g, nil
+}
+
+func (i *ProjectGrant) Revoke(ctx context.Context, pid id.ProjectID, uid accountdomain.UserID, operator *usecase.Operator) (err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	prj, err := i.maintainingProject(ctx, pid, operator)
+	if err != nil {
+		return err
+	}
+
+	g, err := i.projectGrantRepo.FindByProjectAndUser(ctx, prj.ID(), uid)
+	if err != nil {
+		return err
+	}
+	if err := i.projectGrantRepo.Remove(ctx, g.ID()); err != nil {
+		return err
+	}
+
+	if err := i.audit(ctx, operator, auditlog.ActionProjectRevoke, prj.Workspace(), nil,
+		[]auditlog.Target{auditlog.TargetOf(prj.ID()), auditlog.TargetOf(uid)},
+		auditlog.NewChange("role", string(g.Role()), nil),
+	); err != nil {
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// maintainingProject returns the project only if the operator maintains its workspace, as grants are managed like workspace members.
+func (i *ProjectGrant) maintainingProject(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (*project.Project, error) {
+	if err := i.OnlyOperator(operator); err != nil {
+		return nil, err
+	}
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if !operator.IsMaintainingWorkspace(prj.Workspace()) {
+		return nil, interfaces.ErrOperationDenied
+	}
+	return prj, nil
+}
@@ -0,0 +1,148 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/projectgrant"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountdomain/user"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectGrant_Grant(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	prj := project.New().NewID().Workspace(ws.ID()).MustBuild()
+	s := scene.New().NewID().Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).Property(id.NewPropertyID()).MustBuild()
+	contractor := user.New().NewID().Name("contractor").Email("contractor@example.com").MustBuild()
+
+	r := memory.New()
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.User.Save(ctx, contractor)
+	uc := NewProjectGrant(r, &gateway.Container{})
+
+	maintainer := accountdomain.NewUserID()
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:                   &maintainer,
+			ReadableWorkspaces:     workspace.IDList{ws.ID()},
+			WritableWorkspaces:     workspace.IDList{ws.ID()},
+			MaintainableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+	writer := accountdomain.NewUserID()
+	writerOp := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:               &writer,
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+
+	// only maintainers can grant
+	_, err := uc.Grant(ctx, interfaces.GrantProjectAccessParam{ProjectID: prj.ID(), UserID: contractor.ID(), Role: projectgrant.RoleViewer}, writerOp)
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+
+	_, err = uc.Grant(ctx, interfaces.GrantProjectAccessParam{ProjectID: prj.ID(), UserID: contractor.ID(), Role: "owner"}, op)
+	assert.ErrorIs(t, err, projectgrant.ErrInvalidRole)
+
+	_, err = uc.Grant(ctx, interfaces.GrantProjectAccessParam{ProjectID: prj.ID(), UserID: accountdomain.NewUserID(), Role: projectgrant.RoleViewer}, op)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	g, err := uc.Grant(ctx, interfaces.GrantProjectAccessParam{ProjectID: prj.ID(), UserID: contractor.ID(), Role: projectgrant.RoleViewer}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, s.ID(), g.Scene())
+	assert.Equal(t, ws.ID(), g.Workspace())
+	assert.Equal(t, &maintainer, g.GrantedBy())
+
+	// granting again changes the role
+	g2, err := uc.Grant(ctx, interfaces.GrantProjectAccessParam{ProjectID: prj.ID(), UserID: contractor.ID(), Role: projectgrant.RoleEditor}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, g.ID(), g2.ID())
+
+	grants, err := uc.FetchByProject(ctx, prj.ID(), op)
+	assert.NoError(t, err)
+	assert.Len(t, grants, 1)
+	assert.Equal(t, projectgrant.RoleEditor, grants[0].Role())
+
+	_, err = uc.FetchByProject(ctx, prj.ID(), writerOp)
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+
+	assert.Same(t, interfaces.ErrOperationDenied, uc.Revoke(ctx, prj.ID(), contractor.ID(), writerOp))
+	assert.NoError(t, uc.Revoke(ctx, prj.ID(), contractor.ID(), op))
+	assert.ErrorIs(t, uc.Revoke(ctx, prj.ID(), contractor.ID(), op), rerror.ErrNotFound)
+
+	grants, err = uc.FetchByProject(ctx, prj.ID(), op)
+	assert.NoError(t, err)
+	assert.Empty(t, grants)
+}
+
+func TestProjectGrant_Granted(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	prj := project.New().NewID().Workspace(ws.ID()).MustBuild()
+	prj2 := project.New().NewID().Workspace(ws.ID()).MustBuild()
+	s := scene.New().NewID().Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).Property(id.NewPropertyID()).MustBuild()
+	s2 := scene.New().NewID().Project(prj2.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).Property(id.NewPropertyID()).MustBuild()
+
+	r := memory.New()
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Project.Save(ctx, prj2)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.Scene.Save(ctx, s2)
+
+	// the contractor is only a member of the personal workspace, but can read the granted project
+	contractor := accountdomain.NewUserID()
+	personal := workspace.NewID()
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:             &contractor,
+			OwningWorkspaces: workspace.IDList{personal},
+		},
+		ReadableScenes:  scene.IDList{s.ID()},
+		GrantedProjects: id.ProjectIDList{prj.ID()},
+	}
+	r2 := r.Filtered(repo.WorkspaceFilterFromOperator(op), repo.SceneFilterFromOperator(op)).Granted(op.GrantedProjects, op.AllReadableScenes(), op.AllWritableScenes())
+
+	got, err := r2.Project.FindByID(ctx, prj.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, prj, got)
+	_, err = r2.Project.FindByID(ctx, prj2.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	got2, err := r2.Scene.FindByProject(ctx, prj.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, s, got2)
+	_, err = r2.Scene.FindByID(ctx, s2.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	// viewers can not modify the scene
+	assert.ErrorIs(t, r2.Scene.Save(ctx, s), repo.ErrOperationDenied)
+
+	// editors can modify the scene, but can not remove it
+	op.ReadableScenes = nil
+	op.WritableScenes = scene.IDList{s.ID()}
+	r3 := r.Filtered(repo.WorkspaceFilterFromOperator(op), repo.SceneFilterFromOperator(op)).Granted(op.GrantedProjects, op.AllReadableScenes(), op.AllWritableScenes())
+
+	assert.NoError(t, r3.Scene.Save(ctx, s))
+	assert.ErrorIs(t, r3.Scene.Save(ctx, s2), repo.ErrOperationDenied)
+	assert.NoError(t, r3.Scene.Remove(ctx, s.ID()))
+	got2, err = r.Scene.FindByID(ctx, s.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, s.ID(), got2.ID())
+}
@@ -2,21 +2,35 @@ package interactor
 
 import (
 	"context"
+	"io"
 	"net/url"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
 	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/reearth/reearth/server/pkg/policy"
 	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/publishschedule"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/scene/builder"
 	"github.com/reearth/reearth/server/pkg/visualizer"
+	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/account/accountdomain/workspace"
 	"github.com/reearth/reearthx/account/accountinfrastructure/accountmemory"
 	"github.com/reearth/reearthx/account/accountusecase"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
 	"github.com/samber/lo"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -137,3 +151,314 @@ func TestProject_Create(t *testing.T) {
 	assert.Same(t, policy.ErrPolicyViolation, err)
 	assert.Nil(t, got)
 }
+
+func TestProject_PublishAndRollback(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	uid := accountdomain.NewUserID()
+	prj := project.New().NewID().Workspace(ws.ID()).Alias("aliasalias").MustBuild()
+	sid := scene.NewID()
+	rootLayer := layer.NewGroup().NewID().Scene(sid).MustBuild()
+	prop := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(rootLayer.ID()).Property(prop.ID()).MustBuild()
+
+	r := memory.New()
+	_ = r.Workspace.Save(ctx, ws)
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.Layer.Save(ctx, rootLayer)
+	_ = r.Property.Save(ctx, prop)
+
+	mfs := afero.NewMemMapFs()
+	f, _ := fs.NewFile(mfs, "")
+	uc := NewProject(r, &gateway.Container{File: f})
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:               &uid,
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+
+	// publish twice
+	_, err := uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj.ID(), Status: project.PublishmentStatusLimited}, op)
+	assert.NoError(t, err)
+	first := lo.Must(afero.ReadFile(mfs, filepath.Join("published", "aliasalias.json")))
+
+	_, err = uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj.ID(), Status: project.PublishmentStatusPublic}, op)
+	assert.NoError(t, err)
+	second := lo.Must(afero.ReadFile(mfs, filepath.Join("published", "aliasalias.json")))
+	assert.NotEqual(t, first, second)
+
+	revisions, err := uc.FindRevisions(ctx, prj.ID(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(revisions))
+	assert.Equal(t, 2, revisions[0].Number())
+	assert.Equal(t, 1, revisions[1].Number())
+	assert.Equal(t, "limited", revisions[1].Status())
+	assert.Equal(t, &uid, revisions[1].PublishedBy())
+	assert.Equal(t, builder.SchemaVersion, revisions[1].SchemaVersion())
+
+	// rollback to the first revision
+	got, err := uc.Rollback(ctx, interfaces.RollbackProjectParam{ID: prj.ID(), Revision: 1}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, project.PublishmentStatusLimited, got.PublishmentStatus())
+	assert.Equal(t, revisions[1].PublishedAt(), got.PublishedAt())
+	assert.Equal(t, first, lo.Must(afero.ReadFile(mfs, filepath.Join("published", "aliasalias.json"))))
+
+	lock, _ := r.SceneLock.GetLock(ctx, s.ID())
+	assert.Equal(t, scene.LockModeFree, lock)
+
+	// rollback to a revision that does not exist
+	_, err = uc.Rollback(ctx, interfaces.RollbackProjectParam{ID: prj.ID(), Revision: 3}, op)
+	assert.Same(t, rerror.ErrNotFound, err)
+
+	// another project keeping the same alias can not be published over the published one
+	prj2 := project.New().NewID().Workspace(ws.ID()).Alias("aliasalias").MustBuild()
+	s2 := scene.New().NewID().Project(prj2.ID()).Workspace(ws.ID()).RootLayer(rootLayer.ID()).Property(prop.ID()).MustBuild()
+	_ = r.Project.Save(ctx, prj2)
+	_ = r.Scene.Save(ctx, s2)
+	_, err = uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj2.ID(), Status: project.PublishmentStatusPublic}, op)
+	assert.Same(t, interfaces.ErrProjectAliasAlreadyUsed, err)
+	assert.Equal(t, first, lo.Must(afero.ReadFile(mfs, filepath.Join("published", "aliasalias.json"))))
+
+	// a private project can not be rolled back over another project which has taken its alias
+	_, err = uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj.ID(), Status: project.PublishmentStatusPrivate}, op)
+	assert.NoError(t, err)
+	_, err = uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj2.ID(), Status: project.PublishmentStatusPublic}, op)
+	assert.NoError(t, err)
+	third := lo.Must(afero.ReadFile(mfs, filepath.Join("published", "aliasalias.json")))
+	_, err = uc.Rollback(ctx, interfaces.RollbackProjectParam{ID: prj.ID(), Revision: 1}, op)
+	assert.Same(t, interfaces.ErrProjectAliasAlreadyUsed, err)
+	assert.Equal(t, third, lo.Must(afero.ReadFile(mfs, filepath.Join("published", "aliasalias.json"))))
+
+	// operation denied
+	_, err = uc.Rollback(ctx, interfaces.RollbackProjectParam{ID: prj.ID(), Revision: 1}, &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+}
+
+func TestProject_RemoveUnusedChunks(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	uid := accountdomain.NewUserID()
+	prj := project.New().NewID().Workspace(ws.ID()).Alias("aliasalias").MustBuild()
+	sid := scene.NewID()
+	rootLayer := layer.NewGroup().NewID().Scene(sid).MustBuild()
+	prop := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(rootLayer.ID()).Property(prop.ID()).MustBuild()
+
+	r := memory.New()
+	_ = r.Workspace.Save(ctx, ws)
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.Layer.Save(ctx, rootLayer)
+	_ = r.Property.Save(ctx, prop)
+	_ = r.NLSLayer.Save(ctx, nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Title("layer").MustBuild())
+
+	mfs := afero.NewMemMapFs()
+	f, _ := fs.NewFile(mfs, "")
+	uc := NewProject(r, &gateway.Container{File: f})
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:               &uid,
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+
+	_, err := uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj.ID(), Status: project.PublishmentStatusPublic}, op)
+	assert.NoError(t, err)
+	m := lo.Must(builder.ParseManifest(lo.Must(afero.ReadFile(mfs, filepath.Join("published", "aliasalias.json")))))
+
+	chunk := func(h string) string { return filepath.Join("chunks", h+".json") }
+	now := time.Now()
+	old := now.Add(-chunkRemovalGrace)
+	assert.NoError(t, afero.WriteFile(mfs, chunk("unused"), []byte("{}"), 0644))
+	assert.NoError(t, afero.WriteFile(mfs, chunk("recent"), []byte("{}"), 0644))
+	for _, h := range append(m.Hashes(), "unused") {
+		assert.NoError(t, mfs.Chtimes(chunk(h), old, old))
+	}
+
+	// only old chunks which are not referred are removed
+	assert.NoError(t, uc.RemoveUnusedChunks(ctx, now))
+	for _, h := range m.Hashes() {
+		assert.True(t, lo.Must(afero.Exists(mfs, chunk(h))))
+	}
+	assert.False(t, lo.Must(afero.Exists(mfs, chunk("unused"))))
+	assert.True(t, lo.Must(afero.Exists(mfs, chunk("recent"))))
+
+	// builds upload old chunks again instead of reusing them, so that they are not removed while being referred
+	_, err = uc.Publish(ctx, interfaces.PublishProjectParam{ID: prj.ID(), Status: project.PublishmentStatusLimited}, op)
+	assert.NoError(t, err)
+	m2 := lo.Must(builder.ParseManifest(lo.Must(afero.ReadFile(mfs, filepath.Join("published", "aliasalias.json")))))
+	reused := lo.Intersect(m.Hashes(), m2.Hashes())
+	assert.NotEmpty(t, reused)
+	for _, h := range reused {
+		assert.True(t, lo.Must(mfs.Stat(chunk(h))).ModTime().After(old))
+	}
+
+	// chunks are removed after all built scenes and revisions referring to them are removed
+	assert.NoError(t, mfs.RemoveAll("published"))
+	assert.NoError(t, mfs.RemoveAll("revisions"))
+	assert.NoError(t, uc.RemoveUnusedChunks(ctx, time.Now().Add(chunkRemovalGrace)))
+	for _, h := range append(m.Hashes(), m2.Hashes()...) {
+		assert.False(t, lo.Must(afero.Exists(mfs, chunk(h))))
+	}
+}
+
+func TestProject_PublishScheduled(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	prj := project.New().NewID().Workspace(ws.ID()).Alias("aliasalias").MustBuild()
+	sid := scene.NewID()
+	rootLayer := layer.NewGroup().NewID().Scene(sid).MustBuild()
+	prop := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(rootLayer.ID()).Property(prop.ID()).MustBuild()
+
+	r := memory.New()
+	_ = r.Workspace.Save(ctx, ws)
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.Layer.Save(ctx, rootLayer)
+	_ = r.Property.Save(ctx, prop)
+
+	mfs := afero.NewMemMapFs()
+	f, _ := fs.NewFile(mfs, "")
+	uc := NewProject(r, &gateway.Container{File: f})
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+
+	now := time.Now()
+	publishAt := now.Add(time.Hour)
+	unpublishAt := now.Add(2 * time.Hour)
+
+	// invalid schedule
+	_, err := uc.SchedulePublish(ctx, interfaces.SchedulePublishProjectParam{
+		ID:          prj.ID(),
+		Status:      project.PublishmentStatusPublic,
+		PublishAt:   &unpublishAt,
+		UnpublishAt: &publishAt,
+	}, op)
+	assert.Equal(t, publishschedule.ErrInvalid, err)
+
+	got, err := uc.SchedulePublish(ctx, interfaces.SchedulePublishProjectParam{
+		ID:          prj.ID(),
+		Status:      project.PublishmentStatusPublic,
+		PublishAt:   &publishAt,
+		UnpublishAt: &unpublishAt,
+	}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, &publishAt, got.PublishSchedule().NextAt())
+
+	// not due yet
+	assert.NoError(t, uc.PublishScheduled(ctx, now))
+	got, _ = r.Project.FindByID(ctx, prj.ID())
+	assert.Equal(t, project.PublishmentStatusPrivate, got.PublishmentStatus())
+
+	// publish
+	assert.NoError(t, uc.PublishScheduled(ctx, publishAt))
+	got, _ = r.Project.FindByID(ctx, prj.ID())
+	assert.Equal(t, project.PublishmentStatusPublic, got.PublishmentStatus())
+	assert.Equal(t, &unpublishAt, got.PublishSchedule().NextAt())
+	assert.True(t, lo.Must(afero.Exists(mfs, filepath.Join("published", "aliasalias.json"))))
+
+	// unpublish
+	assert.NoError(t, uc.PublishScheduled(ctx, unpublishAt))
+	got, _ = r.Project.FindByID(ctx, prj.ID())
+	assert.Equal(t, project.PublishmentStatusPrivate, got.PublishmentStatus())
+	assert.Nil(t, got.PublishSchedule())
+	assert.False(t, lo.Must(afero.Exists(mfs, filepath.Join("published", "aliasalias.json"))))
+
+	// clear schedule
+	_, _ = uc.SchedulePublish(ctx, interfaces.SchedulePublishProjectParam{ID: prj.ID(), UnpublishAt: &unpublishAt}, op)
+	got, err = uc.SchedulePublish(ctx, interfaces.SchedulePublishProjectParam{ID: prj.ID()}, op)
+	assert.NoError(t, err)
+	assert.Nil(t, got.PublishSchedule())
+}
+
+func TestProject_Preview(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	prj := project.New().NewID().Workspace(ws.ID()).Alias("aliasalias").MustBuild()
+	sid := scene.NewID()
+	rootLayer := layer.NewGroup().NewID().Scene(sid).MustBuild()
+	prop := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(rootLayer.ID()).Property(prop.ID()).MustBuild()
+
+	r := memory.New()
+	_ = r.Workspace.Save(ctx, ws)
+	_ = r.Project.Save(ctx, prj)
+	_ = r.Scene.Save(ctx, s)
+	_ = r.Layer.Save(ctx, rootLayer)
+	_ = r.Property.Save(ctx, prop)
+
+	mfs := afero.NewMemMapFs()
+	f, _ := fs.NewFile(mfs, "")
+	uc := NewProjectWithConfig(r, &gateway.Container{File: f}, ProjectConfig{PreviewURL: lo.Must(url.Parse("https://example.com/preview"))})
+	pub := NewPublished(r.Project, r.Storytelling, f, "<html><head></head></html>")
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+
+	// too long expiry
+	tooLate := time.Now().Add(8 * 24 * time.Hour)
+	_, _, err := uc.Preview(ctx, interfaces.PreviewProjectParam{ID: prj.ID(), ExpiresAt: &tooLate}, op)
+	assert.Same(t, interfaces.ErrInvalidPreviewExpiry, err)
+
+	got, u, err := uc.Preview(ctx, interfaces.PreviewProjectParam{ID: prj.ID()}, op)
+	assert.NoError(t, err)
+	token := got.Preview().Token()
+	assert.Equal(t, "https://example.com/preview/"+token+"/", u.String())
+	assert.Equal(t, project.PublishmentStatusPrivate, got.PublishmentStatus())
+	assert.False(t, lo.Must(afero.Exists(mfs, filepath.Join("published", "aliasalias.json"))))
+
+	data, etag, expiresAt, err := pub.PreviewData(ctx, token)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, lo.Must(io.ReadAll(data)))
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, got.Preview().ExpiresAt(), expiresAt)
+
+	index, _, err := pub.PreviewIndex(ctx, token, lo.Must(url.Parse("https://example.com/preview/"+token+"/")))
+	assert.NoError(t, err)
+	assert.Contains(t, index, `<meta name="robots" content="noindex,nofollow" />`)
+
+	// a new preview replaces the old one
+	got2, _, err := uc.Preview(ctx, interfaces.PreviewProjectParam{ID: prj.ID()}, op)
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, got2.Preview().Token())
+	_, _, _, err = pub.PreviewData(ctx, token)
+	assert.Same(t, rerror.ErrNotFound, err)
+
+	// expired
+	assert.NoError(t, uc.RemoveExpiredPreviews(ctx, got2.Preview().ExpiresAt()))
+	got, _ = r.Project.FindByID(ctx, prj.ID())
+	assert.Nil(t, got.Preview())
+	assert.False(t, lo.Must(afero.Exists(mfs, filepath.Join("previews", got2.Preview().Token()+".json"))))
+
+	// operation denied
+	_, _, err = uc.Preview(ctx, interfaces.PreviewProjectParam{ID: prj.ID()}, &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/history"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearthx/usecasex"
@@ -16,6 +18,8 @@ import (
 type Property struct {
 	common
 	commonSceneLock
+	commonAudit
+	commonHistory
 	propertyRepo       repo.Property
 	propertySchemaRepo repo.PropertySchema
 	datasetRepo        repo.Dataset
@@ -37,6 +41,8 @@ func NewProperty(r *repo.Container, gr *gateway.Container) interfaces.Property {
 		assetRepo:          r.Asset,
 		transaction:        r.Transaction,
 		file:               gr.File,
+		commonAudit:        newCommonAudit(r, gr),
+		commonHistory:      newCommonHistory(r),
 	}
 }
 
@@ -95,6 +101,9 @@ func (i *Property) UpdateValue(ctx context.Context, inp interfaces.UpdatePropert
 	if err := i.CanWriteScene(p.Scene(), operator); err != nil {
 		return nil, nil, nil, nil, err
 	}
+	if err := checkVersion(p.ID(), p.Version(), inp.ExpectedVersion); err != nil {
+		return nil, nil, nil, nil, err
+	}
 
 	if err := i.CheckSceneLock(ctx, p.Scene()); err != nil {
 		return nil, nil, nil, nil, err
@@ -105,6 +114,10 @@ func (i *Property) UpdateValue(ctx context.Context, inp interfaces.UpdatePropert
 		return nil, nil, nil, nil, err
 	}
 
+	prev := p.Clone()
+	prevField, _, _ := p.Field(inp.Pointer)
+	before := prevField.Value().Interface()
+
 	field, pgl, pg, err := p.UpdateValue(ps, inp.Pointer, inp.Value)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -115,6 +128,14 @@ func (i *Property) UpdateValue(ctx context.Context, inp interfaces.UpdatePropert
 		return nil, nil, nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionPropertyUpdateValue, p.Scene(), []auditlog.Target{auditlog.TargetOf(p.ID())},
+		propertyFieldChange(inp.Pointer, before, field.Value().Interface()),
+	); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if err := i.recordHistory(ctx, operator, p.Scene(), auditlog.ActionPropertyUpdateValue, history.PropertyChange(prev, p)); err != nil {
+		return nil, nil, nil, nil, err
+	}
 	tx.Commit()
 	return p, pgl, pg, field, nil
 }
@@ -144,6 +165,10 @@ func (i *Property) RemoveField(ctx context.Context, inp interfaces.RemovePropert
 		return nil, err
 	}
 
+	prev := p.Clone()
+	prevField, _, _ := p.Field(inp.Pointer)
+	before := prevField.Value().Interface()
+
 	p.RemoveField(inp.Pointer)
 	p.Prune()
 
@@ -152,10 +177,27 @@ func (i *Property) RemoveField(ctx context.Context, inp interfaces.RemovePropert
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionPropertyRemoveField, p.Scene(), []auditlog.Target{auditlog.TargetOf(p.ID())},
+		propertyFieldChange(inp.Pointer, before, nil),
+	); err != nil {
+		return nil, err
+	}
+	if err := i.recordHistory(ctx, operator, p.Scene(), auditlog.ActionPropertyRemoveField, history.PropertyChange(prev, p)); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return p, nil
 }
 
+// propertyFieldChange returns the change of the value of the field which the pointer points to.
+func propertyFieldChange(ptr *property.Pointer, before, after any) *auditlog.Change {
+	f, ok := ptr.Field()
+	if !ok {
+		return nil
+	}
+	return auditlog.NewChange(f.String(), before, after)
+}
+
 func (i *Property) LinkValue(ctx context.Context, inp interfaces.LinkPropertyValueParam, operator *usecase.Operator) (p *property.Property, pgl *property.GroupList, pg *property.Group, field *property.Field, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -211,6 +253,9 @@ func (i *Property) LinkValue(ctx context.Context, inp interfaces.LinkPropertyVal
 		return nil, nil, nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionPropertyLinkValue, p.Scene(), []auditlog.Target{auditlog.TargetOf(p.ID())}); err != nil {
+		return nil, nil, nil, nil, err
+	}
 	tx.Commit()
 	return p, pgl, pg, field, nil
 }
@@ -259,6 +304,9 @@ func (i *Property) UnlinkValue(ctx context.Context, inp interfaces.UnlinkPropert
 		return nil, nil, nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionPropertyUnlinkValue, p.Scene(), []auditlog.Target{auditlog.TargetOf(p.ID())}); err != nil {
+		return nil, nil, nil, nil, err
+	}
 	tx.Commit()
 	return p, pgl, pg, field, nil
 }
@@ -293,6 +341,7 @@ func (i *Property) AddItem(ctx context.Context, inp interfaces.AddPropertyItemPa
 		return nil, nil, nil, err
 	}
 
+	prev := p.Clone()
 	item, gl := p.AddListItem(ps, inp.Pointer, inp.Index)
 	if item == nil {
 		return nil, nil, nil, errors.New("failed to create item")
@@ -308,6 +357,12 @@ func (i *Property) AddItem(ctx context.Context, inp interfaces.AddPropertyItemPa
 		return nil, nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionPropertyAddItem, p.Scene(), []auditlog.Target{auditlog.TargetOf(p.ID()), auditlog.TargetOf(item.ID())}); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := i.recordHistory(ctx, operator, p.Scene(), auditlog.ActionPropertyAddItem, history.PropertyChange(prev, p)); err != nil {
+		return nil, nil, nil, err
+	}
 	tx.Commit()
 	return p, gl, item, nil
 }
@@ -337,6 +392,7 @@ func (i *Property) MoveItem(ctx context.Context, inp interfaces.MovePropertyItem
 		return nil, nil, nil, err
 	}
 
+	prev := p.Clone()
 	item, gl := p.MoveListItem(inp.Pointer, inp.Index)
 	if item == nil {
 		return nil, nil, nil, errors.New("failed to move item")
@@ -347,6 +403,12 @@ func (i *Property) MoveItem(ctx context.Context, inp interfaces.MovePropertyItem
 		return nil, nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionPropertyMoveItem, p.Scene(), []auditlog.Target{auditlog.TargetOf(p.ID()), auditlog.TargetOf(item.ID())}); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := i.recordHistory(ctx, operator, p.Scene(), auditlog.ActionPropertyMoveItem, history.PropertyChange(prev, p)); err != nil {
+		return nil, nil, nil, err
+	}
 	tx.Commit()
 	return p, gl, item, nil
 }
@@ -376,6 +438,7 @@ func (i *Property) RemoveItem(ctx context.Context, inp interfaces.RemoveProperty
 		return nil, err
 	}
 
+	prev := p.Clone()
 	if ok := p.RemoveListItem(inp.Pointer); !ok {
 		return nil, errors.New("failed to remove item")
 	}
@@ -385,11 +448,33 @@ func (i *Property) RemoveItem(ctx context.Context, inp interfaces.RemoveProperty
 		return nil, err
 	}
 
+	targets := []auditlog.Target{auditlog.TargetOf(p.ID())}
+	if item, ok := inp.Pointer.Item(); ok {
+		targets = append(targets, auditlog.TargetOf(item))
+	}
+	if err := i.auditScene(ctx, operator, auditlog.ActionPropertyRemoveItem, p.Scene(), targets); err != nil {
+		return nil, err
+	}
+	if err := i.recordHistory(ctx, operator, p.Scene(), auditlog.ActionPropertyRemoveItem, history.PropertyChange(prev, p)); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return p, nil
 }
 
-func (i *Property) UpdateItems(ctx context.Context, inp interfaces.UpdatePropertyItemsParam, operator *usecase.Operator) (*property.Property, error) {
+func (i *Property) UpdateItems(ctx context.Context, inp interfaces.UpdatePropertyItemsParam, operator *usecase.Operator) (_ *property.Property, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
 	p, err := i.propertyRepo.FindByID(ctx, inp.PropertyID)
 	if err != nil {
 		return nil, err
@@ -407,6 +492,7 @@ func (i *Property) UpdateItems(ctx context.Context, inp interfaces.UpdatePropert
 		return nil, err
 	}
 
+	prev := p.Clone()
 	for _, op := range inp.Operations {
 		var ptr *property.Pointer
 		if op.ItemID != nil {
@@ -430,5 +516,12 @@ func (i *Property) UpdateItems(ctx context.Context, inp interfaces.UpdatePropert
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionPropertyUpdateItems, p.Scene(), []auditlog.Target{auditlog.TargetOf(p.ID())}); err != nil {
+		return nil, err
+	}
+	if err := i.recordHistory(ctx, operator, p.Scene(), auditlog.ActionPropertyUpdateItems, history.PropertyChange(prev, p)); err != nil {
+		return nil, err
+	}
+	tx.Commit()
 	return p, nil
 }
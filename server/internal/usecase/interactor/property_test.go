@@ -7,6 +7,7 @@ import (
 	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/scene"
@@ -166,3 +167,51 @@ func TestProperty_UpdateValue_FieldOfGroupInList(t *testing.T) {
 	np2, _ := memory.Property.FindByID(ctx, p.ID())
 	assert.Equal(t, np, np2)
 }
+
+func TestProperty_UpdateValue_ExpectedVersion(t *testing.T) {
+	ctx := context.Background()
+	memory := memory.New()
+
+	ws := accountdomain.NewWorkspaceID()
+	scene := scene.New().NewID().Workspace(ws).RootLayer(id.NewLayerID()).MustBuild()
+	psf := property.NewSchemaField().ID("field").Type(property.ValueTypeString).MustBuild()
+	psg := property.NewSchemaGroup().ID("foobar").Fields([]*property.SchemaField{psf}).MustBuild()
+	ps := property.NewSchema().ID(property.MustSchemaID("xxx~1.1.1/aa")).
+		Groups(property.NewSchemaGroupList([]*property.SchemaGroup{psg})).
+		MustBuild()
+	p := property.New().NewID().Scene(scene.ID()).Schema(ps.ID()).MustBuild()
+	_ = memory.Scene.Save(ctx, scene)
+	_ = memory.PropertySchema.Save(ctx, ps)
+	_ = memory.Property.Save(ctx, p)
+
+	uc := &Property{
+		commonSceneLock:    commonSceneLock{sceneLockRepo: memory.SceneLock},
+		sceneRepo:          memory.Scene,
+		propertyRepo:       memory.Property,
+		propertySchemaRepo: memory.PropertySchema,
+		transaction:        memory.Transaction,
+	}
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{scene.ID()},
+	}
+	param := func(v string, expected int) interfaces.UpdatePropertyValueParam {
+		return interfaces.UpdatePropertyValueParam{
+			PropertyID:      p.ID(),
+			Pointer:         property.PointFieldBySchemaGroup(psg.ID(), psf.ID()),
+			Value:           property.ValueTypeString.ValueFrom(v),
+			ExpectedVersion: &expected,
+		}
+	}
+
+	np, _, _, _, err := uc.UpdateValue(ctx, param("a", 1), op)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, np.Version())
+
+	// another client still has version 1
+	_, _, _, _, err = uc.UpdateValue(ctx, param("b", 1), op)
+	assert.Equal(t, repo.NewVersionConflictError(p.ID(), 1), err)
+
+	np2, _ := memory.Property.FindByID(ctx, p.ID())
+	f, _, _ := np2.Field(property.PointFieldBySchemaGroup(psg.ID(), psf.ID()))
+	assert.Equal(t, property.ValueTypeString.ValueFrom("a"), f.Value())
+}
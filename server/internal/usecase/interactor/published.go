@@ -1,6 +1,7 @@
 package interactor
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
@@ -16,6 +17,8 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene/builder"
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/util"
@@ -43,33 +46,38 @@ func NewPublishedWithURL(project repo.Project, storytelling repo.Storytelling, f
 		project:      project,
 		file:         file,
 		Storytelling: storytelling,
-		indexHTML: util.NewCache(func(c context.Context, i string) (string, error) {
-			req, err := http.NewRequestWithContext(c, http.MethodGet, indexHTMLURL.String(), nil)
-			if err != nil {
-				return "", err
-			}
-			res, err := http.DefaultClient.Do(req)
-			if err != nil {
-				log.Errorfc(c, "published index: conn err: %s", err)
-				return "", errors.New("failed to fetch HTML")
-			}
-			defer func() {
-				_ = res.Body.Close()
-			}()
-			if res.StatusCode >= 300 {
-				log.Errorfc(c, "published index: status err: %d", res.StatusCode)
-				return "", errors.New("failed to fetch HTML")
-			}
-			str, err := io.ReadAll(res.Body)
-			if err != nil {
-				log.Errorfc(c, "published index: read err: %s", err)
-				return "", errors.New("failed to fetch HTML")
-			}
-			return string(str), nil
-		}, time.Hour),
+		indexHTML:    newIndexHTMLCache(indexHTMLURL),
 	}
 }
 
+// newIndexHTMLCache returns a cache of index HTML fetched from indexHTMLURL.
+func newIndexHTMLCache(indexHTMLURL *url.URL) *util.Cache[string] {
+	return util.NewCache(func(c context.Context, i string) (string, error) {
+		req, err := http.NewRequestWithContext(c, http.MethodGet, indexHTMLURL.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			log.Errorfc(c, "published index: conn err: %s", err)
+			return "", errors.New("failed to fetch HTML")
+		}
+		defer func() {
+			_ = res.Body.Close()
+		}()
+		if res.StatusCode >= 300 {
+			log.Errorfc(c, "published index: status err: %d", res.StatusCode)
+			return "", errors.New("failed to fetch HTML")
+		}
+		str, err := io.ReadAll(res.Body)
+		if err != nil {
+			log.Errorfc(c, "published index: read err: %s", err)
+			return "", errors.New("failed to fetch HTML")
+		}
+		return string(str), nil
+	}, time.Hour)
+}
+
 func (i *Published) Metadata(ctx context.Context, name string) (interfaces.ProjectPublishedMetadata, error) {
 	prj, err := i.project.FindByPublicName(ctx, name)
 	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
@@ -90,23 +98,110 @@ func (i *Published) Metadata(ctx context.Context, name string) (interfaces.Proje
 	return interfaces.PublishedMetadataFrom(prj), nil
 }
 
-func (i *Published) Data(ctx context.Context, name string) (io.Reader, error) {
+// Data returns the built data of the scene or the story with its strong ETag.
+// A scene built as chunks is assembled into a single document. The ETag is empty for data built as a single document.
+func (i *Published) Data(ctx context.Context, name string) (io.Reader, string, error) {
 	r, err := i.file.ReadBuiltSceneFile(ctx, name)
 	if err != nil && err != rerror.ErrNotFound {
-		return nil, err
+		return nil, "", err
 	}
 	if r != nil {
-		return r, nil
+		return i.assemble(ctx, r)
 	}
 
 	r, err = i.file.ReadStoryFile(ctx, name)
 	if err != nil && err != rerror.ErrNotFound {
-		return nil, err
+		return nil, "", err
 	}
 	if r != nil {
-		return r, nil
+		return i.assemble(ctx, r)
+	}
+	return nil, "", rerror.ErrNotFound
+}
+
+// Manifest returns the manifest of the scene built as chunks.
+func (i *Published) Manifest(ctx context.Context, name string) (*builder.Manifest, error) {
+	r, err := i.file.ReadBuiltSceneFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	m, ok, err := readManifest(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, rerror.ErrNotFound
+	}
+	return m, nil
+}
+
+// DataChunk returns a chunk of the built scene. Only chunks listed in the manifest of the scene can be read.
+func (i *Published) DataChunk(ctx context.Context, name, hash string) (io.Reader, string, error) {
+	m, err := i.Manifest(ctx, name)
+	if err != nil {
+		return nil, "", err
+	}
+	if !m.Has(hash) {
+		return nil, "", rerror.ErrNotFound
+	}
+
+	r, err := i.file.ReadBuiltSceneChunk(ctx, hash)
+	if err != nil {
+		return nil, "", err
+	}
+	return r, `"` + hash + `"`, nil
+}
+
+// assemble returns a reader of the single document which is assembled from chunks when r is a manifest, or r as it is.
+func (i *Published) assemble(ctx context.Context, r io.ReadCloser) (io.Reader, string, error) {
+	br := bufio.NewReader(r)
+	m, ok, err := readManifest(br)
+	if err != nil {
+		_ = r.Close()
+		return nil, "", err
+	}
+	if !ok {
+		return readCloser{Reader: br, Closer: r}, "", nil
+	}
+	_ = r.Close()
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(m.Assemble(ctx, pw, i.file.ReadBuiltSceneChunk))
+	}()
+	return pr, m.ETag(), nil
+}
+
+var manifestPrefix = []byte(`{"type":"manifest"`)
+
+// readManifest reads a manifest from r only when r starts with a manifest, so that large data built as a single document is not read.
+func readManifest(r *bufio.Reader) (*builder.Manifest, bool, error) {
+	prefix, err := r.Peek(len(manifestPrefix))
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, false, err
 	}
-	return nil, rerror.ErrNotFound
+	if !bytes.Equal(prefix, manifestPrefix) {
+		return nil, false, nil
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false, err
+	}
+	m, err := builder.ParseManifest(b)
+	if err != nil {
+		return nil, false, err
+	}
+	return m, true, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
 }
 
 func (i *Published) Index(ctx context.Context, name string, u *url.URL) (string, error) {
@@ -144,6 +239,57 @@ func (i *Published) Index(ctx context.Context, name string, u *url.URL) (string,
 	return htmlStr, nil
 }
 
+// PreviewData returns the built data of the preview, its strong ETag and when it expires. An expired preview is not found.
+func (i *Published) PreviewData(ctx context.Context, token string) (io.Reader, string, time.Time, error) {
+	prj, err := i.findPreviewProject(ctx, token)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	r, err := i.file.ReadPreviewFile(ctx, prj.Preview().Token())
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	res, etag, err := i.assemble(ctx, r)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	return res, etag, prj.Preview().ExpiresAt(), nil
+}
+
+// PreviewIndex returns index HTML for the preview and when it expires. Previews are never indexed by search engines.
+func (i *Published) PreviewIndex(ctx context.Context, token string, u *url.URL) (string, time.Time, error) {
+	htmlStr := i.indexHTMLStr
+	if i.indexHTML != nil {
+		htmlCachedStr, err := i.indexHTML.Get(ctx)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		htmlStr = htmlCachedStr
+	}
+
+	prj, err := i.findPreviewProject(ctx, token)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	md := interfaces.PublishedMetadataFrom(prj)
+	md.Noindex = true
+	return renderIndex(htmlStr, u.String(), md), prj.Preview().ExpiresAt(), nil
+}
+
+func (i *Published) findPreviewProject(ctx context.Context, token string) (*project.Project, error) {
+	prj, err := i.project.FindByPreviewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if prj.Preview().IsExpired(util.Now()) {
+		return nil, rerror.ErrNotFound
+	}
+	return prj, nil
+}
+
 const headers = `{{if .title}}  <meta name="twitter:title" content="{{.title}}" />
   <meta property="og:title" content="{{.title}}" />{{end}}{{if .description}}
   <meta name="twitter:description" content="{{.description}}" />
@@ -1,9 +1,19 @@
 package interactor
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/scene/builder"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,3 +43,48 @@ func TestRenderIndex(t *testing.T) {
 		},
 	))
 }
+
+func TestPublished_Data(t *testing.T) {
+	ctx := context.Background()
+	mfs := afero.NewMemMapFs()
+	f, _ := fs.NewFile(mfs, "")
+	uc := NewPublished(memory.New().Project, memory.New().Storytelling, f, "")
+
+	// built as a single document
+	_ = f.UploadBuiltScene(ctx, strings.NewReader(`{"schemaVersion":1,"id":"legacy"}`), "legacy")
+	r, etag, err := uc.Data(ctx, "legacy")
+	assert.NoError(t, err)
+	assert.Empty(t, etag)
+	assert.Equal(t, `{"schemaVersion":1,"id":"legacy"}`, string(lo.Must(io.ReadAll(r))))
+	_, err = uc.Manifest(ctx, "legacy")
+	assert.Same(t, rerror.ErrNotFound, err)
+
+	// built as chunks
+	core := builder.NewChunk([]byte(`{"schemaVersion":1,"id":"chunked","nlsLayers":null,"layerStyles":null}`))
+	layer := builder.NewChunk([]byte(`{"id":"layer"}`))
+	for _, c := range []builder.Chunk{core, layer} {
+		_ = f.UploadBuiltSceneChunk(ctx, bytes.NewReader(c.Data), c.Hash)
+	}
+	m := &builder.Manifest{Type: "manifest", SchemaVersion: 1, Scene: core.Hash, NLSLayers: []string{layer.Hash}}
+	_ = f.UploadBuiltScene(ctx, bytes.NewReader(lo.Must(m.Bytes())), "chunked")
+
+	r, etag, err = uc.Data(ctx, "chunked")
+	assert.NoError(t, err)
+	assert.Equal(t, m.ETag(), etag)
+	assert.JSONEq(t, `{"schemaVersion":1,"id":"chunked","nlsLayers":[{"id":"layer"}],"layerStyles":null}`, string(lo.Must(io.ReadAll(r))))
+
+	got, err := uc.Manifest(ctx, "chunked")
+	assert.NoError(t, err)
+	assert.Equal(t, m, got)
+
+	r, etag, err = uc.DataChunk(ctx, "chunked", layer.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, `"`+layer.Hash+`"`, etag)
+	assert.Equal(t, `{"id":"layer"}`, string(lo.Must(io.ReadAll(r))))
+
+	// chunks of other scenes cannot be read
+	other := builder.NewChunk([]byte(`{"id":"other"}`))
+	_ = f.UploadBuiltSceneChunk(ctx, bytes.NewReader(other.Data), other.Hash)
+	_, _, err = uc.DataChunk(ctx, "chunked", other.Hash)
+	assert.Same(t, rerror.ErrNotFound, err)
+}
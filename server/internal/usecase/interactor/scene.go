@@ -8,6 +8,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
 	"github.com/reearth/reearth/server/pkg/builtin"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/layer"
@@ -22,6 +23,7 @@ import (
 
 type Scene struct {
 	common
+	commonAudit
 	sceneRepo          repo.Scene
 	propertyRepo       repo.Property
 	propertySchemaRepo repo.PropertySchema
@@ -32,6 +34,7 @@ type Scene struct {
 	transaction        usecasex.Transaction
 	file               gateway.File
 	pluginRegistry     gateway.PluginRegistry
+	pubsub             gateway.PubSub
 	extensions         []plugin.ID
 }
 
@@ -47,7 +50,9 @@ func NewScene(r *repo.Container, g *gateway.Container) interfaces.Scene {
 		transaction:        r.Transaction,
 		file:               g.File,
 		pluginRegistry:     g.PluginRegistry,
+		pubsub:             g.PubSub,
 		extensions:         r.Extensions,
+		commonAudit:        newCommonAudit(r, g),
 	}
 }
 
@@ -90,6 +95,16 @@ func (i *Scene) FindByProject(ctx context.Context, id id.ProjectID, operator *us
 	return s, nil
 }
 
+func (i *Scene) SubscribeEvents(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (<-chan gateway.SceneEvent, error) {
+	if err := i.CanReadScene(sid, operator); err != nil {
+		return nil, err
+	}
+	if i.pubsub == nil {
+		return nil, rerror.ErrNotImplemented
+	}
+	return i.pubsub.SubscribeSceneEvents(ctx, sid)
+}
+
 func (i *Scene) Create(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (_ *scene.Scene, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -270,6 +285,9 @@ func (i *Scene) AddWidget(ctx context.Context, sid id.SceneID, pid id.PluginID,
 		return nil, nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneAddWidget, s.Workspace(), &sid, []auditlog.Target{auditlog.TargetOf(widget.ID())}); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return s, widget, nil
 }
@@ -294,12 +312,16 @@ func (i *Scene) UpdateWidget(ctx context.Context, param interfaces.UpdateWidgetP
 	if err := i.CanWriteWorkspace(scene.Workspace(), operator); err != nil {
 		return nil, nil, err
 	}
+	if err := checkVersion(scene.ID(), scene.Version(), param.ExpectedVersion); err != nil {
+		return nil, nil, err
+	}
 
 	widget := scene.Widgets().Widget(param.WidgetID)
 	if widget == nil {
 		return nil, nil, rerror.ErrNotFound
 	}
 	_, location := scene.Widgets().Alignment().Find(param.WidgetID)
+	prevLocation, prevEnabled, prevExtended := location, widget.Enabled(), widget.Extended()
 
 	pr, err := i.pluginRepo.FindByID(ctx, widget.Plugin())
 	if err != nil {
@@ -354,6 +376,13 @@ func (i *Scene) UpdateWidget(ctx context.Context, param interfaces.UpdateWidgetP
 		return nil, nil, err2
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneUpdateWidget, scene.Workspace(), &param.SceneID, []auditlog.Target{auditlog.TargetOf(widget.ID())},
+		auditlog.NewChange("enabled", prevEnabled, widget.Enabled()),
+		auditlog.NewChange("extended", prevExtended, widget.Extended()),
+		auditlog.NewChange("location", prevLocation, location),
+	); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return scene, widget, nil
 }
@@ -378,6 +407,9 @@ func (i *Scene) UpdateWidgetAlignSystem(ctx context.Context, param interfaces.Up
 	if err := i.CanWriteWorkspace(s.Workspace(), operator); err != nil {
 		return nil, err
 	}
+	if err := checkVersion(s.ID(), s.Version(), param.ExpectedVersion); err != nil {
+		return nil, err
+	}
 
 	area := s.Widgets().Alignment().Area(param.Location)
 
@@ -406,6 +438,9 @@ func (i *Scene) UpdateWidgetAlignSystem(ctx context.Context, param interfaces.Up
 		return nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneUpdateAlignSystem, s.Workspace(), &param.SceneID, []auditlog.Target{auditlog.TargetOf(s.ID())}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return s, nil
 }
@@ -451,6 +486,9 @@ func (i *Scene) RemoveWidget(ctx context.Context, id id.SceneID, wid id.WidgetID
 		return nil, err2
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneRemoveWidget, scene.Workspace(), &id, []auditlog.Target{auditlog.TargetOf(wid)}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return scene, nil
 }
@@ -497,6 +535,9 @@ func (i *Scene) AddCluster(ctx context.Context, sceneID id.SceneID, name string,
 		return nil, nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneAddCluster, s.Workspace(), &sceneID, []auditlog.Target{auditlog.TargetOf(cid)}); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return s, cluster, nil
 }
@@ -526,6 +567,7 @@ func (i *Scene) UpdateCluster(ctx context.Context, param interfaces.UpdateCluste
 	if cluster == nil {
 		return nil, nil, rerror.ErrNotFound
 	}
+	prevName := cluster.Name()
 	if param.Name != nil {
 		cluster.Rename(*param.Name)
 	}
@@ -537,6 +579,11 @@ func (i *Scene) UpdateCluster(ctx context.Context, param interfaces.UpdateCluste
 		return nil, nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneUpdateCluster, s.Workspace(), &param.SceneID, []auditlog.Target{auditlog.TargetOf(param.ClusterID)},
+		auditlog.NewChange("name", prevName, cluster.Name()),
+	); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return s, cluster, nil
 }
@@ -568,6 +615,9 @@ func (i *Scene) RemoveCluster(ctx context.Context, sceneID id.SceneID, clusterID
 		return nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneRemoveCluster, s.Workspace(), &sceneID, []auditlog.Target{auditlog.TargetOf(clusterID)}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return s, nil
 }
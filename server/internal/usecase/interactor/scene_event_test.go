@@ -0,0 +1,78 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScene_SubscribeEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := memory.New()
+	g := &gateway.Container{PubSub: memory.NewPubSub()}
+
+	s := scene.New().NewID().Workspace(accountdomain.NewWorkspaceID()).RootLayer(id.NewLayerID()).MustBuild()
+	psg := property.NewSchemaGroup().ID("foobar").IsList(true).MustBuild()
+	ps := property.NewSchema().ID(property.MustSchemaID("xxx~1.1.1/aa")).
+		Groups(property.NewSchemaGroupList([]*property.SchemaGroup{psg})).
+		MustBuild()
+	p := property.New().NewID().Scene(s.ID()).Schema(ps.ID()).MustBuild()
+	_ = r.Scene.Save(ctx, s)
+	_ = r.PropertySchema.Save(ctx, ps)
+	_ = r.Property.Save(ctx, p)
+
+	suc := NewScene(r, g)
+	puc := NewProperty(r, g)
+	op := historyTestOperator(s.ID())
+
+	_, err := suc.SubscribeEvents(ctx, s.ID(), &usecase.Operator{})
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+	_, err = NewScene(r, &gateway.Container{}).SubscribeEvents(ctx, s.ID(), op)
+	assert.Equal(t, rerror.ErrNotImplemented, err)
+
+	events, err := suc.SubscribeEvents(ctx, s.ID(), op)
+	assert.NoError(t, err)
+
+	_, _, item, err := puc.AddItem(ctx, interfaces.AddPropertyItemParam{
+		PropertyID: p.ID(),
+		Pointer:    property.PointItemBySchema(psg.ID()),
+		Index:      lo.ToPtr(-1),
+	}, op)
+	assert.NoError(t, err)
+
+	e := <-events
+	assert.Equal(t, s.ID(), e.Scene)
+	assert.Equal(t, auditlog.ActionPropertyAddItem, e.Action)
+	assert.Equal(t, []auditlog.Target{auditlog.TargetOf(p.ID()), auditlog.TargetOf(item.ID())}, e.Targets)
+	assert.Equal(t, op.UserID(), e.Actor)
+
+	// events of mutations which are rolled back are not published
+	r.Transaction = repo.TransactionWithAfterCommit(&usecasex.NopTransaction{CommitError: errors.New("conflict")})
+	_, _, _, err = NewProperty(r, g).AddItem(ctx, interfaces.AddPropertyItemParam{
+		PropertyID: p.ID(),
+		Pointer:    property.PointItemBySchema(psg.ID()),
+		Index:      lo.ToPtr(-1),
+	}, op)
+	assert.Error(t, err)
+	assert.Empty(t, events)
+
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+}
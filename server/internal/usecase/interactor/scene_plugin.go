@@ -7,6 +7,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/layer/layerops"
 	"github.com/reearth/reearth/server/pkg/property"
@@ -76,6 +77,9 @@ func (i *Scene) InstallPlugin(ctx context.Context, sid id.SceneID, pid id.Plugin
 		return nil, nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneInstallPlugin, s.Workspace(), &sid, []auditlog.Target{auditlog.PluginTarget(pid)}); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return s, p.IDRef(), nil
 }
@@ -172,6 +176,9 @@ func (i *Scene) UninstallPlugin(ctx context.Context, sid id.SceneID, pid id.Plug
 		}
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneUninstallPlugin, scene.Workspace(), &sid, []auditlog.Target{auditlog.PluginTarget(pid)}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return scene, nil
 }
@@ -237,6 +244,11 @@ func (i *Scene) UpgradePlugin(ctx context.Context, sid id.SceneID, oldPluginID,
 		return nil, err
 	}
 
+	if err := i.audit(ctx, operator, auditlog.ActionSceneUpgradePlugin, s.Workspace(), &sid, []auditlog.Target{auditlog.PluginTarget(newPluginID)},
+		auditlog.NewChange("plugin", oldPluginID.String(), newPluginID.String()),
+	); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return result.Scene, err
 }
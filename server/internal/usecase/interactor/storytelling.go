@@ -10,14 +10,19 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearth/server/pkg/builtin"
+	"github.com/reearth/reearth/server/pkg/history"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/revision"
 	scene2 "github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/builder"
 	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/reearth/reearthx/account/accountusecase/accountrepo"
+	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
 	"github.com/samber/lo"
@@ -26,6 +31,9 @@ import (
 type Storytelling struct {
 	common
 	commonSceneLock
+	commonPolicy
+	commonAudit
+	commonHistory
 	storytellingRepo repo.Storytelling
 	pluginRepo       repo.Plugin
 	propertyRepo     repo.Property
@@ -40,11 +48,12 @@ type Storytelling struct {
 	transaction      usecasex.Transaction
 	nlsLayerRepo     repo.NLSLayer
 	layerStyles      repo.Style
+	revisionRepo     repo.Revision
 }
 
 func NewStorytelling(r *repo.Container, gr *gateway.Container) interfaces.Storytelling {
 	return &Storytelling{
-		commonSceneLock:  commonSceneLock{sceneLockRepo: r.SceneLock},
+		commonSceneLock:  commonSceneLock{sceneLockRepo: r.SceneLock, lockPubSub: gr.PubSub},
 		storytellingRepo: r.Storytelling,
 		pluginRepo:       r.Plugin,
 		propertyRepo:     r.Property,
@@ -59,6 +68,15 @@ func NewStorytelling(r *repo.Container, gr *gateway.Container) interfaces.Storyt
 		transaction:      r.Transaction,
 		nlsLayerRepo:     r.NLSLayer,
 		layerStyles:      r.Style,
+		revisionRepo:     r.Revision,
+		commonPolicy: commonPolicy{
+			sceneRepo:        r.Scene,
+			workspaceRepo:    r.Workspace,
+			policyRepo:       r.Policy,
+			storytellingRepo: r.Storytelling,
+		},
+		commonAudit:   newCommonAudit(r, gr),
+		commonHistory: newCommonHistory(r),
 	}
 }
 
@@ -87,6 +105,21 @@ func (i *Storytelling) Create(ctx context.Context, inp interfaces.CreateStoryInp
 		return nil, interfaces.ErrOperationDenied
 	}
 
+	// enforce policy
+	p, _, err := i.scenePolicy(ctx, inp.SceneID, op)
+	if err != nil {
+		return nil, err
+	}
+	if p != nil {
+		stories, _, err := i.countStories(ctx, inp.SceneID)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.EnforceStoryCount(stories + 1); err != nil {
+			return nil, err
+		}
+	}
+
 	schema := builtin.GetPropertySchema(builtin.PropertySchemaIDStory)
 	prop, err := property.New().NewID().Schema(schema.ID()).Scene(inp.SceneID).Build()
 	if err != nil {
@@ -111,10 +144,13 @@ func (i *Storytelling) Create(ctx context.Context, inp interfaces.CreateStoryInp
 		return nil, err
 	}
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryCreate, inp.SceneID, []auditlog.Target{auditlog.TargetOf(story.Id())}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return story, nil
 }
@@ -139,7 +175,11 @@ func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInp
 	if err := i.CanWriteScene(story.Scene(), op); err != nil {
 		return nil, err
 	}
+	if err := checkVersion(story.Id(), story.Version(), inp.ExpectedVersion); err != nil {
+		return nil, err
+	}
 
+	before := *story
 	if inp.Title != nil && *inp.Title != "" {
 		story.Rename(*inp.Title)
 	}
@@ -183,7 +223,7 @@ func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInp
 
 	// TODO: Handel ordering
 
-	err = i.storytellingRepo.Save(ctx, *story)
+	err = i.storytellingRepo.Save(ctx, story)
 	if err != nil {
 		return nil, err
 	}
@@ -197,10 +237,28 @@ func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInp
 		}
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryUpdate, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id())}, storyChanges(&before, story)...); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return story, nil
 }
 
+// storyChanges returns the changes of the fields of the story which can be updated.
+func storyChanges(before, after *storytelling.Story) []*auditlog.Change {
+	return []*auditlog.Change{
+		auditlog.NewChange("title", before.Title(), after.Title()),
+		auditlog.NewChange("alias", before.Alias(), after.Alias()),
+		auditlog.NewChange("isBasicAuthActive", before.IsBasicAuthActive(), after.IsBasicAuthActive()),
+		auditlog.NewChange("publicTitle", before.PublicTitle(), after.PublicTitle()),
+		auditlog.NewChange("publicDescription", before.PublicDescription(), after.PublicDescription()),
+		auditlog.NewChange("publicImage", before.PublicImage(), after.PublicImage()),
+		auditlog.NewChange("publicNoIndex", before.PublicNoIndex(), after.PublicNoIndex()),
+		auditlog.NewChange("panelPosition", string(before.PanelPosition()), string(after.PanelPosition())),
+		auditlog.NewChange("bgColor", before.BgColor(), after.BgColor()),
+	}
+}
+
 func (i *Storytelling) Remove(ctx context.Context, inp interfaces.RemoveStoryInput, op *usecase.Operator) (*id.StoryID, error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -224,10 +282,26 @@ func (i *Storytelling) Remove(ctx context.Context, inp interfaces.RemoveStoryInp
 
 	// TODO: Handel ordering
 
+	revisions, err := i.revisionRepo.FindByStory(ctx, inp.StoryID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rev := range revisions {
+		if err := i.file.RemoveRevision(ctx, rev.FileName()); err != nil {
+			return nil, err
+		}
+	}
+	if err := i.revisionRepo.RemoveByStory(ctx, inp.StoryID); err != nil {
+		return nil, err
+	}
+
 	if err := i.storytellingRepo.Remove(ctx, inp.StoryID); err != nil {
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryRemove, story.Scene(), []auditlog.Target{auditlog.TargetOf(inp.StoryID)}); err != nil {
+		return nil, err
+	}
 	return &inp.StoryID, nil
 }
 
@@ -268,30 +342,9 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 	// enableGa := prj.EnableGA()
 	// trackingId := prj.TrackingID()
 
-	//
-	// Commenting this out till the point we make a decision on this: @pyshx
-	//
-	// ws, err := i.workspaceRepo.FindByID(ctx, scene.Workspace())
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	// if story.PublishmentStatus() == storytelling.PublishmentStatusPrivate {
-	// 	// enforce policy
-	// 	if policyID := op.Policy(ws.Policy()); policyID != nil {
-	// 		p, err := i.policyRepo.FindByID(ctx, *policyID)
-	// 		if err != nil {
-	// 			return nil, err
-	// 		}
-	// 		s, err := i.projectRepo.CountPublicByWorkspace(ctx, ws.ID())
-	// 		if err != nil {
-	// 			return nil, err
-	// 		}
-	// 		if err := p.EnforcePublishedProjectCount(s + 1); err != nil {
-	// 			return nil, err
-	// 		}
-	// 	}
-	// }
+	if err := i.enforcePublishedStoryCount(ctx, story, inp.Status, op); err != nil {
+		return nil, err
+	}
 
 	nlsLayers, err := i.nlsLayerRepo.FindByScene(ctx, story.Scene())
 	if err != nil {
@@ -314,17 +367,21 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 	}
 
 	newAlias := prevAlias
-	if inp.Alias != nil && *inp.Alias != prevAlias {
-		if publishedStory, err := i.storytellingRepo.FindByPublicName(ctx, *inp.Alias); err != nil && !errors.Is(rerror.ErrNotFound, err) {
+	if inp.Alias != nil {
+		newAlias = *inp.Alias
+	}
+
+	// the alias kept by the story may have been taken by another story while this one was private
+	if newAlias != "" && newAlias != prevPublishedAlias {
+		if err := i.checkAliasOwner(ctx, story, newAlias); err != nil {
 			return nil, err
-		} else if publishedStory != nil && story.Id() != publishedStory.Id() {
-			return nil, interfaces.ErrProjectAliasAlreadyUsed
 		}
+	}
 
+	if inp.Alias != nil && *inp.Alias != prevAlias {
 		if err := story.UpdateAlias(*inp.Alias); err != nil {
 			return nil, err
 		}
-		newAlias = *inp.Alias
 	}
 
 	// Lock
@@ -334,6 +391,8 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 
 	defer i.ReleaseSceneLock(ctx, scene.ID())
 
+	publishedAt := time.Now()
+
 	if inp.Status == storytelling.PublishmentStatusPrivate {
 		// unpublish
 		if err = i.file.RemoveStory(ctx, prevPublishedAlias); err != nil {
@@ -341,6 +400,27 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 		}
 	} else {
 		// publish
+		revisions, err := i.revisionRepo.FindByStory(ctx, story.Id())
+		if err != nil {
+			return nil, err
+		}
+
+		rev, err := revision.New().
+			NewID().
+			Story(story.Id()).
+			Scene(scene.ID()).
+			Workspace(scene.Workspace()).
+			Number(revisions.NextNumber()).
+			Alias(newAlias).
+			Status(string(inp.Status)).
+			SchemaVersion(builder.SchemaVersion).
+			PublishedBy(op.UserID()).
+			PublishedAt(publishedAt).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
 		r, w := io.Pipe()
 
 		// Build
@@ -359,11 +439,19 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 				repo.TagLoaderFrom(i.tagRepo),
 				repo.TagSceneLoaderFrom(i.tagRepo, scenes),
 				repo.NLSLayerLoaderFrom(i.nlsLayerRepo),
-			).ForScene(scene).WithNLSLayers(&nlsLayers).WithLayerStyle(layerStyles).WithStory(story).Build(ctx, w, time.Now(), true, false, "")
+			).ForScene(scene).WithNLSLayers(&nlsLayers).WithLayerStyle(layerStyles).WithStory(story).Build(ctx, w, publishedAt, true, false, "")
 		}()
 
-		// Save
-		if err := i.file.UploadStory(ctx, r, newAlias); err != nil {
+		// Save the build as a new revision and point the alias to it
+		if err := i.file.UploadRevision(ctx, r, rev.FileName()); err != nil {
+			return nil, err
+		}
+
+		if err := i.uploadRevision(ctx, rev, newAlias); err != nil {
+			return nil, err
+		}
+
+		if err := i.revisionRepo.Save(ctx, rev); err != nil {
 			return nil, err
 		}
 
@@ -376,13 +464,328 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 		}
 	}
 
+	prevStatus := story.PublishmentStatus()
 	story.UpdatePublishmentStatus(inp.Status)
-	story.SetPublishedAt(time.Now())
+	story.SetPublishedAt(publishedAt)
+
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
+		return nil, err
+	}
+
+	action := auditlog.ActionStoryPublish
+	if inp.Status == storytelling.PublishmentStatusPrivate {
+		action = auditlog.ActionStoryUnpublish
+	}
+	if err := i.audit(ctx, op, action, scene.Workspace(), lo.ToPtr(scene.ID()), []auditlog.Target{auditlog.TargetOf(story.Id())},
+		auditlog.NewChange("publishmentStatus", string(prevStatus), string(story.PublishmentStatus())),
+		auditlog.NewChange("alias", prevAlias, story.Alias()),
+	); err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return story, nil
+}
+
+func (i *Storytelling) SchedulePublish(ctx context.Context, inp interfaces.SchedulePublishStoryInput, op *usecase.Operator) (_ *storytelling.Story, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	story, err := i.storytellingRepo.FindByID(ctx, inp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteScene(story.Scene(), op); err != nil {
+		return nil, err
+	}
+
+	var schedule *storytelling.PublishSchedule
+	if inp.PublishAt != nil || inp.UnpublishAt != nil {
+		if inp.PublishAt != nil && story.Alias() == "" {
+			return nil, interfaces.ErrProjectAliasIsNotSet
+		}
+
+		schedule, err = storytelling.NewPublishSchedule(inp.Status, inp.PublishAt, inp.UnpublishAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	before := story.PublishSchedule()
+	story.SetPublishSchedule(schedule)
+
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
+		return nil, err
+	}
+
+	if err := i.auditScene(ctx, op, auditlog.ActionStorySchedulePublish, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id())},
+		auditlog.NewChange("publishAt", before.PublishAt(), schedule.PublishAt()),
+		auditlog.NewChange("unpublishAt", before.UnpublishAt(), schedule.UnpublishAt()),
+	); err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return story, nil
+}
+
+// PublishScheduled publishes or unpublishes stories whose publish schedule is due at now.
+// A fired schedule is advanced even if publishing fails so that it will not be retried forever,
+// except when the scene is locked, in which case it is retried on the next run.
+func (i *Storytelling) PublishScheduled(ctx context.Context, now time.Time) error {
+	stories, err := i.storytellingRepo.FindByPublishScheduleDue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, story := range *stories {
+		status, ok := story.PublishSchedule().Due(now)
+		if !ok {
+			continue
+		}
+
+		s, err := i.sceneRepo.FindByID(ctx, story.Scene())
+		if err != nil {
+			return err
+		}
+
+		if _, err := i.Publish(ctx, interfaces.PublishStoryInput{
+			ID:     story.Id(),
+			Status: status,
+		}, scheduledPublishOperator(s.Workspace(), s.ID())); err != nil {
+			if isSceneLocked(err) {
+				continue
+			}
+			log.Errorfc(ctx, "publish schedule: failed to publish story (%s): %s", story.Id(), err)
+		}
+
+		if err := i.advancePublishSchedule(ctx, story.Id(), now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *Storytelling) advancePublishSchedule(ctx context.Context, sid id.StoryID, now time.Time) error {
+	story, err := i.storytellingRepo.FindByID(ctx, sid)
+	if err != nil {
+		return err
+	}
+
+	story.SetPublishSchedule(story.PublishSchedule().Advance(now))
+	return i.storytellingRepo.Save(ctx, story)
+}
+
+func (i *Storytelling) FindRevisions(ctx context.Context, sid id.StoryID, op *usecase.Operator) (revision.List, error) {
+	story, err := i.storytellingRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadScene(story.Scene(), op); err != nil {
+		return nil, err
+	}
+
+	return i.revisionRepo.FindByStory(ctx, sid)
+}
+
+func (i *Storytelling) Rollback(ctx context.Context, inp interfaces.RollbackStoryInput, op *usecase.Operator) (*storytelling.Story, error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	story, err := i.storytellingRepo.FindByID(ctx, inp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteScene(story.Scene(), op); err != nil {
+		return nil, err
+	}
+
+	alias := story.Alias()
+	if alias == "" {
+		return nil, interfaces.ErrProjectAliasIsNotSet
+	}
+	// the revision is uploaded to the alias, which may have been taken by another story while this one was private
+	if err := i.checkAliasOwner(ctx, story, alias); err != nil {
+		return nil, err
+	}
+
+	revisions, err := i.revisionRepo.FindByStory(ctx, story.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	rev := revisions.FindByNumber(inp.Revision)
+	if rev == nil {
+		return nil, rerror.ErrNotFound
+	}
+
+	status := storytelling.PublishmentStatus(rev.Status())
+	if err := i.enforcePublishedStoryCount(ctx, story, status, op); err != nil {
+		return nil, err
+	}
+
+	if err := i.UpdateSceneLock(ctx, story.Scene(), scene2.LockModeFree, scene2.LockModePublishing); err != nil {
+		return nil, err
+	}
+
+	defer i.ReleaseSceneLock(ctx, story.Scene())
+
+	if err := i.uploadRevision(ctx, rev, alias); err != nil {
+		return nil, err
+	}
+
+	prevStatus := story.PublishmentStatus()
+	story.UpdatePublishmentStatus(status)
+	story.SetPublishedAt(rev.PublishedAt())
+
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
+		return nil, err
+	}
+
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryRollback, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(rev.ID())},
+		auditlog.NewChange("publishmentStatus", string(prevStatus), string(story.PublishmentStatus())),
+	); err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return story, nil
+}
+
+// enforcePublishedStoryCount checks that the story can be published with the status under the policy of its workspace.
+func (i *Storytelling) enforcePublishedStoryCount(ctx context.Context, story *storytelling.Story, status storytelling.PublishmentStatus, op *usecase.Operator) error {
+	if isPublishedStory(story.PublishmentStatus()) || !isPublishedStory(status) {
+		return nil
+	}
+	p, s, err := i.scenePolicy(ctx, story.Scene(), op)
+	if err != nil || p == nil {
+		return err
+	}
+	n, err := i.countPublishedStories(ctx, s.Workspace())
+	if err != nil {
+		return err
+	}
+	return p.EnforcePublishedStoryCount(n + 1)
+}
+
+// checkAliasOwner returns ErrProjectAliasAlreadyUsed if another story is published with the alias.
+func (i *Storytelling) checkAliasOwner(ctx context.Context, story *storytelling.Story, alias string) error {
+	published, err := i.storytellingRepo.FindByPublicName(ctx, alias)
+	if err != nil && !errors.Is(rerror.ErrNotFound, err) {
+		return err
+	}
+	if published != nil && story.Id() != published.Id() {
+		return interfaces.ErrProjectAliasAlreadyUsed
+	}
+	return nil
+}
+
+// uploadRevision points the alias to the built data of the revision without rebuilding the story.
+func (i *Storytelling) uploadRevision(ctx context.Context, rev *revision.Revision, alias string) error {
+	r, err := i.file.ReadRevisionFile(ctx, rev.FileName())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	return i.file.UploadStory(ctx, r, alias)
+}
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+func (i *Storytelling) AddBasicAuthCredential(ctx context.Context, inp interfaces.AddStoryBasicAuthCredentialInput, op *usecase.Operator) (_ *storytelling.Story, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
 		return nil, err
 	}
 
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	story, err := i.storytellingRepo.FindByID(ctx, inp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteScene(story.Scene(), op); err != nil {
+		return nil, err
+	}
+
+	c, err := basicauth.NewCredential(inp.Name, inp.Username, inp.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := story.AddBasicAuthCredential(c); err != nil {
+		return nil, err
+	}
+
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
+		return nil, err
+	}
+
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryAddBasicAuthCredential, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id())},
+		auditlog.NewChange("basicAuthCredential", nil, c.Name()),
+	); err != nil {
+		return nil, err
+	}
+	tx.Commit()
+	return story, nil
+}
+
+func (i *Storytelling) RevokeBasicAuthCredential(ctx context.Context, inp interfaces.RevokeStoryBasicAuthCredentialInput, op *usecase.Operator) (_ *storytelling.Story, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	story, err := i.storytellingRepo.FindByID(ctx, inp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteScene(story.Scene(), op); err != nil {
+		return nil, err
+	}
+
+	if err := story.RevokeBasicAuthCredential(inp.Name); err != nil {
+		return nil, err
+	}
+
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
+		return nil, err
+	}
+
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryRevokeBasicAuthCredential, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id())},
+		auditlog.NewChange("basicAuthCredential", inp.Name, nil),
+	); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return story, nil
 }
@@ -408,6 +811,10 @@ func (i *Storytelling) CreatePage(ctx context.Context, inp interfaces.CreatePage
 		return nil, nil, interfaces.ErrOperationDenied
 	}
 
+	if err := i.enforceStoryPageCount(ctx, inp.SceneID, op); err != nil {
+		return nil, nil, err
+	}
+
 	schema := builtin.GetPropertySchema(builtin.PropertySchemaIDStoryPage)
 	prop, err := property.New().NewID().Schema(schema.ID()).Scene(inp.SceneID).Build()
 	if err != nil {
@@ -444,20 +851,42 @@ func (i *Storytelling) CreatePage(ctx context.Context, inp interfaces.CreatePage
 		return nil, nil, err
 	}
 
+	prev := story.Clone()
 	story.Pages().AddAt(page, inp.Index)
 
 	if err = i.propertyRepo.Save(ctx, prop); err != nil {
 		return nil, nil, err
 	}
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryAddPage, inp.SceneID, []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(page.Id())}); err != nil {
+		return nil, nil, err
+	}
+	if err := i.recordHistory(ctx, op, inp.SceneID, auditlog.ActionStoryAddPage,
+		history.StoryChange(prev, story),
+		history.PropertyChange(nil, prop),
+	); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return story, page, nil
 }
 
+func (i *Storytelling) enforceStoryPageCount(ctx context.Context, sid id.SceneID, op *usecase.Operator) error {
+	p, _, err := i.scenePolicy(ctx, sid, op)
+	if err != nil || p == nil {
+		return err
+	}
+	_, pages, err := i.countStories(ctx, sid)
+	if err != nil {
+		return err
+	}
+	return p.EnforceStoryPageCount(pages + 1)
+}
+
 func (i *Storytelling) UpdatePage(ctx context.Context, inp interfaces.UpdatePageParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -479,12 +908,16 @@ func (i *Storytelling) UpdatePage(ctx context.Context, inp interfaces.UpdatePage
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := checkVersion(story.Id(), story.Version(), inp.ExpectedVersion); err != nil {
+		return nil, nil, err
+	}
 
 	page := story.Pages().Page(inp.PageID)
 	if page == nil {
 		return nil, nil, interfaces.ErrPageNotFound
 	}
 
+	prev := story.Clone()
 	if inp.Title != nil && *inp.Title != "" {
 		page.SetTitle(*inp.Title)
 	}
@@ -503,10 +936,16 @@ func (i *Storytelling) UpdatePage(ctx context.Context, inp interfaces.UpdatePage
 		story.Pages().Move(page.Id(), *inp.Index)
 	}
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryUpdatePage, inp.SceneID, []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(page.Id())}); err != nil {
+		return nil, nil, err
+	}
+	if err := i.recordHistory(ctx, op, inp.SceneID, auditlog.ActionStoryUpdatePage, history.StoryChange(prev, story)); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return story, page, nil
 }
@@ -538,12 +977,19 @@ func (i *Storytelling) RemovePage(ctx context.Context, inp interfaces.RemovePage
 		return nil, nil, interfaces.ErrPageNotFound
 	}
 
+	prev := story.Clone()
 	story.Pages().Remove(page.Id())
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryRemovePage, inp.SceneID, []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(page.Id())}); err != nil {
+		return nil, nil, err
+	}
+	if err := i.recordHistory(ctx, op, inp.SceneID, auditlog.ActionStoryRemovePage, history.StoryChange(prev, story)); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return story, page.Id().Ref(), nil
 }
@@ -575,12 +1021,19 @@ func (i *Storytelling) MovePage(ctx context.Context, inp interfaces.MovePagePara
 		return nil, nil, 0, interfaces.ErrPageNotFound
 	}
 
+	prev := story.Clone()
 	story.Pages().Move(page.Id(), inp.Index)
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
 		return nil, nil, 0, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryMovePage, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(page.Id())}); err != nil {
+		return nil, nil, 0, err
+	}
+	if err := i.recordHistory(ctx, op, story.Scene(), auditlog.ActionStoryMovePage, history.StoryChange(prev, story)); err != nil {
+		return nil, nil, 0, err
+	}
 	tx.Commit()
 	return story, page, inp.Index, nil
 }
@@ -612,13 +1065,20 @@ func (i *Storytelling) DuplicatePage(ctx context.Context, inp interfaces.Duplica
 		return nil, nil, interfaces.ErrPageNotFound
 	}
 
+	if err := i.enforceStoryPageCount(ctx, story.Scene(), op); err != nil {
+		return nil, nil, err
+	}
+
 	dupPage := page.Duplicate()
 	story.Pages().AddAt(dupPage, lo.ToPtr(story.Pages().IndexOf(page.Id())+1))
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryAddPage, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(dupPage.Id()), auditlog.TargetOf(page.Id())}); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return story, dupPage, nil
 }
@@ -660,10 +1120,13 @@ func (i *Storytelling) AddPageLayer(ctx context.Context, inp interfaces.PageLaye
 		page.AddLayer(inp.LayerID)
 	}
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryAddPageLayer, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(page.Id()), auditlog.TargetOf(inp.LayerID)}); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return story, page, nil
 }
@@ -705,10 +1168,13 @@ func (i *Storytelling) RemovePageLayer(ctx context.Context, inp interfaces.PageL
 		page.RemoveLayer(inp.LayerID)
 	}
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+	if err := i.storytellingRepo.Save(ctx, story); err != nil {
 		return nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryRemovePageLayer, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(page.Id()), auditlog.TargetOf(inp.LayerID)}); err != nil {
+		return nil, nil, err
+	}
 	tx.Commit()
 	return story, page, nil
 }
@@ -767,6 +1233,7 @@ func (i *Storytelling) CreateBlock(ctx context.Context, inp interfaces.CreateBlo
 		return nil, nil, nil, -1, interfaces.ErrPageNotFound
 	}
 
+	prev := story.Clone()
 	page.AddBlock(block, index)
 
 	err = i.propertyRepo.Save(ctx, prop)
@@ -774,11 +1241,20 @@ func (i *Storytelling) CreateBlock(ctx context.Context, inp interfaces.CreateBlo
 		return nil, nil, nil, -1, err
 	}
 
-	err = i.storytellingRepo.Save(ctx, *story)
+	err = i.storytellingRepo.Save(ctx, story)
 	if err != nil {
 		return nil, nil, nil, -1, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryAddBlock, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(page.Id()), auditlog.TargetOf(block.ID())}); err != nil {
+		return nil, nil, nil, -1, err
+	}
+	if err := i.recordHistory(ctx, op, story.Scene(), auditlog.ActionStoryAddBlock,
+		history.StoryChange(prev, story),
+		history.PropertyChange(nil, prop),
+	); err != nil {
+		return nil, nil, nil, -1, err
+	}
 	tx.Commit()
 	return story, page, block, 1, err
 }
@@ -814,8 +1290,14 @@ func (i *Storytelling) RemoveBlock(ctx context.Context, inp interfaces.RemoveBlo
 		return nil, nil, nil, interfaces.ErrBlockNotFound
 	}
 
+	prev := story.Clone()
+	prevProp, err := i.propertyRepo.FindByID(ctx, block.Property())
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, nil, nil, err
+	}
+
 	page.RemoveBlock(inp.BlockID)
-	err = i.storytellingRepo.Save(ctx, *story)
+	err = i.storytellingRepo.Save(ctx, story)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -824,6 +1306,15 @@ func (i *Storytelling) RemoveBlock(ctx context.Context, inp interfaces.RemoveBlo
 		return nil, nil, nil, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryRemoveBlock, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(page.Id()), auditlog.TargetOf(inp.BlockID)}); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := i.recordHistory(ctx, op, story.Scene(), auditlog.ActionStoryRemoveBlock,
+		history.StoryChange(prev, story),
+		history.PropertyChange(prevProp, nil),
+	); err != nil {
+		return nil, nil, nil, err
+	}
 	tx.Commit()
 	return story, page, &inp.BlockID, nil
 }
@@ -858,12 +1349,19 @@ func (i *Storytelling) MoveBlock(ctx context.Context, inp interfaces.MoveBlockPa
 		return nil, nil, nil, inp.Index, interfaces.ErrBlockNotFound
 	}
 
+	prev := story.Clone()
 	page.MoveBlock(inp.BlockID, inp.Index)
-	err = i.storytellingRepo.Save(ctx, *story)
+	err = i.storytellingRepo.Save(ctx, story)
 	if err != nil {
 		return nil, nil, nil, inp.Index, err
 	}
 
+	if err := i.auditScene(ctx, op, auditlog.ActionStoryMoveBlock, story.Scene(), []auditlog.Target{auditlog.TargetOf(story.Id()), auditlog.TargetOf(page.Id()), auditlog.TargetOf(inp.BlockID)}); err != nil {
+		return nil, nil, nil, inp.Index, err
+	}
+	if err := i.recordHistory(ctx, op, story.Scene(), auditlog.ActionStoryMoveBlock, history.StoryChange(prev, story)); err != nil {
+		return nil, nil, nil, inp.Index, err
+	}
 	tx.Commit()
 	return story, page, &inp.BlockID, inp.Index, nil
 }
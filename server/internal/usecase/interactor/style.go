@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/history"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/sceneops"
@@ -15,17 +18,21 @@ import (
 type Style struct {
 	common
 	commonSceneLock
+	commonAudit
+	commonHistory
 	styleRepo     repo.Style
 	sceneLockRepo repo.SceneLock
 	transaction   usecasex.Transaction
 }
 
-func NewStyle(r *repo.Container) interfaces.Style {
+func NewStyle(r *repo.Container, g *gateway.Container) interfaces.Style {
 	return &Style{
 		commonSceneLock: commonSceneLock{sceneLockRepo: r.SceneLock},
 		styleRepo:       r.Style,
 		sceneLockRepo:   r.SceneLock,
 		transaction:     r.Transaction,
+		commonAudit:     newCommonAudit(r, g),
+		commonHistory:   newCommonHistory(r),
 	}
 }
 
@@ -63,10 +70,16 @@ func (i *Style) AddStyle(ctx context.Context, param interfaces.AddStyleInput, op
 		return nil, err
 	}
 
-	if err := i.styleRepo.Save(ctx, *style); err != nil {
+	if err := i.styleRepo.Save(ctx, style); err != nil {
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionStyleAdd, param.SceneID, []auditlog.Target{auditlog.TargetOf(style.ID())}); err != nil {
+		return nil, err
+	}
+	if err := i.recordHistory(ctx, operator, param.SceneID, auditlog.ActionStyleAdd, history.StyleChange(nil, style)); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return style, nil
 }
@@ -91,7 +104,12 @@ func (i *Style) UpdateStyle(ctx context.Context, param interfaces.UpdateStyleInp
 	if err != nil {
 		return nil, err
 	}
+	if err := checkVersion(style.ID(), style.Version(), param.ExpectedVersion); err != nil {
+		return nil, err
+	}
 
+	prev := style.Clone()
+	prevName := style.Name()
 	if param.Name != nil {
 		style.Rename(*param.Name)
 	}
@@ -100,10 +118,18 @@ func (i *Style) UpdateStyle(ctx context.Context, param interfaces.UpdateStyleInp
 		style.UpdateValue(param.Value)
 	}
 
-	if err := i.styleRepo.Save(ctx, *style); err != nil {
+	if err := i.styleRepo.Save(ctx, style); err != nil {
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionStyleUpdate, style.Scene(), []auditlog.Target{auditlog.TargetOf(style.ID())},
+		auditlog.NewChange("name", prevName, style.Name()),
+	); err != nil {
+		return nil, err
+	}
+	if err := i.recordHistory(ctx, operator, style.Scene(), auditlog.ActionStyleUpdate, history.StyleChange(prev, style)); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return style, nil
 }
@@ -139,6 +165,12 @@ func (i *Style) RemoveStyle(ctx context.Context, styleID id.StyleID, operator *u
 		return
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionStyleRemove, s.Scene(), []auditlog.Target{auditlog.TargetOf(styleID)}); err != nil {
+		return styleID, err
+	}
+	if err := i.recordHistory(ctx, operator, s.Scene(), auditlog.ActionStyleRemove, history.StyleChange(s, nil)); err != nil {
+		return styleID, err
+	}
 	tx.Commit()
 	return styleID, nil
 }
@@ -167,10 +199,13 @@ func (i *Style) DuplicateStyle(ctx context.Context, styleID id.StyleID, operator
 
 	duplicatedStyle := style.Duplicate()
 
-	if err := i.styleRepo.Save(ctx, *duplicatedStyle); err != nil {
+	if err := i.styleRepo.Save(ctx, duplicatedStyle); err != nil {
 		return nil, err
 	}
 
+	if err := i.auditScene(ctx, operator, auditlog.ActionStyleAdd, style.Scene(), []auditlog.Target{auditlog.TargetOf(duplicatedStyle.ID()), auditlog.TargetOf(styleID)}); err != nil {
+		return nil, err
+	}
 	tx.Commit()
 	return duplicatedStyle, nil
 }
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type AuditLogFilter struct {
+	Scene   *id.SceneID
+	Actor   *accountdomain.UserID
+	Actions []auditlog.Action
+	Target  *string
+	Since   *time.Time
+	Until   *time.Time
+}
+
+type AuditLog interface {
+	// FindByWorkspace returns the audit logs of the workspace from the newest one. Only maintainers of the workspace can read them.
+	FindByWorkspace(context.Context, accountdomain.WorkspaceID, AuditLogFilter, *usecasex.Pagination, *usecase.Operator) (auditlog.List, *usecasex.PageInfo, error)
+}
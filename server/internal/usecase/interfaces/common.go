@@ -23,12 +23,15 @@ var (
 
 type Container struct {
 	Asset        Asset
+	AuditLog     AuditLog
 	Dataset      Dataset
+	History      History
 	Layer        Layer
 	NLSLayer     NLSLayer
 	Plugin       Plugin
 	Policy       Policy
 	Project      Project
+	ProjectGrant ProjectGrant
 	Property     Property
 	Published    Published
 	Scene        Scene
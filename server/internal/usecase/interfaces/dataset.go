@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/dataset"
 	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
@@ -22,6 +24,27 @@ type ImportDatasetParam struct {
 	File     *file.File
 	SceneId  id.SceneID
 	SchemaId *id.DatasetSchemaID
+	// CRS is the CRS of coordinates in the file. If it is nil, coordinates are treated as WGS84.
+	CRS *crs.CRS
+	// Options configures the columns of locations, geometries and types. Empty options detect them from the file.
+	Options dataset.CSVOptions
+	// SheetName is the name of the sheet to import from an XLSX file. The first sheet is imported if it is empty.
+	SheetName string
+}
+
+type ImportGeoPackageParam struct {
+	File    *file.File
+	SceneId id.SceneID
+}
+
+type ExportDatasetParam struct {
+	SchemaID id.DatasetSchemaID
+	// Format is the extension of the format such as csv, json, geojson and xlsx.
+	Format string
+	// Fields are IDs or names of the fields to export. All fields are exported if it is empty.
+	Fields []string
+	// Datasets are the datasets to export. All datasets of the schema are exported if it is empty.
+	Datasets []id.DatasetID
 }
 
 type ImportDatasetFromGoogleSheetParam struct {
@@ -42,18 +65,35 @@ type UpdateDatasetSchemaParam struct {
 	Name     string
 }
 
+type UpdateDatasetSchemaRefreshParam struct {
+	SchemaID id.DatasetSchemaID
+	// Interval is the interval to refresh the schema from its source. 0 disables the refresh.
+	Interval time.Duration
+	// Publish republishes the published project of the scene after every refresh.
+	Publish bool
+}
+
 var (
-	ErrNoDataSourceAvailable error = errors.New("no datasource available")
-	ErrDataSourceInvalidURL  error = errors.New("invalid url")
-	ErrDatasetInvalidDepth   error = errors.New("invalid depth")
+	ErrNoDataSourceAvailable     error = errors.New("no datasource available")
+	ErrDataSourceInvalidURL      error = errors.New("invalid url")
+	ErrDatasetInvalidDepth       error = errors.New("invalid depth")
+	ErrDatasetSchemaNotRemote    error = errors.New("dataset schema is not synced from a url or a google sheet")
+	ErrGoogleSheetNotRefreshable error = errors.New("dataset schema synced from a google sheet can not be refreshed without the access token of the user")
+	ErrNoFeatureTables           error = errors.New("no feature tables")
+	ErrDatasetFieldNotFound      error = errors.New("dataset field not found")
 )
 
 type Dataset interface {
 	Fetch(context.Context, []id.DatasetID) (dataset.List, error)
-	Export(context.Context, id.DatasetSchemaID, string, io.Writer, func(string, string)) error
+	Export(context.Context, ExportDatasetParam, io.Writer, func(string, string)) error
 	GraphFetch(context.Context, id.DatasetID, int, *usecase.Operator) (dataset.List, error)
 	FetchSchema(context.Context, []id.DatasetSchemaID, *usecase.Operator) (dataset.SchemaList, error)
 	ImportDataset(context.Context, ImportDatasetParam, *usecase.Operator) (*dataset.Schema, error)
+	// PreviewDataset parses the file as ImportDataset does without saving anything, and returns the first rows up to the limit.
+	PreviewDataset(context.Context, ImportDatasetParam, int, *usecase.Operator) (*dataset.CSVPreview, error)
+	// ImportGeoPackage imports each feature table of a GeoPackage file as a new schema.
+	// The file is parsed entirely before importing, but tables imported before a failure such as a policy violation are kept.
+	ImportGeoPackage(context.Context, ImportGeoPackageParam, *usecase.Operator) (dataset.SchemaList, error)
 	ImportDatasetFromGoogleSheet(context.Context, ImportDatasetFromGoogleSheetParam, *usecase.Operator) (*dataset.Schema, error)
 	GraphFetchSchema(context.Context, id.DatasetSchemaID, int, *usecase.Operator) (dataset.SchemaList, error)
 	FindBySchema(context.Context, id.DatasetSchemaID, *usecasex.Pagination, *usecase.Operator) (dataset.List, *usecasex.PageInfo, error)
@@ -63,4 +103,8 @@ type Dataset interface {
 	UpdateDatasetSchema(context.Context, UpdateDatasetSchemaParam, *usecase.Operator) (*dataset.Schema, error)
 	Sync(context.Context, id.SceneID, string, *usecase.Operator) (dataset.SchemaList, dataset.List, error)
 	AddDatasetSchema(context.Context, AddDatasetSchemaParam, *usecase.Operator) (*dataset.Schema, error)
+	UpdateDatasetSchemaRefresh(context.Context, UpdateDatasetSchemaRefreshParam, *usecase.Operator) (*dataset.Schema, error)
+	FindSyncLogs(context.Context, id.DatasetSchemaID, int, *usecase.Operator) (dataset.SyncLogList, error)
+	// RefreshScheduled syncs schemas whose refresh is due at now from their sources.
+	RefreshScheduled(context.Context, time.Time) error
 }
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/history"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+var (
+	ErrNothingToUndo      error = errors.New("nothing to undo")
+	ErrNothingToRedo      error = errors.New("nothing to redo")
+	ErrHistoryConflict    error = errors.New("the target has been changed by another user since the edit")
+	ErrHistoryUncheckable error = errors.New("edits of other users can not be checked without the audit log")
+)
+
+type History interface {
+	// Fetch returns the edits of the operator which will be undone and redone next. Each of them is nil if there is none.
+	Fetch(context.Context, id.SceneID, *usecase.Operator) (undo *history.Entry, redo *history.Entry, err error)
+	// Undo reverts the latest edit of the operator on the scene.
+	Undo(context.Context, id.SceneID, *usecase.Operator) (*history.Entry, error)
+	// Redo reapplies the latest edit undone by the operator on the scene.
+	Redo(context.Context, id.SceneID, *usecase.Operator) (*history.Entry, error)
+}
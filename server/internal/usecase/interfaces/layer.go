@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/layer"
@@ -64,6 +65,8 @@ type ImportLayerParam struct {
 	LayerID id.LayerID
 	File    *file.File
 	Format  decoding.LayerEncodingFormat
+	// CRS is the CRS of coordinates in the file. If it is nil, the CRS is detected from the file or treated as WGS84.
+	CRS *crs.CRS
 }
 
 var (
@@ -93,7 +96,7 @@ type Layer interface {
 	FetchMerged(context.Context, id.LayerID, *id.LayerID, *usecase.Operator) (*layer.Merged, error)
 	FetchParentAndMerged(context.Context, id.LayerID, *usecase.Operator) (*layer.Merged, error)
 	FetchByTag(context.Context, id.TagID, *usecase.Operator) (layer.List, error)
-	Export(context.Context, id.LayerID, string) (io.Reader, string, error)
+	Export(context.Context, id.LayerID, string, *crs.CRS) (io.Reader, string, error)
 	AddItem(context.Context, AddLayerItemInput, *usecase.Operator) (*layer.Item, *layer.Group, error)
 	AddGroup(context.Context, AddLayerGroupInput, *usecase.Operator) (*layer.Group, *layer.Group, error)
 	Remove(context.Context, id.LayerID, *usecase.Operator) (id.LayerID, *layer.Group, error)
@@ -2,9 +2,14 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer/decoding"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
 )
 
@@ -20,10 +25,11 @@ type AddNLSLayerSimpleInput struct {
 }
 
 type UpdateNLSLayerInput struct {
-	LayerID id.NLSLayerID
-	Name    *string
-	Visible *bool
-	Config  *nlslayer.Config
+	LayerID         id.NLSLayerID
+	Name            *string
+	Visible         *bool
+	Config          *nlslayer.Config
+	ExpectedVersion *int
 }
 
 type AddNLSInfoboxBlockParam struct {
@@ -68,6 +74,21 @@ type DeleteNLSLayerGeoJSONFeatureParams struct {
 	FeatureID id.FeatureID
 }
 
+type ImportNLSLayerParam struct {
+	SceneID id.SceneID
+	// LayerID is a sketch layer which features are appended to. If it is nil, a new sketch layer is created.
+	LayerID *id.NLSLayerID
+	Title   *string
+	File    *file.File
+	Format  decoding.LayerEncodingFormat
+	// CRS is the CRS of coordinates in the file. If it is nil, the CRS is detected from the file or treated as WGS84.
+	CRS *crs.CRS
+}
+
+var (
+	ErrNoFeaturesImported error = errors.New("no features are imported")
+)
+
 type NLSLayer interface {
 	Fetch(context.Context, id.NLSLayerIDList, *usecase.Operator) (nlslayer.NLSLayerList, error)
 	FetchByScene(context.Context, id.SceneID, *usecase.Operator) (nlslayer.NLSLayerList, error)
@@ -86,4 +107,6 @@ type NLSLayer interface {
 	AddGeoJSONFeature(context.Context, AddNLSLayerGeoJSONFeatureParams, *usecase.Operator) (nlslayer.Feature, error)
 	UpdateGeoJSONFeature(context.Context, UpdateNLSLayerGeoJSONFeatureParams, *usecase.Operator) (nlslayer.Feature, error)
 	DeleteGeoJSONFeature(context.Context, DeleteNLSLayerGeoJSONFeatureParams, *usecase.Operator) (id.FeatureID, error)
+	ImportLayer(context.Context, ImportNLSLayerParam, *usecase.Operator) (nlslayer.NLSLayer, error)
+	Export(context.Context, id.NLSLayerID, string, *crs.CRS, *usecase.Operator) (io.Reader, string, error)
 }
@@ -2,10 +2,44 @@ package interfaces
 
 import (
 	"context"
+	"errors"
 
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearthx/account/accountdomain"
 )
 
+var ErrInvalidUsageThreshold = errors.New("invalid usage threshold")
+
+// SceneUsage is the current usage of a scene and its workspace that is limited by the policy of the workspace.
+type SceneUsage struct {
+	SceneID id.SceneID
+	// Policy is the policy applied to the workspace. It is nil if no policy is applied.
+	Policy        *policy.Policy
+	NLSLayerCount int
+	// SketchFeatureCount is the largest number of features in a sketch layer of the scene.
+	SketchFeatureCount  int
+	StoryCount          int
+	StoryPageCount      int
+	PublishedStoryCount int
+	PluginCount         int
+}
+
+// WorkspaceUsage is the current usage of a workspace with the limits of the policy of the workspace.
+type WorkspaceUsage struct {
+	WorkspaceID accountdomain.WorkspaceID
+	// Policy is the policy applied to the workspace. It is nil if no policy is applied.
+	Policy *policy.Policy
+	Items  []policy.UsageItem
+}
+
 type Policy interface {
 	FetchPolicy(ctx context.Context, ids []policy.ID) ([]*policy.Policy, error)
+	FetchSceneUsage(ctx context.Context, sid id.SceneID, op *usecase.Operator) (*SceneUsage, error)
+	FetchWorkspaceUsage(ctx context.Context, wid accountdomain.WorkspaceID, op *usecase.Operator) (*WorkspaceUsage, error)
+	// SendWorkspaceUsageWarning sends a warning email to the owners of the workspace when any usage reaches the threshold,
+	// which is a percentage of the limit. It returns the items that reached the threshold, and sends nothing if there are none.
+	// Items are warned of only once for each threshold until their usage falls below it again.
+	SendWorkspaceUsageWarning(ctx context.Context, wid accountdomain.WorkspaceID, threshold float64, op *usecase.Operator) ([]policy.UsageItem, error)
 }
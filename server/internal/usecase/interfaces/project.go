@@ -3,11 +3,15 @@ package interfaces
 import (
 	"context"
 	"errors"
+	"io"
 	"net/url"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/revision"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/usecasex"
@@ -22,6 +26,7 @@ type CreateProjectParam struct {
 	Alias       *string
 	Archived    *bool
 	CoreSupport *bool
+	TemplateID  *id.ProjectID
 }
 
 type UpdateProjectParam struct {
@@ -43,6 +48,7 @@ type UpdateProjectParam struct {
 	EnableGa          *bool
 	TrackingID        *string
 	SceneID           *id.SceneID
+	IsTemplate        *bool
 }
 
 type PublishProjectParam struct {
@@ -51,9 +57,53 @@ type PublishProjectParam struct {
 	Status project.PublishmentStatus
 }
 
+type SchedulePublishProjectParam struct {
+	ID          id.ProjectID
+	Status      project.PublishmentStatus
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+}
+
+type AddProjectBasicAuthCredentialParam struct {
+	ID       id.ProjectID
+	Name     string
+	Username string
+	Password string
+}
+
+type RevokeProjectBasicAuthCredentialParam struct {
+	ID   id.ProjectID
+	Name string
+}
+
+type PreviewProjectParam struct {
+	ID        id.ProjectID
+	ExpiresAt *time.Time
+}
+
+type DuplicateProjectParam struct {
+	ID          id.ProjectID
+	WorkspaceID *accountdomain.WorkspaceID
+	Name        *string
+}
+
+type ImportProjectParam struct {
+	WorkspaceID accountdomain.WorkspaceID
+	File        *file.File
+}
+
+type RollbackProjectParam struct {
+	ID       id.ProjectID
+	Revision int
+}
+
 var (
 	ErrProjectAliasIsNotSet    error = errors.New("project alias is not set")
 	ErrProjectAliasAlreadyUsed error = errors.New("project alias is already used by another project")
+	ErrInvalidPreviewExpiry    error = errors.New("preview must expire within 7 days")
+	ErrProjectNotPublished     error = errors.New("project is not published")
+	ErrProjectNotTemplate      error = errors.New("project is not a template")
+	ErrProjectNotDuplicatable  error = errors.New("project with private plugins can not be duplicated to another workspace")
 )
 
 type Project interface {
@@ -62,6 +112,20 @@ type Project interface {
 	Create(context.Context, CreateProjectParam, *usecase.Operator) (*project.Project, error)
 	Update(context.Context, UpdateProjectParam, *usecase.Operator) (*project.Project, error)
 	Publish(context.Context, PublishProjectParam, *usecase.Operator) (*project.Project, error)
+	SchedulePublish(context.Context, SchedulePublishProjectParam, *usecase.Operator) (*project.Project, error)
+	PublishScheduled(context.Context, time.Time) error
+	FindRevisions(context.Context, id.ProjectID, *usecase.Operator) (revision.List, error)
+	Rollback(context.Context, RollbackProjectParam, *usecase.Operator) (*project.Project, error)
+	Preview(context.Context, PreviewProjectParam, *usecase.Operator) (*project.Project, *url.URL, error)
+	RemoveExpiredPreviews(context.Context, time.Time) error
+	RemoveUnusedChunks(context.Context, time.Time) error
+	ExportPublished(context.Context, id.ProjectID, *usecase.Operator) (io.Reader, error)
+	ExportPublishedByAlias(context.Context, string) (io.Reader, error)
+	Export(context.Context, id.ProjectID, *usecase.Operator) (io.Reader, error)
+	Import(context.Context, ImportProjectParam, *usecase.Operator) (*project.Project, error)
+	Duplicate(context.Context, DuplicateProjectParam, *usecase.Operator) (*project.Project, error)
+	AddBasicAuthCredential(context.Context, AddProjectBasicAuthCredentialParam, *usecase.Operator) (*project.Project, error)
+	RevokeBasicAuthCredential(context.Context, RevokeProjectBasicAuthCredentialParam, *usecase.Operator) (*project.Project, error)
 	CheckAlias(context.Context, string) (bool, error)
 	Delete(context.Context, id.ProjectID, *usecase.Operator) error
 }
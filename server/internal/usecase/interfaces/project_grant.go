@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/projectgrant"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type GrantProjectAccessParam struct {
+	ProjectID id.ProjectID
+	UserID    accountdomain.UserID
+	Role      projectgrant.Role
+}
+
+// ProjectGrant manages the roles of users in single projects. Only maintainers of the workspace of the project can manage them.
+type ProjectGrant interface {
+	FetchByProject(context.Context, id.ProjectID, *usecase.Operator) (projectgrant.List, error)
+	// Grant gives the user the role in the project, or changes the role if the user already has one.
+	Grant(context.Context, GrantProjectAccessParam, *usecase.Operator) (*projectgrant.Grant, error)
+	Revoke(context.Context, id.ProjectID, accountdomain.UserID, *usecase.Operator) error
+}
@@ -11,9 +11,10 @@ import (
 )
 
 type UpdatePropertyValueParam struct {
-	PropertyID id.PropertyID
-	Pointer    *property.Pointer
-	Value      *property.Value
+	PropertyID      id.PropertyID
+	Pointer         *property.Pointer
+	Value           *property.Value
+	ExpectedVersion *int
 }
 
 type RemovePropertyFieldParam struct {
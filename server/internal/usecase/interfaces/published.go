@@ -4,6 +4,10 @@ import (
 	"context"
 	"io"
 	"net/url"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/basicauth"
+	"github.com/reearth/reearth/server/pkg/scene/builder"
 )
 
 type HasPublicMeta interface {
@@ -12,8 +16,7 @@ type HasPublicMeta interface {
 	PublicImage() string
 	PublicNoIndex() bool
 	IsBasicAuthActive() bool
-	BasicAuthUsername() string
-	BasicAuthPassword() string
+	BasicAuthCredentials() basicauth.List
 }
 
 type ProjectPublishedMetadata struct {
@@ -22,8 +25,13 @@ type ProjectPublishedMetadata struct {
 	Image             string `json:"image,omitempty"`
 	Noindex           bool   `json:"noindex,omitempty"`
 	IsBasicAuthActive bool   `json:"isBasicAuthActive,omitempty"`
-	BasicAuthUsername string `json:"basicAuthUsername,omitempty"`
-	BasicAuthPassword string `json:"basicAuthPassword,omitempty"`
+	// BasicAuthCredentials are never exposed since they are used only to authenticate viewers
+	BasicAuthCredentials basicauth.List `json:"-"`
+}
+
+// MatchBasicAuth reports whether the username and the password match one of the active basic auth credentials.
+func (m ProjectPublishedMetadata) MatchBasicAuth(username, password string) bool {
+	return m.BasicAuthCredentials.Match(username, password) != nil
 }
 
 func PublishedMetadataFrom(i HasPublicMeta) ProjectPublishedMetadata {
@@ -33,13 +41,17 @@ func PublishedMetadataFrom(i HasPublicMeta) ProjectPublishedMetadata {
 		Image:             i.PublicImage(),
 		Noindex:           i.PublicNoIndex(),
 		IsBasicAuthActive: i.IsBasicAuthActive(),
-		BasicAuthUsername: i.BasicAuthUsername(),
-		BasicAuthPassword: i.BasicAuthPassword(),
+
+		BasicAuthCredentials: i.BasicAuthCredentials(),
 	}
 }
 
 type Published interface {
 	Metadata(context.Context, string) (ProjectPublishedMetadata, error)
-	Data(context.Context, string) (io.Reader, error)
+	Data(context.Context, string) (io.Reader, string, error)
+	Manifest(context.Context, string) (*builder.Manifest, error)
+	DataChunk(context.Context, string, string) (io.Reader, string, error)
 	Index(context.Context, string, *url.URL) (string, error)
+	PreviewData(context.Context, string) (io.Reader, string, time.Time, error)
+	PreviewIndex(context.Context, string, *url.URL) (string, time.Time, error)
 }
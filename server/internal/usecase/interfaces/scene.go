@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/scene"
 )
@@ -30,25 +31,29 @@ type Scene interface {
 	AddCluster(context.Context, id.SceneID, string, *usecase.Operator) (*scene.Scene, *scene.Cluster, error)
 	UpdateCluster(context.Context, UpdateClusterParam, *usecase.Operator) (*scene.Scene, *scene.Cluster, error)
 	RemoveCluster(context.Context, id.SceneID, id.ClusterID, *usecase.Operator) (*scene.Scene, error)
+	// SubscribeEvents returns a channel of the changes made in the scene, which is closed when the context is done.
+	SubscribeEvents(context.Context, id.SceneID, *usecase.Operator) (<-chan gateway.SceneEvent, error)
 }
 
 type UpdateWidgetParam struct {
-	SceneID  id.SceneID
-	WidgetID id.WidgetID
-	Enabled  *bool
-	Extended *bool
-	Location *scene.WidgetLocation
-	Index    *int
+	SceneID         id.SceneID
+	WidgetID        id.WidgetID
+	Enabled         *bool
+	Extended        *bool
+	Location        *scene.WidgetLocation
+	Index           *int
+	ExpectedVersion *int
 }
 
 type UpdateWidgetAlignSystemParam struct {
-	SceneID    id.SceneID
-	Location   scene.WidgetLocation
-	Align      *scene.WidgetAlignType
-	Padding    *scene.WidgetAreaPadding
-	Gap        *int
-	Centered   *bool
-	Background *string
+	SceneID         id.SceneID
+	Location        scene.WidgetLocation
+	Align           *scene.WidgetAlignType
+	Padding         *scene.WidgetAreaPadding
+	Gap             *int
+	Centered        *bool
+	Background      *string
+	ExpectedVersion *int
 }
 
 type UpdateClusterParam struct {
@@ -3,9 +3,11 @@ package interfaces
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/revision"
 	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/reearth/reearthx/i18n"
 	"github.com/reearth/reearthx/rerror"
@@ -34,6 +36,7 @@ type UpdateStoryInput struct {
 	PublicImage       *string
 	PublicNoIndex     *bool
 	DeletePublicImage *bool
+	ExpectedVersion   *int
 }
 
 type MoveStoryInput struct {
@@ -53,6 +56,30 @@ type PublishStoryInput struct {
 	Status storytelling.PublishmentStatus
 }
 
+type SchedulePublishStoryInput struct {
+	ID          id.StoryID
+	Status      storytelling.PublishmentStatus
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+}
+
+type AddStoryBasicAuthCredentialInput struct {
+	ID       id.StoryID
+	Name     string
+	Username string
+	Password string
+}
+
+type RevokeStoryBasicAuthCredentialInput struct {
+	ID   id.StoryID
+	Name string
+}
+
+type RollbackStoryInput struct {
+	ID       id.StoryID
+	Revision int
+}
+
 type CreatePageParam struct {
 	SceneID         id.SceneID
 	StoryID         id.StoryID
@@ -72,6 +99,7 @@ type UpdatePageParam struct {
 	Layers          *[]id.NLSLayerID
 	SwipeableLayers *[]id.NLSLayerID
 	Index           *int
+	ExpectedVersion *int
 }
 
 type MovePageParam struct {
@@ -136,6 +164,12 @@ type Storytelling interface {
 	Remove(context.Context, RemoveStoryInput, *usecase.Operator) (*id.StoryID, error)
 	Move(context.Context, MoveStoryInput, *usecase.Operator) (*id.StoryID, int, error)
 	Publish(context.Context, PublishStoryInput, *usecase.Operator) (*storytelling.Story, error)
+	SchedulePublish(context.Context, SchedulePublishStoryInput, *usecase.Operator) (*storytelling.Story, error)
+	PublishScheduled(context.Context, time.Time) error
+	FindRevisions(context.Context, id.StoryID, *usecase.Operator) (revision.List, error)
+	AddBasicAuthCredential(context.Context, AddStoryBasicAuthCredentialInput, *usecase.Operator) (*storytelling.Story, error)
+	RevokeBasicAuthCredential(context.Context, RevokeStoryBasicAuthCredentialInput, *usecase.Operator) (*storytelling.Story, error)
+	Rollback(context.Context, RollbackStoryInput, *usecase.Operator) (*storytelling.Story, error)
 
 	CreatePage(context.Context, CreatePageParam, *usecase.Operator) (*storytelling.Story, *storytelling.Page, error)
 	UpdatePage(context.Context, UpdatePageParam, *usecase.Operator) (*storytelling.Story, *storytelling.Page, error)
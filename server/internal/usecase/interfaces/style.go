@@ -15,9 +15,10 @@ type AddStyleInput struct {
 }
 
 type UpdateStyleInput struct {
-	StyleID id.StyleID
-	Name    *string
-	Value   *scene.StyleValue
+	StyleID         id.StyleID
+	Name            *string
+	Value           *scene.StyleValue
+	ExpectedVersion *int
 }
 
 type Style interface {
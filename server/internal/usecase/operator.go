@@ -17,7 +17,17 @@ type Operator struct {
 	WritableScenes    scene.IDList
 	MaintainingScenes scene.IDList
 	OwningScenes      scene.IDList
-	DefaultPolicy     *policy.ID
+	// GrantedProjects are the projects granted to the user apart from the workspaces.
+	// Their scenes are included in ReadableScenes or WritableScenes according to the roles.
+	GrantedProjects id.ProjectIDList
+	DefaultPolicy   *policy.ID
+}
+
+func (o *Operator) UserID() *accountdomain.UserID {
+	if o == nil || o.AcOperator == nil {
+		return nil
+	}
+	return o.AcOperator.User.CloneRef()
 }
 
 func (o *Operator) Workspaces(r workspace.Role) accountdomain.WorkspaceIDList {
@@ -10,9 +10,11 @@ import (
 )
 
 type AssetFilter struct {
-	Sort       *asset.SortType
-	Keyword    *string
-	Pagination *usecasex.Pagination
+	Sort    *asset.SortType
+	Keyword *string
+	// ContentTypes are content types such as "image/png" or top-level types such as "image" to filter assets with.
+	ContentTypes []string
+	Pagination   *usecasex.Pagination
 }
 
 type Asset interface {
@@ -0,0 +1,31 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// AuditLogFilter filters audit logs. Empty fields are ignored.
+type AuditLogFilter struct {
+	Scene   *id.SceneID
+	Actor   *accountdomain.UserID
+	Actions []auditlog.Action
+	// Target is the ID of one of the targets.
+	Target *string
+	Since  *time.Time
+	Until  *time.Time
+}
+
+type AuditLog interface {
+	Filtered(WorkspaceFilter) AuditLog
+	// Granted returns a repo which also saves the logs of the scenes granted to the user to write outside the writable workspaces.
+	Granted(id.SceneIDList) AuditLog
+	// FindByWorkspace returns the logs of the workspace from the newest one.
+	FindByWorkspace(context.Context, accountdomain.WorkspaceID, AuditLogFilter, *usecasex.Pagination) (auditlog.List, *usecasex.PageInfo, error)
+	Save(context.Context, *auditlog.Log) error
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/account/accountdomain"
@@ -18,10 +19,14 @@ var (
 
 type Container struct {
 	Asset          Asset
+	AuditLog       AuditLog
 	AuthRequest    authserver.RequestRepo
 	Config         Config
 	DatasetSchema  DatasetSchema
 	Dataset        Dataset
+	DatasetSyncLog DatasetSyncLog
+	History        History
+	ProjectGrant   ProjectGrant
 	Layer          Layer
 	NLSLayer       NLSLayer
 	Style          Style
@@ -30,6 +35,7 @@ type Container struct {
 	Project        Project
 	PropertySchema PropertySchema
 	Property       Property
+	Revision       Revision
 	Scene          Scene
 	SceneLock      SceneLock
 	Tag            Tag
@@ -39,6 +45,7 @@ type Container struct {
 	Policy         Policy
 	Role           accountrepo.Role // TODO: Delete this once the permission check migration is complete.
 	Storytelling   Storytelling
+	UsageWarning   UsageWarning
 	Transaction    usecasex.Transaction
 	Extensions     []plugin.ID
 }
@@ -60,10 +67,14 @@ func (c *Container) Filtered(workspace WorkspaceFilter, scene SceneFilter) *Cont
 	}
 	return &Container{
 		Asset:          c.Asset.Filtered(workspace),
+		AuditLog:       c.AuditLog.Filtered(workspace),
 		AuthRequest:    c.AuthRequest,
 		Config:         c.Config,
 		DatasetSchema:  c.DatasetSchema.Filtered(scene),
 		Dataset:        c.Dataset.Filtered(scene),
+		DatasetSyncLog: c.DatasetSyncLog.Filtered(scene),
+		History:        c.History.Filtered(scene),
+		ProjectGrant:   c.ProjectGrant.Filtered(workspace),
 		Layer:          c.Layer.Filtered(scene),
 		NLSLayer:       c.NLSLayer.Filtered(scene),
 		Style:          c.Style.Filtered(scene),
@@ -74,9 +85,11 @@ func (c *Container) Filtered(workspace WorkspaceFilter, scene SceneFilter) *Cont
 		Project:        c.Project.Filtered(workspace),
 		PropertySchema: c.PropertySchema.Filtered(scene),
 		Property:       c.Property.Filtered(scene),
+		Revision:       c.Revision.Filtered(workspace),
 		Scene:          c.Scene.Filtered(workspace),
 		SceneLock:      c.SceneLock,
 		Tag:            c.Tag.Filtered(scene),
+		UsageWarning:   c.UsageWarning,
 		Transaction:    c.Transaction,
 		User:           c.User,
 		Workspace:      c.Workspace,
@@ -84,6 +97,19 @@ func (c *Container) Filtered(workspace WorkspaceFilter, scene SceneFilter) *Cont
 	}
 }
 
+// Granted lets the repos read the projects and scenes granted to the user outside the readable workspaces,
+// and save the granted writable scenes and their audit logs.
+func (c *Container) Granted(projects id.ProjectIDList, readableScenes, writableScenes id.SceneIDList) *Container {
+	if c == nil || len(projects) == 0 && len(readableScenes) == 0 && len(writableScenes) == 0 {
+		return c
+	}
+	c2 := *c
+	c2.AuditLog = c.AuditLog.Granted(writableScenes)
+	c2.Project = c.Project.Granted(projects)
+	c2.Scene = c.Scene.Granted(readableScenes, writableScenes)
+	return &c2
+}
+
 type WorkspaceFilter struct {
 	Readable accountdomain.WorkspaceIDList
 	Writable accountdomain.WorkspaceIDList
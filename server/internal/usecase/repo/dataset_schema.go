@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/reearth/reearth/server/pkg/dataset"
 	"github.com/reearth/reearth/server/pkg/id"
@@ -15,6 +16,7 @@ type DatasetSchema interface {
 	FindByScene(context.Context, id.SceneID, *usecasex.Pagination) (dataset.SchemaList, *usecasex.PageInfo, error)
 	FindBySceneAll(context.Context, id.SceneID) (dataset.SchemaList, error)
 	FindBySceneAndSource(context.Context, id.SceneID, string) (dataset.SchemaList, error)
+	FindByRefreshDue(context.Context, time.Time) (dataset.SchemaList, error)
 	CountByScene(context.Context, id.SceneID) (int, error)
 	Save(context.Context, *dataset.Schema) error
 	SaveAll(context.Context, dataset.SchemaList) error
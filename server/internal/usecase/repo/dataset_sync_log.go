@@ -0,0 +1,15 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+type DatasetSyncLog interface {
+	Filtered(SceneFilter) DatasetSyncLog
+	FindBySource(context.Context, id.SceneID, string, int) (dataset.SyncLogList, error)
+	Save(context.Context, *dataset.SyncLog) error
+	RemoveByScene(context.Context, id.SceneID) error
+}
@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/history"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type History interface {
+	Filtered(SceneFilter) History
+	// FindLatest returns the latest entry of the user in the scene which can be undone,
+	// or the latest undone entry which can be redone when undone is true.
+	FindLatest(ctx context.Context, sid id.SceneID, uid accountdomain.UserID, undone bool) (*history.Entry, error)
+	Save(context.Context, *history.Entry) error
+	// RemoveUndone removes the undone entries of the user in the scene, which cannot be redone after a new edit.
+	RemoveUndone(context.Context, id.SceneID, accountdomain.UserID) error
+	// RemoveOld removes the entries of the user in the scene except the latest keep entries.
+	RemoveOld(ctx context.Context, sid id.SceneID, uid accountdomain.UserID, keep int) error
+	RemoveByScene(context.Context, id.SceneID) error
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
@@ -12,11 +13,16 @@ import (
 
 type Project interface {
 	Filtered(WorkspaceFilter) Project
+	// Granted returns a repo which also reads the projects granted to the user outside the readable workspaces.
+	Granted(id.ProjectIDList) Project
 	FindByIDs(context.Context, id.ProjectIDList) ([]*project.Project, error)
 	FindByID(context.Context, id.ProjectID) (*project.Project, error)
 	FindByScene(context.Context, id.SceneID) (*project.Project, error)
 	FindByWorkspace(context.Context, accountdomain.WorkspaceID, *usecasex.Pagination) ([]*project.Project, *usecasex.PageInfo, error)
 	FindByPublicName(context.Context, string) (*project.Project, error)
+	FindByPublishScheduleDue(context.Context, time.Time) ([]*project.Project, error)
+	FindByPreviewToken(context.Context, string) (*project.Project, error)
+	FindByPreviewExpired(context.Context, time.Time) ([]*project.Project, error)
 	CountByWorkspace(context.Context, accountdomain.WorkspaceID) (int, error)
 	CountPublicByWorkspace(context.Context, accountdomain.WorkspaceID) (int, error)
 	Save(context.Context, *project.Project) error
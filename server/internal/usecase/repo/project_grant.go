@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/projectgrant"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type ProjectGrant interface {
+	Filtered(WorkspaceFilter) ProjectGrant
+	FindByProject(context.Context, id.ProjectID) (projectgrant.List, error)
+	FindByProjectAndUser(context.Context, id.ProjectID, accountdomain.UserID) (*projectgrant.Grant, error)
+	FindByUser(context.Context, accountdomain.UserID) (projectgrant.List, error)
+	Save(context.Context, *projectgrant.Grant) error
+	Remove(context.Context, id.ProjectGrantID) error
+	RemoveByProject(context.Context, id.ProjectID) error
+}
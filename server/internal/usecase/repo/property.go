@@ -11,6 +11,7 @@ type Property interface {
 	Filtered(SceneFilter) Property
 	FindByID(context.Context, id.PropertyID) (*property.Property, error)
 	FindByIDs(context.Context, id.PropertyIDList) (property.List, error)
+	FindByScene(context.Context, id.SceneID) (property.List, error)
 	FindLinkedAll(context.Context, id.SceneID) (property.List, error)
 	FindByDataset(context.Context, id.DatasetSchemaID, id.DatasetID) (property.List, error)
 	FindBySchema(context.Context, []id.PropertySchemaID, id.SceneID) (property.List, error)
@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/revision"
+)
+
+type Revision interface {
+	Filtered(WorkspaceFilter) Revision
+	FindByID(context.Context, id.RevisionID) (*revision.Revision, error)
+	FindByProject(context.Context, id.ProjectID) (revision.List, error)
+	FindByStory(context.Context, id.StoryID) (revision.List, error)
+	Save(context.Context, *revision.Revision) error
+	RemoveByProject(context.Context, id.ProjectID) error
+	RemoveByStory(context.Context, id.StoryID) error
+}
@@ -10,6 +10,8 @@ import (
 
 type Scene interface {
 	Filtered(WorkspaceFilter) Scene
+	// Granted returns a repo which also reads the readable scenes and saves the writable scenes granted to the user outside the workspaces.
+	Granted(readable, writable id.SceneIDList) Scene
 	FindByID(context.Context, id.SceneID) (*scene.Scene, error)
 	FindByIDs(context.Context, id.SceneIDList) (scene.List, error)
 	FindByWorkspace(context.Context, ...accountdomain.WorkspaceID) (scene.List, error)
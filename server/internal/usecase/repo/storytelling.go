@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/storytelling"
@@ -13,7 +14,8 @@ type Storytelling interface {
 	FindByIDs(context.Context, id.StoryIDList) (*storytelling.StoryList, error)
 	FindByScene(context.Context, id.SceneID) (*storytelling.StoryList, error)
 	FindByPublicName(ctx context.Context, alias string) (*storytelling.Story, error)
-	Save(context.Context, storytelling.Story) error
+	FindByPublishScheduleDue(context.Context, time.Time) (*storytelling.StoryList, error)
+	Save(context.Context, *storytelling.Story) error
 	SaveAll(context.Context, storytelling.StoryList) error
 	Remove(context.Context, id.StoryID) error
 	RemoveAll(context.Context, id.StoryIDList) error
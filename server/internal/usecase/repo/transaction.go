@@ -0,0 +1,101 @@
+package repo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type afterCommitKey struct{}
+
+// afterCommitHooks are the functions to run after the outermost transaction is committed.
+type afterCommitHooks struct {
+	lock  sync.Mutex
+	hooks []func()
+}
+
+func (h *afterCommitHooks) add(f func()) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.hooks = append(h.hooks, f)
+}
+
+func (h *afterCommitHooks) run() {
+	h.lock.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.lock.Unlock()
+
+	for _, f := range hooks {
+		f()
+	}
+}
+
+// AfterCommit runs f after the transaction of the context is committed, and never runs it if the transaction is rolled back.
+// f runs immediately if the context has no transaction wrapped by TransactionWithAfterCommit.
+func AfterCommit(ctx context.Context, f func()) {
+	if h, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok {
+		h.add(f)
+		return
+	}
+	f()
+}
+
+// TransactionWithAfterCommit wraps the transaction so that functions passed to AfterCommit run after it is committed.
+func TransactionWithAfterCommit(t usecasex.Transaction) usecasex.Transaction {
+	if t == nil {
+		return nil
+	}
+	if _, ok := t.(*afterCommitTransaction); ok {
+		return t
+	}
+	return &afterCommitTransaction{t: t}
+}
+
+type afterCommitTransaction struct {
+	t usecasex.Transaction
+}
+
+func (t *afterCommitTransaction) Begin(ctx context.Context) (usecasex.Tx, error) {
+	tx, err := t.t.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// nested transactions leave the hooks to the outermost one
+	if _, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok {
+		return tx, nil
+	}
+	h := &afterCommitHooks{}
+	return &afterCommitTx{
+		Tx:    tx,
+		ctx:   context.WithValue(tx.Context(), afterCommitKey{}, h),
+		hooks: h,
+	}, nil
+}
+
+type afterCommitTx struct {
+	usecasex.Tx
+	ctx       context.Context
+	hooks     *afterCommitHooks
+	committed bool
+}
+
+func (tx *afterCommitTx) Context() context.Context {
+	return tx.ctx
+}
+
+func (tx *afterCommitTx) Commit() {
+	tx.committed = true
+	tx.Tx.Commit()
+}
+
+func (tx *afterCommitTx) End(ctx context.Context) error {
+	if err := tx.Tx.End(ctx); err != nil {
+		return err
+	}
+	if tx.committed {
+		tx.hooks.run()
+	}
+	return nil
+}
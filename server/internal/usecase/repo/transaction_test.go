@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionWithAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	tr := TransactionWithAfterCommit(&usecasex.NopTransaction{})
+	assert.Same(t, tr, TransactionWithAfterCommit(tr))
+
+	var calls []string
+	tx, err := tr.Begin(ctx)
+	assert.NoError(t, err)
+	AfterCommit(tx.Context(), func() { calls = append(calls, "outer") })
+
+	// hooks of nested transactions run after the outermost one is committed
+	tx2, err := tr.Begin(tx.Context())
+	assert.NoError(t, err)
+	AfterCommit(tx2.Context(), func() { calls = append(calls, "inner") })
+	tx2.Commit()
+	assert.NoError(t, tx2.End(tx2.Context()))
+	assert.Empty(t, calls)
+
+	tx.Commit()
+	assert.NoError(t, tx.End(tx.Context()))
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+
+	// hooks do not run if the transaction is not committed
+	calls = nil
+	tx, _ = tr.Begin(ctx)
+	AfterCommit(tx.Context(), func() { calls = append(calls, "rollback") })
+	assert.NoError(t, tx.End(tx.Context()))
+	assert.Empty(t, calls)
+
+	// or if the commit fails
+	tx, _ = TransactionWithAfterCommit(&usecasex.NopTransaction{CommitError: errors.New("conflict")}).Begin(ctx)
+	AfterCommit(tx.Context(), func() { calls = append(calls, "failed") })
+	tx.Commit()
+	assert.Error(t, tx.End(tx.Context()))
+	assert.Empty(t, calls)
+
+	// hooks run immediately outside transactions
+	AfterCommit(ctx, func() { calls = append(calls, "now") })
+	assert.Equal(t, []string{"now"}, calls)
+}
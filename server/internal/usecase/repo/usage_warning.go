@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+// UsageWarning records the threshold of each usage of workspaces which their owners have been warned of last.
+type UsageWarning interface {
+	// FindByWorkspace returns the recorded thresholds of the workspace by names of usage items.
+	FindByWorkspace(context.Context, accountdomain.WorkspaceID) (map[string]float64, error)
+	Save(ctx context.Context, wid accountdomain.WorkspaceID, item string, threshold float64) error
+}
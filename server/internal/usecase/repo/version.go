@@ -0,0 +1,33 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Scenes, properties, NLS layers, stories and styles have versions.
+// Their repos save them only if the stored version is still the one they were loaded with,
+// and increment the versions of both the stored object and the given one.
+
+// ErrVersionConflict matches any VersionConflictError with errors.Is.
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionConflictError is returned when an object is saved although it has been changed by someone else
+// since it was loaded, or when a client expects a version of an object which is not the current one.
+type VersionConflictError struct {
+	ID string
+	// Version is the version of the object the caller has.
+	Version int
+}
+
+func NewVersionConflictError(id fmt.Stringer, version int) *VersionConflictError {
+	return &VersionConflictError{ID: id.String(), Version: version}
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s has been changed since version %d", e.ID, e.Version)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
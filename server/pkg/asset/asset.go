@@ -19,6 +19,8 @@ type Asset struct {
 	size        int64  // file size
 	url         string
 	contentType string
+	metadata    *Metadata
+	thumbnail   string // thumbnail url
 }
 
 func (a *Asset) ID() ID {
@@ -45,6 +47,14 @@ func (a *Asset) ContentType() string {
 	return a.contentType
 }
 
+func (a *Asset) Metadata() *Metadata {
+	return a.metadata.Clone()
+}
+
+func (a *Asset) Thumbnail() string {
+	return a.thumbnail
+}
+
 func (a *Asset) CreatedAt() time.Time {
 	if a == nil {
 		return time.Time{}
@@ -74,6 +74,16 @@ func (b *Builder) ContentType(contentType string) *Builder {
 	return b
 }
 
+func (b *Builder) Metadata(metadata *Metadata) *Builder {
+	b.a.metadata = metadata.Clone()
+	return b
+}
+
+func (b *Builder) Thumbnail(thumbnail string) *Builder {
+	b.a.thumbnail = thumbnail
+	return b
+}
+
 func (b *Builder) CreatedAt(createdAt time.Time) *Builder {
 	b.a.createdAt = createdAt
 	return b
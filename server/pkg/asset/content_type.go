@@ -0,0 +1,90 @@
+package asset
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const defaultContentType = "application/octet-stream"
+
+// extContentTypes are content types of formats used for geospatial data which http.DetectContentType cannot detect.
+var extContentTypes = map[string]string{
+	".geojson": "application/geo+json",
+	".czml":    "application/json",
+	".json":    "application/json",
+	".kml":     "application/vnd.google-earth.kml+xml",
+	".kmz":     "application/vnd.google-earth.kmz",
+	".gltf":    "model/gltf+json",
+	".glb":     "model/gltf-binary",
+	".tif":     "image/tiff",
+	".tiff":    "image/tiff",
+	".csv":     "text/csv",
+	".shp":     "application/vnd.shp",
+	".gpkg":    "application/geopackage+sqlite3",
+	".mvt":     "application/vnd.mapbox-vector-tile",
+}
+
+// DetectContentType returns the content type of a file from its leading bytes and name.
+// The bytes take precedence unless they only tell that the file is a generic text or binary.
+func DetectContentType(name string, data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("glTF")):
+		return "model/gltf-binary"
+	case bytes.HasPrefix(data, []byte("II*\x00")), bytes.HasPrefix(data, []byte("MM\x00*")):
+		return "image/tiff"
+	}
+
+	ct := http.DetectContentType(data)
+	if !isGenericContentType(ct) {
+		return ct
+	}
+
+	ext := strings.ToLower(path.Ext(name))
+	if t, ok := extContentTypes[ext]; ok {
+		return t
+	}
+	if t := mime.TypeByExtension(ext); t != "" {
+		return t
+	}
+	if len(data) == 0 {
+		return defaultContentType
+	}
+	return ct
+}
+
+// MatchContentType returns true if the content type matches one of the types.
+// A type without a subtype such as "image" matches all of its subtypes. Parameters are ignored.
+func MatchContentType(contentType string, types []string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	ct := MediaType(contentType)
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if strings.Contains(t, "/") {
+			if ct == MediaType(t) {
+				return true
+			}
+		} else if strings.HasPrefix(ct, t+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// MediaType returns the content type without its parameters in lower case.
+func MediaType(contentType string) string {
+	t, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(t))
+}
+
+func isGenericContentType(ct string) bool {
+	t := MediaType(ct)
+	return t == defaultContentType || t == "text/plain" || t == "text/xml" || t == "application/zip"
+}
@@ -0,0 +1,43 @@
+package asset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		want     string
+	}{
+		{name: "png", filename: "a.bin", data: []byte("\x89PNG\x0D\x0A\x1A\x0A"), want: "image/png"},
+		{name: "glb", filename: "model", data: []byte("glTF\x02\x00\x00\x00"), want: "model/gltf-binary"},
+		{name: "tiff", filename: "dem", data: []byte("II*\x00\x08\x00\x00\x00"), want: "image/tiff"},
+		{name: "geojson", filename: "a.geojson", data: []byte(`{"type":"FeatureCollection"}`), want: "application/geo+json"},
+		{name: "gltf", filename: "a.GLTF", data: []byte(`{"asset":{}}`), want: "model/gltf+json"},
+		{name: "kml", filename: "a.kml", data: []byte(`<?xml version="1.0"?><kml></kml>`), want: "application/vnd.google-earth.kml+xml"},
+		{name: "kmz", filename: "a.kmz", data: []byte("PK\x03\x04"), want: "application/vnd.google-earth.kmz"},
+		{name: "unknown text", filename: "a", data: []byte("hello"), want: "text/plain; charset=utf-8"},
+		{name: "empty", filename: "a", want: "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, DetectContentType(tt.filename, tt.data))
+		})
+	}
+}
+
+func TestMatchContentType(t *testing.T) {
+	assert.True(t, MatchContentType("image/png", nil))
+	assert.True(t, MatchContentType("image/png", []string{"image"}))
+	assert.True(t, MatchContentType("Image/PNG", []string{"video", "image/png"}))
+	assert.True(t, MatchContentType("text/plain; charset=utf-8", []string{"text/plain"}))
+	assert.False(t, MatchContentType("image/png", []string{"image/jpeg", "imag"}))
+	assert.False(t, MatchContentType("", []string{"image"}))
+}
@@ -0,0 +1,83 @@
+package asset
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+var ErrUnsupportedMetadata = errors.New("unsupported metadata")
+
+// Metadata is the information extracted from the content of an asset.
+// Only the fields which make sense for the content type are set.
+type Metadata struct {
+	// Width and Height are the pixel dimensions of images.
+	Width  int
+	Height int
+	// Bounds is the extent of a GeoTIFF in the coordinate reference system of the file.
+	Bounds *Bounds
+	// EPSG is the code of the coordinate reference system of a GeoTIFF if known.
+	EPSG int
+	// Duration is the length of a video in seconds.
+	Duration float64
+	Model    *ModelStats
+}
+
+type Bounds struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
+}
+
+// ModelStats is the statistics of a glTF model.
+type ModelStats struct {
+	Nodes      int
+	Meshes     int
+	Primitives int
+	Vertices   int
+	Materials  int
+	Textures   int
+	Animations int
+}
+
+func (m *Metadata) Clone() *Metadata {
+	if m == nil {
+		return nil
+	}
+	m2 := *m
+	if m.Bounds != nil {
+		b := *m.Bounds
+		m2.Bounds = &b
+	}
+	if m.Model != nil {
+		s := *m.Model
+		m2.Model = &s
+	}
+	return &m2
+}
+
+// ExtractMetadata extracts the metadata from the content, which may be only the leading part of the file.
+// It returns ErrUnsupportedMetadata if nothing can be extracted from the content type.
+func ExtractMetadata(contentType string, data []byte) (*Metadata, error) {
+	switch MediaType(contentType) {
+	case "image/png", "image/jpeg", "image/gif":
+		c, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return &Metadata{Width: c.Width, Height: c.Height}, nil
+	case "image/tiff":
+		return tiffMetadata(data)
+	case "model/gltf-binary":
+		return glbMetadata(data)
+	case "model/gltf+json":
+		return gltfMetadata(data)
+	case "video/mp4", "video/quicktime":
+		return mp4Metadata(data)
+	}
+	return nil, ErrUnsupportedMetadata
+}
@@ -0,0 +1,62 @@
+package asset
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidGLTF = errors.New("invalid gltf")
+
+type gltfDocument struct {
+	Nodes     []json.RawMessage `json:"nodes"`
+	Meshes    []gltfMesh        `json:"meshes"`
+	Accessors []struct {
+		Count int `json:"count"`
+	} `json:"accessors"`
+	Materials  []json.RawMessage `json:"materials"`
+	Textures   []json.RawMessage `json:"textures"`
+	Animations []json.RawMessage `json:"animations"`
+}
+
+type gltfMesh struct {
+	Primitives []struct {
+		Attributes map[string]int `json:"attributes"`
+	} `json:"primitives"`
+}
+
+// glbMetadata reads the JSON chunk of a binary glTF, which always comes first.
+func glbMetadata(data []byte) (*Metadata, error) {
+	if len(data) < 20 || string(data[:4]) != "glTF" || string(data[16:20]) != "JSON" {
+		return nil, ErrInvalidGLTF
+	}
+	l := int(binary.LittleEndian.Uint32(data[12:]))
+	if l < 0 || 20+l > len(data) {
+		return nil, ErrInvalidGLTF
+	}
+	return gltfMetadata(data[20 : 20+l])
+}
+
+func gltfMetadata(data []byte) (*Metadata, error) {
+	var d gltfDocument
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, ErrInvalidGLTF
+	}
+
+	s := &ModelStats{
+		Nodes:      len(d.Nodes),
+		Meshes:     len(d.Meshes),
+		Materials:  len(d.Materials),
+		Textures:   len(d.Textures),
+		Animations: len(d.Animations),
+	}
+	for _, m := range d.Meshes {
+		s.Primitives += len(m.Primitives)
+		for _, p := range m.Primitives {
+			if a, ok := p.Attributes["POSITION"]; ok && a >= 0 && a < len(d.Accessors) {
+				s.Vertices += d.Accessors[a].Count
+			}
+		}
+	}
+	return &Metadata{Model: s}, nil
+}
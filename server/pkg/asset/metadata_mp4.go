@@ -0,0 +1,76 @@
+package asset
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var ErrInvalidMP4 = errors.New("invalid mp4")
+
+// mp4Metadata reads the duration from the movie header box of an MP4 or QuickTime file.
+// The movie box is often placed after the media data, in which case the whole file is required.
+func mp4Metadata(data []byte) (*Metadata, error) {
+	moov := mp4Box(data, "moov")
+	if moov == nil {
+		return nil, ErrInvalidMP4
+	}
+	mvhd := mp4Box(moov, "mvhd")
+	if len(mvhd) < 4 {
+		return nil, ErrInvalidMP4
+	}
+
+	var timescale, duration uint64
+	switch mvhd[0] {
+	case 0:
+		if len(mvhd) < 20 {
+			return nil, ErrInvalidMP4
+		}
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[12:]))
+		duration = uint64(binary.BigEndian.Uint32(mvhd[16:]))
+	case 1:
+		if len(mvhd) < 32 {
+			return nil, ErrInvalidMP4
+		}
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[20:]))
+		duration = binary.BigEndian.Uint64(mvhd[24:])
+	default:
+		return nil, ErrInvalidMP4
+	}
+	if timescale == 0 {
+		return nil, ErrInvalidMP4
+	}
+
+	return &Metadata{Duration: float64(duration) / float64(timescale)}, nil
+}
+
+// mp4Box returns the content of the first box of the type in the data.
+func mp4Box(data []byte, typ string) []byte {
+	for len(data) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(data))
+		header := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil
+			}
+			size = binary.BigEndian.Uint64(data[8:])
+			header = 16
+		}
+		if size < header {
+			return nil
+		}
+		if string(data[4:8]) == typ {
+			if size > uint64(len(data)) {
+				return nil
+			}
+			return data[header:size]
+		}
+		if size > uint64(len(data)) {
+			return nil
+		}
+		data = data[size:]
+	}
+	return nil
+}
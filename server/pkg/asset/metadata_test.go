@@ -0,0 +1,135 @@
+package asset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMetadata_Image(t *testing.T) {
+	b := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(b, image.NewRGBA(image.Rect(0, 0, 30, 20))))
+
+	got, err := ExtractMetadata("image/png", b.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, &Metadata{Width: 30, Height: 20}, got)
+
+	_, err = ExtractMetadata("image/png", []byte("png"))
+	assert.Error(t, err)
+
+	_, err = ExtractMetadata("text/plain", nil)
+	assert.Same(t, ErrUnsupportedMetadata, err)
+}
+
+func TestExtractMetadata_GeoTIFF(t *testing.T) {
+	type entry struct {
+		tag, typ uint16
+		values   []uint64
+	}
+	entries := []entry{
+		{tag: tiffTagImageWidth, typ: tiffTypeShort, values: []uint64{100}},
+		{tag: tiffTagImageLength, typ: tiffTypeLong, values: []uint64{50}},
+		{tag: tiffTagModelPixelScale, typ: tiffTypeDouble, values: floatBits(0.1, 0.2, 0)},
+		{tag: tiffTagModelTiepoint, typ: tiffTypeDouble, values: floatBits(0, 0, 0, 130, 40, 0)},
+		{tag: tiffTagGeoKeyDirectory, typ: tiffTypeShort, values: []uint64{1, 1, 0, 1, geoKeyGeographicType, 0, 1, 4326}},
+	}
+
+	bo := binary.LittleEndian
+	head := []byte("II*\x00\x08\x00\x00\x00")
+	ifd := make([]byte, 2+len(entries)*12+4)
+	bo.PutUint16(ifd, uint16(len(entries)))
+	var extra []byte
+	for i, e := range entries {
+		size := map[uint16]int{tiffTypeShort: 2, tiffTypeLong: 4, tiffTypeDouble: 8}[e.typ]
+		v := make([]byte, len(e.values)*size)
+		for j, x := range e.values {
+			switch size {
+			case 2:
+				bo.PutUint16(v[j*2:], uint16(x))
+			case 4:
+				bo.PutUint32(v[j*4:], uint32(x))
+			case 8:
+				bo.PutUint64(v[j*8:], x)
+			}
+		}
+		p := ifd[2+i*12:]
+		bo.PutUint16(p, e.tag)
+		bo.PutUint16(p[2:], e.typ)
+		bo.PutUint32(p[4:], uint32(len(e.values)))
+		if len(v) <= 4 {
+			copy(p[8:], v)
+		} else {
+			bo.PutUint32(p[8:], uint32(len(head)+len(ifd)+len(extra)))
+			extra = append(extra, v...)
+		}
+	}
+	data := append(append(head, ifd...), extra...)
+
+	got, err := ExtractMetadata("image/tiff", data)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, got.Width)
+	assert.Equal(t, 50, got.Height)
+	assert.Equal(t, 4326, got.EPSG)
+	assert.InDelta(t, 130, got.Bounds.West, 1e-9)
+	assert.InDelta(t, 140, got.Bounds.East, 1e-9)
+	assert.InDelta(t, 30, got.Bounds.South, 1e-9)
+	assert.InDelta(t, 40, got.Bounds.North, 1e-9)
+
+	_, err = ExtractMetadata("image/tiff", data[:20])
+	assert.Same(t, ErrInvalidTIFF, err)
+}
+
+func TestExtractMetadata_GLTF(t *testing.T) {
+	j := []byte(`{"nodes":[{},{}],"meshes":[{"primitives":[{"attributes":{"POSITION":0}},{"attributes":{"POSITION":1}}]}],"accessors":[{"count":3},{"count":4}],"materials":[{}]}`)
+	want := &Metadata{Model: &ModelStats{Nodes: 2, Meshes: 1, Primitives: 2, Vertices: 7, Materials: 1}}
+
+	got, err := ExtractMetadata("model/gltf+json", j)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	glb := make([]byte, 20)
+	copy(glb, "glTF")
+	binary.LittleEndian.PutUint32(glb[4:], 2)
+	binary.LittleEndian.PutUint32(glb[12:], uint32(len(j)))
+	copy(glb[16:], "JSON")
+	glb = append(glb, j...)
+	got, err = ExtractMetadata("model/gltf-binary", glb)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = ExtractMetadata("model/gltf-binary", glb[:30])
+	assert.Same(t, ErrInvalidGLTF, err)
+}
+
+func TestExtractMetadata_MP4(t *testing.T) {
+	box := func(typ string, content []byte) []byte {
+		b := make([]byte, 8, 8+len(content))
+		binary.BigEndian.PutUint32(b, uint32(8+len(content)))
+		copy(b[4:], typ)
+		return append(b, content...)
+	}
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:], 1000)
+	binary.BigEndian.PutUint32(mvhd[16:], 12500)
+	data := append(append(box("ftyp", []byte("isom")), box("mdat", make([]byte, 16))...), box("moov", box("mvhd", mvhd))...)
+
+	got, err := ExtractMetadata("video/mp4", data)
+	assert.NoError(t, err)
+	assert.Equal(t, &Metadata{Duration: 12.5}, got)
+
+	_, err = ExtractMetadata("video/mp4", data[:40])
+	assert.Same(t, ErrInvalidMP4, err)
+}
+
+func floatBits(f ...float64) []uint64 {
+	res := make([]uint64, len(f))
+	for i, v := range f {
+		res[i] = math.Float64bits(v)
+	}
+	return res
+}
@@ -0,0 +1,196 @@
+package asset
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var ErrInvalidTIFF = errors.New("invalid tiff")
+
+const (
+	tiffTagImageWidth          = 256
+	tiffTagImageLength         = 257
+	tiffTagModelPixelScale     = 33550
+	tiffTagModelTiepoint       = 33922
+	tiffTagModelTransformation = 34264
+	tiffTagGeoKeyDirectory     = 34735
+
+	geoKeyGeographicType  = 2048
+	geoKeyProjectedCSType = 3072
+	geoKeyUserDefined     = 32767
+
+	tiffTypeShort  = 3
+	tiffTypeLong   = 4
+	tiffTypeDouble = 12
+)
+
+// tiffMetadata reads the dimensions and the georeferencing of the first image of a TIFF.
+func tiffMetadata(data []byte) (*Metadata, error) {
+	if len(data) < 8 {
+		return nil, ErrInvalidTIFF
+	}
+
+	var bo binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		bo = binary.LittleEndian
+	case "MM":
+		bo = binary.BigEndian
+	default:
+		return nil, ErrInvalidTIFF
+	}
+	if bo.Uint16(data[2:]) != 42 {
+		// BigTIFF is not supported
+		return nil, ErrInvalidTIFF
+	}
+
+	ifd := int(bo.Uint32(data[4:]))
+	if ifd+2 > len(data) {
+		return nil, ErrInvalidTIFF
+	}
+	n := int(bo.Uint16(data[ifd:]))
+	if ifd+2+n*12 > len(data) {
+		return nil, ErrInvalidTIFF
+	}
+
+	var scale, tiepoint, transformation []float64
+	var geoKeys []uint64
+	m := &Metadata{}
+	for i := 0; i < n; i++ {
+		e := data[ifd+2+i*12:]
+		values, err := tiffValues(data, bo, e)
+		if err != nil {
+			return nil, err
+		}
+		switch bo.Uint16(e) {
+		case tiffTagImageWidth:
+			m.Width = int(tiffUint(values))
+		case tiffTagImageLength:
+			m.Height = int(tiffUint(values))
+		case tiffTagModelPixelScale:
+			scale = tiffFloats(values)
+		case tiffTagModelTiepoint:
+			tiepoint = tiffFloats(values)
+		case tiffTagModelTransformation:
+			transformation = tiffFloats(values)
+		case tiffTagGeoKeyDirectory:
+			geoKeys = values
+		}
+	}
+
+	m.Bounds = tiffBounds(m.Width, m.Height, scale, tiepoint, transformation)
+	m.EPSG = tiffEPSG(geoKeys)
+	return m, nil
+}
+
+// tiffValues returns the values of an IFD entry. Doubles are returned as their bits.
+func tiffValues(data []byte, bo binary.ByteOrder, e []byte) ([]uint64, error) {
+	typ := bo.Uint16(e[2:])
+	count := int(bo.Uint32(e[4:]))
+
+	var size int
+	switch typ {
+	case tiffTypeShort:
+		size = 2
+	case tiffTypeLong:
+		size = 4
+	case tiffTypeDouble:
+		size = 8
+	default:
+		return nil, nil
+	}
+
+	v := e[8:12]
+	if count*size > 4 {
+		off := int(bo.Uint32(e[8:]))
+		if count < 0 || off < 0 || off+count*size > len(data) {
+			return nil, ErrInvalidTIFF
+		}
+		v = data[off:]
+	}
+
+	res := make([]uint64, count)
+	for i := range res {
+		switch size {
+		case 2:
+			res[i] = uint64(bo.Uint16(v[i*2:]))
+		case 4:
+			res[i] = uint64(bo.Uint32(v[i*4:]))
+		case 8:
+			res[i] = bo.Uint64(v[i*8:])
+		}
+	}
+	return res, nil
+}
+
+func tiffUint(v []uint64) uint64 {
+	if len(v) == 0 {
+		return 0
+	}
+	return v[0]
+}
+
+func tiffFloats(v []uint64) []float64 {
+	res := make([]float64, len(v))
+	for i, b := range v {
+		res[i] = math.Float64frombits(b)
+	}
+	return res
+}
+
+func tiffBounds(width, height int, scale, tiepoint, transformation []float64) *Bounds {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	var toModel func(i, j float64) (float64, float64)
+	switch {
+	case len(transformation) >= 16:
+		t := transformation
+		toModel = func(i, j float64) (float64, float64) {
+			return t[0]*i + t[1]*j + t[3], t[4]*i + t[5]*j + t[7]
+		}
+	case len(scale) >= 2 && len(tiepoint) >= 6:
+		toModel = func(i, j float64) (float64, float64) {
+			return tiepoint[3] + (i-tiepoint[0])*scale[0], tiepoint[4] - (j-tiepoint[1])*scale[1]
+		}
+	default:
+		return nil
+	}
+
+	w, h := float64(width), float64(height)
+	b := Bounds{West: math.Inf(1), South: math.Inf(1), East: math.Inf(-1), North: math.Inf(-1)}
+	for _, c := range [][2]float64{{0, 0}, {w, 0}, {0, h}, {w, h}} {
+		x, y := toModel(c[0], c[1])
+		b.West = math.Min(b.West, x)
+		b.East = math.Max(b.East, x)
+		b.South = math.Min(b.South, y)
+		b.North = math.Max(b.North, y)
+	}
+	return &b
+}
+
+// tiffEPSG returns the EPSG code of the projected or geographic CRS in the GeoKey directory.
+func tiffEPSG(keys []uint64) int {
+	if len(keys) < 4 {
+		return 0
+	}
+	var geographic int
+	for i := 4; i+3 < len(keys) && i < 4+int(keys[3])*4; i += 4 {
+		// only keys whose values are stored in the directory itself are supported
+		if keys[i+1] != 0 {
+			continue
+		}
+		if keys[i+3] == geoKeyUserDefined {
+			continue
+		}
+		switch keys[i] {
+		case geoKeyProjectedCSType:
+			return int(keys[i+3])
+		case geoKeyGeographicType:
+			geographic = int(keys[i+3])
+		}
+	}
+	return geographic
+}
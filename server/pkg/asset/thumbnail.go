@@ -0,0 +1,81 @@
+package asset
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+const (
+	ThumbnailSize = 256
+	// maxThumbnailPixels prevents decoding huge images only to make thumbnails.
+	maxThumbnailPixels = 50_000_000
+)
+
+var ErrUnsupportedThumbnail = errors.New("unsupported thumbnail")
+
+// Thumbnail returns a PNG image that fits in a square of the size, keeping the aspect ratio of the image.
+// Images smaller than the size are not enlarged.
+func Thumbnail(contentType string, data []byte, size int) ([]byte, error) {
+	switch MediaType(contentType) {
+	case "image/png", "image/jpeg", "image/gif":
+	default:
+		return nil, ErrUnsupportedThumbnail
+	}
+
+	c, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if c.Width <= 0 || c.Height <= 0 || c.Width*c.Height > maxThumbnailPixels {
+		return nil, ErrUnsupportedThumbnail
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	b := &bytes.Buffer{}
+	if err := png.Encode(b, resize(img, size)); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// resize scales down the image by averaging the source pixels covered by each destination pixel.
+func resize(src image.Image, size int) image.Image {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	dw, dh := sw, sh
+	if sw > size || sh > size {
+		if sw >= sh {
+			dw, dh = size, max(1, sh*size/sw)
+		} else {
+			dw, dh = max(1, sw*size/sh), size
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := sb.Min.Y+y*sh/dh, sb.Min.Y+max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := sb.Min.X+x*sw/dw, sb.Min.X+max((x+1)*sw/dw, x*sw/dw+1)
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+			dst.Set(x, y, color.NRGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
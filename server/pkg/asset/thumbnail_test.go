@@ -0,0 +1,40 @@
+package asset
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbnail(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			src.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	b := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(b, src))
+
+	res, err := Thumbnail("image/png", b.Bytes(), 100)
+	assert.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(res))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 100, 50), img.Bounds())
+	r, g, _, a := img.At(10, 10).RGBA()
+	assert.Equal(t, []uint32{0xffff, 0, 0xffff}, []uint32{r, g, a})
+
+	// small images are not enlarged
+	res, err = Thumbnail("image/png", b.Bytes(), 1000)
+	assert.NoError(t, err)
+	img, err = png.Decode(bytes.NewReader(res))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 400, 200), img.Bounds())
+
+	_, err = Thumbnail("image/tiff", b.Bytes(), 100)
+	assert.Same(t, ErrUnsupportedThumbnail, err)
+}
@@ -0,0 +1,104 @@
+package auditlog
+
+// Action is the kind of mutation in the form of "<target>.<verb>".
+type Action string
+
+const (
+	ActionProjectCreate    Action = "project.create"
+	ActionProjectUpdate    Action = "project.update"
+	ActionProjectPublish   Action = "project.publish"
+	ActionProjectUnpublish Action = "project.unpublish"
+	ActionProjectDelete    Action = "project.delete"
+	ActionProjectGrant     Action = "project.grant"
+	ActionProjectRevoke    Action = "project.revoke"
+
+	ActionProjectSchedulePublish           Action = "project.schedulePublish"
+	ActionProjectRollback                  Action = "project.rollback"
+	ActionProjectPreview                   Action = "project.preview"
+	ActionProjectDuplicate                 Action = "project.duplicate"
+	ActionProjectImport                    Action = "project.import"
+	ActionProjectAddBasicAuthCredential    Action = "project.addBasicAuthCredential"
+	ActionProjectRevokeBasicAuthCredential Action = "project.revokeBasicAuthCredential"
+
+	ActionSceneAddWidget         Action = "scene.addWidget"
+	ActionSceneUpdateWidget      Action = "scene.updateWidget"
+	ActionSceneRemoveWidget      Action = "scene.removeWidget"
+	ActionSceneInstallPlugin     Action = "scene.installPlugin"
+	ActionSceneUninstallPlugin   Action = "scene.uninstallPlugin"
+	ActionSceneUpgradePlugin     Action = "scene.upgradePlugin"
+	ActionSceneAddCluster        Action = "scene.addCluster"
+	ActionSceneUpdateCluster     Action = "scene.updateCluster"
+	ActionSceneRemoveCluster     Action = "scene.removeCluster"
+	ActionSceneUpdateAlignSystem Action = "scene.updateWidgetAlignSystem"
+	ActionSceneUndo              Action = "scene.undo"
+	ActionSceneRedo              Action = "scene.redo"
+	// ActionSceneUpdateLock is only published to clients viewing the scene and is not recorded.
+	ActionSceneUpdateLock Action = "scene.updateLock"
+
+	ActionLayerAdd    Action = "layer.add"
+	ActionLayerUpdate Action = "layer.update"
+	ActionLayerMove   Action = "layer.move"
+	ActionLayerRemove Action = "layer.remove"
+	ActionLayerImport Action = "layer.import"
+
+	ActionLayerCreateInfobox      Action = "layer.createInfobox"
+	ActionLayerRemoveInfobox      Action = "layer.removeInfobox"
+	ActionLayerAddInfoboxField    Action = "layer.addInfoboxField"
+	ActionLayerMoveInfoboxField   Action = "layer.moveInfoboxField"
+	ActionLayerRemoveInfoboxField Action = "layer.removeInfoboxField"
+
+	ActionNLSLayerAdd           Action = "nlsLayer.add"
+	ActionNLSLayerUpdate        Action = "nlsLayer.update"
+	ActionNLSLayerRemove        Action = "nlsLayer.remove"
+	ActionNLSLayerImport        Action = "nlsLayer.import"
+	ActionNLSLayerAddFeature    Action = "nlsLayer.addFeature"
+	ActionNLSLayerUpdateFeature Action = "nlsLayer.updateFeature"
+	ActionNLSLayerRemoveFeature Action = "nlsLayer.removeFeature"
+	ActionNLSLayerAddBlock      Action = "nlsLayer.addInfoboxBlock"
+	ActionNLSLayerMoveBlock     Action = "nlsLayer.moveInfoboxBlock"
+	ActionNLSLayerRemoveBlock   Action = "nlsLayer.removeInfoboxBlock"
+	ActionNLSLayerCreateInfobox Action = "nlsLayer.createInfobox"
+	ActionNLSLayerRemoveInfobox Action = "nlsLayer.removeInfobox"
+	ActionNLSLayerUpdateSchema  Action = "nlsLayer.updateCustomPropertySchema"
+
+	ActionPropertyUpdateValue Action = "property.updateValue"
+	ActionPropertyRemoveField Action = "property.removeField"
+	ActionPropertyAddItem     Action = "property.addItem"
+	ActionPropertyMoveItem    Action = "property.moveItem"
+	ActionPropertyRemoveItem  Action = "property.removeItem"
+	ActionPropertyUpdateItems Action = "property.updateItems"
+	ActionPropertyLinkValue   Action = "property.linkValue"
+	ActionPropertyUnlinkValue Action = "property.unlinkValue"
+
+	ActionStoryCreate      Action = "story.create"
+	ActionStoryUpdate      Action = "story.update"
+	ActionStoryRemove      Action = "story.remove"
+	ActionStoryPublish     Action = "story.publish"
+	ActionStoryUnpublish   Action = "story.unpublish"
+	ActionStoryAddPage     Action = "story.addPage"
+	ActionStoryUpdatePage  Action = "story.updatePage"
+	ActionStoryMovePage    Action = "story.movePage"
+	ActionStoryRemovePage  Action = "story.removePage"
+	ActionStoryAddBlock    Action = "story.addBlock"
+	ActionStoryMoveBlock   Action = "story.moveBlock"
+	ActionStoryRemoveBlock Action = "story.removeBlock"
+
+	ActionStoryAddPageLayer    Action = "story.addPageLayer"
+	ActionStoryRemovePageLayer Action = "story.removePageLayer"
+
+	ActionStorySchedulePublish           Action = "story.schedulePublish"
+	ActionStoryRollback                  Action = "story.rollback"
+	ActionStoryAddBasicAuthCredential    Action = "story.addBasicAuthCredential"
+	ActionStoryRevokeBasicAuthCredential Action = "story.revokeBasicAuthCredential"
+
+	ActionStyleAdd    Action = "style.add"
+	ActionStyleUpdate Action = "style.update"
+	ActionStyleRemove Action = "style.remove"
+
+	ActionDatasetImport              Action = "dataset.import"
+	ActionDatasetSync                Action = "dataset.sync"
+	ActionDatasetAddSchema           Action = "dataset.addSchema"
+	ActionDatasetUpdateSchema        Action = "dataset.updateSchema"
+	ActionDatasetUpdateSchemaRefresh Action = "dataset.updateSchemaRefresh"
+	ActionDatasetRemoveSchema        Action = "dataset.removeSchema"
+)
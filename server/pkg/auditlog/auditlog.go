@@ -0,0 +1,104 @@
+package auditlog
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/reearth/reearthx/idx"
+)
+
+var (
+	ErrEmptyWorkspaceID = errors.New("require workspace id")
+	ErrEmptyAction      = errors.New("require action")
+)
+
+// Log is an immutable record of a mutation made by a user or by the system.
+type Log struct {
+	id        ID
+	actor     *UserID
+	action    Action
+	workspace WorkspaceID
+	scene     *SceneID
+	targets   []Target
+	changes   []Change
+	createdAt time.Time
+}
+
+// Target is an object which is changed by the mutation.
+type Target struct {
+	Type string
+	ID   string
+}
+
+// Change is a change of a field of the target. Before is nil if the field is added and After is nil if it is removed.
+type Change struct {
+	Field  string
+	Before any
+	After  any
+}
+
+func (l *Log) ID() ID {
+	return l.id
+}
+
+// Actor is the user who made the mutation. It is nil if the mutation was made by the system such as a scheduler.
+func (l *Log) Actor() *UserID {
+	return l.actor.CloneRef()
+}
+
+func (l *Log) Action() Action {
+	return l.action
+}
+
+func (l *Log) Workspace() WorkspaceID {
+	return l.workspace
+}
+
+func (l *Log) Scene() *SceneID {
+	return l.scene.CloneRef()
+}
+
+func (l *Log) Targets() []Target {
+	return append([]Target{}, l.targets...)
+}
+
+func (l *Log) Changes() []Change {
+	return append([]Change{}, l.changes...)
+}
+
+func (l *Log) CreatedAt() time.Time {
+	if l.createdAt.IsZero() {
+		return l.id.Timestamp()
+	}
+	return l.createdAt
+}
+
+// HasTarget returns true if the log has the target whose ID is the id.
+func (l *Log) HasTarget(id string) bool {
+	for _, t := range l.targets {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// TargetOf returns the target of the ID.
+func TargetOf[T idx.Type](i idx.ID[T]) Target {
+	var t T
+	return Target{Type: t.Type(), ID: i.String()}
+}
+
+// PluginTarget returns the target of the plugin, whose ID is not an idx.ID.
+func PluginTarget(p PluginID) Target {
+	return Target{Type: "plugin", ID: p.String()}
+}
+
+// NewChange returns a change of the field, or nil if the values are the same.
+func NewChange(field string, before, after any) *Change {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	return &Change{Field: field, Before: before, After: after}
+}
@@ -0,0 +1,88 @@
+package auditlog
+
+import (
+	"time"
+)
+
+type Builder struct {
+	l *Log
+}
+
+func New() *Builder {
+	return &Builder{l: &Log{}}
+}
+
+func (b *Builder) Build() (*Log, error) {
+	if b.l.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if b.l.workspace.IsNil() {
+		return nil, ErrEmptyWorkspaceID
+	}
+	if b.l.action == "" {
+		return nil, ErrEmptyAction
+	}
+	if b.l.createdAt.IsZero() {
+		b.l.createdAt = b.l.id.Timestamp()
+	}
+	return b.l, nil
+}
+
+func (b *Builder) MustBuild() *Log {
+	r, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.l.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.l.id = NewID()
+	return b
+}
+
+func (b *Builder) Actor(actor *UserID) *Builder {
+	b.l.actor = actor.CloneRef()
+	return b
+}
+
+func (b *Builder) Action(action Action) *Builder {
+	b.l.action = action
+	return b
+}
+
+func (b *Builder) Workspace(workspace WorkspaceID) *Builder {
+	b.l.workspace = workspace
+	return b
+}
+
+func (b *Builder) Scene(scene *SceneID) *Builder {
+	b.l.scene = scene.CloneRef()
+	return b
+}
+
+func (b *Builder) Targets(targets ...Target) *Builder {
+	b.l.targets = append([]Target{}, targets...)
+	return b
+}
+
+// Changes sets the changes. Nil changes, which are made by NewChange for unchanged fields, are skipped.
+func (b *Builder) Changes(changes ...*Change) *Builder {
+	b.l.changes = nil
+	for _, c := range changes {
+		if c != nil {
+			b.l.changes = append(b.l.changes, *c)
+		}
+	}
+	return b
+}
+
+func (b *Builder) CreatedAt(createdAt time.Time) *Builder {
+	b.l.createdAt = createdAt
+	return b
+}
@@ -0,0 +1,66 @@
+package auditlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	lid := NewID()
+	wid := accountdomain.NewWorkspaceID()
+	sid := id.NewSceneID()
+	pid := id.NewProjectID()
+	uid := accountdomain.NewUserID()
+	now := time.Now().Truncate(time.Millisecond)
+
+	l, err := New().
+		ID(lid).
+		Actor(&uid).
+		Action(ActionProjectUnpublish).
+		Workspace(wid).
+		Scene(&sid).
+		Targets(TargetOf(pid)).
+		Changes(
+			NewChange("publishmentStatus", "public", "private"),
+			NewChange("alias", "alias", "alias"),
+		).
+		CreatedAt(now).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, lid, l.ID())
+	assert.Equal(t, &uid, l.Actor())
+	assert.Equal(t, ActionProjectUnpublish, l.Action())
+	assert.Equal(t, wid, l.Workspace())
+	assert.Equal(t, &sid, l.Scene())
+	assert.Equal(t, []Target{{Type: "project", ID: pid.String()}}, l.Targets())
+	assert.Equal(t, []Change{{Field: "publishmentStatus", Before: "public", After: "private"}}, l.Changes())
+	assert.Equal(t, now, l.CreatedAt())
+	assert.True(t, l.HasTarget(pid.String()))
+	assert.False(t, l.HasTarget(sid.String()))
+
+	// system actions have no actor
+	l, err = New().ID(lid).Action(ActionProjectPublish).Workspace(wid).Build()
+	assert.NoError(t, err)
+	assert.Nil(t, l.Actor())
+	assert.Nil(t, l.Scene())
+	assert.Equal(t, lid.Timestamp(), l.CreatedAt())
+
+	_, err = New().Action(ActionProjectPublish).Workspace(wid).Build()
+	assert.Equal(t, ErrInvalidID, err)
+
+	_, err = New().NewID().Action(ActionProjectPublish).Build()
+	assert.Equal(t, ErrEmptyWorkspaceID, err)
+
+	_, err = New().NewID().Workspace(wid).Build()
+	assert.Equal(t, ErrEmptyAction, err)
+}
+
+func TestNewChange(t *testing.T) {
+	assert.Nil(t, NewChange("name", "a", "a"))
+	assert.Nil(t, NewChange("tags", []string{"a"}, []string{"a"}))
+	assert.Equal(t, &Change{Field: "name", Before: "a", After: nil}, NewChange("name", "a", nil))
+}
@@ -0,0 +1,26 @@
+package auditlog
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type ID = id.AuditLogID
+type SceneID = id.SceneID
+type WorkspaceID = accountdomain.WorkspaceID
+type UserID = accountdomain.UserID
+type PluginID = id.PluginID
+
+var NewID = id.NewAuditLogID
+var MustID = id.MustAuditLogID
+var IDFrom = id.AuditLogIDFrom
+var IDFromRef = id.AuditLogIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
+
+func MockNewID(lid ID) func() {
+	NewID = func() ID { return lid }
+	return func() {
+		NewID = id.NewAuditLogID
+	}
+}
@@ -0,0 +1,3 @@
+package auditlog
+
+type List []*Log
@@ -0,0 +1,160 @@
+package basicauth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearthx/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultName is the name of the credential which is set through the single username and password of a publication.
+const DefaultName = "default"
+
+var (
+	ErrEmptyName     = errors.New("credential name is empty")
+	ErrEmptyUsername = errors.New("basic auth username is empty")
+	ErrEmptyPassword = errors.New("basic auth password is empty")
+	ErrInvalidHash   = errors.New("invalid password hash")
+)
+
+// Credential is a named pair of a username and a salted password hash to access a publication.
+type Credential struct {
+	name         string
+	username     string
+	passwordHash string
+	createdAt    time.Time
+	revokedAt    *time.Time
+}
+
+func NewCredential(name, username, password string) (*Credential, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if username == "" {
+		return nil, ErrEmptyUsername
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		name:         name,
+		username:     username,
+		passwordHash: hash,
+		createdAt:    util.Now(),
+	}, nil
+}
+
+// CredentialFrom restores a credential from a password hash which has been already stored.
+func CredentialFrom(name, username, passwordHash string, createdAt time.Time, revokedAt *time.Time) (*Credential, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if !IsHash(passwordHash) {
+		return nil, ErrInvalidHash
+	}
+
+	return &Credential{
+		name:         name,
+		username:     username,
+		passwordHash: passwordHash,
+		createdAt:    createdAt,
+		revokedAt:    util.CloneRef(revokedAt),
+	}, nil
+}
+
+func (c *Credential) Name() string {
+	if c == nil {
+		return ""
+	}
+	return c.name
+}
+
+func (c *Credential) Username() string {
+	if c == nil {
+		return ""
+	}
+	return c.username
+}
+
+func (c *Credential) PasswordHash() string {
+	if c == nil {
+		return ""
+	}
+	return c.passwordHash
+}
+
+func (c *Credential) CreatedAt() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	return c.createdAt
+}
+
+func (c *Credential) RevokedAt() *time.Time {
+	if c == nil {
+		return nil
+	}
+	return util.CloneRef(c.revokedAt)
+}
+
+func (c *Credential) IsRevoked() bool {
+	return c == nil || c.revokedAt != nil
+}
+
+// Match reports whether the username and the password match the credential. A revoked credential never matches.
+func (c *Credential) Match(username, password string) bool {
+	if c.IsRevoked() {
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(username), []byte(c.username)) != 1 {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(c.passwordHash), []byte(password)) == nil
+}
+
+func (c *Credential) Clone() *Credential {
+	if c == nil {
+		return nil
+	}
+	c2 := *c
+	c2.revokedAt = util.CloneRef(c.revokedAt)
+	return &c2
+}
+
+func (c *Credential) setPassword(password string) error {
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	c.passwordHash = hash
+	return nil
+}
+
+func (c *Credential) revoke(now time.Time) {
+	if c.revokedAt == nil {
+		c.revokedAt = &now
+	}
+}
+
+// HashPassword returns a salted bcrypt hash of the password.
+func HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", ErrEmptyPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// IsHash reports whether s is a password hash generated by HashPassword.
+func IsHash(s string) bool {
+	_, err := bcrypt.Cost([]byte(s))
+	return err == nil
+}
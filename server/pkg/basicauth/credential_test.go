@@ -0,0 +1,53 @@
+package basicauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCredential(t *testing.T) {
+	c, err := NewCredential("default", "user", "pass")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", c.Name())
+	assert.Equal(t, "user", c.Username())
+	assert.NotEqual(t, "pass", c.PasswordHash())
+	assert.True(t, IsHash(c.PasswordHash()))
+	assert.False(t, c.IsRevoked())
+
+	_, err = NewCredential("", "user", "pass")
+	assert.Equal(t, ErrEmptyName, err)
+	_, err = NewCredential("default", "", "pass")
+	assert.Equal(t, ErrEmptyUsername, err)
+	_, err = NewCredential("default", "user", "")
+	assert.Equal(t, ErrEmptyPassword, err)
+}
+
+func TestCredentialFrom(t *testing.T) {
+	hash, err := HashPassword("pass")
+	assert.NoError(t, err)
+	now := time.Now()
+
+	c, err := CredentialFrom("a", "user", hash, now, &now)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, c.PasswordHash())
+	assert.Equal(t, &now, c.RevokedAt())
+	assert.True(t, c.IsRevoked())
+
+	_, err = CredentialFrom("a", "user", "pass", now, nil)
+	assert.Equal(t, ErrInvalidHash, err)
+}
+
+func TestCredential_Match(t *testing.T) {
+	c, _ := NewCredential("default", "user", "pass")
+
+	assert.True(t, c.Match("user", "pass"))
+	assert.False(t, c.Match("user", "pass2"))
+	assert.False(t, c.Match("user2", "pass"))
+
+	c.revoke(time.Now())
+	assert.False(t, c.Match("user", "pass"))
+
+	assert.False(t, (*Credential)(nil).Match("", ""))
+}
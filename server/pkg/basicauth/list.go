@@ -0,0 +1,104 @@
+package basicauth
+
+import (
+	"errors"
+
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
+)
+
+var (
+	ErrDuplicatedName     = errors.New("credential name is already used")
+	ErrCredentialNotFound = errors.New("credential not found")
+)
+
+type List []*Credential
+
+func (l List) Find(name string) *Credential {
+	c, _ := lo.Find(l, func(c *Credential) bool {
+		return c.Name() == name
+	})
+	return c
+}
+
+func (l List) Active() List {
+	return lo.Filter(l, func(c *Credential, _ int) bool {
+		return !c.IsRevoked()
+	})
+}
+
+// Match returns the active credential that matches the username and the password.
+func (l List) Match(username, password string) *Credential {
+	for _, c := range l {
+		if c.Match(username, password) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Add returns a new list with the credential. A revoked credential with the same name is replaced.
+func (l List) Add(c *Credential) (List, error) {
+	if c == nil {
+		return l, nil
+	}
+	if old := l.Find(c.Name()); old != nil && !old.IsRevoked() {
+		return nil, ErrDuplicatedName
+	}
+
+	res := lo.Reject(l.Clone(), func(c2 *Credential, _ int) bool {
+		return c2.Name() == c.Name()
+	})
+	return append(res, c), nil
+}
+
+// Revoke returns a new list where the credential of the name is revoked.
+func (l List) Revoke(name string) (List, error) {
+	if c := l.Find(name); c == nil || c.IsRevoked() {
+		return nil, ErrCredentialNotFound
+	}
+
+	res := l.Clone()
+	res.Find(name).revoke(util.Now())
+	return res, nil
+}
+
+// SetDefault returns a new list where the username and the password of the default credential are updated.
+// The default credential is created if it does not exist yet. Empty values are ignored
+// since the password cannot be read back from its hash and clients send it back empty.
+func (l List) SetDefault(username, password *string) (List, error) {
+	u, p := lo.FromPtr(username), lo.FromPtr(password)
+	if u == "" && p == "" {
+		return l, nil
+	}
+
+	def := l.Find(DefaultName)
+	if def == nil || def.IsRevoked() {
+		c, err := NewCredential(DefaultName, u, p)
+		if err != nil {
+			return nil, err
+		}
+		return l.Add(c)
+	}
+
+	res := l.Clone()
+	def = res.Find(DefaultName)
+	if u != "" {
+		def.username = u
+	}
+	if p != "" {
+		if err := def.setPassword(p); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (l List) Clone() List {
+	if l == nil {
+		return nil
+	}
+	return lo.Map(l, func(c *Credential, _ int) *Credential {
+		return c.Clone()
+	})
+}
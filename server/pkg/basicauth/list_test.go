@@ -0,0 +1,68 @@
+package basicauth
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList_Add(t *testing.T) {
+	a := lo.Must(NewCredential("a", "user", "pass"))
+	a2 := lo.Must(NewCredential("a", "user2", "pass2"))
+
+	l, err := List{}.Add(a)
+	assert.NoError(t, err)
+	assert.Equal(t, List{a}, l)
+
+	_, err = l.Add(a2)
+	assert.Equal(t, ErrDuplicatedName, err)
+
+	l, err = l.Revoke("a")
+	assert.NoError(t, err)
+	assert.True(t, l.Find("a").IsRevoked())
+	assert.False(t, a.IsRevoked())
+
+	l, err = l.Add(a2)
+	assert.NoError(t, err)
+	assert.Equal(t, List{a2}, l)
+}
+
+func TestList_Revoke(t *testing.T) {
+	l := List{
+		lo.Must(NewCredential("a", "user", "pass")),
+		lo.Must(NewCredential("b", "user2", "pass2")),
+	}
+
+	l, err := l.Revoke("a")
+	assert.NoError(t, err)
+	assert.Nil(t, l.Match("user", "pass"))
+	assert.Equal(t, "b", l.Match("user2", "pass2").Name())
+	assert.Len(t, l.Active(), 1)
+
+	_, err = l.Revoke("a")
+	assert.Equal(t, ErrCredentialNotFound, err)
+	_, err = l.Revoke("c")
+	assert.Equal(t, ErrCredentialNotFound, err)
+}
+
+func TestList_SetDefault(t *testing.T) {
+	l, err := List(nil).SetDefault(nil, lo.ToPtr(""))
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+
+	l, err = l.SetDefault(lo.ToPtr("user"), lo.ToPtr("pass"))
+	assert.NoError(t, err)
+	assert.True(t, l.Find(DefaultName).Match("user", "pass"))
+
+	l, err = l.SetDefault(lo.ToPtr("user2"), lo.ToPtr(""))
+	assert.NoError(t, err)
+	assert.True(t, l.Find(DefaultName).Match("user2", "pass"))
+
+	l, err = l.SetDefault(nil, lo.ToPtr("pass2"))
+	assert.NoError(t, err)
+	assert.True(t, l.Find(DefaultName).Match("user2", "pass2"))
+
+	_, err = List(nil).SetDefault(lo.ToPtr("user"), nil)
+	assert.Equal(t, ErrEmptyPassword, err)
+}
@@ -0,0 +1,145 @@
+package crs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrUnsupportedCRS = errors.New("unsupported coordinate reference system")
+
+// CRS is a coordinate reference system. Coordinates are always treated as (x, y) = (easting, northing) for
+// projected CRSs and (longitude, latitude) in degrees for geographic CRSs regardless of the axis order
+// defined by EPSG, as most GIS file formats do.
+//
+// Geographic CRSs based on JGD2000 and JGD2011 are treated as identical to WGS84
+// since the differences between them are negligible for visualization.
+type CRS struct {
+	code  int
+	name  string
+	datum datum
+	proj  projection
+}
+
+type projection interface {
+	forward(lng, lat float64) (x, y float64)
+	inverse(x, y float64) (lng, lat float64)
+	wkt(name string, d datum) string
+}
+
+var WGS84 = &CRS{code: 4326, name: "WGS 84", datum: datumWGS84}
+
+// EPSG returns a CRS of the EPSG code.
+func EPSG(code int) (*CRS, error) {
+	c := registry(code)
+	if c == nil {
+		return nil, ErrUnsupportedCRS
+	}
+	return c, nil
+}
+
+var (
+	epsgCodeRe = regexp.MustCompile(`(?i)^(?:EPSG:{1,2}|urn:ogc:def:crs:EPSG:[0-9.]*:|https?://www\.opengis\.net/def/crs/EPSG/[0-9.]+/)?([0-9]+)$`)
+	crs84Re    = regexp.MustCompile(`(?i)^(?:urn:ogc:def:crs:OGC:[0-9.]*:|https?://www\.opengis\.net/def/crs/OGC/[0-9.]+/)?CRS84$`)
+)
+
+// Parse parses a CRS from an EPSG code such as "EPSG:6677" or "6677", an OGC URN or URL such as
+// "urn:ogc:def:crs:EPSG::6677", or a WKT string such as the content of a .prj file.
+func Parse(s string) (*CRS, error) {
+	s = strings.TrimSpace(s)
+	if crs84Re.MatchString(s) {
+		return WGS84, nil
+	}
+	if m := epsgCodeRe.FindStringSubmatch(s); m != nil {
+		code, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, ErrUnsupportedCRS
+		}
+		return EPSG(code)
+	}
+	if strings.Contains(s, "[") {
+		return parseWKT(s)
+	}
+	return nil, ErrUnsupportedCRS
+}
+
+// Code returns the EPSG code of the CRS. It returns 0 if the CRS is not registered in EPSG.
+func (c *CRS) Code() int {
+	if c == nil {
+		return 0
+	}
+	return c.code
+}
+
+func (c *CRS) Name() string {
+	if c == nil {
+		return ""
+	}
+	return c.name
+}
+
+func (c *CRS) String() string {
+	if c == nil {
+		return ""
+	}
+	if c.code == 0 {
+		return c.name
+	}
+	return fmt.Sprintf("EPSG:%d", c.code)
+}
+
+// URN returns the OGC URN of the CRS such as "urn:ogc:def:crs:EPSG::6677".
+func (c *CRS) URN() string {
+	if c == nil || c.code == 0 {
+		return ""
+	}
+	return fmt.Sprintf("urn:ogc:def:crs:EPSG::%d", c.code)
+}
+
+// IsGeographic reports whether coordinates of the CRS are longitude and latitude.
+func (c *CRS) IsGeographic() bool {
+	return c == nil || c.proj == nil
+}
+
+// IsWGS84 reports whether coordinates of the CRS do not need to be transformed from or into WGS84.
+// A nil CRS is treated as WGS84.
+func (c *CRS) IsWGS84() bool {
+	return c.IsGeographic()
+}
+
+// ToWGS84 transforms the coordinates in the CRS into longitude and latitude in WGS84.
+func (c *CRS) ToWGS84(x, y float64) (lng, lat float64) {
+	if c.IsGeographic() {
+		return x, y
+	}
+	return c.proj.inverse(x, y)
+}
+
+// FromWGS84 transforms longitude and latitude in WGS84 into coordinates in the CRS.
+func (c *CRS) FromWGS84(lng, lat float64) (x, y float64) {
+	if c.IsGeographic() {
+		return lng, lat
+	}
+	return c.proj.forward(lng, lat)
+}
+
+// Transform returns a function which transforms coordinates in the CRS from into the CRS to.
+func Transform(from, to *CRS) func(x, y float64) (float64, float64) {
+	return func(x, y float64) (float64, float64) {
+		return to.FromWGS84(from.ToWGS84(x, y))
+	}
+}
+
+// WKT returns the ESRI flavored WKT of the CRS, which is used in .prj files of shapefiles.
+func (c *CRS) WKT() string {
+	if c == nil {
+		return WGS84.WKT()
+	}
+	name := strings.NewReplacer(" / ", "_", " ", "_").Replace(c.name)
+	if c.proj == nil {
+		return c.datum.geogcs()
+	}
+	return c.proj.wkt(name, c.datum)
+}
@@ -0,0 +1,188 @@
+package crs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		code  int
+		err   error
+	}{
+		{input: "EPSG:4326", code: 4326},
+		{input: "epsg:6677", code: 6677},
+		{input: "6677", code: 6677},
+		{input: "urn:ogc:def:crs:EPSG::3857", code: 3857},
+		{input: "urn:ogc:def:crs:EPSG:6.6:32654", code: 32654},
+		{input: "http://www.opengis.net/def/crs/EPSG/0/2451", code: 2451},
+		{input: "urn:ogc:def:crs:OGC:1.3:CRS84", code: 4326},
+		{input: "EPSG:900913", code: 3857},
+		{input: "EPSG:30169", err: ErrUnsupportedCRS},
+		{input: "EPSG:", err: ErrUnsupportedCRS},
+		{input: "foo", err: ErrUnsupportedCRS},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := Parse(tc.input)
+			if tc.err != nil {
+				assert.Same(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.code, got.Code())
+		})
+	}
+}
+
+func TestCRS(t *testing.T) {
+	c, _ := EPSG(6677)
+	assert.Equal(t, "EPSG:6677", c.String())
+	assert.Equal(t, "urn:ogc:def:crs:EPSG::6677", c.URN())
+	assert.Equal(t, "JGD2011 / Japan Plane Rectangular CS IX", c.Name())
+	assert.False(t, c.IsGeographic())
+	assert.False(t, c.IsWGS84())
+
+	c, _ = EPSG(6668)
+	assert.True(t, c.IsWGS84())
+	assert.True(t, (*CRS)(nil).IsWGS84())
+
+	c, _ = EPSG(32754)
+	assert.Equal(t, "WGS 84 / UTM zone 54S", c.Name())
+}
+
+func TestCRS_Transform(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     int
+		lng, lat float64
+		x, y     float64
+		delta    float64
+	}{
+		{
+			// the origin of the Japan plane rectangular CS IX
+			name: "JGD2011 plane rectangular CS IX origin",
+			code: 6677,
+			lng:  139 + 50.0/60, lat: 36,
+			x: 0, y: 0,
+			delta: 1e-6,
+		},
+		{
+			name: "JGD2000 plane rectangular CS I origin",
+			code: 2443,
+			lng:  129.5, lat: 33,
+			x: 0, y: 0,
+			delta: 1e-6,
+		},
+		{
+			// EPSG Guidance Note 7-2, Popular Visualisation Pseudo Mercator
+			name: "Web Mercator",
+			code: 3857,
+			lng:  -(100 + 20.0/60), lat: 24 + 22.0/60 + 54.433/3600,
+			x: -11169055.58, y: 2800000.00,
+			delta: 0.01,
+		},
+		{
+			name: "UTM on the central meridian and the equator",
+			code: 32654,
+			lng:  141, lat: 0,
+			x: 500000, y: 0,
+			delta: 1e-6,
+		},
+		{
+			name: "UTM south",
+			code: 32754,
+			lng:  141, lat: 0,
+			x: 500000, y: 10000000,
+			delta: 1e-6,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			c, err := EPSG(tc.code)
+			assert.NoError(t, err)
+			x, y := c.FromWGS84(tc.lng, tc.lat)
+			assert.InDelta(t, tc.x, x, tc.delta)
+			assert.InDelta(t, tc.y, y, tc.delta)
+			lng, lat := c.ToWGS84(tc.x, tc.y)
+			assert.InDelta(t, tc.lng, lng, 1e-7)
+			assert.InDelta(t, tc.lat, lat, 1e-7)
+		})
+	}
+}
+
+func TestCRS_RoundTrip(t *testing.T) {
+	points := [][2]float64{
+		{139.767125, 35.681236}, // Tokyo station
+		{135.495951, 34.702485}, // Osaka station
+		{141.350755, 43.068661}, // Sapporo station
+		{127.681, 26.212},       // Naha
+	}
+	for _, code := range []int{6669, 6677, 6680, 6683, 2451, 3100, 6691, 32653, 32654, 3857} {
+		c, err := EPSG(code)
+		assert.NoError(t, err)
+		for _, p := range points {
+			x, y := c.FromWGS84(p[0], p[1])
+			lng, lat := c.ToWGS84(x, y)
+			assert.InDelta(t, p[0], lng, 1e-9, "EPSG:%d", code)
+			assert.InDelta(t, p[1], lat, 1e-9, "EPSG:%d", code)
+		}
+	}
+}
+
+func TestTransverseMercator(t *testing.T) {
+	// EPSG Guidance Note 7-2, OSGB 1936 / British National Grid
+	airy := datum{a: 6377563.396, invFlattening: 299.3249646}
+	p := newTransverseMercator(airy, 49, -2, 0.9996012717, 400000, -100000)
+	x, y := p.forward(0.5, 50.5)
+	assert.InDelta(t, 577274.99, x, 0.01)
+	assert.InDelta(t, 69740.50, y, 0.01)
+	lng, lat := p.inverse(577274.99, 69740.50)
+	assert.InDelta(t, 0.5, lng, 1e-7)
+	assert.InDelta(t, 50.5, lat, 1e-7)
+
+	// the scale factor on the central meridian is k0
+	c, _ := EPSG(6677)
+	_, y1 := c.FromWGS84(139+50.0/60, 36)
+	_, y2 := c.FromWGS84(139+50.0/60, 36.0001)
+	assert.InDelta(t, 0.9999, (y2-y1)/meridianArc(36, 36.0001), 1e-6)
+}
+
+// meridianArc computes the length of the meridian arc between the latitudes on GRS80 numerically.
+func meridianArc(lat1, lat2 float64) float64 {
+	a, f := 6378137.0, 1/298.257222101
+	e2 := f * (2 - f)
+	const steps = 1000
+	var sum float64
+	h := (lat2 - lat1) / steps * math.Pi / 180
+	for i := 0; i < steps; i++ {
+		phi := lat1*math.Pi/180 + (float64(i)+0.5)*h
+		s := math.Sin(phi)
+		sum += a * (1 - e2) / math.Pow(1-e2*s*s, 1.5) * h
+	}
+	return sum
+}
+
+func TestCRS_WKT(t *testing.T) {
+	for _, code := range []int{4326, 6668, 6677, 2451, 32654, 6691, 3857} {
+		c, _ := EPSG(code)
+		got, err := Parse(c.WKT())
+		assert.NoError(t, err, "EPSG:%d", code)
+		assert.Equal(t, code, got.Code(), "EPSG:%d", code)
+	}
+
+	c, _ := EPSG(6677)
+	assert.Equal(t,
+		`PROJCS["JGD2011_Japan_Plane_Rectangular_CS_IX",GEOGCS["GCS_JGD_2011",DATUM["D_JGD_2011",SPHEROID["GRS_1980",6378137.0,298.257222101]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Transverse_Mercator"],PARAMETER["False_Easting",0.0],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",139.833333333333],PARAMETER["Scale_Factor",0.9999],PARAMETER["Latitude_Of_Origin",36.0],UNIT["Meter",1.0]]`,
+		c.WKT(),
+	)
+}
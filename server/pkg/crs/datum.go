@@ -0,0 +1,74 @@
+package crs
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// datum is a geodetic datum which is treated as identical to WGS84 except for the ellipsoid.
+type datum struct {
+	name          string
+	esriName      string
+	ellipsoid     string
+	a             float64
+	invFlattening float64
+}
+
+var (
+	datumWGS84   = datum{name: "WGS_1984", esriName: "GCS_WGS_1984", ellipsoid: "WGS_1984", a: 6378137, invFlattening: 298.257223563}
+	datumJGD2000 = datum{name: "JGD_2000", esriName: "GCS_JGD_2000", ellipsoid: "GRS_1980", a: 6378137, invFlattening: 298.257222101}
+	datumJGD2011 = datum{name: "JGD_2011", esriName: "GCS_JGD_2011", ellipsoid: "GRS_1980", a: 6378137, invFlattening: 298.257222101}
+)
+
+func (d datum) flattening() float64 {
+	return 1 / d.invFlattening
+}
+
+func (d datum) geogcs() string {
+	return fmt.Sprintf(
+		`GEOGCS["%s",DATUM["D_%s",SPHEROID["%s",%s,%s]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]]`,
+		d.esriName, d.name, d.ellipsoid, formatFloat(d.a), formatFloat(d.invFlattening),
+	)
+}
+
+// datumFrom returns a known datum from the name of the datum and the ellipsoid in WKT. Datums which need
+// datum transformations to WGS84, such as the Tokyo datum, are not supported.
+func datumFrom(name string, a, invFlattening float64) (datum, bool) {
+	n := normalizeName(name)
+	var d datum
+	switch {
+	case strings.Contains(n, "tokyo"):
+		return datum{}, false
+	case strings.Contains(n, "jgd2011") || strings.Contains(n, "japanesegeodeticdatum2011"):
+		d = datumJGD2011
+	case strings.Contains(n, "jgd2000") || strings.Contains(n, "japanesegeodeticdatum2000"):
+		d = datumJGD2000
+	default:
+		d = datumWGS84
+	}
+	// ellipsoids other than WGS84 and GRS80, which are almost identical, imply datums which need datum transformations
+	if a != 0 && (math.Abs(a-datumWGS84.a) > 1e-3 || math.Abs(invFlattening-datumWGS84.invFlattening) > 1e-3) {
+		return datum{}, false
+	}
+	return d, true
+}
+
+// normalizeName converts a name in WKT into lower case letters and digits only to compare names.
+func normalizeName(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func formatFloat(f float64) string {
+	s := fmt.Sprintf("%.15g", f)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
@@ -0,0 +1,120 @@
+package crs
+
+import "fmt"
+
+// origins of the Japanese plane rectangular coordinate systems I to XIX in degrees
+var japanPlaneRectangularOrigins = [19][2]float64{
+	{33, 129 + 30.0/60},
+	{33, 131},
+	{36, 132 + 10.0/60},
+	{33, 133 + 30.0/60},
+	{36, 134 + 20.0/60},
+	{36, 136},
+	{36, 137 + 10.0/60},
+	{36, 138 + 30.0/60},
+	{36, 139 + 50.0/60},
+	{40, 140 + 50.0/60},
+	{44, 140 + 15.0/60},
+	{44, 142 + 15.0/60},
+	{44, 144 + 15.0/60},
+	{26, 142},
+	{26, 127 + 30.0/60},
+	{26, 124},
+	{26, 131},
+	{20, 136},
+	{26, 154},
+}
+
+var romanNumerals = [19]string{
+	"I", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX", "X",
+	"XI", "XII", "XIII", "XIV", "XV", "XVI", "XVII", "XVIII", "XIX",
+}
+
+// registry returns the CRS of the EPSG code. Supported CRSs are:
+//   - WGS84 (4326), JGD2000 (4612) and JGD2011 (6668) geographic CRSs
+//   - Web Mercator (3857 and its aliases 900913, 3785 and 102100)
+//   - WGS84 UTM zones (32601-32660 and 32701-32760)
+//   - JGD2000 UTM zones 51-55 (3097-3101) and JGD2011 UTM zones 51-55 (6688-6692)
+//   - JGD2000 (2443-2461) and JGD2011 (6669-6687) Japan plane rectangular CS I-XIX
+func registry(code int) *CRS {
+	switch {
+	case code == 4326:
+		return WGS84
+	case code == 4612:
+		return &CRS{code: code, name: "JGD2000", datum: datumJGD2000}
+	case code == 6668:
+		return &CRS{code: code, name: "JGD2011", datum: datumJGD2011}
+	case code == 3857 || code == 900913 || code == 3785 || code == 102100:
+		return &CRS{code: 3857, name: "WGS 84 / Pseudo-Mercator", datum: datumWGS84, proj: webMercator{}}
+	case code >= 32601 && code <= 32660:
+		return utm(code, "WGS 84", datumWGS84, code-32600, true)
+	case code >= 32701 && code <= 32760:
+		return utm(code, "WGS 84", datumWGS84, code-32700, false)
+	case code >= 3097 && code <= 3101:
+		return utm(code, "JGD2000", datumJGD2000, code-3097+51, true)
+	case code >= 6688 && code <= 6692:
+		return utm(code, "JGD2011", datumJGD2011, code-6688+51, true)
+	case code >= 2443 && code <= 2461:
+		return japanPlaneRectangular(code, "JGD2000", datumJGD2000, code-2443)
+	case code >= 6669 && code <= 6687:
+		return japanPlaneRectangular(code, "JGD2011", datumJGD2011, code-6669)
+	}
+	return nil
+}
+
+func utm(code int, datumName string, d datum, zone int, north bool) *CRS {
+	hemisphere, falseNorthing := "N", 0.0
+	if !north {
+		hemisphere, falseNorthing = "S", 10000000
+	}
+	return &CRS{
+		code:  code,
+		name:  fmt.Sprintf("%s / UTM zone %d%s", datumName, zone, hemisphere),
+		datum: d,
+		proj:  newTransverseMercator(d, 0, float64(zone*6-183), 0.9996, 500000, falseNorthing),
+	}
+}
+
+func japanPlaneRectangular(code int, datumName string, d datum, zone int) *CRS {
+	o := japanPlaneRectangularOrigins[zone]
+	return &CRS{
+		code:  code,
+		name:  fmt.Sprintf("%s / Japan Plane Rectangular CS %s", datumName, romanNumerals[zone]),
+		datum: d,
+		proj:  newTransverseMercator(d, o[0], o[1], 0.9999, 0, 0),
+	}
+}
+
+// registered returns the registered CRS which has the same definition as the CRS.
+func registered(c *CRS) *CRS {
+	tm, ok := c.proj.(*transverseMercator)
+	if !ok {
+		return nil
+	}
+	candidates := make([]int, 0, 180)
+	for code := 32601; code <= 32660; code++ {
+		candidates = append(candidates, code, code+100)
+	}
+	for code := 3097; code <= 3101; code++ {
+		candidates = append(candidates, code, code-3097+6688)
+	}
+	for code := 2443; code <= 2461; code++ {
+		candidates = append(candidates, code, code-2443+6669)
+	}
+	for _, code := range candidates {
+		r := registry(code)
+		if r.datum.name != c.datum.name {
+			continue
+		}
+		if rtm := r.proj.(*transverseMercator); equalFloat(rtm.lat0, tm.lat0) && equalFloat(rtm.lng0, tm.lng0) &&
+			equalFloat(rtm.k0, tm.k0) && equalFloat(rtm.falseEasting, tm.falseEasting) && equalFloat(rtm.falseNorthing, tm.falseNorthing) {
+			return r
+		}
+	}
+	return nil
+}
+
+func equalFloat(a, b float64) bool {
+	d := a - b
+	return d < 1e-6 && d > -1e-6
+}
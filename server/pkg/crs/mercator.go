@@ -0,0 +1,26 @@
+package crs
+
+import "math"
+
+// webMercator is the spherical Mercator projection used by web maps (EPSG:3857).
+type webMercator struct{}
+
+const webMercatorRadius = 6378137.0
+
+func (webMercator) forward(lng, lat float64) (float64, float64) {
+	// latitudes are clamped to avoid infinity at the poles
+	lat = math.Max(-89.999999, math.Min(89.999999, lat))
+	x := webMercatorRadius * lng * math.Pi / 180
+	y := webMercatorRadius * math.Log(math.Tan(math.Pi/4+lat*math.Pi/360))
+	return x, y
+}
+
+func (webMercator) inverse(x, y float64) (float64, float64) {
+	lng := x / webMercatorRadius * 180 / math.Pi
+	lat := (math.Pi/2 - 2*math.Atan(math.Exp(-y/webMercatorRadius))) * 180 / math.Pi
+	return lng, lat
+}
+
+func (webMercator) wkt(name string, d datum) string {
+	return `PROJCS["WGS_1984_Web_Mercator_Auxiliary_Sphere",` + d.geogcs() + `,PROJECTION["Mercator_Auxiliary_Sphere"],PARAMETER["False_Easting",0.0],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",0.0],PARAMETER["Standard_Parallel_1",0.0],PARAMETER["Auxiliary_Sphere_Type",0.0],UNIT["Meter",1.0]]`
+}
@@ -0,0 +1,121 @@
+package crs
+
+import (
+	"fmt"
+	"math"
+)
+
+// transverseMercator is the transverse Mercator projection computed with the Krüger series to the sixth order
+// of the third flattening, which is accurate to a few millimeters within thousands of kilometers from the
+// central meridian. It is the same method as the Geospatial Information Authority of Japan uses.
+type transverseMercator struct {
+	lat0, lng0       float64 // degrees
+	k0               float64
+	falseEasting     float64
+	falseNorthing    float64
+	e                float64 // first eccentricity
+	rectifyingRadius float64 // A in Krüger series multiplied by k0
+	alpha, beta      [6]float64
+	northing0        float64 // rectifying distance to the latitude of origin
+}
+
+func newTransverseMercator(d datum, lat0, lng0, k0, falseEasting, falseNorthing float64) *transverseMercator {
+	f := d.flattening()
+	n := f / (2 - f)
+	n2, n3 := n*n, n*n*n
+	n4, n5, n6 := n3*n, n3*n2, n3*n3
+
+	p := &transverseMercator{
+		lat0:             lat0,
+		lng0:             lng0,
+		k0:               k0,
+		falseEasting:     falseEasting,
+		falseNorthing:    falseNorthing,
+		e:                math.Sqrt(f * (2 - f)),
+		rectifyingRadius: k0 * d.a / (1 + n) * (1 + n2/4 + n4/64 + n6/256),
+		alpha: [6]float64{
+			n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180 - 127*n5/288 + 7891*n6/37800,
+			13*n2/48 - 3*n3/5 + 557*n4/1440 + 281*n5/630 - 1983433*n6/1935360,
+			61*n3/240 - 103*n4/140 + 15061*n5/26880 + 167603*n6/181440,
+			49561*n4/161280 - 179*n5/168 + 6601661*n6/7257600,
+			34729*n5/80640 - 3418889*n6/1995840,
+			212378941 * n6 / 319334400,
+		},
+		beta: [6]float64{
+			n/2 - 2*n2/3 + 37*n3/96 - n4/360 - 81*n5/512 + 96199*n6/604800,
+			n2/48 + n3/15 - 437*n4/1440 + 46*n5/105 - 1118711*n6/3870720,
+			17*n3/480 - 37*n4/840 - 209*n5/4480 + 5569*n6/90720,
+			4397*n4/161280 - 11*n5/504 - 830251*n6/7257600,
+			4583*n5/161280 - 108847*n6/3991680,
+			20648693 * n6 / 638668800,
+		},
+	}
+	_, p.northing0 = p.project(0, lat0)
+	return p
+}
+
+// project returns the easting and northing from the central meridian and the equator.
+func (p *transverseMercator) project(dlng, lat float64) (float64, float64) {
+	phi := lat * math.Pi / 180
+	lambda := dlng * math.Pi / 180
+
+	sinPhi := math.Sin(phi)
+	t := math.Sinh(math.Atanh(sinPhi) - p.e*math.Atanh(p.e*sinPhi))
+	xi1 := math.Atan2(t, math.Cos(lambda))
+	eta1 := math.Atanh(math.Sin(lambda) / math.Sqrt(1+t*t))
+
+	xi, eta := xi1, eta1
+	for j, a := range p.alpha {
+		k := 2 * float64(j+1)
+		xi += a * math.Sin(k*xi1) * math.Cosh(k*eta1)
+		eta += a * math.Cos(k*xi1) * math.Sinh(k*eta1)
+	}
+	return p.rectifyingRadius * eta, p.rectifyingRadius * xi
+}
+
+func (p *transverseMercator) forward(lng, lat float64) (float64, float64) {
+	x, y := p.project(lng-p.lng0, lat)
+	return x + p.falseEasting, y - p.northing0 + p.falseNorthing
+}
+
+func (p *transverseMercator) inverse(x, y float64) (float64, float64) {
+	xi := (y - p.falseNorthing + p.northing0) / p.rectifyingRadius
+	eta := (x - p.falseEasting) / p.rectifyingRadius
+
+	xi1, eta1 := xi, eta
+	for j, b := range p.beta {
+		k := 2 * float64(j+1)
+		xi1 -= b * math.Sin(k*xi) * math.Cosh(k*eta)
+		eta1 -= b * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+
+	// conformal latitude and longitude
+	chi := math.Asin(math.Sin(xi1) / math.Cosh(eta1))
+	lambda := math.Atan2(math.Sinh(eta1), math.Cos(xi1))
+
+	return p.lng0 + lambda*180/math.Pi, p.latitude(chi) * 180 / math.Pi
+}
+
+// latitude converts the conformal latitude into the geodetic latitude with the Newton's method.
+func (p *transverseMercator) latitude(chi float64) float64 {
+	e2 := p.e * p.e
+	tau1 := math.Tan(chi)
+	tau := tau1
+	for i := 0; i < 10; i++ {
+		sigma := math.Sinh(p.e * math.Atanh(p.e*tau/math.Sqrt(1+tau*tau)))
+		taui := tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+		d := (tau1 - taui) / math.Sqrt(1+taui*taui) * (1 + (1-e2)*tau*tau) / ((1 - e2) * math.Sqrt(1+tau*tau))
+		tau += d
+		if math.Abs(d) < 1e-14 {
+			break
+		}
+	}
+	return math.Atan(tau)
+}
+
+func (p *transverseMercator) wkt(name string, d datum) string {
+	return fmt.Sprintf(
+		`PROJCS["%s",%s,PROJECTION["Transverse_Mercator"],PARAMETER["False_Easting",%s],PARAMETER["False_Northing",%s],PARAMETER["Central_Meridian",%s],PARAMETER["Scale_Factor",%s],PARAMETER["Latitude_Of_Origin",%s],UNIT["Meter",1.0]]`,
+		name, d.geogcs(), formatFloat(p.falseEasting), formatFloat(p.falseNorthing), formatFloat(p.lng0), formatFloat(p.k0), formatFloat(p.lat0),
+	)
+}
@@ -0,0 +1,223 @@
+package crs
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// wktNode is a node of WKT such as PROJCS["name",GEOGCS[...],PARAMETER["name",0.0]].
+type wktNode struct {
+	keyword  string
+	values   []string // strings and numbers
+	children []*wktNode
+}
+
+func (n *wktNode) child(keywords ...string) *wktNode {
+	for _, c := range n.children {
+		for _, k := range keywords {
+			if strings.EqualFold(c.keyword, k) {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+func (n *wktNode) value(i int) string {
+	if n == nil || i >= len(n.values) {
+		return ""
+	}
+	return n.values[i]
+}
+
+func (n *wktNode) number(i int) float64 {
+	f, _ := strconv.ParseFloat(n.value(i), 64)
+	return f
+}
+
+// epsgCode returns the EPSG code in AUTHORITY["EPSG","6677"] (WKT1) or ID["EPSG",6677] (WKT2).
+func (n *wktNode) epsgCode() int {
+	a := n.child("AUTHORITY", "ID")
+	if a == nil || !strings.EqualFold(a.value(0), "EPSG") {
+		return 0
+	}
+	code, _ := strconv.Atoi(a.value(1))
+	return code
+}
+
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *wktParser) parseNode() (*wktNode, bool) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && (unicode.IsLetter(rune(p.s[p.pos])) || unicode.IsDigit(rune(p.s[p.pos])) || p.s[p.pos] == '_') {
+		p.pos++
+	}
+	n := &wktNode{keyword: p.s[start:p.pos]}
+	p.skipSpaces()
+	if n.keyword == "" || p.pos >= len(p.s) || (p.s[p.pos] != '[' && p.s[p.pos] != '(') {
+		return nil, false
+	}
+	p.pos++
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.s) {
+			return nil, false
+		}
+		switch c := p.s[p.pos]; {
+		case c == ']' || c == ')':
+			p.pos++
+			return n, true
+		case c == ',':
+			p.pos++
+		case c == '"':
+			end := strings.IndexByte(p.s[p.pos+1:], '"')
+			if end < 0 {
+				return nil, false
+			}
+			n.values = append(n.values, p.s[p.pos+1:p.pos+1+end])
+			p.pos += end + 2
+		case c == '-' || c == '+' || c == '.' || unicode.IsDigit(rune(c)):
+			start := p.pos
+			for p.pos < len(p.s) && strings.IndexByte("+-.eE0123456789", p.s[p.pos]) >= 0 {
+				p.pos++
+			}
+			n.values = append(n.values, p.s[start:p.pos])
+		default:
+			start := p.pos
+			child, ok := p.parseNode()
+			if !ok {
+				// enums such as AXIS["Easting",EAST]
+				p.pos = start
+				for p.pos < len(p.s) && strings.IndexByte(",])", p.s[p.pos]) < 0 {
+					p.pos++
+				}
+				if start == p.pos {
+					return nil, false
+				}
+				n.values = append(n.values, strings.TrimSpace(p.s[start:p.pos]))
+				continue
+			}
+			n.children = append(n.children, child)
+		}
+	}
+}
+
+// parseWKT parses a WKT of a CRS. The EPSG code in the WKT is used if it is supported. Otherwise, geographic CRSs,
+// transverse Mercator and Web Mercator projections on WGS84 compatible datums are supported.
+func parseWKT(s string) (*CRS, error) {
+	p := &wktParser{s: s}
+	root, ok := p.parseNode()
+	if !ok {
+		return nil, ErrUnsupportedCRS
+	}
+	if c, err := EPSG(root.epsgCode()); err == nil {
+		return c, nil
+	}
+
+	switch strings.ToUpper(root.keyword) {
+	case "GEOGCS", "GEOGCRS", "GEODCRS", "GEOGRAPHICCRS":
+		d, ok := wktDatum(root)
+		if !ok {
+			return nil, ErrUnsupportedCRS
+		}
+		switch d.name {
+		case datumJGD2000.name:
+			return EPSG(4612)
+		case datumJGD2011.name:
+			return EPSG(6668)
+		}
+		return WGS84, nil
+	case "PROJCS", "PROJCRS", "PROJECTEDCRS":
+		return parseProjectedWKT(root)
+	}
+	return nil, ErrUnsupportedCRS
+}
+
+func wktDatum(n *wktNode) (datum, bool) {
+	if g := n.child("GEOGCS", "BASEGEOGCRS", "BASEGEODCRS"); g != nil {
+		n = g
+	}
+	dn := n.child("DATUM", "TRF")
+	if dn == nil {
+		return datum{}, false
+	}
+	sp := dn.child("SPHEROID", "ELLIPSOID")
+	return datumFrom(dn.value(0), sp.number(1), sp.number(2))
+}
+
+func parseProjectedWKT(root *wktNode) (*CRS, error) {
+	d, ok := wktDatum(root)
+	if !ok {
+		return nil, ErrUnsupportedCRS
+	}
+
+	// WKT1 has PROJECTION and PARAMETERs in the root, and WKT2 has METHOD and PARAMETERs in CONVERSION
+	method := root.child("PROJECTION")
+	params := root
+	if c := root.child("CONVERSION"); c != nil {
+		method = c.child("METHOD")
+		params = c
+	}
+	if method == nil {
+		return nil, ErrUnsupportedCRS
+	}
+
+	values := map[string]float64{}
+	for _, c := range params.children {
+		if strings.EqualFold(c.keyword, "PARAMETER") {
+			values[normalizeName(c.value(0))] = c.number(1)
+		}
+	}
+	param := func(names ...string) float64 {
+		for _, n := range names {
+			if v, ok := values[n]; ok {
+				return v
+			}
+		}
+		return 0
+	}
+
+	// linear units other than meters are not supported
+	if u := root.child("UNIT", "LENGTHUNIT"); u != nil && u.number(1) != 1 {
+		return nil, ErrUnsupportedCRS
+	}
+
+	switch normalizeName(method.value(0)) {
+	case "transversemercator", "gausskruger":
+		k0 := param("scalefactor", "scalefactoratnaturalorigin")
+		if k0 == 0 {
+			k0 = 1
+		}
+		c := &CRS{
+			name:  root.value(0),
+			datum: d,
+			proj: newTransverseMercator(
+				d,
+				param("latitudeoforigin", "latitudeofnaturalorigin"),
+				param("centralmeridian", "longitudeofnaturalorigin", "longitudeoforigin"),
+				k0,
+				param("falseeasting"),
+				param("falsenorthing"),
+			),
+		}
+		if r := registered(c); r != nil {
+			return r, nil
+		}
+		return c, nil
+	case "mercatorauxiliarysphere", "popularvisualisationpseudomercator":
+		return EPSG(3857)
+	}
+	return nil, ErrUnsupportedCRS
+}
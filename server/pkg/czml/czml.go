@@ -3,10 +3,14 @@ package czml
 type Feature struct {
 	Id       string    `json:"id"`
 	Name     string    `json:"name"`
+	Parent   string    `json:"parent,omitempty"`
+	Version  string    `json:"version,omitempty"`
 	Polygon  *Polygon  `json:"polygon,omitempty"`
 	Polyline *Polyline `json:"polyline,omitempty"`
 	Position *Position `json:"position,omitempty"`
 	Point    *Point    `json:"point,omitempty"`
+	// Properties are custom properties of the packet. Values may be constant or time-dynamic.
+	Properties map[string]any `json:"properties,omitempty"`
 }
 type Polyline struct {
 	Positions Position  `json:"positions"`
@@ -15,6 +19,7 @@ type Polyline struct {
 }
 type Polygon struct {
 	Positions   Position  `json:"positions"`
+	Holes       *Holes    `json:"holes,omitempty"`
 	Fill        bool      `json:"fill,omitempty"`
 	Material    *Material `json:"material,omitempty"`
 	Stroke      bool      `json:"outline,omitempty"`
@@ -28,6 +33,9 @@ type Point struct {
 type Position struct {
 	CartographicDegrees []float64 `json:"cartographicDegrees"`
 }
+type Holes struct {
+	CartographicDegrees [][]float64 `json:"cartographicDegrees"`
+}
 type Material struct {
 	SolidColor      *SolidColor      `json:"solidColor,omitempty"`
 	PolylineOutline *PolylineOutline `json:"polylineOutline,omitempty"`
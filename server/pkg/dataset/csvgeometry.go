@@ -0,0 +1,221 @@
+package dataset
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/crs"
+)
+
+var ErrInvalidGeometry error = errors.New("invalid geometry")
+
+// cellGeometry is a point, a line string or a polygon written in a cell as WKT or GeoJSON.
+type cellGeometry struct {
+	typ   string
+	rings []Coordinates
+	hasZ  bool
+}
+
+// parseCellGeometry parses a WKT such as "POINT (139.7 35.6)" or a GeoJSON geometry object.
+// Coordinates are transformed from the CRS into WGS84.
+func parseCellGeometry(s string, c *crs.CRS) (*cellGeometry, error) {
+	s = strings.TrimSpace(s)
+	var g *cellGeometry
+	var err error
+	if strings.HasPrefix(s, "{") {
+		g, err = parseGeoJSONGeometry(s)
+	} else {
+		g, err = parseWKTGeometry(s)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.IsWGS84() {
+		for _, r := range g.rings {
+			for i, p := range r {
+				r[i].Lng, r[i].Lat = c.ToWGS84(p.Lng, p.Lat)
+			}
+		}
+	}
+	return g, nil
+}
+
+// valueType returns the type of the field that stores the geometry.
+func (g *cellGeometry) valueType() ValueType {
+	switch g.typ {
+	case "Point":
+		if g.hasZ {
+			return ValueTypeLatLngHeight
+		}
+		return ValueTypeLatLng
+	case "LineString":
+		return ValueTypeCoordinates
+	case "Polygon":
+		return TypePolygon
+	}
+	return ValueTypeUnknown
+}
+
+// value converts the geometry into a value of the type. A point with height can be stored as a LatLng and vice versa.
+func (g *cellGeometry) value(t ValueType) (*Value, error) {
+	switch {
+	case t == ValueTypeLatLng && g.typ == "Point":
+		p := g.rings[0][0]
+		return t.ValueFrom(LatLng{Lat: p.Lat, Lng: p.Lng}), nil
+	case t == ValueTypeLatLngHeight && g.typ == "Point":
+		return t.ValueFrom(g.rings[0][0]), nil
+	case t == ValueTypeCoordinates && g.typ == "LineString":
+		return t.ValueFrom(g.rings[0]), nil
+	case t == TypePolygon && g.typ == "Polygon":
+		return t.ValueFrom(Polygon(g.rings)), nil
+	}
+	return nil, ErrInvalidGeometry
+}
+
+func parseWKTGeometry(s string) (*cellGeometry, error) {
+	// EWKT such as "SRID=4326;POINT (0 0)"
+	if i := strings.Index(s, ";"); i >= 0 && strings.HasPrefix(strings.ToUpper(s), "SRID=") {
+		s = s[i+1:]
+	}
+
+	open, end := strings.Index(s, "("), strings.LastIndex(s, ")")
+	if open < 0 || end < open {
+		return nil, ErrInvalidGeometry
+	}
+	tag := strings.Fields(strings.ToUpper(s[:open]))
+	if len(tag) == 0 || len(tag) > 2 || len(tag) == 2 && tag[1] != "Z" {
+		return nil, ErrInvalidGeometry
+	}
+	body := strings.TrimSpace(s[open+1 : end])
+
+	g := &cellGeometry{}
+	switch tag[0] {
+	case "POINT":
+		g.typ = "Point"
+		r, hasZ, err := parseWKTPositions(body)
+		if err != nil || len(r) != 1 {
+			return nil, ErrInvalidGeometry
+		}
+		g.rings = []Coordinates{r}
+		g.hasZ = hasZ
+	case "LINESTRING":
+		g.typ = "LineString"
+		r, hasZ, err := parseWKTPositions(body)
+		if err != nil || len(r) < 2 {
+			return nil, ErrInvalidGeometry
+		}
+		g.rings = []Coordinates{r}
+		g.hasZ = hasZ
+	case "POLYGON":
+		g.typ = "Polygon"
+		for _, ring := range strings.Split(body, "),") {
+			ring = strings.TrimSpace(ring)
+			ring = strings.TrimSuffix(strings.TrimPrefix(ring, "("), ")")
+			r, hasZ, err := parseWKTPositions(ring)
+			if err != nil || len(r) < 3 {
+				return nil, ErrInvalidGeometry
+			}
+			g.rings = append(g.rings, r)
+			g.hasZ = g.hasZ || hasZ
+		}
+	default:
+		return nil, ErrInvalidGeometry
+	}
+
+	g.hasZ = g.hasZ || len(tag) == 2
+	return g, nil
+}
+
+func parseWKTPositions(s string) (Coordinates, bool, error) {
+	var res Coordinates
+	hasZ := false
+	for _, p := range strings.Split(s, ",") {
+		f := strings.Fields(p)
+		if len(f) < 2 || len(f) > 3 {
+			return nil, false, ErrInvalidGeometry
+		}
+		n := make([]float64, len(f))
+		for i, v := range f {
+			x, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, false, ErrInvalidGeometry
+			}
+			n[i] = x
+		}
+		res = append(res, position(n))
+		hasZ = hasZ || len(f) > 2
+	}
+	return res, hasZ, nil
+}
+
+func parseGeoJSONGeometry(s string) (*cellGeometry, error) {
+	var obj struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal([]byte(s), &obj); err != nil {
+		return nil, ErrInvalidGeometry
+	}
+
+	g := &cellGeometry{typ: obj.Type}
+	switch obj.Type {
+	case "Point":
+		var c []float64
+		if err := json.Unmarshal(obj.Coordinates, &c); err != nil || len(c) < 2 {
+			return nil, ErrInvalidGeometry
+		}
+		g.rings = []Coordinates{{position(c)}}
+		g.hasZ = len(c) > 2
+	case "LineString":
+		var c [][]float64
+		if err := json.Unmarshal(obj.Coordinates, &c); err != nil || len(c) < 2 {
+			return nil, ErrInvalidGeometry
+		}
+		r, hasZ, ok := positions(c)
+		if !ok {
+			return nil, ErrInvalidGeometry
+		}
+		g.rings = []Coordinates{r}
+		g.hasZ = hasZ
+	case "Polygon":
+		var c [][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &c); err != nil || len(c) == 0 {
+			return nil, ErrInvalidGeometry
+		}
+		for _, ring := range c {
+			r, hasZ, ok := positions(ring)
+			if !ok || len(r) < 3 {
+				return nil, ErrInvalidGeometry
+			}
+			g.rings = append(g.rings, r)
+			g.hasZ = g.hasZ || hasZ
+		}
+	default:
+		return nil, ErrInvalidGeometry
+	}
+	return g, nil
+}
+
+func positions(c [][]float64) (Coordinates, bool, bool) {
+	res := make(Coordinates, 0, len(c))
+	hasZ := false
+	for _, p := range c {
+		if len(p) < 2 {
+			return nil, false, false
+		}
+		res = append(res, position(p))
+		hasZ = hasZ || len(p) > 2
+	}
+	return res, hasZ, true
+}
+
+func position(p []float64) LatLngHeight {
+	l := LatLngHeight{Lng: p[0], Lat: p[1]}
+	if len(p) > 2 {
+		l.Height = p[2]
+	}
+	return l
+}
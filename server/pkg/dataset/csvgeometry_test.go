@@ -0,0 +1,110 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCellGeometry(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		crs       *crs.CRS
+		wantType  ValueType
+		wantValue any
+		wantErr   error
+	}{
+		{
+			name:      "wkt point",
+			input:     "POINT (15 12)",
+			wantType:  ValueTypeLatLng,
+			wantValue: LatLng{Lat: 12, Lng: 15},
+		},
+		{
+			name:      "ewkt point z",
+			input:     "SRID=4326;POINT Z (15 12 3)",
+			wantType:  ValueTypeLatLngHeight,
+			wantValue: LatLngHeight{Lat: 12, Lng: 15, Height: 3},
+		},
+		{
+			name:      "wkt linestring",
+			input:     "linestring (15 12, 16 13)",
+			wantType:  ValueTypeCoordinates,
+			wantValue: Coordinates{{Lat: 12, Lng: 15}, {Lat: 13, Lng: 16}},
+		},
+		{
+			name:     "wkt polygon",
+			input:    "POLYGON ((0 0, 1 0, 1 1, 0 0), (0.2 0.2, 0.4 0.2, 0.4 0.4, 0.2 0.2))",
+			wantType: TypePolygon,
+			wantValue: Polygon{
+				{{Lng: 0, Lat: 0}, {Lng: 1, Lat: 0}, {Lng: 1, Lat: 1}, {Lng: 0, Lat: 0}},
+				{{Lng: 0.2, Lat: 0.2}, {Lng: 0.4, Lat: 0.2}, {Lng: 0.4, Lat: 0.4}, {Lng: 0.2, Lat: 0.2}},
+			},
+		},
+		{
+			name:      "geojson linestring",
+			input:     `{"type":"LineString","coordinates":[[15,12],[16,13,1]]}`,
+			wantType:  ValueTypeCoordinates,
+			wantValue: Coordinates{{Lat: 12, Lng: 15}, {Lat: 13, Lng: 16, Height: 1}},
+		},
+		{
+			name:     "crs",
+			input:    "POINT (0 0)",
+			crs:      lo.Must(crs.EPSG(6677)),
+			wantType: ValueTypeLatLng,
+			// the origin of JGD2011 / Japan Plane Rectangular CS IX
+			wantValue: LatLng{Lat: 36, Lng: 139 + 50.0/60},
+		},
+		{
+			name:    "multipoint",
+			input:   "MULTIPOINT ((0 0))",
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "invalid wkt",
+			input:   "POINT (a b)",
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "invalid geojson",
+			input:   `{"type":"Point","coordinates":[1]}`,
+			wantErr: ErrInvalidGeometry,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := parseCellGeometry(tt.input, tt.crs)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantType, g.valueType())
+
+			v, err := g.value(tt.wantType)
+			assert.NoError(t, err)
+			if l, ok := tt.wantValue.(LatLng); ok && tt.crs != nil {
+				got := v.ValueLatLng()
+				assert.InDelta(t, l.Lat, got.Lat, 1e-9)
+				assert.InDelta(t, l.Lng, got.Lng, 1e-9)
+				return
+			}
+			assert.Equal(t, tt.wantValue, v.Interface())
+		})
+	}
+}
+
+func TestCellGeometry_Value(t *testing.T) {
+	g := lo.Must(parseCellGeometry("POINT Z (15 12 3)", nil))
+	assert.Equal(t, LatLng{Lat: 12, Lng: 15}, lo.Must(g.value(ValueTypeLatLng)).Interface())
+
+	_, err := g.value(ValueTypeCoordinates)
+	assert.ErrorIs(t, err, ErrInvalidGeometry)
+}
@@ -3,23 +3,122 @@ package dataset
 import (
 	"encoding/csv"
 	"errors"
+	"fmt"
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/samber/lo"
 )
 
 var (
 	ErrFailedToParseCSVorTSVFile error = errors.New("failed to parse file content")
 	ErrIncompatibleSchema        error = errors.New("schema is not compatible with csv")
 	ErrDuplicatiedNameFields     error = errors.New("failed to parse, name-duplicated fields")
+	ErrCSVColumnNotFound         error = errors.New("column is not found")
+	ErrUnsupportedCSVType        error = errors.New("unsupported column type")
+	ErrInvalidCSVValue           error = errors.New("invalid value")
+)
+
+// csvSampleSize is the number of rows that are read to guess types of columns.
+const csvSampleSize = 100
+
+// maxCSVPreviewErrors is the maximum number of row errors that a preview reports.
+const maxCSVPreviewErrors = 100
+
+var (
+	latColumns      = []string{"lat", "latitude", "y"}
+	lngColumns      = []string{"lng", "lon", "long", "longitude", "x"}
+	heightColumns   = []string{"height", "alt", "altitude", "z"}
+	geometryColumns = []string{"geometry", "geom", "the_geom", "wkt", "geojson"}
 )
 
+// CSVOptions configures how columns of a CSV or TSV file are mapped into fields.
+type CSVOptions struct {
+	// LatColumn, LngColumn and HeightColumn are the columns that make the "location" field.
+	// They are detected from the headers such as "lat", "latitude", "y", "lng", "lon", "x" and "height" if empty.
+	LatColumn    string
+	LngColumn    string
+	HeightColumn string
+	// GeometryColumn is the column of WKT or GeoJSON geometries of points, line strings or polygons.
+	// It is detected from the headers such as "geometry" and "wkt" if empty.
+	GeometryColumn string
+	// Types overrides the guessed types of the columns. Only bool, number, string and url are supported.
+	Types map[string]ValueType
+	// IDColumn is the column that identifies rows when the data is synced again. It is "id" if empty.
+	IDColumn string
+}
+
+// CSVRowError is an error of a row or a cell in a CSV file.
+type CSVRowError struct {
+	// Row is the 1-based number of the row, not counting the header.
+	Row    int
+	Column string
+	Err    error
+}
+
+func (e *CSVRowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d, column %s: %s", e.Row, e.Column, e.Err)
+}
+
+func (e *CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// CSVPreview is the result of a dry run of an import.
+type CSVPreview struct {
+	Schema *Schema
+	// Datasets are the first rows of the file.
+	Datasets []*Dataset
+	// Total is the number of the rows of the file.
+	Total  int
+	Errors []*CSVRowError
+}
+
 type DatasetCSVParser struct {
-	reader    *csv.Reader
-	firstline []string
-	headers   []string
-	schema    *Schema
-	name      string
+	reader  rowReader
+	sample  [][]string
+	headers []string
+	schema  *Schema
+	name    string
+	source  string
+	crs     *crs.CRS
+	options CSVOptions
+	columns csvColumns
+}
+
+// csvColumns is the indexes of the columns that have special meanings. -1 means that there is no such column.
+type csvColumns struct {
+	lat, lng, height, geometry int
+}
+
+func (c csvColumns) hasLocation() bool {
+	return c.lat >= 0 && c.lng >= 0
+}
+
+func (c csvColumns) isLocation(i int) bool {
+	return c.hasLocation() && (i == c.lat || i == c.lng || i == c.height)
+}
+
+// rowReader reads a row of cells such as a line of a CSV file.
+type rowReader interface {
+	Read() ([]string, error)
+}
+
+// rowsReader is a rowReader of rows that are read already.
+type rowsReader [][]string
+
+func (r *rowsReader) Read() ([]string, error) {
+	if len(*r) == 0 {
+		return nil, io.EOF
+	}
+	row := (*r)[0]
+	*r = (*r)[1:]
+	return row, nil
 }
 
 func NewCSVParser(r io.Reader, n string, seperator rune) *DatasetCSVParser {
@@ -28,54 +127,186 @@ func NewCSVParser(r io.Reader, n string, seperator rune) *DatasetCSVParser {
 	obj := &DatasetCSVParser{
 		reader: r2,
 		name:   n,
+		source: "file:///" + n,
 	}
 	return obj
 }
 
+// NewTableParser returns a parser of rows such as rows of a spreadsheet. The first row is the header.
+func NewTableParser(rows [][]string, n string) *DatasetCSVParser {
+	r := rowsReader(rows)
+	return &DatasetCSVParser{
+		reader: &r,
+		name:   n,
+		source: "file:///" + n,
+	}
+}
+
+// SetSource sets the source of the schema, which is used to find the schema to be updated when the data is synced again.
+func (p *DatasetCSVParser) SetSource(s string) {
+	p.source = s
+}
+
+// SetCRS sets the CRS of coordinates in the file. Values in the longitude and latitude columns are treated
+// as x (easting) and y (northing) in the CRS and transformed into WGS84. A nil CRS means WGS84.
+func (p *DatasetCSVParser) SetCRS(c *crs.CRS) {
+	p.crs = c
+}
+
+// SetOptions sets the mapping of the columns. It should be called before Init.
+func (p *DatasetCSVParser) SetOptions(o CSVOptions) {
+	p.options = o
+}
+
 func (p *DatasetCSVParser) Init() error {
 	headers, err := p.reader.Read()
 	if err != nil {
 		return ErrFailedToParseCSVorTSVFile
 	}
 	p.headers = headers
-	p.firstline, err = p.reader.Read()
-	if err != nil {
+
+	for len(p.sample) < csvSampleSize {
+		line, err := p.reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ErrFailedToParseCSVorTSVFile
+		}
+		p.sample = append(p.sample, line)
+	}
+	if len(p.sample) == 0 {
 		return ErrFailedToParseCSVorTSVFile
 	}
+
+	for _, t := range p.options.Types {
+		if t != ValueTypeBool && t != ValueTypeNumber && t != ValueTypeString && t != ValueTypeURL {
+			return ErrUnsupportedCSVType
+		}
+	}
+
+	return p.initColumns()
+}
+
+func (p *DatasetCSVParser) initColumns() (err error) {
+	p.columns.lat, err = p.findColumn(p.options.LatColumn, latColumns)
+	if err != nil {
+		return err
+	}
+	p.columns.lng, err = p.findColumn(p.options.LngColumn, lngColumns)
+	if err != nil {
+		return err
+	}
+	p.columns.height, err = p.findColumn(p.options.HeightColumn, heightColumns)
+	if err != nil {
+		return err
+	}
+	p.columns.geometry, err = p.findColumn(p.options.GeometryColumn, geometryColumns)
+	if err != nil {
+		return err
+	}
+
+	// the geometry column is an ordinary column if none of its values are geometries
+	if p.columns.geometry >= 0 && p.geometryType() == ValueTypeUnknown {
+		p.columns.geometry = -1
+	}
 	return nil
 }
+
+// findColumn returns the index of the column of the name, or the first column whose header is one of the candidates.
+func (p *DatasetCSVParser) findColumn(name string, candidates []string) (int, error) {
+	if name != "" {
+		i := lo.IndexOf(p.headers, name)
+		if i < 0 {
+			return -1, fmt.Errorf("%w: %s", ErrCSVColumnNotFound, name)
+		}
+		return i, nil
+	}
+	for _, c := range candidates {
+		for i, h := range p.headers {
+			if strings.EqualFold(strings.TrimSpace(h), c) {
+				return i, nil
+			}
+		}
+	}
+	return -1, nil
+}
+
 func (p *DatasetCSVParser) validateLine(line []string) bool {
 	return len(p.headers) == len(line)
 }
 
+// columnType guesses the type of the column from the sampled rows. Blank cells are ignored, and a column that has
+// values of different types or no values is a string column.
+func (p *DatasetCSVParser) columnType(i int) ValueType {
+	if t, ok := p.options.Types[p.headers[i]]; ok {
+		return t
+	}
+
+	t := ValueTypeUnknown
+	for _, line := range p.sample {
+		if i >= len(line) || strings.TrimSpace(line[i]) == "" {
+			continue
+		}
+		vt := ValueFromStringOrNumber(strings.TrimSpace(line[i])).Type()
+		if t == ValueTypeUnknown {
+			t = vt
+		} else if t != vt {
+			return ValueTypeString
+		}
+	}
+	if t == ValueTypeUnknown {
+		return ValueTypeString
+	}
+	return t
+}
+
+// geometryType returns the type of the first geometry in the sampled rows of the geometry column.
+func (p *DatasetCSVParser) geometryType() ValueType {
+	for _, line := range p.sample {
+		i := p.columns.geometry
+		if i >= len(line) || strings.TrimSpace(line[i]) == "" {
+			continue
+		}
+		if g, err := parseCellGeometry(line[i], nil); err == nil {
+			return g.valueType()
+		}
+	}
+	return ValueTypeUnknown
+}
+
+func (p *DatasetCSVParser) locationType() ValueType {
+	if p.columns.height >= 0 {
+		return ValueTypeLatLngHeight
+	}
+	return ValueTypeLatLng
+}
+
 func (p *DatasetCSVParser) GuessSchema(sid SceneID) error {
-	if !p.validateLine(p.firstline) {
+	if !p.validateLine(p.sample[0]) {
 		return ErrFailedToParseCSVorTSVFile
 	}
 	schemafields := []*SchemaField{}
-	haslat, haslng := false, false
 	for k, h := range p.headers {
-		if h == "lat" {
-			haslat = true
+		if p.columns.isLocation(k) || strings.TrimSpace(h) == "" {
+			continue
 		}
-		if h == "lng" {
-			haslng = true
-		}
-		if h != "lng" && h != "lat" && strings.TrimSpace(h) != "" {
-			t := ValueFromStringOrNumber(p.firstline[k]).Type()
-			field, _ := NewSchemaField().NewID().Name(h).Type(t).Build()
-			schemafields = append(schemafields, field)
+		t := p.columnType(k)
+		if k == p.columns.geometry {
+			t = p.geometryType()
 		}
+		field, _ := NewSchemaField().NewID().Name(h).Source(h).Type(t).Build()
+		schemafields = append(schemafields, field)
 	}
-	if haslat && haslng {
-		field, _ := NewSchemaField().NewID().Name("location").Type(ValueTypeLatLng).Build()
+	if p.columns.hasLocation() {
+		field, _ := NewSchemaField().NewID().Name("location").Source("location").Type(p.locationType()).Build()
 		schemafields = append(schemafields, field)
 	}
 	schema, err := NewSchema().
 		NewID().
 		Scene(sid).
 		Name(p.name).
-		Source("file:///" + p.name).
+		Source(p.source).
 		Fields(schemafields).
 		Build()
 	if err != nil {
@@ -86,85 +317,210 @@ func (p *DatasetCSVParser) GuessSchema(sid SceneID) error {
 }
 
 func (p *DatasetCSVParser) ReadAll() (*Schema, []*Dataset, error) {
+	datasets := []*Dataset{}
+	err := p.read(func(ds *Dataset, _ []*CSVRowError, err *CSVRowError) bool {
+		if err != nil {
+			return false
+		}
+		datasets = append(datasets, ds)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.schema, datasets, nil
+}
+
+// Preview reads all rows without stopping at errors, and returns the first limit rows and the errors of all rows.
+func (p *DatasetCSVParser) Preview(limit int) (*CSVPreview, error) {
+	res := &CSVPreview{Schema: p.schema}
+	addErrors := func(errs ...*CSVRowError) {
+		for _, e := range errs {
+			if e != nil && len(res.Errors) < maxCSVPreviewErrors {
+				res.Errors = append(res.Errors, e)
+			}
+		}
+	}
+	err := p.read(func(ds *Dataset, warnings []*CSVRowError, err *CSVRowError) bool {
+		res.Total++
+		addErrors(warnings...)
+		addErrors(err)
+		if ds != nil && len(res.Datasets) < limit {
+			res.Datasets = append(res.Datasets, ds)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// read reads rows and calls f with the dataset of each row. warnings are errors of cells whose values are kept
+// as they are, and err is an error that makes the row invalid. It stops reading when f returns false,
+// and returns the error of the row.
+func (p *DatasetCSVParser) read(f func(ds *Dataset, warnings []*CSVRowError, err *CSVRowError) bool) error {
 	if p.schema == nil {
-		return nil, nil, errors.New("schema is not generated yet")
+		return errors.New("schema is not generated yet")
 	}
-	var fields []*Field
-	schemafieldmap := make(map[string]FieldID)
+	schemafieldmap := make(map[string]*SchemaField)
 	for _, f := range p.schema.Fields() {
 		if _, ok := schemafieldmap[f.Name()]; !ok {
-			schemafieldmap[f.Name()] = f.ID()
+			schemafieldmap[f.Name()] = f
 		} else {
-			return nil, nil, ErrDuplicatiedNameFields
+			return ErrDuplicatiedNameFields
 		}
 	}
-	datasets := []*Dataset{}
-	i := 0
-	for {
+
+	for i := 0; ; i++ {
 		var line []string
-		var err error
-		if i == 0 {
-			// process first line
-			line = p.firstline
+		if i < len(p.sample) {
+			line = p.sample[i]
 		} else {
+			var err error
 			line, err = p.reader.Read()
 			if err == io.EOF {
 				break
 			}
 			if err != nil {
-				return nil, nil, err
+				return err
 			}
 		}
+
 		if !p.validateLine(line) {
-			return nil, nil, ErrFailedToParseCSVorTSVFile
+			rerr := &CSVRowError{Row: i + 1, Err: ErrFailedToParseCSVorTSVFile}
+			if !f(nil, nil, rerr) {
+				return rerr
+			}
+			continue
 		}
 
-		fields, err = p.getFields(line, schemafieldmap)
-		if err != nil {
-			return nil, nil, err
+		fields, warnings, rerr := p.getFields(line, i, schemafieldmap)
+		var ds *Dataset
+		if rerr == nil {
+			var err error
+			ds, err = New().NewID().
+				Source(p.rowSource(line, i)).
+				Fields(fields).
+				Scene(p.schema.Scene()).Schema(p.schema.ID()).Build()
+			if err != nil {
+				return err
+			}
 		}
-		ds, err := New().NewID().
-			Fields(fields).
-			Scene(p.schema.Scene()).Schema(p.schema.ID()).Build()
-		if err != nil {
-			return nil, nil, err
+		if !f(ds, warnings, rerr) {
+			return rerr
 		}
-		datasets = append(datasets, ds)
-		i++
 	}
+	return nil
+}
 
-	return p.schema, datasets, nil
+// rowSource returns the value of the id column if any or the row number, which identifies the row when the data is synced again.
+func (p *DatasetCSVParser) rowSource(line []string, i int) string {
+	idColumn := p.options.IDColumn
+	if idColumn == "" {
+		idColumn = "id"
+	}
+	for k, h := range p.headers {
+		if h == idColumn && line[k] != "" {
+			return line[k]
+		}
+	}
+	return strconv.Itoa(i + 1)
 }
 
-func (p *DatasetCSVParser) getFields(line []string, sfm map[string]FieldID) ([]*Field, error) {
+func (p *DatasetCSVParser) getFields(line []string, row int, sfm map[string]*SchemaField) ([]*Field, []*CSVRowError, *CSVRowError) {
 	fields := []*Field{}
-	var lat, lng *float64
+	var warnings []*CSVRowError
+	var lat, lng, height *float64
 	for i, record := range line {
-		value := ValueFromStringOrNumber(record)
-		if p.headers[i] == "lng" {
-			value, err := strconv.ParseFloat(record, 64)
+		h := p.headers[i]
+
+		if p.columns.isLocation(i) {
+			// a row whose lat and lng are blank has no location
+			if strings.TrimSpace(record) == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimSpace(record), 64)
 			if err != nil {
-				return nil, ErrFailedToParseCSVorTSVFile
+				return nil, nil, &CSVRowError{Row: row + 1, Column: h, Err: ErrFailedToParseCSVorTSVFile}
 			}
-			lng = &value
+			switch i {
+			case p.columns.lat:
+				lat = &value
+			case p.columns.lng:
+				lng = &value
+			case p.columns.height:
+				height = &value
+			}
+			continue
+		}
+
+		sf := sfm[h]
+		if sf == nil {
+			continue
 		}
-		if p.headers[i] == "lat" {
-			value, err := strconv.ParseFloat(record, 64)
+
+		if i == p.columns.geometry {
+			if strings.TrimSpace(record) == "" {
+				continue
+			}
+			g, err := parseCellGeometry(record, p.crs)
+			if err != nil {
+				return nil, nil, &CSVRowError{Row: row + 1, Column: h, Err: err}
+			}
+			v, err := g.value(sf.Type())
 			if err != nil {
-				return nil, ErrFailedToParseCSVorTSVFile
+				return nil, nil, &CSVRowError{Row: row + 1, Column: h, Err: err}
 			}
-			lat = &value
+			fields = append(fields, NewField(sf.ID(), v, h))
+			continue
 		}
 
-		if p.headers[i] != "lat" && p.headers[i] != "lng" {
-			fields = append(fields, NewField(sfm[p.headers[i]], value, ""))
+		v, err := cellValue(sf.Type(), record)
+		if err != nil {
+			// the value is kept as it is so that no data is lost
+			warnings = append(warnings, &CSVRowError{Row: row + 1, Column: h, Err: err})
+			v = ValueFromStringOrNumber(record)
+		}
+		if v != nil {
+			fields = append(fields, NewField(sf.ID(), v, h))
+		}
+	}
+
+	if (lat == nil) != (lng == nil) {
+		h := p.headers[p.columns.lat]
+		if lat != nil {
+			h = p.headers[p.columns.lng]
 		}
+		return nil, nil, &CSVRowError{Row: row + 1, Column: h, Err: ErrFailedToParseCSVorTSVFile}
 	}
 	if lat != nil && lng != nil {
-		latlng := LatLng{Lat: *lat, Lng: *lng}
-		fields = append(fields, NewField(sfm["location"], ValueTypeLatLng.ValueFrom(latlng), ""))
+		x, y := p.crs.ToWGS84(*lng, *lat)
+		var v *Value
+		if p.columns.height >= 0 {
+			v = ValueTypeLatLngHeight.ValueFrom(LatLngHeight{Lat: y, Lng: x, Height: lo.FromPtr(height)})
+		} else {
+			v = ValueTypeLatLng.ValueFrom(LatLng{Lat: y, Lng: x})
+		}
+		fields = append(fields, NewField(sfm["location"].ID(), v, "location"))
+	}
+	return fields, warnings, nil
+}
+
+// cellValue converts the cell into a value of the type. Blank cells are empty strings in string columns
+// and no values in other columns.
+func cellValue(t ValueType, s string) (*Value, error) {
+	if t == ValueTypeString {
+		return t.ValueFrom(s), nil
 	}
-	return append([]*Field{}, fields...), nil
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if v := t.ValueFrom(s); v != nil {
+		return v, nil
+	}
+	return nil, ErrInvalidCSVValue
 }
 
 func (p *DatasetCSVParser) CheckCompatible(s *Schema) error {
@@ -172,28 +528,25 @@ func (p *DatasetCSVParser) CheckCompatible(s *Schema) error {
 	for _, f := range s.Fields() {
 		fieldsmap[f.Name()] = f
 	}
-	haslat, haslng := false, false
 	for i, h := range p.headers {
-		if h != "lat" && h != "lng" {
-			if fieldsmap[h] == nil {
-				return ErrIncompatibleSchema
-			}
-			t := fieldsmap[h].Type()
-			v := ValueFromStringOrNumber(p.firstline[i])
-			if v.Type() != t {
-				return ErrIncompatibleSchema
-			}
+		if p.columns.isLocation(i) {
+			continue
 		}
-		if h == "lat" {
-			haslat = true
+		if fieldsmap[h] == nil {
+			return ErrIncompatibleSchema
 		}
-		if h == "lng" {
-			haslng = true
+		t := fieldsmap[h].Type()
+		if i == p.columns.geometry {
+			if p.geometryType() != t {
+				return ErrIncompatibleSchema
+			}
+		} else if p.columnType(i) != t && !p.isBlankColumn(i) {
+			return ErrIncompatibleSchema
 		}
 	}
 	// check for location fields
-	if haslat && haslng {
-		if fieldsmap["location"] == nil {
+	if p.columns.hasLocation() {
+		if fieldsmap["location"] == nil || fieldsmap["location"].Type() != p.locationType() {
 			return ErrIncompatibleSchema
 		}
 	} else {
@@ -205,3 +558,12 @@ func (p *DatasetCSVParser) CheckCompatible(s *Schema) error {
 	p.schema = s
 	return nil
 }
+
+func (p *DatasetCSVParser) isBlankColumn(i int) bool {
+	for _, line := range p.sample {
+		if i < len(line) && strings.TrimSpace(line[i]) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,6 +31,8 @@ func TestCSVParser(t *testing.T) {
 	assert.Equal(t, "file:///hoge.csv", schema.Source())
 
 	assert.Equal(t, 1, len(datasets))
+	assert.Equal(t, "1", datasets[0].Source())
+	assert.Equal(t, "hoge", schema.FieldBySource("hoge").Name())
 
 	dsfm := make(map[string]interface{})
 	for _, dsf := range datasets[0].Fields() {
@@ -42,6 +46,21 @@ func TestCSVParser(t *testing.T) {
 	}, dsfm)
 }
 
+func TestCSVParser_SetCRS(t *testing.T) {
+	// the origin of JGD2011 / Japan Plane Rectangular CS IX
+	p := NewCSVParser(strings.NewReader("name,lat,lng\na,0,0"), "hoge.csv", ',')
+	p.SetCRS(lo.Must(crs.EPSG(6677)))
+	assert.NoError(t, p.Init())
+	assert.NoError(t, p.GuessSchema(NewSceneID()))
+
+	_, datasets, err := p.ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(datasets))
+	latlng := datasets[0].FieldByType(ValueTypeLatLng).Value().ValueLatLng()
+	assert.InDelta(t, 36, latlng.Lat, 1e-9)
+	assert.InDelta(t, 139+50.0/60, latlng.Lng, 1e-9)
+}
+
 func TestCSVParserCheckCompatible(t *testing.T) {
 	r := strings.NewReader(csvmock)
 	p := NewCSVParser(r, "hoge", ',')
@@ -57,3 +76,193 @@ func TestCSVParserCheckCompatible(t *testing.T) {
 	result := p.CheckCompatible(ds)
 	assert.NoError(t, result)
 }
+
+func TestCSVParser_Sample(t *testing.T) {
+	// the first row has a blank value and the third row has a value of a different type
+	p := NewCSVParser(strings.NewReader("id,pop,name,mixed\na,,x,1\nb,10,,2\nc,20,z,three"), "hoge.csv", ',')
+	assert.NoError(t, p.Init())
+	assert.NoError(t, p.GuessSchema(NewSceneID()))
+
+	schema, datasets, err := p.ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, ValueTypeNumber, schema.FieldBySource("pop").Type())
+	assert.Equal(t, ValueTypeString, schema.FieldBySource("name").Type())
+	assert.Equal(t, ValueTypeString, schema.FieldBySource("mixed").Type())
+	assert.Nil(t, schema.FieldBySource("location"))
+
+	assert.Equal(t, 3, len(datasets))
+	assert.Equal(t, "a", datasets[0].Source())
+	assert.Nil(t, datasets[0].FieldBySource("pop"))
+	assert.Equal(t, "1", datasets[0].FieldBySource("mixed").Value().Interface())
+	assert.Equal(t, 10.0, datasets[1].FieldBySource("pop").Value().Interface())
+	assert.Equal(t, "", datasets[1].FieldBySource("name").Value().Interface())
+}
+
+func TestCSVParser_SetOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		csv      string
+		options  CSVOptions
+		want     map[string]any
+		wantType map[string]ValueType
+		wantErr  error
+	}{
+		{
+			name: "x and y",
+			csv:  "name,X,Y\na,15,12",
+			want: map[string]any{
+				"name":     "a",
+				"location": LatLng{Lat: 12, Lng: 15},
+			},
+		},
+		{
+			name: "latitude, longitude and altitude",
+			csv:  "name,Latitude,Longitude,altitude\na,12,15,100",
+			want: map[string]any{
+				"name":     "a",
+				"location": LatLngHeight{Lat: 12, Lng: 15, Height: 100},
+			},
+		},
+		{
+			name: "blank location",
+			csv:  "name,lat,lng\na,,",
+			want: map[string]any{
+				"name": "a",
+			},
+		},
+		{
+			name:    "explicit columns",
+			csv:     "name,n,e,lat\na,12,15,x",
+			options: CSVOptions{LatColumn: "n", LngColumn: "e"},
+			want: map[string]any{
+				"name":     "a",
+				"lat":      "x",
+				"location": LatLng{Lat: 12, Lng: 15},
+			},
+		},
+		{
+			name:    "missing column",
+			csv:     "name,lat,lng\na,12,15",
+			options: CSVOptions{LatColumn: "n"},
+			wantErr: ErrCSVColumnNotFound,
+		},
+		{
+			name:    "types",
+			csv:     "code,flag,site\n001,true,https://example.com",
+			options: CSVOptions{Types: map[string]ValueType{"code": ValueTypeString, "site": ValueTypeURL}},
+			want: map[string]any{
+				"code": "001",
+				"flag": true,
+				"site": "https://example.com",
+			},
+			wantType: map[string]ValueType{
+				"code": ValueTypeString,
+				"flag": ValueTypeBool,
+				"site": ValueTypeURL,
+			},
+		},
+		{
+			name:    "unsupported type",
+			csv:     "code\n001",
+			options: CSVOptions{Types: map[string]ValueType{"code": ValueTypeLatLng}},
+			wantErr: ErrUnsupportedCSVType,
+		},
+		{
+			name: "wkt",
+			csv:  "name,wkt\na,\"LINESTRING (15 12, 16 13)\"",
+			want: map[string]any{
+				"name": "a",
+				"wkt":  Coordinates{{Lat: 12, Lng: 15}, {Lat: 13, Lng: 16}},
+			},
+		},
+		{
+			name:    "geojson",
+			csv:     "name\tshape\na\t\"{\"\"type\"\":\"\"Point\"\",\"\"coordinates\"\":[15,12,3]}\"",
+			options: CSVOptions{GeometryColumn: "shape"},
+			want: map[string]any{
+				"name":  "a",
+				"shape": LatLngHeight{Lat: 12, Lng: 15, Height: 3},
+			},
+		},
+		{
+			name: "not geometry",
+			csv:  "name,geometry\na,none",
+			want: map[string]any{
+				"name":     "a",
+				"geometry": "none",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sep := ','
+			if strings.Contains(tt.csv, "\t") {
+				sep = '\t'
+			}
+			p := NewCSVParser(strings.NewReader(tt.csv), "hoge.csv", sep)
+			p.SetOptions(tt.options)
+			err := p.Init()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, p.GuessSchema(NewSceneID()))
+
+			schema, datasets, err := p.ReadAll()
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(datasets))
+
+			got := map[string]any{}
+			for _, f := range datasets[0].Fields() {
+				got[schema.Field(f.Field()).Name()] = f.Value().Interface()
+			}
+			assert.Equal(t, tt.want, got)
+			for k, v := range tt.wantType {
+				assert.Equal(t, v, schema.FieldBySource(k).Type(), k)
+			}
+		})
+	}
+}
+
+func TestCSVParser_ReadAll_InvalidLocation(t *testing.T) {
+	p := NewCSVParser(strings.NewReader("name,lat,lng\na,12,15\nb,north,15"), "hoge.csv", ',')
+	assert.NoError(t, p.Init())
+	assert.NoError(t, p.GuessSchema(NewSceneID()))
+
+	_, _, err := p.ReadAll()
+	var rerr *CSVRowError
+	assert.ErrorAs(t, err, &rerr)
+	assert.Equal(t, 2, rerr.Row)
+	assert.Equal(t, "lat", rerr.Column)
+}
+
+func TestCSVParser_Preview(t *testing.T) {
+	csv := "name,pop,lat,lng,geometry\n" +
+		"a,1,12,15,POINT (15 12)\n" +
+		"b,x,12,15,\n" +
+		"c,3,north,15,POINT (15 12)\n" +
+		"d,4,12,15,POINT (15 12\n" +
+		"e,5,12,15,POINT (15 12)"
+	p := NewCSVParser(strings.NewReader(csv), "hoge.csv", ',')
+	p.SetOptions(CSVOptions{Types: map[string]ValueType{"pop": ValueTypeNumber}})
+	assert.NoError(t, p.Init())
+	assert.NoError(t, p.GuessSchema(NewSceneID()))
+
+	res, err := p.Preview(2)
+	assert.NoError(t, err)
+	assert.Equal(t, p.schema, res.Schema)
+	assert.Equal(t, 5, res.Total)
+	assert.Equal(t, 2, len(res.Datasets))
+	assert.Equal(t, "b", res.Datasets[1].FieldBySource("name").Value().Interface())
+	assert.Equal(t, "x", res.Datasets[1].FieldBySource("pop").Value().Interface())
+	assert.Equal(t, []*CSVRowError{
+		{Row: 2, Column: "pop", Err: ErrInvalidCSVValue},
+		{Row: 3, Column: "lat", Err: ErrFailedToParseCSVorTSVFile},
+		{Row: 4, Column: "geometry", Err: ErrInvalidGeometry},
+	}, res.Errors)
+}
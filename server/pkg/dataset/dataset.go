@@ -1,5 +1,11 @@
 package dataset
 
+import (
+	"reflect"
+
+	"github.com/samber/lo"
+)
+
 type Dataset struct {
 	id     ID
 	source string
@@ -88,6 +94,34 @@ func (d *Dataset) FieldBySource(source string) *Field {
 	return nil
 }
 
+// EqualValuesBySource returns true if the datasets have the same values for the fields with the same sources.
+// Field IDs are not compared, since they are regenerated every time a schema is synced.
+func (d *Dataset) EqualValuesBySource(d2 *Dataset) bool {
+	v1, v2 := d.valuesBySource(), d2.valuesBySource()
+	if len(v1) != len(v2) {
+		return false
+	}
+	for s, v := range v1 {
+		if w, ok := v2[s]; !ok || !reflect.DeepEqual(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Dataset) valuesBySource() map[string]any {
+	if d == nil {
+		return nil
+	}
+	res := make(map[string]any, len(d.fields))
+	for _, f := range d.fields {
+		if f != nil && !f.IsEmpty() {
+			res[f.Source()] = f.Value().Interface()
+		}
+	}
+	return res
+}
+
 func (d *Dataset) FieldByType(t ValueType) *Field {
 	if d == nil {
 		return nil
@@ -120,6 +154,23 @@ func (d *Dataset) Interface(s *Schema, idkey string) map[string]interface{} {
 	return m
 }
 
+// SelectFields returns a copy of the dataset that has only the fields of the IDs.
+func (d *Dataset) SelectFields(ids []FieldID) *Dataset {
+	if d == nil {
+		return nil
+	}
+	d2 := *d
+	d2.fields = make(map[FieldID]*Field, len(ids))
+	d2.order = make([]FieldID, 0, len(ids))
+	for _, id := range d.order {
+		if lo.Contains(ids, id) {
+			d2.fields[id] = d.fields[id]
+			d2.order = append(d2.order, id)
+		}
+	}
+	return &d2
+}
+
 // Interface is almost same as Interface, but keys of the map are IDs of fields.
 func (d *Dataset) InterfaceWithFieldIDs(idkey string) map[string]interface{} {
 	if d == nil {
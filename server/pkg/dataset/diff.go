@@ -4,4 +4,6 @@ type Diff struct {
 	Added   List
 	Removed List
 	Others  map[ID]*Dataset
+	// Changed is the new datasets in Others whose values are different from the old ones.
+	Changed List
 }
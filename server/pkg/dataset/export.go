@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/reearth/reearth/server/pkg/xlsx"
 	"github.com/reearth/reearthx/i18n"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/samber/lo"
@@ -28,6 +29,16 @@ var formats = map[string]Format{
 		ContentType: "application/json",
 		export:      ExportJSON,
 	},
+	"geojson": {
+		Ext:         "geojson",
+		ContentType: "application/geo+json",
+		export:      ExportGeoJSON,
+	},
+	"xlsx": {
+		Ext:         "xlsx",
+		ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		export:      ExportXLSX,
+	},
 }
 
 var ErrUnknownFormat = rerror.NewE(i18n.T("unknown format"))
@@ -135,6 +146,164 @@ func ExportJSON(w io.Writer, ds *Schema, printSchema bool, loader func(func(*Dat
 	return nil
 }
 
+// ExportGeoJSON exports datasets as a feature collection. The first field of a location, coordinates or polygon becomes
+// the geometry of features, and the other fields become properties. Features are identified by IDs of datasets.
+func ExportGeoJSON(w io.Writer, ds *Schema, _ bool, loader func(func(*Dataset) error) error) error {
+	var geometryField *SchemaField
+	for _, f := range ds.Fields() {
+		if t := f.Type(); t == ValueTypeLatLng || t == ValueTypeLatLngHeight || t == ValueTypeCoordinates || t == TypePolygon {
+			geometryField = f
+			break
+		}
+	}
+
+	if _, err := w.Write([]byte(`{"type":"FeatureCollection","features":[`)); err != nil {
+		return err
+	}
+
+	first := true
+	if err := loader(func(d *Dataset) error {
+		properties := map[string]any{}
+		for _, sf := range ds.Fields() {
+			if sf == geometryField {
+				continue
+			}
+			if f := d.Field(sf.ID()); f != nil {
+				properties[sf.Name()] = f.Value().Interface()
+			}
+		}
+		var geometry any
+		if geometryField != nil {
+			geometry = geoJSONGeometry(d.Field(geometryField.ID()))
+		}
+
+		b, err := json.Marshal(map[string]any{
+			"type":       "Feature",
+			"id":         d.ID().String(),
+			"geometry":   geometry,
+			"properties": properties,
+		})
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := w.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = w.Write(b)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	_, err := w.Write([]byte("]}\n"))
+	return err
+}
+
+// ExportXLSX exports datasets as a workbook that has the same columns as CSV, whose cells have types of numbers,
+// booleans and strings.
+func ExportXLSX(w io.Writer, ds *Schema, _ bool, loader func(func(*Dataset) error) error) error {
+	xw, err := xlsx.NewWriter(w, ds.Name())
+	if err != nil {
+		return err
+	}
+	// nil means the dataset ID
+	dsfields := append([]*SchemaField{nil}, ds.Fields()...)
+
+	header := lo.FlatMap(dsfields, func(f *SchemaField, _ int) []any {
+		return lo.ToAnySlice(csvHeader(f))
+	})
+	if err := xw.WriteRow(header); err != nil {
+		return err
+	}
+
+	if err := loader(func(d *Dataset) error {
+		row := lo.FlatMap(dsfields, func(sf *SchemaField, _ int) []any {
+			if sf == nil {
+				return []any{d.ID().String()}
+			}
+			return xlsxValue(sf, d.Field(sf.ID()))
+		})
+		return xw.WriteRow(row)
+	}); err != nil {
+		return err
+	}
+
+	return xw.Close()
+}
+
+func geoJSONGeometry(f *Field) any {
+	if f == nil {
+		return nil
+	}
+	position := func(l LatLngHeight, hasHeight bool) []float64 {
+		if hasHeight {
+			return []float64{l.Lng, l.Lat, l.Height}
+		}
+		return []float64{l.Lng, l.Lat}
+	}
+	positions := func(c Coordinates) [][]float64 {
+		return lo.Map(c, func(l LatLngHeight, _ int) []float64 {
+			return position(l, l.Height != 0)
+		})
+	}
+
+	v := f.Value()
+	switch f.Type() {
+	case ValueTypeLatLng:
+		if l := v.ValueLatLng(); l != nil {
+			return map[string]any{"type": "Point", "coordinates": position(LatLngHeight{Lat: l.Lat, Lng: l.Lng}, false)}
+		}
+	case ValueTypeLatLngHeight:
+		if l := v.ValueLatLngHeight(); l != nil {
+			return map[string]any{"type": "Point", "coordinates": position(*l, true)}
+		}
+	case ValueTypeCoordinates:
+		if c := v.ValueCoordinates(); c != nil {
+			return map[string]any{"type": "LineString", "coordinates": positions(*c)}
+		}
+	case TypePolygon:
+		if p := v.ValuePolygon(); p != nil {
+			return map[string]any{"type": "Polygon", "coordinates": lo.Map(*p, func(c Coordinates, _ int) [][]float64 {
+				return positions(c)
+			})}
+		}
+	}
+	return nil
+}
+
+func xlsxValue(sf *SchemaField, f *Field) []any {
+	n := len(csvHeader(sf))
+	if f == nil {
+		return make([]any, n)
+	}
+
+	v := f.Value()
+	switch f.Type() {
+	case ValueTypeLatLng:
+		if l := v.ValueLatLng(); l != nil {
+			return []any{l.Lng, l.Lat}
+		}
+	case ValueTypeLatLngHeight:
+		if l := v.ValueLatLngHeight(); l != nil {
+			return []any{l.Lng, l.Lat, l.Height}
+		}
+	case ValueTypeNumber:
+		if n := v.ValueNumber(); n != nil {
+			return []any{*n}
+		}
+	case ValueTypeBool:
+		if b := v.ValueBool(); b != nil {
+			return []any{*b}
+		}
+	default:
+		return []any{v.String()}
+	}
+	return make([]any, n)
+}
+
 func csvHeader(f *SchemaField) []string {
 	if f == nil {
 		return []string{""} // dataset id
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/reearth/reearth/server/pkg/xlsx"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
@@ -107,3 +108,107 @@ func TestExportJSON(t *testing.T) {
 		},
 	}, res)
 }
+
+func TestExportGeoJSON(t *testing.T) {
+	ds := NewSchema().NewID().Name("aaa").Fields([]*SchemaField{
+		NewSchemaField().NewID().Name("a").Type(ValueTypeNumber).MustBuild(),
+		NewSchemaField().NewID().Name("b").Type(ValueTypeLatLngHeight).MustBuild(),
+		NewSchemaField().NewID().Name("c").Type(ValueTypeLatLng).MustBuild(),
+	}).MustBuild()
+	dsf := ds.Fields()
+
+	d1 := New().NewID().Schema(ds.ID()).Fields([]*Field{
+		NewField(dsf[0].ID(), ValueTypeNumber.ValueFrom(1), ""),
+		NewField(dsf[1].ID(), ValueTypeLatLngHeight.ValueFrom(LatLngHeight{Lat: 1, Lng: 2, Height: 3}), ""),
+		NewField(dsf[2].ID(), ValueTypeLatLng.ValueFrom(LatLng{Lat: 4, Lng: 5}), ""),
+	}).MustBuild()
+	d2 := New().NewID().Schema(ds.ID()).Fields([]*Field{
+		NewField(dsf[0].ID(), ValueTypeNumber.ValueFrom(2), ""),
+	}).MustBuild()
+
+	var buf bytes.Buffer
+	err := Export(&buf, "geojson", ds, true, func(cb func(*Dataset) error) error {
+		if err := cb(d1); err != nil {
+			return err
+		}
+		return cb(d2)
+	})
+	assert.NoError(t, err)
+
+	var res map[string]any
+	lo.Must0(json.Unmarshal(buf.Bytes(), &res))
+	assert.Equal(t, map[string]any{
+		"type": "FeatureCollection",
+		"features": []any{
+			map[string]any{
+				"type":     "Feature",
+				"id":       d1.ID().String(),
+				"geometry": map[string]any{"type": "Point", "coordinates": []any{2.0, 1.0, 3.0}},
+				"properties": map[string]any{
+					"a": 1.0,
+					"c": map[string]any{"lat": 4.0, "lng": 5.0},
+				},
+			},
+			map[string]any{
+				"type":       "Feature",
+				"id":         d2.ID().String(),
+				"geometry":   nil,
+				"properties": map[string]any{"a": 2.0},
+			},
+		},
+	}, res)
+}
+
+func TestExportXLSX(t *testing.T) {
+	ds := NewSchema().NewID().Name("aaa").Fields([]*SchemaField{
+		NewSchemaField().NewID().Name("a").Type(ValueTypeNumber).MustBuild(),
+		NewSchemaField().NewID().Name("b").Type(ValueTypeString).MustBuild(),
+		NewSchemaField().NewID().Name("c").Type(ValueTypeLatLng).MustBuild(),
+		NewSchemaField().NewID().Name("d").Type(ValueTypeBool).MustBuild(),
+	}).MustBuild()
+	dsf := ds.Fields()
+
+	d := New().NewID().Schema(ds.ID()).Fields([]*Field{
+		NewField(dsf[0].ID(), ValueTypeNumber.ValueFrom(1.5), ""),
+		NewField(dsf[1].ID(), ValueTypeString.ValueFrom("2"), ""),
+		NewField(dsf[2].ID(), ValueTypeLatLng.ValueFrom(LatLng{Lat: 1, Lng: 2}), ""),
+	}).MustBuild()
+
+	var buf bytes.Buffer
+	err := Export(&buf, "xlsx", ds, true, func(cb func(*Dataset) error) error {
+		return cb(d)
+	})
+	assert.NoError(t, err)
+
+	f, err := xlsx.Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aaa"}, f.SheetNames())
+	rows, err := f.Rows("")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"", "a", "b", "c_lng", "c_lat", "d"},
+		{d.ID().String(), "1.5", "2", "2", "1", ""},
+	}, rows)
+}
+
+func TestSchema_SelectFields(t *testing.T) {
+	ds := NewSchema().NewID().Name("aaa").Fields([]*SchemaField{
+		NewSchemaField().NewID().Name("a").Type(ValueTypeNumber).MustBuild(),
+		NewSchemaField().NewID().Name("b").Type(ValueTypeString).MustBuild(),
+	}).MustBuild()
+	dsf := ds.Fields()
+	d := New().NewID().Schema(ds.ID()).Fields([]*Field{
+		NewField(dsf[0].ID(), ValueTypeNumber.ValueFrom(1), ""),
+		NewField(dsf[1].ID(), ValueTypeString.ValueFrom("2"), ""),
+	}).MustBuild()
+
+	ids := []FieldID{dsf[1].ID(), NewFieldID()}
+	var buf bytes.Buffer
+	err := Export(&buf, "csv", ds.SelectFields(ids), true, func(cb func(*Dataset) error) error {
+		return cb(d.SelectFields(ids))
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ",b\n"+d.ID().String()+",2\n", buf.String())
+	assert.Len(t, ds.Fields(), 2)
+	assert.Len(t, d.Fields(), 2)
+}
@@ -11,8 +11,9 @@ func NewField(field FieldID, value *Value, source string) *Field {
 		return nil
 	}
 	return &Field{
-		field: field,
-		value: value,
+		field:  field,
+		value:  value,
+		source: source,
 	}
 }
 
@@ -0,0 +1,151 @@
+package dataset
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/gpkg"
+)
+
+var ErrUnsupportedGeometry = errors.New("unsupported geometry")
+
+// NewGeoPackageParsers returns parsers of the feature tables of the GeoPackage file. Point geometries are read
+// into the "location" field, and line strings and polygons are read into a field named after the geometry column.
+// Multi geometries are supported only if they have one part.
+func NewGeoPackageParsers(r io.ReaderAt, size int64, n string) ([]*DatasetCSVParser, error) {
+	f, err := gpkg.Open(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := f.Layers()
+	res := make([]*DatasetCSVParser, 0, len(layers))
+	for _, l := range layers {
+		p, err := newGeoPackageParser(f, l, n)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", l.Name, err)
+		}
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+func newGeoPackageParser(f *gpkg.File, l *gpkg.Layer, n string) (*DatasetCSVParser, error) {
+	features, err := f.Features(l)
+	if err != nil {
+		return nil, err
+	}
+
+	var c *crs.CRS
+	if l.SRS != nil && strings.EqualFold(l.SRS.Organization, "EPSG") {
+		if c, err = crs.EPSG(l.SRS.Code); err != nil {
+			return nil, err
+		}
+	}
+
+	geometries := make([]*gpkg.Geometry, 0, len(features))
+	points, hasZ := true, false
+	for i, feature := range features {
+		g := feature.Geometry
+		if g != nil {
+			if g = g.Single(); g == nil {
+				return nil, &CSVRowError{Row: i + 1, Column: l.GeometryColumn, Err: ErrUnsupportedGeometry}
+			}
+			points = points && g.Type == gpkg.GeometryTypePoint
+			hasZ = hasZ || g.Type == gpkg.GeometryTypePoint && len(g.Positions[0]) > 2
+		}
+		geometries = append(geometries, g)
+	}
+
+	o := CSVOptions{Types: map[string]ValueType{}}
+	var headers []string
+	if points {
+		// location columns come first so that they are found before attribute columns of the same names
+		o.LatColumn, o.LngColumn = "lat", "lng"
+		headers = append(headers, "lng", "lat")
+		if hasZ {
+			o.HeightColumn = "height"
+			headers = append(headers, "height")
+		}
+	} else {
+		o.GeometryColumn = l.GeometryColumn
+		headers = append(headers, l.GeometryColumn)
+	}
+	for _, col := range l.Columns {
+		headers = append(headers, col.Name)
+		if t := geoPackageColumnType(col.Type); t != ValueTypeUnknown {
+			o.Types[col.Name] = t
+		}
+		if col.PrimaryKey {
+			o.IDColumn = col.Name
+		}
+	}
+
+	rows := make([][]string, 0, len(features)+1)
+	rows = append(rows, headers)
+	for i, feature := range features {
+		row := make([]string, 0, len(headers))
+		g := geometries[i]
+		if points {
+			if g != nil {
+				for _, v := range g.Positions[0] {
+					row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+				}
+			}
+			for len(row) < len(headers)-len(l.Columns) {
+				row = append(row, "")
+			}
+		} else if g != nil {
+			row = append(row, g.WKT())
+		} else {
+			row = append(row, "")
+		}
+		for _, col := range l.Columns {
+			row = append(row, geoPackageValue(feature.Properties[col.Name], o.Types[col.Name]))
+		}
+		rows = append(rows, row)
+	}
+
+	name := l.Identifier
+	if name == "" {
+		name = l.Name
+	}
+	p := NewTableParser(rows, name)
+	p.SetSource("file:///" + n + "#" + l.Name)
+	p.SetCRS(c)
+	p.SetOptions(o)
+	return p, nil
+}
+
+// geoPackageColumnType returns the type of the field of a column by its declared type.
+func geoPackageColumnType(t string) ValueType {
+	switch {
+	case t == "BOOLEAN":
+		return ValueTypeBool
+	case strings.Contains(t, "INT"), t == "REAL", t == "FLOAT", t == "DOUBLE", strings.HasPrefix(t, "NUMERIC"), strings.HasPrefix(t, "DECIMAL"):
+		return ValueTypeNumber
+	case strings.HasPrefix(t, "TEXT"), strings.Contains(t, "CHAR"), t == "DATE", t == "DATETIME":
+		return ValueTypeString
+	}
+	return ValueTypeUnknown
+}
+
+func geoPackageValue(v any, t ValueType) string {
+	switch v := v.(type) {
+	case int64:
+		if t == ValueTypeBool {
+			return strconv.FormatBool(v != 0)
+		}
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	}
+	// null and blob
+	return ""
+}
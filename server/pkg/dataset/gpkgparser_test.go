@@ -0,0 +1,69 @@
+package dataset
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/gpkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGeoPackageParsers(t *testing.T) {
+	b, err := os.ReadFile("../gpkg/testdata/test.gpkg")
+	assert.NoError(t, err)
+	_, err = NewGeoPackageParsers(bytes.NewReader(b), int64(len(b)), "test.gpkg")
+	// the 2nd feature of roads has a multi line string of two line strings
+	var rerr *CSVRowError
+	assert.ErrorAs(t, err, &rerr)
+	assert.ErrorIs(t, err, ErrUnsupportedGeometry)
+	assert.Equal(t, 2, rerr.Row)
+}
+
+func TestGeoPackageParser(t *testing.T) {
+	b, err := os.ReadFile("../gpkg/testdata/test.gpkg")
+	assert.NoError(t, err)
+	p, err := newTestGeoPackageParser(b, "cities")
+	assert.NoError(t, err)
+	assert.NoError(t, p.Init())
+	assert.NoError(t, p.GuessSchema(NewSceneID()))
+
+	schema, datasets, err := p.ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, "Cities", schema.Name())
+	assert.Equal(t, "file:///test.gpkg#cities", schema.Source())
+	assert.Equal(t, []string{"fid", "name", "pop", "area", "capital", "note", "location"}, fieldNames(schema))
+	assert.Equal(t, ValueTypeNumber, schema.FieldBySource("fid").Type())
+	assert.Equal(t, ValueTypeBool, schema.FieldBySource("capital").Type())
+	assert.Equal(t, ValueTypeLatLngHeight, schema.FieldBySource("location").Type())
+
+	assert.Len(t, datasets, 200)
+	assert.Equal(t, "1", datasets[0].Source())
+	assert.Equal(t, LatLngHeight{Lat: 35.68, Lng: 139.76, Height: 40}, datasets[0].FieldBySource("location").Value().Interface())
+	assert.Equal(t, true, datasets[0].FieldBySource("capital").Value().Interface())
+	assert.Equal(t, 1000.0, datasets[0].FieldBySource("pop").Value().Interface())
+	assert.Equal(t, LatLngHeight{Lat: 34.69, Lng: 135.5}, datasets[1].FieldBySource("location").Value().Interface())
+	assert.Nil(t, datasets[2].FieldBySource("location"))
+	assert.Equal(t, false, datasets[1].FieldBySource("capital").Value().Interface())
+}
+
+func newTestGeoPackageParser(b []byte, table string) (*DatasetCSVParser, error) {
+	f, err := gpkg.Open(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range f.Layers() {
+		if l.Name == table {
+			return newGeoPackageParser(f, l, "test.gpkg")
+		}
+	}
+	return nil, gpkg.ErrTableNotFound
+}
+
+func fieldNames(s *Schema) []string {
+	res := []string{}
+	for _, f := range s.Fields() {
+		res = append(res, f.Name())
+	}
+	return res
+}
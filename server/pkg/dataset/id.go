@@ -6,21 +6,25 @@ type ID = id.DatasetID
 type FieldID = id.DatasetFieldID
 type SchemaID = id.DatasetSchemaID
 type SceneID = id.SceneID
+type SyncLogID = id.DatasetSyncLogID
 
 var NewID = id.NewDatasetID
 var NewSchemaID = id.NewDatasetSchemaID
 var NewFieldID = id.NewDatasetFieldID
 var NewSceneID = id.NewSceneID
+var NewSyncLogID = id.NewDatasetSyncLogID
 
 var MustID = id.MustDatasetID
 var MustSchemaID = id.MustDatasetSchemaID
 var MustFieldID = id.MustDatasetFieldID
 var MustSceneID = id.MustSceneID
+var MustSyncLogID = id.MustDatasetSyncLogID
 
 var IDFrom = id.DatasetIDFrom
 var SchemaIDFrom = id.DatasetSchemaIDFrom
 var FieldIDFrom = id.DatasetFieldIDFrom
 var SceneIDFrom = id.SceneIDFrom
+var SyncLogIDFrom = id.DatasetSyncLogIDFrom
 
 var IDFromRef = id.DatasetIDFromRef
 var SchemaIDFromRef = id.DatasetSchemaIDFromRef
@@ -0,0 +1,251 @@
+package dataset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+var ErrFailedToParseJSONFile error = errors.New("failed to parse file content")
+
+// DatasetJSONParser parses a GeoJSON feature collection or a JSON array of objects into a schema and datasets.
+// Properties of features and objects become fields. Point geometries of features and numeric "lat" and "lng"
+// properties of objects become the "location" field.
+type DatasetJSONParser struct {
+	reader io.Reader
+	name   string
+	source string
+}
+
+type jsonRecord struct {
+	source     string
+	properties map[string]any
+	location   *LatLng
+}
+
+func NewJSONParser(r io.Reader, n string) *DatasetJSONParser {
+	return &DatasetJSONParser{
+		reader: r,
+		name:   n,
+		source: "file:///" + n,
+	}
+}
+
+// SetSource sets the source of the schema, which is used to find the schema to be updated when the data is synced again.
+func (p *DatasetJSONParser) SetSource(s string) {
+	p.source = s
+}
+
+func (p *DatasetJSONParser) Parse(sid SceneID) (*Schema, []*Dataset, error) {
+	var content any
+	if err := json.NewDecoder(p.reader).Decode(&content); err != nil {
+		return nil, nil, ErrFailedToParseJSONFile
+	}
+	records, err := jsonRecords(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// field types are guessed from the first non-null values
+	var keys []string
+	types := map[string]ValueType{}
+	hasLocation := false
+	for _, r := range records {
+		for _, k := range sortedKeys(r.properties) {
+			t, ok := types[k]
+			if !ok {
+				keys = append(keys, k)
+			}
+			if t == ValueTypeUnknown {
+				types[k] = jsonValueType(r.properties[k])
+			}
+		}
+		hasLocation = hasLocation || r.location != nil
+	}
+
+	schemaFields := make([]*SchemaField, 0, len(keys)+1)
+	fieldIDs := map[string]FieldID{}
+	for _, k := range keys {
+		t := types[k]
+		if t == ValueTypeUnknown {
+			t = ValueTypeString
+		}
+		f, err := NewSchemaField().NewID().Name(k).Source(k).Type(t).Build()
+		if err != nil {
+			return nil, nil, err
+		}
+		schemaFields = append(schemaFields, f)
+		fieldIDs[k] = f.ID()
+		types[k] = t
+	}
+	if hasLocation {
+		f, err := NewSchemaField().NewID().Name("location").Source("location").Type(ValueTypeLatLng).Build()
+		if err != nil {
+			return nil, nil, err
+		}
+		schemaFields = append(schemaFields, f)
+	}
+
+	schema, err := NewSchema().
+		NewID().
+		Scene(sid).
+		Name(p.name).
+		Source(p.source).
+		Fields(schemaFields).
+		Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	datasets := make([]*Dataset, 0, len(records))
+	for _, r := range records {
+		fields := make([]*Field, 0, len(r.properties)+1)
+		for _, k := range keys {
+			if f := NewField(fieldIDs[k], jsonValue(types[k], r.properties[k]), k); f != nil {
+				fields = append(fields, f)
+			}
+		}
+		if r.location != nil {
+			fields = append(fields, NewField(schemaFields[len(schemaFields)-1].ID(), ValueTypeLatLng.ValueFrom(*r.location), "location"))
+		}
+		ds, err := New().NewID().
+			Source(r.source).
+			Fields(fields).
+			Scene(sid).
+			Schema(schema.ID()).
+			Build()
+		if err != nil {
+			return nil, nil, err
+		}
+		datasets = append(datasets, ds)
+	}
+
+	return schema, datasets, nil
+}
+
+func jsonRecords(content any) ([]jsonRecord, error) {
+	switch c := content.(type) {
+	case []any:
+		res := make([]jsonRecord, 0, len(c))
+		for i, o := range c {
+			obj, ok := o.(map[string]any)
+			if !ok {
+				return nil, ErrFailedToParseJSONFile
+			}
+			r := jsonRecord{source: recordSource(nil, obj, i), properties: map[string]any{}}
+			lat, ok1 := obj["lat"].(float64)
+			lng, ok2 := obj["lng"].(float64)
+			if ok1 && ok2 {
+				r.location = &LatLng{Lat: lat, Lng: lng}
+			}
+			for k, v := range obj {
+				if r.location == nil || k != "lat" && k != "lng" {
+					r.properties[k] = v
+				}
+			}
+			res = append(res, r)
+		}
+		return res, nil
+	case map[string]any:
+		switch c["type"] {
+		case "FeatureCollection":
+			features, ok := c["features"].([]any)
+			if !ok {
+				return nil, ErrFailedToParseJSONFile
+			}
+			res := make([]jsonRecord, 0, len(features))
+			for i, f := range features {
+				obj, ok := f.(map[string]any)
+				if !ok {
+					return nil, ErrFailedToParseJSONFile
+				}
+				res = append(res, featureRecord(obj, i))
+			}
+			return res, nil
+		case "Feature":
+			return []jsonRecord{featureRecord(c, 0)}, nil
+		}
+	}
+	return nil, ErrFailedToParseJSONFile
+}
+
+func featureRecord(f map[string]any, i int) jsonRecord {
+	properties, _ := f["properties"].(map[string]any)
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	r := jsonRecord{source: recordSource(f["id"], properties, i), properties: properties}
+	if g, ok := f["geometry"].(map[string]any); ok && g["type"] == "Point" {
+		if c, ok := g["coordinates"].([]any); ok && len(c) >= 2 {
+			lng, ok1 := c[0].(float64)
+			lat, ok2 := c[1].(float64)
+			if ok1 && ok2 {
+				r.location = &LatLng{Lat: lat, Lng: lng}
+				delete(properties, "location")
+			}
+		}
+	}
+	return r
+}
+
+// recordSource returns the ID of the feature, the "id" property or the record number, which identifies the record
+// when the data is synced again.
+func recordSource(id any, properties map[string]any, i int) string {
+	if id == nil {
+		id = properties["id"]
+	}
+	switch id := id.(type) {
+	case string:
+		if id != "" {
+			return id
+		}
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	}
+	return strconv.Itoa(i + 1)
+}
+
+func jsonValueType(v any) ValueType {
+	switch v.(type) {
+	case nil:
+		return ValueTypeUnknown
+	case bool:
+		return ValueTypeBool
+	case float64:
+		return ValueTypeNumber
+	}
+	return ValueTypeString
+}
+
+// jsonValue converts a JSON value into a value of the type. Objects and arrays are stored as JSON strings.
+func jsonValue(t ValueType, v any) *Value {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case map[string]any, []any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		return t.ValueFrom(string(b))
+	case string:
+		return t.ValueFrom(v)
+	default:
+		if t == ValueTypeString {
+			return t.ValueFrom(fmt.Sprint(v))
+		}
+		return t.ValueFrom(v)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
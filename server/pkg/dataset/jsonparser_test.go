@@ -0,0 +1,77 @@
+package dataset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONParser_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		sources []string
+		want    []map[string]any
+		err     error
+	}{
+		{
+			name: "GeoJSON",
+			content: `{"type":"FeatureCollection","features":[
+				{"type":"Feature","id":"a","geometry":{"type":"Point","coordinates":[139.7,35.6]},"properties":{"name":"Tokyo","pop":null}},
+				{"type":"Feature","geometry":{"type":"LineString","coordinates":[[0,0],[1,1]]},"properties":{"name":"line","pop":2,"tags":["x"]}}
+			]}`,
+			sources: []string{"a", "2"},
+			want: []map[string]any{
+				{"name": "Tokyo", "location": LatLng{Lat: 35.6, Lng: 139.7}},
+				{"name": "line", "pop": 2.0, "tags": `["x"]`},
+			},
+		},
+		{
+			name:    "JSON array",
+			content: `[{"id":10,"name":"a","lat":35.6,"lng":139.7,"ok":true},{"name":"b","ok":false}]`,
+			sources: []string{"10", "2"},
+			want: []map[string]any{
+				{"id": 10.0, "name": "a", "ok": true, "location": LatLng{Lat: 35.6, Lng: 139.7}},
+				{"name": "b", "ok": false},
+			},
+		},
+		{
+			name:    "invalid",
+			content: `{"type":"Point","coordinates":[0,0]}`,
+			err:     ErrFailedToParseJSONFile,
+		},
+		{
+			name:    "broken",
+			content: `[`,
+			err:     ErrFailedToParseJSONFile,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p := NewJSONParser(strings.NewReader(tc.content), "data.json")
+			p.SetSource("https://example.com/data.json")
+			schema, datasets, err := p.Parse(NewSceneID())
+			if tc.err != nil {
+				assert.Same(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "https://example.com/data.json", schema.Source())
+			assert.Equal(t, len(tc.want), len(datasets))
+			for i, ds := range datasets {
+				assert.Equal(t, tc.sources[i], ds.Source())
+				got := map[string]any{}
+				for _, f := range ds.Fields() {
+					sf := schema.Field(f.Field())
+					assert.Equal(t, sf.Name(), sf.Source())
+					got[sf.Name()] = f.Value().Interface()
+				}
+				assert.Equal(t, tc.want[i], got)
+			}
+		})
+	}
+}
@@ -62,6 +62,7 @@ func (l List) DiffBySource(l2 List) Diff {
 	removed := []*Dataset{}
 	// others := map[string]DatasetDiffTouple{}
 	others2 := map[ID]*Dataset{}
+	var changed List
 
 	s1 := map[string]*Dataset{}
 	for _, d1 := range l {
@@ -73,6 +74,9 @@ func (l List) DiffBySource(l2 List) Diff {
 			// others
 			// others[d2.Source()] = DatasetDiffTouple{Old: d1, New: d2}
 			others2[d1.ID()] = d2
+			if !d1.EqualValuesBySource(d2) {
+				changed = append(changed, d2)
+			}
 		} else {
 			// added
 			added = append(added, d2)
@@ -90,6 +94,7 @@ func (l List) DiffBySource(l2 List) Diff {
 		Added:   added,
 		Removed: removed,
 		Others:  others2,
+		Changed: changed,
 		// Others: others,
 	}
 }
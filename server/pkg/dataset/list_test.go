@@ -40,6 +40,15 @@ func TestDatasetListDiff(t *testing.T) {
 		},
 	}
 	assert.Equal(t, expected, diff)
+
+	// values are compared by field sources, since field IDs are regenerated on sync
+	fid1, fid2 := NewFieldID(), NewFieldID()
+	d6, _ := New().NewID().Scene(sid).Source(source1).Fields([]*Field{NewField(fid1, ValueTypeString.ValueFrom("a"), "name")}).Build()
+	d7, _ := New().NewID().Scene(sid).Source(source1).Fields([]*Field{NewField(fid2, ValueTypeString.ValueFrom("a"), "name")}).Build()
+	d8, _ := New().NewID().Scene(sid).Source(source2).Fields([]*Field{NewField(fid1, ValueTypeString.ValueFrom("b"), "name")}).Build()
+	d9, _ := New().NewID().Scene(sid).Source(source2).Fields([]*Field{NewField(fid2, ValueTypeString.ValueFrom("c"), "name")}).Build()
+	diff = List{d6, d8}.DiffBySource(List{d7, d9})
+	assert.Equal(t, List{d9}, diff.Changed)
 }
 
 func TestDatasetMapGraphSearchByFields(t *testing.T) {
@@ -0,0 +1,97 @@
+package dataset
+
+import (
+	"errors"
+	"time"
+)
+
+// MinRefreshInterval is the shortest interval that a schema can be refreshed at.
+const MinRefreshInterval = 5 * time.Minute
+
+var ErrInvalidRefreshInterval = errors.New("refresh interval must be 5 minutes or longer")
+
+// Refresh describes the periodic refresh of a schema that was synced from a URL.
+// When nextAt comes, the source of the schema is fetched again and the changes are applied to the linked layers.
+// If publish is true, the published projects whose layers link the schema are published again after the refresh.
+type Refresh struct {
+	interval     time.Duration
+	publish      bool
+	nextAt       time.Time
+	lastSyncedAt *time.Time
+}
+
+func NewRefresh(interval time.Duration, publish bool, now time.Time) (*Refresh, error) {
+	if interval < MinRefreshInterval {
+		return nil, ErrInvalidRefreshInterval
+	}
+	return &Refresh{
+		interval: interval,
+		publish:  publish,
+		nextAt:   now.Add(interval),
+	}, nil
+}
+
+// RefreshFrom restores a refresh from the stored state.
+func RefreshFrom(interval time.Duration, publish bool, nextAt time.Time, lastSyncedAt *time.Time) *Refresh {
+	return &Refresh{
+		interval:     interval,
+		publish:      publish,
+		nextAt:       nextAt,
+		lastSyncedAt: cloneTime(lastSyncedAt),
+	}
+}
+
+func (r *Refresh) Interval() time.Duration {
+	if r == nil {
+		return 0
+	}
+	return r.interval
+}
+
+func (r *Refresh) Publish() bool {
+	if r == nil {
+		return false
+	}
+	return r.publish
+}
+
+func (r *Refresh) NextAt() *time.Time {
+	if r == nil {
+		return nil
+	}
+	t := r.nextAt
+	return &t
+}
+
+func (r *Refresh) LastSyncedAt() *time.Time {
+	if r == nil {
+		return nil
+	}
+	return cloneTime(r.lastSyncedAt)
+}
+
+// Due returns true if the schema should be refreshed at now.
+func (r *Refresh) Due(now time.Time) bool {
+	return r != nil && !r.nextAt.After(now)
+}
+
+// Advance returns a new refresh that is synced at now and will be fired after the interval.
+func (r *Refresh) Advance(now time.Time) *Refresh {
+	if r == nil {
+		return nil
+	}
+	return &Refresh{
+		interval:     r.interval,
+		publish:      r.publish,
+		nextAt:       now.Add(r.interval),
+		lastSyncedAt: &now,
+	}
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	t2 := *t
+	return &t2
+}
@@ -0,0 +1,60 @@
+package dataset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRefresh(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		want     *Refresh
+		wantErr  error
+	}{
+		{
+			name:     "hourly",
+			interval: time.Hour,
+			want:     &Refresh{interval: time.Hour, publish: true, nextAt: now.Add(time.Hour)},
+		},
+		{
+			name:     "too short",
+			interval: time.Minute,
+			wantErr:  ErrInvalidRefreshInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewRefresh(tt.interval, true, now)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRefresh_Due(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r, _ := NewRefresh(time.Hour, false, now)
+
+	assert.False(t, r.Due(now))
+	assert.True(t, r.Due(now.Add(time.Hour)))
+	assert.False(t, (*Refresh)(nil).Due(now))
+}
+
+func TestRefresh_Advance(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(90 * time.Minute)
+	r, _ := NewRefresh(time.Hour, true, now)
+
+	got := r.Advance(later)
+	assert.Equal(t, &Refresh{interval: time.Hour, publish: true, nextAt: later.Add(time.Hour), lastSyncedAt: &later}, got)
+	assert.Nil(t, r.LastSyncedAt())
+	assert.Nil(t, (*Refresh)(nil).Advance(later))
+}
@@ -10,6 +10,7 @@ type Schema struct {
 	order               []FieldID
 	representativeField *FieldID
 	scene               SceneID
+	refresh             *Refresh
 }
 
 func (d *Schema) ID() (i SchemaID) {
@@ -114,6 +115,23 @@ func (u *Schema) Rename(name string) {
 	u.name = name
 }
 
+// Refresh returns the periodic refresh of the schema, or nil if the schema is not refreshed automatically.
+func (d *Schema) Refresh() *Refresh {
+	if d == nil {
+		return nil
+	}
+	return d.refresh
+}
+
+func (d *Schema) SetRefresh(r *Refresh) {
+	d.refresh = r
+}
+
+// IsRemote returns true if the schema was synced from a URL or a Google Sheet.
+func (d *Schema) IsRemote() bool {
+	return IsRemoteSource(d.Source())
+}
+
 // JSONSchema prints a JSON schema for the schema
 func (d *Schema) JSONSchema() map[string]any {
 	if d == nil {
@@ -147,3 +165,23 @@ func (d *Schema) JSONSchema() map[string]any {
 	}
 	return m
 }
+
+// SelectFields returns a copy of the schema that has only the fields in the order of the IDs. Unknown IDs are ignored.
+func (d *Schema) SelectFields(ids []FieldID) *Schema {
+	if d == nil {
+		return nil
+	}
+	s := *d
+	s.fields = make(map[FieldID]*SchemaField, len(ids))
+	s.order = make([]FieldID, 0, len(ids))
+	for _, id := range ids {
+		if f := d.fields[id]; f != nil && s.fields[id] == nil {
+			s.fields[id] = f
+			s.order = append(s.order, id)
+		}
+	}
+	if s.representativeField != nil && s.fields[*s.representativeField] == nil {
+		s.representativeField = nil
+	}
+	return &s
+}
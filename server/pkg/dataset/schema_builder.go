@@ -52,6 +52,11 @@ func (b *SchemaBuilder) Source(source string) *SchemaBuilder {
 	return b
 }
 
+func (b *SchemaBuilder) Refresh(r *Refresh) *SchemaBuilder {
+	b.d.refresh = r
+	return b
+}
+
 func (b *SchemaBuilder) RepresentativeField(representativeField FieldID) *SchemaBuilder {
 	rf := representativeField
 	b.d.representativeField = &rf
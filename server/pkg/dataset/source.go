@@ -0,0 +1,42 @@
+package dataset
+
+import (
+	"net/url"
+	"strings"
+)
+
+const googleSheetScheme = "googlesheet"
+
+// GoogleSheetSource returns the source of a schema imported from a sheet of a Google Sheets file.
+func GoogleSheetSource(fileID, sheetName string) string {
+	u := url.URL{
+		Scheme: googleSheetScheme,
+		Host:   fileID,
+		Path:   "/" + sheetName,
+	}
+	return u.String()
+}
+
+// GoogleSheetFromSource returns the file ID and the sheet name of the source returned by GoogleSheetSource.
+func GoogleSheetFromSource(s string) (fileID, sheetName string, ok bool) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != googleSheetScheme || u.Host == "" {
+		return "", "", false
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), true
+}
+
+// IsRemoteSource returns true if the source is a http(s) URL or a Google Sheet, which can be fetched again.
+func IsRemoteSource(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return u.Host != ""
+	case googleSheetScheme:
+		return u.Host != ""
+	}
+	return false
+}
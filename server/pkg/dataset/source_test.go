@@ -0,0 +1,42 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoogleSheetSource(t *testing.T) {
+	s := GoogleSheetSource("1bXBDUrOgYWdHzScMiLNHRUsmNC9SUV4VFOvpqrx0Yok", "Sheet 1/a")
+	assert.Equal(t, "googlesheet://1bXBDUrOgYWdHzScMiLNHRUsmNC9SUV4VFOvpqrx0Yok/Sheet%201/a", s)
+
+	fileID, sheetName, ok := GoogleSheetFromSource(s)
+	assert.True(t, ok)
+	assert.Equal(t, "1bXBDUrOgYWdHzScMiLNHRUsmNC9SUV4VFOvpqrx0Yok", fileID)
+	assert.Equal(t, "Sheet 1/a", sheetName)
+
+	_, _, ok = GoogleSheetFromSource("https://example.com/data.csv")
+	assert.False(t, ok)
+}
+
+func TestIsRemoteSource(t *testing.T) {
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		{source: "https://example.com/data.csv", want: true},
+		{source: "http://example.com/data.csv", want: true},
+		{source: "googlesheet://file/sheet", want: true},
+		{source: "file:///data.csv", want: false},
+		{source: "data.csv", want: false},
+		{source: "", want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.source, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, IsRemoteSource(tt.source))
+		})
+	}
+}
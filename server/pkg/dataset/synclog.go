@@ -0,0 +1,141 @@
+package dataset
+
+import "time"
+
+// SyncLog is a record of a sync of a schema from its source. It is kept per source rather than per schema,
+// because the schema is replaced with a new one every time it is synced.
+type SyncLog struct {
+	id       SyncLogID
+	scene    SceneID
+	schema   *SchemaID
+	source   string
+	auto     bool
+	added    int
+	removed  int
+	changed  int
+	err      string
+	syncedAt time.Time
+}
+
+func (l *SyncLog) ID() SyncLogID {
+	return l.id
+}
+
+func (l *SyncLog) Scene() SceneID {
+	return l.scene
+}
+
+// Schema returns the schema created by the sync. It is nil if the sync failed.
+func (l *SyncLog) Schema() *SchemaID {
+	return l.schema.CloneRef()
+}
+
+func (l *SyncLog) Source() string {
+	return l.source
+}
+
+// Auto returns true if the sync was run by the periodic refresh rather than by a user.
+func (l *SyncLog) Auto() bool {
+	return l.auto
+}
+
+func (l *SyncLog) Added() int {
+	return l.added
+}
+
+func (l *SyncLog) Removed() int {
+	return l.removed
+}
+
+func (l *SyncLog) Changed() int {
+	return l.changed
+}
+
+// Error returns the message of the error that the sync failed with.
+func (l *SyncLog) Error() string {
+	return l.err
+}
+
+func (l *SyncLog) Succeeded() bool {
+	return l.err == ""
+}
+
+func (l *SyncLog) SyncedAt() time.Time {
+	return l.syncedAt
+}
+
+type SyncLogList []*SyncLog
+
+type SyncLogBuilder struct {
+	l *SyncLog
+}
+
+func NewSyncLog() *SyncLogBuilder {
+	return &SyncLogBuilder{l: &SyncLog{}}
+}
+
+func (b *SyncLogBuilder) Build() (*SyncLog, error) {
+	if b.l.id.IsNil() || b.l.scene.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if b.l.syncedAt.IsZero() {
+		b.l.syncedAt = b.l.id.Timestamp()
+	}
+	return b.l, nil
+}
+
+func (b *SyncLogBuilder) MustBuild() *SyncLog {
+	l, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+func (b *SyncLogBuilder) ID(id SyncLogID) *SyncLogBuilder {
+	b.l.id = id
+	return b
+}
+
+func (b *SyncLogBuilder) NewID() *SyncLogBuilder {
+	b.l.id = NewSyncLogID()
+	return b
+}
+
+func (b *SyncLogBuilder) Scene(id SceneID) *SyncLogBuilder {
+	b.l.scene = id
+	return b
+}
+
+func (b *SyncLogBuilder) Schema(id *SchemaID) *SyncLogBuilder {
+	b.l.schema = id.CloneRef()
+	return b
+}
+
+func (b *SyncLogBuilder) Source(source string) *SyncLogBuilder {
+	b.l.source = source
+	return b
+}
+
+func (b *SyncLogBuilder) Auto(auto bool) *SyncLogBuilder {
+	b.l.auto = auto
+	return b
+}
+
+// Stats sets the numbers of the datasets that were added, removed and changed by the sync.
+func (b *SyncLogBuilder) Stats(added, removed, changed int) *SyncLogBuilder {
+	b.l.added = added
+	b.l.removed = removed
+	b.l.changed = changed
+	return b
+}
+
+func (b *SyncLogBuilder) Error(err string) *SyncLogBuilder {
+	b.l.err = err
+	return b
+}
+
+func (b *SyncLogBuilder) SyncedAt(t time.Time) *SyncLogBuilder {
+	b.l.syncedAt = t
+	return b
+}
@@ -0,0 +1,31 @@
+package dataset
+
+import (
+	"io"
+
+	"github.com/reearth/reearth/server/pkg/xlsx"
+)
+
+// NewXLSXParser returns a parser of the sheet of the workbook. The first sheet is read if the sheet name is empty.
+// The name of the schema is the name of the sheet.
+func NewXLSXParser(r io.ReaderAt, size int64, n, sheet string) (*DatasetCSVParser, error) {
+	f, err := xlsx.Open(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if sheet == "" {
+		names := f.SheetNames()
+		if len(names) == 0 {
+			return nil, xlsx.ErrSheetNotFound
+		}
+		sheet = names[0]
+	}
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	p := NewTableParser(rows, sheet)
+	p.SetSource("file:///" + n + "#" + sheet)
+	return p, nil
+}
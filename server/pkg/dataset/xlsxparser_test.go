@@ -0,0 +1,51 @@
+package dataset
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/xlsx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewXLSXParser(t *testing.T) {
+	b := &bytes.Buffer{}
+	z := zip.NewWriter(b)
+	for name, content := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Sheet1" r:id="rId1"/><sheet name="cities" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="worksheets/sheet2.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>a</t></is></c></row><row r="2"><c r="A2"><v>1</v></c></row></sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>name</t></is></c><c r="B1" t="inlineStr"><is><t>Latitude</t></is></c><c r="C1" t="inlineStr"><is><t>Longitude</t></is></c></row>
+<row r="2"><c r="A2" t="inlineStr"><is><t>Tokyo</t></is></c><c r="B2"><v>35.68</v></c><c r="C2"><v>139.76</v></c></row>
+</sheetData></worksheet>`,
+	} {
+		w, _ := z.Create(name)
+		_, _ = w.Write([]byte(content))
+	}
+	assert.NoError(t, z.Close())
+	r := bytes.NewReader(b.Bytes())
+
+	p, err := NewXLSXParser(r, r.Size(), "book.xlsx", "cities")
+	assert.NoError(t, err)
+	assert.NoError(t, p.Init())
+	assert.NoError(t, p.GuessSchema(NewSceneID()))
+	schema, datasets, err := p.ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, "cities", schema.Name())
+	assert.Equal(t, "file:///book.xlsx#cities", schema.Source())
+	assert.Len(t, datasets, 1)
+	assert.Equal(t, "Tokyo", datasets[0].FieldBySource("name").Value().Interface())
+	assert.Equal(t, LatLng{Lat: 35.68, Lng: 139.76}, datasets[0].FieldBySource("location").Value().Interface())
+
+	p, err = NewXLSXParser(r, r.Size(), "book.xlsx", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1", p.name)
+
+	_, err = NewXLSXParser(r, r.Size(), "book.xlsx", "none")
+	assert.Same(t, xlsx.ErrSheetNotFound, err)
+}
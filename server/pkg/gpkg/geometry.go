@@ -0,0 +1,277 @@
+package gpkg
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidGeometry = errors.New("invalid geometry")
+
+const (
+	GeometryTypePoint           = "Point"
+	GeometryTypeLineString      = "LineString"
+	GeometryTypePolygon         = "Polygon"
+	GeometryTypeMultiPoint      = "MultiPoint"
+	GeometryTypeMultiLineString = "MultiLineString"
+	GeometryTypeMultiPolygon    = "MultiPolygon"
+)
+
+var wkbTypes = map[uint32]string{
+	1: GeometryTypePoint,
+	2: GeometryTypeLineString,
+	3: GeometryTypePolygon,
+	4: GeometryTypeMultiPoint,
+	5: GeometryTypeMultiLineString,
+	6: GeometryTypeMultiPolygon,
+}
+
+// Geometry is a simple feature geometry. Positions are [x, y] or [x, y, z].
+type Geometry struct {
+	Type string
+	// Positions are the positions of a point or a line string.
+	Positions [][]float64
+	// Rings are the rings of a polygon.
+	Rings [][][]float64
+	// Parts are the geometries of a multi geometry.
+	Parts []*Geometry
+}
+
+// Single returns the geometry itself, or the only part of a multi geometry. It returns nil if a multi geometry has several parts.
+func (g *Geometry) Single() *Geometry {
+	if g == nil || len(g.Parts) == 0 && !strings.HasPrefix(g.Type, "Multi") {
+		return g
+	}
+	if len(g.Parts) == 1 {
+		return g.Parts[0]
+	}
+	return nil
+}
+
+// WKT returns the geometry as WKT such as "POINT Z (139.7 35.6 10)".
+func (g *Geometry) WKT() string {
+	var b strings.Builder
+	b.WriteString(strings.ToUpper(g.Type))
+	if g.hasZ() {
+		b.WriteString(" Z")
+	}
+	b.WriteString(" ")
+	g.writeWKTBody(&b)
+	return b.String()
+}
+
+func (g *Geometry) writeWKTBody(b *strings.Builder) {
+	b.WriteString("(")
+	switch g.Type {
+	case GeometryTypePoint, GeometryTypeLineString:
+		writeWKTPositions(b, g.Positions)
+	case GeometryTypePolygon:
+		for i, r := range g.Rings {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("(")
+			writeWKTPositions(b, r)
+			b.WriteString(")")
+		}
+	default:
+		for i, p := range g.Parts {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			p.writeWKTBody(b)
+		}
+	}
+	b.WriteString(")")
+}
+
+func writeWKTPositions(b *strings.Builder, positions [][]float64) {
+	for i, p := range positions {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		for j, c := range p {
+			if j > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(strconv.FormatFloat(c, 'f', -1, 64))
+		}
+	}
+}
+
+func (g *Geometry) hasZ() bool {
+	for _, p := range g.Positions {
+		if len(p) > 2 {
+			return true
+		}
+	}
+	for _, r := range g.Rings {
+		for _, p := range r {
+			if len(p) > 2 {
+				return true
+			}
+		}
+	}
+	for _, p := range g.Parts {
+		if p.hasZ() {
+			return true
+		}
+	}
+	return false
+}
+
+// parseGeometry parses a GeoPackage geometry blob, which is a header followed by WKB. It returns nil for empty geometries.
+func parseGeometry(b []byte) (*Geometry, error) {
+	if len(b) < 8 || b[0] != 'G' || b[1] != 'P' {
+		return nil, ErrInvalidGeometry
+	}
+	flags := b[3]
+	if flags&0x10 != 0 {
+		// empty geometry
+		return nil, nil
+	}
+	envelope := map[byte]int{0: 0, 1: 32, 2: 48, 3: 48, 4: 64}
+	size, ok := envelope[(flags>>1)&0x07]
+	if !ok || len(b) < 8+size {
+		return nil, ErrInvalidGeometry
+	}
+	r := &wkbReader{b: b[8+size:]}
+	g := r.geometry(0)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if g.Type == GeometryTypePoint && len(g.Positions) == 0 {
+		// empty point whose coordinates are NaN
+		return nil, nil
+	}
+	return g, nil
+}
+
+type wkbReader struct {
+	b   []byte
+	err error
+}
+
+func (r *wkbReader) read(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < n {
+		r.err = ErrInvalidGeometry
+		return nil
+	}
+	res := r.b[:n]
+	r.b = r.b[n:]
+	return res
+}
+
+func (r *wkbReader) geometry(depth int) *Geometry {
+	if depth > 2 {
+		r.err = ErrInvalidGeometry
+		return nil
+	}
+	bo := r.read(1)
+	if bo == nil {
+		return nil
+	}
+	var order binary.ByteOrder = binary.BigEndian
+	if bo[0] == 1 {
+		order = binary.LittleEndian
+	}
+	tb := r.read(4)
+	if tb == nil {
+		return nil
+	}
+
+	t := order.Uint32(tb)
+	// EWKB flags
+	hasZ, hasM := t&0x80000000 != 0, t&0x40000000 != 0
+	t &= 0x0fffffff
+	// ISO WKB Z, M and ZM types
+	switch t / 1000 {
+	case 1:
+		hasZ = true
+	case 2:
+		hasM = true
+	case 3:
+		hasZ, hasM = true, true
+	}
+	d := dims{z: hasZ, m: hasM}
+	typ, ok := wkbTypes[t%1000]
+	if !ok {
+		r.err = ErrInvalidGeometry
+		return nil
+	}
+
+	g := &Geometry{Type: typ}
+	switch typ {
+	case GeometryTypePoint:
+		p := r.position(order, d)
+		if p != nil && !math.IsNaN(p[0]) {
+			g.Positions = [][]float64{p}
+		}
+	case GeometryTypeLineString:
+		g.Positions = r.positions(order, d)
+	case GeometryTypePolygon:
+		n := r.count(order)
+		for i := 0; i < n && r.err == nil; i++ {
+			g.Rings = append(g.Rings, r.positions(order, d))
+		}
+	default:
+		n := r.count(order)
+		for i := 0; i < n && r.err == nil; i++ {
+			g.Parts = append(g.Parts, r.geometry(depth+1))
+		}
+	}
+	return g
+}
+
+func (r *wkbReader) count(order binary.ByteOrder) int {
+	b := r.read(4)
+	if b == nil {
+		return 0
+	}
+	n := int(order.Uint32(b))
+	// each element has at least 8 bytes
+	if n > len(r.b)/8+1 {
+		r.err = ErrInvalidGeometry
+		return 0
+	}
+	return n
+}
+
+func (r *wkbReader) positions(order binary.ByteOrder, d dims) [][]float64 {
+	n := r.count(order)
+	res := make([][]float64, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		res = append(res, r.position(order, d))
+	}
+	return res
+}
+
+// dims is whether positions have Z and M values.
+type dims struct {
+	z, m bool
+}
+
+// position reads a position and drops its M value.
+func (r *wkbReader) position(order binary.ByteOrder, d dims) []float64 {
+	n := 2
+	if d.z {
+		n++
+	}
+	if d.m {
+		n++
+	}
+	b := r.read(8 * n)
+	if b == nil {
+		return nil
+	}
+	p := make([]float64, 0, 3)
+	for i := 0; i < 2 || i == 2 && d.z; i++ {
+		p = append(p, math.Float64frombits(order.Uint64(b[i*8:])))
+	}
+	return p
+}
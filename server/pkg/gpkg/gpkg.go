@@ -0,0 +1,188 @@
+// Package gpkg reads feature tables of GeoPackage files with a pure Go reader of SQLite databases,
+// so that they can be read without cgo or any external libraries.
+// See https://www.geopackage.org/spec/
+package gpkg
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidGeoPackage = errors.New("invalid geopackage")
+	ErrTableNotFound     = errors.New("feature table not found")
+)
+
+type File struct {
+	db     *database
+	tables map[string]*table
+	layers []*Layer
+}
+
+// Layer is a feature table.
+type Layer struct {
+	// Name is the name of the table.
+	Name           string
+	Identifier     string
+	GeometryColumn string
+	// GeometryType is the geometry type name such as POINT, LINESTRING and GEOMETRY.
+	GeometryType string
+	// SRS is the spatial reference system of the geometries. It is nil if the SRS is undefined.
+	SRS *SRS
+	// Columns are the columns of attributes, which do not include the geometry column, in the order of the table.
+	Columns []Column
+}
+
+type Column struct {
+	Name string
+	// Type is the declared type of the column such as INTEGER, REAL, TEXT and BOOLEAN.
+	Type       string
+	PrimaryKey bool
+}
+
+type SRS struct {
+	ID           int
+	Organization string
+	// Code is the code of the SRS in the organization such as 4326 of EPSG.
+	Code int
+}
+
+// Feature is a row of a feature table.
+type Feature struct {
+	Properties map[string]any
+	// Geometry is nil if the geometry is null or empty.
+	Geometry *Geometry
+}
+
+// Open reads the tables of the GeoPackage file.
+func Open(r io.ReaderAt, size int64) (*File, error) {
+	db, err := openDatabase(r, size)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := db.tables()
+	if err != nil {
+		return nil, err
+	}
+	f := &File{db: db, tables: tables}
+
+	for _, name := range []string{"gpkg_contents", "gpkg_geometry_columns", "gpkg_spatial_ref_sys"} {
+		if tables[name] == nil {
+			return nil, ErrInvalidGeoPackage
+		}
+	}
+
+	srs := map[int]*SRS{}
+	err = db.rows(tables["gpkg_spatial_ref_sys"], func(v map[string]any) error {
+		s := &SRS{
+			ID:           int(integer(v["srs_id"])),
+			Organization: str(v["organization"]),
+			Code:         int(integer(v["organization_coordsys_id"])),
+		}
+		srs[s.ID] = s
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	geometryColumns := map[string]map[string]any{}
+	err = db.rows(tables["gpkg_geometry_columns"], func(v map[string]any) error {
+		geometryColumns[strings.ToLower(str(v["table_name"]))] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.rows(tables["gpkg_contents"], func(v map[string]any) error {
+		if str(v["data_type"]) != "features" {
+			return nil
+		}
+		name := str(v["table_name"])
+		t, gc := tables[strings.ToLower(name)], geometryColumns[strings.ToLower(name)]
+		if t == nil || gc == nil {
+			return nil
+		}
+
+		l := &Layer{
+			Name:           name,
+			Identifier:     str(v["identifier"]),
+			GeometryColumn: str(gc["column_name"]),
+			GeometryType:   strings.ToUpper(str(gc["geometry_type_name"])),
+		}
+		if s := srs[int(integer(gc["srs_id"]))]; s != nil && s.Code > 0 {
+			l.SRS = s
+		}
+		for _, c := range t.columns {
+			if strings.EqualFold(c.name, l.GeometryColumn) {
+				continue
+			}
+			l.Columns = append(l.Columns, Column{Name: c.name, Type: c.typ, PrimaryKey: c.rowidKey})
+		}
+		f.layers = append(f.layers, l)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(f.layers, func(i, j int) bool {
+		return f.layers[i].Name < f.layers[j].Name
+	})
+	return f, nil
+}
+
+// Layers returns the feature tables in the order of their names.
+func (f *File) Layers() []*Layer {
+	return append([]*Layer{}, f.layers...)
+}
+
+// Features reads all rows of the feature table.
+func (f *File) Features(l *Layer) ([]*Feature, error) {
+	t := f.tables[strings.ToLower(l.Name)]
+	if t == nil {
+		return nil, ErrTableNotFound
+	}
+
+	var res []*Feature
+	err := f.db.rows(t, func(v map[string]any) error {
+		feature := &Feature{Properties: map[string]any{}}
+		for k, p := range v {
+			if !strings.EqualFold(k, l.GeometryColumn) {
+				feature.Properties[k] = p
+				continue
+			}
+			if b, ok := p.([]byte); ok {
+				g, err := parseGeometry(b)
+				if err != nil {
+					return err
+				}
+				feature.Geometry = g
+			}
+		}
+		res = append(res, feature)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func integer(v any) int64 {
+	switch i := v.(type) {
+	case int64:
+		return i
+	case float64:
+		return int64(i)
+	}
+	return 0
+}
@@ -0,0 +1,216 @@
+package gpkg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testdata/test.gpkg is a GeoPackage whose page size is 512 bytes so that tables have interior pages and overflow pages.
+// It has the following tables:
+//   - cities: 200 points in EPSG:4326 with name, pop, area, capital and note. The 1st point has Z and a long note,
+//     the 2nd point is big-endian, the 3rd geometry is null and the 4th geometry is empty.
+//   - roads: multi line strings in EPSG:6677 with "road name". The 1st one has a line string and the 2nd one has two.
+//   - attrs: an attribute table that is not a feature table.
+func openTestFile(t *testing.T) *File {
+	t.Helper()
+	b, err := os.ReadFile("testdata/test.gpkg")
+	assert.NoError(t, err)
+	f, err := Open(bytes.NewReader(b), int64(len(b)))
+	assert.NoError(t, err)
+	return f
+}
+
+func TestOpen(t *testing.T) {
+	f := openTestFile(t)
+	layers := f.Layers()
+	assert.Equal(t, []*Layer{
+		{
+			Name:           "cities",
+			Identifier:     "Cities",
+			GeometryColumn: "geom",
+			GeometryType:   "POINT",
+			SRS:            &SRS{ID: 4326, Organization: "EPSG", Code: 4326},
+			Columns: []Column{
+				{Name: "fid", Type: "INTEGER", PrimaryKey: true},
+				{Name: "name", Type: "TEXT"},
+				{Name: "pop", Type: "INTEGER"},
+				{Name: "area", Type: "REAL"},
+				{Name: "capital", Type: "BOOLEAN"},
+				{Name: "note", Type: "TEXT"},
+			},
+		},
+		{
+			Name:           "roads",
+			Identifier:     "Roads",
+			GeometryColumn: "geom",
+			GeometryType:   "MULTILINESTRING",
+			SRS:            &SRS{ID: 6677, Organization: "EPSG", Code: 6677},
+			Columns: []Column{
+				{Name: "fid", Type: "INTEGER", PrimaryKey: true},
+				{Name: "road name", Type: "TEXT"},
+			},
+		},
+	}, layers)
+
+	_, err := Open(bytes.NewReader([]byte("hoge")), 4)
+	assert.Same(t, ErrInvalidDatabase, err)
+}
+
+func TestFile_Features(t *testing.T) {
+	f := openTestFile(t)
+	layers := f.Layers()
+
+	cities, err := f.Features(layers[0])
+	assert.NoError(t, err)
+	assert.Len(t, cities, 200)
+	assert.Equal(t, map[string]any{
+		"fid":     int64(1),
+		"name":    "city1",
+		"pop":     int64(1000),
+		"area":    1.5,
+		"capital": int64(1),
+		"note":    string(bytes.Repeat([]byte("x"), 3000)),
+	}, cities[0].Properties)
+	assert.Equal(t, &Geometry{Type: GeometryTypePoint, Positions: [][]float64{{139.76, 35.68, 40}}}, cities[0].Geometry)
+	assert.Equal(t, &Geometry{Type: GeometryTypePoint, Positions: [][]float64{{135.5, 34.69}}}, cities[1].Geometry)
+	assert.Nil(t, cities[2].Geometry)
+	assert.Nil(t, cities[3].Geometry)
+	assert.Equal(t, int64(200), cities[199].Properties["fid"])
+	assert.Equal(t, "city200", cities[199].Properties["name"])
+	assert.Nil(t, cities[199].Properties["note"])
+	assert.InDelta(t, 139.2, cities[199].Geometry.Positions[0][0], 1e-9)
+
+	roads, err := f.Features(layers[1])
+	assert.NoError(t, err)
+	assert.Len(t, roads, 2)
+	assert.Equal(t, "main", roads[0].Properties["road name"])
+	assert.Equal(t, "MULTILINESTRING ((0 0, 100 100))", roads[0].Geometry.WKT())
+	assert.Equal(t, "LINESTRING (0 0, 100 100)", roads[0].Geometry.Single().WKT())
+	assert.Nil(t, roads[1].Geometry.Single())
+}
+
+func TestParseGeometry(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		want    *Geometry
+		wantErr error
+	}{
+		{
+			name: "polygon z",
+			input: []byte{
+				'G', 'P', 0, 0x01, 0, 0, 0, 0,
+				// little endian, ISO polygon Z, 1 ring, 4 positions
+				1, 0xeb, 0x03, 0, 0, 1, 0, 0, 0, 4, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			},
+			want: &Geometry{Type: GeometryTypePolygon, Rings: [][][]float64{{{0, 0, 0}, {1, 0, 0}, {1, 1, 2}, {0, 0, 0}}}},
+		},
+		{
+			name: "point m",
+			input: []byte{
+				'G', 'P', 0, 0x01, 0, 0, 0, 0,
+				// little endian, EWKB point with M
+				1, 1, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0x08, 0x40,
+			},
+			want: &Geometry{Type: GeometryTypePoint, Positions: [][]float64{{1, 2}}},
+		},
+		{
+			name:    "not geopackage",
+			input:   []byte{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "truncated",
+			input:   []byte{'G', 'P', 0, 0x01, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0},
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "unsupported type",
+			input:   []byte{'G', 'P', 0, 0x01, 0, 0, 0, 0, 1, 7, 0, 0, 0, 0, 0, 0, 0},
+			wantErr: ErrInvalidGeometry,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseGeometry(tt.input)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVarint(t *testing.T) {
+	tests := []struct {
+		input []byte
+		want  int64
+		n     int
+	}{
+		{input: []byte{0x05}, want: 5, n: 1},
+		{input: []byte{0x81, 0x00}, want: 128, n: 2},
+		{input: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, want: -1, n: 9},
+	}
+
+	for _, tt := range tests {
+		got, n := varint(tt.input)
+		assert.Equal(t, tt.want, got)
+		assert.Equal(t, tt.n, n)
+	}
+}
+
+func TestDatabase_BrokenCell(t *testing.T) {
+	// newDatabase returns a database of 4 pages of 512 bytes whose 1st page is a table leaf page with a cell at 150
+	newDatabase := func(cell []byte) []byte {
+		b := make([]byte, 512*4)
+		copy(b, sqliteMagic)
+		binary.BigEndian.PutUint16(b[16:], 512)
+		binary.BigEndian.PutUint32(b[56:], 1)
+		b[100] = pageLeafTable
+		binary.BigEndian.PutUint16(b[103:], 1)
+		binary.BigEndian.PutUint16(b[108:], 150)
+		copy(b[150:], cell)
+		return b
+	}
+	scan := func(b []byte) error {
+		d, err := openDatabase(bytes.NewReader(b), int64(len(b)))
+		assert.NoError(t, err)
+		return d.scan(1, func(int64, []any) error { return nil })
+	}
+
+	// the payload size is larger than the file
+	assert.Same(t, ErrInvalidDatabase, scan(newDatabase([]byte{0x84, 0x80, 0x80, 0x00, 0x01})))
+
+	// the overflow pages refer to each other: 2 -> 3 -> 2
+	// the payload of 1800 bytes has 276 bytes in the cell and 508 bytes in each of 3 overflow pages
+	b := newDatabase([]byte{0x8e, 0x08, 0x01})
+	binary.BigEndian.PutUint32(b[150+3+276:], 2)
+	binary.BigEndian.PutUint32(b[512:], 3)
+	binary.BigEndian.PutUint32(b[512*2:], 2)
+	assert.Same(t, ErrInvalidDatabase, scan(b))
+
+	// the page refers to itself as a child
+	b = newDatabase(nil)
+	b[100] = pageInteriorTable
+	binary.BigEndian.PutUint16(b[103:], 0)
+	binary.BigEndian.PutUint32(b[108:], 1)
+	assert.Same(t, ErrInvalidDatabase, scan(b))
+}
+
+func TestParseColumns(t *testing.T) {
+	assert.Equal(t, []column{
+		{name: "id", typ: "INTEGER", rowidKey: true},
+		{name: "a b", typ: "TEXT"},
+		{name: "c", typ: "NUMERIC(10,2)"},
+		{name: "d"},
+	}, parseColumns("CREATE TABLE t (id INTEGER NOT NULL PRIMARY KEY, [a b] TEXT DEFAULT ',', `c` NUMERIC(10,2), \"d\", UNIQUE (c, d))"))
+}
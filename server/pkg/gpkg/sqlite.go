@@ -0,0 +1,391 @@
+package gpkg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strings"
+)
+
+var ErrInvalidDatabase = errors.New("invalid sqlite database")
+
+var sqliteMagic = []byte("SQLite format 3\x00")
+
+const (
+	pageInteriorTable = 0x05
+	pageLeafTable     = 0x0d
+)
+
+// database is a read-only reader of SQLite database files, which supports only rowid tables of UTF-8 databases.
+// See https://www.sqlite.org/fileformat.html
+type database struct {
+	r          io.ReaderAt
+	pageSize   int
+	usableSize int
+	pageCount  int
+}
+
+// table is a rowid table in sqlite_master.
+type table struct {
+	name     string
+	rootPage int
+	columns  []column
+}
+
+type column struct {
+	name     string
+	typ      string
+	rowidKey bool
+}
+
+func openDatabase(r io.ReaderAt, size int64) (*database, error) {
+	h := make([]byte, 100)
+	if _, err := r.ReadAt(h, 0); err != nil || !bytes.Equal(h[:16], sqliteMagic) {
+		return nil, ErrInvalidDatabase
+	}
+	pageSize := int(binary.BigEndian.Uint16(h[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, ErrInvalidDatabase
+	}
+	// 1: UTF-8
+	if enc := binary.BigEndian.Uint32(h[56:60]); enc != 1 && enc != 0 {
+		return nil, ErrInvalidDatabase
+	}
+	return &database{
+		r:          r,
+		pageSize:   pageSize,
+		usableSize: pageSize - int(h[20]),
+		pageCount:  int(size / int64(pageSize)),
+	}, nil
+}
+
+func (d *database) page(n int) ([]byte, error) {
+	if n < 1 || n > d.pageCount {
+		return nil, ErrInvalidDatabase
+	}
+	p := make([]byte, d.pageSize)
+	if _, err := d.r.ReadAt(p, int64(n-1)*int64(d.pageSize)); err != nil {
+		return nil, ErrInvalidDatabase
+	}
+	return p, nil
+}
+
+// tables reads sqlite_master and returns the rowid tables.
+func (d *database) tables() (map[string]*table, error) {
+	res := map[string]*table{}
+	err := d.scan(1, func(_ int64, values []any) error {
+		// type, name, tbl_name, rootpage, sql
+		if len(values) < 5 || values[0] != "table" {
+			return nil
+		}
+		name, _ := values[1].(string)
+		root, _ := values[3].(int64)
+		sql, _ := values[4].(string)
+		if root == 0 || strings.Contains(strings.ToUpper(sql), "WITHOUT ROWID") {
+			return nil
+		}
+		res[strings.ToLower(name)] = &table{
+			name:     name,
+			rootPage: int(root),
+			columns:  parseColumns(sql),
+		}
+		return nil
+	})
+	return res, err
+}
+
+// rows reads all rows of the table. Values are nil, int64, float64, string or []byte.
+func (d *database) rows(t *table, f func(values map[string]any) error) error {
+	return d.scan(t.rootPage, func(rowid int64, values []any) error {
+		row := make(map[string]any, len(t.columns))
+		for i, c := range t.columns {
+			if c.rowidKey {
+				row[c.name] = rowid
+			} else if i < len(values) {
+				row[c.name] = values[i]
+			} else {
+				row[c.name] = nil
+			}
+		}
+		return f(row)
+	})
+}
+
+// scan walks the table b-tree from the root page and calls f with records in the order of rowids.
+func (d *database) scan(root int, f func(rowid int64, values []any) error) error {
+	return d.scanPage(root, 0, map[int]struct{}{}, f)
+}
+
+// scanPage reads each page only once, as pages of a broken file may refer to each other.
+func (d *database) scanPage(n, depth int, visited map[int]struct{}, f func(rowid int64, values []any) error) error {
+	if depth > 64 {
+		return ErrInvalidDatabase
+	}
+	if _, ok := visited[n]; ok {
+		return ErrInvalidDatabase
+	}
+	visited[n] = struct{}{}
+	p, err := d.page(n)
+	if err != nil {
+		return err
+	}
+	h := p
+	if n == 1 {
+		h = p[100:]
+	}
+	if len(h) < 8 {
+		return ErrInvalidDatabase
+	}
+	cells := int(binary.BigEndian.Uint16(h[3:5]))
+	switch h[0] {
+	case pageInteriorTable:
+		if len(h) < 12+cells*2 {
+			return ErrInvalidDatabase
+		}
+		for i := 0; i < cells; i++ {
+			off := int(binary.BigEndian.Uint16(h[12+i*2:]))
+			if off+4 > len(p) {
+				return ErrInvalidDatabase
+			}
+			if err := d.scanPage(int(binary.BigEndian.Uint32(p[off:])), depth+1, visited, f); err != nil {
+				return err
+			}
+		}
+		return d.scanPage(int(binary.BigEndian.Uint32(h[8:12])), depth+1, visited, f)
+	case pageLeafTable:
+		if len(h) < 8+cells*2 {
+			return ErrInvalidDatabase
+		}
+		for i := 0; i < cells; i++ {
+			off := int(binary.BigEndian.Uint16(h[8+i*2:]))
+			rowid, payload, err := d.leafCell(p, off)
+			if err != nil {
+				return err
+			}
+			values, err := parseRecord(payload)
+			if err != nil {
+				return err
+			}
+			if err := f(rowid, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ErrInvalidDatabase
+}
+
+// leafCell reads the rowid and the payload of a cell of a table leaf page including overflow pages.
+func (d *database) leafCell(p []byte, off int) (int64, []byte, error) {
+	if off >= len(p) {
+		return 0, nil, ErrInvalidDatabase
+	}
+	size, n := varint(p[off:])
+	off += n
+	if off >= len(p) {
+		return 0, nil, ErrInvalidDatabase
+	}
+	rowid, n := varint(p[off:])
+	off += n
+	// the size is not trusted until the payload is read, so it is limited to what the file can hold
+	if size < 0 || size > math.MaxInt32 || size > int64(d.pageCount)*int64(d.usableSize) {
+		return 0, nil, ErrInvalidDatabase
+	}
+
+	total := int(size)
+	u := d.usableSize
+	x := u - 35
+	local := total
+	if total > x {
+		m := (u-12)*32/255 - 23
+		k := m + (total-m)%(u-4)
+		if k <= x {
+			local = k
+		} else {
+			local = m
+		}
+	}
+	if off+local > len(p) {
+		return 0, nil, ErrInvalidDatabase
+	}
+	payload := make([]byte, 0, total)
+	payload = append(payload, p[off:off+local]...)
+	if local == total {
+		return rowid, payload, nil
+	}
+
+	if off+local+4 > len(p) {
+		return 0, nil, ErrInvalidDatabase
+	}
+	next := int(binary.BigEndian.Uint32(p[off+local:]))
+	visited := map[int]struct{}{}
+	for len(payload) < total {
+		if _, ok := visited[next]; ok {
+			return 0, nil, ErrInvalidDatabase
+		}
+		visited[next] = struct{}{}
+		op, err := d.page(next)
+		if err != nil {
+			return 0, nil, err
+		}
+		next = int(binary.BigEndian.Uint32(op))
+		payload = append(payload, op[4:min(u, 4+total-len(payload))]...)
+		if next == 0 && len(payload) < total {
+			return 0, nil, ErrInvalidDatabase
+		}
+	}
+	return rowid, payload, nil
+}
+
+func parseRecord(b []byte) ([]any, error) {
+	hsize, n := varint(b)
+	if hsize < int64(n) || hsize > int64(len(b)) {
+		return nil, ErrInvalidDatabase
+	}
+	var types []int64
+	for i := n; i < int(hsize); {
+		t, n := varint(b[i:])
+		types = append(types, t)
+		i += n
+	}
+
+	values := make([]any, 0, len(types))
+	body := b[hsize:]
+	for _, t := range types {
+		var v any
+		size := 0
+		switch {
+		case t == 0:
+		case t >= 1 && t <= 6:
+			size = []int{1, 2, 3, 4, 6, 8}[t-1]
+			if len(body) < size {
+				return nil, ErrInvalidDatabase
+			}
+			// big-endian two's complement integers
+			var x int64
+			if body[0]&0x80 != 0 {
+				x = -1
+			}
+			for _, c := range body[:size] {
+				x = x<<8 | int64(c)
+			}
+			v = x
+		case t == 7:
+			size = 8
+			if len(body) < size {
+				return nil, ErrInvalidDatabase
+			}
+			v = math.Float64frombits(binary.BigEndian.Uint64(body))
+		case t == 8:
+			v = int64(0)
+		case t == 9:
+			v = int64(1)
+		case t >= 12:
+			size = int((t - 12) / 2)
+			if len(body) < size {
+				return nil, ErrInvalidDatabase
+			}
+			if t%2 == 0 {
+				v = append([]byte{}, body[:size]...)
+			} else {
+				v = string(body[:size])
+			}
+		default:
+			return nil, ErrInvalidDatabase
+		}
+		body = body[size:]
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// varint decodes a variable-length integer of SQLite, which is big-endian unlike the one of encoding/binary.
+func varint(b []byte) (int64, int) {
+	var v uint64
+	for i := 0; i < 9 && i < len(b); i++ {
+		if i == 8 {
+			return int64(v<<8 | uint64(b[i])), 9
+		}
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return int64(v), i + 1
+		}
+	}
+	return int64(v), max(len(b), 1)
+}
+
+// parseColumns parses column definitions in a CREATE TABLE statement.
+func parseColumns(sql string) []column {
+	open, end := strings.Index(sql, "("), strings.LastIndex(sql, ")")
+	if open < 0 || end < open {
+		return nil
+	}
+
+	var defs []string
+	depth, start := 0, open+1
+	quote := rune(0)
+	for i, c := range sql[open+1 : end] {
+		switch {
+		case quote != 0:
+			if c == quote || quote == '[' && c == ']' {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`' || c == '[':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			defs = append(defs, sql[start:open+1+i])
+			start = open + 2 + i
+		}
+	}
+	defs = append(defs, sql[start:end])
+
+	var res []column
+	for _, def := range defs {
+		name, rest := splitIdentifier(strings.TrimSpace(def))
+		if name == "" {
+			continue
+		}
+		upper := strings.ToUpper(rest)
+		switch strings.ToUpper(name) {
+		case "CONSTRAINT", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN":
+			if !strings.HasPrefix(def, `"`) && !strings.HasPrefix(def, "`") && !strings.HasPrefix(def, "[") {
+				continue
+			}
+		}
+		typ := strings.Fields(upper)
+		c := column{name: name}
+		if len(typ) > 0 {
+			c.typ = typ[0]
+		}
+		c.rowidKey = c.typ == "INTEGER" && strings.Contains(upper, "PRIMARY KEY")
+		res = append(res, c)
+	}
+	return res
+}
+
+// splitIdentifier splits the first identifier, which may be quoted, and the rest.
+func splitIdentifier(s string) (string, string) {
+	if s == "" {
+		return "", ""
+	}
+	if end := map[byte]byte{'"': '"', '`': '`', '[': ']'}[s[0]]; end != 0 {
+		i := strings.IndexByte(s[1:], end)
+		if i < 0 {
+			return "", ""
+		}
+		return s[1 : i+1], s[i+2:]
+	}
+	if i := strings.IndexAny(s, " \t\r\n"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
@@ -0,0 +1,100 @@
+package history
+
+import (
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/auditlog"
+)
+
+var (
+	ErrEmptySceneID = errors.New("require scene id")
+	ErrEmptyUserID  = errors.New("require user id")
+	ErrEmptyChanges = errors.New("require changes")
+)
+
+type Builder struct {
+	e *Entry
+}
+
+func New() *Builder {
+	return &Builder{e: &Entry{}}
+}
+
+func (b *Builder) Build() (*Entry, error) {
+	if b.e.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if b.e.scene.IsNil() {
+		return nil, ErrEmptySceneID
+	}
+	if b.e.user.IsNil() {
+		return nil, ErrEmptyUserID
+	}
+	if len(b.e.changes) == 0 {
+		return nil, ErrEmptyChanges
+	}
+	if b.e.createdAt.IsZero() {
+		b.e.createdAt = b.e.id.Timestamp()
+	}
+	return b.e, nil
+}
+
+func (b *Builder) MustBuild() *Entry {
+	r, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.e.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.e.id = NewID()
+	return b
+}
+
+func (b *Builder) Scene(scene SceneID) *Builder {
+	b.e.scene = scene
+	return b
+}
+
+func (b *Builder) User(user UserID) *Builder {
+	b.e.user = user
+	return b
+}
+
+func (b *Builder) Action(action auditlog.Action) *Builder {
+	b.e.action = action
+	return b
+}
+
+// Changes sets the changes. Nil changes are skipped.
+func (b *Builder) Changes(changes ...*Change) *Builder {
+	b.e.changes = nil
+	for _, c := range changes {
+		if c != nil {
+			b.e.changes = append(b.e.changes, *c)
+		}
+	}
+	return b
+}
+
+func (b *Builder) UndoneAt(undoneAt *time.Time) *Builder {
+	if undoneAt == nil {
+		b.e.undoneAt = nil
+		return b
+	}
+	t := *undoneAt
+	b.e.undoneAt = &t
+	return b
+}
+
+func (b *Builder) CreatedAt(createdAt time.Time) *Builder {
+	b.e.createdAt = createdAt
+	return b
+}
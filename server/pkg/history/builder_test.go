@@ -0,0 +1,73 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/auditlog"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	hid := NewID()
+	sid := id.NewSceneID()
+	uid := accountdomain.NewUserID()
+	now := time.Now().Truncate(time.Millisecond)
+	p := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+
+	e, err := New().
+		ID(hid).
+		Scene(sid).
+		User(uid).
+		Action(auditlog.ActionPropertyUpdateItems).
+		Changes(PropertyChange(nil, p), PropertyChange(nil, nil)).
+		CreatedAt(now).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, hid, e.ID())
+	assert.Equal(t, sid, e.Scene())
+	assert.Equal(t, uid, e.User())
+	assert.Equal(t, auditlog.ActionPropertyUpdateItems, e.Action())
+	assert.Equal(t, []string{p.ID().String()}, e.Targets())
+	assert.Equal(t, now, e.CreatedAt())
+	assert.False(t, e.IsUndone())
+
+	e.Undo(now)
+	assert.True(t, e.IsUndone())
+	assert.Equal(t, &now, e.UndoneAt())
+	e.Redo()
+	assert.False(t, e.IsUndone())
+	assert.Nil(t, e.UndoneAt())
+
+	_, err = New().Scene(sid).User(uid).Changes(PropertyChange(nil, p)).Build()
+	assert.Equal(t, ErrInvalidID, err)
+
+	_, err = New().NewID().User(uid).Changes(PropertyChange(nil, p)).Build()
+	assert.Equal(t, ErrEmptySceneID, err)
+
+	_, err = New().NewID().Scene(sid).Changes(PropertyChange(nil, p)).Build()
+	assert.Equal(t, ErrEmptyUserID, err)
+
+	_, err = New().NewID().Scene(sid).User(uid).Changes(PropertyChange(nil, nil)).Build()
+	assert.Equal(t, ErrEmptyChanges, err)
+}
+
+func TestPropertyChange(t *testing.T) {
+	sid := id.NewSceneID()
+	p := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+
+	c := PropertyChange(p, nil)
+	assert.Equal(t, KindProperty, c.Kind())
+	assert.Equal(t, p.ID().String(), c.ID())
+	assert.Nil(t, c.After())
+
+	// snapshots are not affected by later edits
+	before := c.Before().(*property.Property)
+	assert.Equal(t, p.ID(), before.ID())
+	assert.NotSame(t, p, before)
+
+	assert.Nil(t, PropertyChange(nil, nil))
+}
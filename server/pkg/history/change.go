@@ -0,0 +1,130 @@
+package history
+
+import (
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+)
+
+type Kind string
+
+const (
+	KindProperty Kind = "property"
+	KindLayer    Kind = "layer"
+	KindNLSLayer Kind = "nlsLayer"
+	KindStory    Kind = "story"
+	KindStyle    Kind = "style"
+)
+
+// Change holds snapshots of an object before and after an edit.
+// Before is nil if the object was created by the edit, and After is nil if it was removed.
+// Snapshots are *property.Property, layer.Layer, nlslayer.NLSLayer, *storytelling.Story or *scene.Style according to the kind.
+type Change struct {
+	kind   Kind
+	id     string
+	before any
+	after  any
+}
+
+func (c Change) Kind() Kind {
+	return c.kind
+}
+
+func (c Change) ID() string {
+	return c.id
+}
+
+func (c Change) Before() any {
+	return c.before
+}
+
+func (c Change) After() any {
+	return c.after
+}
+
+// PropertyChange returns the change of the property, or nil if both are nil.
+func PropertyChange(before, after *property.Property) *Change {
+	if before == nil && after == nil {
+		return nil
+	}
+	c := &Change{kind: KindProperty}
+	if before != nil {
+		c.id = before.ID().String()
+		c.before = before.Clone()
+	}
+	if after != nil {
+		c.id = after.ID().String()
+		c.after = after.Clone()
+	}
+	return c
+}
+
+// LayerChange returns the change of the layer, or nil if both are nil.
+func LayerChange(before, after layer.Layer) *Change {
+	if before == nil && after == nil {
+		return nil
+	}
+	c := &Change{kind: KindLayer}
+	if before != nil {
+		c.id = before.ID().String()
+		c.before = layer.Clone(before)
+	}
+	if after != nil {
+		c.id = after.ID().String()
+		c.after = layer.Clone(after)
+	}
+	return c
+}
+
+// NLSLayerChange returns the change of the layer, or nil if both are nil.
+func NLSLayerChange(before, after nlslayer.NLSLayer) *Change {
+	if before == nil && after == nil {
+		return nil
+	}
+	c := &Change{kind: KindNLSLayer}
+	if before != nil {
+		c.id = before.ID().String()
+		c.before = nlslayer.Clone(before)
+	}
+	if after != nil {
+		c.id = after.ID().String()
+		c.after = nlslayer.Clone(after)
+	}
+	return c
+}
+
+// StoryChange returns the change of the story, or nil if both are nil.
+func StoryChange(before, after *storytelling.Story) *Change {
+	if before == nil && after == nil {
+		return nil
+	}
+	c := &Change{kind: KindStory}
+	if before != nil {
+		c.id = before.Id().String()
+		c.before = before.Clone()
+	}
+	if after != nil {
+		c.id = after.Id().String()
+		c.after = after.Clone()
+	}
+	return c
+}
+
+// StyleChange returns the change of the style, or nil if both are nil.
+func StyleChange(before, after *scene.Style) *Change {
+	if before == nil && after == nil {
+		return nil
+	}
+	c := &Change{kind: KindStyle}
+	if before != nil {
+		c.id = before.ID().String()
+		c.before = before.Clone()
+	}
+	if after != nil {
+		c.id = after.ID().String()
+		c.after = after.Clone()
+	}
+	return c
+}
@@ -0,0 +1,77 @@
+package history
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/auditlog"
+)
+
+// Entry is an edit of a scene made by a user, which can be undone and redone by the user.
+type Entry struct {
+	id        ID
+	scene     SceneID
+	user      UserID
+	action    auditlog.Action
+	changes   []Change
+	undoneAt  *time.Time
+	createdAt time.Time
+}
+
+func (e *Entry) ID() ID {
+	return e.id
+}
+
+func (e *Entry) Scene() SceneID {
+	return e.scene
+}
+
+func (e *Entry) User() UserID {
+	return e.user
+}
+
+// Action is the action of the edit, which is the same as the one recorded in the audit log.
+func (e *Entry) Action() auditlog.Action {
+	return e.action
+}
+
+func (e *Entry) Changes() []Change {
+	return append([]Change{}, e.changes...)
+}
+
+// Targets returns the IDs of the objects changed by the edit.
+func (e *Entry) Targets() []string {
+	res := make([]string, 0, len(e.changes))
+	for _, c := range e.changes {
+		res = append(res, c.id)
+	}
+	return res
+}
+
+func (e *Entry) IsUndone() bool {
+	return e.undoneAt != nil
+}
+
+func (e *Entry) UndoneAt() *time.Time {
+	if e.undoneAt == nil {
+		return nil
+	}
+	t := *e.undoneAt
+	return &t
+}
+
+func (e *Entry) CreatedAt() time.Time {
+	if e.createdAt.IsZero() {
+		return e.id.Timestamp()
+	}
+	return e.createdAt
+}
+
+// Undo marks the entry as undone.
+func (e *Entry) Undo(now time.Time) {
+	e.undoneAt = &now
+}
+
+// Redo marks the entry as not undone.
+func (e *Entry) Redo() {
+	e.undoneAt = nil
+}
@@ -0,0 +1,24 @@
+package history
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type ID = id.HistoryID
+type SceneID = id.SceneID
+type UserID = accountdomain.UserID
+
+var NewID = id.NewHistoryID
+var MustID = id.MustHistoryID
+var IDFrom = id.HistoryIDFrom
+var IDFromRef = id.HistoryIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
+
+func MockNewID(hid ID) func() {
+	NewID = func() ID { return hid }
+	return func() {
+		NewID = id.NewHistoryID
+	}
+}
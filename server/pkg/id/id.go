@@ -26,6 +26,11 @@ type Style struct{}
 type Infobox struct{}
 type InfoboxBlock struct{}
 type Feature struct{}
+type Revision struct{}
+type DatasetSyncLog struct{}
+type AuditLog struct{}
+type History struct{}
+type ProjectGrant struct{}
 
 func (Asset) Type() string               { return "asset" }
 func (AuthRequest) Type() string         { return "authRequest" }
@@ -51,6 +56,11 @@ func (Style) Type() string               { return "style" }
 func (Infobox) Type() string             { return "infobox" }
 func (InfoboxBlock) Type() string        { return "infoboxBlock" }
 func (Feature) Type() string             { return "feature" }
+func (Revision) Type() string            { return "revision" }
+func (DatasetSyncLog) Type() string      { return "datasetSyncLog" }
+func (AuditLog) Type() string            { return "auditLog" }
+func (History) Type() string             { return "history" }
+func (ProjectGrant) Type() string        { return "projectGrant" }
 
 type AssetID = idx.ID[Asset]
 type AuthRequestID = idx.ID[AuthRequest]
@@ -73,6 +83,11 @@ type StyleID = idx.ID[Style]
 type InfoboxID = idx.ID[Infobox]
 type InfoboxBlockID = idx.ID[InfoboxBlock]
 type FeatureID = idx.ID[Feature]
+type RevisionID = idx.ID[Revision]
+type DatasetSyncLogID = idx.ID[DatasetSyncLog]
+type AuditLogID = idx.ID[AuditLog]
+type HistoryID = idx.ID[History]
+type ProjectGrantID = idx.ID[ProjectGrant]
 
 type PluginExtensionID = idx.StringID[PluginExtension]
 type PropertySchemaGroupID = idx.StringID[PropertySchemaGroup]
@@ -97,6 +112,11 @@ var NewStyleID = idx.New[Style]
 var NewInfoboxID = idx.New[Infobox]
 var NewInfoboxBlockID = idx.New[InfoboxBlock]
 var NewFeatureID = idx.New[Feature]
+var NewRevisionID = idx.New[Revision]
+var NewDatasetSyncLogID = idx.New[DatasetSyncLog]
+var NewAuditLogID = idx.New[AuditLog]
+var NewHistoryID = idx.New[History]
+var NewProjectGrantID = idx.New[ProjectGrant]
 
 var MustAssetID = idx.Must[Asset]
 var MustAuthRequestID = idx.Must[AuthRequest]
@@ -119,6 +139,11 @@ var MustStyleID = idx.Must[Style]
 var MustInfoboxID = idx.Must[Infobox]
 var MustInfoboxBlockID = idx.Must[InfoboxBlock]
 var MustFeatureID = idx.Must[Feature]
+var MustRevisionID = idx.Must[Revision]
+var MustDatasetSyncLogID = idx.Must[DatasetSyncLog]
+var MustAuditLogID = idx.Must[AuditLog]
+var MustHistoryID = idx.Must[History]
+var MustProjectGrantID = idx.Must[ProjectGrant]
 
 var AssetIDFrom = idx.From[Asset]
 var AuthRequestIDFrom = idx.From[AuthRequest]
@@ -141,6 +166,11 @@ var StyleIDFrom = idx.From[Style]
 var InfoboxIDFrom = idx.From[Infobox]
 var InfoboxBlockIDFrom = idx.From[InfoboxBlock]
 var FeatureIDFrom = idx.From[Feature]
+var RevisionIDFrom = idx.From[Revision]
+var DatasetSyncLogIDFrom = idx.From[DatasetSyncLog]
+var AuditLogIDFrom = idx.From[AuditLog]
+var HistoryIDFrom = idx.From[History]
+var ProjectGrantIDFrom = idx.From[ProjectGrant]
 
 var AssetIDFromRef = idx.FromRef[Asset]
 var AuthRequestIDFromRef = idx.FromRef[AuthRequest]
@@ -163,6 +193,11 @@ var StyleIDFromRef = idx.FromRef[Style]
 var InfoboxIDFromRef = idx.FromRef[Infobox]
 var InfoboxBlockIDFromRef = idx.FromRef[InfoboxBlock]
 var FeatureIDFromRef = idx.FromRef[Feature]
+var RevisionIDFromRef = idx.FromRef[Revision]
+var DatasetSyncLogIDFromRef = idx.FromRef[DatasetSyncLog]
+var AuditLogIDFromRef = idx.FromRef[AuditLog]
+var HistoryIDFromRef = idx.FromRef[History]
+var ProjectGrantIDFromRef = idx.FromRef[ProjectGrant]
 
 var PluginExtensionIDFromRef = idx.StringIDFromRef[PluginExtension]
 var PropertyFieldIDFromRef = idx.StringIDFromRef[PropertyField]
@@ -189,6 +224,11 @@ type StyleIDList = idx.List[Style]
 type InfoboxIDList = idx.List[Infobox]
 type InfoboxBlockIDList = idx.List[InfoboxBlock]
 type FeatureIDList = idx.List[Feature]
+type RevisionIDList = idx.List[Revision]
+type DatasetSyncLogIDList = idx.List[DatasetSyncLog]
+type AuditLogIDList = idx.List[AuditLog]
+type HistoryIDList = idx.List[History]
+type ProjectGrantIDList = idx.List[ProjectGrant]
 
 var AssetIDListFrom = idx.ListFrom[Asset]
 var AuthRequestIDListFrom = idx.ListFrom[AuthRequest]
@@ -211,6 +251,11 @@ var StyleIDListFrom = idx.ListFrom[Style]
 var InfoboxIDListFrom = idx.ListFrom[Infobox]
 var InfoboxBlockIDListFrom = idx.ListFrom[InfoboxBlock]
 var FeatureIDListFrom = idx.ListFrom[Feature]
+var RevisionIDListFrom = idx.ListFrom[Revision]
+var DatasetSyncLogIDListFrom = idx.ListFrom[DatasetSyncLog]
+var AuditLogIDListFrom = idx.ListFrom[AuditLog]
+var HistoryIDListFrom = idx.ListFrom[History]
+var ProjectGrantIDListFrom = idx.ListFrom[ProjectGrant]
 
 type AssetIDSet = idx.Set[Asset]
 type AuthRequestIDSet = idx.Set[AuthRequest]
@@ -233,6 +278,11 @@ type StyleIDSet = idx.Set[Style]
 type InfoboxIDSet = idx.Set[Infobox]
 type InfoboxBlockIDSet = idx.Set[InfoboxBlock]
 type FeatureIDSet = idx.Set[Feature]
+type RevisionIDSet = idx.Set[Revision]
+type DatasetSyncLogIDSet = idx.Set[DatasetSyncLog]
+type AuditLogIDSet = idx.Set[AuditLog]
+type HistoryIDSet = idx.Set[History]
+type ProjectGrantIDSet = idx.Set[ProjectGrant]
 
 var NewAssetIDSet = idx.NewSet[Asset]
 var NewAuthRequestIDSet = idx.NewSet[AuthRequest]
@@ -255,6 +305,11 @@ var NewStyleIDSet = idx.NewSet[Style]
 var NewInfoboxIDSet = idx.NewSet[InfoboxBlock]
 var NewInfoboxBlockIDSet = idx.NewSet[InfoboxBlock]
 var NewFeatureIDSet = idx.NewSet[Feature]
+var NewRevisionIDSet = idx.NewSet[Revision]
+var NewDatasetSyncLogIDSet = idx.NewSet[DatasetSyncLog]
+var NewAuditLogIDSet = idx.NewSet[AuditLog]
+var NewHistoryIDSet = idx.NewSet[History]
+var NewProjectGrantIDSet = idx.NewSet[ProjectGrant]
 
 // Storytelling ids
 
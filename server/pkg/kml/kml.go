@@ -11,11 +11,34 @@ type Collection struct {
 	Name       string       `xml:"name"`
 }
 type Placemark struct {
-	Point    Point      `xml:"Point"`
-	Polygon  Polygon    `xml:"Polygon"`
-	Polyline LineString `xml:"LineString"`
-	Name     string     `xml:"name"`
-	StyleUrl string     `xml:"styleUrl"`
+	Point         Point         `xml:"Point"`
+	Polygon       Polygon       `xml:"Polygon"`
+	Polyline      LineString    `xml:"LineString"`
+	Name          string        `xml:"name"`
+	StyleUrl      string        `xml:"styleUrl"`
+	Description   string        `xml:"description"`
+	ExtendedData  ExtendedData  `xml:"ExtendedData"`
+	MultiGeometry MultiGeometry `xml:"MultiGeometry"`
+}
+type MultiGeometry struct {
+	Points    []Point      `xml:"Point"`
+	Polylines []LineString `xml:"LineString"`
+	Polygons  []Polygon    `xml:"Polygon"`
+}
+type ExtendedData struct {
+	Data       []Data       `xml:"Data"`
+	SchemaData []SchemaData `xml:"SchemaData"`
+}
+type Data struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+type SchemaData struct {
+	SimpleData []SimpleData `xml:"SimpleData"`
+}
+type SimpleData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
 }
 type BoundaryIs struct {
 	LinearRing LinearRing `xml:"LinearRing"`
@@ -1,11 +1,13 @@
 package decoding
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 
 	geojson "github.com/paulmach/go.geojson"
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/property"
 )
@@ -20,6 +22,8 @@ type GeoJSONDecoder struct {
 	features  []*geojson.Feature
 	sceneId   layer.SceneID
 	groupName string
+	crs       *crs.CRS
+	crsErr    error
 }
 
 func NewGeoJSONDecoder(r io.Reader, s layer.SceneID) *GeoJSONDecoder {
@@ -62,6 +66,7 @@ func (d *GeoJSONDecoder) Decode() (Result, error) {
 	if err != nil {
 		return Result{}, errors.New("unable to parse file content")
 	}
+	d.crs, d.crsErr = geoJSONCRS(con)
 	fc, err := geojson.UnmarshalFeatureCollection(con)
 
 	if err != nil {
@@ -104,6 +109,30 @@ func (d *GeoJSONDecoder) Decode() (Result, error) {
 	return resultFrom(lg, layers, properties)
 }
 
+// CRS returns the CRS specified by the "crs" member, which was defined in the 2008 GeoJSON specification.
+// It returns nil if the member does not exist.
+func (d *GeoJSONDecoder) CRS() (*crs.CRS, error) {
+	return d.crs, d.crsErr
+}
+
+func geoJSONCRS(content []byte) (*crs.CRS, error) {
+	var v struct {
+		CRS *struct {
+			Type       string `json:"type"`
+			Properties struct {
+				Name string `json:"name"`
+			} `json:"properties"`
+		} `json:"crs"`
+	}
+	if err := json.Unmarshal(content, &v); err != nil || v.CRS == nil {
+		return nil, nil
+	}
+	if v.CRS.Type != "name" {
+		return nil, crs.ErrUnsupportedCRS
+	}
+	return crs.Parse(v.CRS.Properties.Name)
+}
+
 func (d *GeoJSONDecoder) decodeLayer() (*layer.Item, *property.Property, error) {
 	var feat *geojson.Feature
 	var p *property.Property
@@ -0,0 +1,49 @@
+package decoding
+
+import (
+	"github.com/reearth/reearth/server/pkg/crs"
+)
+
+// crsDetector is implemented by decoders which can detect the CRS of the source data after decoding.
+type crsDetector interface {
+	CRS() (*crs.CRS, error)
+}
+
+// ReprojectDecoder decodes data with Decoder and transforms coordinates of the result from CRS into WGS84.
+// When CRS is nil, the CRS detected by Decoder is used, and coordinates are assumed to be WGS84 if it is not detected.
+type ReprojectDecoder struct {
+	Decoder Decoder
+	CRS     *crs.CRS
+}
+
+func (d ReprojectDecoder) Decode() (Result, error) {
+	r, err := d.Decoder.Decode()
+	if err != nil {
+		return Result{}, err
+	}
+
+	c := d.CRS
+	if c == nil {
+		if cd, ok := d.Decoder.(crsDetector); ok {
+			if c, err = cd.CRS(); err != nil {
+				return Result{}, err
+			}
+		}
+	}
+	if c.IsWGS84() {
+		return r, nil
+	}
+	r.Reproject(c.ToWGS84)
+	return r, nil
+}
+
+// Reproject transforms all coordinates in the properties of the result with f.
+func (r Result) Reproject(f func(x, y float64) (float64, float64)) {
+	for _, p := range r.Properties {
+		for _, field := range p.Fields(nil) {
+			if v := field.Value(); v != nil {
+				field.UpdateUnsafe(v.TransformCoordinates(f))
+			}
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package decoding
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/shp"
@@ -11,9 +16,23 @@ type ShapeReader interface {
 	Shape() (int, shp.Shape)
 	Err() error
 }
+
+// shapeAttributeReader is implemented by shape readers which can read attributes from a DBF table.
+type shapeAttributeReader interface {
+	Fields() []shp.Field
+	Attributes() map[string]any
+}
+
+// shapeProjectionReader is implemented by shape readers which can read a .prj file.
+type shapeProjectionReader interface {
+	Projection() string
+}
+
 type ShapeDecoder struct {
 	reader  ShapeReader
 	sceneId layer.SceneID
+	crs     *crs.CRS
+	crsErr  error
 }
 
 func NewShapeDecoder(r ShapeReader, s layer.SceneID) *ShapeDecoder {
@@ -23,7 +42,7 @@ func NewShapeDecoder(r ShapeReader, s layer.SceneID) *ShapeDecoder {
 	}
 }
 
-func (shd *ShapeDecoder) getLayer(t string, coords interface{}) (*layer.Item, *property.Property, error) {
+func (shd *ShapeDecoder) getLayer(t string, coords interface{}, name string, ib *layer.Infobox) (*layer.Item, *property.Property, error) {
 	var p *property.Property
 	var l *layer.Item
 	var ex layer.PluginExtensionID
@@ -36,8 +55,10 @@ func (shd *ShapeDecoder) getLayer(t string, coords interface{}) (*layer.Item, *p
 	l, err = layer.
 		NewItem().
 		NewID().
+		Name(name).
 		Scene(shd.sceneId).
 		Property(p.IDRef()).
+		Infobox(ib).
 		Extension(&ex).
 		Plugin(&layer.OfficialPluginID).
 		Build()
@@ -46,6 +67,83 @@ func (shd *ShapeDecoder) getLayer(t string, coords interface{}) (*layer.Item, *p
 	}
 	return l, p, nil
 }
+
+// attributes returns the name and the infobox which shows the attributes of the current shape.
+func (shd *ShapeDecoder) attributes() (string, *layer.Infobox, property.Map, error) {
+	ar, ok := shd.reader.(shapeAttributeReader)
+	if !ok {
+		return "", nil, nil, nil
+	}
+	fields := ar.Fields()
+	attrs := ar.Attributes()
+	if len(fields) == 0 || attrs == nil {
+		return "", nil, nil, nil
+	}
+
+	var name string
+	var sb strings.Builder
+	sb.WriteString("| Attribute | Value |\n| --- | --- |\n")
+	for _, f := range fields {
+		k := f.String()
+		v := attributeString(attrs[k])
+		if name == "" && (k == "name" || k == "NAME" || k == "Name") {
+			name = v
+		}
+		sb.WriteString("| " + escapeMarkdownTableCell(k) + " | " + escapeMarkdownTableCell(v) + " |\n")
+	}
+
+	ib, pm, err := (&layer.InitializerInfobox{
+		Fields: []*layer.InitializerInfoboxField{
+			{
+				Plugin:    layer.OfficialPluginID,
+				Extension: layer.PluginExtensionID("textblock"),
+				Property: &property.Initializer{
+					Schema: layer.NewPropertySchemaID(layer.OfficialPluginID, "textblock"),
+					Items: []*property.InitializerItem{
+						{
+							SchemaItem: property.SchemaGroupID("default"),
+							Fields: []*property.InitializerField{
+								{Field: "title", Type: property.ValueTypeString, Value: property.ValueTypeString.ValueFrom("Attributes")},
+								{Field: "text", Type: property.ValueTypeString, Value: property.ValueTypeString.ValueFrom(sb.String())},
+								{Field: "markdown", Type: property.ValueTypeBool, Value: property.ValueTypeBool.ValueFrom(true)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}).Infobox(shd.sceneId)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return name, ib, pm, nil
+}
+
+func attributeString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+	return fmt.Sprint(v)
+}
+
+func escapeMarkdownTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// CRS returns the CRS defined in the .prj file. It returns nil if the file does not exist.
+func (shd *ShapeDecoder) CRS() (*crs.CRS, error) {
+	return shd.crs, shd.crsErr
+}
+
 func (shd *ShapeDecoder) pointsToCoords(pl []shp.Point) []property.LatLngHeight {
 	var ls []property.LatLngHeight
 	for _, p := range pl {
@@ -62,12 +160,22 @@ func (shd *ShapeDecoder) Decode() (Result, error) {
 	if err != nil {
 		return Result{}, err
 	}
+	if pr, ok := shd.reader.(shapeProjectionReader); ok {
+		if prj := pr.Projection(); prj != "" {
+			shd.crs, shd.crsErr = crs.Parse(prj)
+		}
+	}
+
 	var properties property.Map
 	var layers layer.Map
 	for shd.reader.Next() {
 		_, shape := shd.reader.Shape()
 		var li *layer.Item
 		var p *property.Property
+		name, ib, ibp, err := shd.attributes()
+		if err != nil {
+			return Result{}, err
+		}
 		point, okPoint := shape.(*shp.Point)
 		polyline, okPolyLine := shape.(*shp.PolyLine)
 		polygon, okPolygon := shape.(*shp.Polygon)
@@ -75,13 +183,13 @@ func (shd *ShapeDecoder) Decode() (Result, error) {
 			li, p, err = shd.getLayer("Point", property.LatLng{
 				Lat: point.Y,
 				Lng: point.X,
-			})
+			}, name, ib)
 		}
 		if okPolyLine {
-			li, p, err = shd.getLayer("Polyline", shd.pointsToCoords(polyline.Points))
+			li, p, err = shd.getLayer("Polyline", shd.pointsToCoords(polyline.Points), name, ib)
 		}
 		if okPolygon {
-			li, p, err = shd.getLayer("Polygon", append(make([][]property.LatLngHeight, 1), shd.pointsToCoords(polygon.Points)))
+			li, p, err = shd.getLayer("Polygon", append(make([][]property.LatLngHeight, 1), shd.pointsToCoords(polygon.Points)), name, ib)
 		}
 		if err != nil {
 			return Result{}, err
@@ -93,6 +201,7 @@ func (shd *ShapeDecoder) Decode() (Result, error) {
 		}
 		if p != nil {
 			properties = properties.Add(p)
+			properties = properties.Add(ibp.List()...)
 		}
 	}
 
@@ -1,11 +1,17 @@
 package decoding
 
 import (
+	"archive/zip"
+	"bytes"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/shp"
+	wsc "github.com/reearth/reearth/server/pkg/writer"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
@@ -144,3 +150,82 @@ func getShapesFromFile(filename string, t *testing.T) (shapes []shp.Shape) {
 
 	return shapes
 }
+
+func TestShapeDecoder_Attributes(t *testing.T) {
+	var dbf bytes.Buffer
+	assert.NoError(t, shp.WriteDBF(&dbf, []shp.Field{
+		shp.StringField("NAME", 10),
+		shp.NumberField("POP", 5),
+	}, [][]any{
+		{"a|b", 1},
+		{"c", nil},
+		{"", 3},
+	}))
+
+	s := layer.NewSceneID()
+	result, err := NewShapeDecoder(lo.Must(shp.ReadZipFrom(shapeZip(t, map[string][]byte{
+		"point.shp": lo.Must(os.ReadFile("shapetest/point.shp")),
+		"point.dbf": dbf.Bytes(),
+		"point.cpg": []byte(shp.UTF8Codepage),
+		"point.prj": []byte(shp.WGS84Projection),
+	}))), s).Decode()
+	assert.NoError(t, err)
+
+	root := result.RootLayers().ToLayerGroupList()[0]
+	assert.Equal(t, 3, root.Layers().LayerCount())
+
+	l := result.Layers.Layer(root.Layers().LayerAt(0))
+	assert.Equal(t, "a|b", l.Name())
+	assert.Equal(t, 1, len(l.Infobox().Fields()))
+	f := l.Infobox().FieldAt(0)
+	assert.Equal(t, layer.PluginExtensionID("textblock"), f.Extension())
+	p := result.Properties[f.Property()]
+	assert.NotNil(t, p)
+	text, _, _ := p.Field(property.PointFieldBySchemaGroup("default", "text"))
+	assert.Equal(t, "| Attribute | Value |\n| --- | --- |\n| NAME | a\\|b |\n| POP | 1 |\n", text.Value().Value())
+	assert.NotNil(t, result.Properties[l.Infobox().Property()])
+
+	l = result.Layers.Layer(root.Layers().LayerAt(2))
+	assert.Equal(t, "", l.Name())
+}
+
+func TestShapeDecoder_Projection(t *testing.T) {
+	d := NewShapeDecoder(lo.Must(shp.ReadZipFrom(shapeZip(t, map[string][]byte{
+		"point.shp": lo.Must(os.ReadFile("shapetest/point.shp")),
+		"point.prj": []byte(`PROJCS["JGD2011 / Japan Plane Rectangular CS IX",GEOGCS["JGD2011"]]`),
+	}))), layer.NewSceneID())
+	_, err := ReprojectDecoder{Decoder: d}.Decode()
+	assert.Same(t, crs.ErrUnsupportedCRS, err)
+
+	c := lo.Must(crs.EPSG(6677))
+	x, y := c.FromWGS84(139.767125, 35.681236)
+	var buf wsc.WriterSeeker
+	assert.NoError(t, shp.WriteZip(&buf, "point", shp.POINT, []shp.Shape{&shp.Point{X: x, Y: y}}, []shp.Field{shp.StringField("NAME", 10)}, [][]any{{"Tokyo"}}, c.WKT()))
+
+	d = NewShapeDecoder(lo.Must(shp.ReadZipFrom(bytes.NewReader(buf.Buffer()))), layer.NewSceneID())
+	result, err := ReprojectDecoder{Decoder: d}.Decode()
+	assert.NoError(t, err)
+	got, _ := d.CRS()
+	assert.Equal(t, 6677, got.Code())
+
+	l := result.Layers.Layer(result.RootLayers().ToLayerGroupList()[0].Layers().LayerAt(0))
+	f, _, _ := result.Properties[*l.Property()].Field(property.PointFieldBySchemaGroup("default", "location"))
+	assert.Equal(t, "Tokyo", l.Name())
+	latlng := f.Value().ValueLatLng()
+	assert.InDelta(t, 139.767125, latlng.Lng, 1e-9)
+	assert.InDelta(t, 35.681236, latlng.Lat, 1e-9)
+}
+
+func shapeZip(t *testing.T, files map[string][]byte) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, b := range files {
+		f, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = f.Write(b)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	return bytes.NewReader(buf.Bytes())
+}
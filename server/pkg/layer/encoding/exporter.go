@@ -2,15 +2,37 @@ package encoding
 
 import (
 	"context"
+	"errors"
 
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/layer/merging"
 )
 
+var ErrCRSNotSupported = errors.New("the format does not support coordinate reference systems other than WGS84")
+
+// crsEncoder is implemented by encoders which can write coordinates in CRSs other than WGS84.
+type crsEncoder interface {
+	setCRS(*crs.CRS)
+}
+
+// Exporter exports layers with Encoder. Coordinates are transformed into CRS unless it is nil.
 type Exporter struct {
 	Merger  *merging.Merger
 	Sealer  *merging.Sealer
 	Encoder Encoder
+	CRS     *crs.CRS
+}
+
+// ValidateCRS returns ErrCRSNotSupported if the encoder cannot write coordinates in the CRS.
+func (e *Exporter) ValidateCRS() error {
+	if e.CRS.IsWGS84() {
+		return nil
+	}
+	if _, ok := e.Encoder.(crsEncoder); !ok {
+		return ErrCRSNotSupported
+	}
+	return nil
 }
 
 func (e *Exporter) ExportLayerByID(ctx context.Context, l layer.ID) error {
@@ -43,9 +65,28 @@ func (e *Exporter) Encode(ctx context.Context, m merging.MergedLayer) error {
 	if err != nil {
 		return err
 	}
+	if !e.CRS.IsWGS84() {
+		if err := e.ValidateCRS(); err != nil {
+			return err
+		}
+		e.Encoder.(crsEncoder).setCRS(e.CRS)
+		reproject(s, e.CRS.FromWGS84)
+	}
 	err = e.Encoder.Encode(s)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+func reproject(l merging.SealedLayer, f func(x, y float64) (float64, float64)) {
+	if l == nil {
+		return
+	}
+	l.Common().Property.TransformCoordinates(f)
+	if g := l.Group(); g != nil {
+		for _, c := range g.Children {
+			reproject(c, f)
+		}
+	}
+}
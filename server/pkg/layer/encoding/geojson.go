@@ -5,6 +5,7 @@ import (
 	"io"
 
 	geojson "github.com/paulmach/go.geojson"
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/layer/merging"
 	"github.com/reearth/reearth/server/pkg/property"
@@ -12,6 +13,7 @@ import (
 
 type GeoJSONEncoder struct {
 	writer io.Writer
+	crs    *crs.CRS
 }
 
 func NewGeoJSONEncoder(w io.Writer) *GeoJSONEncoder {
@@ -24,6 +26,21 @@ func (*GeoJSONEncoder) MimeType() string {
 	return "application/json"
 }
 
+func (e *GeoJSONEncoder) setCRS(c *crs.CRS) {
+	e.crs = c
+}
+
+// geoJSONCRS returns the "crs" member defined in the 2008 GeoJSON specification. It returns nil for WGS84.
+func geoJSONCRS(c *crs.CRS) map[string]any {
+	if c.IsWGS84() {
+		return nil
+	}
+	return map[string]any{
+		"type":       "name",
+		"properties": map[string]any{"name": c.URN()},
+	}
+}
+
 func (e *GeoJSONEncoder) polygonToFloat(p property.Polygon) [][][]float64 {
 	var res [][][]float64
 	for _, c := range p {
@@ -138,6 +155,7 @@ func (e *GeoJSONEncoder) Encode(layer merging.SealedLayer) error {
 			return err
 		}
 		if geo != nil {
+			geo.CRS = geoJSONCRS(e.crs)
 			data, err = geo.MarshalJSON()
 			if err != nil {
 				return err
@@ -149,6 +167,7 @@ func (e *GeoJSONEncoder) Encode(layer merging.SealedLayer) error {
 			return err
 		}
 		if fc != nil {
+			fc.CRS = geoJSONCRS(e.crs)
 			data, err = fc.MarshalJSON()
 			if err != nil {
 				return err
@@ -5,9 +5,11 @@ import (
 	"testing"
 
 	geojson "github.com/paulmach/go.geojson"
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/layer/merging"
 	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -224,3 +226,47 @@ func TestGeoJSONEncoder_Encode(t *testing.T) {
 		})
 	}
 }
+
+func TestGeoJSONEncoder_Encode_CRS(t *testing.T) {
+	c := lo.Must(crs.EPSG(32654))
+	l := &merging.SealedLayerItem{
+		SealedLayerCommon: merging.SealedLayerCommon{
+			Merged: layer.Merged{
+				Original:    layer.NewID(),
+				Scene:       layer.NewSceneID(),
+				PluginID:    &layer.OfficialPluginID,
+				ExtensionID: layer.PluginExtensionID("marker").Ref(),
+			},
+			Property: &property.Sealed{
+				Original: property.NewID().Ref(),
+				Items: []*property.SealedItem{
+					{
+						Original:    property.NewItemID().Ref(),
+						SchemaGroup: property.SchemaGroupID("default"),
+						Fields: []*property.SealedField{
+							{
+								ID: property.FieldID("location"),
+								Val: property.NewValueAndDatasetValue(
+									property.ValueTypeLatLng,
+									nil,
+									property.ValueTypeLatLng.ValueFrom(property.LatLng{Lat: 0, Lng: 141}),
+								),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	reproject(l, c.FromWGS84)
+	var b bytes.Buffer
+	e := NewGeoJSONEncoder(&b)
+	e.setCRS(c)
+	assert.NoError(t, e.Encode(l))
+
+	f, err := geojson.UnmarshalFeature(b.Bytes())
+	assert.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{500000, 0}, f.Geometry.Point, 1e-6)
+	assert.Equal(t, map[string]any{"type": "name", "properties": map[string]any{"name": "urn:ogc:def:crs:EPSG::32654"}}, f.CRS)
+}
@@ -3,16 +3,19 @@ package encoding
 import (
 	"errors"
 	"io"
+	"slices"
+	"strings"
 
+	"github.com/reearth/reearth/server/pkg/crs"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/layer/merging"
 	"github.com/reearth/reearth/server/pkg/property"
 	shp "github.com/reearth/reearth/server/pkg/shp"
-	wsc "github.com/reearth/reearth/server/pkg/writer"
 )
 
 type SHPEncoder struct {
 	writer io.Writer
+	prj    string
 }
 
 func NewSHPEncoder(w io.Writer) *SHPEncoder {
@@ -22,7 +25,11 @@ func NewSHPEncoder(w io.Writer) *SHPEncoder {
 }
 
 func (*SHPEncoder) MimeType() string {
-	return "application/octet-stream"
+	return "application/zip"
+}
+
+func (e *SHPEncoder) setCRS(c *crs.CRS) {
+	e.prj = c.WKT()
 }
 
 func coordsToPoints(coords property.Coordinates) []shp.Point {
@@ -121,73 +128,162 @@ func (e *SHPEncoder) encodeLayer(li *merging.SealedLayerItem) (sh shp.Shape, st
 	return sh, st, nil
 }
 
-func (e *SHPEncoder) encodeLayerGroup(w *wsc.WriterSeeker, li *merging.SealedLayerGroup, shape *shp.Writer) error {
+// shpRecord is a shape and its attributes to be written in a shapefile.
+type shpRecord struct {
+	shape      shp.Shape
+	attributes map[string]string
+}
+
+func (e *SHPEncoder) encodeLayerGroup(li *merging.SealedLayerGroup, records []shpRecord, t shp.ShapeType) ([]shpRecord, shp.ShapeType, error) {
 	for _, ch := range li.Children {
+		var err error
 		if g, ok := ch.(*merging.SealedLayerGroup); ok {
-			if err := e.encodeLayerGroup(w, g, shape); err != nil {
-				return err
+			records, t, err = e.encodeLayerGroup(g, records, t)
+			if err != nil {
+				return nil, 0, err
 			}
 		} else if i, ok := ch.(*merging.SealedLayerItem); ok {
-			l, t, err := e.encodeLayer(i)
+			l, st, err := e.encodeLayer(i)
 			if err != nil {
-				return err
+				return nil, 0, err
 			}
-
-			if shape == nil {
-				shape, err = shp.CreateFrom(w, t)
-
-				if err != nil {
-					return err
-				}
-
-				defer func() {
-					err = shape.Close()
-				}()
-			}
-
-			if _, err := shape.Write(l); err != nil {
-				return err
+			if t == shp.NULL {
+				t = st
 			}
+			records = append(records, shpRecord{shape: l, attributes: itemAttributes(i)})
 		}
 	}
-
-	return nil
+	return records, t, nil
 }
 
+// Encode writes a ZIP archive of a shapefile. Names of layers and attributes shown in infobox text blocks
+// as markdown tables, which are created when shapefiles are imported, are written in the DBF table.
 func (e *SHPEncoder) Encode(layer merging.SealedLayer) error {
-	var err error
-	var w wsc.WriterSeeker
+	var records []shpRecord
+	var t shp.ShapeType
 	if i, ok := layer.(*merging.SealedLayerItem); ok {
-		l, t, err := e.encodeLayer(i)
+		l, st, err := e.encodeLayer(i)
 		if err != nil {
 			return err
 		}
-		shape, err := shp.CreateFrom(&w, t)
+		records, t = []shpRecord{{shape: l, attributes: itemAttributes(i)}}, st
+	} else if g, ok := layer.(*merging.SealedLayerGroup); ok {
+		var err error
+		records, t, err = e.encodeLayerGroup(g, nil, shp.NULL)
 		if err != nil {
 			return err
 		}
-		defer func() {
-			err = shape.Close()
+	}
 
-		}()
-		if err != nil {
-			return err
+	keys := []string{"name"}
+	for _, r := range records {
+		for k := range r.attributes {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
 		}
-		_, err = shape.Write(l)
-		if err != nil {
-			return err
+	}
+	slices.Sort(keys[1:])
+
+	shapes := make([]shp.Shape, 0, len(records))
+	rows := make([][]any, 0, len(records))
+	for _, r := range records {
+		shapes = append(shapes, r.shape)
+		row := make([]any, 0, len(keys))
+		for _, k := range keys {
+			if v, ok := r.attributes[k]; ok {
+				row = append(row, v)
+			} else {
+				row = append(row, nil)
+			}
 		}
-	} else if g, ok := layer.(*merging.SealedLayerGroup); ok {
-		err := e.encodeLayerGroup(&w, g, nil)
-		if err != nil {
-			return err
+		rows = append(rows, row)
+	}
+
+	names := shp.FieldNames(keys)
+	fields := make([]shp.Field, 0, len(keys))
+	for i, n := range names {
+		values := make([]any, 0, len(rows))
+		for _, r := range rows {
+			values = append(values, r[i])
+		}
+		fields = append(fields, shp.FieldFor(n, values))
+	}
+
+	return shp.WriteZip(e.writer, "layer", t, shapes, fields, rows, e.prj)
+}
+
+// itemAttributes returns the name of the layer and attributes in markdown tables of text blocks in the infobox.
+func itemAttributes(li *merging.SealedLayerItem) map[string]string {
+	res := map[string]string{"name": li.Name}
+	if li.Infobox == nil {
+		return res
+	}
+	for _, f := range li.Infobox.Fields {
+		if f == nil || !f.Plugin.Equal(layer.OfficialPluginID) || f.Extension != "textblock" {
+			continue
+		}
+		text := f.Property.Field("text").Value().ValueString()
+		if text == nil {
+			continue
+		}
+		for k, v := range markdownTableAttributes(*text) {
+			if k == "name" && v == li.Name {
+				continue
+			}
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// markdownTableAttributes parses rows of markdown tables which have two columns as pairs of keys and values.
+// Header rows and delimiter rows are skipped.
+func markdownTableAttributes(text string) map[string]string {
+	res := map[string]string{}
+	header := true
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			header = true
+			continue
+		}
+		cells := markdownTableCells(line)
+		if len(cells) != 2 {
+			continue
+		}
+		if header {
+			header = false
+			continue
+		}
+		if strings.Trim(cells[0], "-: ") == "" && strings.Trim(cells[1], "-: ") == "" {
+			continue
+		}
+		if cells[0] != "" {
+			res[cells[0]] = cells[1]
 		}
 	}
-	_, err = w.WriteTo(e.writer)
-	if err != nil {
-		return err
+	return res
+}
+
+func markdownTableCells(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	var cells []string
+	var sb strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == '|' {
+			sb.WriteByte('|')
+			i++
+			continue
+		}
+		if line[i] == '|' {
+			cells = append(cells, strings.TrimSpace(sb.String()))
+			sb.Reset()
+			continue
+		}
+		sb.WriteByte(line[i])
 	}
-	return nil
+	return append(cells, strings.TrimSpace(sb.String()))
 }
 
 func (*SHPEncoder) encodeMarker(li *merging.SealedLayerItem) (shp.Shape, shp.ShapeType) {
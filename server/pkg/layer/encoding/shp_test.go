@@ -19,6 +19,7 @@ func TestEncodeSHP(t *testing.T) {
 		name  string
 		layer *merging.SealedLayerItem
 		want  shp.Shape
+		attrs map[string]string
 	}{
 		{
 			name: "polygon",
@@ -74,6 +75,7 @@ func TestEncodeSHP(t *testing.T) {
 					{X: 654.34, Y: 34.66},
 				},
 			},
+			attrs: map[string]string{"name": ""},
 		},
 		{
 			name: "polyline",
@@ -89,6 +91,33 @@ func TestEncodeSHP(t *testing.T) {
 						PluginID:    &layer.OfficialPluginID,
 						ExtensionID: layer.PluginExtensionID("polyline").Ref(),
 					},
+					Infobox: &merging.SealedInfobox{
+						Fields: []*merging.SealedInfoboxField{
+							{
+								MergedInfoboxField: layer.MergedInfoboxField{
+									Plugin:    layer.OfficialPluginID,
+									Extension: layer.PluginExtensionID("textblock"),
+								},
+								Property: &property.Sealed{
+									Items: []*property.SealedItem{
+										{
+											SchemaGroup: property.SchemaGroupID("default"),
+											Fields: []*property.SealedField{
+												{
+													ID: property.FieldID("text"),
+													Val: property.NewValueAndDatasetValue(
+														property.ValueTypeString,
+														nil,
+														property.ValueTypeString.ValueFrom("| Attribute | Value |\n| --- | --- |\n| KIND | a\\|b |\n| ROAD_ID | 12 |\n"),
+													),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
 					Property: &property.Sealed{
 						Original: property.NewID().Ref(),
 						Items: []*property.SealedItem{
@@ -130,6 +159,7 @@ func TestEncodeSHP(t *testing.T) {
 					{X: 654.34, Y: 34.66},
 				},
 			},
+			attrs: map[string]string{"name": "test", "KIND": "a|b", "ROAD_ID": "12"},
 		},
 	}
 
@@ -138,21 +168,27 @@ func TestEncodeSHP(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// sequential test
 
-			tmpFile, err := os.CreateTemp(os.TempDir(), "*.shp")
+			tmpFile, err := os.CreateTemp(os.TempDir(), "*.zip")
 			assert.NoError(t, err)
 			en := NewSHPEncoder(tmpFile)
 			assert.NoError(t, en.Encode(tt.layer))
 			assert.NoError(t, tmpFile.Close())
 
-			shape, err := shp.Open(tmpFile.Name())
+			shape, err := shp.OpenZip(tmpFile.Name())
 			assert.NoError(t, err)
 			assert.True(t, shape.Next())
 
+			_, p := shape.Shape()
+			attrs := map[string]string{}
+			for i, f := range shape.Fields() {
+				attrs[f.String()] = shape.Attribute(i)
+			}
+
 			assert.NoError(t, shape.Close())
 			assert.NoError(t, os.Remove(tmpFile.Name()))
 
-			_, p := shape.Shape()
 			assert.Equal(t, tt.want, p)
+			assert.Equal(t, tt.attrs, attrs)
 		})
 	}
 }
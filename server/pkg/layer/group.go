@@ -188,3 +188,15 @@ func (l *Group) Tags() *TagList {
 	}
 	return l.layerBase.tags
 }
+
+func (l *Group) Clone() *Group {
+	if l == nil {
+		return nil
+	}
+	return &Group{
+		layerBase:           l.layerBase.clone(),
+		layers:              l.layers.Clone(),
+		linkedDatasetSchema: l.linkedDatasetSchema.CloneRef(),
+		root:                l.root,
+	}
+}
@@ -154,3 +154,13 @@ func (l *Item) Tags() *TagList {
 	}
 	return l.layerBase.tags
 }
+
+func (l *Item) Clone() *Item {
+	if l == nil {
+		return nil
+	}
+	return &Item{
+		layerBase:     l.layerBase.clone(),
+		linkedDataset: l.linkedDataset.CloneRef(),
+	}
+}
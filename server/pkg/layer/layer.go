@@ -80,6 +80,31 @@ type layerBase struct {
 	tags      *TagList
 }
 
+// Clone returns a deep copy of the layer.
+func Clone(l Layer) Layer {
+	if i := ToLayerItem(l); i != nil {
+		return i.Clone()
+	}
+	if g := ToLayerGroup(l); g != nil {
+		return g.Clone()
+	}
+	return nil
+}
+
+func (l *layerBase) clone() layerBase {
+	return layerBase{
+		id:        l.id,
+		name:      l.name,
+		visible:   l.visible,
+		plugin:    l.plugin.CloneRef(),
+		extension: l.extension.CloneRef(),
+		property:  l.property.CloneRef(),
+		infobox:   l.infobox.Clone(),
+		scene:     l.scene,
+		tags:      l.tags.Clone(),
+	}
+}
+
 func (l *layerBase) ID() ID {
 	return l.id
 }
@@ -21,6 +21,11 @@ func (b *Builder) ID(id ID) *Builder {
 	return b
 }
 
+func (b *Builder) Version(v int) *Builder {
+	b.base.version = v
+	return b
+}
+
 func (b *Builder) NewID() *Builder {
 	b.base.id = NewID()
 	return b
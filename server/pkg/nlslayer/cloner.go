@@ -3,3 +3,12 @@ package nlslayer
 type Cloner interface {
 	Clone() Cloner
 }
+
+// Clone returns a deep copy of the layer.
+func Clone(l NLSLayer) NLSLayer {
+	if l == nil {
+		return nil
+	}
+	c, _ := l.Clone().(NLSLayer)
+	return c
+}
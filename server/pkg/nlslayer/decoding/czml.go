@@ -0,0 +1,88 @@
+package decoding
+
+import (
+	"encoding/json"
+
+	"github.com/reearth/reearth/server/pkg/czml"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+type CZMLDecoder struct {
+	decoder *json.Decoder
+}
+
+func NewCZMLDecoder(d *json.Decoder) *CZMLDecoder {
+	return &CZMLDecoder{
+		decoder: d,
+	}
+}
+
+// Decode decodes packets which have a polygon, a polyline or a point. The document packet and packets without
+// any supported graphics are skipped.
+func (d *CZMLDecoder) Decode() ([]nlslayer.Feature, error) {
+	var packets []czml.Feature
+	if err := d.decoder.Decode(&packets); err != nil {
+		return nil, ErrInvalidContent
+	}
+
+	var res []nlslayer.Feature
+	for _, p := range packets {
+		if p.Id == "document" {
+			continue
+		}
+
+		var g nlslayer.Geometry
+		switch {
+		case p.Polygon != nil:
+			coords, err := czmlCoordinates(p.Polygon.Positions.CartographicDegrees)
+			if err != nil {
+				return nil, err
+			}
+			g = newPolygon([][][]float64{coords})
+		case p.Polyline != nil:
+			coords, err := czmlCoordinates(p.Polyline.Positions.CartographicDegrees)
+			if err != nil {
+				return nil, err
+			}
+			g = newLineString(coords)
+		case p.Position != nil:
+			coords, err := czmlCoordinates(p.Position.CartographicDegrees)
+			if err != nil || len(coords) != 1 {
+				return nil, ErrInvalidContent
+			}
+			g = newPoint(coords[0])
+		default:
+			continue
+		}
+
+		f, err := newFeature(g, czmlProperties(p))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
+
+// czmlCoordinates splits cartographic degrees, which are a flat list of longitude, latitude and height, into coordinates.
+func czmlCoordinates(c []float64) ([][]float64, error) {
+	if len(c) == 0 || len(c)%3 != 0 {
+		return nil, ErrInvalidContent
+	}
+	res := make([][]float64, 0, len(c)/3)
+	for i := 0; i < len(c); i += 3 {
+		res = append(res, []float64{c[i], c[i+1], c[i+2]})
+	}
+	return res, nil
+}
+
+func czmlProperties(p czml.Feature) map[string]any {
+	res := make(map[string]any, len(p.Properties)+1)
+	for k, v := range p.Properties {
+		res[k] = v
+	}
+	if p.Name != "" {
+		res["name"] = p.Name
+	}
+	return res
+}
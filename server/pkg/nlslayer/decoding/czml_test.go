@@ -0,0 +1,48 @@
+package decoding
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Decoder = &CZMLDecoder{}
+
+const czmlmock = `[
+  { "id": "document", "name": "CZML Geometries", "version": "1.0" },
+  {
+    "id": "point",
+    "name": "point",
+    "position": { "cartographicDegrees": [-111.0, 40.0, 0] },
+    "point": { "pixelSize": 10 },
+    "properties": { "kind": "station" }
+  },
+  {
+    "id": "line",
+    "polyline": { "positions": { "cartographicDegrees": [-75, 35, 0, -125, 35, 0] } }
+  },
+  {
+    "id": "polygon",
+    "polygon": { "positions": { "cartographicDegrees": [0, 0, 0, 1, 0, 0, 1, 1, 0] } }
+  },
+  { "id": "label" }
+]`
+
+func TestCZMLDecoder_Decode(t *testing.T) {
+	got, err := NewCZMLDecoder(json.NewDecoder(strings.NewReader(czmlmock))).Decode()
+	assert.NoError(t, err)
+	assert.Len(t, got, 3)
+
+	assert.Equal(t, []float64{-111.0, 40.0, 0}, got[0].Geometry().(*nlslayer.Point).Coordinates())
+	assert.Equal(t, &map[string]any{"name": "point", "kind": "station"}, got[0].Properties())
+	assert.Equal(t, [][]float64{{-75, 35, 0}, {-125, 35, 0}}, got[1].Geometry().(*nlslayer.LineString).Coordinates())
+	assert.Equal(t, [][][]float64{{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}}}, got[2].Geometry().(*nlslayer.Polygon).Coordinates())
+
+	_, err = NewCZMLDecoder(json.NewDecoder(strings.NewReader(`[{"id":"a","position":{"cartographicDegrees":[1,2]}}]`))).Decode()
+	assert.Same(t, ErrInvalidContent, err)
+	_, err = NewCZMLDecoder(json.NewDecoder(strings.NewReader(`{}`))).Decode()
+	assert.Same(t, ErrInvalidContent, err)
+}
@@ -0,0 +1,42 @@
+package decoding
+
+import (
+	"errors"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+var ErrInvalidContent = errors.New("unable to parse file content")
+
+// Decoder decodes a file into features of a sketch layer.
+type Decoder interface {
+	Decode() ([]nlslayer.Feature, error)
+}
+
+func newFeature(g nlslayer.Geometry, properties map[string]any) (nlslayer.Feature, error) {
+	f, err := nlslayer.NewFeatureWithNewId("Feature", g)
+	if err != nil {
+		return nlslayer.Feature{}, err
+	}
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	f.UpdateProperties(&properties)
+	return *f, nil
+}
+
+func newPoint(c []float64) *nlslayer.Point {
+	return nlslayer.NewPoint("Point", c)
+}
+
+func newLineString(c [][]float64) *nlslayer.LineString {
+	return nlslayer.NewLineString("LineString", c)
+}
+
+func newPolygon(c [][][]float64) *nlslayer.Polygon {
+	return nlslayer.NewPolygon("Polygon", c)
+}
+
+func newGeometryCollection(g []nlslayer.Geometry) *nlslayer.GeometryCollection {
+	return nlslayer.NewGeometryCollection("GeometryCollection", g)
+}
@@ -0,0 +1,99 @@
+package decoding
+
+import (
+	"fmt"
+	"io"
+
+	geojson "github.com/paulmach/go.geojson"
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/samber/lo"
+)
+
+type GeoJSONDecoder struct {
+	reader io.Reader
+	crs    *crs.CRS
+	crsErr error
+}
+
+func NewGeoJSONDecoder(r io.Reader) *GeoJSONDecoder {
+	return &GeoJSONDecoder{
+		reader: r,
+	}
+}
+
+// Decode decodes a feature collection, a feature or a geometry. Properties of features are kept as they are.
+func (d *GeoJSONDecoder) Decode() ([]nlslayer.Feature, error) {
+	con, err := io.ReadAll(d.reader)
+	if err != nil {
+		return nil, ErrInvalidContent
+	}
+	d.crs, d.crsErr = geoJSONCRS(con)
+
+	var features []*geojson.Feature
+	if fc, err := geojson.UnmarshalFeatureCollection(con); err == nil && len(fc.Features) > 0 {
+		features = fc.Features
+	} else if f, err := geojson.UnmarshalFeature(con); err == nil && f.Geometry != nil {
+		features = []*geojson.Feature{f}
+	} else if g, err := geojson.UnmarshalGeometry(con); err == nil {
+		features = []*geojson.Feature{geojson.NewFeature(g)}
+	} else {
+		return nil, ErrInvalidContent
+	}
+
+	res := make([]nlslayer.Feature, 0, len(features))
+	for _, f := range features {
+		if f.Geometry == nil {
+			continue
+		}
+		g, err := geoJSONGeometry(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		nf, err := newFeature(g, f.Properties)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, nf)
+	}
+	return res, nil
+}
+
+// CRS returns the CRS specified by the "crs" member of the decoded content. It returns nil if the member does not exist.
+func (d *GeoJSONDecoder) CRS() (*crs.CRS, error) {
+	return d.crs, d.crsErr
+}
+
+// geoJSONGeometry converts a GeoJSON geometry. Since sketch layers do not have MultiPoint and MultiLineString,
+// they are converted into geometry collections.
+func geoJSONGeometry(g *geojson.Geometry) (nlslayer.Geometry, error) {
+	switch g.Type {
+	case geojson.GeometryPoint:
+		return newPoint(g.Point), nil
+	case geojson.GeometryMultiPoint:
+		return newGeometryCollection(lo.Map(g.MultiPoint, func(c []float64, _ int) nlslayer.Geometry {
+			return newPoint(c)
+		})), nil
+	case geojson.GeometryLineString:
+		return newLineString(g.LineString), nil
+	case geojson.GeometryMultiLineString:
+		return newGeometryCollection(lo.Map(g.MultiLineString, func(c [][]float64, _ int) nlslayer.Geometry {
+			return newLineString(c)
+		})), nil
+	case geojson.GeometryPolygon:
+		return newPolygon(g.Polygon), nil
+	case geojson.GeometryMultiPolygon:
+		return nlslayer.NewMultiPolygon("MultiPolygon", g.MultiPolygon), nil
+	case geojson.GeometryCollection:
+		geometries := make([]nlslayer.Geometry, 0, len(g.Geometries))
+		for _, g2 := range g.Geometries {
+			ng, err := geoJSONGeometry(g2)
+			if err != nil {
+				return nil, err
+			}
+			geometries = append(geometries, ng)
+		}
+		return newGeometryCollection(geometries), nil
+	}
+	return nil, fmt.Errorf("unsupported geometry type: %s", g.Type)
+}
@@ -0,0 +1,60 @@
+package decoding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Decoder = &GeoJSONDecoder{}
+
+const geojsonmock = `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "geometry": { "type": "Point", "coordinates": [102.0, 0.5] },
+      "properties": { "name": "point", "count": 1 }
+    },
+    {
+      "type": "Feature",
+      "geometry": { "type": "LineString", "coordinates": [[102.0, 0.0], [103.0, 1.0]] },
+      "properties": null
+    },
+    {
+      "type": "Feature",
+      "geometry": { "type": "MultiPoint", "coordinates": [[100.0, 0.0], [101.0, 1.0]] },
+      "properties": {}
+    },
+    {
+      "type": "Feature",
+      "geometry": null,
+      "properties": {}
+    }
+  ]
+}`
+
+func TestGeoJSONDecoder_Decode(t *testing.T) {
+	got, err := NewGeoJSONDecoder(strings.NewReader(geojsonmock)).Decode()
+	assert.NoError(t, err)
+	assert.Len(t, got, 3)
+
+	assert.Equal(t, []float64{102.0, 0.5}, got[0].Geometry().(*nlslayer.Point).Coordinates())
+	assert.Equal(t, &map[string]any{"name": "point", "count": 1.0}, got[0].Properties())
+	assert.Equal(t, [][]float64{{102.0, 0.0}, {103.0, 1.0}}, got[1].Geometry().(*nlslayer.LineString).Coordinates())
+	assert.Equal(t, &map[string]any{}, got[1].Properties())
+	gc := got[2].Geometry().(*nlslayer.GeometryCollection)
+	assert.Len(t, gc.Geometries(), 2)
+	assert.Equal(t, []float64{101.0, 1.0}, gc.Geometries()[1].(*nlslayer.Point).Coordinates())
+
+	// a single geometry
+	got, err = NewGeoJSONDecoder(strings.NewReader(`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`)).Decode()
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, [][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, got[0].Geometry().(*nlslayer.Polygon).Coordinates())
+
+	_, err = NewGeoJSONDecoder(strings.NewReader(`invalid`)).Decode()
+	assert.Same(t, ErrInvalidContent, err)
+}
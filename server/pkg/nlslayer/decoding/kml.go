@@ -0,0 +1,178 @@
+package decoding
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/kml"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+type KMLDecoder struct {
+	decoder *xml.Decoder
+}
+
+func NewKMLDecoder(d *xml.Decoder) *KMLDecoder {
+	return &KMLDecoder{
+		decoder: d,
+	}
+}
+
+// Decode decodes all placemarks in the document regardless of the folders they are in.
+// Names, descriptions and extended data of placemarks become properties of features.
+func (d *KMLDecoder) Decode() ([]nlslayer.Feature, error) {
+	var res []nlslayer.Feature
+	for {
+		token, err := d.decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidContent
+		}
+
+		se, ok := token.(xml.StartElement)
+		if !ok || se.Name.Local != "Placemark" {
+			continue
+		}
+
+		var p kml.Placemark
+		if err := d.decoder.DecodeElement(&p, &se); err != nil {
+			return nil, ErrInvalidContent
+		}
+		g, err := kmlGeometry(p)
+		if err != nil {
+			return nil, err
+		}
+		if g == nil {
+			continue
+		}
+		f, err := newFeature(g, kmlProperties(p))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
+
+func kmlGeometry(p kml.Placemark) (nlslayer.Geometry, error) {
+	if c := p.Point.Coordinates; strings.TrimSpace(c) != "" {
+		return kmlPoint(c)
+	}
+	if c := p.Polyline.Coordinates; strings.TrimSpace(c) != "" {
+		return kmlLineString(c)
+	}
+	if strings.TrimSpace(p.Polygon.OuterBoundaryIs.LinearRing.Coordinates) != "" {
+		return kmlPolygon(p.Polygon)
+	}
+
+	var geometries []nlslayer.Geometry
+	for _, pt := range p.MultiGeometry.Points {
+		g, err := kmlPoint(pt.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, g)
+	}
+	for _, l := range p.MultiGeometry.Polylines {
+		g, err := kmlLineString(l.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, g)
+	}
+	for _, pl := range p.MultiGeometry.Polygons {
+		g, err := kmlPolygon(pl)
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, g)
+	}
+	if len(geometries) == 0 {
+		return nil, nil
+	}
+	return newGeometryCollection(geometries), nil
+}
+
+func kmlPoint(c string) (nlslayer.Geometry, error) {
+	coords, err := kmlCoordinates(c)
+	if err != nil {
+		return nil, err
+	}
+	if len(coords) != 1 {
+		return nil, ErrInvalidContent
+	}
+	return newPoint(coords[0]), nil
+}
+
+func kmlLineString(c string) (nlslayer.Geometry, error) {
+	coords, err := kmlCoordinates(c)
+	if err != nil {
+		return nil, err
+	}
+	return newLineString(coords), nil
+}
+
+func kmlPolygon(p kml.Polygon) (nlslayer.Geometry, error) {
+	outer, err := kmlCoordinates(p.OuterBoundaryIs.LinearRing.Coordinates)
+	if err != nil {
+		return nil, err
+	}
+	rings := [][][]float64{outer}
+	for _, ib := range p.InnerBoundaryIs {
+		inner, err := kmlCoordinates(ib.LinearRing.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, inner)
+	}
+	return newPolygon(rings), nil
+}
+
+// kmlCoordinates parses coordinates which are tuples of longitude, latitude and optional altitude separated by whitespaces.
+func kmlCoordinates(c string) ([][]float64, error) {
+	var res [][]float64
+	for _, tuple := range strings.Fields(c) {
+		parts := strings.Split(tuple, ",")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, ErrInvalidContent
+		}
+		coord := make([]float64, 0, len(parts))
+		for _, p := range parts {
+			v, err := strconv.ParseFloat(p, 64)
+			if err != nil {
+				return nil, ErrInvalidContent
+			}
+			coord = append(coord, v)
+		}
+		res = append(res, coord)
+	}
+	return res, nil
+}
+
+func kmlProperties(p kml.Placemark) map[string]any {
+	res := map[string]any{}
+	if p.Name != "" {
+		res["name"] = p.Name
+	}
+	if d := strings.TrimSpace(p.Description); d != "" {
+		res["description"] = d
+	}
+	for _, d := range p.ExtendedData.Data {
+		if d.Name != "" {
+			res[d.Name] = d.Value
+		}
+	}
+	for _, sd := range p.ExtendedData.SchemaData {
+		for _, d := range sd.SimpleData {
+			if d.Name != "" {
+				res[d.Name] = d.Value
+			}
+		}
+	}
+	return res
+}
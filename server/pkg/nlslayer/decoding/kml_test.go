@@ -0,0 +1,78 @@
+package decoding
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Decoder = &KMLDecoder{}
+
+const kmlmock = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Folder>
+      <Placemark>
+        <name>point</name>
+        <description>a point</description>
+        <ExtendedData>
+          <Data name="height"><value>43</value></Data>
+        </ExtendedData>
+        <Point>
+          <coordinates>-122.08,37.42,43</coordinates>
+        </Point>
+      </Placemark>
+      <Placemark>
+        <ExtendedData>
+          <SchemaData schemaUrl="#schema">
+            <SimpleData name="kind">road</SimpleData>
+          </SchemaData>
+        </ExtendedData>
+        <LineString>
+          <coordinates>
+            -112.08,36.10 -112.09,36.11
+          </coordinates>
+        </LineString>
+      </Placemark>
+    </Folder>
+    <Placemark>
+      <Polygon>
+        <outerBoundaryIs><LinearRing><coordinates>0,0 4,0 4,4 0,0</coordinates></LinearRing></outerBoundaryIs>
+        <innerBoundaryIs><LinearRing><coordinates>1,1 2,1 2,2 1,1</coordinates></LinearRing></innerBoundaryIs>
+      </Polygon>
+    </Placemark>
+    <Placemark>
+      <MultiGeometry>
+        <Point><coordinates>1,2</coordinates></Point>
+        <Point><coordinates>3,4</coordinates></Point>
+      </MultiGeometry>
+    </Placemark>
+    <Placemark>
+      <name>empty</name>
+    </Placemark>
+  </Document>
+</kml>`
+
+func TestKMLDecoder_Decode(t *testing.T) {
+	got, err := NewKMLDecoder(xml.NewDecoder(strings.NewReader(kmlmock))).Decode()
+	assert.NoError(t, err)
+	assert.Len(t, got, 4)
+
+	assert.Equal(t, []float64{-122.08, 37.42, 43}, got[0].Geometry().(*nlslayer.Point).Coordinates())
+	assert.Equal(t, &map[string]any{"name": "point", "description": "a point", "height": "43"}, got[0].Properties())
+	assert.Equal(t, [][]float64{{-112.08, 36.10}, {-112.09, 36.11}}, got[1].Geometry().(*nlslayer.LineString).Coordinates())
+	assert.Equal(t, &map[string]any{"kind": "road"}, got[1].Properties())
+	assert.Equal(t, [][][]float64{
+		{{0, 0}, {4, 0}, {4, 4}, {0, 0}},
+		{{1, 1}, {2, 1}, {2, 2}, {1, 1}},
+	}, got[2].Geometry().(*nlslayer.Polygon).Coordinates())
+	gc := got[3].Geometry().(*nlslayer.GeometryCollection)
+	assert.Len(t, gc.Geometries(), 2)
+	assert.Equal(t, []float64{3, 4}, gc.Geometries()[1].(*nlslayer.Point).Coordinates())
+
+	_, err = NewKMLDecoder(xml.NewDecoder(strings.NewReader(`<kml><Placemark><Point><coordinates>a,b</coordinates></Point></Placemark></kml>`))).Decode()
+	assert.Same(t, ErrInvalidContent, err)
+}
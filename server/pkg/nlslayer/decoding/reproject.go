@@ -0,0 +1,62 @@
+package decoding
+
+import (
+	"encoding/json"
+
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+// crsDetector is implemented by decoders which can detect the CRS of the source data after decoding.
+type crsDetector interface {
+	CRS() (*crs.CRS, error)
+}
+
+// ReprojectDecoder decodes data with Decoder and transforms geometries of features from CRS into WGS84.
+// When CRS is nil, the CRS detected by Decoder is used, and coordinates are assumed to be WGS84 if it is not detected.
+type ReprojectDecoder struct {
+	Decoder Decoder
+	CRS     *crs.CRS
+}
+
+func (d ReprojectDecoder) Decode() ([]nlslayer.Feature, error) {
+	features, err := d.Decoder.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	c := d.CRS
+	if c == nil {
+		if cd, ok := d.Decoder.(crsDetector); ok {
+			if c, err = cd.CRS(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if c.IsWGS84() {
+		return features, nil
+	}
+	for i := range features {
+		features[i].UpdateGeometry(nlslayer.TransformGeometry(features[i].Geometry(), c.ToWGS84))
+	}
+	return features, nil
+}
+
+// geoJSONCRS returns the CRS specified by the "crs" member, which was defined in the 2008 GeoJSON specification.
+func geoJSONCRS(content []byte) (*crs.CRS, error) {
+	var v struct {
+		CRS *struct {
+			Type       string `json:"type"`
+			Properties struct {
+				Name string `json:"name"`
+			} `json:"properties"`
+		} `json:"crs"`
+	}
+	if err := json.Unmarshal(content, &v); err != nil || v.CRS == nil {
+		return nil, nil
+	}
+	if v.CRS.Type != "name" {
+		return nil, crs.ErrUnsupportedCRS
+	}
+	return crs.Parse(v.CRS.Properties.Name)
+}
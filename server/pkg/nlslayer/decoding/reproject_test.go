@@ -0,0 +1,70 @@
+package decoding
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Decoder = ReprojectDecoder{}
+
+func TestReprojectDecoder_Decode(t *testing.T) {
+	// Tokyo station in JGD2011 / Japan Plane Rectangular CS IX
+	c := lo.Must(crs.EPSG(6677))
+	x, y := c.FromWGS84(139.767125, 35.681236)
+	point := `{"type":"Point","coordinates":[` + strconv.FormatFloat(x, 'f', -1, 64) + `,` + strconv.FormatFloat(y, 'f', -1, 64) + `,10]}`
+
+	tests := []struct {
+		name    string
+		content string
+		crs     *crs.CRS
+		want    []float64
+		err     error
+	}{
+		{
+			name:    "detected",
+			content: `{"type":"Feature","crs":{"type":"name","properties":{"name":"urn:ogc:def:crs:EPSG::6677"}},"geometry":` + point + `,"properties":{}}`,
+			want:    []float64{139.767125, 35.681236, 10},
+		},
+		{
+			name:    "specified",
+			content: point,
+			crs:     c,
+			want:    []float64{139.767125, 35.681236, 10},
+		},
+		{
+			name:    "not detected",
+			content: `{"type":"Point","coordinates":[139.767125,35.681236]}`,
+			want:    []float64{139.767125, 35.681236},
+		},
+		{
+			name:    "unsupported",
+			content: `{"type":"Feature","crs":{"type":"name","properties":{"name":"EPSG:30169"}},"geometry":` + point + `,"properties":{}}`,
+			err:     crs.ErrUnsupportedCRS,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ReprojectDecoder{Decoder: NewGeoJSONDecoder(strings.NewReader(tc.content)), CRS: tc.crs}.Decode()
+			if tc.err != nil {
+				assert.Same(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			coords := got[0].Geometry().(*nlslayer.Point).Coordinates()
+			assert.Len(t, coords, len(tc.want))
+			for i := range tc.want {
+				assert.InDelta(t, tc.want[i], coords[i], 1e-9)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package decoding
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+// Property types of custom property schemas of sketch layers
+const (
+	propertyTypeText     = "Text"
+	propertyTypeTextArea = "TextArea"
+	propertyTypeURL      = "URL"
+	propertyTypeFloat    = "Float"
+	propertyTypeInt      = "Int"
+	propertyTypeBoolean  = "Boolean"
+)
+
+// Schema infers a custom property schema of a sketch layer from properties of features.
+// Keys are sorted and each value is "<type>_<index>" where the index starts from 1.
+func Schema(features []nlslayer.Feature) map[string]any {
+	return MergeSchema(nil, features)
+}
+
+// MergeSchema adds keys of properties of features which are not included in the base schema.
+// Existing keys are kept as they are and new keys get indexes following the existing ones.
+func MergeSchema(base map[string]any, features []nlslayer.Feature) map[string]any {
+	types := map[string]string{}
+	for _, f := range features {
+		p := f.Properties()
+		if p == nil {
+			continue
+		}
+		for k, v := range *p {
+			if _, ok := base[k]; ok || v == nil {
+				continue
+			}
+			t := propertyType(v)
+			if prev, ok := types[k]; ok {
+				t = mergePropertyType(prev, t)
+			}
+			types[k] = t
+		}
+	}
+
+	keys := make([]string, 0, len(types))
+	for k := range types {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := make(map[string]any, len(base)+len(keys))
+	for k, v := range base {
+		res[k] = v
+	}
+	for i, k := range keys {
+		res[k] = fmt.Sprintf("%s_%d", types[k], len(base)+i+1)
+	}
+	return res
+}
+
+func propertyType(v any) string {
+	switch v := v.(type) {
+	case bool:
+		return propertyTypeBoolean
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return propertyTypeInt
+	case float32:
+		return numberType(float64(v))
+	case float64:
+		return numberType(v)
+	case string:
+		if strings.Contains(v, "\n") {
+			return propertyTypeTextArea
+		}
+		if u, err := url.Parse(v); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			return propertyTypeURL
+		}
+	}
+	return propertyTypeText
+}
+
+func numberType(v float64) string {
+	if v == math.Trunc(v) && !math.IsInf(v, 0) {
+		return propertyTypeInt
+	}
+	return propertyTypeFloat
+}
+
+// mergePropertyType returns a type which can hold values of both types.
+func mergePropertyType(a, b string) string {
+	switch {
+	case a == b:
+		return a
+	case isNumberType(a) && isNumberType(b):
+		return propertyTypeFloat
+	case isTextType(a) && isTextType(b):
+		if a == propertyTypeTextArea || b == propertyTypeTextArea {
+			return propertyTypeTextArea
+		}
+	}
+	return propertyTypeText
+}
+
+func isNumberType(t string) bool {
+	return t == propertyTypeInt || t == propertyTypeFloat
+}
+
+func isTextType(t string) bool {
+	return t == propertyTypeText || t == propertyTypeTextArea || t == propertyTypeURL
+}
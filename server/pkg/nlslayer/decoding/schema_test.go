@@ -0,0 +1,55 @@
+package decoding
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	newFeatureWith := func(p map[string]any) nlslayer.Feature {
+		return lo.Must(newFeature(newPoint([]float64{0, 0}), p))
+	}
+
+	features := []nlslayer.Feature{
+		newFeatureWith(map[string]any{
+			"name":    "a",
+			"count":   1.0,
+			"height":  1.0,
+			"visible": true,
+			"url":     "https://example.com",
+			"memo":    "a\nb",
+			"nested":  map[string]any{"a": 1},
+			"null":    nil,
+		}),
+		newFeatureWith(map[string]any{
+			"name":    "b",
+			"count":   2.0,
+			"height":  1.5,
+			"visible": "yes",
+			"url":     "https://example.com/b",
+			"memo":    "c",
+		}),
+	}
+
+	assert.Equal(t, map[string]any{
+		"count":   "Int_1",
+		"height":  "Float_2",
+		"memo":    "TextArea_3",
+		"name":    "Text_4",
+		"nested":  "Text_5",
+		"url":     "URL_6",
+		"visible": "Text_7",
+	}, Schema(features))
+
+	assert.Equal(t, map[string]any{}, Schema(nil))
+
+	assert.Equal(t, map[string]any{
+		"name":  "TextArea_1",
+		"count": "Int_2",
+	}, MergeSchema(map[string]any{"name": "TextArea_1"}, []nlslayer.Feature{
+		newFeatureWith(map[string]any{"name": "a", "count": 1}),
+	}))
+}
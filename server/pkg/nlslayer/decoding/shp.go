@@ -0,0 +1,192 @@
+package decoding
+
+import (
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/shp"
+)
+
+type ShapeReader interface {
+	Next() bool
+	Shape() (int, shp.Shape)
+	Err() error
+}
+
+// shapeAttributeReader is implemented by shape readers which can read attributes from a DBF table.
+type shapeAttributeReader interface {
+	Attributes() map[string]any
+}
+
+// shapeProjectionReader is implemented by shape readers which can read a .prj file.
+type shapeProjectionReader interface {
+	Projection() string
+}
+
+type ShapeDecoder struct {
+	reader ShapeReader
+	crs    *crs.CRS
+	crsErr error
+}
+
+func NewShapeDecoder(r ShapeReader) *ShapeDecoder {
+	return &ShapeDecoder{
+		reader: r,
+	}
+}
+
+// Decode decodes all shapes in the file. Null shapes and multipatches are skipped.
+// Attributes in the DBF table become properties of features.
+func (d *ShapeDecoder) Decode() ([]nlslayer.Feature, error) {
+	if pr, ok := d.reader.(shapeProjectionReader); ok {
+		if prj := pr.Projection(); prj != "" {
+			d.crs, d.crsErr = crs.Parse(prj)
+		}
+	}
+
+	ar, _ := d.reader.(shapeAttributeReader)
+	var res []nlslayer.Feature
+	for d.reader.Next() {
+		_, shape := d.reader.Shape()
+		g := shapeGeometry(shape)
+		if g == nil {
+			continue
+		}
+		var properties map[string]any
+		if ar != nil {
+			properties = ar.Attributes()
+		}
+		f, err := newFeature(g, properties)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, f)
+	}
+	if err := d.reader.Err(); err != nil {
+		return nil, ErrInvalidContent
+	}
+	return res, nil
+}
+
+// CRS returns the CRS defined in the .prj file. It returns nil if the file does not exist.
+func (d *ShapeDecoder) CRS() (*crs.CRS, error) {
+	return d.crs, d.crsErr
+}
+
+func shapeGeometry(shape shp.Shape) nlslayer.Geometry {
+	switch s := shape.(type) {
+	case *shp.Point:
+		return newPoint([]float64{s.X, s.Y})
+	case *shp.PointZ:
+		return newPoint([]float64{s.X, s.Y, s.Z})
+	case *shp.PointM:
+		return newPoint([]float64{s.X, s.Y})
+	case *shp.MultiPoint:
+		return shapeMultiPoint(shapeCoords(s.Points, nil))
+	case *shp.MultiPointZ:
+		return shapeMultiPoint(shapeCoords(s.Points, s.ZArray))
+	case *shp.MultiPointM:
+		return shapeMultiPoint(shapeCoords(s.Points, nil))
+	case *shp.PolyLine:
+		return shapeLineStrings(shapeParts(s.Parts, shapeCoords(s.Points, nil)))
+	case *shp.PolyLineZ:
+		return shapeLineStrings(shapeParts(s.Parts, shapeCoords(s.Points, s.ZArray)))
+	case *shp.PolyLineM:
+		return shapeLineStrings(shapeParts(s.Parts, shapeCoords(s.Points, nil)))
+	case *shp.Polygon:
+		return shapePolygons(shapeParts(s.Parts, shapeCoords(s.Points, nil)))
+	case *shp.PolygonZ:
+		return shapePolygons(shapeParts(s.Parts, shapeCoords(s.Points, s.ZArray)))
+	case *shp.PolygonM:
+		return shapePolygons(shapeParts(s.Parts, shapeCoords(s.Points, nil)))
+	}
+	return nil
+}
+
+func shapeCoords(points []shp.Point, z []float64) [][]float64 {
+	res := make([][]float64, 0, len(points))
+	for i, p := range points {
+		if i < len(z) {
+			res = append(res, []float64{p.X, p.Y, z[i]})
+		} else {
+			res = append(res, []float64{p.X, p.Y})
+		}
+	}
+	return res
+}
+
+// shapeParts splits coordinates into parts by the indexes of their first points.
+func shapeParts(parts []int32, coords [][]float64) [][][]float64 {
+	res := make([][][]float64, 0, len(parts))
+	for i, start := range parts {
+		end := len(coords)
+		if i+1 < len(parts) {
+			end = int(parts[i+1])
+		}
+		if int(start) < 0 || int(start) > end || end > len(coords) {
+			continue
+		}
+		res = append(res, coords[start:end])
+	}
+	return res
+}
+
+func shapeMultiPoint(coords [][]float64) nlslayer.Geometry {
+	if len(coords) == 0 {
+		return nil
+	}
+	if len(coords) == 1 {
+		return newPoint(coords[0])
+	}
+	geometries := make([]nlslayer.Geometry, 0, len(coords))
+	for _, c := range coords {
+		geometries = append(geometries, newPoint(c))
+	}
+	return newGeometryCollection(geometries)
+}
+
+func shapeLineStrings(parts [][][]float64) nlslayer.Geometry {
+	if len(parts) == 0 {
+		return nil
+	}
+	if len(parts) == 1 {
+		return newLineString(parts[0])
+	}
+	geometries := make([]nlslayer.Geometry, 0, len(parts))
+	for _, p := range parts {
+		geometries = append(geometries, newLineString(p))
+	}
+	return newGeometryCollection(geometries)
+}
+
+// shapePolygons groups rings into polygons. In shapefiles, outer rings are clockwise and
+// holes are counterclockwise, and each hole belongs to the outer ring preceding it.
+func shapePolygons(rings [][][]float64) nlslayer.Geometry {
+	var polygons [][][][]float64
+	for _, r := range rings {
+		if len(r) == 0 {
+			continue
+		}
+		if len(polygons) == 0 || isClockwise(r) {
+			polygons = append(polygons, [][][]float64{r})
+			continue
+		}
+		last := len(polygons) - 1
+		polygons[last] = append(polygons[last], r)
+	}
+
+	switch len(polygons) {
+	case 0:
+		return nil
+	case 1:
+		return newPolygon(polygons[0])
+	}
+	return nlslayer.NewMultiPolygon("MultiPolygon", polygons)
+}
+
+func isClockwise(ring [][]float64) bool {
+	var sum float64
+	for i := 0; i < len(ring)-1; i++ {
+		sum += (ring[i+1][0] - ring[i][0]) * (ring[i+1][1] + ring[i][1])
+	}
+	return sum > 0
+}
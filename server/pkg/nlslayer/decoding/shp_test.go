@@ -0,0 +1,126 @@
+package decoding
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/shp"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Decoder = &ShapeDecoder{}
+var _ ShapeReader = &shp.ZipReader{}
+var _ ShapeReader = &shp.Reader{}
+
+type shapeReaderMock struct {
+	shapes []shp.Shape
+	i      int
+}
+
+func (r *shapeReaderMock) Next() bool {
+	r.i++
+	return r.i <= len(r.shapes)
+}
+
+func (r *shapeReaderMock) Shape() (int, shp.Shape) {
+	return r.i - 1, r.shapes[r.i-1]
+}
+
+func (r *shapeReaderMock) Err() error {
+	return nil
+}
+
+func TestShapeDecoder_Decode(t *testing.T) {
+	outer := []shp.Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 0}, {X: 0, Y: 0}}
+	hole := []shp.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 1}}
+	outer2 := []shp.Point{{X: 10, Y: 10}, {X: 10, Y: 11}, {X: 11, Y: 11}, {X: 10, Y: 10}}
+
+	r := &shapeReaderMock{shapes: []shp.Shape{
+		&shp.Point{X: 1, Y: 2},
+		&shp.PointZ{X: 1, Y: 2, Z: 3},
+		&shp.Null{},
+		&shp.PolyLine{Parts: []int32{0}, Points: []shp.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+		&shp.PolyLine{Parts: []int32{0, 2}, Points: []shp.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}}},
+		&shp.Polygon{Parts: []int32{0, 5}, Points: append(append([]shp.Point{}, outer...), hole...)},
+		&shp.Polygon{Parts: []int32{0, 5}, Points: append(append([]shp.Point{}, outer...), outer2...)},
+		&shp.MultiPoint{Points: []shp.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}},
+	}}
+
+	got, err := NewShapeDecoder(r).Decode()
+	assert.NoError(t, err)
+	assert.Len(t, got, 7)
+
+	assert.Equal(t, []float64{1, 2}, got[0].Geometry().(*nlslayer.Point).Coordinates())
+	assert.Equal(t, &map[string]any{}, got[0].Properties())
+	assert.Equal(t, []float64{1, 2, 3}, got[1].Geometry().(*nlslayer.Point).Coordinates())
+	assert.Equal(t, [][]float64{{0, 0}, {1, 1}}, got[2].Geometry().(*nlslayer.LineString).Coordinates())
+	assert.Len(t, got[3].Geometry().(*nlslayer.GeometryCollection).Geometries(), 2)
+
+	polygon := got[4].Geometry().(*nlslayer.Polygon).Coordinates()
+	assert.Len(t, polygon, 2)
+	assert.Equal(t, []float64{1, 1}, polygon[1][0])
+
+	mp := got[5].Geometry().(*nlslayer.MultiPolygon).Coordinates()
+	assert.Len(t, mp, 2)
+	assert.Equal(t, []float64{10, 10}, mp[1][0][0])
+
+	assert.Len(t, got[6].Geometry().(*nlslayer.GeometryCollection).Geometries(), 2)
+}
+
+type attributedShapeReaderMock struct {
+	shapeReaderMock
+	attributes []map[string]any
+	projection string
+}
+
+func (r *attributedShapeReaderMock) Attributes() map[string]any {
+	return r.attributes[r.i-1]
+}
+
+func (r *attributedShapeReaderMock) Projection() string {
+	return r.projection
+}
+
+func TestShapeDecoder_Decode_Attributes(t *testing.T) {
+	r := &attributedShapeReaderMock{
+		shapeReaderMock: shapeReaderMock{shapes: []shp.Shape{
+			&shp.Point{X: 1, Y: 2},
+			&shp.Null{},
+			&shp.Point{X: 3, Y: 4},
+		}},
+		attributes: []map[string]any{
+			{"NAME": "a", "POP": int64(1)},
+			{"NAME": "b", "POP": int64(2)},
+			{"NAME": "c", "POP": nil},
+		},
+		projection: shp.WGS84Projection,
+	}
+
+	got, err := NewShapeDecoder(r).Decode()
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, &map[string]any{"NAME": "a", "POP": int64(1)}, got[0].Properties())
+	assert.Equal(t, &map[string]any{"NAME": "c", "POP": nil}, got[1].Properties())
+
+	r = &attributedShapeReaderMock{
+		shapeReaderMock: shapeReaderMock{shapes: []shp.Shape{&shp.Point{X: 1, Y: 2}}},
+		attributes:      []map[string]any{nil},
+		projection:      `PROJCS["WGS 84 / Pseudo-Mercator",GEOGCS["WGS 84"]]`,
+	}
+	_, err = ReprojectDecoder{Decoder: NewShapeDecoder(r)}.Decode()
+	assert.Same(t, crs.ErrUnsupportedCRS, err)
+
+	webMercator := lo.Must(crs.EPSG(3857))
+	r = &attributedShapeReaderMock{
+		shapeReaderMock: shapeReaderMock{shapes: []shp.Shape{&shp.Point{X: -11169055.58, Y: 2800000}}},
+		attributes:      []map[string]any{nil},
+		projection:      webMercator.WKT(),
+	}
+	got, err = ReprojectDecoder{Decoder: NewShapeDecoder(r)}.Decode()
+	assert.NoError(t, err)
+	coords := got[0].Geometry().(*nlslayer.Point).Coordinates()
+	assert.InDelta(t, -(100 + 20.0/60), coords[0], 1e-7)
+	assert.InDelta(t, 24+22.0/60+54.433/3600, coords[1], 1e-7)
+}
@@ -0,0 +1,153 @@
+package encoding
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/reearth/reearth/server/pkg/czml"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+type CZMLEncoder struct {
+	writer io.Writer
+}
+
+func NewCZMLEncoder(w io.Writer) *CZMLEncoder {
+	return &CZMLEncoder{
+		writer: w,
+	}
+}
+
+func (*CZMLEncoder) MimeType() string {
+	return "application/json"
+}
+
+// cartographicDegrees flattens coordinates into a list of longitude, latitude and height.
+func (e *CZMLEncoder) cartographicDegrees(c [][]float64) []float64 {
+	res := make([]float64, 0, len(c)*3)
+	for _, p := range c {
+		res = append(res, e.cartographicDegree(p)...)
+	}
+	return res
+}
+
+func (e *CZMLEncoder) cartographicDegree(p []float64) []float64 {
+	res := []float64{0, 0, 0}
+	copy(res, p)
+	return res
+}
+
+func (e *CZMLEncoder) polygon(rings [][][]float64) *czml.Polygon {
+	if len(rings) == 0 {
+		return nil
+	}
+	res := &czml.Polygon{
+		Positions: czml.Position{CartographicDegrees: e.cartographicDegrees(rings[0])},
+	}
+	if len(rings) > 1 {
+		holes := make([][]float64, 0, len(rings)-1)
+		for _, r := range rings[1:] {
+			holes = append(holes, e.cartographicDegrees(r))
+		}
+		res.Holes = &czml.Holes{CartographicDegrees: holes}
+	}
+	return res
+}
+
+// encodeGeometry sets graphics of the geometry to the packet. Since a packet can have only one graphics of each type,
+// parts of multi geometries are encoded as child packets.
+func (e *CZMLEncoder) encodeGeometry(g nlslayer.Geometry, packet *czml.Feature) ([]*czml.Feature, error) {
+	var parts []nlslayer.Geometry
+	switch g := g.(type) {
+	case *nlslayer.Point:
+		packet.Position = &czml.Position{CartographicDegrees: e.cartographicDegree(g.Coordinates())}
+		packet.Point = &czml.Point{}
+		return nil, nil
+	case *nlslayer.LineString:
+		packet.Polyline = &czml.Polyline{Positions: czml.Position{CartographicDegrees: e.cartographicDegrees(g.Coordinates())}}
+		return nil, nil
+	case *nlslayer.Polygon:
+		packet.Polygon = e.polygon(g.Coordinates())
+		return nil, nil
+	case *nlslayer.MultiPolygon:
+		for _, p := range g.Coordinates() {
+			parts = append(parts, nlslayer.NewPolygon("Polygon", p))
+		}
+	case *nlslayer.GeometryCollection:
+		parts = g.Geometries()
+	default:
+		return nil, ErrUnsupportedGeometry
+	}
+
+	var res []*czml.Feature
+	for i, p := range parts {
+		child := &czml.Feature{
+			Id:     packet.Id + "_" + strconv.Itoa(i),
+			Parent: packet.Id,
+		}
+		children, err := e.encodeGeometry(p, child)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, child)
+		res = append(res, children...)
+	}
+	return res, nil
+}
+
+func (e *CZMLEncoder) encodeFeature(f nlslayer.Feature, parent string) ([]*czml.Feature, error) {
+	packet := &czml.Feature{
+		Id:     f.ID().String(),
+		Name:   featureName(f),
+		Parent: parent,
+	}
+	if _, properties := featureProperties(f); len(properties) > 0 {
+		packet.Properties = properties
+	}
+	children, err := e.encodeGeometry(f.Geometry(), packet)
+	if err != nil {
+		return nil, err
+	}
+	return append([]*czml.Feature{packet}, children...), nil
+}
+
+// encodeLayer encodes the layer as a packet without graphics, which is a parent of packets of its features and children.
+func (e *CZMLEncoder) encodeLayer(l *Layer, parent string) ([]*czml.Feature, error) {
+	res := []*czml.Feature{{
+		Id:     l.ID.String(),
+		Name:   l.Title,
+		Parent: parent,
+	}}
+
+	for _, f := range l.Features {
+		packets, err := e.encodeFeature(f, l.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, packets...)
+	}
+
+	for _, c := range l.Children {
+		packets, err := e.encodeLayer(c, l.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, packets...)
+	}
+	return res, nil
+}
+
+func (e *CZMLEncoder) Encode(l *Layer) error {
+	packets, err := e.encodeLayer(l, "")
+	if err != nil {
+		return err
+	}
+
+	res := append([]*czml.Feature{{
+		Id:      "document",
+		Name:    l.Title,
+		Version: "1.0",
+	}}, packets...)
+	return json.NewEncoder(e.writer).Encode(res)
+}
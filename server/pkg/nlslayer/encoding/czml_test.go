@@ -0,0 +1,52 @@
+package encoding
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/czml"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Encoder = (*CZMLEncoder)(nil)
+
+func TestCZMLEncoder_Encode(t *testing.T) {
+	f1 := testFeature(nlslayer.NewPoint("Point", []float64{1, 2}), map[string]any{"name": "a"})
+	f2 := testFeature(nlslayer.NewMultiPolygon("MultiPolygon", [][][][]float64{
+		{{{0, 0}, {4, 0}, {4, 4}, {0, 0}}, {{1, 1}, {2, 1}, {2, 2}, {1, 1}}},
+		{{{10, 10}, {11, 10}, {11, 11}, {10, 10}}},
+	}), nil)
+	child := &Layer{ID: id.NewNLSLayerID(), Title: "child", Features: []nlslayer.Feature{f1, f2}}
+	l := &Layer{ID: id.NewNLSLayerID(), Title: "group", Group: true, Children: []*Layer{child}}
+
+	var b bytes.Buffer
+	assert.NoError(t, NewCZMLEncoder(&b).Encode(l))
+
+	var got []czml.Feature
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &got))
+	assert.Len(t, got, 7)
+	assert.Equal(t, czml.Feature{Id: "document", Name: "group", Version: "1.0"}, got[0])
+	assert.Equal(t, czml.Feature{Id: l.ID.String(), Name: "group"}, got[1])
+	assert.Equal(t, czml.Feature{Id: child.ID.String(), Name: "child", Parent: l.ID.String()}, got[2])
+	assert.Equal(t, czml.Feature{
+		Id:         f1.ID().String(),
+		Name:       "a",
+		Parent:     child.ID.String(),
+		Position:   &czml.Position{CartographicDegrees: []float64{1, 2, 0}},
+		Point:      &czml.Point{},
+		Properties: map[string]any{"name": "a"},
+	}, got[3])
+
+	// parts of the multi polygon
+	assert.Equal(t, f2.ID().String(), got[4].Id)
+	assert.Nil(t, got[4].Polygon)
+	assert.Equal(t, f2.ID().String()+"_0", got[5].Id)
+	assert.Equal(t, f2.ID().String(), got[5].Parent)
+	assert.Equal(t, []float64{0, 0, 0, 4, 0, 0, 4, 4, 0, 0, 0, 0}, got[5].Polygon.Positions.CartographicDegrees)
+	assert.Len(t, got[5].Polygon.Holes.CartographicDegrees, 1)
+	assert.Equal(t, f2.ID().String()+"_1", got[6].Id)
+	assert.Nil(t, got[6].Polygon.Holes)
+}
@@ -0,0 +1,186 @@
+package encoding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+var ErrCRSNotSupported = errors.New("the format does not support coordinate reference systems other than WGS84")
+
+// crsEncoder is implemented by encoders which can write coordinates in CRSs other than WGS84.
+type crsEncoder interface {
+	setCRS(*crs.CRS)
+}
+
+// Layer is a layer to be encoded. Features are sketch features of a simple layer and
+// children are set only when the layer is a group.
+type Layer struct {
+	ID       nlslayer.ID
+	Title    string
+	Group    bool
+	Features []nlslayer.Feature
+	Children []*Layer
+}
+
+// AllFeatures returns features of the layer and all of its descendants.
+func (l *Layer) AllFeatures() []nlslayer.Feature {
+	if l == nil {
+		return nil
+	}
+	res := append([]nlslayer.Feature{}, l.Features...)
+	for _, c := range l.Children {
+		res = append(res, c.AllFeatures()...)
+	}
+	return res
+}
+
+// Exporter exports layers with Encoder. Coordinates are transformed into CRS unless it is nil.
+type Exporter struct {
+	Loader  nlslayer.Loader
+	Encoder Encoder
+	CRS     *crs.CRS
+}
+
+// ValidateCRS returns ErrCRSNotSupported if the encoder cannot write coordinates in the CRS.
+func (e *Exporter) ValidateCRS() error {
+	if e.CRS.IsWGS84() {
+		return nil
+	}
+	if _, ok := e.Encoder.(crsEncoder); !ok {
+		return ErrCRSNotSupported
+	}
+	return nil
+}
+
+func (e *Exporter) ExportLayerByID(ctx context.Context, lid nlslayer.ID) error {
+	if e == nil {
+		return nil
+	}
+	l, err := e.Loader(ctx, lid)
+	if err != nil {
+		return err
+	}
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	return e.ExportLayer(ctx, *l[0])
+}
+
+func (e *Exporter) ExportLayer(ctx context.Context, l nlslayer.NLSLayer) error {
+	if e == nil {
+		return nil
+	}
+	m, err := e.Load(ctx, l)
+	if err != nil {
+		return err
+	}
+	if !e.CRS.IsWGS84() {
+		if err := e.ValidateCRS(); err != nil {
+			return err
+		}
+		e.Encoder.(crsEncoder).setCRS(e.CRS)
+		m.reproject(e.CRS.FromWGS84)
+	}
+	return e.Encoder.Encode(m)
+}
+
+// reproject replaces features of the layer and its descendants with copies whose geometries are transformed with f.
+func (l *Layer) reproject(f func(x, y float64) (float64, float64)) {
+	if l == nil {
+		return
+	}
+	features := make([]nlslayer.Feature, 0, len(l.Features))
+	for _, ft := range l.Features {
+		ft.UpdateGeometry(nlslayer.TransformGeometry(ft.Geometry(), f))
+		features = append(features, ft)
+	}
+	l.Features = features
+	for _, c := range l.Children {
+		c.reproject(f)
+	}
+}
+
+// Load loads children of the layer recursively.
+func (e *Exporter) Load(ctx context.Context, l nlslayer.NLSLayer) (*Layer, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	res := &Layer{
+		ID:    l.ID(),
+		Title: l.Title(),
+	}
+	if s := l.Sketch(); s != nil && s.FeatureCollection() != nil {
+		res.Features = s.FeatureCollection().Features()
+	}
+
+	g := nlslayer.ToNLSLayerGroup(l)
+	if g == nil {
+		return res, nil
+	}
+	res.Group = true
+	if g.Children().LayerCount() == 0 {
+		return res, nil
+	}
+
+	children, err := e.Loader(ctx, g.Children().Layers()...)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range children {
+		if c == nil {
+			continue
+		}
+		cl, err := e.Load(ctx, *c)
+		if err != nil {
+			return nil, err
+		}
+		res.Children = append(res.Children, cl)
+	}
+	return res, nil
+}
+
+// featureName returns the name property of the feature if any.
+func featureName(f nlslayer.Feature) string {
+	if p := f.Properties(); p != nil {
+		if n, ok := (*p)["name"].(string); ok {
+			return n
+		}
+	}
+	return ""
+}
+
+// featureProperties returns properties of the feature with sorted keys.
+func featureProperties(f nlslayer.Feature) ([]string, map[string]any) {
+	p := f.Properties()
+	if p == nil {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(*p))
+	for k := range *p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, *p
+}
+
+// stringValue converts a property value into a string for formats which only support string values.
+func stringValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		if b, err := json.Marshal(v); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprint(v)
+}
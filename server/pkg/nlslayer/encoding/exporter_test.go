@@ -0,0 +1,77 @@
+package encoding
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	geojson "github.com/paulmach/go.geojson"
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFeature(g nlslayer.Geometry, properties map[string]any) nlslayer.Feature {
+	f := lo.Must(nlslayer.NewFeatureWithNewId("Feature", g))
+	f.UpdateProperties(&properties)
+	return *f
+}
+
+func testSketchLayer(title string, features ...nlslayer.Feature) *nlslayer.NLSLayerSimple {
+	return nlslayer.NewNLSLayerSimple().NewID().Scene(id.NewSceneID()).Title(title).IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", features))).MustBuild()
+}
+
+func TestExporter_ExportLayerByID(t *testing.T) {
+	f1 := testFeature(nlslayer.NewPoint("Point", []float64{1, 2}), map[string]any{"name": "a"})
+	f2 := testFeature(nlslayer.NewPoint("Point", []float64{3, 4}), map[string]any{"name": "b"})
+	l1 := testSketchLayer("l1", f1)
+	l2 := testSketchLayer("l2", f2)
+	l3 := nlslayer.NewNLSLayerSimple().NewID().Scene(id.NewSceneID()).Title("l3").MustBuild()
+	g := nlslayer.NewNLSLayerGroup().NewID().Scene(id.NewSceneID()).Title("group").
+		Layers(nlslayer.NewIDList([]nlslayer.ID{l1.ID(), l2.ID(), l3.ID()})).MustBuild()
+	loader := nlslayer.LoaderFrom([]nlslayer.NLSLayer{l1, l2, l3, g})
+
+	e := &Exporter{Loader: loader}
+	got, err := e.Load(context.Background(), g)
+	assert.NoError(t, err)
+	assert.Equal(t, &Layer{
+		ID:    g.ID(),
+		Title: "group",
+		Group: true,
+		Children: []*Layer{
+			{ID: l1.ID(), Title: "l1", Features: []nlslayer.Feature{f1}},
+			{ID: l2.ID(), Title: "l2", Features: []nlslayer.Feature{f2}},
+			{ID: l3.ID(), Title: "l3"},
+		},
+	}, got)
+	assert.Equal(t, []nlslayer.Feature{f1, f2}, got.AllFeatures())
+
+	var b bytes.Buffer
+	e.Encoder = NewGeoJSONEncoder(&b)
+	assert.NoError(t, e.ExportLayerByID(context.Background(), g.ID()))
+	assert.Contains(t, b.String(), `"FeatureCollection"`)
+}
+
+func TestExporter_ExportLayer_CRS(t *testing.T) {
+	c := lo.Must(crs.EPSG(6677))
+	f := testFeature(nlslayer.NewPoint("Point", []float64{139.767125, 35.681236, 10}), map[string]any{"name": "a"})
+	l := testSketchLayer("l", f)
+
+	var b bytes.Buffer
+	e := &Exporter{Loader: nlslayer.LoaderFrom([]nlslayer.NLSLayer{l}), Encoder: NewGeoJSONEncoder(&b), CRS: c}
+	assert.NoError(t, e.ExportLayer(context.Background(), l))
+
+	fc, err := geojson.UnmarshalFeatureCollection(b.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:ogc:def:crs:EPSG::6677", fc.CRS["properties"].(map[string]any)["name"])
+	x, y := c.FromWGS84(139.767125, 35.681236)
+	assert.InDeltaSlice(t, []float64{x, y, 10}, fc.Features[0].Geometry.Point, 1e-6)
+	// the original feature is not modified
+	assert.Equal(t, []float64{139.767125, 35.681236, 10}, l.Sketch().FeatureCollection().Features()[0].Geometry().(*nlslayer.Point).Coordinates())
+
+	e.Encoder = NewKMLEncoder(&b)
+	assert.Same(t, ErrCRSNotSupported, e.ExportLayer(context.Background(), l))
+}
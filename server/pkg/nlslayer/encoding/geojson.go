@@ -0,0 +1,97 @@
+package encoding
+
+import (
+	"errors"
+	"io"
+
+	geojson "github.com/paulmach/go.geojson"
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+var ErrUnsupportedGeometry = errors.New("unsupported geometry")
+
+type GeoJSONEncoder struct {
+	writer io.Writer
+	crs    *crs.CRS
+}
+
+func NewGeoJSONEncoder(w io.Writer) *GeoJSONEncoder {
+	return &GeoJSONEncoder{
+		writer: w,
+	}
+}
+
+func (*GeoJSONEncoder) MimeType() string {
+	return "application/json"
+}
+
+func (e *GeoJSONEncoder) setCRS(c *crs.CRS) {
+	e.crs = c
+}
+
+func (e *GeoJSONEncoder) encodeGeometry(g nlslayer.Geometry) (*geojson.Geometry, error) {
+	switch g := g.(type) {
+	case *nlslayer.Point:
+		return geojson.NewPointGeometry(g.Coordinates()), nil
+	case *nlslayer.LineString:
+		return geojson.NewLineStringGeometry(g.Coordinates()), nil
+	case *nlslayer.Polygon:
+		return geojson.NewPolygonGeometry(g.Coordinates()), nil
+	case *nlslayer.MultiPolygon:
+		return geojson.NewMultiPolygonGeometry(g.Coordinates()...), nil
+	case *nlslayer.GeometryCollection:
+		geometries := make([]*geojson.Geometry, 0, len(g.Geometries()))
+		for _, c := range g.Geometries() {
+			cg, err := e.encodeGeometry(c)
+			if err != nil {
+				return nil, err
+			}
+			geometries = append(geometries, cg)
+		}
+		return geojson.NewCollectionGeometry(geometries...), nil
+	}
+	return nil, ErrUnsupportedGeometry
+}
+
+func (e *GeoJSONEncoder) encodeFeature(f nlslayer.Feature) (*geojson.Feature, error) {
+	g, err := e.encodeGeometry(f.Geometry())
+	if err != nil {
+		return nil, err
+	}
+	res := geojson.NewFeature(g)
+	res.ID = f.ID().String()
+	_, properties := featureProperties(f)
+	for k, v := range properties {
+		res.SetProperty(k, v)
+	}
+	return res, nil
+}
+
+// Encode encodes all features of the layer and its descendants into a feature collection,
+// since GeoJSON cannot nest feature collections.
+func (e *GeoJSONEncoder) Encode(l *Layer) error {
+	fc := geojson.NewFeatureCollection()
+	for _, f := range l.AllFeatures() {
+		gf, err := e.encodeFeature(f)
+		if err != nil {
+			return err
+		}
+		fc.AddFeature(gf)
+	}
+
+	if !e.crs.IsWGS84() {
+		// the "crs" member defined in the 2008 GeoJSON specification
+		fc.CRS = map[string]any{
+			"type":       "name",
+			"properties": map[string]any{"name": e.crs.URN()},
+		}
+	}
+
+	data, err := fc.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	_, err = e.writer.Write(data)
+	return err
+}
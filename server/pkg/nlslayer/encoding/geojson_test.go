@@ -0,0 +1,38 @@
+package encoding
+
+import (
+	"bytes"
+	"testing"
+
+	geojson "github.com/paulmach/go.geojson"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Encoder = (*GeoJSONEncoder)(nil)
+
+func TestGeoJSONEncoder_Encode(t *testing.T) {
+	f1 := testFeature(nlslayer.NewPoint("Point", []float64{1, 2}), map[string]any{"name": "a", "count": 1.0})
+	f2 := testFeature(nlslayer.NewGeometryCollection("GeometryCollection", []nlslayer.Geometry{
+		nlslayer.NewLineString("LineString", [][]float64{{0, 0}, {1, 1}}),
+		nlslayer.NewMultiPolygon("MultiPolygon", [][][][]float64{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}),
+	}), nil)
+	l := &Layer{
+		Title:    "group",
+		Group:    true,
+		Children: []*Layer{{Features: []nlslayer.Feature{f1}}, {Features: []nlslayer.Feature{f2}}},
+	}
+
+	var b bytes.Buffer
+	assert.NoError(t, NewGeoJSONEncoder(&b).Encode(l))
+
+	got, err := geojson.UnmarshalFeatureCollection(b.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, got.Features, 2)
+	assert.Equal(t, f1.ID().String(), got.Features[0].ID)
+	assert.Equal(t, []float64{1, 2}, got.Features[0].Geometry.Point)
+	assert.Equal(t, map[string]any{"name": "a", "count": 1.0}, got.Features[0].Properties)
+	assert.Equal(t, geojson.GeometryCollection, got.Features[1].Geometry.Type)
+	assert.Equal(t, [][]float64{{0, 0}, {1, 1}}, got.Features[1].Geometry.Geometries[0].LineString)
+	assert.Len(t, got.Features[1].Geometry.Geometries[1].MultiPolygon, 1)
+}
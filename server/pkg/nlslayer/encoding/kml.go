@@ -0,0 +1,145 @@
+package encoding
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	kml "github.com/twpayne/go-kml"
+)
+
+type KMLEncoder struct {
+	writer io.Writer
+}
+
+func NewKMLEncoder(w io.Writer) *KMLEncoder {
+	return &KMLEncoder{
+		writer: w,
+	}
+}
+
+func (*KMLEncoder) MimeType() string {
+	return "application/xml"
+}
+
+func (e *KMLEncoder) coordinates(c [][]float64) *kml.CoordinatesElement {
+	res := make([]kml.Coordinate, 0, len(c))
+	for _, p := range c {
+		res = append(res, e.coordinate(p))
+	}
+	return kml.Coordinates(res...)
+}
+
+func (e *KMLEncoder) coordinate(p []float64) kml.Coordinate {
+	var c kml.Coordinate
+	if len(p) > 0 {
+		c.Lon = p[0]
+	}
+	if len(p) > 1 {
+		c.Lat = p[1]
+	}
+	if len(p) > 2 {
+		c.Alt = p[2]
+	}
+	return c
+}
+
+func (e *KMLEncoder) polygon(rings [][][]float64) *kml.CompoundElement {
+	res := kml.Polygon()
+	// the first ring is the outer boundary and the others are holes
+	for i, r := range rings {
+		if i == 0 {
+			res.Add(kml.OuterBoundaryIs(kml.LinearRing(e.coordinates(r))))
+		} else {
+			res.Add(kml.InnerBoundaryIs(kml.LinearRing(e.coordinates(r))))
+		}
+	}
+	return res
+}
+
+func (e *KMLEncoder) encodeGeometry(g nlslayer.Geometry) (kml.Element, error) {
+	switch g := g.(type) {
+	case *nlslayer.Point:
+		return kml.Point(kml.Coordinates(e.coordinate(g.Coordinates()))), nil
+	case *nlslayer.LineString:
+		return kml.LineString(e.coordinates(g.Coordinates())), nil
+	case *nlslayer.Polygon:
+		return e.polygon(g.Coordinates()), nil
+	case *nlslayer.MultiPolygon:
+		res := kml.MultiGeometry()
+		for _, p := range g.Coordinates() {
+			res.Add(e.polygon(p))
+		}
+		return res, nil
+	case *nlslayer.GeometryCollection:
+		res := kml.MultiGeometry()
+		for _, c := range g.Geometries() {
+			cg, err := e.encodeGeometry(c)
+			if err != nil {
+				return nil, err
+			}
+			res.Add(cg)
+		}
+		return res, nil
+	}
+	return nil, ErrUnsupportedGeometry
+}
+
+func (e *KMLEncoder) encodeFeature(f nlslayer.Feature) (*kml.CompoundElement, error) {
+	g, err := e.encodeGeometry(f.Geometry())
+	if err != nil {
+		return nil, err
+	}
+
+	placemark := kml.Placemark()
+	placemark.Attr = append(placemark.Attr, xml.Attr{Name: xml.Name{Local: "id"}, Value: f.ID().String()})
+	if name := featureName(f); name != "" {
+		placemark.Add(kml.Name(name))
+	}
+
+	// properties are written as untyped extended data since custom property schemas are not part of KML
+	if keys, properties := featureProperties(f); len(keys) > 0 {
+		ed := kml.ExtendedData()
+		for _, k := range keys {
+			d := kml.Data(kml.Value(stringValue(properties[k])))
+			d.Attr = append(d.Attr, xml.Attr{Name: xml.Name{Local: "name"}, Value: k})
+			ed.Add(d)
+		}
+		placemark.Add(ed)
+	}
+
+	return placemark.Add(g), nil
+}
+
+func (e *KMLEncoder) encodeLayer(l *Layer, parent *kml.CompoundElement) (*kml.CompoundElement, error) {
+	if l.Title != "" {
+		parent.Add(kml.Name(l.Title))
+	}
+
+	for _, f := range l.Features {
+		placemark, err := e.encodeFeature(f)
+		if err != nil {
+			return nil, err
+		}
+		parent.Add(placemark)
+	}
+
+	for _, c := range l.Children {
+		folder, err := e.encodeLayer(c, kml.Folder())
+		if err != nil {
+			return nil, err
+		}
+		parent.Add(folder)
+	}
+
+	return parent, nil
+}
+
+// Encode encodes the layer into a document. Each child layer of a group becomes a folder.
+func (e *KMLEncoder) Encode(l *Layer) error {
+	doc, err := e.encodeLayer(l, kml.Document())
+	if err != nil {
+		return err
+	}
+	return kml.KML(doc).WriteIndent(e.writer, "", "  ")
+}
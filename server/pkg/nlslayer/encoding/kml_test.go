@@ -0,0 +1,61 @@
+package encoding
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/kml"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Encoder = (*KMLEncoder)(nil)
+
+func TestKMLEncoder_Encode(t *testing.T) {
+	f1 := testFeature(nlslayer.NewPoint("Point", []float64{1, 2, 3}), map[string]any{"name": "a", "count": 1.0, "tags": []any{"x"}})
+	f2 := testFeature(nlslayer.NewPolygon("Polygon", [][][]float64{
+		{{0, 0}, {4, 0}, {4, 4}, {0, 0}},
+		{{1, 1}, {2, 1}, {2, 2}, {1, 1}},
+	}), nil)
+	l := &Layer{
+		ID:    id.NewNLSLayerID(),
+		Title: "group",
+		Group: true,
+		Children: []*Layer{
+			{Title: "points", Features: []nlslayer.Feature{f1}},
+			{Title: "polygons", Features: []nlslayer.Feature{f2}},
+		},
+	}
+
+	var b bytes.Buffer
+	assert.NoError(t, NewKMLEncoder(&b).Encode(l))
+
+	var got struct {
+		Document struct {
+			Name    string `xml:"name"`
+			Folders []struct {
+				Name       string          `xml:"name"`
+				Placemarks []kml.Placemark `xml:"Placemark"`
+			} `xml:"Folder"`
+		} `xml:"Document"`
+	}
+	assert.NoError(t, xml.Unmarshal(b.Bytes(), &got))
+	assert.Equal(t, "group", got.Document.Name)
+	assert.Len(t, got.Document.Folders, 2)
+
+	p1 := got.Document.Folders[0].Placemarks[0]
+	assert.Equal(t, "points", got.Document.Folders[0].Name)
+	assert.Equal(t, "a", p1.Name)
+	assert.Equal(t, "1,2,3", p1.Point.Coordinates)
+	assert.Equal(t, []kml.Data{
+		{Name: "count", Value: "1"},
+		{Name: "name", Value: "a"},
+		{Name: "tags", Value: `["x"]`},
+	}, p1.ExtendedData.Data)
+
+	p2 := got.Document.Folders[1].Placemarks[0]
+	assert.Equal(t, "0,0 4,0 4,4 0,0", p2.Polygon.OuterBoundaryIs.LinearRing.Coordinates)
+	assert.Len(t, p2.Polygon.InnerBoundaryIs, 1)
+}
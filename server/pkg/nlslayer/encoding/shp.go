@@ -0,0 +1,239 @@
+package encoding
+
+import (
+	"errors"
+	"io"
+	"slices"
+
+	"github.com/reearth/reearth/server/pkg/crs"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/shp"
+)
+
+var ErrMixedGeometryTypes = errors.New("shapefile cannot contain features of different geometry types")
+
+type SHPEncoder struct {
+	writer io.Writer
+	prj    string
+}
+
+func NewSHPEncoder(w io.Writer) *SHPEncoder {
+	return &SHPEncoder{
+		writer: w,
+	}
+}
+
+func (*SHPEncoder) MimeType() string {
+	return "application/zip"
+}
+
+func (e *SHPEncoder) setCRS(c *crs.CRS) {
+	e.prj = c.WKT()
+}
+
+func (e *SHPEncoder) points(c [][]float64) []shp.Point {
+	res := make([]shp.Point, 0, len(c))
+	for _, p := range c {
+		if len(p) < 2 {
+			continue
+		}
+		res = append(res, shp.Point{X: p[0], Y: p[1]})
+	}
+	return res
+}
+
+func (e *SHPEncoder) bbox(points []shp.Point) shp.Box {
+	var b shp.Box
+	for i, p := range points {
+		if i == 0 {
+			b = p.BBox()
+			continue
+		}
+		b.ExtendWithPoint(p)
+	}
+	return b
+}
+
+// parts concatenates parts into points and returns indexes of the first point of each part.
+func (e *SHPEncoder) parts(parts [][]shp.Point) ([]shp.Point, []int32) {
+	var points []shp.Point
+	indexes := make([]int32, 0, len(parts))
+	for _, p := range parts {
+		indexes = append(indexes, int32(len(points)))
+		points = append(points, p...)
+	}
+	return points, indexes
+}
+
+// rings returns rings of polygons. Outer rings are clockwise and holes are counterclockwise in shapefiles.
+func (e *SHPEncoder) rings(polygons [][][][]float64) [][]shp.Point {
+	var res [][]shp.Point
+	for _, p := range polygons {
+		for i, r := range p {
+			points := e.points(r)
+			if isClockwise(points) != (i == 0) {
+				points = reversePoints(points)
+			}
+			res = append(res, points)
+		}
+	}
+	return res
+}
+
+// encodeGeometry converts a geometry into a shape. Geometry collections are supported only when all of
+// their geometries have the same type.
+func (e *SHPEncoder) encodeGeometry(g nlslayer.Geometry) (shp.Shape, shp.ShapeType, error) {
+	switch g := g.(type) {
+	case *nlslayer.Point:
+		c := g.Coordinates()
+		if len(c) < 2 {
+			return nil, 0, ErrUnsupportedGeometry
+		}
+		return &shp.Point{X: c[0], Y: c[1]}, shp.POINT, nil
+	case *nlslayer.LineString:
+		return e.polyLine([][]shp.Point{e.points(g.Coordinates())}), shp.POLYLINE, nil
+	case *nlslayer.Polygon:
+		return e.polygon(e.rings([][][][]float64{g.Coordinates()})), shp.POLYGON, nil
+	case *nlslayer.MultiPolygon:
+		return e.polygon(e.rings(g.Coordinates())), shp.POLYGON, nil
+	case *nlslayer.GeometryCollection:
+		return e.encodeGeometryCollection(g)
+	}
+	return nil, 0, ErrUnsupportedGeometry
+}
+
+func (e *SHPEncoder) encodeGeometryCollection(g *nlslayer.GeometryCollection) (shp.Shape, shp.ShapeType, error) {
+	var t shp.ShapeType
+	var points []shp.Point
+	var lines [][]shp.Point
+	var polygons [][][][]float64
+	for _, c := range g.Geometries() {
+		var ct shp.ShapeType
+		switch c := c.(type) {
+		case *nlslayer.Point:
+			ct = shp.MULTIPOINT
+			points = append(points, e.points([][]float64{c.Coordinates()})...)
+		case *nlslayer.LineString:
+			ct = shp.POLYLINE
+			lines = append(lines, e.points(c.Coordinates()))
+		case *nlslayer.Polygon:
+			ct = shp.POLYGON
+			polygons = append(polygons, c.Coordinates())
+		case *nlslayer.MultiPolygon:
+			ct = shp.POLYGON
+			polygons = append(polygons, c.Coordinates()...)
+		default:
+			return nil, 0, ErrUnsupportedGeometry
+		}
+		if t != 0 && t != ct {
+			return nil, 0, ErrMixedGeometryTypes
+		}
+		t = ct
+	}
+
+	switch t {
+	case shp.MULTIPOINT:
+		return &shp.MultiPoint{
+			Box:       e.bbox(points),
+			NumPoints: int32(len(points)),
+			Points:    points,
+		}, t, nil
+	case shp.POLYLINE:
+		return e.polyLine(lines), t, nil
+	case shp.POLYGON:
+		return e.polygon(e.rings(polygons)), t, nil
+	}
+	return nil, 0, ErrUnsupportedGeometry
+}
+
+func (e *SHPEncoder) polyLine(parts [][]shp.Point) *shp.PolyLine {
+	points, indexes := e.parts(parts)
+	return &shp.PolyLine{
+		Box:       e.bbox(points),
+		NumParts:  int32(len(indexes)),
+		NumPoints: int32(len(points)),
+		Parts:     indexes,
+		Points:    points,
+	}
+}
+
+func (e *SHPEncoder) polygon(rings [][]shp.Point) *shp.Polygon {
+	return (*shp.Polygon)(e.polyLine(rings))
+}
+
+// Encode writes all features of the layer and its descendants into a ZIP archive of a shapefile. Since a
+// shapefile has only one shape type, all features should have geometries of the same type.
+// Properties of features are written in the DBF table.
+func (e *SHPEncoder) Encode(l *Layer) error {
+	t := shp.NULL
+	var shapes []shp.Shape
+	var keys []string
+	var properties []map[string]any
+
+	for _, f := range l.AllFeatures() {
+		s, st, err := e.encodeGeometry(f.Geometry())
+		if err != nil {
+			return err
+		}
+		if t == shp.NULL {
+			t = st
+		} else if t != st {
+			return ErrMixedGeometryTypes
+		}
+		shapes = append(shapes, s)
+
+		k, p := featureProperties(f)
+		for _, k := range k {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+		properties = append(properties, p)
+	}
+	slices.Sort(keys)
+
+	records := make([][]any, 0, len(properties))
+	for _, p := range properties {
+		r := make([]any, 0, len(keys))
+		for _, k := range keys {
+			r = append(r, dbfValue(p[k]))
+		}
+		records = append(records, r)
+	}
+
+	fields := make([]shp.Field, 0, len(keys))
+	for i, n := range shp.FieldNames(keys) {
+		values := make([]any, 0, len(records))
+		for _, r := range records {
+			values = append(values, r[i])
+		}
+		fields = append(fields, shp.FieldFor(n, values))
+	}
+
+	return shp.WriteZip(e.writer, "layer", t, shapes, fields, records, e.prj)
+}
+
+// dbfValue converts a property value into a value which can be stored in a DBF table.
+func dbfValue(v any) any {
+	switch v.(type) {
+	case nil, bool, float64, float32, int, int32, int64:
+		return v
+	}
+	return stringValue(v)
+}
+
+func isClockwise(ring []shp.Point) bool {
+	var sum float64
+	for i := 0; i < len(ring)-1; i++ {
+		sum += (ring[i+1].X - ring[i].X) * (ring[i+1].Y + ring[i].Y)
+	}
+	return sum > 0
+}
+
+func reversePoints(points []shp.Point) []shp.Point {
+	res := make([]shp.Point, len(points))
+	for i, p := range points {
+		res[len(points)-1-i] = p
+	}
+	return res
+}
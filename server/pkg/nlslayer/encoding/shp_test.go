@@ -0,0 +1,52 @@
+package encoding
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/shp"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Encoder = (*SHPEncoder)(nil)
+
+func TestSHPEncoder_Encode(t *testing.T) {
+	// the outer ring is counterclockwise and the hole is clockwise, so both are reversed
+	f1 := testFeature(nlslayer.NewPolygon("Polygon", [][][]float64{
+		{{0, 0}, {4, 0}, {4, 4}, {0, 0}},
+		{{1, 1}, {2, 2}, {2, 1}, {1, 1}},
+	}), map[string]any{"name": "a", "population": float64(100), "area": 1.5, "visible": true, "tags": []any{"x"}})
+	f2 := testFeature(nlslayer.NewMultiPolygon("MultiPolygon", [][][][]float64{
+		{{{10, 10}, {10, 11}, {11, 11}, {10, 10}}},
+	}), map[string]any{"name": "b", "population_2020": float64(20)})
+	l := &Layer{Group: true, Children: []*Layer{{Features: []nlslayer.Feature{f1}}, {Features: []nlslayer.Feature{f2}}}}
+
+	var b bytes.Buffer
+	assert.NoError(t, NewSHPEncoder(&b).Encode(l))
+
+	r, err := shp.ReadZipFrom(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, shp.WGS84Projection, r.Projection())
+
+	assert.True(t, r.Next())
+	_, s := r.Shape()
+	p := s.(*shp.Polygon)
+	assert.Equal(t, []int32{0, 4}, p.Parts)
+	assert.Equal(t, []shp.Point{{X: 0, Y: 0}, {X: 4, Y: 4}, {X: 4, Y: 0}, {X: 0, Y: 0}}, p.Points[:4])
+	assert.Equal(t, []shp.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 1}}, p.Points[4:])
+	assert.Equal(t, map[string]any{
+		"area": 1.5, "name": "a", "population": int64(100), "populatio1": nil, "tags": `["x"]`, "visible": true,
+	}, r.Attributes())
+	assert.True(t, r.Next())
+	assert.Equal(t, map[string]any{
+		"area": nil, "name": "b", "population": nil, "populatio1": int64(20), "tags": "", "visible": nil,
+	}, r.Attributes())
+	assert.False(t, r.Next())
+	assert.NoError(t, r.Err())
+
+	// features of different geometry types
+	f3 := testFeature(nlslayer.NewPoint("Point", []float64{1, 2}), nil)
+	l = &Layer{Features: []nlslayer.Feature{f1, f3}}
+	assert.Same(t, ErrMixedGeometryTypes, NewSHPEncoder(&bytes.Buffer{}).Encode(l))
+}
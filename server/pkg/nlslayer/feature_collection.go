@@ -16,6 +16,13 @@ func NewFeatureCollection(featureCollectionType string, features []Feature) *Fea
 	}
 }
 
+func (fc *FeatureCollection) Clone() *FeatureCollection {
+	if fc == nil {
+		return nil
+	}
+	return NewFeatureCollection(fc.featureCollectionType, append(fc.features[:0:0], fc.features...))
+}
+
 func (fc *FeatureCollection) FeatureCollectionType() string {
 	return fc.featureCollectionType
 }
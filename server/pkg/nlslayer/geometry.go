@@ -243,3 +243,54 @@ func NewGeometryFromMap(data map[string]any) (Geometry, error) {
 
 	return nil, fmt.Errorf("unsupported geometry type: %s", geometryType)
 }
+
+// TransformGeometry returns a new geometry whose positions are transformed by f, which receives and returns
+// longitude and latitude, or x and y in projected coordinate reference systems. Other elements of positions
+// such as heights are kept as they are.
+func TransformGeometry(g Geometry, f func(x, y float64) (float64, float64)) Geometry {
+	position := func(c []float64) []float64 {
+		res := append([]float64{}, c...)
+		if len(res) >= 2 {
+			res[0], res[1] = f(res[0], res[1])
+		}
+		return res
+	}
+	line := func(c [][]float64) [][]float64 {
+		res := make([][]float64, 0, len(c))
+		for _, p := range c {
+			res = append(res, position(p))
+		}
+		return res
+	}
+	polygon := func(c [][][]float64) [][][]float64 {
+		res := make([][][]float64, 0, len(c))
+		for _, r := range c {
+			res = append(res, line(r))
+		}
+		return res
+	}
+
+	switch g := g.(type) {
+	case *Point:
+		return NewPoint(g.PointType(), position(g.Coordinates()))
+	case *LineString:
+		return NewLineString(g.LineStringType(), line(g.Coordinates()))
+	case *Polygon:
+		return NewPolygon(g.PolygonType(), polygon(g.Coordinates()))
+	case *MultiPolygon:
+		c := g.Coordinates()
+		res := make([][][][]float64, 0, len(c))
+		for _, p := range c {
+			res = append(res, polygon(p))
+		}
+		return NewMultiPolygon(g.MultiPolygonType(), res)
+	case *GeometryCollection:
+		geometries := g.Geometries()
+		res := make([]Geometry, 0, len(geometries))
+		for _, c := range geometries {
+			res = append(res, TransformGeometry(c, f))
+		}
+		return NewGeometryCollection(g.GeometryCollectionType(), res)
+	}
+	return g
+}
@@ -233,3 +233,22 @@ func TestNewGeometryFromMap(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformGeometry(t *testing.T) {
+	f := func(x, y float64) (float64, float64) { return x * 10, y + 1 }
+	p := NewPoint("Point", []float64{1, 2, 3})
+	l := NewLineString("LineString", [][]float64{{1, 2}, {3, 4}})
+	po := NewPolygon("Polygon", [][][]float64{{{1, 2}, {3, 4}, {5, 6}, {1, 2}}})
+	m := NewMultiPolygon("MultiPolygon", [][][][]float64{{{{1, 2}, {3, 4}, {5, 6}, {1, 2}}}})
+	gc := NewGeometryCollection("GeometryCollection", []Geometry{p, l, po, m})
+
+	got := TransformGeometry(gc, f).(*GeometryCollection)
+	assert.Equal(t, []Geometry{
+		NewPoint("Point", []float64{10, 3, 3}),
+		NewLineString("LineString", [][]float64{{10, 3}, {30, 5}}),
+		NewPolygon("Polygon", [][][]float64{{{10, 3}, {30, 5}, {50, 7}, {10, 3}}}),
+		NewMultiPolygon("MultiPolygon", [][][][]float64{{{{10, 3}, {30, 5}, {50, 7}, {10, 3}}}}),
+	}, got.Geometries())
+	// the original geometry is not modified
+	assert.Equal(t, []float64{1, 2, 3}, p.Coordinates())
+}
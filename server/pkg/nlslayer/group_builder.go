@@ -52,6 +52,11 @@ func (b *NLSLayerGroupBuilder) ID(id ID) *NLSLayerGroupBuilder {
 	return b
 }
 
+func (b *NLSLayerGroupBuilder) Version(v int) *NLSLayerGroupBuilder {
+	b.l.version = v
+	return b
+}
+
 func (b *NLSLayerGroupBuilder) NewID() *NLSLayerGroupBuilder {
 	b.l.id = NewID()
 	return b
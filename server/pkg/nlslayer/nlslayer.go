@@ -20,6 +20,8 @@ type NLSLayer interface {
 	HasSketch() bool
 	Sketch() *SketchInfo
 	SetSketch(*SketchInfo)
+	Version() int
+	SetVersion(int)
 }
 
 func ToNLSLayerGroup(l NLSLayer) *NLSLayerGroup {
@@ -68,6 +70,7 @@ type layerBase struct {
 	config    *Config
 	isSketch  bool
 	sketch    *SketchInfo
+	version   int
 }
 
 func (l *layerBase) ID() ID {
@@ -178,6 +181,7 @@ func (l *layerBase) Clone() *layerBase {
 		visible:   l.visible,
 		config:    clonedConfig,
 		isSketch:  l.isSketch,
+		version:   l.version,
 	}
 
 	if l.infobox != nil {
@@ -256,3 +260,17 @@ func (l *layerBase) SetSketchInfo(sketch *SketchInfo) {
 	}
 	l.sketch = sketch
 }
+
+func (l *layerBase) Version() int {
+	if l == nil {
+		return 0
+	}
+	return l.version
+}
+
+func (l *layerBase) SetVersion(v int) {
+	if l == nil {
+		return
+	}
+	l.version = v
+}
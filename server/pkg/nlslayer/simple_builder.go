@@ -52,6 +52,11 @@ func (b *NLSLayerSimpleBuilder) ID(id ID) *NLSLayerSimpleBuilder {
 	return b
 }
 
+func (b *NLSLayerSimpleBuilder) Version(v int) *NLSLayerSimpleBuilder {
+	b.l.version = v
+	return b
+}
+
 func (b *NLSLayerSimpleBuilder) NewID() *NLSLayerSimpleBuilder {
 	b.l.id = NewID()
 	return b
@@ -31,6 +31,6 @@ func (s *SketchInfo) Clone() *SketchInfo {
 
 	return &SketchInfo{
 		customPropertySchema: s.customPropertySchema,
-		featureCollection:    s.featureCollection,
+		featureCollection:    s.featureCollection.Clone(),
 	}
 }
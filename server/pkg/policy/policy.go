@@ -25,6 +25,20 @@ type Option struct {
 	AssetStorageSize      *int64
 	DatasetSchemaCount    *int
 	DatasetCount          *int
+	// NLSLayerCount is the max number of NLS layers per scene.
+	NLSLayerCount *int
+	// SketchFeatureCount is the max number of features per sketch layer.
+	SketchFeatureCount *int
+	// StoryCount is the max number of stories per scene.
+	StoryCount *int
+	// StoryPageCount is the max number of story pages per scene.
+	StoryPageCount *int
+	// PublishedStoryCount is the max number of published stories per workspace.
+	PublishedStoryCount *int
+	// PluginCount is the max number of uploaded private plugins per workspace.
+	PluginCount *int
+	// MaxUploadSize is the max size in bytes of a file uploaded at once.
+	MaxUploadSize *int64
 }
 
 func New(opts Option) *Policy {
@@ -71,6 +85,34 @@ func (p *Policy) EnforceDatasetCount(count int) error {
 	return p.error(p == nil || p.opts.DatasetCount == nil || *p.opts.DatasetCount >= count)
 }
 
+func (p *Policy) EnforceNLSLayerCount(count int) error {
+	return p.error(p == nil || p.opts.NLSLayerCount == nil || *p.opts.NLSLayerCount >= count)
+}
+
+func (p *Policy) EnforceSketchFeatureCount(count int) error {
+	return p.error(p == nil || p.opts.SketchFeatureCount == nil || *p.opts.SketchFeatureCount >= count)
+}
+
+func (p *Policy) EnforceStoryCount(count int) error {
+	return p.error(p == nil || p.opts.StoryCount == nil || *p.opts.StoryCount >= count)
+}
+
+func (p *Policy) EnforceStoryPageCount(count int) error {
+	return p.error(p == nil || p.opts.StoryPageCount == nil || *p.opts.StoryPageCount >= count)
+}
+
+func (p *Policy) EnforcePublishedStoryCount(count int) error {
+	return p.error(p == nil || p.opts.PublishedStoryCount == nil || *p.opts.PublishedStoryCount >= count)
+}
+
+func (p *Policy) EnforcePluginCount(count int) error {
+	return p.error(p == nil || p.opts.PluginCount == nil || *p.opts.PluginCount >= count)
+}
+
+func (p *Policy) EnforceUploadSize(size int64) error {
+	return p.error(p == nil || p.opts.MaxUploadSize == nil || *p.opts.MaxUploadSize >= size)
+}
+
 func (*Policy) error(ok bool) error {
 	if !ok {
 		return ErrPolicyViolation
@@ -96,5 +138,12 @@ func (p Option) Clone() Option {
 		AssetStorageSize:      util.CloneRef(p.AssetStorageSize),
 		DatasetSchemaCount:    util.CloneRef(p.DatasetSchemaCount),
 		DatasetCount:          util.CloneRef(p.DatasetCount),
+		NLSLayerCount:         util.CloneRef(p.NLSLayerCount),
+		SketchFeatureCount:    util.CloneRef(p.SketchFeatureCount),
+		StoryCount:            util.CloneRef(p.StoryCount),
+		StoryPageCount:        util.CloneRef(p.StoryPageCount),
+		PublishedStoryCount:   util.CloneRef(p.PublishedStoryCount),
+		PluginCount:           util.CloneRef(p.PluginCount),
+		MaxUploadSize:         util.CloneRef(p.MaxUploadSize),
 	}
 }
@@ -150,6 +150,125 @@ func TestPolicy_EnforceDatasetCount(t *testing.T) {
 	})
 }
 
+func TestPolicy_EnforceNLSLayerCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{NLSLayerCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforceNLSLayerCount(a)
+	})
+}
+
+func TestPolicy_EnforceSketchFeatureCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{SketchFeatureCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforceSketchFeatureCount(a)
+	})
+}
+
+func TestPolicy_EnforceStoryCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{StoryCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforceStoryCount(a)
+	})
+}
+
+func TestPolicy_EnforceStoryPageCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{StoryPageCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforceStoryPageCount(a)
+	})
+}
+
+func TestPolicy_EnforcePublishedStoryCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{PublishedStoryCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforcePublishedStoryCount(a)
+	})
+}
+
+func TestPolicy_EnforcePluginCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{PluginCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforcePluginCount(a)
+	})
+}
+
+func TestPolicy_EnforceUploadSize(t *testing.T) {
+	tests := []policyTest[int64]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 20000, arg: 19999, fail: false},
+		{limit: 20000, arg: 20000, fail: false},
+		{limit: 20000, arg: 20001, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int64) Option {
+		return Option{MaxUploadSize: lo.ToPtr(d)}
+	}, func(p *Policy, a int64) error {
+		return p.EnforceUploadSize(a)
+	})
+}
+
 func testPolicy[T any](t *testing.T, tests []policyTest[T], f func(d T) Option, tf func(p *Policy, a T) error) {
 	t.Helper()
 	for _, tt := range tests {
@@ -192,6 +311,13 @@ func TestPolicy_Clone(t *testing.T) {
 			AssetStorageSize:      lo.ToPtr(int64(1)),
 			DatasetSchemaCount:    lo.ToPtr(1),
 			DatasetCount:          lo.ToPtr(2),
+			NLSLayerCount:         lo.ToPtr(3),
+			SketchFeatureCount:    lo.ToPtr(4),
+			StoryCount:            lo.ToPtr(5),
+			StoryPageCount:        lo.ToPtr(6),
+			PublishedStoryCount:   lo.ToPtr(7),
+			PluginCount:           lo.ToPtr(8),
+			MaxUploadSize:         lo.ToPtr(int64(9)),
 		},
 	}
 	got := p.Clone()
@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
+)
+
+const (
+	UsageProjectCount          = "projectCount"
+	UsagePublishedProjectCount = "publishedProjectCount"
+	UsageLayerCount            = "layerCount"
+	UsageAssetStorageSize      = "assetStorageSize"
+	UsageDatasetSchemaCount    = "datasetSchemaCount"
+	UsageDatasetCount          = "datasetCount"
+)
+
+// Usage is the current usage of a workspace. Counts whose limits are applied to each scene or dataset schema
+// are the largest ones in the workspace, so that they can be compared with the limits.
+type Usage struct {
+	ProjectCount          int
+	PublishedProjectCount int
+	// LayerCount is the largest number of layers in a scene.
+	LayerCount       int
+	AssetStorageSize int64
+	// DatasetSchemaCount is the largest number of dataset schemas in a scene.
+	DatasetSchemaCount int
+	// DatasetCount is the largest number of datasets in a dataset schema.
+	DatasetCount int
+}
+
+// UsageItem is a used amount with its limit. Limit is nil if the amount is unlimited.
+type UsageItem struct {
+	Name  string
+	Used  int64
+	Limit *int64
+}
+
+// Percentage returns how much of the limit is used in percent. It returns nil if the amount is unlimited.
+func (i UsageItem) Percentage() *float64 {
+	if i.Limit == nil {
+		return nil
+	}
+	if *i.Limit <= 0 {
+		return lo.ToPtr(100.0)
+	}
+	return lo.ToPtr(float64(i.Used) * 100 / float64(*i.Limit))
+}
+
+// Exceeds returns true if the percentage is the threshold or more.
+func (i UsageItem) Exceeds(threshold float64) bool {
+	p := i.Percentage()
+	return p != nil && *p >= threshold
+}
+
+// UsageItems returns the items of the usage with the limits of the policy. A nil policy has no limits.
+func (p *Policy) UsageItems(u Usage) []UsageItem {
+	var o Option
+	if p != nil {
+		o = p.opts
+	}
+	return []UsageItem{
+		{Name: UsageProjectCount, Used: int64(u.ProjectCount), Limit: int64Ref(o.ProjectCount)},
+		{Name: UsagePublishedProjectCount, Used: int64(u.PublishedProjectCount), Limit: int64Ref(o.PublishedProjectCount)},
+		{Name: UsageLayerCount, Used: int64(u.LayerCount), Limit: int64Ref(o.LayerCount)},
+		{Name: UsageAssetStorageSize, Used: u.AssetStorageSize, Limit: util.CloneRef(o.AssetStorageSize)},
+		{Name: UsageDatasetSchemaCount, Used: int64(u.DatasetSchemaCount), Limit: int64Ref(o.DatasetSchemaCount)},
+		{Name: UsageDatasetCount, Used: int64(u.DatasetCount), Limit: int64Ref(o.DatasetCount)},
+	}
+}
+
+// ExceededUsageItems returns the items whose percentages are the threshold or more.
+func ExceededUsageItems(items []UsageItem, threshold float64) []UsageItem {
+	return lo.Filter(items, func(i UsageItem, _ int) bool {
+		return i.Exceeds(threshold)
+	})
+}
+
+func int64Ref(i *int) *int64 {
+	if i == nil {
+		return nil
+	}
+	return lo.ToPtr(int64(*i))
+}
@@ -0,0 +1,64 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageItem_Percentage(t *testing.T) {
+	tests := []struct {
+		name string
+		item UsageItem
+		want *float64
+	}{
+		{name: "unlimited", item: UsageItem{Used: 10}, want: nil},
+		{name: "half", item: UsageItem{Used: 5, Limit: lo.ToPtr(int64(10))}, want: lo.ToPtr(50.0)},
+		{name: "over", item: UsageItem{Used: 15, Limit: lo.ToPtr(int64(10))}, want: lo.ToPtr(150.0)},
+		{name: "zero limit", item: UsageItem{Used: 0, Limit: lo.ToPtr(int64(0))}, want: lo.ToPtr(100.0)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.item.Percentage())
+		})
+	}
+}
+
+func TestPolicy_UsageItems(t *testing.T) {
+	u := Usage{
+		ProjectCount:          1,
+		PublishedProjectCount: 2,
+		LayerCount:            3,
+		AssetStorageSize:      4,
+		DatasetSchemaCount:    5,
+		DatasetCount:          6,
+	}
+	p := New(Option{
+		ProjectCount:     lo.ToPtr(2),
+		LayerCount:       lo.ToPtr(3),
+		AssetStorageSize: lo.ToPtr(int64(100)),
+	})
+
+	items := p.UsageItems(u)
+	assert.Equal(t, []UsageItem{
+		{Name: UsageProjectCount, Used: 1, Limit: lo.ToPtr(int64(2))},
+		{Name: UsagePublishedProjectCount, Used: 2},
+		{Name: UsageLayerCount, Used: 3, Limit: lo.ToPtr(int64(3))},
+		{Name: UsageAssetStorageSize, Used: 4, Limit: lo.ToPtr(int64(100))},
+		{Name: UsageDatasetSchemaCount, Used: 5},
+		{Name: UsageDatasetCount, Used: 6},
+	}, items)
+	assert.Equal(t, []UsageItem{
+		{Name: UsageProjectCount, Used: 1, Limit: lo.ToPtr(int64(2))},
+		{Name: UsageLayerCount, Used: 3, Limit: lo.ToPtr(int64(3))},
+	}, ExceededUsageItems(items, 50))
+	assert.Equal(t, []UsageItem{
+		{Name: UsageLayerCount, Used: 3, Limit: lo.ToPtr(int64(3))},
+	}, ExceededUsageItems(items, 100))
+
+	assert.Empty(t, ExceededUsageItems((*Policy)(nil).UsageItems(u), 0))
+}
@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 )
 
@@ -56,13 +57,8 @@ func (b *Builder) IsBasicAuthActive(isBasicAuthActive bool) *Builder {
 	return b
 }
 
-func (b *Builder) BasicAuthUsername(basicAuthUsername string) *Builder {
-	b.p.basicAuthUsername = basicAuthUsername
-	return b
-}
-
-func (b *Builder) BasicAuthPassword(basicAuthPassword string) *Builder {
-	b.p.basicAuthPassword = basicAuthPassword
+func (b *Builder) BasicAuthCredentials(basicAuthCredentials basicauth.List) *Builder {
+	b.p.basicAuthCredentials = basicAuthCredentials.Clone()
 	return b
 }
 
@@ -156,3 +152,18 @@ func (b *Builder) Scene(sceneId SceneID) *Builder {
 	b.p.sceneId = sceneId
 	return b
 }
+
+func (b *Builder) PublishSchedule(publishSchedule *PublishSchedule) *Builder {
+	b.p.publishSchedule = publishSchedule
+	return b
+}
+
+func (b *Builder) Preview(preview *Preview) *Builder {
+	b.p.preview = preview
+	return b
+}
+
+func (b *Builder) IsTemplate(isTemplate bool) *Builder {
+	b.p.isTemplate = isTemplate
+	return b
+}
@@ -6,8 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -52,21 +54,24 @@ func TestBuilder_IsArchived(t *testing.T) {
 	assert.True(t, res.IsArchived())
 }
 
+func TestBuilder_IsTemplate(t *testing.T) {
+	var tb = New().NewID()
+	res := tb.IsTemplate(true).MustBuild()
+	assert.True(t, res.IsTemplate())
+}
+
 func TestBuilder_Experimental(t *testing.T) {
 	var tb = New().NewID()
 	res := tb.CoreSupport(true).MustBuild()
 	assert.True(t, res.CoreSupport())
 }
 
-func TestBuilder_BasicAuthUsername(t *testing.T) {
+func TestBuilder_BasicAuthCredentials(t *testing.T) {
 	var tb = New().NewID()
-	res := tb.BasicAuthUsername("username").MustBuild()
+	c := lo.Must(basicauth.NewCredential(basicauth.DefaultName, "username", "password"))
+	res := tb.BasicAuthCredentials(basicauth.List{c}).MustBuild()
 	assert.Equal(t, "username", res.BasicAuthUsername())
-}
-func TestBuilder_BasicAuthPassword(t *testing.T) {
-	var tb = New().NewID()
-	res := tb.BasicAuthPassword("password").MustBuild()
-	assert.Equal(t, "password", res.BasicAuthPassword())
+	assert.Equal(t, basicauth.List{c}, res.BasicAuthCredentials())
 }
 
 func TestBuilder_ImageURL(t *testing.T) {
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+const previewTokenLength = 32
+
+var ErrInvalidPreview = errors.New("invalid preview")
+
+// Preview is a short-lived build of a project which can be accessed only with its token.
+// It is independent from the alias and the publishment status of the project.
+type Preview struct {
+	token     string
+	createdAt time.Time
+	expiresAt time.Time
+}
+
+// NewPreview issues a preview with a new random token which expires after ttl.
+func NewPreview(now time.Time, ttl time.Duration) (*Preview, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidPreview
+	}
+
+	b := make([]byte, previewTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return &Preview{
+		token:     hex.EncodeToString(b),
+		createdAt: now,
+		expiresAt: now.Add(ttl),
+	}, nil
+}
+
+// PreviewFrom restores a preview which has been already issued.
+func PreviewFrom(token string, createdAt, expiresAt time.Time) (*Preview, error) {
+	if token == "" || !expiresAt.After(createdAt) {
+		return nil, ErrInvalidPreview
+	}
+	return &Preview{
+		token:     token,
+		createdAt: createdAt,
+		expiresAt: expiresAt,
+	}, nil
+}
+
+func (p *Preview) Token() string {
+	if p == nil {
+		return ""
+	}
+	return p.token
+}
+
+func (p *Preview) CreatedAt() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return p.createdAt
+}
+
+func (p *Preview) ExpiresAt() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return p.expiresAt
+}
+
+// IsExpired reports whether the preview can no longer be accessed at now. A nil preview is always expired.
+func (p *Preview) IsExpired(now time.Time) bool {
+	return p == nil || !now.Before(p.expiresAt)
+}
@@ -0,0 +1,47 @@
+package project
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPreview(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	p, err := NewPreview(now, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, p.Token(), previewTokenLength*2)
+	assert.Equal(t, now, p.CreatedAt())
+	assert.Equal(t, now.Add(time.Hour), p.ExpiresAt())
+
+	p2, _ := NewPreview(now, time.Hour)
+	assert.NotEqual(t, p.Token(), p2.Token())
+
+	_, err = NewPreview(now, 0)
+	assert.Equal(t, ErrInvalidPreview, err)
+}
+
+func TestPreviewFrom(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	p, err := PreviewFrom("token", now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, &Preview{token: "token", createdAt: now, expiresAt: now.Add(time.Hour)}, p)
+
+	_, err = PreviewFrom("", now, now.Add(time.Hour))
+	assert.Equal(t, ErrInvalidPreview, err)
+	_, err = PreviewFrom("token", now, now)
+	assert.Equal(t, ErrInvalidPreview, err)
+}
+
+func TestPreview_IsExpired(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p, _ := NewPreview(now, time.Hour)
+
+	assert.False(t, p.IsExpired(now))
+	assert.False(t, p.IsExpired(now.Add(59*time.Minute)))
+	assert.True(t, p.IsExpired(now.Add(time.Hour)))
+	assert.True(t, (*Preview)(nil).IsExpired(now))
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 )
 
@@ -15,28 +16,30 @@ var (
 )
 
 type Project struct {
-	id                ID
-	isArchived        bool
-	isBasicAuthActive bool
-	basicAuthUsername string
-	basicAuthPassword string
-	updatedAt         time.Time
-	publishedAt       time.Time
-	name              string
-	description       string
-	alias             string
-	imageURL          *url.URL
-	publicTitle       string
-	publicDescription string
-	publicImage       string
-	publicNoIndex     bool
-	workspace         WorkspaceID
-	visualizer        visualizer.Visualizer
-	publishmentStatus PublishmentStatus
-	coreSupport       bool
-	enableGa          bool
-	trackingId        string
-	sceneId           SceneID
+	id                   ID
+	isArchived           bool
+	isBasicAuthActive    bool
+	basicAuthCredentials basicauth.List
+	updatedAt            time.Time
+	publishedAt          time.Time
+	name                 string
+	description          string
+	alias                string
+	imageURL             *url.URL
+	publicTitle          string
+	publicDescription    string
+	publicImage          string
+	publicNoIndex        bool
+	workspace            WorkspaceID
+	visualizer           visualizer.Visualizer
+	publishmentStatus    PublishmentStatus
+	coreSupport          bool
+	enableGa             bool
+	trackingId           string
+	sceneId              SceneID
+	publishSchedule      *PublishSchedule
+	preview              *Preview
+	isTemplate           bool
 }
 
 func (p *Project) ID() ID {
@@ -47,16 +50,31 @@ func (p *Project) IsArchived() bool {
 	return p.isArchived
 }
 
+// IsTemplate returns whether the project is a template, from which new projects can be created.
+func (p *Project) IsTemplate() bool {
+	return p.isTemplate
+}
+
 func (p *Project) IsBasicAuthActive() bool {
 	return p.isBasicAuthActive
 }
 
+// BasicAuthUsername returns the username of the default basic auth credential.
 func (p *Project) BasicAuthUsername() string {
-	return p.basicAuthUsername
+	c := p.basicAuthCredentials.Find(basicauth.DefaultName)
+	if c.IsRevoked() {
+		return ""
+	}
+	return c.Username()
 }
 
-func (p *Project) BasicAuthPassword() string {
-	return p.basicAuthPassword
+func (p *Project) BasicAuthCredentials() basicauth.List {
+	return p.basicAuthCredentials.Clone()
+}
+
+// MatchBasicAuth reports whether the username and the password match one of the active basic auth credentials.
+func (p *Project) MatchBasicAuth(username, password string) bool {
+	return p.basicAuthCredentials.Match(username, password) != nil
 }
 
 func (p *Project) UpdatedAt() time.Time {
@@ -124,6 +142,14 @@ func (p *Project) PublishmentStatus() PublishmentStatus {
 	return p.publishmentStatus
 }
 
+func (p *Project) PublishSchedule() *PublishSchedule {
+	return p.publishSchedule
+}
+
+func (p *Project) Preview() *Preview {
+	return p.preview
+}
+
 func (p *Project) Workspace() WorkspaceID {
 	return p.workspace
 }
@@ -140,16 +166,40 @@ func (p *Project) SetArchived(isArchived bool) {
 	p.isArchived = isArchived
 }
 
+func (p *Project) SetTemplate(isTemplate bool) {
+	p.isTemplate = isTemplate
+}
+
 func (p *Project) SetIsBasicAuthActive(isBasicAuthActive bool) {
 	p.isBasicAuthActive = isBasicAuthActive
 }
 
-func (p *Project) SetBasicAuthUsername(basicAuthUsername string) {
-	p.basicAuthUsername = basicAuthUsername
+// SetBasicAuth updates the username and the password of the default basic auth credential.
+func (p *Project) SetBasicAuth(basicAuthUsername, basicAuthPassword *string) error {
+	l, err := p.basicAuthCredentials.SetDefault(basicAuthUsername, basicAuthPassword)
+	if err != nil {
+		return err
+	}
+	p.basicAuthCredentials = l
+	return nil
+}
+
+func (p *Project) AddBasicAuthCredential(c *basicauth.Credential) error {
+	l, err := p.basicAuthCredentials.Add(c)
+	if err != nil {
+		return err
+	}
+	p.basicAuthCredentials = l
+	return nil
 }
 
-func (p *Project) SetBasicAuthPassword(basicAuthPassword string) {
-	p.basicAuthPassword = basicAuthPassword
+func (p *Project) RevokeBasicAuthCredential(name string) error {
+	l, err := p.basicAuthCredentials.Revoke(name)
+	if err != nil {
+		return err
+	}
+	p.basicAuthCredentials = l
+	return nil
 }
 
 func (p *Project) SetUpdatedAt(updatedAt time.Time) {
@@ -187,6 +237,11 @@ func (p *Project) UpdateAlias(alias string) error {
 	return nil
 }
 
+// ClearAlias removes the alias, so that the project has to be given a new one before it is published.
+func (p *Project) ClearAlias() {
+	p.alias = ""
+}
+
 func (p *Project) UpdatePublicTitle(publicTitle string) {
 	p.publicTitle = publicTitle
 }
@@ -215,6 +270,14 @@ func (p *Project) UpdatePublishmentStatus(publishmentStatus PublishmentStatus) {
 	p.publishmentStatus = publishmentStatus
 }
 
+func (p *Project) SetPublishSchedule(publishSchedule *PublishSchedule) {
+	p.publishSchedule = publishSchedule
+}
+
+func (p *Project) SetPreview(preview *Preview) {
+	p.preview = preview
+}
+
 func (p *Project) UpdateEnableGA(enableGa bool) {
 	p.enableGa = enableGa
 }
@@ -5,8 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -92,6 +94,12 @@ func TestProject_SetArchived(t *testing.T) {
 	assert.Equal(t, true, p.IsArchived())
 }
 
+func TestProject_SetTemplate(t *testing.T) {
+	p := &Project{}
+	p.SetTemplate(true)
+	assert.Equal(t, true, p.IsTemplate())
+}
+
 func TestProject_SetPublishedAt(t *testing.T) {
 	p := &Project{}
 	p.SetPublishedAt(time.Date(1900, 1, 1, 00, 00, 1, 1, time.UTC))
@@ -348,17 +356,17 @@ func TestProject_Experimental(t *testing.T) {
 
 func TestProject_BasicAuthUsername(t *testing.T) {
 	t.Run("return basic auth username", func(t *testing.T) {
-		p := &Project{basicAuthUsername: "test1"}
+		c := lo.Must(basicauth.NewCredential(basicauth.DefaultName, "test1", "password"))
+		p := &Project{basicAuthCredentials: basicauth.List{c}}
 		res := p.BasicAuthUsername()
 		assert.Equal(t, "test1", res)
 	})
-}
 
-func TestProject_BasicAuthPassword(t *testing.T) {
-	t.Run("return basic auth password", func(t *testing.T) {
-		p := &Project{basicAuthPassword: "password"}
-		res := p.BasicAuthPassword()
-		assert.Equal(t, "password", res)
+	t.Run("return empty if there is no default credential", func(t *testing.T) {
+		c := lo.Must(basicauth.NewCredential("client", "test1", "password"))
+		p := &Project{basicAuthCredentials: basicauth.List{c}}
+		res := p.BasicAuthUsername()
+		assert.Equal(t, "", res)
 	})
 }
 
@@ -368,14 +376,32 @@ func TestProject_SetIsBasicAuthActive(t *testing.T) {
 	assert.Equal(t, true, p.isBasicAuthActive)
 }
 
-func TestProject_SetBasicAuthUsername(t *testing.T) {
+func TestProject_SetBasicAuth(t *testing.T) {
 	p := &Project{}
-	p.SetBasicAuthUsername("username")
-	assert.Equal(t, "username", p.basicAuthUsername)
+	assert.Equal(t, basicauth.ErrEmptyPassword, p.SetBasicAuth(lo.ToPtr("username"), nil))
+
+	assert.NoError(t, p.SetBasicAuth(lo.ToPtr("username"), lo.ToPtr("password")))
+	assert.Equal(t, "username", p.BasicAuthUsername())
+	assert.NotEqual(t, "password", p.BasicAuthCredentials()[0].PasswordHash())
+	assert.True(t, p.MatchBasicAuth("username", "password"))
+
+	assert.NoError(t, p.SetBasicAuth(nil, lo.ToPtr("password2")))
+	assert.False(t, p.MatchBasicAuth("username", "password"))
+	assert.True(t, p.MatchBasicAuth("username", "password2"))
 }
 
-func TestProject_SetBasicAuthPassword(t *testing.T) {
+func TestProject_BasicAuthCredentials(t *testing.T) {
 	p := &Project{}
-	p.SetBasicAuthPassword("password")
-	assert.Equal(t, "password", p.basicAuthPassword)
+	assert.NoError(t, p.AddBasicAuthCredential(lo.Must(basicauth.NewCredential("a", "user", "pass"))))
+	assert.NoError(t, p.AddBasicAuthCredential(lo.Must(basicauth.NewCredential("b", "user", "pass2"))))
+	assert.Equal(t, basicauth.ErrDuplicatedName, p.AddBasicAuthCredential(lo.Must(basicauth.NewCredential("a", "user", "pass3"))))
+	assert.True(t, p.MatchBasicAuth("user", "pass"))
+	assert.True(t, p.MatchBasicAuth("user", "pass2"))
+
+	assert.NoError(t, p.RevokeBasicAuthCredential("a"))
+	assert.False(t, p.MatchBasicAuth("user", "pass"))
+	assert.True(t, p.MatchBasicAuth("user", "pass2"))
+	assert.Equal(t, basicauth.ErrCredentialNotFound, p.RevokeBasicAuthCredential("a"))
+	assert.Equal(t, 2, len(p.BasicAuthCredentials()))
+	assert.Equal(t, 1, len(p.BasicAuthCredentials().Active()))
 }
@@ -0,0 +1,14 @@
+package project
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/publishschedule"
+)
+
+// PublishSchedule describes a future publication of a project and an optional expiry.
+type PublishSchedule = publishschedule.Schedule[PublishmentStatus]
+
+func NewPublishSchedule(status PublishmentStatus, publishAt, unpublishAt *time.Time) (*PublishSchedule, error) {
+	return publishschedule.New(status, publishAt, unpublishAt)
+}
@@ -0,0 +1,99 @@
+package projectgrant
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmptyProjectID   = errors.New("require project id")
+	ErrEmptySceneID     = errors.New("require scene id")
+	ErrEmptyWorkspaceID = errors.New("require workspace id")
+	ErrEmptyUserID      = errors.New("require user id")
+)
+
+type Builder struct {
+	g *Grant
+}
+
+func New() *Builder {
+	return &Builder{g: &Grant{}}
+}
+
+func (b *Builder) Build() (*Grant, error) {
+	if b.g.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if b.g.project.IsNil() {
+		return nil, ErrEmptyProjectID
+	}
+	if b.g.scene.IsNil() {
+		return nil, ErrEmptySceneID
+	}
+	if b.g.workspace.IsNil() {
+		return nil, ErrEmptyWorkspaceID
+	}
+	if b.g.user.IsNil() {
+		return nil, ErrEmptyUserID
+	}
+	if !b.g.role.Valid() {
+		return nil, ErrInvalidRole
+	}
+	if b.g.createdAt.IsZero() {
+		b.g.createdAt = b.g.id.Timestamp()
+	}
+	return b.g, nil
+}
+
+func (b *Builder) MustBuild() *Grant {
+	r, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.g.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.g.id = NewID()
+	return b
+}
+
+func (b *Builder) Project(p ProjectID) *Builder {
+	b.g.project = p
+	return b
+}
+
+func (b *Builder) Scene(s SceneID) *Builder {
+	b.g.scene = s
+	return b
+}
+
+func (b *Builder) Workspace(w WorkspaceID) *Builder {
+	b.g.workspace = w
+	return b
+}
+
+func (b *Builder) User(u UserID) *Builder {
+	b.g.user = u
+	return b
+}
+
+func (b *Builder) Role(r Role) *Builder {
+	b.g.role = r
+	return b
+}
+
+func (b *Builder) GrantedBy(u *UserID) *Builder {
+	b.g.grantedBy = u.CloneRef()
+	return b
+}
+
+func (b *Builder) CreatedAt(t time.Time) *Builder {
+	b.g.createdAt = t
+	return b
+}
@@ -0,0 +1,79 @@
+package projectgrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	gid := NewID()
+	pid := id.NewProjectID()
+	sid := id.NewSceneID()
+	wid := accountdomain.NewWorkspaceID()
+	uid := accountdomain.NewUserID()
+	by := accountdomain.NewUserID()
+	now := time.Now().Truncate(time.Millisecond)
+
+	g, err := New().
+		ID(gid).
+		Project(pid).
+		Scene(sid).
+		Workspace(wid).
+		User(uid).
+		Role(RoleEditor).
+		GrantedBy(&by).
+		CreatedAt(now).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, gid, g.ID())
+	assert.Equal(t, pid, g.Project())
+	assert.Equal(t, sid, g.Scene())
+	assert.Equal(t, wid, g.Workspace())
+	assert.Equal(t, uid, g.User())
+	assert.Equal(t, RoleEditor, g.Role())
+	assert.Equal(t, &by, g.GrantedBy())
+	assert.Equal(t, now, g.CreatedAt())
+
+	assert.NoError(t, g.SetRole(RoleViewer))
+	assert.Equal(t, RoleViewer, g.Role())
+	assert.Equal(t, ErrInvalidRole, g.SetRole("owner"))
+
+	_, err = New().Project(pid).Scene(sid).Workspace(wid).User(uid).Role(RoleViewer).Build()
+	assert.Equal(t, ErrInvalidID, err)
+
+	_, err = New().NewID().Scene(sid).Workspace(wid).User(uid).Role(RoleViewer).Build()
+	assert.Equal(t, ErrEmptyProjectID, err)
+
+	_, err = New().NewID().Project(pid).Scene(sid).Workspace(wid).Role(RoleViewer).Build()
+	assert.Equal(t, ErrEmptyUserID, err)
+
+	_, err = New().NewID().Project(pid).Scene(sid).Workspace(wid).User(uid).Build()
+	assert.Equal(t, ErrInvalidRole, err)
+}
+
+func TestRoleFrom(t *testing.T) {
+	r, err := RoleFrom("EDITOR")
+	assert.NoError(t, err)
+	assert.Equal(t, RoleEditor, r)
+	assert.True(t, r.CanWrite())
+	assert.False(t, RoleCommenter.CanWrite())
+
+	_, err = RoleFrom("owner")
+	assert.Equal(t, ErrInvalidRole, err)
+}
+
+func TestList_Scenes(t *testing.T) {
+	sid1, sid2 := id.NewSceneID(), id.NewSceneID()
+	l := List{
+		New().NewID().Project(id.NewProjectID()).Scene(sid1).Workspace(accountdomain.NewWorkspaceID()).User(accountdomain.NewUserID()).Role(RoleViewer).MustBuild(),
+		nil,
+		New().NewID().Project(id.NewProjectID()).Scene(sid2).Workspace(accountdomain.NewWorkspaceID()).User(accountdomain.NewUserID()).Role(RoleEditor).MustBuild(),
+	}
+	assert.Equal(t, id.SceneIDList{sid1}, l.Scenes(RoleViewer, RoleCommenter))
+	assert.Equal(t, id.SceneIDList{sid2}, l.Scenes(RoleEditor))
+	assert.Equal(t, 2, len(l.Projects()))
+}
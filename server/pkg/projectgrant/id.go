@@ -0,0 +1,20 @@
+package projectgrant
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type ID = id.ProjectGrantID
+type IDList = id.ProjectGrantIDList
+type ProjectID = id.ProjectID
+type SceneID = id.SceneID
+type WorkspaceID = accountdomain.WorkspaceID
+type UserID = accountdomain.UserID
+
+var NewID = id.NewProjectGrantID
+var MustID = id.MustProjectGrantID
+var IDFrom = id.ProjectGrantIDFrom
+var IDFromRef = id.ProjectGrantIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
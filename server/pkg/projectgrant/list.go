@@ -0,0 +1,30 @@
+package projectgrant
+
+import (
+	"slices"
+
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+type List []*Grant
+
+// Scenes returns the scenes of the grants which give any of the roles.
+func (l List) Scenes(roles ...Role) id.SceneIDList {
+	var res id.SceneIDList
+	for _, g := range l {
+		if g != nil && slices.Contains(roles, g.Role()) {
+			res = append(res, g.Scene())
+		}
+	}
+	return res
+}
+
+func (l List) Projects() id.ProjectIDList {
+	var res id.ProjectIDList
+	for _, g := range l {
+		if g != nil {
+			res = append(res, g.Project())
+		}
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+package projectgrant
+
+import (
+	"time"
+)
+
+// Grant gives a user a role in a single project without making the user a member of the workspace of the project.
+type Grant struct {
+	id        ID
+	project   ProjectID
+	scene     SceneID
+	workspace WorkspaceID
+	user      UserID
+	role      Role
+	grantedBy *UserID
+	createdAt time.Time
+}
+
+func (g *Grant) ID() ID {
+	return g.id
+}
+
+func (g *Grant) Project() ProjectID {
+	return g.project
+}
+
+// Scene is the scene of the project, to which the role is applied.
+func (g *Grant) Scene() SceneID {
+	return g.scene
+}
+
+// Workspace is the workspace of the project, whose maintainers manage the grant.
+func (g *Grant) Workspace() WorkspaceID {
+	return g.workspace
+}
+
+func (g *Grant) User() UserID {
+	return g.user
+}
+
+func (g *Grant) Role() Role {
+	return g.role
+}
+
+func (g *Grant) GrantedBy() *UserID {
+	return g.grantedBy.CloneRef()
+}
+
+func (g *Grant) CreatedAt() time.Time {
+	if g.createdAt.IsZero() {
+		return g.id.Timestamp()
+	}
+	return g.createdAt
+}
+
+func (g *Grant) SetRole(r Role) error {
+	if !r.Valid() {
+		return ErrInvalidRole
+	}
+	g.role = r
+	return nil
+}
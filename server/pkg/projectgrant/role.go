@@ -0,0 +1,37 @@
+package projectgrant
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidRole = errors.New("invalid role")
+
+// Role is a role of a user in a single project, which is given apart from the roles in workspaces.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	// RoleCommenter can only read the project for now, as comments are not supported yet.
+	RoleCommenter Role = "commenter"
+	RoleEditor    Role = "editor"
+)
+
+var roles = []Role{RoleViewer, RoleCommenter, RoleEditor}
+
+func RoleFrom(r string) (Role, error) {
+	role := Role(strings.ToLower(r))
+	if role.Valid() {
+		return role, nil
+	}
+	return role, ErrInvalidRole
+}
+
+func (r Role) Valid() bool {
+	return slices.Contains(roles, r)
+}
+
+func (r Role) CanWrite() bool {
+	return r == RoleEditor
+}
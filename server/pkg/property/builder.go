@@ -39,6 +39,11 @@ func (b *Builder) NewID() *Builder {
 	return b
 }
 
+func (b *Builder) Version(v int) *Builder {
+	b.p.version = v
+	return b
+}
+
 func (b *Builder) Scene(s SceneID) *Builder {
 	b.p.scene = s
 	return b
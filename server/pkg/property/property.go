@@ -9,16 +9,31 @@ import (
 )
 
 type Property struct {
-	id     ID
-	scene  SceneID
-	schema SchemaID
-	items  []Item
+	id      ID
+	scene   SceneID
+	schema  SchemaID
+	items   []Item
+	version int
 }
 
 func (p *Property) ID() ID {
 	return p.id
 }
 
+func (p *Property) Version() int {
+	if p == nil {
+		return 0
+	}
+	return p.version
+}
+
+func (p *Property) SetVersion(v int) {
+	if p == nil {
+		return
+	}
+	p.version = v
+}
+
 func (p *Property) IDRef() *ID {
 	if p == nil {
 		return nil
@@ -168,10 +183,11 @@ func (p *Property) Clone() *Property {
 	}
 
 	return &Property{
-		id:     p.id,
-		schema: p.schema,
-		scene:  p.scene,
-		items:  items,
+		id:      p.id,
+		schema:  p.schema,
+		scene:   p.scene,
+		items:   items,
+		version: p.version,
 	}
 }
 
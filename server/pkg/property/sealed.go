@@ -261,3 +261,30 @@ func (s *SealedItem) Field(id FieldID) *SealedField {
 	}
 	return nil
 }
+
+// TransformCoordinates transforms coordinates of all fields in the sealed property with f in place.
+func (s *Sealed) TransformCoordinates(f func(x, y float64) (float64, float64)) {
+	if s == nil {
+		return
+	}
+	for _, i := range s.Items {
+		i.TransformCoordinates(f)
+	}
+}
+
+func (s *SealedItem) TransformCoordinates(f func(x, y float64) (float64, float64)) {
+	if s == nil {
+		return
+	}
+	for _, field := range s.Fields {
+		if field == nil || field.Val == nil {
+			continue
+		}
+		if v := field.Val.Value(); v != nil {
+			field.Val = NewValueAndDatasetValue(field.Val.Type(), nil, v.TransformCoordinates(f))
+		}
+	}
+	for _, g := range s.Groups {
+		g.TransformCoordinates(f)
+	}
+}
@@ -234,6 +234,55 @@ func (v *Value) ValuePolygon() *Polygon {
 	return nil
 }
 
+// TransformCoordinates returns a new value whose positions are transformed by f, which receives and
+// returns longitude and latitude, or x and y in projected coordinate reference systems.
+// Values which do not have positions are returned as they are.
+func (v *Value) TransformCoordinates(f func(x, y float64) (float64, float64)) *Value {
+	if v == nil {
+		return nil
+	}
+	transform := func(c Coordinates) Coordinates {
+		res := make(Coordinates, 0, len(c))
+		for _, l := range c {
+			lng, lat := f(l.Lng, l.Lat)
+			res = append(res, LatLngHeight{Lat: lat, Lng: lng, Height: l.Height})
+		}
+		return res
+	}
+
+	switch v.Type() {
+	case ValueTypeLatLng:
+		if l := v.ValueLatLng(); l != nil {
+			lng, lat := f(l.Lng, l.Lat)
+			return ValueTypeLatLng.ValueFrom(LatLng{Lat: lat, Lng: lng})
+		}
+	case ValueTypeLatLngHeight:
+		if l := v.ValueLatLngHeight(); l != nil {
+			lng, lat := f(l.Lng, l.Lat)
+			return ValueTypeLatLngHeight.ValueFrom(LatLngHeight{Lat: lat, Lng: lng, Height: l.Height})
+		}
+	case ValueTypeCoordinates:
+		if c := v.ValueCoordinates(); c != nil {
+			return ValueTypeCoordinates.ValueFrom(transform(*c))
+		}
+	case ValueTypePolygon:
+		if p := v.ValuePolygon(); p != nil {
+			res := make(Polygon, 0, len(*p))
+			for _, c := range *p {
+				res = append(res, transform(c))
+			}
+			return ValueTypePolygon.ValueFrom(res)
+		}
+	case ValueTypeRect:
+		if r := v.ValueRect(); r != nil {
+			west, south := f(r.West, r.South)
+			east, north := f(r.East, r.North)
+			return ValueTypeRect.ValueFrom(Rect{West: west, South: south, East: east, North: north})
+		}
+	}
+	return v.Clone()
+}
+
 func ValueFromStringOrNumber(s string) *Value {
 	if s == "true" || s == "false" || s == "TRUE" || s == "FALSE" || s == "True" || s == "False" {
 		return ValueTypeBool.ValueFrom(s)
@@ -345,6 +345,60 @@ func TestValueFromDataset(t *testing.T) {
 	}
 }
 
+func TestValue_TransformCoordinates(t *testing.T) {
+	f := func(x, y float64) (float64, float64) {
+		return x + 1, y * 2
+	}
+
+	tests := []struct {
+		name     string
+		target   *Value
+		expected *Value
+	}{
+		{
+			name:     "latlng",
+			target:   ValueTypeLatLng.ValueFrom(LatLng{Lat: 10, Lng: 12}),
+			expected: ValueTypeLatLng.ValueFrom(LatLng{Lat: 20, Lng: 13}),
+		},
+		{
+			name:     "latlngheight",
+			target:   ValueTypeLatLngHeight.ValueFrom(LatLngHeight{Lat: 10, Lng: 12, Height: 5}),
+			expected: ValueTypeLatLngHeight.ValueFrom(LatLngHeight{Lat: 20, Lng: 13, Height: 5}),
+		},
+		{
+			name:     "coordinates",
+			target:   ValueTypeCoordinates.ValueFrom(Coordinates{{Lat: 1, Lng: 2, Height: 3}, {Lat: 4, Lng: 5}}),
+			expected: ValueTypeCoordinates.ValueFrom(Coordinates{{Lat: 2, Lng: 3, Height: 3}, {Lat: 8, Lng: 6}}),
+		},
+		{
+			name:     "polygon",
+			target:   ValueTypePolygon.ValueFrom(Polygon{{{Lat: 1, Lng: 2}}, {{Lat: 3, Lng: 4}}}),
+			expected: ValueTypePolygon.ValueFrom(Polygon{{{Lat: 2, Lng: 3}}, {{Lat: 6, Lng: 5}}}),
+		},
+		{
+			name:     "rect",
+			target:   ValueTypeRect.ValueFrom(Rect{West: 1, South: 2, East: 3, North: 4}),
+			expected: ValueTypeRect.ValueFrom(Rect{West: 2, South: 4, East: 4, North: 8}),
+		},
+		{
+			name:     "string",
+			target:   ValueTypeString.ValueFrom("a"),
+			expected: ValueTypeString.ValueFrom("a"),
+		},
+		{
+			name: "nil",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, tc.target.TransformCoordinates(f))
+		})
+	}
+}
+
 func TestValueFromStringOrNumber(t *testing.T) {
 	type args struct {
 		s string
@@ -0,0 +1,108 @@
+// Package publishschedule implements schedules to publish and unpublish projects and stories at given times.
+package publishschedule
+
+import (
+	"errors"
+	"time"
+
+	"github.com/reearth/reearthx/util"
+)
+
+var (
+	ErrEmpty         = errors.New("publish schedule requires publishAt or unpublishAt")
+	ErrInvalid       = errors.New("unpublishAt must be after publishAt")
+	ErrInvalidStatus = errors.New("scheduled publishment status must be public or limited")
+)
+
+// Publishment statuses shared by projects and stories.
+const (
+	statusPublic  = "public"
+	statusLimited = "limited"
+	statusPrivate = "private"
+)
+
+// Schedule describes a future publication and an optional expiry of what has the publishment status S.
+// When publishAt comes, it is published with status, and when unpublishAt comes, it is made private.
+type Schedule[S ~string] struct {
+	status      S
+	publishAt   *time.Time
+	unpublishAt *time.Time
+}
+
+func New[S ~string](status S, publishAt, unpublishAt *time.Time) (*Schedule[S], error) {
+	if publishAt == nil && unpublishAt == nil {
+		return nil, ErrEmpty
+	}
+	if publishAt != nil && status != statusPublic && status != statusLimited {
+		return nil, ErrInvalidStatus
+	}
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return nil, ErrInvalid
+	}
+	if publishAt == nil {
+		status = statusPrivate
+	}
+	return &Schedule[S]{
+		status:      status,
+		publishAt:   util.CloneRef(publishAt),
+		unpublishAt: util.CloneRef(unpublishAt),
+	}, nil
+}
+
+func (s *Schedule[S]) Status() S {
+	if s == nil {
+		return ""
+	}
+	return s.status
+}
+
+func (s *Schedule[S]) PublishAt() *time.Time {
+	if s == nil {
+		return nil
+	}
+	return util.CloneRef(s.publishAt)
+}
+
+func (s *Schedule[S]) UnpublishAt() *time.Time {
+	if s == nil {
+		return nil
+	}
+	return util.CloneRef(s.unpublishAt)
+}
+
+// NextAt returns the time when the schedule should be fired next.
+func (s *Schedule[S]) NextAt() *time.Time {
+	if s == nil {
+		return nil
+	}
+	if s.publishAt != nil {
+		return util.CloneRef(s.publishAt)
+	}
+	return util.CloneRef(s.unpublishAt)
+}
+
+// Due returns the publishment status that should be applied at now, and whether the schedule is due.
+func (s *Schedule[S]) Due(now time.Time) (S, bool) {
+	next := s.NextAt()
+	if next == nil || next.After(now) {
+		return "", false
+	}
+	if s.publishAt != nil {
+		return s.status, true
+	}
+	return statusPrivate, true
+}
+
+// Advance returns the remaining schedule after the due part has been fired. It returns nil when nothing remains.
+func (s *Schedule[S]) Advance(now time.Time) *Schedule[S] {
+	if _, ok := s.Due(now); !ok {
+		return s
+	}
+	if s.publishAt == nil || s.unpublishAt == nil {
+		return nil
+	}
+	return &Schedule[S]{
+		status:      statusPrivate,
+		unpublishAt: util.CloneRef(s.unpublishAt),
+	}
+}
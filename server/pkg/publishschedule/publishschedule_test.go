@@ -0,0 +1,110 @@
+package publishschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStatus string
+
+const (
+	testStatusPublic  testStatus = "public"
+	testStatusLimited testStatus = "limited"
+	testStatusPrivate testStatus = "private"
+)
+
+func TestNew(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		status      testStatus
+		publishAt   *time.Time
+		unpublishAt *time.Time
+		want        *Schedule[testStatus]
+		wantErr     error
+	}{
+		{
+			name:        "publish and unpublish",
+			status:      testStatusPublic,
+			publishAt:   &now,
+			unpublishAt: &later,
+			want:        &Schedule[testStatus]{status: testStatusPublic, publishAt: &now, unpublishAt: &later},
+		},
+		{
+			name:        "unpublish only",
+			status:      testStatusPublic,
+			unpublishAt: &later,
+			want:        &Schedule[testStatus]{status: testStatusPrivate, unpublishAt: &later},
+		},
+		{
+			name:    "empty",
+			status:  testStatusPublic,
+			wantErr: ErrEmpty,
+		},
+		{
+			name:      "private",
+			status:    testStatusPrivate,
+			publishAt: &now,
+			wantErr:   ErrInvalidStatus,
+		},
+		{
+			name:        "unpublishAt before publishAt",
+			status:      testStatusLimited,
+			publishAt:   &later,
+			unpublishAt: &now,
+			wantErr:     ErrInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := New(tt.status, tt.publishAt, tt.unpublishAt)
+			if tt.wantErr != nil {
+				assert.Nil(t, got)
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchedule_DueAndAdvance(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	publishAt := now.Add(time.Hour)
+	unpublishAt := now.Add(2 * time.Hour)
+
+	s := lo.Must(New(testStatusLimited, &publishAt, &unpublishAt))
+	assert.Equal(t, &publishAt, s.NextAt())
+
+	status, ok := s.Due(now)
+	assert.False(t, ok)
+	assert.Equal(t, testStatus(""), status)
+	assert.Same(t, s, s.Advance(now))
+
+	status, ok = s.Due(publishAt)
+	assert.True(t, ok)
+	assert.Equal(t, testStatusLimited, status)
+
+	s = s.Advance(publishAt)
+	assert.Equal(t, &Schedule[testStatus]{status: testStatusPrivate, unpublishAt: &unpublishAt}, s)
+	assert.Equal(t, &unpublishAt, s.NextAt())
+
+	status, ok = s.Due(unpublishAt.Add(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, testStatusPrivate, status)
+	assert.Nil(t, s.Advance(unpublishAt.Add(time.Minute)))
+
+	var s2 *Schedule[testStatus]
+	_, ok = s2.Due(now)
+	assert.False(t, ok)
+	assert.Nil(t, s2.NextAt())
+}
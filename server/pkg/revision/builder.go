@@ -0,0 +1,108 @@
+package revision
+
+import "time"
+
+type Builder struct {
+	r *Revision
+}
+
+func New() *Builder {
+	return &Builder{r: &Revision{}}
+}
+
+func (b *Builder) Build() (*Revision, error) {
+	if b.r.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	switch b.r.target {
+	case TargetProject:
+		if b.r.project == nil {
+			return nil, ErrInvalidTarget
+		}
+	case TargetStory:
+		if b.r.story == nil {
+			return nil, ErrInvalidTarget
+		}
+	default:
+		return nil, ErrInvalidTarget
+	}
+	if b.r.number <= 0 {
+		return nil, ErrInvalidNumber
+	}
+	if b.r.publishedAt.IsZero() {
+		b.r.publishedAt = b.r.id.Timestamp()
+	}
+	return b.r, nil
+}
+
+func (b *Builder) MustBuild() *Revision {
+	r, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.r.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.r.id = NewID()
+	return b
+}
+
+func (b *Builder) Project(id ProjectID) *Builder {
+	b.r.target = TargetProject
+	b.r.project = &id
+	b.r.story = nil
+	return b
+}
+
+func (b *Builder) Story(id StoryID) *Builder {
+	b.r.target = TargetStory
+	b.r.story = &id
+	b.r.project = nil
+	return b
+}
+
+func (b *Builder) Scene(id SceneID) *Builder {
+	b.r.scene = id
+	return b
+}
+
+func (b *Builder) Workspace(id WorkspaceID) *Builder {
+	b.r.workspace = id
+	return b
+}
+
+func (b *Builder) Number(n int) *Builder {
+	b.r.number = n
+	return b
+}
+
+func (b *Builder) Alias(alias string) *Builder {
+	b.r.alias = alias
+	return b
+}
+
+func (b *Builder) Status(status string) *Builder {
+	b.r.status = status
+	return b
+}
+
+func (b *Builder) SchemaVersion(v int) *Builder {
+	b.r.schemaVersion = v
+	return b
+}
+
+func (b *Builder) PublishedBy(u *UserID) *Builder {
+	b.r.publishedBy = u.CloneRef()
+	return b
+}
+
+func (b *Builder) PublishedAt(t time.Time) *Builder {
+	b.r.publishedAt = t
+	return b
+}
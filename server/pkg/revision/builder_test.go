@@ -0,0 +1,66 @@
+package revision
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	rid := NewID()
+	pid := id.NewProjectID()
+	sid := id.NewStoryID()
+	uid := accountdomain.NewUserID()
+	now := time.Now().Truncate(time.Millisecond)
+
+	r, err := New().
+		ID(rid).
+		Project(pid).
+		Number(1).
+		Alias("alias").
+		Status("public").
+		SchemaVersion(1).
+		PublishedBy(&uid).
+		PublishedAt(now).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, rid, r.ID())
+	assert.Equal(t, TargetProject, r.Target())
+	assert.Equal(t, &pid, r.Project())
+	assert.Nil(t, r.Story())
+	assert.Equal(t, 1, r.Number())
+	assert.Equal(t, "alias", r.Alias())
+	assert.Equal(t, "public", r.Status())
+	assert.Equal(t, 1, r.SchemaVersion())
+	assert.Equal(t, &uid, r.PublishedBy())
+	assert.Equal(t, now, r.PublishedAt())
+	assert.Equal(t, rid.String(), r.FileName())
+
+	r, err = New().ID(rid).Project(pid).Story(sid).Number(2).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, TargetStory, r.Target())
+	assert.Nil(t, r.Project())
+	assert.Equal(t, &sid, r.Story())
+	assert.Equal(t, rid.Timestamp(), r.PublishedAt())
+
+	_, err = New().Project(pid).Number(1).Build()
+	assert.Equal(t, ErrInvalidID, err)
+
+	_, err = New().NewID().Number(1).Build()
+	assert.Equal(t, ErrInvalidTarget, err)
+
+	_, err = New().NewID().Project(pid).Build()
+	assert.Equal(t, ErrInvalidNumber, err)
+}
+
+func TestBuilder_MustBuild(t *testing.T) {
+	assert.Panics(t, func() {
+		_ = New().MustBuild()
+	})
+	assert.NotPanics(t, func() {
+		_ = New().NewID().Story(id.NewStoryID()).Number(1).MustBuild()
+	})
+}
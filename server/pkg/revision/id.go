@@ -0,0 +1,27 @@
+package revision
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type ID = id.RevisionID
+type ProjectID = id.ProjectID
+type StoryID = id.StoryID
+type SceneID = id.SceneID
+type WorkspaceID = accountdomain.WorkspaceID
+type UserID = accountdomain.UserID
+
+var NewID = id.NewRevisionID
+var MustID = id.MustRevisionID
+var IDFrom = id.RevisionIDFrom
+var IDFromRef = id.RevisionIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
+
+func MockNewID(rid ID) func() {
+	NewID = func() ID { return rid }
+	return func() {
+		NewID = id.NewRevisionID
+	}
+}
@@ -0,0 +1,44 @@
+package revision
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+type List []*Revision
+
+// Latest returns the revision with the largest number.
+func (l List) Latest() *Revision {
+	var res *Revision
+	for _, r := range l {
+		if r != nil && (res == nil || r.Number() > res.Number()) {
+			res = r
+		}
+	}
+	return res
+}
+
+// NextNumber returns the number that the next revision should have.
+func (l List) NextNumber() int {
+	if latest := l.Latest(); latest != nil {
+		return latest.Number() + 1
+	}
+	return 1
+}
+
+func (l List) FindByNumber(n int) *Revision {
+	r, _ := lo.Find(l, func(r *Revision) bool {
+		return r != nil && r.Number() == n
+	})
+	return r
+}
+
+// Sorted returns a copy of the list sorted by number in descending order.
+func (l List) Sorted() List {
+	res := lo.Filter(l, func(r *Revision, _ int) bool { return r != nil })
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Number() > res[j].Number()
+	})
+	return res
+}
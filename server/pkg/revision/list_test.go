@@ -0,0 +1,26 @@
+package revision
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	pid := id.NewProjectID()
+	r1 := New().NewID().Project(pid).Number(1).MustBuild()
+	r2 := New().NewID().Project(pid).Number(2).MustBuild()
+	r3 := New().NewID().Project(pid).Number(3).MustBuild()
+
+	l := List{r2, nil, r3, r1}
+	assert.Equal(t, r3, l.Latest())
+	assert.Equal(t, 4, l.NextNumber())
+	assert.Equal(t, r2, l.FindByNumber(2))
+	assert.Nil(t, l.FindByNumber(4))
+	assert.Equal(t, List{r3, r2, r1}, l.Sorted())
+	assert.Equal(t, List{r2, nil, r3, r1}, l)
+
+	assert.Nil(t, List(nil).Latest())
+	assert.Equal(t, 1, List(nil).NextNumber())
+}
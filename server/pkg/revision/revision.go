@@ -0,0 +1,96 @@
+package revision
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidTarget error = errors.New("invalid revision target")
+	ErrInvalidNumber error = errors.New("invalid revision number")
+)
+
+type Target string
+
+const (
+	TargetProject Target = "project"
+	TargetStory   Target = "story"
+)
+
+// Revision is an immutable record of a single publish of a project or a story.
+// The built data of every revision is kept in the file storage so that the
+// public alias can be pointed back to it without rebuilding the scene.
+type Revision struct {
+	id            ID
+	target        Target
+	project       *ProjectID
+	story         *StoryID
+	scene         SceneID
+	workspace     WorkspaceID
+	number        int
+	alias         string
+	status        string
+	schemaVersion int
+	publishedBy   *UserID
+	publishedAt   time.Time
+}
+
+func (r *Revision) ID() ID {
+	return r.id
+}
+
+func (r *Revision) Target() Target {
+	return r.target
+}
+
+func (r *Revision) Project() *ProjectID {
+	return r.project.CloneRef()
+}
+
+func (r *Revision) Story() *StoryID {
+	return r.story.CloneRef()
+}
+
+func (r *Revision) Scene() SceneID {
+	return r.scene
+}
+
+func (r *Revision) Workspace() WorkspaceID {
+	return r.workspace
+}
+
+// Number is the sequential number of the revision within its project or story, starting from 1.
+func (r *Revision) Number() int {
+	return r.number
+}
+
+// Alias is the alias the revision was published to.
+func (r *Revision) Alias() string {
+	return r.alias
+}
+
+// Status is the publishment status the revision was published with.
+func (r *Revision) Status() string {
+	return r.status
+}
+
+// SchemaVersion is the version of the built scene schema.
+func (r *Revision) SchemaVersion() int {
+	return r.schemaVersion
+}
+
+func (r *Revision) PublishedBy() *UserID {
+	return r.publishedBy.CloneRef()
+}
+
+func (r *Revision) PublishedAt() time.Time {
+	return r.publishedAt
+}
+
+// FileName returns the name used to store the built data of the revision.
+func (r *Revision) FileName() string {
+	if r == nil {
+		return ""
+	}
+	return r.id.String()
+}
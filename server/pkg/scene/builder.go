@@ -64,6 +64,11 @@ func (b *Builder) UpdatedAt(updatedAt time.Time) *Builder {
 	return b
 }
 
+func (b *Builder) Version(v int) *Builder {
+	b.scene.version = v
+	return b
+}
+
 func (b *Builder) Widgets(widgets *Widgets) *Builder {
 	b.scene.widgets = widgets
 	return b
@@ -18,8 +18,8 @@ import (
 )
 
 const (
-	// schema version
-	version = 1
+	// SchemaVersion is the version of the schema of built scenes
+	SchemaVersion = 1
 )
 
 type Builder struct {
@@ -33,6 +33,7 @@ type Builder struct {
 	nlsLayer    *nlslayer.NLSLayerList
 	layerStyles *scene.StyleList
 	story       *storytelling.Story
+	prev        *Manifest
 }
 
 func New(ll layer.Loader, pl property.Loader, dl dataset.GraphLoader, tl tag.Loader, tsl tag.SceneLoader, nlsl nlslayer.Loader) *Builder {
@@ -88,6 +89,15 @@ func (b *Builder) WithStory(s *storytelling.Story) *Builder {
 	return b
 }
 
+// WithPreviousBuild lets BuildChunks reuse chunks of the manifest built previously for the same scene.
+func (b *Builder) WithPreviousBuild(m *Manifest) *Builder {
+	if b == nil {
+		return nil
+	}
+	b.prev = m
+	return b
+}
+
 func (b *Builder) Build(ctx context.Context, w io.Writer, publishedAt time.Time, coreSupport bool, enableGa bool, trackingId string) error {
 	if b == nil || b.scene == nil {
 		return nil
@@ -1,7 +1,9 @@
 package builder
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"testing"
 	"time"
 
@@ -728,7 +730,7 @@ func TestSceneBuilder(t *testing.T) {
 	}
 
 	expected := &sceneJSON{
-		SchemaVersion: version,
+		SchemaVersion: SchemaVersion,
 		ID:            sceneID.String(),
 		PublishedAt:   publishedAt,
 		Layers:        expectedLayers,
@@ -775,3 +777,90 @@ func TestSceneBuilder(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, result)
 }
+
+func TestBuilder_BuildChunks(t *testing.T) {
+	ctx := context.Background()
+	publishedAt := time.Date(2019, time.August, 15, 0, 0, 0, 0, time.UTC)
+	sceneID := scene.NewID()
+	rootLayer := layer.NewGroup().NewID().Scene(sceneID).MustBuild()
+	scenep := property.New().NewID().Scene(sceneID).Schema(property.MustSchemaID("hoge~0.1.0/foobar")).MustBuild()
+	s := scene.New().ID(sceneID).Workspace(accountdomain.NewWorkspaceID()).RootLayer(rootLayer.ID()).Property(scenep.ID()).MustBuild()
+	l1 := nlslayer.NLSLayer(nlslayer.NewNLSLayerSimple().NewID().Scene(sceneID).Title("l1").MustBuild())
+	l2 := nlslayer.NLSLayer(nlslayer.NewNLSLayerSimple().NewID().Scene(sceneID).Title("l2").MustBuild())
+	styles := scene.StyleList{scene.NewStyle().NewID().Scene(sceneID).Name("style").Value(&scene.StyleValue{"a": "b"}).MustBuild()}
+
+	newBuilder := func(nlsLayers nlslayer.NLSLayerList) *Builder {
+		return New(
+			layer.LoaderFrom([]layer.Layer{rootLayer}),
+			property.LoaderFrom([]*property.Property{scenep}),
+			dataset.GraphLoaderFromMap(map[dataset.ID]*dataset.Dataset{}),
+			tag.LoaderFrom(nil),
+			tag.SceneLoaderFrom(nil),
+			nlslayer.LoaderFrom(nil),
+		).ForScene(s).WithNLSLayers(&nlsLayers).WithLayerStyle(&styles)
+	}
+
+	var expected bytes.Buffer
+	assert.NoError(t, newBuilder(nlslayer.NLSLayerList{&l1, &l2}).Build(ctx, &expected, publishedAt, false, false, ""))
+
+	store := &testChunkStore{stored: map[string][]byte{}}
+	m, err := newBuilder(nlslayer.NLSLayerList{&l1, &l2}).BuildChunks(ctx, publishedAt, false, false, "", store)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(store.stored))
+	assert.Equal(t, 2, len(m.NLSLayers))
+	assert.Equal(t, 2, len(m.NLSLayerKeys))
+
+	var actual bytes.Buffer
+	assert.NoError(t, m.Assemble(ctx, &actual, func(_ context.Context, h string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(store.stored[h])), nil
+	}))
+	assert.JSONEq(t, expected.String(), actual.String())
+
+	// unchanged layers and styles produce the same chunks
+	m2, err := newBuilder(nlslayer.NLSLayerList{&l1}).BuildChunks(ctx, publishedAt, false, false, "", store)
+	assert.NoError(t, err)
+	assert.Equal(t, m.NLSLayers[:1], m2.NLSLayers)
+	assert.Equal(t, m.LayerStyles, m2.LayerStyles)
+	assert.NotEqual(t, m.ETag(), m2.ETag())
+
+	// layers whose versions have not been changed since the previous build are not built again
+	l1.Rename("l1'")
+	m3, err := newBuilder(nlslayer.NLSLayerList{&l1, &l2}).WithPreviousBuild(m).BuildChunks(ctx, publishedAt, false, false, "", store)
+	assert.NoError(t, err)
+	assert.Equal(t, m.NLSLayers, m3.NLSLayers)
+	l1.SetVersion(l1.Version() + 1)
+	m4, err := newBuilder(nlslayer.NLSLayerList{&l1, &l2}).WithPreviousBuild(m).BuildChunks(ctx, publishedAt, false, false, "", store)
+	assert.NoError(t, err)
+	assert.NotEqual(t, m.NLSLayers[0], m4.NLSLayers[0])
+	assert.NotEqual(t, m.NLSLayerKeys[0], m4.NLSLayerKeys[0])
+	assert.Equal(t, m.NLSLayers[1], m4.NLSLayers[1])
+
+	// chunks that are no longer reusable are built again
+	delete(store.stored, m.NLSLayers[1])
+	m5, err := newBuilder(nlslayer.NLSLayerList{&l2}).WithPreviousBuild(m).BuildChunks(ctx, publishedAt, false, false, "", store)
+	assert.NoError(t, err)
+	assert.Equal(t, m.NLSLayers[1:], m5.NLSLayers)
+	assert.Contains(t, store.stored, m.NLSLayers[1])
+
+	// errors of the store stop the build
+	_, err = newBuilder(nlslayer.NLSLayerList{&l1}).BuildChunks(ctx, publishedAt, false, false, "", &testChunkStore{stored: map[string][]byte{}, err: io.ErrShortWrite})
+	assert.Same(t, io.ErrShortWrite, err)
+}
+
+type testChunkStore struct {
+	stored map[string][]byte
+	err    error
+}
+
+func (s *testChunkStore) Reusable(_ context.Context, hash string) (bool, error) {
+	_, ok := s.stored[hash]
+	return ok, nil
+}
+
+func (s *testChunkStore) Put(_ context.Context, c Chunk) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.stored[c.Hash] = c.Data
+	return nil
+}
@@ -0,0 +1,364 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/property"
+)
+
+const manifestType = "manifest"
+
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+// Chunk is a part of a built scene that is addressed by the hash of its content,
+// so that chunks which have not been changed since the previous build can be reused as they are.
+type Chunk struct {
+	Hash string
+	Data []byte
+}
+
+func NewChunk(data []byte) Chunk {
+	h := sha256.Sum256(data)
+	return Chunk{
+		Hash: hex.EncodeToString(h[:]),
+		Data: data,
+	}
+}
+
+func newJSONChunk(v any) (Chunk, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Chunk{}, err
+	}
+	return NewChunk(data), nil
+}
+
+// ChunkStore stores chunks of built scenes.
+type ChunkStore interface {
+	// Reusable reports whether the chunk has been stored and can be referred to by a new manifest without being put again.
+	Reusable(ctx context.Context, hash string) (bool, error)
+	Put(ctx context.Context, c Chunk) error
+}
+
+// Manifest lists the chunks that compose a built scene: the scene core, each NLS layer and the layer styles.
+// NLSLayerKeys are keys of the contents of the NLS layers in the same order as NLSLayers,
+// with which the next build finds chunks of unchanged layers without building them.
+type Manifest struct {
+	Type          string   `json:"type"`
+	SchemaVersion int      `json:"schemaVersion"`
+	Scene         string   `json:"scene"`
+	NLSLayers     []string `json:"nlsLayers"`
+	NLSLayerKeys  []string `json:"nlsLayerKeys,omitempty"`
+	LayerStyles   string   `json:"layerStyles"`
+}
+
+// ParseManifest parses a manifest. It returns ErrInvalidManifest when data is not a manifest,
+// e.g. a scene which was built as a single document before chunked builds were introduced.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil || m.Type != manifestType || m.Scene == "" {
+		return nil, ErrInvalidManifest
+	}
+	return &m, nil
+}
+
+// Hashes returns hashes of all chunks of the manifest.
+func (m *Manifest) Hashes() []string {
+	if m == nil {
+		return nil
+	}
+	res := make([]string, 0, len(m.NLSLayers)+2)
+	res = append(res, m.Scene)
+	res = append(res, m.NLSLayers...)
+	if m.LayerStyles != "" {
+		res = append(res, m.LayerStyles)
+	}
+	return res
+}
+
+// Has reports whether the manifest has the chunk of the hash.
+func (m *Manifest) Has(hash string) bool {
+	for _, h := range m.Hashes() {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// nlsLayerChunk returns the hash of the chunk of the NLS layer whose content has the key.
+func (m *Manifest) nlsLayerChunk(key string) (string, bool) {
+	if m == nil || len(m.NLSLayerKeys) != len(m.NLSLayers) {
+		return "", false
+	}
+	for i, k := range m.NLSLayerKeys {
+		if k == key {
+			return m.NLSLayers[i], true
+		}
+	}
+	return "", false
+}
+
+// ETag returns a strong entity tag of the scene assembled from the manifest.
+func (m *Manifest) ETag() string {
+	h := sha256.New()
+	for _, c := range m.Hashes() {
+		_, _ = io.WriteString(h, c)
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+func (m *Manifest) Bytes() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+// Assemble writes the scene as a single document, which is the same as the one written by Builder.Build.
+// Chunks of NLS layers are streamed as they are without decoding.
+func (m *Manifest) Assemble(ctx context.Context, w io.Writer, read func(context.Context, string) (io.ReadCloser, error)) error {
+	core, err := readChunk(ctx, m.Scene, read)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(core, &fields); err != nil {
+		return err
+	}
+	delete(fields, "nlsLayers")
+	delete(fields, "layerStyles")
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		key, _ := json.Marshal(k)
+		if _, err := w.Write(append(append(key, ':'), append(fields[k], ',')...)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, `"nlsLayers":`); err != nil {
+		return err
+	}
+	if len(m.NLSLayers) == 0 {
+		if _, err := io.WriteString(w, "null"); err != nil {
+			return err
+		}
+	} else {
+		for i, h := range m.NLSLayers {
+			sep := ","
+			if i == 0 {
+				sep = "["
+			}
+			if _, err := io.WriteString(w, sep); err != nil {
+				return err
+			}
+			if err := copyChunk(ctx, w, h, read); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "]"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, `,"layerStyles":`); err != nil {
+		return err
+	}
+	if m.LayerStyles == "" {
+		if _, err := io.WriteString(w, "null"); err != nil {
+			return err
+		}
+	} else if err := copyChunk(ctx, w, m.LayerStyles, read); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "}\n")
+	return err
+}
+
+func readChunk(ctx context.Context, hash string, read func(context.Context, string) (io.ReadCloser, error)) ([]byte, error) {
+	var b bytes.Buffer
+	if err := copyChunk(ctx, &b, hash, read); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func copyChunk(ctx context.Context, w io.Writer, hash string, read func(context.Context, string) (io.ReadCloser, error)) error {
+	r, err := read(ctx, hash)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// BuildChunks builds the scene as content-addressed chunks and returns the manifest.
+// Each chunk is put to the store as soon as it is built so that all chunks are not held in memory at once,
+// and chunks with the same content or reusable in the store are put only once.
+// NLS layers which have not been changed since the previous build are not built again if their chunks are reusable.
+func (b *Builder) BuildChunks(ctx context.Context, publishedAt time.Time, coreSupport bool, enableGa bool, trackingId string, store ChunkStore) (*Manifest, error) {
+	if b == nil || b.scene == nil {
+		return nil, nil
+	}
+
+	res, err := b.buildScene(ctx, publishedAt, coreSupport, enableGa, trackingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.story != nil {
+		story, err := b.buildStory(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res.Story = story
+	}
+
+	m := &Manifest{
+		Type:          manifestType,
+		SchemaVersion: SchemaVersion,
+	}
+	add := func(v any) (string, error) {
+		c, err := newJSONChunk(v)
+		if err != nil {
+			return "", err
+		}
+		if m.Has(c.Hash) {
+			return c.Hash, nil
+		}
+		if ok, err := store.Reusable(ctx, c.Hash); err != nil || ok {
+			return c.Hash, err
+		}
+		return c.Hash, store.Put(ctx, c)
+	}
+
+	if m.Scene, err = add(res); err != nil {
+		return nil, err
+	}
+
+	if b.nlsLayer != nil {
+		for _, l := range *b.nlsLayer {
+			if l == nil {
+				continue
+			}
+			key, err := b.nlsLayerKey(ctx, *l)
+			if err != nil {
+				return nil, err
+			}
+			h, err := b.reusedNLSLayerChunk(ctx, key, store)
+			if err != nil {
+				return nil, err
+			}
+			if h == "" {
+				j, _ := b.getNLSLayerJSON(ctx, *l)
+				if j == nil {
+					continue
+				}
+				if h, err = add(j); err != nil {
+					return nil, err
+				}
+			}
+			m.NLSLayers = append(m.NLSLayers, h)
+			m.NLSLayerKeys = append(m.NLSLayerKeys, key)
+		}
+	}
+
+	if b.layerStyles != nil {
+		layerStyles, err := b.buildLayerStyles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if layerStyles != nil {
+			if m.LayerStyles, err = add(layerStyles); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// reusedNLSLayerChunk returns the hash of the chunk of the previous build for the NLS layer with the key,
+// or an empty string if the layer has been changed or the chunk is no longer reusable.
+func (b *Builder) reusedNLSLayerChunk(ctx context.Context, key string, store ChunkStore) (string, error) {
+	h, ok := b.prev.nlsLayerChunk(key)
+	if !ok {
+		return "", nil
+	}
+	if ok, err := store.Reusable(ctx, h); err != nil || !ok {
+		return "", err
+	}
+	return h, nil
+}
+
+// nlsLayerKey returns a key of the content of the NLS layer built by getNLSLayerJSON.
+// It is made of the versions of the layer, its children and the properties of their infoboxes,
+// which are incremented on every save, so that the layer does not have to be built to know whether it has been changed.
+func (b *Builder) nlsLayerKey(ctx context.Context, l nlslayer.NLSLayer) (string, error) {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d", SchemaVersion)
+	if err := b.writeNLSLayerKey(ctx, h, l); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (b *Builder) writeNLSLayerKey(ctx context.Context, w io.Writer, l nlslayer.NLSLayer) error {
+	_, _ = fmt.Fprintf(w, "/%s@%d", l.ID(), l.Version())
+
+	if ib := l.Infobox(); ib != nil {
+		ids := []property.ID{ib.Property()}
+		for _, block := range ib.Blocks() {
+			if block != nil {
+				ids = append(ids, block.Property())
+			}
+		}
+		pp, err := b.ploader(ctx, ids...)
+		if err != nil {
+			return err
+		}
+		for _, p := range pp {
+			if p != nil {
+				_, _ = fmt.Fprintf(w, "/%s@%d", p.ID(), p.Version())
+			}
+		}
+	}
+
+	if lg := nlslayer.ToNLSLayerGroup(l); lg != nil {
+		children, err := b.nlsloader(ctx, lg.Children().Layers()...)
+		if err != nil {
+			return err
+		}
+		for _, c := range children {
+			if c == nil {
+				continue
+			}
+			if err := b.writeNLSLayerKey(ctx, w, *c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
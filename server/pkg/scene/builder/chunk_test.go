@@ -0,0 +1,70 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChunk(t *testing.T) {
+	c := NewChunk([]byte("{}"))
+	assert.Equal(t, "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", c.Hash)
+	assert.Equal(t, c, NewChunk([]byte("{}")))
+	assert.NotEqual(t, c.Hash, NewChunk([]byte("[]")).Hash)
+}
+
+func TestParseManifest(t *testing.T) {
+	m, err := ParseManifest([]byte(`{"type":"manifest","schemaVersion":1,"scene":"a","nlsLayers":["b"],"layerStyles":"c"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &Manifest{Type: "manifest", SchemaVersion: 1, Scene: "a", NLSLayers: []string{"b"}, LayerStyles: "c"}, m)
+	assert.Equal(t, []string{"a", "b", "c"}, m.Hashes())
+	assert.True(t, m.Has("b"))
+	assert.False(t, m.Has("d"))
+
+	_, err = ParseManifest([]byte(`{"schemaVersion":1,"id":"xxx"}`))
+	assert.Same(t, ErrInvalidManifest, err)
+	_, err = ParseManifest([]byte(`aaa`))
+	assert.Same(t, ErrInvalidManifest, err)
+}
+
+func TestManifest_ETag(t *testing.T) {
+	m1 := &Manifest{Scene: "a", NLSLayers: []string{"b"}}
+	m2 := &Manifest{Scene: "a", NLSLayers: []string{"c"}}
+	assert.Equal(t, m1.ETag(), (&Manifest{Scene: "a", NLSLayers: []string{"b"}}).ETag())
+	assert.NotEqual(t, m1.ETag(), m2.ETag())
+	assert.Regexp(t, `^"[0-9a-f]{64}"$`, m1.ETag())
+}
+
+func TestManifest_Assemble(t *testing.T) {
+	core := NewChunk([]byte(`{"schemaVersion":1,"id":"s","nlsLayers":null,"layerStyles":null,"layers":[]}`))
+	l1 := NewChunk([]byte(`{"id":"l1"}`))
+	l2 := NewChunk([]byte(`{"id":"l2"}`))
+	styles := NewChunk([]byte(`[{"id":"st"}]`))
+	chunks := map[string][]byte{}
+	for _, c := range []Chunk{core, l1, l2, styles} {
+		chunks[c.Hash] = c.Data
+	}
+	read := func(_ context.Context, h string) (io.ReadCloser, error) {
+		if d, ok := chunks[h]; ok {
+			return io.NopCloser(bytes.NewReader(d)), nil
+		}
+		return nil, rerror.ErrNotFound
+	}
+
+	var b bytes.Buffer
+	m := &Manifest{Type: manifestType, Scene: core.Hash, NLSLayers: []string{l1.Hash, l2.Hash}, LayerStyles: styles.Hash}
+	assert.NoError(t, m.Assemble(context.Background(), &b, read))
+	assert.JSONEq(t, `{"schemaVersion":1,"id":"s","layers":[],"nlsLayers":[{"id":"l1"},{"id":"l2"}],"layerStyles":[{"id":"st"}]}`, b.String())
+
+	b.Reset()
+	m = &Manifest{Type: manifestType, Scene: core.Hash}
+	assert.NoError(t, m.Assemble(context.Background(), &b, read))
+	assert.JSONEq(t, `{"schemaVersion":1,"id":"s","layers":[],"nlsLayers":null,"layerStyles":null}`, b.String())
+
+	m = &Manifest{Type: manifestType, Scene: core.Hash, NLSLayers: []string{"x"}}
+	assert.Same(t, rerror.ErrNotFound, m.Assemble(context.Background(), &b, read))
+}
@@ -35,7 +35,7 @@ func (b *Builder) sceneJSON(ctx context.Context, publishedAt time.Time, l []*lay
 	}
 
 	return &sceneJSON{
-		SchemaVersion:     version,
+		SchemaVersion:     SchemaVersion,
 		ID:                b.scene.ID().String(),
 		PublishedAt:       publishedAt,
 		Property:          b.property(ctx, findProperty(p, b.scene.Property())),
@@ -18,6 +18,8 @@ type Scene struct {
 	property  PropertyID
 	clusters  *ClusterList
 	styles    *StyleList
+	// version is incremented every time the scene is saved, and is used to detect concurrent edits.
+	version int
 }
 
 func (s *Scene) ID() ID {
@@ -90,6 +92,20 @@ func (s *Scene) SetUpdatedAt(updatedAt time.Time) {
 	s.updatedAt = updatedAt
 }
 
+func (s *Scene) Version() int {
+	if s == nil {
+		return 0
+	}
+	return s.version
+}
+
+func (s *Scene) SetVersion(v int) {
+	if s == nil {
+		return
+	}
+	s.version = v
+}
+
 func (s *Scene) Properties() []PropertyID {
 	if s == nil {
 		return nil
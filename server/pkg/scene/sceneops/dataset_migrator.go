@@ -26,6 +26,9 @@ type MigrateDatasetResult struct {
 	RemovedLayers         *layer.IDSet
 	RemovedDatasetSchemas []dataset.SchemaID
 	RemovedDatasets       []dataset.ID
+	// Diffs is the differences of the datasets from the old ones, keyed by the new schema IDs.
+	// Schemas that have no old schemas of the same source are not included.
+	Diffs map[dataset.SchemaID]dataset.Diff
 }
 
 func (r MigrateDatasetResult) Merge(r2 MigrateDatasetResult) MigrateDatasetResult {
@@ -157,6 +160,7 @@ func (srv DatasetMigrator) Migrate(ctx context.Context, sid dataset.SceneID, new
 
 	result.RemovedDatasetSchemas = append(result.RemovedDatasetSchemas, noLogerUsedDS...)
 	result.RemovedDatasets = append(result.RemovedDatasets, noLogerUsedD...)
+	result.Diffs = datasetDiffMap
 	return result, nil
 }
 
@@ -1,10 +1,11 @@
 package scene
 
 type Style struct {
-	id    StyleID
-	name  string
-	value *StyleValue
-	scene ID
+	id      StyleID
+	name    string
+	value   *StyleValue
+	scene   ID
+	version int
 }
 
 func (s *Style) ID() StyleID {
@@ -46,6 +47,29 @@ func (l *Style) Scene() ID {
 	return l.scene
 }
 
+func (s *Style) Version() int {
+	if s == nil {
+		return 0
+	}
+	return s.version
+}
+
+func (s *Style) SetVersion(v int) {
+	if s == nil {
+		return
+	}
+	s.version = v
+}
+
+// Clone returns a copy of the style. The value is shared since it is replaced rather than modified on update.
+func (s *Style) Clone() *Style {
+	if s == nil {
+		return nil
+	}
+	s2 := *s
+	return &s2
+}
+
 func (s *Style) Duplicate() *Style {
 	if s == nil {
 		return nil
@@ -47,3 +47,8 @@ func (b *StyleBuilder) Name(n string) *StyleBuilder {
 	b.s.name = n
 	return b
 }
+
+func (b *StyleBuilder) Version(v int) *StyleBuilder {
+	b.s.version = v
+	return b
+}
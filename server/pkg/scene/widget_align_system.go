@@ -32,6 +32,17 @@ func NewWidgetAlignSystem() *WidgetAlignSystem {
 	return &WidgetAlignSystem{}
 }
 
+// Clone returns a deep copy of the align system.
+func (was *WidgetAlignSystem) Clone() *WidgetAlignSystem {
+	if was == nil {
+		return nil
+	}
+	return &WidgetAlignSystem{
+		inner: was.inner.Clone(),
+		outer: was.outer.Clone(),
+	}
+}
+
 // Zone will return a specific zone in the align system.
 func (was *WidgetAlignSystem) Zone(zone WidgetZoneType) *WidgetZone {
 	if was == nil {
@@ -62,6 +73,17 @@ func (was *WidgetAlignSystem) Remove(wid WidgetID) {
 	was.outer.Remove(wid)
 }
 
+// ReplaceWidgetIDs replaces IDs of widgets with the IDs in the map, e.g. when the widgets are copied with new IDs.
+// Widgets that are not in the map are kept as they are.
+func (was *WidgetAlignSystem) ReplaceWidgetIDs(ids map[WidgetID]WidgetID) {
+	if was == nil {
+		return
+	}
+
+	was.inner.ReplaceWidgetIDs(ids)
+	was.outer.ReplaceWidgetIDs(ids)
+}
+
 func (was *WidgetAlignSystem) Area(loc WidgetLocation) *WidgetArea {
 	return was.Zone(loc.Zone).Section(loc.Section).Area(loc.Area)
 }
@@ -18,6 +18,20 @@ func TestWidgetAlignSystem_Zone(t *testing.T) {
 	assert.NotNil(t, was.outer)
 }
 
+func TestWidgetAlignSystem_Clone(t *testing.T) {
+	wid := NewWidgetID()
+	was := NewWidgetAlignSystem()
+	was.Area(WidgetLocation{Zone: WidgetZoneInner, Section: WidgetSectionLeft, Area: WidgetAreaTop}).Add(wid, -1)
+
+	got := was.Clone()
+	assert.Equal(t, was, got)
+	assert.NotSame(t, was.inner.left.top, got.inner.left.top)
+
+	got.Remove(wid)
+	assert.Equal(t, WidgetIDList{wid}, was.inner.left.top.WidgetIDs())
+	assert.Nil(t, (*WidgetAlignSystem)(nil).Clone())
+}
+
 func TestWidgetAlignSystem_Area(t *testing.T) {
 	was := NewWidgetAlignSystem()
 	assert.Same(t, was.inner.right.middle, was.Area(WidgetLocation{
@@ -151,6 +165,22 @@ func TestWidgetAlignSystem_Remove(t *testing.T) {
 	}
 }
 
+func TestWidgetAlignSystem_ReplaceWidgetIDs(t *testing.T) {
+	wid1, wid2, wid3 := NewWidgetID(), NewWidgetID(), NewWidgetID()
+	was := NewWidgetAlignSystem()
+	was.Area(WidgetLocation{Zone: WidgetZoneInner, Section: WidgetSectionLeft, Area: WidgetAreaTop}).AddAll([]WidgetID{wid1, wid2})
+	was.Area(WidgetLocation{Zone: WidgetZoneOuter, Section: WidgetSectionRight, Area: WidgetAreaBottom}).Add(wid3, -1)
+
+	wid1b, wid3b := NewWidgetID(), NewWidgetID()
+	was.ReplaceWidgetIDs(map[WidgetID]WidgetID{wid1: wid1b, wid3: wid3b})
+	assert.Equal(t, WidgetIDList{wid1b, wid2}, was.inner.left.top.WidgetIDs())
+	assert.Equal(t, WidgetIDList{wid3b}, was.outer.right.bottom.WidgetIDs())
+	// areas which do not exist are not created
+	assert.Nil(t, was.inner.center)
+
+	(*WidgetAlignSystem)(nil).ReplaceWidgetIDs(nil)
+}
+
 func TestWidgetAlignSystem_Move(t *testing.T) {
 	wid1 := NewWidgetID()
 	wid2 := NewWidgetID()
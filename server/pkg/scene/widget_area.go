@@ -35,6 +35,20 @@ func NewWidgetArea(widgetIds []WidgetID, align WidgetAlignType, padding *WidgetA
 	return wa
 }
 
+func (a *WidgetArea) Clone() *WidgetArea {
+	if a == nil {
+		return nil
+	}
+	return &WidgetArea{
+		widgetIds:  a.widgetIds.Clone(),
+		align:      a.align,
+		padding:    util.CloneRef(a.padding),
+		gap:        util.CloneRef(a.gap),
+		centered:   a.centered,
+		background: util.CloneRef(a.background),
+	}
+}
+
 // WidgetIds will return a slice of widget ids from a specific area.
 func (a *WidgetArea) WidgetIDs() WidgetIDList {
 	if a == nil {
@@ -159,6 +173,18 @@ func (a *WidgetArea) Remove(wid WidgetID) {
 	}
 }
 
+func (a *WidgetArea) ReplaceWidgetIDs(ids map[WidgetID]WidgetID) {
+	if a == nil {
+		return
+	}
+
+	for i, w := range a.widgetIds {
+		if w2, ok := ids[w]; ok {
+			a.widgetIds[i] = w2
+		}
+	}
+}
+
 func (a *WidgetArea) Move(from, to int) {
 	if a == nil {
 		return
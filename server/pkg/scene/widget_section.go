@@ -19,6 +19,17 @@ func NewWidgetSection() *WidgetSection {
 	return &WidgetSection{}
 }
 
+func (s *WidgetSection) Clone() *WidgetSection {
+	if s == nil {
+		return nil
+	}
+	return &WidgetSection{
+		top:    s.top.Clone(),
+		middle: s.middle.Clone(),
+		bottom: s.bottom.Clone(),
+	}
+}
+
 func (s *WidgetSection) Area(t WidgetAreaType) *WidgetArea {
 	if s == nil {
 		return nil
@@ -71,6 +82,16 @@ func (s *WidgetSection) Remove(wid WidgetID) {
 	s.bottom.Remove(wid)
 }
 
+func (s *WidgetSection) ReplaceWidgetIDs(ids map[WidgetID]WidgetID) {
+	if s == nil {
+		return
+	}
+
+	s.top.ReplaceWidgetIDs(ids)
+	s.middle.ReplaceWidgetIDs(ids)
+	s.bottom.ReplaceWidgetIDs(ids)
+}
+
 func (s *WidgetSection) SetArea(t WidgetAreaType, a *WidgetArea) {
 	if s == nil {
 		return
@@ -19,6 +19,17 @@ func NewWidgetZone() *WidgetZone {
 	return &WidgetZone{}
 }
 
+func (z *WidgetZone) Clone() *WidgetZone {
+	if z == nil {
+		return nil
+	}
+	return &WidgetZone{
+		left:   z.left.Clone(),
+		center: z.center.Clone(),
+		right:  z.right.Clone(),
+	}
+}
+
 func (wz *WidgetZone) Section(s WidgetSectionType) *WidgetSection {
 	switch s {
 	case WidgetSectionLeft:
@@ -50,6 +61,16 @@ func (z *WidgetZone) Remove(wid WidgetID) {
 	z.right.Remove(wid)
 }
 
+func (z *WidgetZone) ReplaceWidgetIDs(ids map[WidgetID]WidgetID) {
+	if z == nil {
+		return
+	}
+
+	z.left.ReplaceWidgetIDs(ids)
+	z.center.ReplaceWidgetIDs(ids)
+	z.right.ReplaceWidgetIDs(ids)
+}
+
 func (z *WidgetZone) Find(wid WidgetID) (int, WidgetSectionType, WidgetAreaType) {
 	if z == nil {
 		return -1, "", ""
@@ -0,0 +1,373 @@
+package shp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/japanese"
+)
+
+var ErrUnsupportedCodepage = errors.New("unsupported codepage")
+
+// UTF8Codepage is the content of a .cpg file for DBF files written by WriteDBF.
+const UTF8Codepage = "UTF-8"
+
+// WGS84Projection is the content of a .prj file for shapefiles in WGS84 longitude and latitude.
+const WGS84Projection = `GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]]`
+
+// language driver IDs in DBF headers which are used when there is no .cpg file
+var languageDrivers = map[byte]encoding.Encoding{
+	0x01: charmap.CodePage437,
+	0x02: charmap.CodePage850,
+	0x03: charmap.Windows1252,
+	0x13: japanese.ShiftJIS,
+	0x57: charmap.Windows1252,
+	0x7b: japanese.ShiftJIS,
+}
+
+// Codepage returns the encoding which is specified by the content of a .cpg file, such as "UTF-8", "SJIS" or "1252".
+// It returns nil for UTF-8 since strings in Go are UTF-8.
+func Codepage(cpg string) (encoding.Encoding, error) {
+	name := strings.ToLower(strings.TrimSpace(cpg))
+	name = strings.TrimPrefix(name, "ansi ")
+	switch name {
+	case "", "utf-8", "utf8", "65001":
+		return nil, nil
+	case "sjis", "cp932", "932", "ms932", "windows-31j":
+		return japanese.ShiftJIS, nil
+	case "eucjp", "euc-jp", "20932", "51932":
+		return japanese.EUCJP, nil
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(name, "cp")); err == nil {
+		switch {
+		case n >= 1250 && n <= 1258:
+			name = "windows-" + strconv.Itoa(n)
+		case n >= 28591 && n <= 28606:
+			name = "iso-8859-" + strconv.Itoa(n-28590)
+		}
+	}
+	e, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, ErrUnsupportedCodepage
+	}
+	return e, nil
+}
+
+// dbfReader reads a DBF table sequentially.
+type dbfReader struct {
+	r            io.Reader
+	decoder      *encoding.Decoder
+	fields       []Field
+	numRecords   int32
+	headerLength int16
+	recordLength int16
+	row          []byte
+}
+
+// newDBFReader reads the header of a DBF table. If enc is nil, the encoding is detected
+// from the language driver ID of the header and it falls back to UTF-8.
+func newDBFReader(r io.Reader, enc encoding.Encoding) (*dbfReader, error) {
+	d := &dbfReader{r: r}
+
+	header := make([]byte, 32)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("error when reading DBF header: %v", err)
+	}
+	d.numRecords = int32(binary.LittleEndian.Uint32(header[4:8]))
+	d.headerLength = int16(binary.LittleEndian.Uint16(header[8:10]))
+	d.recordLength = int16(binary.LittleEndian.Uint16(header[10:12]))
+	if enc == nil {
+		enc = languageDrivers[header[29]]
+	}
+	if enc != nil {
+		d.decoder = enc.NewDecoder()
+	}
+
+	numFields := int(math.Floor(float64(d.headerLength-33) / 32.0))
+	if numFields < 0 {
+		return nil, fmt.Errorf("invalid DBF header length: %d", d.headerLength)
+	}
+	d.fields = make([]Field, numFields)
+	if err := binary.Read(r, binary.LittleEndian, &d.fields); err != nil {
+		return nil, fmt.Errorf("error when reading DBF fields: %v", err)
+	}
+
+	// skip the terminator and the rest of the header
+	if _, err := io.CopyN(io.Discard, r, int64(d.headerLength)-32-int64(numFields)*32); err != nil {
+		return nil, fmt.Errorf("error when reading DBF header: %v", err)
+	}
+
+	d.row = make([]byte, d.recordLength)
+	return d, nil
+}
+
+func (d *dbfReader) next() error {
+	if _, err := io.ReadFull(d.r, d.row); err != nil {
+		return fmt.Errorf("error when reading DBF row: %v", err)
+	}
+	if d.row[0] != 0x20 && d.row[0] != 0x2a {
+		return fmt.Errorf("attribute row starts with incorrect deletion indicator")
+	}
+	return nil
+}
+
+// attribute returns the n-th attribute of the current row as a string.
+func (d *dbfReader) attribute(n int) string {
+	if n < 0 || n >= len(d.fields) {
+		return ""
+	}
+	start := 1
+	for f := 0; f < n; f++ {
+		start += int(d.fields[f].Size)
+	}
+	end := start + int(d.fields[n].Size)
+	if end > len(d.row) {
+		return ""
+	}
+	b := d.row[start:end]
+	if d.decoder != nil {
+		if decoded, err := d.decoder.Bytes(b); err == nil {
+			b = decoded
+		}
+	}
+	return strings.Trim(string(b), " \x00")
+}
+
+// attributes returns all attributes of the current row with values converted according to field types.
+func (d *dbfReader) attributes() map[string]any {
+	res := make(map[string]any, len(d.fields))
+	for i, f := range d.fields {
+		res[f.String()] = FieldValue(f, d.attribute(i))
+	}
+	return res
+}
+
+// FieldValue converts a raw attribute value into a value of the type of the field.
+// Numbers become int64 or float64, logicals become bool and dates become strings formatted as YYYY-MM-DD.
+// Empty or undefined values become nil.
+func FieldValue(f Field, v string) any {
+	switch f.Fieldtype {
+	case 'N', 'F':
+		if v == "" || strings.Trim(v, "*") == "" {
+			return nil
+		}
+		if f.Precision == 0 {
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+		return nil
+	case 'L':
+		switch v {
+		case "T", "t", "Y", "y":
+			return true
+		case "F", "f", "N", "n":
+			return false
+		}
+		return nil
+	case 'D':
+		if t, err := time.Parse("20060102", v); err == nil {
+			return t.Format("2006-01-02")
+		}
+		if v == "" {
+			return nil
+		}
+	}
+	return v
+}
+
+// WriteDBF writes a DBF table encoded in UTF-8. Each record should have values for all fields in the same order.
+// Values which do not fit in fields are truncated.
+func WriteDBF(w io.Writer, fields []Field, records [][]any) error {
+	bw := bufio.NewWriter(w)
+
+	recordLength := 1
+	for _, f := range fields {
+		recordLength += int(f.Size)
+	}
+	headerLength := 32 + 32*len(fields) + 1
+	if recordLength > math.MaxUint16 || headerLength > math.MaxUint16 {
+		return errors.New("too many DBF fields")
+	}
+
+	now := time.Now()
+	header := make([]byte, 32)
+	header[0] = 0x03
+	header[1] = byte(now.Year() - 1900)
+	header[2] = byte(now.Month())
+	header[3] = byte(now.Day())
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(records)))
+	binary.LittleEndian.PutUint16(header[8:10], uint16(headerLength))
+	binary.LittleEndian.PutUint16(header[10:12], uint16(recordLength))
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, fields); err != nil {
+		return err
+	}
+	if err := bw.WriteByte(0x0d); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if err := bw.WriteByte(0x20); err != nil {
+			return err
+		}
+		for i, f := range fields {
+			var v any
+			if i < len(r) {
+				v = r[i]
+			}
+			if _, err := bw.WriteString(formatFieldValue(f, v)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := bw.WriteByte(0x1a); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// formatFieldValue formats the value so that it fills the whole field.
+func formatFieldValue(f Field, v any) string {
+	size := int(f.Size)
+	var s string
+	switch f.Fieldtype {
+	case 'N', 'F':
+		if n, ok := toFloat(v); ok {
+			s = strconv.FormatFloat(n, 'f', int(f.Precision), 64)
+			if len(s) > size {
+				s = strings.Repeat("*", size)
+			}
+		}
+		return strings.Repeat(" ", size-len(s)) + s
+	case 'L':
+		s = "?"
+		if b, ok := v.(bool); ok {
+			s = "F"
+			if b {
+				s = "T"
+			}
+		}
+	case 'D':
+		if t, ok := v.(time.Time); ok {
+			s = t.Format("20060102")
+		} else if str, ok := v.(string); ok {
+			if t, err := time.Parse("2006-01-02", str); err == nil {
+				s = t.Format("20060102")
+			}
+		}
+	default:
+		if n, ok := toFloat(v); ok {
+			s = strconv.FormatFloat(n, 'f', -1, 64)
+		} else if v != nil {
+			s = fmt.Sprint(v)
+		}
+	}
+	s = truncateUTF8(s, size)
+	return s + strings.Repeat(" ", size-len(s))
+}
+
+// FieldNames returns names which are valid as DBF field names. Names are truncated to 10 bytes
+// and suffixed with numbers when they conflict with each other.
+func FieldNames(names []string) []string {
+	res := make([]string, 0, len(names))
+	used := map[string]struct{}{}
+	for _, n := range names {
+		name := truncateUTF8(n, 10)
+		if name == "" {
+			name = "FIELD"
+		}
+		for i := 1; ; i++ {
+			if _, ok := used[name]; !ok {
+				break
+			}
+			suffix := strconv.Itoa(i)
+			name = truncateUTF8(n, 10-len(suffix)) + suffix
+		}
+		used[name] = struct{}{}
+		res = append(res, name)
+	}
+	return res
+}
+
+// FieldFor returns a field which is large enough to store all values. Numbers are stored in
+// numeric fields, booleans in logical fields and other values in character fields as strings.
+func FieldFor(name string, values []any) Field {
+	numbers, bools, others := 0, 0, 0
+	intLen, fracLen, strLen := 1, 0, 1
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		str := fmt.Sprint(v)
+		if n, ok := toFloat(v); ok {
+			numbers++
+			str = strconv.FormatFloat(n, 'f', -1, 64)
+			i, f, _ := strings.Cut(str, ".")
+			intLen = max(intLen, len(i))
+			fracLen = max(fracLen, len(f))
+		} else if _, ok := v.(bool); ok {
+			bools++
+		} else {
+			others++
+		}
+		strLen = max(strLen, len(str))
+	}
+
+	switch {
+	case numbers > 0 && bools == 0 && others == 0:
+		fracLen = min(fracLen, 15)
+		if fracLen == 0 {
+			return NumberField(name, uint8(min(intLen, 254)))
+		}
+		return FloatField(name, uint8(min(intLen+fracLen+1, 254)), uint8(fracLen))
+	case bools > 0 && numbers == 0 && others == 0:
+		return LogicalField(name)
+	}
+	return StringField(name, uint8(min(strLen, 254)))
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// truncateUTF8 truncates the string to the size in bytes without breaking a multi-byte character.
+func truncateUTF8(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	s = s[:size]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,144 @@
+package shp
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	wsc "github.com/reearth/reearth/server/pkg/writer"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestCodepage(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected encoding.Encoding
+		err      error
+	}{
+		{input: "", expected: nil},
+		{input: "UTF-8\n", expected: nil},
+		{input: "SJIS", expected: japanese.ShiftJIS},
+		{input: "CP932", expected: japanese.ShiftJIS},
+		{input: "1252", expected: charmap.Windows1252},
+		{input: "ANSI 1252", expected: charmap.Windows1252},
+		{input: "ISO-8859-1", expected: charmap.Windows1252},
+		{input: "unknown", err: ErrUnsupportedCodepage},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := Codepage(tc.input)
+			if tc.err != nil {
+				assert.Same(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestWriteDBF(t *testing.T) {
+	fields := []Field{
+		StringField("name", 8),
+		NumberField("count", 5),
+		FloatField("height", 8, 2),
+		LogicalField("visible"),
+		DateField("date"),
+	}
+	records := [][]any{
+		{"東京タワー", 1, 332.6, true, "1958-12-23"},
+		{"a", nil, nil, nil, nil},
+		{"toolongvalue", 123456, 1.005, false, "invalid"},
+	}
+
+	var shpBuf, dbfBuf bytes.Buffer
+	assert.NoError(t, WriteDBF(&dbfBuf, fields, records))
+
+	// write shapes for the records to read them with the reader
+	var ws wsc.WriterSeeker
+	w, err := CreateFrom(&ws, POINT)
+	assert.NoError(t, err)
+	for range records {
+		_, err := w.Write(&Point{})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	shpBuf.Write(ws.Buffer())
+
+	r, err := ReadFromExt(&shpBuf, &dbfBuf, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, fields, r.Fields())
+
+	var got []map[string]any
+	for r.Next() {
+		got = append(got, r.Attributes())
+	}
+	assert.NoError(t, r.Err())
+	assert.Equal(t, []map[string]any{
+		// multi-byte characters are not broken when they are truncated
+		{"name": "東京", "count": int64(1), "height": 332.6, "visible": true, "date": "1958-12-23"},
+		{"name": "a", "count": nil, "height": nil, "visible": nil, "date": nil},
+		{"name": "toolongv", "count": nil, "height": 1.0, "visible": false, "date": nil},
+	}, got)
+	assert.Equal(t, "toolongv", r.ReadAttribute(2, 0))
+	assert.Equal(t, "*****", r.ReadAttribute(2, 1))
+}
+
+func TestReadFromExt_Encoding(t *testing.T) {
+	name, _ := japanese.ShiftJIS.NewEncoder().String("東京")
+	var dbfBuf bytes.Buffer
+	assert.NoError(t, WriteDBF(&dbfBuf, []Field{StringField("name", 10)}, [][]any{{name}}))
+
+	var ws wsc.WriterSeeker
+	w, _ := CreateFrom(&ws, POINT)
+	_, _ = w.Write(&Point{})
+	_ = w.Close()
+
+	r, err := ReadFromExt(bytes.NewReader(ws.Buffer()), bytes.NewReader(dbfBuf.Bytes()), japanese.ShiftJIS)
+	assert.NoError(t, err)
+	assert.True(t, r.Next())
+	assert.Equal(t, "東京", r.Attribute(0))
+}
+
+func TestZipReader_Attributes(t *testing.T) {
+	f, err := os.Open("test_files/ne_110m_admin_0_countries.zip")
+	assert.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+
+	zr, err := ReadZipFrom(f)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(zr.Projection(), "GEOGCS"))
+	assert.Len(t, zr.Fields(), 94)
+
+	assert.True(t, zr.Next())
+	attrs := zr.Attributes()
+	assert.Equal(t, "Fiji", attrs["NAME"])
+	assert.Equal(t, "フィジー", attrs["NAME_JA"])
+	assert.Equal(t, int64(920938), attrs["POP_EST"])
+	assert.Equal(t, "Fiji", zr.Attribute(8))
+}
+
+func TestFieldNames(t *testing.T) {
+	assert.Equal(t,
+		[]string{"name", "population", "populatio1", "FIELD", "東京タ", "東京ス", "東京タ1"},
+		FieldNames([]string{"name", "population", "population_2020", "", "東京タワー", "東京スカイツリー", "東京タワー"}),
+	)
+}
+
+func TestFieldFor(t *testing.T) {
+	assert.Equal(t, NumberField("a", 5), FieldFor("a", []any{1, nil, -1234, float64(10)}))
+	assert.Equal(t, FloatField("a", 7, 3), FieldFor("a", []any{1.5, 123.125, nil}))
+	assert.Equal(t, LogicalField("a"), FieldFor("a", []any{true, nil, false}))
+	assert.Equal(t, StringField("a", 4), FieldFor("a", []any{"abc", 1.25, true}))
+	assert.Equal(t, StringField("a", 1), FieldFor("a", []any{nil}))
+	assert.Equal(t, StringField("a", 254), FieldFor("a", []any{strings.Repeat("a", 300)}))
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"math"
+
+	"golang.org/x/text/encoding"
 )
 
 // Reader provides a interface for reading Shapefiles. Calls
@@ -23,12 +25,8 @@ type Reader struct {
 	// filename   string
 	filelength int64
 
-	/* Note: not used
-	dbf             io.ReadSeeker
-	dbfFields       []Field
-	dbfNumRecords   int32
-	dbfHeaderLength int16
-	dbfRecordLength int16*/
+	dbf       io.ReadSeeker
+	dbfReader *dbfReader
 }
 
 // ReadFrom read from io.Reader
@@ -43,6 +41,24 @@ func ReadFrom(r io.Reader) (*Reader, error) {
 	return sr, sr.readHeaders()
 }
 
+// ReadFromExt reads a shapefile from shp and its attributes from dbf. Strings in dbf are decoded
+// with enc, and if enc is nil, the encoding is detected from the language driver ID of dbf.
+func ReadFromExt(shp, dbf io.Reader, enc encoding.Encoding) (*Reader, error) {
+	sr, err := ReadFrom(shp)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(dbf); err != nil {
+		return nil, err
+	}
+	sr.dbf = bytes.NewReader(buf.Bytes())
+	if sr.dbfReader, err = newDBFReader(sr.dbf, enc); err != nil {
+		return nil, err
+	}
+	return sr, nil
+}
+
 // BBox returns the bounding box of the shapefile.
 func (r *Reader) BBox() Box {
 	return r.bbox
@@ -100,12 +116,20 @@ func (r *Reader) Shape() (int, Shape) {
 	return int(r.num) - 1, r.shape
 }
 
-/* Note: not used
 // Attribute returns value of the n-th attribute of the most recent feature
 // that was read by a call to Next.
 func (r *Reader) Attribute(n int) string {
 	return r.ReadAttribute(int(r.num)-1, n)
-}*/
+}
+
+// Attributes returns all attributes of the most recent feature by field names.
+// It returns nil if the reader does not have a DBF table.
+func (r *Reader) Attributes() map[string]any {
+	if r.dbfReader == nil || !r.readRow(int(r.num)-1) {
+		return nil
+	}
+	return r.dbfReader.attributes()
+}
 
 // newShape creates a new shape with a given type.
 func newShape(shapetype ShapeType) (Shape, error) {
@@ -202,59 +226,14 @@ func (r *Reader) Next() bool {
 	return true
 }
 
-/* Note: not used
-// Opens DBF file using r.filename + "dbf". This method
-// will parse the header and fill out all dbf* values int
-// the f object.
-func (r *Reader) openDbf() (err error) {
-	if r.dbf != nil {
-		return
-	}
-
-	r.dbf, err = os.Open(r.filename + ".dbf")
-	if err != nil {
-		return
-	}
-
-	// read header
-	_, err = r.dbf.Seek(4, io.SeekStart)
-	if err != nil {
-		return err
-	}
-	err = binary.Read(r.dbf, binary.LittleEndian, &r.dbfNumRecords)
-	if err != nil {
-		return err
-	}
-	err = binary.Read(r.dbf, binary.LittleEndian, &r.dbfHeaderLength)
-	if err != nil {
-		return err
-	}
-	err = binary.Read(r.dbf, binary.LittleEndian, &r.dbfRecordLength)
-	if err != nil {
-		return err
-	}
-	_, err = r.dbf.Seek(20, io.SeekCurrent) // skip padding
-	if err != nil {
-		return err
-	}
-	numFields := int(math.Floor(float64(r.dbfHeaderLength-33) / 32.0))
-	r.dbfFields = make([]Field, numFields)
-	err = binary.Read(r.dbf, binary.LittleEndian, &r.dbfFields)
-	if err != nil {
-		return err
-	}
-	return
-}
-
 // Fields returns a slice of Fields that are present in the
 // DBF table.
 func (r *Reader) Fields() []Field {
-	err := r.openDbf() // make sure we have dbf file to read from
-	if err != nil {
+	if r.dbfReader == nil {
 		return nil
 	}
-	return r.dbfFields
-}*/
+	return r.dbfReader.fields
+}
 
 // Err returns the last non-EOF error encountered.
 func (r *Reader) Err() error {
@@ -264,26 +243,23 @@ func (r *Reader) Err() error {
 	return r.err
 }
 
-/* Note: not used
 // ReadAttribute returns the attribute value at row for field in
 // the DBF table as a string. Both values starts at 0.
 func (r *Reader) ReadAttribute(row int, field int) string {
-	err := r.openDbf() // make sure we have a dbf file to read from
-	if err != nil {
+	if r.dbfReader == nil || !r.readRow(row) {
 		return ""
 	}
-	seekTo := 1 + int64(r.dbfHeaderLength) + (int64(row) * int64(r.dbfRecordLength))
-	for n := 0; n < field; n++ {
-		seekTo += int64(r.dbfFields[n].Size)
-	}
-	_, err = r.dbf.Seek(seekTo, io.SeekStart)
-	if err != nil {
-		return ""
+	return r.dbfReader.attribute(field)
+}
+
+// readRow reads the row of the DBF table into the buffer of the DBF reader.
+func (r *Reader) readRow(row int) bool {
+	if row < 0 || row >= int(r.dbfReader.numRecords) {
+		return false
 	}
-	buf := make([]byte, r.dbfFields[field].Size)
-	_, err = r.dbf.Read(buf)
-	if err != nil {
-		return ""
+	seekTo := int64(r.dbfReader.headerLength) + (int64(row) * int64(r.dbfReader.recordLength))
+	if _, err := r.dbf.Seek(seekTo, io.SeekStart); err != nil {
+		return false
 	}
-	return strings.Trim(string(buf[:]), " ")
-}*/
+	return r.dbfReader.next() == nil
+}
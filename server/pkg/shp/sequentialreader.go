@@ -4,6 +4,8 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+
+	"golang.org/x/text/encoding"
 )
 
 // SequentialReader is the interface that allows reading shapes and attributes one after another. It also embeds io.Closer.
@@ -20,7 +22,6 @@ type SequentialReader interface {
 	// encountered any errors, nil is returned for the Shape.
 	Shape() (int, Shape)
 
-	/* Note: not used
 	// Attribute returns the value of the n-th attribute in the current row. If
 	// the SequentialReader encountered any errors, the empty string is
 	// returned.
@@ -28,13 +29,12 @@ type SequentialReader interface {
 
 	// Fields returns the fields of the database. If the SequentialReader
 	// encountered any errors, nil is returned.
-	Fields() []Field*/
+	Fields() []Field
 
 	// Err returns the last non-EOF error encountered.
 	Err() error
 }
 
-/* Note: not used
 // Attributes returns all attributes of the shape that sr was last advanced to.
 func Attributes(sr SequentialReader) []string {
 	if sr.Err() != nil {
@@ -50,13 +50,27 @@ func Attributes(sr SequentialReader) []string {
 // AttributeCount returns the number of fields of the database.
 func AttributeCount(sr SequentialReader) int {
 	return len(sr.Fields())
-}*/
+}
+
+// AttributeMap returns all attributes of the shape that sr was last advanced to by field names.
+// Values are converted according to field types by FieldValue.
+func AttributeMap(sr SequentialReader) map[string]any {
+	fields := sr.Fields()
+	if sr.Err() != nil || len(fields) == 0 {
+		return nil
+	}
+	res := make(map[string]any, len(fields))
+	for i, f := range fields {
+		res[f.String()] = FieldValue(f, sr.Attribute(i))
+	}
+	return res
+}
 
 // seqReader implements SequentialReader based on external io.ReadCloser
 // instances
 type seqReader struct {
-	shp/*, dbf*/ io.ReadCloser
-	err error
+	shp, dbf io.ReadCloser
+	err      error
 
 	geometryType ShapeType
 	bbox         Box
@@ -65,12 +79,8 @@ type seqReader struct {
 	num        int32
 	filelength int64
 
-	/* Note: not used
-	dbfFields       []Field
-	dbfNumRecords   int32
-	dbfHeaderLength int16
-	dbfRecordLength int16
-	dbfRow          []byte*/
+	dbfEncoding encoding.Encoding
+	dbfReader   *dbfReader
 }
 
 // Read and parse headers in the Shapefile. This will fill out GeometryType,
@@ -117,59 +127,15 @@ func (sr *seqReader) readHeaders() {
 		return
 	}
 
-	/* Note: not used
 	// dbf header
-	er = &errReader{Reader: sr.dbf}
 	if sr.dbf == nil {
 		return
 	}
-	_, err = io.CopyN(io.Discard, er, 4)
-	if err != nil {
-		sr.err = err
-		return
-	}
-	err = binary.Read(er, binary.LittleEndian, &sr.dbfNumRecords)
+	sr.dbfReader, err = newDBFReader(sr.dbf, sr.dbfEncoding)
 	if err != nil {
 		sr.err = err
 		return
 	}
-	err = binary.Read(er, binary.LittleEndian, &sr.dbfHeaderLength)
-	if err != nil {
-		sr.err = err
-		return
-	}
-	err = binary.Read(er, binary.LittleEndian, &sr.dbfRecordLength)
-	if err != nil {
-		sr.err = err
-		return
-	}
-	_, err = io.CopyN(io.Discard, er, 20) // skip padding
-	if err != nil {
-		sr.err = err
-		return
-	}
-	numFields := int(math.Floor(float64(sr.dbfHeaderLength-33) / 32.0))
-	sr.dbfFields = make([]Field, numFields)
-	err = binary.Read(er, binary.LittleEndian, &sr.dbfFields)
-	if err != nil {
-		sr.err = err
-		return
-	}
-	buf := make([]byte, 1)
-	_, err = er.Read(buf[:])
-	if err != nil {
-		sr.err = fmt.Errorf("error when reading DBF header: %v", err)
-		return
-	}
-	if er.e != nil {
-		sr.err = fmt.Errorf("error when reading DBF header: %v", er.e)
-		return
-	}
-	if buf[0] != 0x0d {
-		sr.err = fmt.Errorf("Field descriptor array terminator not found")
-		return
-	}
-	sr.dbfRow = make([]byte, sr.dbfRecordLength)*/
 }
 
 // Next implements a method of interface SequentialReader for seqReader.
@@ -234,14 +200,11 @@ func (sr *seqReader) Next() bool {
 		sr.err = fmt.Errorf("error when discarding bytes on sequential read: %v", ce)
 		return false
 	}
-	/* Note: not used
-	if _, err := io.ReadFull(sr.dbf, sr.dbfRow); err != nil {
-		sr.err = fmt.Errorf("error when reading DBF row: %v", err)
-		return false
+	if sr.dbfReader != nil {
+		if err := sr.dbfReader.next(); err != nil {
+			sr.err = fmt.Errorf("error in row %d: %v", num, err)
+		}
 	}
-	if sr.dbfRow[0] != 0x20 && sr.dbfRow[0] != 0x2a {
-		sr.err = fmt.Errorf("Attribute row %d starts with incorrect deletion indicator", num)
-	}*/
 	return sr.err == nil
 }
 
@@ -250,20 +213,13 @@ func (sr *seqReader) Shape() (int, Shape) {
 	return int(sr.num) - 1, sr.shape
 }
 
-/* Note: not used
 // Attribute implements a method of interface SequentialReader for seqReader.
 func (sr *seqReader) Attribute(n int) string {
-	if sr.err != nil {
+	if sr.err != nil || sr.dbfReader == nil {
 		return ""
 	}
-	start := 1
-	f := 0
-	for ; f < n; f++ {
-		start += int(sr.dbfFields[f].Size)
-	}
-	s := string(sr.dbfRow[start : start+int(sr.dbfFields[f].Size)])
-	return strings.Trim(s, " ")
-}*/
+	return sr.dbfReader.attribute(n)
+}
 
 // Err returns the first non-EOF error that was encountered.
 func (sr *seqReader) Err() error {
@@ -278,23 +234,32 @@ func (sr *seqReader) Close() error {
 	if err := sr.shp.Close(); err != nil {
 		return err
 	}
-	/* Note: not used
-	if err := sr.dbf.Close(); err != nil {
-		return err
-	}*/
+	if sr.dbf != nil {
+		if err := sr.dbf.Close(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-/* Note: not used
 // Fields returns a slice of the fields that are present in the DBF table.
 func (sr *seqReader) Fields() []Field {
-	return sr.dbfFields
-}*/
+	if sr.err != nil || sr.dbfReader == nil {
+		return nil
+	}
+	return sr.dbfReader.fields
+}
 
 // SequentialReaderFromExt returns a new SequentialReader that interprets shp
-// as a source of shapes whose attributes can be retrieved from dbf.
-func SequentialReaderFromExt(shp /*, dbf*/ io.ReadCloser) SequentialReader {
-	sr := &seqReader{shp: shp /*, dbf: dbf*/}
+// as a source of shapes whose attributes can be retrieved from dbf. dbf is optional
+// and the encoding of its strings is detected from its language driver ID.
+func SequentialReaderFromExt(shp, dbf io.ReadCloser) SequentialReader {
+	return newSeqReader(shp, dbf, nil)
+}
+
+// newSeqReader returns a new seqReader which decodes strings in dbf with enc.
+func newSeqReader(shp, dbf io.ReadCloser, enc encoding.Encoding) *seqReader {
+	sr := &seqReader{shp: shp, dbf: dbf, dbfEncoding: enc}
 	sr.readHeaders()
 	return sr
 }
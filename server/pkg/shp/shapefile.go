@@ -3,6 +3,7 @@ package shp
 import (
 	"encoding/binary"
 	"io"
+	"strings"
 )
 
 //go:generate stringer -type=ShapeType
@@ -1024,23 +1025,21 @@ type Field struct {
 	Padding   [14]byte
 }
 
-/* Note: not used
 // Returns a string representation of the Field. Currently
 // this only returns field name.
 func (f Field) String() string {
 	return strings.TrimRight(string(f.Name[:]), "\x00")
 }
 
-// StringField returns a Field that can be used in SetFields to initialize the
+// StringField returns a Field that can be used in WriteDBF to initialize the
 // DBF file.
 func StringField(name string, length uint8) Field {
-	// TODO: Error checking
 	field := Field{Fieldtype: 'C', Size: length}
 	copy(field.Name[:], []byte(name))
 	return field
 }
 
-// NumberField returns a Field that can be used in SetFields to initialize the
+// NumberField returns a Field that can be used in WriteDBF to initialize the
 // DBF file.
 func NumberField(name string, length uint8) Field {
 	field := Field{Fieldtype: 'N', Size: length}
@@ -1048,7 +1047,7 @@ func NumberField(name string, length uint8) Field {
 	return field
 }
 
-// FloatField returns a Field that can be used in SetFields to initialize the
+// FloatField returns a Field that can be used in WriteDBF to initialize the
 // DBF file. Used to store floating points with precision in the DBF.
 func FloatField(name string, length uint8, precision uint8) Field {
 	field := Field{Fieldtype: 'F', Size: length, Precision: precision}
@@ -1056,11 +1055,19 @@ func FloatField(name string, length uint8, precision uint8) Field {
 	return field
 }
 
-// DateField feturns a Field that can be used in SetFields to initialize the
+// DateField feturns a Field that can be used in WriteDBF to initialize the
 // DBF file. Used to store Date strings formatted as YYYYMMDD. Data wise this
 // is the same as a StringField with length 8.
 func DateField(name string) Field {
 	field := Field{Fieldtype: 'D', Size: 8}
 	copy(field.Name[:], []byte(name))
 	return field
-}*/
+}
+
+// LogicalField returns a Field that can be used in WriteDBF to initialize the
+// DBF file. Used to store boolean values.
+func LogicalField(name string) Field {
+	field := Field{Fieldtype: 'L', Size: 1}
+	copy(field.Name[:], []byte(name))
+	return field
+}
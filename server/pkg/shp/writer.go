@@ -12,6 +12,8 @@ type Writer struct {
 	GeometryType ShapeType
 	num          int32
 	bbox         Box
+	// offsets and content lengths of records in 16-bit words for the index file
+	index [][2]int32
 }
 
 func CreateFrom(ws io.WriteSeeker, t ShapeType) (*Writer, error) {
@@ -37,8 +39,13 @@ func (w *Writer) Write(shape Shape) (int32, error) {
 		w.bbox.Extend(shape.BBox())
 	}
 
+	offset, err := w.shp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+
 	w.num++
-	err := binary.Write(w.shp, binary.BigEndian, w.num)
+	err = binary.Write(w.shp, binary.BigEndian, w.num)
 	if err != nil {
 		return 0, err
 	}
@@ -71,6 +78,7 @@ func (w *Writer) Write(shape Shape) (int32, error) {
 	if err != nil {
 		return 0, err
 	}
+	w.index = append(w.index, [2]int32{int32(offset / 2), length})
 	_, err = w.shp.Seek(finish, io.SeekStart)
 	if err != nil {
 		return 0, err
@@ -83,6 +91,21 @@ func (w *Writer) Close() error {
 	return w.writeHeader(w.shp)
 }
 
+// WriteIndex writes the index file (.shx) of the shapes written so far to ws.
+func (w *Writer) WriteIndex(ws io.WriteSeeker) error {
+	_, err := ws.Seek(100, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	for _, r := range w.index {
+		err = binary.Write(ws, binary.BigEndian, r)
+		if err != nil {
+			return err
+		}
+	}
+	return w.writeHeader(ws)
+}
+
 // writeHeader writes SHP to ws.
 func (w *Writer) writeHeader(ws io.WriteSeeker) error {
 	filelength, _ := ws.Seek(0, io.SeekEnd)
@@ -10,8 +10,9 @@ import (
 
 // ZipReader provides an interface for reading Shapefiles that are compressed in a ZIP archive.
 type ZipReader struct {
-	sr SequentialReader
-	z  *zip.Reader
+	sr         SequentialReader
+	z          *zip.Reader
+	projection string
 }
 
 // openFromZIP is convenience function for opening the file called name that is
@@ -50,14 +51,36 @@ func ReadZipFrom(r io.Reader) (*ZipReader, error) {
 	if err != nil {
 		return nil, err
 	}
-	/* Note: not used
 	withoutExt := strings.TrimSuffix(shapeFiles[0].Name, ".shp")
-	// dbf is optional, so no error checking here
-	dbf, _ := openFromZIP(zr.z, withoutExt+".dbf")*/
-	zr.sr = SequentialReaderFromExt(shp /*, dbf*/)
+	// dbf, cpg and prj are optional, so no error checking here
+	dbf, _ := openFromZIP(zr.z, withoutExt+".dbf")
+	cpg, _ := readFromZIP(zr.z, withoutExt+".cpg")
+	prj, _ := readFromZIP(zr.z, withoutExt+".prj")
+	enc, err := Codepage(cpg)
+	if err != nil {
+		return nil, err
+	}
+	zr.projection = strings.TrimSpace(prj)
+	zr.sr = newSeqReader(shp, dbf, enc)
 	return zr, nil
 }
 
+// readFromZIP reads the whole content of the file called name that is compressed in z.
+func readFromZIP(z *zip.Reader, name string) (string, error) {
+	f, err := openFromZIP(z, name)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
 func shapesInZip(z *zip.Reader) []*zip.File {
 	var shapeFiles []*zip.File
 	for _, f := range z.File {
@@ -88,7 +111,6 @@ func (zr *ZipReader) Shape() (int, Shape) {
 	return zr.sr.Shape()
 }
 
-/* Note: not used
 // Attribute returns the n-th field of the last row that was read. If there
 // were any errors before, the empty string is returned.
 func (zr *ZipReader) Attribute(n int) string {
@@ -99,7 +121,19 @@ func (zr *ZipReader) Attribute(n int) string {
 // DBF table.
 func (zr *ZipReader) Fields() []Field {
 	return zr.sr.Fields()
-}*/
+}
+
+// Attributes returns all attributes of the last row that was read by field names.
+// It returns nil if the archive does not contain a DBF file.
+func (zr *ZipReader) Attributes() map[string]any {
+	return AttributeMap(zr.sr)
+}
+
+// Projection returns the WKT of the coordinate system in the .prj file. It returns
+// an empty string if the archive does not contain a .prj file.
+func (zr *ZipReader) Projection() string {
+	return zr.projection
+}
 
 // Err returns the last non-EOF error that was encountered by this ZipReader.
 func (zr *ZipReader) Err() error {
@@ -0,0 +1,62 @@
+package shp
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+
+	wsc "github.com/reearth/reearth/server/pkg/writer"
+)
+
+// WriteZip writes shapes of type t and their attributes as a ZIP archive which contains
+// .shp, .shx, .dbf, .cpg and .prj files named after name. Attributes are encoded in UTF-8
+// and prj is written as the projection of the coordinates. WGS84 is written if prj is empty.
+func WriteZip(w io.Writer, name string, t ShapeType, shapes []Shape, fields []Field, records [][]any, prj string) error {
+	if prj == "" {
+		prj = WGS84Projection
+	}
+
+	var shpBuf, shxBuf wsc.WriterSeeker
+	sw, err := CreateFrom(&shpBuf, t)
+	if err != nil {
+		return err
+	}
+	for _, s := range shapes {
+		if _, err := sw.Write(s); err != nil {
+			return err
+		}
+	}
+	if err := sw.Close(); err != nil {
+		return err
+	}
+	if err := sw.WriteIndex(&shxBuf); err != nil {
+		return err
+	}
+
+	var dbfBuf bytes.Buffer
+	if err := WriteDBF(&dbfBuf, fields, records); err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	files := []struct {
+		ext  string
+		data []byte
+	}{
+		{ext: ".shp", data: shpBuf.Buffer()},
+		{ext: ".shx", data: shxBuf.Buffer()},
+		{ext: ".dbf", data: dbfBuf.Bytes()},
+		{ext: ".cpg", data: []byte(UTF8Codepage)},
+		{ext: ".prj", data: []byte(prj)},
+	}
+	for _, f := range files {
+		fw, err := zw.Create(name + f.ext)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
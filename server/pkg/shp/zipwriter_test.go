@@ -0,0 +1,59 @@
+package shp
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteZip(t *testing.T) {
+	shapes := []Shape{
+		&Point{X: 139.76, Y: 35.68},
+		&Point{X: 135.5, Y: 34.7},
+	}
+	fields := []Field{StringField("name", 10), NumberField("pop", 10)}
+	records := [][]any{{"東京", 14000000}, {"大阪", nil}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteZip(&buf, "cities", POINT, shapes, fields, records, ""))
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	var names []string
+	for _, f := range z.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"cities.shp", "cities.shx", "cities.dbf", "cities.cpg", "cities.prj"}, names)
+
+	shx, err := z.File[1].Open()
+	assert.NoError(t, err)
+	shxBytes, err := io.ReadAll(shx)
+	assert.NoError(t, err)
+	assert.Len(t, shxBytes, 100+8*len(shapes))
+	// the first record starts right after the header and each point record has 10 words of content
+	assert.Equal(t, int32(50), int32(binary.BigEndian.Uint32(shxBytes[100:104])))
+	assert.Equal(t, int32(10), int32(binary.BigEndian.Uint32(shxBytes[104:108])))
+	assert.Equal(t, int32(64), int32(binary.BigEndian.Uint32(shxBytes[108:112])))
+
+	zr, err := ReadZipFrom(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, WGS84Projection, zr.Projection())
+
+	var got []Shape
+	var attrs []map[string]any
+	for zr.Next() {
+		_, s := zr.Shape()
+		got = append(got, s)
+		attrs = append(attrs, zr.Attributes())
+	}
+	assert.NoError(t, zr.Err())
+	assert.Equal(t, shapes, got)
+	assert.Equal(t, []map[string]any{
+		{"name": "東京", "pop": int64(14000000)},
+		{"name": "大阪", "pop": nil},
+	}, attrs)
+}
@@ -13,6 +13,17 @@ func NewPageList(pages []*Page) *PageList {
 	return &PageList{pages: pages}
 }
 
+func (l *PageList) Clone() *PageList {
+	if l == nil {
+		return nil
+	}
+	pages := make([]*Page, 0, len(l.pages))
+	for _, p := range l.pages {
+		pages = append(pages, p.Clone())
+	}
+	return NewPageList(pages)
+}
+
 func (l *PageList) Pages() []*Page {
 	if l == nil || l.pages == nil {
 		return nil
@@ -0,0 +1,14 @@
+package storytelling
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/publishschedule"
+)
+
+// PublishSchedule describes a future publication of a story and an optional expiry.
+type PublishSchedule = publishschedule.Schedule[PublishmentStatus]
+
+func NewPublishSchedule(status PublishmentStatus, publishAt, unpublishAt *time.Time) (*PublishSchedule, error) {
+	return publishschedule.New(status, publishAt, unpublishAt)
+}
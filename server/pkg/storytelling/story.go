@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearth/server/pkg/builtin"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
 )
 
 var (
@@ -26,17 +28,18 @@ type Story struct {
 	panelPosition Position
 	bgColor       string
 	updatedAt     time.Time
+	version       int
 
-	alias             string
-	status            PublishmentStatus
-	publishedAt       *time.Time
-	isBasicAuthActive bool
-	basicAuthUsername string
-	basicAuthPassword string
-	publicTitle       string
-	publicDescription string
-	publicImage       string
-	publicNoIndex     bool
+	alias                string
+	status               PublishmentStatus
+	publishedAt          *time.Time
+	isBasicAuthActive    bool
+	basicAuthCredentials basicauth.List
+	publicTitle          string
+	publicDescription    string
+	publicImage          string
+	publicNoIndex        bool
+	publishSchedule      *PublishSchedule
 }
 
 func (s *Story) Id() StoryID {
@@ -51,10 +54,26 @@ func (s *Story) Scene() SceneID {
 	return s.scene
 }
 
+// Clone returns a deep copy of the story. The publish schedule is shared since it is immutable.
+func (s *Story) Clone() *Story {
+	if s == nil {
+		return nil
+	}
+	s2 := *s
+	s2.pages = s.pages.Clone()
+	s2.publishedAt = util.CloneRef(s.publishedAt)
+	s2.basicAuthCredentials = s.basicAuthCredentials.Clone()
+	return &s2
+}
+
 func (s *Story) Pages() *PageList {
 	return s.pages
 }
 
+func (s *Story) SetPages(pages *PageList) {
+	s.pages = pages
+}
+
 func (s *Story) Title() string {
 	return s.title
 }
@@ -71,6 +90,10 @@ func (s *Story) PublishedAt() *time.Time {
 	return s.publishedAt
 }
 
+func (s *Story) PublishSchedule() *PublishSchedule {
+	return s.publishSchedule
+}
+
 func (s *Story) CreatedAt() time.Time {
 	return s.id.Timestamp()
 }
@@ -83,18 +106,25 @@ func (s *Story) IsBasicAuthActive() bool {
 	return s.isBasicAuthActive
 }
 
+// BasicAuthUsername returns the username of the default basic auth credential.
 func (s *Story) BasicAuthUsername() string {
 	if !s.isBasicAuthActive {
 		return ""
 	}
-	return s.basicAuthUsername
-}
-
-func (s *Story) BasicAuthPassword() string {
-	if !s.isBasicAuthActive {
+	c := s.basicAuthCredentials.Find(basicauth.DefaultName)
+	if c.IsRevoked() {
 		return ""
 	}
-	return s.basicAuthPassword
+	return c.Username()
+}
+
+func (s *Story) BasicAuthCredentials() basicauth.List {
+	return s.basicAuthCredentials.Clone()
+}
+
+// MatchBasicAuth reports whether the username and the password match one of the active basic auth credentials.
+func (s *Story) MatchBasicAuth(username, password string) bool {
+	return s.basicAuthCredentials.Match(username, password) != nil
 }
 
 func (s *Story) PublicTitle() string {
@@ -130,18 +160,54 @@ func (s *Story) SetUpdatedAt(now time.Time) {
 	s.updatedAt = now
 }
 
+func (s *Story) Version() int {
+	if s == nil {
+		return 0
+	}
+	return s.version
+}
+
+func (s *Story) SetVersion(v int) {
+	if s == nil {
+		return
+	}
+	s.version = v
+}
+
+// SetBasicAuth activates or deactivates basic auth and updates the default basic auth credential.
+// Credentials are kept while basic auth is inactive so that they can be used again when it is reactivated.
 func (s *Story) SetBasicAuth(isBasicAuthActive bool, basicAuthUsername, basicAuthPassword *string) error {
-	s.isBasicAuthActive = isBasicAuthActive
 	if !isBasicAuthActive {
-		s.basicAuthUsername = ""
-		s.basicAuthPassword = ""
+		s.isBasicAuthActive = false
 		return nil
 	}
-	if isBasicAuthActive && (basicAuthUsername == nil || basicAuthPassword == nil) {
+	if (lo.FromPtr(basicAuthUsername) == "" || lo.FromPtr(basicAuthPassword) == "") && len(s.basicAuthCredentials.Active()) == 0 {
 		return ErrBasicAuthUserNamePasswordEmpty
 	}
-	s.basicAuthUsername = *basicAuthUsername
-	s.basicAuthPassword = *basicAuthPassword
+	l, err := s.basicAuthCredentials.SetDefault(basicAuthUsername, basicAuthPassword)
+	if err != nil {
+		return err
+	}
+	s.isBasicAuthActive = true
+	s.basicAuthCredentials = l
+	return nil
+}
+
+func (s *Story) AddBasicAuthCredential(c *basicauth.Credential) error {
+	l, err := s.basicAuthCredentials.Add(c)
+	if err != nil {
+		return err
+	}
+	s.basicAuthCredentials = l
+	return nil
+}
+
+func (s *Story) RevokeBasicAuthCredential(name string) error {
+	l, err := s.basicAuthCredentials.Revoke(name)
+	if err != nil {
+		return err
+	}
+	s.basicAuthCredentials = l
 	return nil
 }
 
@@ -213,6 +279,10 @@ func (s *Story) SetPublishedAt(now time.Time) {
 	s.publishedAt = &now
 }
 
+func (s *Story) SetPublishSchedule(publishSchedule *PublishSchedule) {
+	s.publishSchedule = publishSchedule
+}
+
 func (s *Story) UpdateAlias(alias string) error {
 	if CheckAliasPattern(alias) {
 		s.alias = alias
@@ -222,6 +292,11 @@ func (s *Story) UpdateAlias(alias string) error {
 	return nil
 }
 
+// ClearAlias removes the alias, so that the story has to be given a new one before it is published.
+func (s *Story) ClearAlias() {
+	s.alias = ""
+}
+
 func (s *Story) MatchWithPublicName(name string) bool {
 	if s == nil || name == "" || s.status == PublishmentStatusPrivate {
 		return false
@@ -2,6 +2,8 @@ package storytelling
 
 import (
 	"time"
+
+	"github.com/reearth/reearth/server/pkg/basicauth"
 )
 
 type StoryBuilder struct {
@@ -93,10 +95,14 @@ func (b *StoryBuilder) UpdatedAt(at time.Time) *StoryBuilder {
 	return b
 }
 
-func (b *StoryBuilder) PublicBasicAuth(active bool, username, password string) *StoryBuilder {
+func (b *StoryBuilder) Version(v int) *StoryBuilder {
+	b.s.version = v
+	return b
+}
+
+func (b *StoryBuilder) PublicBasicAuth(active bool, credentials basicauth.List) *StoryBuilder {
 	b.s.isBasicAuthActive = active
-	b.s.basicAuthUsername = username
-	b.s.basicAuthPassword = password
+	b.s.basicAuthCredentials = credentials.Clone()
 	return b
 }
 
@@ -119,3 +125,8 @@ func (b *StoryBuilder) PublicNoIndex(noIndex bool) *StoryBuilder {
 	b.s.publicNoIndex = noIndex
 	return b
 }
+
+func (b *StoryBuilder) PublishSchedule(publishSchedule *PublishSchedule) *StoryBuilder {
+	b.s.publishSchedule = publishSchedule
+	return b
+}
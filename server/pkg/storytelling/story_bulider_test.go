@@ -3,7 +3,9 @@ package storytelling
 import (
 	"testing"
 
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,6 +27,7 @@ func TestStoryBuilder(t *testing.T) {
 	storyID := NewStoryID()
 	propertyID := NewPropertyID()
 	sceneID := NewSceneID()
+	credential := lo.Must(basicauth.NewCredential(basicauth.DefaultName, "user", "pass"))
 
 	b = b.ID(storyID).
 		Scene(sceneID).
@@ -38,28 +41,27 @@ func TestStoryBuilder(t *testing.T) {
 		PublicDescription("public description").
 		PublicImage("/test.jpg").
 		PublicNoIndex(true).
-		PublicBasicAuth(true, "user", "pass")
+		PublicBasicAuth(true, basicauth.List{credential})
 
 	s, err = b.Build()
 	assert.NoError(t, err)
 	assert.Equal(t, &Story{
-		id:                storyID,
-		property:          propertyID,
-		scene:             sceneID,
-		title:             "title",
-		alias:             "alias",
-		pages:             nil,
-		status:            PublishmentStatusPrivate,
-		panelPosition:     PositionLeft,
-		publishedAt:       nil,
-		updatedAt:         storyID.Timestamp(),
-		publicTitle:       "public title",
-		publicDescription: "public description",
-		publicImage:       "/test.jpg",
-		publicNoIndex:     true,
-		isBasicAuthActive: true,
-		basicAuthUsername: "user",
-		basicAuthPassword: "pass",
+		id:                   storyID,
+		property:             propertyID,
+		scene:                sceneID,
+		title:                "title",
+		alias:                "alias",
+		pages:                nil,
+		status:               PublishmentStatusPrivate,
+		panelPosition:        PositionLeft,
+		publishedAt:          nil,
+		updatedAt:            storyID.Timestamp(),
+		publicTitle:          "public title",
+		publicDescription:    "public description",
+		publicImage:          "/test.jpg",
+		publicNoIndex:        true,
+		isBasicAuthActive:    true,
+		basicAuthCredentials: basicauth.List{credential},
 	}, s)
 
 	now := util.Now()
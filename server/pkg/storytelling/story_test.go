@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/reearth/reearth/server/pkg/basicauth"
 	"github.com/reearth/reearthx/util"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -28,8 +29,6 @@ func TestStory_SettersGetters(t *testing.T) {
 		updatedAt:         now,
 		panelPosition:     PositionRight,
 		isBasicAuthActive: false,
-		basicAuthUsername: "user",
-		basicAuthPassword: "pass",
 		publicTitle:       "public title",
 		publicDescription: "public description",
 		publicImage:       "/test.jpg",
@@ -49,7 +48,6 @@ func TestStory_SettersGetters(t *testing.T) {
 	assert.Equal(t, PositionRight, s.PanelPosition())
 	assert.Equal(t, false, s.IsBasicAuthActive())
 	assert.Equal(t, "", s.BasicAuthUsername())
-	assert.Equal(t, "", s.BasicAuthPassword())
 	assert.Equal(t, "public title", s.PublicTitle())
 	assert.Equal(t, "public description", s.PublicDescription())
 	assert.Equal(t, "/test.jpg", s.PublicImage())
@@ -76,13 +74,31 @@ func TestStory_SettersGetters(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, false, s.IsBasicAuthActive())
 	assert.Equal(t, "", s.BasicAuthUsername())
-	assert.Equal(t, "", s.BasicAuthPassword())
+	assert.Nil(t, s.BasicAuthCredentials())
 
 	err = s.SetBasicAuth(true, lo.ToPtr("user"), lo.ToPtr("pass"))
 	assert.NoError(t, err)
 	assert.Equal(t, true, s.IsBasicAuthActive())
 	assert.Equal(t, "user", s.BasicAuthUsername())
-	assert.Equal(t, "pass", s.BasicAuthPassword())
+	assert.True(t, s.MatchBasicAuth("user", "pass"))
+
+	err = s.SetBasicAuth(false, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", s.BasicAuthUsername())
+
+	// credentials are kept while basic auth is inactive
+	err = s.SetBasicAuth(true, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "user", s.BasicAuthUsername())
+
+	err = s.AddBasicAuthCredential(lo.Must(basicauth.NewCredential("client", "user2", "pass2")))
+	assert.NoError(t, err)
+	assert.True(t, s.MatchBasicAuth("user2", "pass2"))
+
+	err = s.RevokeBasicAuthCredential("client")
+	assert.NoError(t, err)
+	assert.False(t, s.MatchBasicAuth("user2", "pass2"))
+	assert.True(t, s.MatchBasicAuth("user", "pass"))
 
 	s.SetPublicTitle("public title 2")
 	assert.Equal(t, "public title 2", s.PublicTitle())
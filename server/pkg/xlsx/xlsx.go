@@ -0,0 +1,362 @@
+// Package xlsx reads and writes values of cells in worksheets of Office Open XML workbooks (.xlsx).
+// Styles other than date formats, formulas and merged cells are not supported, and cached values of formulas are read instead.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidFile    = errors.New("invalid xlsx file")
+	ErrSheetNotFound  = errors.New("sheet not found")
+	ErrInvalidCellRef = errors.New("invalid cell reference")
+	ErrTooManyCells   = errors.New("too many cells")
+)
+
+const (
+	// MaxRows and MaxColumns are the limits of a worksheet of Excel.
+	MaxRows    = 1048576
+	MaxColumns = 16384
+	// MaxCells is the limit of rows multiplied by columns read from a sheet, as rows are padded to the same length.
+	MaxCells = 10_000_000
+)
+
+type File struct {
+	z             *zip.Reader
+	sheets        []sheet
+	sharedStrings []string
+	dateStyles    []bool
+	date1904      bool
+}
+
+type sheet struct {
+	name string
+	path string
+}
+
+// Open reads the workbook, shared strings and styles of the file.
+func Open(r io.ReaderAt, size int64) (*File, error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, ErrInvalidFile
+	}
+	f := &File{z: z}
+
+	var wb struct {
+		WorkbookPr struct {
+			Date1904 string `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := f.decode("xl/workbook.xml", &wb); err != nil {
+		return nil, ErrInvalidFile
+	}
+	f.date1904 = wb.WorkbookPr.Date1904 == "1" || wb.WorkbookPr.Date1904 == "true"
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := f.decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, ErrInvalidFile
+	}
+	targets := map[string]string{}
+	for _, r := range rels.Relationships {
+		if strings.HasPrefix(r.Target, "/") {
+			targets[r.ID] = strings.TrimPrefix(r.Target, "/")
+		} else {
+			targets[r.ID] = path.Join("xl", r.Target)
+		}
+	}
+	for _, s := range wb.Sheets {
+		if t, ok := targets[s.RID]; ok {
+			f.sheets = append(f.sheets, sheet{name: s.Name, path: t})
+		}
+	}
+
+	if err := f.readSharedStrings(); err != nil {
+		return nil, err
+	}
+	if err := f.readStyles(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// SheetNames returns the names of the worksheets in the order of the workbook.
+func (f *File) SheetNames() []string {
+	res := make([]string, 0, len(f.sheets))
+	for _, s := range f.sheets {
+		res = append(res, s.name)
+	}
+	return res
+}
+
+// Rows returns values of the cells of the sheet. The first sheet is read if the name is empty.
+// Missing cells are empty strings, and rows are padded to the same length.
+// ErrTooManyCells is returned if the padded rows have more than MaxCells cells.
+func (f *File) Rows(name string) ([][]string, error) {
+	var s *sheet
+	for i := range f.sheets {
+		if name == "" || f.sheets[i].name == name {
+			s = &f.sheets[i]
+			break
+		}
+	}
+	if s == nil {
+		return nil, ErrSheetNotFound
+	}
+
+	var ws struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R         string    `xml:"r,attr"`
+				T         string    `xml:"t,attr"`
+				S         int       `xml:"s,attr"`
+				V         *string   `xml:"v"`
+				InlineStr *richText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := f.decode(s.path, &ws); err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	var rows [][]string
+	width := 0
+	for _, r := range ws.Rows {
+		row := r.R - 1
+		if r.R == 0 {
+			row = len(rows)
+		}
+		if row < 0 || row >= MaxRows {
+			return nil, ErrInvalidCellRef
+		}
+		for len(rows) <= row {
+			rows = append(rows, nil)
+		}
+		for _, c := range r.Cells {
+			col := len(rows[row])
+			if c.R != "" {
+				cc, _, err := ParseCellRef(c.R)
+				if err != nil {
+					return nil, err
+				}
+				col = cc
+			}
+			if col >= MaxColumns {
+				return nil, ErrInvalidCellRef
+			}
+			for len(rows[row]) <= col {
+				rows[row] = append(rows[row], "")
+			}
+			rows[row][col] = f.cellValue(c.T, c.S, c.V, c.InlineStr)
+		}
+		width = max(width, len(rows[row]))
+		if len(rows)*width > MaxCells {
+			return nil, ErrTooManyCells
+		}
+	}
+
+	for i := range rows {
+		for len(rows[i]) < width {
+			rows[i] = append(rows[i], "")
+		}
+	}
+	return rows, nil
+}
+
+func (f *File) cellValue(t string, style int, v *string, is *richText) string {
+	switch t {
+	case "inlineStr":
+		return is.String()
+	case "s":
+		if v == nil {
+			return ""
+		}
+		i, err := strconv.Atoi(*v)
+		if err != nil || i < 0 || i >= len(f.sharedStrings) {
+			return ""
+		}
+		return f.sharedStrings[i]
+	case "b":
+		if v != nil && *v == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	case "e":
+		return ""
+	}
+	if v == nil {
+		return ""
+	}
+	if (t == "" || t == "n") && style >= 0 && style < len(f.dateStyles) && f.dateStyles[style] {
+		if d, err := strconv.ParseFloat(*v, 64); err == nil {
+			return f.formatDate(d)
+		}
+	}
+	return *v
+}
+
+// formatDate formats a serial date number as an ISO 8601 date or date time.
+func (f *File) formatDate(d float64) string {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if f.date1904 {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	days, frac := math.Modf(d)
+	t := epoch.AddDate(0, 0, int(days)).Add(time.Duration(math.Round(frac*86400)) * time.Second)
+	if frac == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02T15:04:05")
+}
+
+func (f *File) readSharedStrings() error {
+	var sst struct {
+		Items []richText `xml:"si"`
+	}
+	if err := f.decode("xl/sharedStrings.xml", &sst); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		return err
+	}
+	f.sharedStrings = make([]string, 0, len(sst.Items))
+	for i := range sst.Items {
+		f.sharedStrings = append(f.sharedStrings, sst.Items[i].String())
+	}
+	return nil
+}
+
+func (f *File) readStyles() error {
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := f.decode("xl/styles.xml", &styles); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	dateFormats := map[int]bool{}
+	for _, n := range styles.NumFmts {
+		dateFormats[n.ID] = isDateFormat(n.Code)
+	}
+	f.dateStyles = make([]bool, 0, len(styles.CellXfs))
+	for _, x := range styles.CellXfs {
+		isDate, ok := dateFormats[x.NumFmtID]
+		if !ok {
+			// built-in date and time formats
+			isDate = x.NumFmtID >= 14 && x.NumFmtID <= 22 || x.NumFmtID >= 45 && x.NumFmtID <= 47
+		}
+		f.dateStyles = append(f.dateStyles, isDate)
+	}
+	return nil
+}
+
+// isDateFormat returns true if the custom number format has tokens of dates or times outside quotes and brackets.
+func isDateFormat(code string) bool {
+	quoted, bracket := false, false
+	for _, c := range strings.ToLower(code) {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			bracket = true
+		case c == ']':
+			bracket = false
+		case bracket:
+		case c == 'y' || c == 'd' || c == 'h' || c == 's':
+			return true
+		}
+	}
+	return false
+}
+
+// errNotFound is returned by decode if the file does not exist in the archive.
+var errNotFound = errors.New("not found")
+
+func (f *File) decode(name string, v any) error {
+	for _, zf := range f.z.File {
+		if zf.Name != name {
+			continue
+		}
+		r, err := zf.Open()
+		if err != nil {
+			return ErrInvalidFile
+		}
+		defer func() {
+			_ = r.Close()
+		}()
+		if err := xml.NewDecoder(r).Decode(v); err != nil {
+			return ErrInvalidFile
+		}
+		return nil
+	}
+	return errNotFound
+}
+
+// richText is a plain text or runs of rich text.
+type richText struct {
+	T    *string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (r *richText) String() string {
+	if r == nil {
+		return ""
+	}
+	if r.T != nil {
+		return *r.T
+	}
+	var b strings.Builder
+	for _, run := range r.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+// ParseCellRef parses a cell reference such as "B3" into 0-based column and row indexes.
+func ParseCellRef(ref string) (col, row int, err error) {
+	i := 0
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' {
+		col = col*26 + int(ref[i]-'A'+1)
+		if col > MaxColumns {
+			return 0, 0, ErrInvalidCellRef
+		}
+		i++
+	}
+	if i == 0 || i == len(ref) {
+		return 0, 0, ErrInvalidCellRef
+	}
+	row, err = strconv.Atoi(ref[i:])
+	if err != nil || row < 1 || row > MaxRows {
+		return 0, 0, ErrInvalidCellRef
+	}
+	return col - 1, row - 1, nil
+}
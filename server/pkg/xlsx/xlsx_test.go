@@ -0,0 +1,189 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFile(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+	b := &bytes.Buffer{}
+	z := zip.NewWriter(b)
+	for name, content := range files {
+		w, err := z.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, z.Close())
+	return bytes.NewReader(b.Bytes())
+}
+
+var testWorkbook = map[string]string{
+	"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="cities" sheetId="1" r:id="rId1"/><sheet name="other" sheetId="2" r:id="rId2"/></sheets>
+</workbook>`,
+	"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`,
+	"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>name</t></si><si><t>lat</t></si><si><t>lng</t></si><si><r><t>To</t></r><r><t>kyo</t></r></si><si><t>date</t></si><si><t>open</t></si>
+</sst>`,
+	"xl/styles.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts><numFmt numFmtId="164" formatCode="yyyy/mm/dd"/><numFmt numFmtId="165" formatCode="&quot;day&quot;0.00"/></numFmts>
+<cellXfs><xf numFmtId="0"/><xf numFmtId="164"/><xf numFmtId="165"/><xf numFmtId="22"/></cellXfs>
+</styleSheet>`,
+	"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c><c r="D1" t="s"><v>4</v></c><c r="E1" t="s"><v>5</v></c></row>
+<row r="2"><c r="A2" t="s"><v>3</v></c><c r="B2"><v>35.68</v></c><c r="C2"><v>139.76</v></c><c r="D2" s="1"><v>45292</v></c><c r="E2" t="b"><v>1</v></c></row>
+<row r="4"><c r="A4" t="inlineStr"><is><t>Osaka</t></is></c><c r="C4" s="2"><v>135.5</v></c><c r="D4" s="3"><v>45292.5</v></c><c r="E4" t="e"><v>#N/A</v></c></row>
+</sheetData></worksheet>`,
+	"xl/worksheets/sheet2.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row><c><v>1</v></c><c t="str"><v>x</v></c></row>
+</sheetData></worksheet>`,
+}
+
+func TestFile_Rows(t *testing.T) {
+	r := testFile(t, testWorkbook)
+	f, err := Open(r, r.Size())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cities", "other"}, f.SheetNames())
+
+	rows, err := f.Rows("")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"name", "lat", "lng", "date", "open"},
+		{"Tokyo", "35.68", "139.76", "2024-01-01", "TRUE"},
+		{"", "", "", "", ""},
+		{"Osaka", "", "135.5", "2024-01-01T12:00:00", ""},
+	}, rows)
+
+	rows, err = f.Rows("other")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "x"}}, rows)
+
+	_, err = f.Rows("none")
+	assert.Same(t, ErrSheetNotFound, err)
+}
+
+func TestFile_Rows_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		sheet string
+		err   error
+	}{
+		{name: "negative row", sheet: `<row r="-5"><c><v>1</v></c></row>`, err: ErrInvalidCellRef},
+		{name: "too large row", sheet: `<row r="1048577"><c><v>1</v></c></row>`, err: ErrInvalidCellRef},
+		{name: "too large column", sheet: `<row r="1"><c r="XFE1"><v>1</v></c></row>`, err: ErrInvalidCellRef},
+		{name: "sparse cells", sheet: `<row r="1"><c r="XFD1"><v>1</v></c></row><row r="300000"><c r="A300000"><v>1</v></c></row>`, err: ErrTooManyCells},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			files := map[string]string{}
+			for k, v := range testWorkbook {
+				files[k] = v
+			}
+			files["xl/worksheets/sheet2.xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + tt.sheet + `</sheetData></worksheet>`
+			r := testFile(t, files)
+			f, err := Open(r, r.Size())
+			assert.NoError(t, err)
+			_, err = f.Rows("other")
+			assert.Same(t, tt.err, err)
+		})
+	}
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	r := bytes.NewReader([]byte("hoge"))
+	_, err := Open(r, r.Size())
+	assert.Same(t, ErrInvalidFile, err)
+
+	r = testFile(t, map[string]string{"xl/sharedStrings.xml": "<sst/>"})
+	_, err = Open(r, r.Size())
+	assert.Same(t, ErrInvalidFile, err)
+}
+
+func TestParseCellRef(t *testing.T) {
+	tests := []struct {
+		ref      string
+		col, row int
+		err      error
+	}{
+		{ref: "A1", col: 0, row: 0},
+		{ref: "Z10", col: 25, row: 9},
+		{ref: "AA3", col: 26, row: 2},
+		{ref: "AB12", col: 27, row: 11},
+		{ref: "1", err: ErrInvalidCellRef},
+		{ref: "A", err: ErrInvalidCellRef},
+		{ref: "A0", err: ErrInvalidCellRef},
+		{ref: "XFD1048576", col: 16383, row: 1048575},
+		{ref: "XFE1", err: ErrInvalidCellRef},
+		{ref: "A1048577", err: ErrInvalidCellRef},
+		{ref: "AAAAAAAAAAAAAAAAAAAA1", err: ErrInvalidCellRef},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.ref, func(t *testing.T) {
+			t.Parallel()
+			col, row, err := ParseCellRef(tt.ref)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.col, col)
+			assert.Equal(t, tt.row, row)
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	b := &bytes.Buffer{}
+	w, err := NewWriter(b, "a/b")
+	assert.NoError(t, err)
+	assert.NoError(t, w.WriteRow([]any{"name", "pop", "capital", "note"}))
+	assert.NoError(t, w.WriteRow([]any{"Tokyo <東京>", 14.1, true, nil}))
+	assert.NoError(t, w.WriteRow([]any{"Osaka", int64(8), false, "a\x01b"}))
+	assert.NoError(t, w.Close())
+
+	f, err := Open(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_b"}, f.SheetNames())
+	rows, err := f.Rows("")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"name", "pop", "capital", "note"},
+		{"Tokyo <東京>", "14.1", "TRUE", ""},
+		{"Osaka", "8", "FALSE", "ab"},
+	}, rows)
+}
+
+func TestCellRef(t *testing.T) {
+	assert.Equal(t, "A1", CellRef(0, 0))
+	assert.Equal(t, "Z2", CellRef(25, 1))
+	assert.Equal(t, "AA3", CellRef(26, 2))
+	assert.Equal(t, "AZ1", CellRef(51, 0))
+	assert.Equal(t, "BA1", CellRef(52, 0))
+	for i := 0; i < 1000; i++ {
+		col, row, err := ParseCellRef(CellRef(i, i))
+		assert.NoError(t, err)
+		assert.Equal(t, []int{i, i}, []int{col, row})
+	}
+}
+
+func TestSheetName(t *testing.T) {
+	assert.Equal(t, "Sheet1", SheetName(""))
+	assert.Equal(t, "a_b_c", SheetName("a:b?c"))
+	assert.Equal(t, "0123456789012345678901234567890", SheetName("01234567890123456789012345678901234"))
+}